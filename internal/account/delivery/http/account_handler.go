@@ -0,0 +1,140 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// AccountHandler ...
+type AccountHandler struct {
+	AccountUseCase domain.AccountUseCase
+}
+
+// NewAccountHandler ...
+func NewAccountHandler(e *echo.Echo, us domain.AccountUseCase) {
+	handler := &AccountHandler{
+		AccountUseCase: us,
+	}
+	e.POST("/auth/password/forgot", handler.ForgotPassword)
+	e.POST("/auth/password/reset", handler.ResetPassword)
+	e.POST("/auth/email/verify", handler.VerifyEmail)
+	e.POST("/auth/email/verification", handler.ResendVerification)
+}
+
+// ForgotPassword godoc
+// @Summary Ask for a password reset link.
+// @Description Email a password reset link to the account with the email. The response is the same whether or not an account exists, and links are rate limited.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body domain.PasswordForgot true "Email"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/password/forgot [post]
+func (c *AccountHandler) ForgotPassword(echoContext echo.Context) error {
+	var request domain.PasswordForgot
+	err := echoContext.Bind(&request)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&request); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.AccountUseCase.ForgotPassword(ctx, request.Email); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// ResetPassword godoc
+// @Summary Reset the password.
+// @Description Set a new password with the token of a reset link. The account is unlocked and every session signed out.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param reset body domain.PasswordReset true "Token and new password"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Unknown, used or expired token"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/password/reset [post]
+func (c *AccountHandler) ResetPassword(echoContext echo.Context) error {
+	var reset domain.PasswordReset
+	err := echoContext.Bind(&reset)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&reset); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.AccountUseCase.ResetPassword(ctx, &reset); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// VerifyEmail godoc
+// @Summary Verify the email.
+// @Description Verify the email of an account with the token of a verification link.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param verification body domain.EmailVerification true "Token"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Unknown, used or expired token, or the email changed since"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/email/verify [post]
+func (c *AccountHandler) VerifyEmail(echoContext echo.Context) error {
+	var verification domain.EmailVerification
+	err := echoContext.Bind(&verification)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&verification); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.AccountUseCase.VerifyEmail(ctx, verification.Token); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// ResendVerification godoc
+// @Summary Resend the verification link.
+// @Description Email a new verification link to the authenticated user.
+// @Tags auth
+// @Accept */*
+// @Produce json
+// @Success 204
+// @Failure 401 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "The email is verified already"
+// @Failure 429 {object} domain.APIResponseError "Too many links sent lately"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/email/verification [post]
+func (c *AccountHandler) ResendVerification(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return echoContext.JSON(http.StatusUnauthorized, ResponseError{Message: domain.ErrUnauthorized.Error()})
+	}
+	if err := c.AccountUseCase.ResendVerification(ctx, userID); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,147 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	accountHTTP "github.com/meroedu/meroedu/internal/account/delivery/http"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+)
+
+func TestForgotPassword(t *testing.T) {
+	mockUCase := new(mocks.AccountUseCase)
+	mockUCase.On("ForgotPassword", mock.Anything, "ram@meroedu.com").Return(nil).Once()
+	mockUCase.On("ForgotPassword", mock.Anything, "sita@meroedu.com").Return(domain.ErrTooManyRequests).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"email":"ram@meroedu.com"}`, http.StatusNoContent},
+		{`{"email":"sita@meroedu.com"}`, http.StatusTooManyRequests},
+		{`{"email":"ram"}`, http.StatusBadRequest},
+		{`{"email":`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/password/forgot", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := accountHTTP.AccountHandler{
+			AccountUseCase: mockUCase,
+		}
+		err = handler.ForgotPassword(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestResetPassword(t *testing.T) {
+	mockUCase := new(mocks.AccountUseCase)
+	mockUCase.On("ResetPassword", mock.Anything, &domain.PasswordReset{Token: "valid", Password: "new-password"}).Return(nil).Once()
+	mockUCase.On("ResetPassword", mock.Anything, &domain.PasswordReset{Token: "used", Password: "new-password"}).Return(domain.ErrBadParamInput).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"token":"valid","password":"new-password"}`, http.StatusNoContent},
+		{`{"token":"used","password":"new-password"}`, http.StatusBadRequest},
+		{`{"token":"valid","password":"short"}`, http.StatusBadRequest},
+		{`{"password":"new-password"}`, http.StatusBadRequest},
+		{`{"token":1}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/password/reset", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := accountHTTP.AccountHandler{
+			AccountUseCase: mockUCase,
+		}
+		err = handler.ResetPassword(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestVerifyEmail(t *testing.T) {
+	mockUCase := new(mocks.AccountUseCase)
+	mockUCase.On("VerifyEmail", mock.Anything, "valid").Return(nil).Once()
+	mockUCase.On("VerifyEmail", mock.Anything, "expired").Return(domain.ErrBadParamInput).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"token":"valid"}`, http.StatusNoContent},
+		{`{"token":"expired"}`, http.StatusBadRequest},
+		{`{}`, http.StatusBadRequest},
+		{`{"token":`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/email/verify", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := accountHTTP.AccountHandler{
+			AccountUseCase: mockUCase,
+		}
+		err = handler.VerifyEmail(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestResendVerification(t *testing.T) {
+	mockUCase := new(mocks.AccountUseCase)
+	mockUCase.On("ResendVerification", mock.Anything, int64(3)).Return(nil).Once()
+	mockUCase.On("ResendVerification", mock.Anything, int64(4)).Return(domain.ErrConflict).Once()
+
+	tests := []struct {
+		userID int64
+		code   int
+	}{
+		{3, http.StatusNoContent},
+		{4, http.StatusConflict},
+		{0, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/email/verification", strings.NewReader(""))
+		assert.NoError(t, err)
+		if tt.userID != 0 {
+			req = req.WithContext(domain.WithUserID(req.Context(), tt.userID))
+		}
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := accountHTTP.AccountHandler{
+			AccountUseCase: mockUCase,
+		}
+		err = handler.ResendVerification(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.userID)
+	}
+	mockUCase.AssertExpectations(t)
+}
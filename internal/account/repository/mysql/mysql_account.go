@@ -0,0 +1,105 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the account's Repository interface
+func Init(db *sql.DB) domain.AccountRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func (m *mysqlRepository) CreateToken(ctx context.Context, t *domain.UserToken) (err error) {
+	query := `INSERT user_tokens SET user_id=?,organization_id=?,purpose=?,email=?,token_hash=?,expires_at=?,created_at=?`
+	res, err := m.conn.ExecContext(ctx, query, t.UserID, t.OrganizationID, t.Purpose, t.Email, t.TokenHash, t.ExpiresAt, t.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	t.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+	}
+	return
+}
+
+// GetToken looks the token up across every organization: the token is all the caller has.
+func (m *mysqlRepository) GetToken(ctx context.Context, tokenHash string) (*domain.UserToken, error) {
+	query := `SELECT id,user_id,organization_id,purpose,email,token_hash,expires_at,used_at,created_at FROM user_tokens WHERE token_hash = ?`
+	t := domain.UserToken{}
+	usedAt := sql.NullInt64{}
+	err := m.conn.QueryRowContext(ctx, query, tokenHash).Scan(&t.ID, &t.UserID, &t.OrganizationID, &t.Purpose, &t.Email, &t.TokenHash,
+		&t.ExpiresAt, &usedAt, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	t.UsedAt = usedAt.Int64
+	return &t, nil
+}
+
+// UseToken marks the token used, along with the other unused tokens of the user for the same purpose.
+// It returns ErrNotFound when the token was used meanwhile, so a token can only be used once.
+func (m *mysqlRepository) UseToken(ctx context.Context, t *domain.UserToken, usedAt int64) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `UPDATE user_tokens SET used_at=? WHERE id = ? AND organization_id = ? AND used_at IS NULL`
+	res, err := tx.ExecContext(ctx, query, usedAt, t.ID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		err = domain.ErrNotFound
+		return
+	}
+	query = `UPDATE user_tokens SET used_at=? WHERE user_id = ? AND purpose = ? AND organization_id = ? AND used_at IS NULL`
+	if _, err = tx.ExecContext(ctx, query, usedAt, t.UserID, t.Purpose, domain.OrganizationIDFromContext(ctx)); err != nil {
+		log.Error(err)
+		return
+	}
+	t.UsedAt = usedAt
+	return
+}
+
+// CountTokens returns how many tokens for the purpose were created for the user since the given time
+func (m *mysqlRepository) CountTokens(ctx context.Context, userID int64, purpose string, since int64) (int, error) {
+	query := `SELECT COUNT(*) FROM user_tokens WHERE user_id = ? AND purpose = ? AND organization_id = ? AND created_at >= ?`
+	count := 0
+	err := m.conn.QueryRowContext(ctx, query, userID, purpose, domain.OrganizationIDFromContext(ctx), since).Scan(&count)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+	return count, nil
+}
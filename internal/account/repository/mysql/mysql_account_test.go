@@ -0,0 +1,87 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mysqlrepo "github.com/meroedu/meroedu/internal/account/repository/mysql"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+func TestGetToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now().Unix()
+	columns := []string{"id", "user_id", "organization_id", "purpose", "email", "token_hash", "expires_at", "used_at", "created_at"}
+	rows := sqlmock.NewRows(columns).AddRow(1, 5, 2, domain.TokenPasswordReset, "sita@school.local", "abc", now+3600, nil, now)
+	query := `SELECT .+ FROM user_tokens WHERE token_hash = \?`
+	mock.ExpectQuery(query).WithArgs("abc").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("xyz").WillReturnRows(sqlmock.NewRows(columns))
+
+	repo := mysqlrepo.Init(db)
+	token, err := repo.GetToken(context.TODO(), "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), token.OrganizationID)
+	assert.Zero(t, token.UsedAt)
+
+	_, err = repo.GetToken(context.TODO(), "xyz")
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestUseToken(t *testing.T) {
+	use := `UPDATE user_tokens SET used_at=\? WHERE id = \? AND organization_id = \? AND used_at IS NULL`
+	others := `UPDATE user_tokens SET used_at=\? WHERE user_id = \? AND purpose = \? AND organization_id = \? AND used_at IS NULL`
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(use).WithArgs(100, 1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(others).WithArgs(100, 5, domain.TokenPasswordReset, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		token := &domain.UserToken{ID: 1, UserID: 5, Purpose: domain.TokenPasswordReset}
+		err = repo.UseToken(orgCtx, token, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), token.UsedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("used-meanwhile", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(use).WithArgs(100, 1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := mysqlrepo.Init(db)
+		err = repo.UseToken(orgCtx, &domain.UserToken{ID: 1, UserID: 5, Purpose: domain.TokenPasswordReset}, 100)
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCountTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `SELECT COUNT\(\*\) FROM user_tokens WHERE user_id = \? AND purpose = \? AND organization_id = \? AND created_at >= \?`
+	mock.ExpectQuery(query).WithArgs(5, domain.TokenEmailVerification, 2, 100).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := mysqlrepo.Init(db)
+	count, err := repo.CountTokens(orgCtx, 5, domain.TokenEmailVerification, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+	"github.com/meroedu/meroedu/pkg/password"
+)
+
+// rateWindow is the window in which at most tokenLimit tokens of a purpose are sent to a user
+const rateWindow = time.Hour
+
+// AccountUseCase ...
+type AccountUseCase struct {
+	accountRepo     domain.AccountRepository
+	userRepo        domain.UserRepository
+	authUseCase     domain.AuthUseCase
+	mailer          domain.Mailer
+	resetURL        string
+	verifyURL       string
+	resetTTL        time.Duration
+	verificationTTL time.Duration
+	tokenLimit      int
+	contextTimeOut  time.Duration
+}
+
+// NewAccountUseCase will create new an AccountUseCase. The emailed links are resetURL and verifyURL with the
+// token as query parameter; at most tokenLimit links of each kind are sent to a user per hour.
+func NewAccountUseCase(a domain.AccountRepository, u domain.UserRepository, auth domain.AuthUseCase, mailer domain.Mailer, resetURL string,
+	verifyURL string, resetTTL time.Duration, verificationTTL time.Duration, tokenLimit int, timeout time.Duration) domain.AccountUseCase {
+	return &AccountUseCase{
+		accountRepo:     a,
+		userRepo:        u,
+		authUseCase:     auth,
+		mailer:          mailer,
+		resetURL:        resetURL,
+		verifyURL:       verifyURL,
+		resetTTL:        resetTTL,
+		verificationTTL: verificationTTL,
+		tokenLimit:      tokenLimit,
+		contextTimeOut:  timeout,
+	}
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// send creates a token for the purpose and emails the link to the user. It returns ErrTooManyRequests when
+// the user was sent too many links of the purpose lately.
+func (usecase *AccountUseCase) send(ctx context.Context, user *domain.User, purpose string) error {
+	now := time.Now()
+	count, err := usecase.accountRepo.CountTokens(ctx, user.ID, purpose, now.Add(-rateWindow).Unix())
+	if err != nil {
+		return err
+	}
+	if count >= usecase.tokenLimit {
+		return domain.ErrTooManyRequests
+	}
+	raw := make([]byte, 32)
+	if _, err = rand.Read(raw); err != nil {
+		return err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	t := &domain.UserToken{
+		UserID:         user.ID,
+		OrganizationID: user.OrganizationID,
+		Purpose:        purpose,
+		Email:          user.Email,
+		TokenHash:      hashToken(token),
+		CreatedAt:      now.Unix(),
+	}
+
+	mail := &domain.Mail{To: user.Email}
+	switch purpose {
+	case domain.TokenPasswordReset:
+		t.ExpiresAt = now.Add(usecase.resetTTL).Unix()
+		mail.Subject = "Reset your Meroedu password"
+		mail.Body = fmt.Sprintf("Hello,\n\nSomeone asked to reset the password of your Meroedu account. Open the link below to choose "+
+			"a new password:\n\n%s?token=%s\n\nThe link expires in %d minutes. If you did not ask for it, ignore this email; "+
+			"your password is unchanged.\n", usecase.resetURL, url.QueryEscape(token), int(usecase.resetTTL.Minutes()))
+	case domain.TokenEmailVerification:
+		t.ExpiresAt = now.Add(usecase.verificationTTL).Unix()
+		mail.Subject = "Verify your Meroedu email"
+		mail.Body = fmt.Sprintf("Hello,\n\nOpen the link below to verify the email of your Meroedu account:\n\n%s?token=%s\n\n"+
+			"The link expires in %d hours.\n", usecase.verifyURL, url.QueryEscape(token), int(usecase.verificationTTL.Hours()))
+	default:
+		return domain.ErrBadParamInput
+	}
+	if err = usecase.accountRepo.CreateToken(ctx, t); err != nil {
+		return err
+	}
+	return usecase.mailer.Send(ctx, mail)
+}
+
+// ForgotPassword emails a password reset link to the active user with the email. Unknown emails and
+// requests over the rate limit are ignored, so the response does not tell whether an account exists.
+func (usecase *AccountUseCase) ForgotPassword(c context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	user, err := usecase.userRepo.GetByEmail(ctx, email)
+	if err == domain.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.Status != domain.UserActive {
+		return nil
+	}
+	err = usecase.send(domain.WithOrganizationID(ctx, user.OrganizationID), user, domain.TokenPasswordReset)
+	if err == domain.ErrTooManyRequests {
+		log.Infof("Password reset of user %d skipped: too many requests", user.ID)
+		return nil
+	}
+	return err
+}
+
+// useToken returns the unused, unexpired token of the purpose, with the context of its organization.
+// Any other token gives ErrUnauthorized.
+func (usecase *AccountUseCase) useToken(ctx context.Context, token string, purpose string) (context.Context, *domain.UserToken, error) {
+	t, err := usecase.accountRepo.GetToken(ctx, hashToken(token))
+	if err == domain.ErrNotFound {
+		return nil, nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now().Unix()
+	if t.Purpose != purpose || t.UsedAt != 0 || t.ExpiresAt < now {
+		return nil, nil, domain.ErrUnauthorized
+	}
+	ctx = domain.WithOrganizationID(ctx, t.OrganizationID)
+	err = usecase.accountRepo.UseToken(ctx, t, now)
+	if err == domain.ErrNotFound {
+		return nil, nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctx, t, nil
+}
+
+// ResetPassword sets the password of the user of the reset token. The account is unlocked, every session
+// signed out and, as the link reached the inbox, the email verified.
+func (usecase *AccountUseCase) ResetPassword(c context.Context, reset *domain.PasswordReset) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	hash, err := password.Hash(reset.Password)
+	if err != nil {
+		return err
+	}
+	ctx, t, err := usecase.useToken(ctx, reset.Token, domain.TokenPasswordReset)
+	if err != nil {
+		return err
+	}
+	user, err := usecase.userRepo.GetByID(ctx, t.UserID)
+	if err == domain.ErrNotFound {
+		return domain.ErrUnauthorized
+	}
+	if err != nil {
+		return err
+	}
+	if user.Status != domain.UserActive {
+		return domain.ErrUnauthorized
+	}
+	now := time.Now().Unix()
+	if err = usecase.userRepo.UpdatePassword(ctx, user.ID, hash, now); err != nil {
+		return err
+	}
+	if err = usecase.userRepo.ResetLoginFailures(ctx, user.ID); err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt == 0 {
+		if err = usecase.userRepo.MarkEmailVerified(ctx, user.ID, t.Email, now); err != nil && err != domain.ErrNotFound {
+			return err
+		}
+	}
+	return usecase.authUseCase.RevokeAll(ctx, user.ID)
+}
+
+// SendVerification emails a verification link for the current email of the user, after signup or an email change
+func (usecase *AccountUseCase) SendVerification(c context.Context, user *domain.User) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.send(domain.WithOrganizationID(ctx, user.OrganizationID), user, domain.TokenEmailVerification)
+}
+
+// ResendVerification emails a new verification link to the user. A verified email gives ErrConflict.
+func (usecase *AccountUseCase) ResendVerification(c context.Context, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	user, err := usecase.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt != 0 {
+		return domain.ErrConflict
+	}
+	return usecase.send(ctx, user, domain.TokenEmailVerification)
+}
+
+// VerifyEmail verifies the email the token was sent to. A token for an email the user changed since gives ErrUnauthorized.
+func (usecase *AccountUseCase) VerifyEmail(c context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	ctx, t, err := usecase.useToken(ctx, token, domain.TokenEmailVerification)
+	if err != nil {
+		return err
+	}
+	err = usecase.userRepo.MarkEmailVerified(ctx, t.UserID, t.Email, time.Now().Unix())
+	if err == domain.ErrNotFound {
+		return domain.ErrUnauthorized
+	}
+	return err
+}
@@ -0,0 +1,203 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	ucase "github.com/meroedu/meroedu/internal/account/usecase"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	_smtp "github.com/meroedu/meroedu/internal/mail/smtp"
+	"github.com/meroedu/meroedu/internal/mail/smtp/smtptest"
+	"github.com/meroedu/meroedu/pkg/password"
+)
+
+const (
+	resetURL  = "https://school.local/password/reset"
+	verifyURL = "https://school.local/email/verify"
+)
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestForgotPassword(t *testing.T) {
+	user := &domain.User{ID: 5, OrganizationID: 2, Email: "sita@school.local", Status: domain.UserActive}
+
+	t.Run("success", func(t *testing.T) {
+		server := smtptest.NewServer()
+		defer server.Close()
+		mockAccountRepo := new(mocks.AccountRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mailer := _smtp.Init(server.Host, server.Port, "", "", "no-reply@school.local")
+		u := ucase.NewAccountUseCase(mockAccountRepo, mockUserRepo, mockAuthUseCase, mailer, resetURL, verifyURL, time.Hour, 48*time.Hour, 3,
+			time.Second*2)
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockAccountRepo.On("CountTokens", mock.Anything, user.ID, domain.TokenPasswordReset, mock.AnythingOfType("int64")).Return(0, nil).Once()
+		var created *domain.UserToken
+		mockAccountRepo.On("CreateToken", mock.Anything, mock.AnythingOfType("*domain.UserToken")).Return(nil).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*domain.UserToken) }).Once()
+
+		err := u.ForgotPassword(context.TODO(), user.Email)
+		assert.NoError(t, err)
+		mockAccountRepo.AssertExpectations(t)
+
+		messages := server.Messages()
+		if assert.Len(t, messages, 1) && assert.NotNil(t, created) {
+			assert.Equal(t, []string{user.Email}, messages[0].To)
+			i := strings.Index(messages[0].Data, resetURL+"?")
+			if assert.True(t, i >= 0, messages[0].Data) {
+				link, err := url.Parse(strings.Fields(messages[0].Data[i:])[0])
+				assert.NoError(t, err)
+				assert.Equal(t, created.TokenHash, hash(link.Query().Get("token")))
+			}
+			assert.Equal(t, int64(2), created.OrganizationID)
+			assert.Equal(t, domain.TokenPasswordReset, created.Purpose)
+		}
+	})
+	t.Run("rate-limited", func(t *testing.T) {
+		mailer := new(mocks.Mailer)
+		mockAccountRepo := new(mocks.AccountRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewAccountUseCase(mockAccountRepo, mockUserRepo, mockAuthUseCase, mailer, resetURL, verifyURL, time.Hour,
+			48*time.Hour, 3, time.Second*2)
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockAccountRepo.On("CountTokens", mock.Anything, user.ID, domain.TokenPasswordReset, mock.AnythingOfType("int64")).Return(3, nil).Once()
+
+		err := u.ForgotPassword(context.TODO(), user.Email)
+		assert.NoError(t, err)
+		mockAccountRepo.AssertNotCalled(t, "CreateToken", mock.Anything, mock.Anything)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+	t.Run("unknown-email", func(t *testing.T) {
+		mailer := new(mocks.Mailer)
+		mockAccountRepo := new(mocks.AccountRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewAccountUseCase(mockAccountRepo, mockUserRepo, mockAuthUseCase, mailer, resetURL, verifyURL, time.Hour,
+			48*time.Hour, 3, time.Second*2)
+		mockUserRepo.On("GetByEmail", mock.Anything, "nobody@school.local").Return(nil, domain.ErrNotFound).Once()
+
+		err := u.ForgotPassword(context.TODO(), "nobody@school.local")
+		assert.NoError(t, err)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	now := time.Now().Unix()
+	token := &domain.UserToken{ID: 1, UserID: 5, OrganizationID: 2, Purpose: domain.TokenPasswordReset, Email: "sita@school.local",
+		TokenHash: hash("abc"), ExpiresAt: now + 3600}
+
+	t.Run("success", func(t *testing.T) {
+		mockAccountRepo := new(mocks.AccountRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewAccountUseCase(mockAccountRepo, mockUserRepo, mockAuthUseCase, new(mocks.Mailer), resetURL, verifyURL,
+			time.Hour, 48*time.Hour, 3, time.Second*2)
+		user := &domain.User{ID: 5, OrganizationID: 2, Email: "sita@school.local", Status: domain.UserActive, FailedLogins: 4}
+		mockAccountRepo.On("GetToken", mock.Anything, hash("abc")).Return(token, nil).Once()
+		mockAccountRepo.On("UseToken", mock.Anything, token, mock.AnythingOfType("int64")).Return(nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, int64(5)).Return(user, nil).Once()
+		var stored string
+		mockUserRepo.On("UpdatePassword", mock.Anything, int64(5), mock.AnythingOfType("string"), mock.AnythingOfType("int64")).Return(nil).
+			Run(func(args mock.Arguments) {
+				stored = args.String(2)
+				assert.Equal(t, int64(2), domain.OrganizationIDFromContext(args.Get(0).(context.Context)))
+			}).Once()
+		mockUserRepo.On("ResetLoginFailures", mock.Anything, int64(5)).Return(nil).Once()
+		mockUserRepo.On("MarkEmailVerified", mock.Anything, int64(5), "sita@school.local", mock.AnythingOfType("int64")).Return(nil).Once()
+		mockAuthUseCase.On("RevokeAll", mock.Anything, int64(5)).Return(nil).Once()
+
+		err := u.ResetPassword(context.TODO(), &domain.PasswordReset{Token: "abc", Password: "n3w-passw0rd"})
+		assert.NoError(t, err)
+		assert.True(t, password.Compare(stored, "n3w-passw0rd"))
+		mockUserRepo.AssertExpectations(t)
+		mockAuthUseCase.AssertExpectations(t)
+	})
+	t.Run("expired", func(t *testing.T) {
+		mockAccountRepo := new(mocks.AccountRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewAccountUseCase(mockAccountRepo, mockUserRepo, mockAuthUseCase, new(mocks.Mailer), resetURL, verifyURL,
+			time.Hour, 48*time.Hour, 3, time.Second*2)
+		expired := *token
+		expired.ExpiresAt = now - 1
+		mockAccountRepo.On("GetToken", mock.Anything, hash("abc")).Return(&expired, nil).Once()
+
+		err := u.ResetPassword(context.TODO(), &domain.PasswordReset{Token: "abc", Password: "n3w-passw0rd"})
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockAccountRepo.AssertNotCalled(t, "UseToken", mock.Anything, mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("verification-token", func(t *testing.T) {
+		mockAccountRepo := new(mocks.AccountRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewAccountUseCase(mockAccountRepo, mockUserRepo, mockAuthUseCase, new(mocks.Mailer), resetURL, verifyURL,
+			time.Hour, 48*time.Hour, 3, time.Second*2)
+		verification := *token
+		verification.Purpose = domain.TokenEmailVerification
+		mockAccountRepo.On("GetToken", mock.Anything, hash("abc")).Return(&verification, nil).Once()
+
+		err := u.ResetPassword(context.TODO(), &domain.PasswordReset{Token: "abc", Password: "n3w-passw0rd"})
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+}
+
+func TestVerifyEmail(t *testing.T) {
+	token := &domain.UserToken{ID: 1, UserID: 5, OrganizationID: 2, Purpose: domain.TokenEmailVerification, Email: "sita@school.local",
+		TokenHash: hash("abc"), ExpiresAt: time.Now().Unix() + 3600}
+
+	t.Run("success", func(t *testing.T) {
+		mockAccountRepo := new(mocks.AccountRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewAccountUseCase(mockAccountRepo, mockUserRepo, mockAuthUseCase, new(mocks.Mailer), resetURL, verifyURL,
+			time.Hour, 48*time.Hour, 3, time.Second*2)
+		mockAccountRepo.On("GetToken", mock.Anything, hash("abc")).Return(token, nil).Once()
+		mockAccountRepo.On("UseToken", mock.Anything, token, mock.AnythingOfType("int64")).Return(nil).Once()
+		mockUserRepo.On("MarkEmailVerified", mock.Anything, int64(5), "sita@school.local", mock.AnythingOfType("int64")).Return(nil).Once()
+
+		err := u.VerifyEmail(context.TODO(), "abc")
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+	t.Run("email-changed", func(t *testing.T) {
+		mockAccountRepo := new(mocks.AccountRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewAccountUseCase(mockAccountRepo, mockUserRepo, mockAuthUseCase, new(mocks.Mailer), resetURL, verifyURL,
+			time.Hour, 48*time.Hour, 3, time.Second*2)
+		mockAccountRepo.On("GetToken", mock.Anything, hash("abc")).Return(token, nil).Once()
+		mockAccountRepo.On("UseToken", mock.Anything, token, mock.AnythingOfType("int64")).Return(nil).Once()
+		mockUserRepo.On("MarkEmailVerified", mock.Anything, int64(5), "sita@school.local", mock.AnythingOfType("int64")).
+			Return(domain.ErrNotFound).Once()
+
+		err := u.VerifyEmail(context.TODO(), "abc")
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+}
+
+func TestResendVerification(t *testing.T) {
+	mockAccountRepo := new(mocks.AccountRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	mockAuthUseCase := new(mocks.AuthUseCase)
+	u := ucase.NewAccountUseCase(mockAccountRepo, mockUserRepo, mockAuthUseCase, new(mocks.Mailer), resetURL, verifyURL,
+		time.Hour, 48*time.Hour, 3, time.Second*2)
+	mockUserRepo.On("GetByID", mock.Anything, int64(5)).Return(&domain.User{ID: 5, EmailVerifiedAt: 100}, nil).Once()
+
+	err := u.ResendVerification(context.TODO(), 5)
+	assert.Equal(t, domain.ErrConflict, err)
+}
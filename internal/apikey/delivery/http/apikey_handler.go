@@ -0,0 +1,165 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// APIKeyHandler ...
+type APIKeyHandler struct {
+	APIKeyUseCase domain.APIKeyUseCase
+}
+
+// NewAPIKeyHandler ...
+func NewAPIKeyHandler(e *echo.Echo, us domain.APIKeyUseCase) {
+	handler := &APIKeyHandler{
+		APIKeyUseCase: us,
+	}
+	e.GET("/api-keys", handler.GetAll, rbac.Require(domain.PermAPIKeyManage))
+	e.GET("/api-keys/:id", handler.GetByID, rbac.Require(domain.PermAPIKeyManage))
+	e.POST("/api-keys", handler.CreateAPIKey, rbac.Require(domain.PermAPIKeyManage))
+	e.DELETE("/api-keys/:id", handler.RevokeAPIKey, rbac.Require(domain.PermAPIKeyManage))
+}
+
+// GetAll godoc
+// @Summary Get All API keys.
+// @Description Get the API keys of the organization, revoked ones included. The keys themselves are never shown again.
+// @Tags api-keys
+// @Accept */*
+// @Produce json
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /api-keys [get]
+func (c *APIKeyHandler) GetAll(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	start, limit := 0, 10
+	var err error
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		}
+	}
+
+	list, err := c.APIKeyUseCase.GetAll(ctx, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary Get API key by ID.
+// @Description Get Specific API key details, with its scopes and last use.
+// @Tags api-keys
+// @Accept */*
+// @Produce json
+// @Param id path int true "API key Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /api-keys/{id} [get]
+func (c *APIKeyHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	key, err := c.APIKeyUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    key,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Create an API key for a service, with permission scopes and an optional expiry. The key is in the response, and only there. Services send it as a bearer token.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param key body domain.APIKey true "API key Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Invalid data, unknown scope or expiry in the past"
+// @Failure 403 {object} domain.APIResponseError "A scope the caller lacks"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /api-keys [post]
+func (c *APIKeyHandler) CreateAPIKey(echoContext echo.Context) error {
+	var key domain.APIKey
+	err := echoContext.Bind(&key)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&key); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	created, err := c.APIKeyUseCase.CreateAPIKey(ctx, &key)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    created,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusCreated, res)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Revoke an API key. Requests made with it are refused from now on.
+// @Tags api-keys
+// @Accept */*
+// @Produce json
+// @Param id path int true "API key Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID, or revoked already"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /api-keys/{id} [delete]
+func (c *APIKeyHandler) RevokeAPIKey(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.APIKeyUseCase.RevokeAPIKey(ctx, int64(idParam)); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
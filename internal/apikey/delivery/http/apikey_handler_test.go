@@ -0,0 +1,138 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apikeyHTTP "github.com/meroedu/meroedu/internal/apikey/delivery/http"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+)
+
+func TestGetAll(t *testing.T) {
+	mockUCase := new(mocks.APIKeyUseCase)
+	mockUCase.On("GetAll", mock.Anything, 0, 10).Return([]domain.APIKey{{ID: 4, Name: "reporting"}}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/api-keys", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := apikeyHTTP.APIKeyHandler{
+		APIKeyUseCase: mockUCase,
+	}
+	err = handler.GetAll(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	mockUCase := new(mocks.APIKeyUseCase)
+	mockUCase.On("GetByID", mock.Anything, int64(4)).Return(&domain.APIKey{ID: 4, Name: "reporting"}, nil).Once()
+	mockUCase.On("GetByID", mock.Anything, int64(5)).Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"4", http.StatusOK},
+		{"5", http.StatusNotFound},
+		{"mero_", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/api-keys/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/api-keys/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := apikeyHTTP.APIKeyHandler{
+			APIKeyUseCase: mockUCase,
+		}
+		err = handler.GetByID(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	mockUCase := new(mocks.APIKeyUseCase)
+	mockUCase.On("CreateAPIKey", mock.Anything, mock.MatchedBy(func(key *domain.APIKey) bool { return key.Name == "reporting" })).
+		Return(&domain.NewAPIKey{APIKey: domain.APIKey{ID: 4, Name: "reporting"}, Key: "mero_abc"}, nil).Once()
+	mockUCase.On("CreateAPIKey", mock.Anything, mock.MatchedBy(func(key *domain.APIKey) bool { return key.Name == "admin" })).
+		Return(nil, domain.ErrForbidden).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"name":"reporting","scopes":["report:view"]}`, http.StatusCreated},
+		{`{"name":"admin","scopes":["user:manage"]}`, http.StatusForbidden},
+		{`{"name":"reporting","scopes":[]}`, http.StatusBadRequest},
+		{`{"scopes":["report:view"]}`, http.StatusBadRequest},
+		{`{"name":"reporting","scopes":"report:view"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/api-keys", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := apikeyHTTP.APIKeyHandler{
+			APIKeyUseCase: mockUCase,
+		}
+		err = handler.CreateAPIKey(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	mockUCase := new(mocks.APIKeyUseCase)
+	mockUCase.On("RevokeAPIKey", mock.Anything, int64(4)).Return(nil).Once()
+	mockUCase.On("RevokeAPIKey", mock.Anything, int64(5)).Return(domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"4", http.StatusNoContent},
+		{"5", http.StatusNotFound},
+		{"mero_", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/api-keys/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/api-keys/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := apikeyHTTP.APIKeyHandler{
+			APIKeyUseCase: mockUCase,
+		}
+		err = handler.RevokeAPIKey(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,178 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const apiKeyQuery = `SELECT k.id,k.organization_id,k.name,k.prefix,k.key_hash,k.created_by,k.expires_at,k.last_used_at,k.revoked_at,
+	k.updated_at,k.created_at,
+	COALESCE((SELECT GROUP_CONCAT(s.permission ORDER BY s.permission) FROM api_keys_scopes s WHERE s.api_key_id = k.id),'')
+	FROM api_keys k`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the API key's Repository interface
+func Init(db *sql.DB) domain.APIKeyRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func nullInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: i, Valid: i != 0}
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.APIKey, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.APIKey, 0)
+	for rows.Next() {
+		t := domain.APIKey{}
+		var createdBy, expiresAt, lastUsedAt, revokedAt sql.NullInt64
+		var scopes string
+		err = rows.Scan(
+			&t.ID,
+			&t.OrganizationID,
+			&t.Name,
+			&t.Prefix,
+			&t.KeyHash,
+			&createdBy,
+			&expiresAt,
+			&lastUsedAt,
+			&revokedAt,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&scopes,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.CreatedBy = createdBy.Int64
+		t.ExpiresAt = expiresAt.Int64
+		t.LastUsedAt = lastUsedAt.Int64
+		t.RevokedAt = revokedAt.Int64
+		t.Scopes = make([]domain.Permission, 0)
+		if scopes != "" {
+			for _, p := range strings.Split(scopes, ",") {
+				t.Scopes = append(t.Scopes, domain.Permission(p))
+			}
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) getOne(ctx context.Context, query string, args ...interface{}) (*domain.APIKey, error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// GetAll returns the API keys of the caller's organization, revoked ones included
+func (m *mysqlRepository) GetAll(ctx context.Context, start int, limit int) ([]domain.APIKey, error) {
+	query := apiKeyQuery + ` WHERE k.organization_id = ? ORDER BY k.created_at DESC LIMIT ?,?`
+	return m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.APIKey, error) {
+	query := apiKeyQuery + ` WHERE k.id = ? AND k.organization_id = ?`
+	return m.getOne(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+}
+
+// GetByHash looks the key up across every organization: the key is all the caller has.
+func (m *mysqlRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := apiKeyQuery + ` WHERE k.key_hash = ?`
+	return m.getOne(ctx, query, keyHash)
+}
+
+// CreateAPIKey stores the key in the caller's organization with its scopes
+func (m *mysqlRepository) CreateAPIKey(ctx context.Context, k *domain.APIKey) (err error) {
+	k.OrganizationID = domain.OrganizationIDFromContext(ctx)
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `INSERT api_keys SET organization_id=?,name=?,prefix=?,key_hash=?,created_by=?,expires_at=?,updated_at=?,created_at=?`
+	res, err := tx.ExecContext(ctx, query, k.OrganizationID, k.Name, k.Prefix, k.KeyHash, nullInt64(k.CreatedBy), nullInt64(k.ExpiresAt),
+		k.UpdatedAt, k.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	if k.ID, err = res.LastInsertId(); err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return
+	}
+	query = `INSERT api_keys_scopes SET api_key_id=?,permission=?`
+	for _, p := range k.Scopes {
+		if _, err = tx.ExecContext(ctx, query, k.ID, p); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+	return
+}
+
+// RevokeAPIKey revokes a key which is not revoked yet
+func (m *mysqlRepository) RevokeAPIKey(ctx context.Context, id int64, revokedAt int64) error {
+	query := `UPDATE api_keys SET revoked_at=?,updated_at=? WHERE id = ? AND organization_id = ? AND revoked_at IS NULL`
+	res, err := m.conn.ExecContext(ctx, query, revokedAt, revokedAt, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// TouchAPIKey records when the key was last used
+func (m *mysqlRepository) TouchAPIKey(ctx context.Context, id int64, usedAt int64) error {
+	query := `UPDATE api_keys SET last_used_at=? WHERE id = ? AND organization_id = ?`
+	if _, err := m.conn.ExecContext(ctx, query, usedAt, id, domain.OrganizationIDFromContext(ctx)); err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	mysqlrepo "github.com/meroedu/meroedu/internal/apikey/repository/mysql"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+var columns = []string{"id", "organization_id", "name", "prefix", "key_hash", "created_by", "expires_at", "last_used_at", "revoked_at",
+	"updated_at", "created_at", "scopes"}
+
+func TestGetByHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `SELECT .+ FROM api_keys k WHERE k.key_hash = \?`
+	mock.ExpectQuery(query).WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 2, "HR sync", "mero_abcdef", "hash", nil, nil, 90, nil, 100, 100, "enrollment:manage,user:manage"))
+	mock.ExpectQuery(query).WithArgs("other").WillReturnRows(sqlmock.NewRows(columns))
+
+	repo := mysqlrepo.Init(db)
+	key, err := repo.GetByHash(context.TODO(), "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), key.OrganizationID)
+	assert.Equal(t, int64(90), key.LastUsedAt)
+	assert.Zero(t, key.CreatedBy)
+	assert.Equal(t, []domain.Permission{"enrollment:manage", "user:manage"}, key.Scopes)
+
+	_, err = repo.GetByHash(context.TODO(), "other")
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT api_keys SET organization_id=\?,name=\?,prefix=\?,key_hash=\?,created_by=\?,expires_at=\?,updated_at=\?,created_at=\?`).
+		WithArgs(2, "HR sync", "mero_abcdef", "hash", 5, nil, 100, 100).WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(`INSERT api_keys_scopes SET api_key_id=\?,permission=\?`).WithArgs(3, domain.PermCourseView).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := mysqlrepo.Init(db)
+	key := &domain.APIKey{Name: "HR sync", Prefix: "mero_abcdef", KeyHash: "hash", CreatedBy: 5,
+		Scopes: []domain.Permission{domain.PermCourseView}, UpdatedAt: 100, CreatedAt: 100}
+	err = repo.CreateAPIKey(orgCtx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), key.ID)
+	assert.Equal(t, int64(2), key.OrganizationID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `UPDATE api_keys SET revoked_at=\?,updated_at=\? WHERE id = \? AND organization_id = \? AND revoked_at IS NULL`
+	mock.ExpectExec(query).WithArgs(100, 100, 3, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(100, 100, 3, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	assert.NoError(t, repo.RevokeAPIKey(orgCtx, 3, 100))
+	assert.Equal(t, domain.ErrNotFound, repo.RevokeAPIKey(orgCtx, 3, 100), "a key is revoked once")
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// lastUsedResolution is how precisely the last use of a key is tracked, sparing a write on every request
+const lastUsedResolution = time.Minute
+
+// prefixLength is the length of the start of a key kept in clear to recognise it
+const prefixLength = len(domain.APIKeyPrefix) + 6
+
+// APIKeyUseCase ...
+type APIKeyUseCase struct {
+	apiKeyRepo     domain.APIKeyRepository
+	contextTimeOut time.Duration
+}
+
+// NewAPIKeyUseCase will create new an APIKeyUseCase
+func NewAPIKeyUseCase(a domain.APIKeyRepository, timeout time.Duration) domain.APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo:     a,
+		contextTimeOut: timeout,
+	}
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetAll ...
+func (usecase *APIKeyUseCase) GetAll(c context.Context, start int, limit int) ([]domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.apiKeyRepo.GetAll(ctx, start, limit)
+}
+
+// GetByID ...
+func (usecase *APIKeyUseCase) GetByID(c context.Context, id int64) (*domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.apiKeyRepo.GetByID(ctx, id)
+}
+
+// CreateAPIKey creates a key of the caller's organization and returns it in clear, once. The caller must hold
+// every scope of the key, and keys can not manage keys.
+func (usecase *APIKeyUseCase) CreateAPIKey(c context.Context, key *domain.APIKey) (*domain.NewAPIKey, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	now := time.Now()
+	if key.ExpiresAt != 0 && key.ExpiresAt <= now.Unix() {
+		return nil, domain.ErrBadParamInput
+	}
+	scopes := make([]domain.Permission, 0, len(key.Scopes))
+	seen := map[domain.Permission]bool{}
+	for _, p := range key.Scopes {
+		if !p.IsValid() || p == domain.PermAPIKeyManage {
+			return nil, domain.ErrBadParamInput
+		}
+		if !domain.HasPermission(ctx, p) {
+			return nil, domain.ErrForbidden
+		}
+		if !seen[p] {
+			seen[p] = true
+			scopes = append(scopes, p)
+		}
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	plain := domain.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	key.Scopes = scopes
+	key.Prefix = plain[:prefixLength]
+	key.KeyHash = hashKey(plain)
+	key.CreatedBy = domain.UserIDFromContext(ctx)
+	key.LastUsedAt = 0
+	key.RevokedAt = 0
+	key.UpdatedAt = now.Unix()
+	key.CreatedAt = now.Unix()
+	if err := usecase.apiKeyRepo.CreateAPIKey(ctx, key); err != nil {
+		return nil, err
+	}
+	return &domain.NewAPIKey{APIKey: *key, Key: plain}, nil
+}
+
+// RevokeAPIKey revokes the key; requests with it are refused from now on
+func (usecase *APIKeyUseCase) RevokeAPIKey(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.apiKeyRepo.RevokeAPIKey(ctx, id, time.Now().Unix())
+}
+
+// Authenticate returns the caller of a request made with the key: its organization, with the scopes of
+// the key as permissions. Unknown, revoked and expired keys give ErrUnauthorized.
+func (usecase *APIKeyUseCase) Authenticate(c context.Context, key string) (*domain.Principal, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if !strings.HasPrefix(key, domain.APIKeyPrefix) {
+		return nil, domain.ErrUnauthorized
+	}
+	apiKey, err := usecase.apiKeyRepo.GetByHash(ctx, hashKey(key))
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if apiKey.RevokedAt != 0 || (apiKey.ExpiresAt != 0 && apiKey.ExpiresAt <= now.Unix()) {
+		return nil, domain.ErrUnauthorized
+	}
+	if apiKey.LastUsedAt < now.Add(-lastUsedResolution).Unix() {
+		ctx = domain.WithOrganizationID(ctx, apiKey.OrganizationID)
+		if err = usecase.apiKeyRepo.TouchAPIKey(ctx, apiKey.ID, now.Unix()); err != nil {
+			log.Errorf("Tracking the use of API key %d failed: %v", apiKey.ID, err)
+		}
+	}
+	return &domain.Principal{
+		APIKeyID:       apiKey.ID,
+		OrganizationID: apiKey.OrganizationID,
+		Permissions:    apiKey.Scopes,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	ucase "github.com/meroedu/meroedu/internal/apikey/usecase"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+)
+
+var adminCtx = domain.WithPermissions(domain.WithUserID(domain.WithOrganizationID(context.TODO(), 2), 5),
+	[]domain.Permission{domain.PermUserManage, domain.PermCourseView, domain.PermAPIKeyManage})
+
+func TestCreateAPIKey(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(mocks.APIKeyRepository)
+		var stored *domain.APIKey
+		mockRepo.On("CreateAPIKey", mock.Anything, mock.AnythingOfType("*domain.APIKey")).Return(nil).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.APIKey) }).Once()
+		u := ucase.NewAPIKeyUseCase(mockRepo, time.Second*2)
+
+		key := &domain.APIKey{Name: "HR sync", Scopes: []domain.Permission{domain.PermUserManage, domain.PermUserManage}}
+		created, err := u.CreateAPIKey(adminCtx, key)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Key, domain.APIKeyPrefix))
+		assert.True(t, strings.HasPrefix(created.Key, stored.Prefix))
+		sum := sha256.Sum256([]byte(created.Key))
+		assert.Equal(t, hex.EncodeToString(sum[:]), stored.KeyHash, "keys are stored hashed")
+		assert.Equal(t, int64(5), stored.CreatedBy)
+		assert.Equal(t, []domain.Permission{domain.PermUserManage}, stored.Scopes)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		mockRepo := new(mocks.APIKeyRepository)
+		u := ucase.NewAPIKeyUseCase(mockRepo, time.Second*2)
+
+		_, err := u.CreateAPIKey(adminCtx, &domain.APIKey{Name: "keys", Scopes: []domain.Permission{domain.PermAPIKeyManage}})
+		assert.Equal(t, domain.ErrBadParamInput, err, "keys can not manage keys")
+		_, err = u.CreateAPIKey(adminCtx, &domain.APIKey{Name: "old", Scopes: []domain.Permission{domain.PermCourseView}, ExpiresAt: 100})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		_, err = u.CreateAPIKey(adminCtx, &domain.APIKey{Name: "roles", Scopes: []domain.Permission{domain.PermRoleManage}})
+		assert.Equal(t, domain.ErrForbidden, err, "a scope the caller lacks")
+		mockRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	const plain = "mero_secret"
+	sum := sha256.Sum256([]byte(plain))
+	hash := hex.EncodeToString(sum[:])
+	now := time.Now().Unix()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(mocks.APIKeyRepository)
+		key := &domain.APIKey{ID: 3, OrganizationID: 2, Scopes: []domain.Permission{domain.PermUserManage}, LastUsedAt: now - 3600}
+		mockRepo.On("GetByHash", mock.Anything, hash).Return(key, nil).Once()
+		mockRepo.On("TouchAPIKey", mock.Anything, int64(3), mock.AnythingOfType("int64")).Return(nil).Once()
+		u := ucase.NewAPIKeyUseCase(mockRepo, time.Second*2)
+
+		principal, err := u.Authenticate(context.TODO(), plain)
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Principal{APIKeyID: 3, OrganizationID: 2, Permissions: key.Scopes}, principal)
+		mockRepo.AssertExpectations(t)
+	})
+	t.Run("used-recently", func(t *testing.T) {
+		mockRepo := new(mocks.APIKeyRepository)
+		mockRepo.On("GetByHash", mock.Anything, hash).Return(&domain.APIKey{ID: 3, OrganizationID: 2, LastUsedAt: now}, nil).Once()
+		u := ucase.NewAPIKeyUseCase(mockRepo, time.Second*2)
+
+		_, err := u.Authenticate(context.TODO(), plain)
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "TouchAPIKey", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("refused", func(t *testing.T) {
+		mockRepo := new(mocks.APIKeyRepository)
+		mockRepo.On("GetByHash", mock.Anything, hash).Return(&domain.APIKey{ID: 3, RevokedAt: now}, nil).Once()
+		mockRepo.On("GetByHash", mock.Anything, hash).Return(&domain.APIKey{ID: 3, ExpiresAt: now - 1}, nil).Once()
+		mockRepo.On("GetByHash", mock.Anything, hash).Return(nil, domain.ErrNotFound).Once()
+		u := ucase.NewAPIKeyUseCase(mockRepo, time.Second*2)
+
+		for _, reason := range []string{"revoked", "expired", "unknown"} {
+			_, err := u.Authenticate(context.TODO(), plain)
+			assert.Equal(t, domain.ErrUnauthorized, err, reason)
+		}
+	})
+}
@@ -0,0 +1,409 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// AssignmentHandler ...
+type AssignmentHandler struct {
+	AssignmentUseCase domain.AssignmentUseCase
+}
+
+// NewAssignmentHandler ...
+func NewAssignmentHandler(e *echo.Echo, us domain.AssignmentUseCase) {
+	handler := &AssignmentHandler{
+		AssignmentUseCase: us,
+	}
+	// Assignments
+	e.GET("/lessons/:id/assignments", handler.GetByLesson, rbac.Require(domain.PermCourseView))
+	e.GET("/assignments/:id", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.POST("/lessons/:id/assignments", handler.CreateAssignment, rbac.Require(domain.PermCourseUpdate))
+	e.PUT("/assignments/:id", handler.UpdateAssignment, rbac.Require(domain.PermCourseUpdate))
+	e.DELETE("/assignments/:id", handler.DeleteAssignment, rbac.Require(domain.PermCourseUpdate))
+	e.GET("/assignments/:id/results", handler.GetResults, rbac.Require(domain.PermCourseUpdate))
+
+	// Submissions
+	e.POST("/assignments/:id/submissions", handler.Submit, rbac.Require(domain.PermCourseView))
+	e.GET("/assignments/:id/submissions", handler.GetSubmissions, rbac.Require(domain.PermCourseView))
+	e.GET("/submissions/:id", handler.GetSubmission, rbac.Require(domain.PermCourseView))
+	e.GET("/submissions/:id/files/:name", handler.DownloadFile, rbac.Require(domain.PermCourseView))
+	e.PUT("/submissions/:id/grade", handler.GradeSubmission, rbac.Require(domain.PermCourseUpdate))
+}
+
+// startLimit parses the start and limit query params, 0 and 10 by default
+func startLimit(echoContext echo.Context) (start int, limit int, err error) {
+	start, limit = 0, 10
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// GetByLesson godoc
+// @Summary Get the assignments of a lesson.
+// @Description Get the assignments of a lesson.
+// @Tags assignments
+// @Accept */*
+// @Produce json
+// @Param id path int true "Lesson Id"
+// @Success 200 {object} domain.Response
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /lessons/{id}/assignments [get]
+func (c *AssignmentHandler) GetByLesson(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	list, err := c.AssignmentUseCase.GetByLesson(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: list, Message: domain.Success})
+}
+
+// GetByID godoc
+// @Summary Get an assignment.
+// @Description Get an assignment with its instructions, due date, late policy and criteria.
+// @Tags assignments
+// @Accept */*
+// @Produce json
+// @Param id path int true "Assignment Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /assignments/{id} [get]
+func (c *AssignmentHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	assignment, err := c.AssignmentUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: assignment, Message: domain.Success})
+}
+
+// CreateAssignment godoc
+// @Summary Add an assignment to a lesson.
+// @Description Add an assignment. The late policy is accept, penalize or reject, accept by default. With criteria,
+// @Description the points of the assignment are the total of their points.
+// @Tags assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Lesson Id"
+// @Param assignment body domain.Assignment true "assignment Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Unknown late policy, or no points"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /lessons/{id}/assignments [post]
+func (c *AssignmentHandler) CreateAssignment(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var assignment domain.Assignment
+	err = echoContext.Bind(&assignment)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&assignment); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	assignment.LessonID = int64(idParam)
+	ctx := echoContext.Request().Context()
+	err = c.AssignmentUseCase.CreateAssignment(ctx, &assignment)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusCreated, domain.Response{Data: assignment, Message: domain.Success})
+}
+
+// UpdateAssignment godoc
+// @Summary Update an assignment.
+// @Description Update an assignment. The submissions graded before keep their scores.
+// @Tags assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Assignment Id"
+// @Param assignment body domain.Assignment true "assignment Data"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError "Unknown late policy, or no points"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /assignments/{id} [put]
+func (c *AssignmentHandler) UpdateAssignment(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var assignment domain.Assignment
+	err = echoContext.Bind(&assignment)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&assignment); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.AssignmentUseCase.UpdateAssignment(ctx, &assignment, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// DeleteAssignment godoc
+// @Summary Delete an assignment.
+// @Description Delete an assignment with its submissions.
+// @Tags assignments
+// @Accept */*
+// @Produce json
+// @Param id path int true "Assignment Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /assignments/{id} [delete]
+func (c *AssignmentHandler) DeleteAssignment(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.AssignmentUseCase.DeleteAssignment(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// GetResults godoc
+// @Summary Get the submissions to an assignment.
+// @Description Get the submissions of every learner to an assignment with their grades, the oldest first.
+// @Tags assignments
+// @Accept */*
+// @Produce json
+// @Param id path int true "Assignment Id"
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /assignments/{id}/results [get]
+func (c *AssignmentHandler) GetResults(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	start, limit, err := startLimit(echoContext)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	list, err := c.AssignmentUseCase.GetResults(ctx, int64(idParam), start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// Submit godoc
+// @Summary Hand in work to an assignment.
+// @Description Hand in a text and files to an assignment of a course the caller is enrolled in. The caller can submit
+// @Description again when the assignment allows it and the submission is not graded yet, or when it was returned.
+// @Tags assignments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Assignment Id"
+// @Param text formData string false "Text"
+// @Param files formData file false "Files"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Nothing handed in, or too many files"
+// @Failure 403 {object} domain.APIResponseError "Not enrolled in the course"
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Past the due date, no submissions left, or submitted meanwhile by another request"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /assignments/{id}/submissions [post]
+func (c *AssignmentHandler) Submit(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	submission := domain.Submission{
+		AssignmentID: int64(idParam),
+		Text:         echoContext.FormValue("text"),
+		Files:        make([]domain.SubmissionFile, 0),
+	}
+	if form, err := echoContext.MultipartForm(); err == nil {
+		for _, fileHeader := range form.File["files"] {
+			file, err := fileHeader.Open()
+			if err != nil {
+				return echoContext.JSON(http.StatusBadRequest, ResponseError{Message: err.Error()})
+			}
+			defer file.Close()
+			submission.Files = append(submission.Files, domain.SubmissionFile{
+				Filename: fileHeader.Filename,
+				Type:     fileHeader.Header.Get("Content-Type"),
+				Size:     fileHeader.Size,
+				File:     file,
+			})
+		}
+	}
+	ctx := echoContext.Request().Context()
+	err = c.AssignmentUseCase.Submit(ctx, &submission)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusCreated, domain.Response{Data: submission, Message: domain.Success})
+}
+
+// GetSubmissions godoc
+// @Summary Get the caller's submissions to an assignment.
+// @Description Get the caller's submissions to an assignment with their grades, the first one first.
+// @Tags assignments
+// @Accept */*
+// @Produce json
+// @Param id path int true "Assignment Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /assignments/{id}/submissions [get]
+func (c *AssignmentHandler) GetSubmissions(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	list, err := c.AssignmentUseCase.GetSubmissions(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: list, Message: domain.Success})
+}
+
+// GetSubmission godoc
+// @Summary Get a submission.
+// @Description Get a submission with its grade, to the learner who made it and to the ones working on the course.
+// @Tags assignments
+// @Accept */*
+// @Produce json
+// @Param id path int true "Submission Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /submissions/{id} [get]
+func (c *AssignmentHandler) GetSubmission(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	submission, err := c.AssignmentUseCase.GetSubmission(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: submission, Message: domain.Success})
+}
+
+// DownloadFile godoc
+// @Summary Download a file of a submission.
+// @Description Download a file handed in, to the learner who made the submission and to the ones working on the course.
+// @Tags assignments
+// @Accept */*
+// @Param id path int true "Submission Id"
+// @Param name path string true "Stored file name"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /submissions/{id}/files/{name} [get]
+func (c *AssignmentHandler) DownloadFile(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	filePath, err := c.AssignmentUseCase.DownloadFile(ctx, int64(idParam), echoContext.Param("name"))
+	if err != nil {
+		log.Errorf("error while getting file path %v", err)
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.File(filePath)
+}
+
+// GradeSubmission godoc
+// @Summary Grade a submission.
+// @Description Score a submission with feedback. With criteria, every criterion is scored and the score is their total.
+// @Description The late penalty is deducted from the final score. Return asks the learner to submit again.
+// @Tags assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Submission Id"
+// @Param grade body domain.SubmissionGrade true "grade"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "The scores do not match the criteria"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /submissions/{id}/grade [put]
+func (c *AssignmentHandler) GradeSubmission(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var grade domain.SubmissionGrade
+	err = echoContext.Bind(&grade)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&grade); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	submission, err := c.AssignmentUseCase.GradeSubmission(ctx, int64(idParam), &grade)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: submission, Message: domain.Success})
+}
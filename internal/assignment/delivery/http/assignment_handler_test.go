@@ -0,0 +1,405 @@
+package http_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assignmentHTTP "github.com/meroedu/meroedu/internal/assignment/delivery/http"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+)
+
+const essay = `{"title":"Essay","instructions":"Write 500 words","late_policy":"accept","criteria":[{"title":"Argument","points":10}]}`
+
+func TestGetByLesson(t *testing.T) {
+	mockUCase := new(mocks.AssignmentUseCase)
+	mockUCase.On("GetByLesson", mock.Anything, int64(4)).Return([]domain.Assignment{{ID: 6, LessonID: 4, Title: "Essay"}}, nil).Once()
+	mockUCase.On("GetByLesson", mock.Anything, int64(5)).Return(nil, domain.ErrForbidden).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"4", http.StatusOK},
+		{"5", http.StatusForbidden},
+		{"intro", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/lessons/"+tt.id+"/assignments", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/lessons/:id/assignments")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := assignmentHTTP.AssignmentHandler{
+			AssignmentUseCase: mockUCase,
+		}
+		err = handler.GetByLesson(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	mockUCase := new(mocks.AssignmentUseCase)
+	mockUCase.On("GetByID", mock.Anything, int64(6)).Return(nil, domain.ErrNotFound).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/assignments/6", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/assignments/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("6")
+	handler := assignmentHTTP.AssignmentHandler{
+		AssignmentUseCase: mockUCase,
+	}
+	err = handler.GetByID(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateAssignment(t *testing.T) {
+	mockUCase := new(mocks.AssignmentUseCase)
+	mockUCase.On("CreateAssignment", mock.Anything, mock.MatchedBy(func(a *domain.Assignment) bool { return a.LessonID == 4 && a.Title == "Essay" })).Return(nil).Once()
+	mockUCase.On("CreateAssignment", mock.Anything, mock.MatchedBy(func(a *domain.Assignment) bool { return a.LatePolicy == "never" })).Return(domain.ErrBadParamInput).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{essay, http.StatusCreated},
+		{strings.Replace(essay, "accept", "never", 1), http.StatusBadRequest},
+		{`{"instructions":"Write 500 words"}`, http.StatusBadRequest},
+		{`{"title":"Essay","late_penalty":150}`, http.StatusBadRequest},
+		{`{"title":"Essay","criteria":[{"title":"Argument","points":0}]}`, http.StatusBadRequest},
+		{`{"title":"Essay","due_at":"tomorrow"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/lessons/4/assignments", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/lessons/:id/assignments")
+		c.SetParamNames("id")
+		c.SetParamValues("4")
+		handler := assignmentHTTP.AssignmentHandler{
+			AssignmentUseCase: mockUCase,
+		}
+		err = handler.CreateAssignment(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdateAssignment(t *testing.T) {
+	mockUCase := new(mocks.AssignmentUseCase)
+	mockUCase.On("UpdateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment"), int64(6)).Return(nil).Once()
+	mockUCase.On("UpdateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment"), int64(7)).Return(domain.ErrConflict).Once()
+
+	tests := []struct {
+		id   string
+		body string
+		code int
+	}{
+		{"6", essay, http.StatusNoContent},
+		{"7", essay, http.StatusConflict},
+		{"6", `{"title":""}`, http.StatusBadRequest},
+		{"essay", essay, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/assignments/"+tt.id, strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/assignments/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := assignmentHTTP.AssignmentHandler{
+			AssignmentUseCase: mockUCase,
+		}
+		err = handler.UpdateAssignment(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteAssignment(t *testing.T) {
+	mockUCase := new(mocks.AssignmentUseCase)
+	mockUCase.On("DeleteAssignment", mock.Anything, int64(6)).Return(nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/assignments/6", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/assignments/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("6")
+	handler := assignmentHTTP.AssignmentHandler{
+		AssignmentUseCase: mockUCase,
+	}
+	err = handler.DeleteAssignment(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetResults(t *testing.T) {
+	mockUCase := new(mocks.AssignmentUseCase)
+	mockUCase.On("GetResults", mock.Anything, int64(6), 0, 50).Return([]domain.Submission{{ID: 15, AssignmentID: 6}}, nil).Once()
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"limit=50", http.StatusOK},
+		{"limit=all", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/assignments/6/results?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/assignments/:id/results")
+		c.SetParamNames("id")
+		c.SetParamValues("6")
+		handler := assignmentHTTP.AssignmentHandler{
+			AssignmentUseCase: mockUCase,
+		}
+		err = handler.GetResults(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.query)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSubmit(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockUCase := new(mocks.AssignmentUseCase)
+		mockUCase.On("Submit", mock.Anything, mock.MatchedBy(func(s *domain.Submission) bool {
+			return s.AssignmentID == 6 && s.Text == "My essay" && len(s.Files) == 1 && s.Files[0].Filename == "essay.txt"
+		})).Return(nil).Once()
+
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		require.NoError(t, writer.WriteField("text", "My essay"))
+		part, err := writer.CreateFormFile("files", "essay.txt")
+		require.NoError(t, err)
+		_, err = part.Write([]byte("Five hundred words"))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/assignments/6/submissions", body)
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/assignments/:id/submissions")
+		c.SetParamNames("id")
+		c.SetParamValues("6")
+		handler := assignmentHTTP.AssignmentHandler{
+			AssignmentUseCase: mockUCase,
+		}
+		err = handler.Submit(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("closed", func(t *testing.T) {
+		mockUCase := new(mocks.AssignmentUseCase)
+		mockUCase.On("Submit", mock.Anything, mock.AnythingOfType("*domain.Submission")).Return(domain.ErrSubmissionClosed).Once()
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/assignments/6/submissions", strings.NewReader("text=My+essay"))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/assignments/:id/submissions")
+		c.SetParamNames("id")
+		c.SetParamValues("6")
+		handler := assignmentHTTP.AssignmentHandler{
+			AssignmentUseCase: mockUCase,
+		}
+		err = handler.Submit(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("bad-id", func(t *testing.T) {
+		mockUCase := new(mocks.AssignmentUseCase)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/assignments/essay/submissions", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/assignments/:id/submissions")
+		c.SetParamNames("id")
+		c.SetParamValues("essay")
+		handler := assignmentHTTP.AssignmentHandler{
+			AssignmentUseCase: mockUCase,
+		}
+		err = handler.Submit(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
+
+func TestGetSubmissions(t *testing.T) {
+	mockUCase := new(mocks.AssignmentUseCase)
+	mockUCase.On("GetSubmissions", mock.Anything, int64(6)).Return([]domain.Submission{{ID: 15, AssignmentID: 6}}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/assignments/6/submissions", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/assignments/:id/submissions")
+	c.SetParamNames("id")
+	c.SetParamValues("6")
+	handler := assignmentHTTP.AssignmentHandler{
+		AssignmentUseCase: mockUCase,
+	}
+	err = handler.GetSubmissions(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetSubmission(t *testing.T) {
+	mockUCase := new(mocks.AssignmentUseCase)
+	mockUCase.On("GetSubmission", mock.Anything, int64(15)).Return(nil, domain.ErrForbidden).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/submissions/15", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/submissions/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("15")
+	handler := assignmentHTTP.AssignmentHandler{
+		AssignmentUseCase: mockUCase,
+	}
+	err = handler.GetSubmission(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDownloadFile(t *testing.T) {
+	rootDirectory, err := os.Getwd()
+	require.NoError(t, err)
+	mockUCase := new(mocks.AssignmentUseCase)
+	mockUCase.On("DownloadFile", mock.Anything, int64(15), "essay.txt").Return(rootDirectory+"/assignment_handler.go", nil).Once()
+	mockUCase.On("DownloadFile", mock.Anything, int64(15), "other.txt").Return("", domain.ErrNotFound).Once()
+
+	tests := []struct {
+		name string
+		code int
+	}{
+		{"essay.txt", http.StatusOK},
+		{"other.txt", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/submissions/15/files/"+tt.name, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/submissions/:id/files/:name")
+		c.SetParamNames("id", "name")
+		c.SetParamValues("15", tt.name)
+		handler := assignmentHTTP.AssignmentHandler{
+			AssignmentUseCase: mockUCase,
+		}
+		err = handler.DownloadFile(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.name)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGradeSubmission(t *testing.T) {
+	mockUCase := new(mocks.AssignmentUseCase)
+	mockUCase.On("GradeSubmission", mock.Anything, int64(15), &domain.SubmissionGrade{Score: 8, Feedback: "Good"}).
+		Return(&domain.Submission{ID: 15, Status: domain.SubmissionGraded}, nil).Once()
+	mockUCase.On("GradeSubmission", mock.Anything, int64(16), mock.AnythingOfType("*domain.SubmissionGrade")).Return(nil, domain.ErrBadParamInput).Once()
+
+	tests := []struct {
+		id   string
+		body string
+		code int
+	}{
+		{"15", `{"score":8,"feedback":"Good"}`, http.StatusOK},
+		{"16", `{"score":80}`, http.StatusBadRequest},
+		{"15", `{"score":-1}`, http.StatusBadRequest},
+		{"15", `{"scores":[{"score":2}]}`, http.StatusBadRequest},
+		{"15", `{"score":"eight"}`, http.StatusUnprocessableEntity},
+		{"mine", `{"score":8}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/submissions/"+tt.id+"/grade", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/submissions/:id/grade")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := assignmentHTTP.AssignmentHandler{
+			AssignmentUseCase: mockUCase,
+		}
+		err = handler.GradeSubmission(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
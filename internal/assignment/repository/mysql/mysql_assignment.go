@@ -0,0 +1,338 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/repository/mysqlerr"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const assignmentQuery = `SELECT a.id,a.lesson_id,l.course_id,a.title,a.instructions,a.due_at,a.late_policy,a.late_penalty,a.max_points,
+	a.criteria,a.rubric_id,a.allow_resubmission,a.max_submissions,a.peer_reviews,a.peer_anonymous,a.peer_review_due_at,
+	a.peer_allocated_at,a.created_by,a.updated_at,a.created_at FROM assignments a
+	JOIN lessons l ON l.id = a.lesson_id JOIN courses c ON c.id = l.course_id`
+
+const submissionQuery = `SELECT s.id,s.assignment_id,s.user_id,s.number,s.text,s.files,s.status,s.late,s.submitted_at,s.score,s.scores,
+	s.penalty,s.final_score,s.feedback,s.graded_by,s.graded_at FROM assignment_submissions s JOIN assignments a ON a.id = s.assignment_id
+	JOIN lessons l ON l.id = a.lesson_id JOIN courses c ON c.id = l.course_id`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the assignment's Repository interface
+func Init(db *sql.DB) domain.AssignmentRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func nullInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: i, Valid: i != 0}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
+
+// peerReview returns the peer review settings of an assignment as stored, no reviews when there is no peer review
+func peerReview(a *domain.Assignment) (reviews int, anonymous bool, dueAt sql.NullInt64) {
+	if a.PeerReview == nil {
+		return 0, false, sql.NullInt64{}
+	}
+	return a.PeerReview.Reviews, a.PeerReview.Anonymous, nullInt64(a.PeerReview.DueAt)
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Assignment, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Assignment, 0)
+	for rows.Next() {
+		t := domain.Assignment{}
+		var instructions sql.NullString
+		var dueAt, rubricID, reviewDueAt, allocatedAt, createdBy sql.NullInt64
+		var criteria string
+		var reviews int
+		var anonymous bool
+		err = rows.Scan(
+			&t.ID,
+			&t.LessonID,
+			&t.CourseID,
+			&t.Title,
+			&instructions,
+			&dueAt,
+			&t.LatePolicy,
+			&t.LatePenalty,
+			&t.MaxPoints,
+			&criteria,
+			&rubricID,
+			&t.AllowResubmission,
+			&t.MaxSubmissions,
+			&reviews,
+			&anonymous,
+			&reviewDueAt,
+			&allocatedAt,
+			&createdBy,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.Instructions = instructions.String
+		t.DueAt = dueAt.Int64
+		t.RubricID = rubricID.Int64
+		if reviews > 0 {
+			t.PeerReview = &domain.PeerReviewSettings{Reviews: reviews, Anonymous: anonymous, DueAt: reviewDueAt.Int64,
+				AllocatedAt: allocatedAt.Int64}
+		}
+		t.CreatedBy = createdBy.Int64
+		if err = json.Unmarshal([]byte(criteria), &t.Criteria); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) GetByLesson(ctx context.Context, lessonID int64) ([]domain.Assignment, error) {
+	query := assignmentQuery + ` WHERE a.lesson_id = ? AND c.organization_id = ? AND l.deleted_at IS NULL AND c.deleted_at IS NULL ORDER BY a.id`
+	return m.fetch(ctx, query, lessonID, domain.OrganizationIDFromContext(ctx))
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.Assignment, error) {
+	query := assignmentQuery + ` WHERE a.id = ? AND c.organization_id = ? AND l.deleted_at IS NULL AND c.deleted_at IS NULL`
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// CreateAssignment adds the assignment to a lesson of the caller's organization
+func (m *mysqlRepository) CreateAssignment(ctx context.Context, a *domain.Assignment) error {
+	criteria, err := json.Marshal(a.Criteria)
+	if err != nil {
+		return err
+	}
+	reviews, anonymous, reviewDueAt := peerReview(a)
+	query := `INSERT INTO assignments (lesson_id,title,instructions,due_at,late_policy,late_penalty,max_points,criteria,rubric_id,
+		allow_resubmission,max_submissions,peer_reviews,peer_anonymous,peer_review_due_at,created_by,updated_at,created_at)
+		SELECT l.id,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,? FROM lessons l JOIN courses c ON c.id = l.course_id
+		WHERE l.id = ? AND c.organization_id = ? AND l.deleted_at IS NULL AND c.deleted_at IS NULL`
+	res, err := m.conn.ExecContext(ctx, query, a.Title, nullString(a.Instructions), nullInt64(a.DueAt), a.LatePolicy, a.LatePenalty,
+		a.MaxPoints, criteria, nullInt64(a.RubricID), a.AllowResubmission, a.MaxSubmissions, reviews, anonymous, reviewDueAt,
+		nullInt64(a.CreatedBy), a.UpdatedAt, a.CreatedAt, a.LessonID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	a.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+	}
+	return err
+}
+
+func (m *mysqlRepository) UpdateAssignment(ctx context.Context, a *domain.Assignment) error {
+	criteria, err := json.Marshal(a.Criteria)
+	if err != nil {
+		return err
+	}
+	reviews, anonymous, reviewDueAt := peerReview(a)
+	query := `UPDATE assignments a JOIN lessons l ON l.id = a.lesson_id JOIN courses c ON c.id = l.course_id SET a.title=?,
+		a.instructions=?,a.due_at=?,a.late_policy=?,a.late_penalty=?,a.max_points=?,a.criteria=?,a.rubric_id=?,
+		a.allow_resubmission=?,a.max_submissions=?,a.peer_reviews=?,a.peer_anonymous=?,a.peer_review_due_at=?,a.updated_at=?
+		WHERE a.id = ? AND c.organization_id = ?`
+	_, err = m.conn.ExecContext(ctx, query, a.Title, nullString(a.Instructions), nullInt64(a.DueAt), a.LatePolicy, a.LatePenalty,
+		a.MaxPoints, criteria, nullInt64(a.RubricID), a.AllowResubmission, a.MaxSubmissions, reviews, anonymous, reviewDueAt, a.UpdatedAt,
+		a.ID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+	}
+	return err
+}
+
+// DeleteAssignment removes the assignment with its submissions
+func (m *mysqlRepository) DeleteAssignment(ctx context.Context, id int64) error {
+	query := `DELETE a FROM assignments a JOIN lessons l ON l.id = a.lesson_id JOIN courses c ON c.id = l.course_id
+		WHERE a.id = ? AND c.organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mysqlRepository) fetchSubmissions(ctx context.Context, query string, args ...interface{}) (result []domain.Submission, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Submission, 0)
+	for rows.Next() {
+		t := domain.Submission{}
+		var text, scores, feedback sql.NullString
+		var files string
+		var score, finalScore sql.NullFloat64
+		var gradedBy, gradedAt sql.NullInt64
+		err = rows.Scan(
+			&t.ID,
+			&t.AssignmentID,
+			&t.UserID,
+			&t.Number,
+			&text,
+			&files,
+			&t.Status,
+			&t.Late,
+			&t.SubmittedAt,
+			&score,
+			&scores,
+			&t.Penalty,
+			&finalScore,
+			&feedback,
+			&gradedBy,
+			&gradedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.Text = text.String
+		t.Feedback = feedback.String
+		t.GradedBy = gradedBy.Int64
+		t.GradedAt = gradedAt.Int64
+		if score.Valid {
+			t.Score = &score.Float64
+		}
+		if finalScore.Valid {
+			t.FinalScore = &finalScore.Float64
+		}
+		if err = json.Unmarshal([]byte(files), &t.Files); err != nil {
+			return nil, err
+		}
+		if scores.String != "" {
+			if err = json.Unmarshal([]byte(scores.String), &t.Scores); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// CreateSubmission adds the submission, numbered after the previous submissions of the learner
+// CreateSubmission stores a submission under its number. A submission with the same number, made meanwhile by
+// another request of the learner, gives ErrConflict.
+func (m *mysqlRepository) CreateSubmission(ctx context.Context, s *domain.Submission) error {
+	files, err := json.Marshal(s.Files)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO assignment_submissions (assignment_id,user_id,number,text,files,status,late,submitted_at)
+		VALUES (?,?,?,?,?,?,?,?)`
+	res, err := m.conn.ExecContext(ctx, query, s.AssignmentID, s.UserID, s.Number, nullString(s.Text), files, s.Status, s.Late,
+		s.SubmittedAt)
+	if mysqlerr.IsDuplicate(err) {
+		return domain.ErrConflict
+	}
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	s.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+	}
+	return err
+}
+
+func (m *mysqlRepository) GetSubmission(ctx context.Context, id int64) (*domain.Submission, error) {
+	query := submissionQuery + ` WHERE s.id = ? AND c.organization_id = ?`
+	list, err := m.fetchSubmissions(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// GetSubmissions returns the submissions of a user to an assignment, the first one first
+func (m *mysqlRepository) GetSubmissions(ctx context.Context, assignmentID int64, userID int64) ([]domain.Submission, error) {
+	query := submissionQuery + ` WHERE s.assignment_id = ? AND s.user_id = ? AND c.organization_id = ? ORDER BY s.number`
+	return m.fetchSubmissions(ctx, query, assignmentID, userID, domain.OrganizationIDFromContext(ctx))
+}
+
+// GetResults returns the submissions of every learner to an assignment, the oldest first
+func (m *mysqlRepository) GetResults(ctx context.Context, assignmentID int64, start int, limit int) ([]domain.Submission, error) {
+	query := submissionQuery + ` WHERE s.assignment_id = ? AND c.organization_id = ? ORDER BY s.id LIMIT ?,?`
+	return m.fetchSubmissions(ctx, query, assignmentID, domain.OrganizationIDFromContext(ctx), start, limit)
+}
+
+func (m *mysqlRepository) GradeSubmission(ctx context.Context, s *domain.Submission) error {
+	scores, err := json.Marshal(s.Scores)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE assignment_submissions SET status=?,score=?,scores=?,penalty=?,final_score=?,feedback=?,graded_by=?,graded_at=?
+		WHERE id = ?`
+	_, err = m.conn.ExecContext(ctx, query, s.Status, nullFloat64(s.Score), scores, s.Penalty, nullFloat64(s.FinalScore),
+		nullString(s.Feedback), nullInt64(s.GradedBy), s.GradedAt, s.ID)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+	}
+	return err
+}
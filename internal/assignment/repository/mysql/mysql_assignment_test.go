@@ -0,0 +1,157 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	mysqlrepo "github.com/meroedu/meroedu/internal/assignment/repository/mysql"
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+var assignmentColumns = []string{"id", "lesson_id", "course_id", "title", "instructions", "due_at", "late_policy", "late_penalty",
+	"max_points", "criteria", "rubric_id", "allow_resubmission", "max_submissions", "peer_reviews", "peer_anonymous", "peer_review_due_at",
+	"peer_allocated_at", "created_by", "updated_at", "created_at"}
+
+func TestGetByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`SELECT .+ FROM assignments a\s+JOIN lessons l ON l.id = a.lesson_id JOIN courses c ON c.id = l.course_id WHERE a.id = \? AND c.organization_id = \?\s+AND l.deleted_at IS NULL AND c.deleted_at IS NULL`).
+			WithArgs(5, 2).
+			WillReturnRows(sqlmock.NewRows(assignmentColumns).AddRow(5, 8, 3, "Essay", nil, 1000, "penalize", 10, 10,
+				`[{"id":1,"title":"Structure","points":4},{"id":2,"title":"Sources","points":6}]`, nil, true, 2, 3, true, 2000, nil, 4, 100, 100))
+
+		repo := mysqlrepo.Init(db)
+		assignment, err := repo.GetByID(orgCtx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Assignment{ID: 5, LessonID: 8, CourseID: 3, Title: "Essay", DueAt: 1000, LatePolicy: domain.LatePenalize,
+			LatePenalty: 10, MaxPoints: 10, Criteria: []domain.AssignmentCriterion{{ID: 1, Title: "Structure", Points: 4},
+				{ID: 2, Title: "Sources", Points: 6}}, PeerReview: &domain.PeerReviewSettings{Reviews: 3, Anonymous: true, DueAt: 2000},
+			AllowResubmission: true, MaxSubmissions: 2, CreatedBy: 4, UpdatedAt: 100,
+			CreatedAt: 100}, assignment)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`FROM assignments a`).WithArgs(5, 2).WillReturnRows(sqlmock.NewRows(assignmentColumns))
+
+		repo := mysqlrepo.Init(db)
+		_, err = repo.GetByID(orgCtx, 5)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestCreateAssignment(t *testing.T) {
+	query := `INSERT INTO assignments .+ SELECT l.id,.+ FROM lessons l\s+JOIN courses c ON c.id = l.course_id WHERE l.id = \? AND c.organization_id = \?`
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectExec(query).
+			WithArgs("Essay", "Write 500 words.", nil, "accept", float64(0), float64(10), []byte("[]"), 7, false, 0, 0, false, nil, 4, 100, 100, 8, 2).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+
+		repo := mysqlrepo.Init(db)
+		assignment := &domain.Assignment{LessonID: 8, Title: "Essay", Instructions: "Write 500 words.", LatePolicy: domain.LateAccept,
+			MaxPoints: 10, Criteria: []domain.AssignmentCriterion{}, RubricID: 7, CreatedBy: 4, UpdatedAt: 100, CreatedAt: 100}
+		assert.NoError(t, repo.CreateAssignment(orgCtx, assignment))
+		assert.Equal(t, int64(5), assignment.ID)
+	})
+	t.Run("lesson-not-found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := mysqlrepo.Init(db)
+		assert.Equal(t, domain.ErrNotFound, repo.CreateAssignment(orgCtx, &domain.Assignment{LessonID: 8, Title: "Essay"}))
+	})
+}
+
+var submissionColumns = []string{"id", "assignment_id", "user_id", "number", "text", "files", "status", "late", "submitted_at", "score",
+	"scores", "penalty", "final_score", "feedback", "graded_by", "graded_at"}
+
+func TestGetSubmissions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`FROM assignment_submissions s JOIN assignments a ON a.id = s.assignment_id\s+.+ WHERE s.assignment_id = \? AND s.user_id = \? AND c.organization_id = \? ORDER BY s.number`).
+		WithArgs(5, 6, 2).
+		WillReturnRows(sqlmock.NewRows(submissionColumns).
+			AddRow(1, 5, 6, 1, "Draft", `[]`, "returned", false, 100, 5, `[{"criterion_id":1,"score":5}]`, 0, 5, "More sources.", 4, 150).
+			AddRow(2, 5, 6, 2, nil, `[{"name":"a.pdf","filename":"essay.pdf","file_type":"application/pdf","file_size":12}]`, "submitted",
+				true, 200, nil, nil, 0, nil, nil, nil, nil))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetSubmissions(orgCtx, 5, 6)
+	assert.NoError(t, err)
+	five := float64(5)
+	assert.Equal(t, []domain.Submission{
+		{ID: 1, AssignmentID: 5, UserID: 6, Number: 1, Text: "Draft", Files: []domain.SubmissionFile{}, Status: domain.SubmissionReturned,
+			SubmittedAt: 100, Score: &five, Scores: []domain.CriterionScore{{CriterionID: 1, Score: 5}}, FinalScore: &five,
+			Feedback: "More sources.", GradedBy: 4, GradedAt: 150},
+		{ID: 2, AssignmentID: 5, UserID: 6, Number: 2, Files: []domain.SubmissionFile{{Name: "a.pdf", Filename: "essay.pdf",
+			Type: "application/pdf", Size: 12}}, Status: domain.SubmissionSubmitted, Late: true, SubmittedAt: 200},
+	}, list)
+}
+
+func TestCreateSubmission(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectExec(`INSERT INTO assignment_submissions \(assignment_id,user_id,number,text,files,status,late,submitted_at\)\s+VALUES`).
+			WithArgs(5, 6, 2, "Essay", []byte("[]"), "submitted", false, 100).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+
+		repo := mysqlrepo.Init(db)
+		submission := &domain.Submission{AssignmentID: 5, UserID: 6, Number: 2, Text: "Essay", Files: []domain.SubmissionFile{},
+			Status: domain.SubmissionSubmitted, SubmittedAt: 100}
+		assert.NoError(t, repo.CreateSubmission(orgCtx, submission))
+		assert.Equal(t, int64(3), submission.ID)
+	})
+	t.Run("number-taken", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectExec(`INSERT INTO assignment_submissions`).
+			WithArgs(5, 6, 2, "Essay", []byte("[]"), "submitted", false, 100).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '5-6-2' for key 'index_on_assignment_id_user_id_number'"})
+
+		repo := mysqlrepo.Init(db)
+		submission := &domain.Submission{AssignmentID: 5, UserID: 6, Number: 2, Text: "Essay", Files: []domain.SubmissionFile{},
+			Status: domain.SubmissionSubmitted, SubmittedAt: 100}
+		assert.Equal(t, domain.ErrConflict, repo.CreateSubmission(orgCtx, submission))
+	})
+}
+
+func TestGradeSubmission(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`UPDATE assignment_submissions SET status=\?,score=\?,scores=\?,penalty=\?,final_score=\?,feedback=\?,graded_by=\?,graded_at=\?\s+WHERE id = \?`).
+		WithArgs("graded", float64(8), []byte("null"), float64(20), 6.4, "Good.", 4, 300, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := mysqlrepo.Init(db)
+	score, final := float64(8), 6.4
+	submission := &domain.Submission{ID: 3, Status: domain.SubmissionGraded, Score: &score, Penalty: 20, FinalScore: &final,
+		Feedback: "Good.", GradedBy: 4, GradedAt: 300}
+	assert.NoError(t, repo.GradeSubmission(orgCtx, submission))
+}
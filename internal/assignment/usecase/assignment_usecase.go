@@ -0,0 +1,453 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// maxSubmissionFiles is the number of files of a submission
+const maxSubmissionFiles = 10
+
+// day is the length of a day late, in seconds
+const day = 24 * 60 * 60
+
+// AssignmentUseCase ...
+type AssignmentUseCase struct {
+	assignmentRepo      domain.AssignmentRepository
+	lessonRepo          domain.LessonRepository
+	enrollmentRepo      domain.EnrollmentRepository
+	collaboratorUseCase domain.CollaboratorUseCase
+	rubricUseCase       domain.RubricUseCase
+	fileStore           domain.AttachmentStorage
+	contextTimeOut      time.Duration
+}
+
+// NewAssignmentUseCase will create new an AssignmentUseCase
+func NewAssignmentUseCase(a domain.AssignmentRepository, l domain.LessonRepository, e domain.EnrollmentRepository,
+	cu domain.CollaboratorUseCase, ru domain.RubricUseCase, store domain.AttachmentStorage, timeout time.Duration) domain.AssignmentUseCase {
+	return &AssignmentUseCase{
+		assignmentRepo:      a,
+		lessonRepo:          l,
+		enrollmentRepo:      e,
+		collaboratorUseCase: cu,
+		rubricUseCase:       ru,
+		fileStore:           store,
+		contextTimeOut:      timeout,
+	}
+}
+
+// fileName returns a unique name to store a file of a type handed in
+func fileName(fileType string) string {
+	name := uuid.New().String()
+	switch fileType {
+	case "image/png":
+		return name + ".png"
+	case "image/jpg", "image/jpeg":
+		return name + ".jpg"
+	case "text/plain":
+		return name + ".txt"
+	case "text/markdown":
+		return name + ".md"
+	case "text/html":
+		return name + ".html"
+	case "application/pdf":
+		return name + ".pdf"
+	case "application/zip":
+		return name + ".zip"
+	case "application/vnd.openxmlformats-officedocument.wordprocessingml.document":
+		return name + ".docx"
+	case "video/mp4":
+		return name + ".mp4"
+	}
+	return ""
+}
+
+// validateAssignment checks the late policy of an assignment, numbers its criteria and sets its points from them.
+// The peer review needs a rubric to review against, and a due date to allocate the reviewers after.
+func validateAssignment(a *domain.Assignment) error {
+	if a.LatePolicy == "" {
+		a.LatePolicy = domain.LateAccept
+	}
+	if !a.LatePolicy.IsValid() {
+		return domain.ErrBadParamInput
+	}
+	if a.LatePolicy != domain.LatePenalize {
+		a.LatePenalty = 0
+	}
+	if !a.AllowResubmission {
+		a.MaxSubmissions = 0
+	}
+	if a.Criteria == nil {
+		a.Criteria = make([]domain.AssignmentCriterion, 0)
+	}
+	if len(a.Criteria) > 0 {
+		a.MaxPoints = 0
+		for i := range a.Criteria {
+			a.Criteria[i].ID = i + 1
+			a.MaxPoints += a.Criteria[i].Points
+		}
+	}
+	if a.MaxPoints <= 0 {
+		return domain.ErrBadParamInput
+	}
+	if p := a.PeerReview; p != nil {
+		if a.RubricID == 0 || a.DueAt == 0 || p.DueAt != 0 && p.DueAt <= a.DueAt {
+			return domain.ErrBadParamInput
+		}
+		p.AllocatedAt = 0
+	}
+	return nil
+}
+
+// setRubric sets the points of an assignment marked with a rubric to the points of the rubric. An assignment is
+// marked either with criteria or with a rubric.
+func (usecase *AssignmentUseCase) setRubric(ctx context.Context, a *domain.Assignment) error {
+	if a.RubricID == 0 {
+		return nil
+	}
+	if len(a.Criteria) > 0 {
+		return domain.ErrBadParamInput
+	}
+	rubric, err := usecase.rubricUseCase.GetByID(ctx, a.RubricID)
+	if err == domain.ErrNotFound {
+		return domain.ErrBadParamInput
+	}
+	if err != nil {
+		return err
+	}
+	a.MaxPoints = rubric.MaxPoints
+	return nil
+}
+
+// GetByLesson returns the assignments of a lesson
+func (usecase *AssignmentUseCase) GetByLesson(c context.Context, lessonID int64) ([]domain.Assignment, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.assignmentRepo.GetByLesson(ctx, lessonID)
+}
+
+// GetByID returns an assignment
+func (usecase *AssignmentUseCase) GetByID(c context.Context, id int64) (*domain.Assignment, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.assignmentRepo.GetByID(ctx, id)
+}
+
+// CreateAssignment adds an assignment to a lesson
+func (usecase *AssignmentUseCase) CreateAssignment(c context.Context, assignment *domain.Assignment) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeLesson(ctx, assignment.LessonID, domain.CollaboratorEditor); err != nil {
+		return err
+	}
+	if err := usecase.setRubric(ctx, assignment); err != nil {
+		return err
+	}
+	if err := validateAssignment(assignment); err != nil {
+		return err
+	}
+	lesson, err := usecase.lessonRepo.GetByID(ctx, assignment.LessonID)
+	if err != nil {
+		return err
+	}
+	assignment.CourseID = lesson.CourseID
+	assignment.CreatedBy = domain.UserIDFromContext(ctx)
+	assignment.UpdatedAt = time.Now().Unix()
+	assignment.CreatedAt = assignment.UpdatedAt
+	return usecase.assignmentRepo.CreateAssignment(ctx, assignment)
+}
+
+// UpdateAssignment updates an assignment. The submissions graded before keep their scores.
+func (usecase *AssignmentUseCase) UpdateAssignment(c context.Context, assignment *domain.Assignment, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existedAssignment, err := usecase.assignmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, existedAssignment.LessonID, domain.CollaboratorEditor); err != nil {
+		return err
+	}
+	if err = usecase.setRubric(ctx, assignment); err != nil {
+		return err
+	}
+	if err = validateAssignment(assignment); err != nil {
+		return err
+	}
+	assignment.ID = id
+	assignment.LessonID = existedAssignment.LessonID
+	assignment.CourseID = existedAssignment.CourseID
+	assignment.CreatedBy = existedAssignment.CreatedBy
+	assignment.CreatedAt = existedAssignment.CreatedAt
+	assignment.UpdatedAt = time.Now().Unix()
+	return usecase.assignmentRepo.UpdateAssignment(ctx, assignment)
+}
+
+// DeleteAssignment removes an assignment with its submissions
+func (usecase *AssignmentUseCase) DeleteAssignment(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, assignment.LessonID, domain.CollaboratorEditor); err != nil {
+		return err
+	}
+	return usecase.assignmentRepo.DeleteAssignment(ctx, id)
+}
+
+// canSubmit checks the learner can hand in a submission now, given the previous ones. It reports whether the
+// submission is late. A submission returned by the instructor can always be submitted again, and is never late.
+func canSubmit(assignment *domain.Assignment, previous []domain.Submission, now int64) (late bool, err error) {
+	if len(previous) > 0 {
+		last := previous[len(previous)-1]
+		if last.Status == domain.SubmissionReturned {
+			return false, nil
+		}
+		if !assignment.AllowResubmission || last.Status != domain.SubmissionSubmitted {
+			return false, domain.ErrSubmissionClosed
+		}
+		if assignment.MaxSubmissions > 0 && len(previous) >= assignment.MaxSubmissions {
+			return false, domain.ErrSubmissionClosed
+		}
+	}
+	if assignment.DueAt == 0 || now <= assignment.DueAt {
+		return false, nil
+	}
+	if assignment.LatePolicy == domain.LateReject {
+		return false, domain.ErrSubmissionClosed
+	}
+	return true, nil
+}
+
+// Submit hands in the caller's work to an assignment of a course they are enrolled in. A submission made meanwhile
+// by another request of the learner gives ErrConflict, so the number of submissions stays within the limit.
+func (usecase *AssignmentUseCase) Submit(c context.Context, submission *domain.Submission) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return domain.ErrForbidden
+	}
+	if submission.Text == "" && len(submission.Files) == 0 || len(submission.Files) > maxSubmissionFiles {
+		return domain.ErrBadParamInput
+	}
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, submission.AssignmentID)
+	if err != nil {
+		return err
+	}
+	_, err = usecase.enrollmentRepo.GetEnrollment(ctx, assignment.CourseID, userID)
+	if err == domain.ErrNotFound {
+		return domain.ErrForbidden
+	}
+	if err != nil {
+		return err
+	}
+	previous, err := usecase.assignmentRepo.GetSubmissions(ctx, assignment.ID, userID)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	if submission.Late, err = canSubmit(assignment, previous, now); err != nil {
+		return err
+	}
+	for i := range submission.Files {
+		file := &submission.Files[i]
+		if file.Name = fileName(file.Type); file.Name == "" {
+			return domain.ErrUnsupportedFileType
+		}
+	}
+	for i, file := range submission.Files {
+		err = usecase.fileStore.CreateAttachment(ctx, domain.Attachment{Name: file.Name, File: file.File, Type: file.Type})
+		if err != nil {
+			log.Errorf("error received from usecase storage %v", err)
+			usecase.removeFiles(ctx, submission.Files[:i])
+			return err
+		}
+	}
+	submission.UserID = userID
+	submission.Number = len(previous) + 1
+	submission.Status = domain.SubmissionSubmitted
+	submission.SubmittedAt = now
+	submission.Score, submission.Scores, submission.FinalScore = nil, nil, nil
+	if err = usecase.assignmentRepo.CreateSubmission(ctx, submission); err != nil {
+		usecase.removeFiles(ctx, submission.Files)
+		return err
+	}
+	return nil
+}
+
+// removeFiles deletes the stored files of a submission that could not be saved
+func (usecase *AssignmentUseCase) removeFiles(ctx context.Context, files []domain.SubmissionFile) {
+	for _, file := range files {
+		if err := usecase.fileStore.DeleteAttachment(ctx, file.Name); err != nil {
+			log.Errorf("error removing the file %s of an unsaved submission: %v", file.Name, err)
+		}
+	}
+}
+
+// authorize lets the learner who made a submission and the ones working on the course see it
+func (usecase *AssignmentUseCase) authorize(ctx context.Context, submission *domain.Submission) (*domain.Assignment, error) {
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, submission.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.UserID != domain.UserIDFromContext(ctx) {
+		if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, assignment.LessonID, domain.CollaboratorEditor); err != nil {
+			return nil, err
+		}
+	}
+	return assignment, nil
+}
+
+// GetSubmission returns a submission to the learner who made it and to the ones working on the course
+func (usecase *AssignmentUseCase) GetSubmission(c context.Context, id int64) (*domain.Submission, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	submission, err := usecase.assignmentRepo.GetSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = usecase.authorize(ctx, submission); err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+// GetSubmissions returns the caller's submissions to an assignment
+func (usecase *AssignmentUseCase) GetSubmissions(c context.Context, assignmentID int64) ([]domain.Submission, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.assignmentRepo.GetByID(ctx, assignmentID); err != nil {
+		return nil, err
+	}
+	return usecase.assignmentRepo.GetSubmissions(ctx, assignmentID, domain.UserIDFromContext(ctx))
+}
+
+// GetResults returns the submissions of every learner to an assignment to the ones working on the course
+func (usecase *AssignmentUseCase) GetResults(c context.Context, assignmentID int64, start int, limit int) ([]domain.Submission, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, assignment.LessonID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	return usecase.assignmentRepo.GetResults(ctx, assignmentID, start, limit)
+}
+
+// penalty returns the percentage of the score deducted for a submission, for each day started after the due date
+func penalty(assignment *domain.Assignment, submission *domain.Submission) float64 {
+	if !submission.Late || assignment.LatePolicy != domain.LatePenalize {
+		return 0
+	}
+	days := math.Ceil(float64(submission.SubmittedAt-assignment.DueAt) / day)
+	return math.Min(100, days*assignment.LatePenalty)
+}
+
+// score checks the grade of a submission and returns its score. With criteria, every criterion is scored within
+// its points and the score is their total.
+func score(assignment *domain.Assignment, grade *domain.SubmissionGrade) (float64, error) {
+	if len(assignment.Criteria) == 0 {
+		if len(grade.Scores) > 0 || grade.Score > assignment.MaxPoints {
+			return 0, domain.ErrBadParamInput
+		}
+		return grade.Score, nil
+	}
+	if len(grade.Scores) != len(assignment.Criteria) {
+		return 0, domain.ErrBadParamInput
+	}
+	scored := make(map[int]bool, len(grade.Scores))
+	total := 0.0
+	for _, s := range grade.Scores {
+		if s.CriterionID < 1 || s.CriterionID > len(assignment.Criteria) || scored[s.CriterionID] {
+			return 0, domain.ErrBadParamInput
+		}
+		if s.Score > assignment.Criteria[s.CriterionID-1].Points {
+			return 0, domain.ErrBadParamInput
+		}
+		scored[s.CriterionID] = true
+		total += s.Score
+	}
+	return total, nil
+}
+
+// GradeSubmission scores a submission with feedback, deducting the late penalty. A submission can be graded
+// again, and returned to the learner to submit again. The submissions to an assignment marked with a rubric are
+// scored with a level of each criterion of the rubric, and their scores are kept for the statistics of the rubric.
+func (usecase *AssignmentUseCase) GradeSubmission(c context.Context, id int64, grade *domain.SubmissionGrade) (*domain.Submission, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	submission, err := usecase.assignmentRepo.GetSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, submission.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, assignment.LessonID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	var total float64
+	if assignment.RubricID != 0 {
+		total, _, err = usecase.rubricUseCase.Apply(ctx, assignment.RubricID, grade.Scores)
+	} else {
+		total, err = score(assignment, grade)
+	}
+	if err != nil {
+		return nil, err
+	}
+	submission.Score = &total
+	submission.Scores = grade.Scores
+	submission.Penalty = penalty(assignment, submission)
+	final := total * (100 - submission.Penalty) / 100
+	submission.FinalScore = &final
+	submission.Feedback = grade.Feedback
+	submission.Status = domain.SubmissionGraded
+	if grade.Return {
+		submission.Status = domain.SubmissionReturned
+	}
+	submission.GradedBy = domain.UserIDFromContext(ctx)
+	submission.GradedAt = time.Now().Unix()
+	// the scores of the criteria are recorded first, replacing the ones of an earlier grading, so that a graded
+	// submission always has the scores its total was made of
+	if assignment.RubricID != 0 {
+		work := &domain.RubricWork{RubricID: assignment.RubricID, CourseID: assignment.CourseID, SubmissionID: submission.ID}
+		if err = usecase.rubricUseCase.Record(ctx, work, grade.Scores); err != nil {
+			return nil, err
+		}
+	}
+	if err = usecase.assignmentRepo.GradeSubmission(ctx, submission); err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+// DownloadFile returns the path of a file of a submission to the learner who made it and to the ones working on the course
+func (usecase *AssignmentUseCase) DownloadFile(c context.Context, submissionID int64, name string) (string, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	submission, err := usecase.assignmentRepo.GetSubmission(ctx, submissionID)
+	if err != nil {
+		return "", err
+	}
+	if _, err = usecase.authorize(ctx, submission); err != nil {
+		return "", err
+	}
+	for _, file := range submission.Files {
+		if file.Name == name {
+			return usecase.fileStore.DownloadAttachment(ctx, name)
+		}
+	}
+	return "", domain.ErrNotFound
+}
@@ -0,0 +1,526 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	ucase "github.com/meroedu/meroedu/internal/assignment/usecase"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+)
+
+var learnerCtx = domain.WithUserID(domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2),
+	[]domain.Permission{domain.PermCourseView}), 6)
+
+var instructorCtx = domain.WithUserID(domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2),
+	[]domain.Permission{domain.PermCourseUpdate}), 4)
+
+func criteria() []domain.AssignmentCriterion {
+	return []domain.AssignmentCriterion{{ID: 1, Title: "Structure", Points: 4}, {ID: 2, Title: "Sources", Points: 6}}
+}
+
+func TestCreateAssignment(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockLessonRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Lesson{ID: 8, CourseID: 3}, nil).Once()
+		mockAssignmentRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+
+		assignment := &domain.Assignment{LessonID: 8, Title: "Essay", MaxPoints: 100, LatePenalty: 10, MaxSubmissions: 3,
+			Criteria: []domain.AssignmentCriterion{{Title: "Structure", Points: 4}, {Title: "Sources", Points: 6}}}
+		assert.NoError(t, u.CreateAssignment(instructorCtx, assignment))
+		assert.Equal(t, int64(3), assignment.CourseID)
+		assert.Equal(t, domain.LateAccept, assignment.LatePolicy, "late submissions are accepted by default")
+		assert.Zero(t, assignment.LatePenalty, "only the penalize policy has a penalty")
+		assert.Zero(t, assignment.MaxSubmissions)
+		assert.Equal(t, criteria(), assignment.Criteria, "the criteria are numbered by position")
+		assert.Equal(t, float64(10), assignment.MaxPoints, "the points are the total of the criteria")
+	})
+	t.Run("no-points", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+
+		err := u.CreateAssignment(instructorCtx, &domain.Assignment{LessonID: 8, Title: "Essay"})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockAssignmentRepo.AssertNotCalled(t, "CreateAssignment", mock.Anything, mock.Anything)
+	})
+	t.Run("unknown-late-policy", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+
+		err := u.CreateAssignment(instructorCtx, &domain.Assignment{LessonID: 8, Title: "Essay", MaxPoints: 10, LatePolicy: "forgive"})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+	t.Run("rubric", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockRubricUseCase.On("GetByID", mock.Anything, int64(7)).Return(&domain.Rubric{ID: 7, MaxPoints: 12}, nil).Once()
+		mockLessonRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Lesson{ID: 8, CourseID: 3}, nil).Once()
+		mockAssignmentRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+
+		assignment := &domain.Assignment{LessonID: 8, Title: "Essay", RubricID: 7}
+		assert.NoError(t, u.CreateAssignment(instructorCtx, assignment))
+		assert.Equal(t, float64(12), assignment.MaxPoints, "the points are the points of the rubric")
+	})
+	t.Run("rubric-and-criteria", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+
+		err := u.CreateAssignment(instructorCtx, &domain.Assignment{LessonID: 8, Title: "Essay", RubricID: 7, Criteria: criteria()})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockRubricUseCase.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+	t.Run("unknown-rubric", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockRubricUseCase.On("GetByID", mock.Anything, int64(7)).Return(nil, domain.ErrNotFound).Once()
+
+		err := u.CreateAssignment(instructorCtx, &domain.Assignment{LessonID: 8, Title: "Essay", RubricID: 7})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+	t.Run("peer-review", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockRubricUseCase.On("GetByID", mock.Anything, int64(7)).Return(&domain.Rubric{ID: 7, MaxPoints: 12}, nil).Once()
+		mockLessonRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Lesson{ID: 8, CourseID: 3}, nil).Once()
+		mockAssignmentRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+
+		assignment := &domain.Assignment{LessonID: 8, Title: "Essay", RubricID: 7, DueAt: 1000,
+			PeerReview: &domain.PeerReviewSettings{Reviews: 3, DueAt: 2000, AllocatedAt: 1500}}
+		assert.NoError(t, u.CreateAssignment(instructorCtx, assignment))
+		assert.Zero(t, assignment.PeerReview.AllocatedAt, "the reviewers are allocated once the assignment is due")
+	})
+	tests := []struct {
+		name       string
+		assignment *domain.Assignment
+	}{
+		{"peer-review-without-rubric", &domain.Assignment{LessonID: 8, Title: "Essay", MaxPoints: 10, DueAt: 1000,
+			PeerReview: &domain.PeerReviewSettings{Reviews: 3}}},
+		{"peer-review-without-due-date", &domain.Assignment{LessonID: 8, Title: "Essay", RubricID: 7,
+			PeerReview: &domain.PeerReviewSettings{Reviews: 3}}},
+		{"peer-review-due-before-assignment", &domain.Assignment{LessonID: 8, Title: "Essay", RubricID: 7, DueAt: 1000,
+			PeerReview: &domain.PeerReviewSettings{Reviews: 3, DueAt: 1000}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAssignmentRepo := new(mocks.AssignmentRepository)
+			mockLessonRepo := new(mocks.LessonRepository)
+			mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+			mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+			mockRubricUseCase := new(mocks.RubricUseCase)
+			mockAttachmentStorage := new(mocks.AttachmentStorage)
+			u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+				mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+			mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+			mockRubricUseCase.On("GetByID", mock.Anything, int64(7)).Return(&domain.Rubric{ID: 7, MaxPoints: 12}, nil).Maybe()
+
+			assert.Equal(t, domain.ErrBadParamInput, u.CreateAssignment(instructorCtx, tt.assignment))
+			mockAssignmentRepo.AssertNotCalled(t, "CreateAssignment", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	now := time.Now().Unix()
+	open := &domain.Assignment{ID: 5, LessonID: 8, CourseID: 3, DueAt: now + 3600, LatePolicy: domain.LateReject, MaxPoints: 10,
+		AllowResubmission: true, MaxSubmissions: 2}
+	t.Run("success", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(open, nil).Once()
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(&domain.Enrollment{ID: 1}, nil).Once()
+		mockAssignmentRepo.On("GetSubmissions", mock.Anything, int64(5), int64(6)).Return([]domain.Submission{}, nil).Once()
+		mockAttachmentStorage.On("CreateAttachment", mock.Anything, mock.MatchedBy(func(a domain.Attachment) bool {
+			return len(a.Name) > 4 && a.Name[len(a.Name)-4:] == ".pdf"
+		})).Return(nil).Once()
+		mockAssignmentRepo.On("CreateSubmission", mock.Anything, mock.AnythingOfType("*domain.Submission")).Return(nil).Once()
+
+		submission := &domain.Submission{AssignmentID: 5, Text: "My essay",
+			Files: []domain.SubmissionFile{{Filename: "essay.pdf", Type: "application/pdf", Size: 12}}}
+		assert.NoError(t, u.Submit(learnerCtx, submission))
+		assert.Equal(t, int64(6), submission.UserID)
+		assert.Equal(t, 1, submission.Number)
+		assert.Equal(t, domain.SubmissionSubmitted, submission.Status)
+		assert.False(t, submission.Late)
+		assert.NotEmpty(t, submission.Files[0].Name)
+		mockAttachmentStorage.AssertExpectations(t)
+	})
+	t.Run("submitted-meanwhile", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(open, nil).Once()
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(&domain.Enrollment{ID: 1}, nil).Once()
+		mockAssignmentRepo.On("GetSubmissions", mock.Anything, int64(5), int64(6)).Return([]domain.Submission{{ID: 1, Number: 1, Status: domain.SubmissionSubmitted}}, nil).Once()
+		mockAttachmentStorage.On("CreateAttachment", mock.Anything, mock.AnythingOfType("domain.Attachment")).Return(nil).Once()
+		mockAssignmentRepo.On("CreateSubmission", mock.Anything, mock.MatchedBy(func(s *domain.Submission) bool {
+			return s.Number == 2
+		})).Return(domain.ErrConflict).Once()
+		var stored string
+		mockAttachmentStorage.On("DeleteAttachment", mock.Anything, mock.MatchedBy(func(name string) bool {
+			stored = name
+			return true
+		})).Return(nil).Once()
+
+		submission := &domain.Submission{AssignmentID: 5,
+			Files: []domain.SubmissionFile{{Filename: "essay.pdf", Type: "application/pdf", Size: 12}}}
+		assert.Equal(t, domain.ErrConflict, u.Submit(learnerCtx, submission))
+		assert.Equal(t, submission.Files[0].Name, stored, "the file of the unsaved submission is removed")
+		mockAttachmentStorage.AssertExpectations(t)
+	})
+	t.Run("unsupported-file-type", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(open, nil).Once()
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(&domain.Enrollment{ID: 1}, nil).Once()
+		mockAssignmentRepo.On("GetSubmissions", mock.Anything, int64(5), int64(6)).Return([]domain.Submission{}, nil).Once()
+
+		submission := &domain.Submission{AssignmentID: 5, Files: []domain.SubmissionFile{{Filename: "run.exe", Type: "application/x-msdownload"}}}
+		assert.Equal(t, domain.ErrUnsupportedFileType, u.Submit(learnerCtx, submission))
+		mockAttachmentStorage.AssertNotCalled(t, "CreateAttachment", mock.Anything, mock.Anything)
+	})
+	t.Run("empty", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		assert.Equal(t, domain.ErrBadParamInput, u.Submit(learnerCtx, &domain.Submission{AssignmentID: 5}))
+	})
+	t.Run("not-enrolled", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(open, nil).Once()
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(nil, domain.ErrNotFound).Once()
+
+		assert.Equal(t, domain.ErrForbidden, u.Submit(learnerCtx, &domain.Submission{AssignmentID: 5, Text: "My essay"}))
+	})
+
+	tests := []struct {
+		name       string
+		assignment domain.Assignment
+		previous   []domain.Submission
+		err        error
+		late       bool
+	}{
+		{name: "past-due-rejected", assignment: domain.Assignment{DueAt: now - 60, LatePolicy: domain.LateReject},
+			err: domain.ErrSubmissionClosed},
+		{name: "past-due-accepted", assignment: domain.Assignment{DueAt: now - 60, LatePolicy: domain.LateAccept}, late: true},
+		{name: "past-due-penalized", assignment: domain.Assignment{DueAt: now - 60, LatePolicy: domain.LatePenalize}, late: true},
+		{name: "no-resubmission", assignment: domain.Assignment{}, previous: []domain.Submission{{Status: domain.SubmissionSubmitted}},
+			err: domain.ErrSubmissionClosed},
+		{name: "resubmission", assignment: domain.Assignment{AllowResubmission: true},
+			previous: []domain.Submission{{Status: domain.SubmissionSubmitted}}},
+		{name: "no-submissions-left", assignment: domain.Assignment{AllowResubmission: true, MaxSubmissions: 1},
+			previous: []domain.Submission{{Status: domain.SubmissionSubmitted}}, err: domain.ErrSubmissionClosed},
+		{name: "already-graded", assignment: domain.Assignment{AllowResubmission: true},
+			previous: []domain.Submission{{Status: domain.SubmissionGraded}}, err: domain.ErrSubmissionClosed},
+		{name: "returned", assignment: domain.Assignment{DueAt: now - 60, LatePolicy: domain.LateReject},
+			previous: []domain.Submission{{Status: domain.SubmissionReturned}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAssignmentRepo := new(mocks.AssignmentRepository)
+			mockLessonRepo := new(mocks.LessonRepository)
+			mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+			mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+			mockRubricUseCase := new(mocks.RubricUseCase)
+			mockAttachmentStorage := new(mocks.AttachmentStorage)
+			u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+				mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+			assignment := tt.assignment
+			assignment.ID, assignment.CourseID, assignment.MaxPoints = 5, 3, 10
+			mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(&assignment, nil).Once()
+			mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(&domain.Enrollment{ID: 1}, nil).Once()
+			mockAssignmentRepo.On("GetSubmissions", mock.Anything, int64(5), int64(6)).Return(tt.previous, nil).Once()
+			mockAssignmentRepo.On("CreateSubmission", mock.Anything, mock.AnythingOfType("*domain.Submission")).Return(nil).Maybe()
+
+			submission := &domain.Submission{AssignmentID: 5, Text: "My essay"}
+			assert.Equal(t, tt.err, u.Submit(learnerCtx, submission))
+			if tt.err == nil {
+				assert.Equal(t, tt.late, submission.Late)
+				assert.Equal(t, len(tt.previous)+1, submission.Number)
+			} else {
+				mockAssignmentRepo.AssertNotCalled(t, "CreateSubmission", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestGradeSubmission(t *testing.T) {
+	assignment := &domain.Assignment{ID: 5, LessonID: 8, CourseID: 3, DueAt: 1000, LatePolicy: domain.LatePenalize, LatePenalty: 10,
+		MaxPoints: 10, Criteria: criteria()}
+	t.Run("late", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		submission := &domain.Submission{ID: 2, AssignmentID: 5, UserID: 6, Status: domain.SubmissionSubmitted, Late: true,
+			SubmittedAt: 1000 + 24*3600 + 1}
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(2)).Return(submission, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(assignment, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockAssignmentRepo.On("GradeSubmission", mock.Anything, submission).Return(nil).Once()
+
+		graded, err := u.GradeSubmission(instructorCtx, 2, &domain.SubmissionGrade{Feedback: "Good.", Scores: []domain.CriterionScore{
+			{CriterionID: 2, Score: 5, Comment: "One source is missing."},
+			{CriterionID: 1, Score: 4},
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, float64(9), *graded.Score, "the score is the total of the criteria")
+		assert.Equal(t, float64(20), graded.Penalty, "two days started after the due date")
+		assert.InDelta(t, 7.2, *graded.FinalScore, 1e-9)
+		assert.Equal(t, domain.SubmissionGraded, graded.Status)
+		assert.Equal(t, int64(4), graded.GradedBy)
+	})
+	t.Run("returned", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		submission := &domain.Submission{ID: 2, AssignmentID: 5, UserID: 6, Status: domain.SubmissionSubmitted, SubmittedAt: 900}
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(2)).Return(submission, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(assignment, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockAssignmentRepo.On("GradeSubmission", mock.Anything, submission).Return(nil).Once()
+
+		graded, err := u.GradeSubmission(instructorCtx, 2, &domain.SubmissionGrade{Return: true, Scores: []domain.CriterionScore{
+			{CriterionID: 1, Score: 2}, {CriterionID: 2, Score: 1},
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.SubmissionReturned, graded.Status)
+		assert.Equal(t, float64(3), *graded.FinalScore)
+	})
+
+	tests := []struct {
+		name   string
+		scores []domain.CriterionScore
+	}{
+		{name: "missing-criterion", scores: []domain.CriterionScore{{CriterionID: 1, Score: 4}}},
+		{name: "duplicate-criterion", scores: []domain.CriterionScore{{CriterionID: 1, Score: 4}, {CriterionID: 1, Score: 4}}},
+		{name: "unknown-criterion", scores: []domain.CriterionScore{{CriterionID: 1, Score: 4}, {CriterionID: 3, Score: 4}}},
+		{name: "above-points", scores: []domain.CriterionScore{{CriterionID: 1, Score: 5}, {CriterionID: 2, Score: 4}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAssignmentRepo := new(mocks.AssignmentRepository)
+			mockLessonRepo := new(mocks.LessonRepository)
+			mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+			mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+			mockRubricUseCase := new(mocks.RubricUseCase)
+			mockAttachmentStorage := new(mocks.AttachmentStorage)
+			u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+				mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+			mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(2)).Return(&domain.Submission{ID: 2, AssignmentID: 5}, nil).Once()
+			mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(assignment, nil).Once()
+			mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+
+			_, err := u.GradeSubmission(instructorCtx, 2, &domain.SubmissionGrade{Scores: tt.scores})
+			assert.Equal(t, domain.ErrBadParamInput, err)
+			mockAssignmentRepo.AssertNotCalled(t, "GradeSubmission", mock.Anything, mock.Anything)
+		})
+	}
+	t.Run("not-working-on-the-course", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(2)).Return(&domain.Submission{ID: 2, AssignmentID: 5}, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(assignment, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+
+		_, err := u.GradeSubmission(learnerCtx, 2, &domain.SubmissionGrade{Score: 10})
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+	t.Run("rubric", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		rubricAssignment := &domain.Assignment{ID: 5, LessonID: 8, CourseID: 3, MaxPoints: 12, RubricID: 7}
+		submission := &domain.Submission{ID: 2, AssignmentID: 5, UserID: 6, Status: domain.SubmissionSubmitted, SubmittedAt: 900}
+		scores := []domain.CriterionScore{{CriterionID: 1, LevelID: 2}, {CriterionID: 2, LevelID: 3}}
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(2)).Return(submission, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(rubricAssignment, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockRubricUseCase.On("Apply", mock.Anything, int64(7), scores).Return(float64(9), float64(12), nil).Once()
+		mockAssignmentRepo.On("GradeSubmission", mock.Anything, submission).Return(nil).Once()
+		mockRubricUseCase.On("Record", mock.Anything, &domain.RubricWork{RubricID: 7, CourseID: 3, SubmissionID: 2}, scores).Return(nil).Once()
+
+		graded, err := u.GradeSubmission(instructorCtx, 2, &domain.SubmissionGrade{Scores: scores})
+		assert.NoError(t, err)
+		assert.Equal(t, float64(9), *graded.Score, "the score is the total of the levels of the rubric")
+		mockRubricUseCase.AssertExpectations(t)
+	})
+	t.Run("rubric-scores-not-recorded", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		rubricAssignment := &domain.Assignment{ID: 5, LessonID: 8, CourseID: 3, MaxPoints: 12, RubricID: 7}
+		submission := &domain.Submission{ID: 2, AssignmentID: 5, UserID: 6, Status: domain.SubmissionSubmitted, SubmittedAt: 900}
+		scores := []domain.CriterionScore{{CriterionID: 1, LevelID: 2}, {CriterionID: 2, LevelID: 3}}
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(2)).Return(submission, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(rubricAssignment, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockRubricUseCase.On("Apply", mock.Anything, int64(7), scores).Return(float64(9), float64(12), nil).Once()
+		mockRubricUseCase.On("Record", mock.Anything, mock.Anything, scores).Return(domain.ErrInternalServerError).Once()
+
+		_, err := u.GradeSubmission(instructorCtx, 2, &domain.SubmissionGrade{Scores: scores})
+		assert.Equal(t, domain.ErrInternalServerError, err)
+		mockAssignmentRepo.AssertNotCalled(t, "GradeSubmission", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDownloadFile(t *testing.T) {
+	submission := &domain.Submission{ID: 2, AssignmentID: 5, UserID: 6, Files: []domain.SubmissionFile{{Name: "a.pdf"}}}
+	t.Run("owner", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(2)).Return(submission, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Assignment{ID: 5, LessonID: 8}, nil).Once()
+		mockAttachmentStorage.On("DownloadAttachment", mock.Anything, "a.pdf").Return("/files/a.pdf", nil).Once()
+
+		path, err := u.DownloadFile(learnerCtx, 2, "a.pdf")
+		assert.NoError(t, err)
+		assert.Equal(t, "/files/a.pdf", path)
+	})
+	t.Run("file-of-another-submission", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(2)).Return(submission, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Assignment{ID: 5, LessonID: 8}, nil).Once()
+
+		_, err := u.DownloadFile(learnerCtx, 2, "b.pdf")
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockAttachmentStorage.AssertNotCalled(t, "DownloadAttachment", mock.Anything, mock.Anything)
+	})
+	t.Run("another-learner", func(t *testing.T) {
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewAssignmentUseCase(mockAssignmentRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+			mockRubricUseCase, mockAttachmentStorage, time.Second*2)
+		other := domain.WithUserID(learnerCtx, 7)
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(2)).Return(submission, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Assignment{ID: 5, LessonID: 8}, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+
+		_, err := u.DownloadFile(other, 2, "a.pdf")
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+}
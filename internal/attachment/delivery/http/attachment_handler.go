@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
 	"github.com/meroedu/meroedu/internal/util"
 	"github.com/meroedu/meroedu/pkg/log"
 )
@@ -28,9 +29,9 @@ func NewAttachmentHandler(e *echo.Echo, us domain.AttachmentUseCase) {
 	}
 
 	// Create Attachment
-	e.POST("attachments", handler.CreateAttachment)
+	e.POST("attachments", handler.CreateAttachment, rbac.Require(domain.PermContentUpload))
 	// Download attachment
-	e.GET("attachments/download", handler.DownloadAttachment)
+	e.GET("attachments/download", handler.DownloadAttachment, rbac.Require(domain.PermCourseView))
 
 }
 
@@ -44,6 +45,7 @@ func NewAttachmentHandler(e *echo.Echo, us domain.AttachmentUseCase) {
 // @Param file formData file true  "Upload file"
 // @Produce json
 // @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Course not found"
 // @Failure 500 {object} domain.APIResponseError "Internal Server Error"
 // @Router /attachments [post]
 func (a *AttachmentHandler) CreateAttachment(echoContext echo.Context) error {
@@ -58,20 +58,29 @@ func Init(db *sql.DB) domain.AttachmentRepository {
 	}
 }
 
-// CreateAttachment ...
+// CreateAttachment stores the attachment of a course of the caller's organization
 func (r mysqlRepository) CreateAttachment(ctx context.Context, a domain.Attachment) error {
-	query := `INSERT attachments SET title=?,description=?,name=?,size=?,type=?,course_id=?,updated_at=?,created_at=?`
+	query := `INSERT INTO attachments (title,description,name,size,type,course_id,organization_id,updated_at,created_at)
+		SELECT ?,?,?,?,?,id,organization_id,?,? FROM courses WHERE id = ? AND organization_id = ?`
 	stmt, err := r.conn.PrepareContext(ctx, query)
 	if err != nil {
 		log.Error("error while preparing statement ", err)
 		return err
 	}
 	timestamp := time.Now().Unix()
-	res, err := stmt.ExecContext(ctx, a.Title, a.Description, a.Name, a.Size, a.Type, a.CourseID, timestamp, timestamp)
+	res, err := stmt.ExecContext(ctx, a.Title, a.Description, a.Name, a.Size, a.Type, timestamp, timestamp,
+		a.CourseID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		log.Error("error while executing statement ", err)
 		return err
 	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
 	lastID, err := res.LastInsertId()
 	if err != nil {
 		log.Error("got an error from LastInsertId method: ", err)
@@ -82,8 +91,8 @@ func (r mysqlRepository) CreateAttachment(ctx context.Context, a domain.Attachme
 }
 
 func (m *mysqlRepository) GetAttachmentByCourse(ctx context.Context, courseID int64) ([]domain.Attachment, error) {
-	query := `SELECT id,title,description,name,size,type,updated_at,created_at FROM attachments WHERE course_id = ?`
-	list, err := m.fetch(ctx, query, courseID)
+	query := `SELECT id,title,description,name,size,type,updated_at,created_at FROM attachments WHERE course_id = ? AND organization_id = ?`
+	list, err := m.fetch(ctx, query, courseID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -26,14 +26,20 @@ func TestCreateAttachment(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `INSERT attachments SET title=\?,description=\?,name=\?,size=\?,type=\?,course_id=\?,updated_at=\?,created_at=\?`
+	query := `INSERT INTO attachments (.+) SELECT (.+) FROM courses WHERE id = \? AND organization_id = \?`
 
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(a.Title, a.Description, a.Name, a.Size, a.Type, a.CourseID, a.UpdatedAt, a.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(a.Title, a.Description, a.Name, a.Size, a.Type, sqlmock.AnyArg(), sqlmock.AnyArg(), a.CourseID, int64(1)).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(a.Title, a.Description, a.Name, a.Size, a.Type, sqlmock.AnyArg(), sqlmock.AnyArg(), a.CourseID, int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.CreateAttachment(context.TODO(), a)
+	err = repo.CreateAttachment(domain.WithOrganizationID(context.TODO(), 1), a)
 	assert.NoError(t, err)
+	err = repo.CreateAttachment(domain.WithOrganizationID(context.TODO(), 2), a)
+	assert.Equal(t, domain.ErrNotFound, err)
 	assert.Equal(t, int64(12), a.ID)
 }
 
@@ -45,10 +51,10 @@ func TestGetAttachmentByCourse(t *testing.T) {
 	row := sqlmock.NewRows([]string{"id", "title", "description", "name", "size", "type", "updated_at", "created_at"}).
 		AddRow("1", "testing-2", "description", "name", 240, "application/pdf", time.Now().Unix(), time.Now().Unix())
 
-	query := `SELECT id,title,description,name,size,type,updated_at,created_at FROM attachments WHERE course_id = \?`
-	mock.ExpectQuery(query).WillReturnRows(row)
+	query := `SELECT id,title,description,name,size,type,updated_at,created_at FROM attachments WHERE course_id = \? AND organization_id = \?`
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(1)).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	attachments, err := c.GetAttachmentByCourse(context.TODO(), 1)
+	attachments, err := c.GetAttachmentByCourse(domain.WithOrganizationID(context.TODO(), 1), 1)
 
 	assert.NoError(t, err)
 	assert.Equal(t, len(attachments), 1)
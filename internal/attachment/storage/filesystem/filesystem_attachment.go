@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/meroedu/meroedu/internal/config"
 	"github.com/meroedu/meroedu/internal/domain"
@@ -16,11 +17,17 @@ type fileStorage struct {
 
 // Init will create an object that represent the attachment's Repository interface
 func Init() (domain.AttachmentStorage, error) {
+	return InitIn(config.C.Filesystem.RelativePath)
+}
+
+// InitIn will create an object that represent the attachment's Repository interface, keeping the files in their
+// own directory, relative to the working directory, apart from the attachments of the courses
+func InitIn(relativePath string) (domain.AttachmentStorage, error) {
 	rootDirectory, err := os.Getwd()
 	if err != nil {
 		return nil, err
 	}
-	path := rootDirectory + "/" + config.C.Filesystem.RelativePath
+	path := rootDirectory + "/" + relativePath
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		os.Mkdir(path, 0700)
 	}
@@ -29,11 +36,19 @@ func Init() (domain.AttachmentStorage, error) {
 	}, nil
 }
 
+// validName reports whether a file name stays in the directory of the storage
+func validName(fileName string) bool {
+	return fileName != "" && fileName != "." && !strings.Contains(fileName, "..") && !strings.ContainsAny(fileName, "/\\")
+}
+
 func (repo *fileStorage) CreateAttachment(ctx context.Context, attachment domain.Attachment) error {
 	src := attachment.File
 	if src == nil {
 		return domain.ErrFileEmpty
 	}
+	if !validName(attachment.Name) {
+		return domain.ErrBadParamInput
+	}
 	filePath := repo.path + "/" + attachment.Name
 	dst, err := os.Create(filePath)
 	if err != nil {
@@ -51,9 +66,24 @@ func (repo *fileStorage) CreateAttachment(ctx context.Context, attachment domain
 }
 
 func (repo *fileStorage) DownloadAttachment(ctx context.Context, fileName string) (string, error) {
+	if !validName(fileName) {
+		return "", domain.ErrBadParamInput
+	}
 	filePath := repo.path + "/" + fileName
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return "", err
 	}
 	return filePath, nil
 }
+
+func (repo *fileStorage) DeleteAttachment(ctx context.Context, fileName string) error {
+	if !validName(fileName) {
+		return domain.ErrBadParamInput
+	}
+	filePath := repo.path + "/" + fileName
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		log.Errorf("error occur while removing filepath: %v, error: %v", filePath, err)
+		return err
+	}
+	return nil
+}
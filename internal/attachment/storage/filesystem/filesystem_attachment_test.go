@@ -77,6 +77,27 @@ func TestCreateAttachment(t *testing.T) {
 	}
 }
 
+func TestDeleteAttachment(t *testing.T) {
+	filename := "attachment.txt"
+	file, err := createTempFile(filename)
+	assert.NoError(t, err)
+	mockAttachment := domain.Attachment{ID: 1, Name: filename, File: file}
+	defer file.Close()
+	s, err := filestore.Init()
+	if err != nil {
+		t.Errorf("error init filestore")
+	}
+	assert.NoError(t, s.CreateAttachment(context.TODO(), mockAttachment))
+	t.Run("success", func(t *testing.T) {
+		assert.NoError(t, s.DeleteAttachment(context.TODO(), filename))
+		_, err := s.DownloadAttachment(context.TODO(), filename)
+		assert.Error(t, err)
+	})
+	t.Run("missing-file", func(t *testing.T) {
+		assert.NoError(t, s.DeleteAttachment(context.TODO(), "abc.txt"))
+	})
+}
+
 func TestDownloadAttachment(t *testing.T) {
 	filename := "attachment.txt"
 	file, err := createTempFile(filename)
@@ -105,3 +126,40 @@ func TestDownloadAttachment(t *testing.T) {
 		t.Errorf("error removing %v", filename)
 	}
 }
+
+func TestAttachmentNameOutsideStorage(t *testing.T) {
+	filename := "attachment.txt"
+	file, err := createTempFile(filename)
+	assert.NoError(t, err)
+	defer file.Close()
+	defer removeFile(filename)
+	s, err := filestore.Init()
+	assert.NoError(t, err)
+	for _, name := range []string{"../config.yml", "a/b.txt", "..", `a\b.txt`, ""} {
+		t.Run(name, func(t *testing.T) {
+			path, err := s.DownloadAttachment(context.TODO(), name)
+			assert.Equal(t, domain.ErrBadParamInput, err)
+			assert.Empty(t, path)
+			assert.Equal(t, domain.ErrBadParamInput, s.DeleteAttachment(context.TODO(), name))
+			assert.Equal(t, domain.ErrBadParamInput, s.CreateAttachment(context.TODO(), domain.Attachment{Name: name, File: file}))
+		})
+	}
+}
+
+func TestInitIn(t *testing.T) {
+	filename := "attachment.txt"
+	file, err := createTempFile(filename)
+	assert.NoError(t, err)
+	defer file.Close()
+	defer removeFile(filename)
+	s, err := filestore.InitIn("submissions")
+	assert.NoError(t, err)
+	defer os.RemoveAll("submissions")
+
+	_, err = s.DownloadAttachment(context.TODO(), filename)
+	assert.Error(t, err, "the files of the working directory are not in the storage")
+	assert.NoError(t, s.CreateAttachment(context.TODO(), domain.Attachment{Name: "submission.txt", File: file}))
+	path, err := s.DownloadAttachment(context.TODO(), "submission.txt")
+	assert.NoError(t, err)
+	assert.Contains(t, path, "/submissions/submission.txt")
+}
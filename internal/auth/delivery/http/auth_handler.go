@@ -0,0 +1,184 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// AuthHandler ...
+type AuthHandler struct {
+	AuthUseCase domain.AuthUseCase
+}
+
+// NewAuthHandler ...
+func NewAuthHandler(e *echo.Echo, us domain.AuthUseCase) {
+	handler := &AuthHandler{
+		AuthUseCase: us,
+	}
+	e.POST("/auth/login", handler.Login)
+	e.POST("/auth/refresh", handler.Refresh)
+	e.POST("/auth/logout", handler.Logout)
+	e.POST("/auth/revoke", handler.RevokeAll)
+	e.PUT("/auth/password", handler.ChangePassword)
+}
+
+// Login godoc
+// @Summary Log in with password.
+// @Description Log in with email or username and password. Returns a short lived access token and a refresh token, or a two-factor challenge to answer at /auth/2fa/login. Repeated wrong passwords lock the account for a while.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param login body domain.Login true "Credentials"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Invalid login or password"
+// @Failure 423 {object} domain.APIResponseError "Locked after too many failed logins"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/login [post]
+func (c *AuthHandler) Login(echoContext echo.Context) error {
+	var login domain.Login
+	err := echoContext.Bind(&login)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&login); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	tokens, err := c.AuthUseCase.Login(ctx, login.Login, login.Password)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    tokens,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// Refresh godoc
+// @Summary Refresh the access token.
+// @Description Exchange a refresh token for a new token pair. The given refresh token can not be used again.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body domain.RefreshRequest true "Refresh token"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Invalid, expired or revoked refresh token"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/refresh [post]
+func (c *AuthHandler) Refresh(echoContext echo.Context) error {
+	var req domain.RefreshRequest
+	err := echoContext.Bind(&req)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&req); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	tokens, err := c.AuthUseCase.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    tokens,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// Logout godoc
+// @Summary Log out.
+// @Description Revoke the given refresh token.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body domain.RefreshRequest true "Refresh token"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/logout [post]
+func (c *AuthHandler) Logout(echoContext echo.Context) error {
+	var req domain.RefreshRequest
+	err := echoContext.Bind(&req)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&req); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.AuthUseCase.Logout(ctx, req.RefreshToken); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// RevokeAll godoc
+// @Summary Log out everywhere.
+// @Description Revoke every refresh token of the authenticated user.
+// @Tags auth
+// @Accept */*
+// @Produce json
+// @Success 204
+// @Failure 401 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/revoke [post]
+func (c *AuthHandler) RevokeAll(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return echoContext.JSON(http.StatusUnauthorized, ResponseError{Message: domain.ErrUnauthorized.Error()})
+	}
+	if err := c.AuthUseCase.RevokeAll(ctx, userID); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// ChangePassword godoc
+// @Summary Change password.
+// @Description Change the password of the authenticated user. Every refresh token of the user is revoked.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param password body domain.PasswordChange true "Current and new password"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Wrong current password"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/password [put]
+func (c *AuthHandler) ChangePassword(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return echoContext.JSON(http.StatusUnauthorized, ResponseError{Message: domain.ErrUnauthorized.Error()})
+	}
+	var change domain.PasswordChange
+	err := echoContext.Bind(&change)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&change); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	if err = c.AuthUseCase.ChangePassword(ctx, userID, &change); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
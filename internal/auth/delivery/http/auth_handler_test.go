@@ -0,0 +1,63 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	authHTTP "github.com/meroedu/meroedu/internal/auth/delivery/http"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+)
+
+func TestLogin(t *testing.T) {
+	mockUCase := new(mocks.AuthUseCase)
+	mockUCase.On("Login", mock.Anything, "dinesh", "s3cret-pass").Return(&domain.TokenPair{AccessToken: "a", RefreshToken: "r"}, nil).Once()
+	mockUCase.On("Login", mock.Anything, "dinesh", "wrong").Return(nil, domain.ErrInvalidCredentials).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"login":"dinesh","password":"s3cret-pass"}`, http.StatusOK},
+		{`{"login":"dinesh","password":"wrong"}`, http.StatusUnauthorized},
+		{`{"login":"dinesh"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/login", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := authHTTP.AuthHandler{
+			AuthUseCase: mockUCase,
+		}
+		err = handler.Login(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRevokeAllRequiresUser(t *testing.T) {
+	mockUCase := new(mocks.AuthUseCase)
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/auth/revoke", strings.NewReader(""))
+	assert.NoError(t, err)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := authHTTP.AuthHandler{
+		AuthUseCase: mockUCase,
+	}
+	err = handler.RevokeAll(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockUCase.AssertNotCalled(t, "RevokeAll", mock.Anything, mock.Anything)
+}
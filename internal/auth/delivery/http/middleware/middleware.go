@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// Authenticate returns a middleware that requires a valid bearer access token and puts its user, session, the
+// user's organization and the permissions of the user's role into the request context, and records that the
+// user was seen. A bearer API key is accepted as well, giving its organization and scopes without a user.
+// Routes listed in publicPaths are left open; a trailing "*" matches any suffix. The client of every request
+// is put into the context, for the sessions opened by the login routes.
+func Authenticate(auth domain.AuthUseCase, keys domain.APIKeyUseCase, sessions domain.SessionUseCase, publicPaths ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := domain.WithClient(c.Request().Context(), domain.Client{IP: c.RealIP(), UserAgent: c.Request().UserAgent()})
+			c.SetRequest(c.Request().WithContext(ctx))
+			if isPublic(c.Path(), publicPaths) {
+				return next(c)
+			}
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if !strings.HasPrefix(header, "Bearer ") {
+				return c.JSON(http.StatusUnauthorized, ResponseError{Message: domain.ErrUnauthorized.Error()})
+			}
+			token := strings.TrimPrefix(header, "Bearer ")
+			var principal *domain.Principal
+			var err error
+			if strings.HasPrefix(token, domain.APIKeyPrefix) {
+				principal, err = keys.Authenticate(ctx, token)
+			} else {
+				principal, err = auth.Authenticate(ctx, token)
+			}
+			if err != nil {
+				return c.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+			sessions.Touch(ctx, principal.UserID, principal.SessionID)
+			ctx = domain.WithUserID(ctx, principal.UserID)
+			ctx = domain.WithSessionID(ctx, principal.SessionID)
+			ctx = domain.WithOrganizationID(ctx, principal.OrganizationID)
+			ctx = domain.WithPermissions(ctx, principal.Permissions)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+func isPublic(path string, publicPaths []string) bool {
+	for _, p := range publicPaths {
+		if p == path || (strings.HasSuffix(p, "*") && strings.HasPrefix(path, strings.TrimSuffix(p, "*"))) {
+			return true
+		}
+	}
+	return false
+}
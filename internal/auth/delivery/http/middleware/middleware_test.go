@@ -0,0 +1,75 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/auth/delivery/http/middleware"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+)
+
+func TestAuthenticate(t *testing.T) {
+	mockUCase := new(mocks.AuthUseCase)
+	mockUCase.On("Authenticate", mock.Anything, "good").
+		Return(&domain.Principal{UserID: 4, OrganizationID: 2, Permissions: []domain.Permission{domain.PermCourseView}}, nil)
+	mockUCase.On("Authenticate", mock.Anything, "bad").Return(nil, domain.ErrUnauthorized)
+	mockKeys := new(mocks.APIKeyUseCase)
+	mockKeys.On("Authenticate", mock.Anything, "mero_good").
+		Return(&domain.Principal{APIKeyID: 9, OrganizationID: 2, Permissions: []domain.Permission{domain.PermCourseView}}, nil)
+	mockKeys.On("Authenticate", mock.Anything, "mero_revoked").Return(nil, domain.ErrUnauthorized)
+	mockSessions := new(mocks.SessionUseCase)
+	mockSessions.On("Touch", mock.Anything, int64(4), int64(0)).Return()
+	mockSessions.On("Touch", mock.Anything, int64(0), int64(0)).Return()
+
+	e := echo.New()
+	e.Use(middleware.Authenticate(mockUCase, mockKeys, mockSessions, "/auth/login", "/swagger/*"))
+	var seen int64
+	handler := func(c echo.Context) error {
+		seen = domain.UserIDFromContext(c.Request().Context())
+		org := domain.OrganizationIDFromContext(c.Request().Context())
+		if org != 0 && org != 2 {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		if org != 0 && !domain.HasPermission(c.Request().Context(), domain.PermCourseView) {
+			return c.NoContent(http.StatusForbidden)
+		}
+		return c.NoContent(http.StatusOK)
+	}
+	e.GET("/courses", handler)
+	e.GET("/swagger/*", handler)
+	e.POST("/auth/login", handler)
+
+	tests := []struct {
+		method string
+		path   string
+		header string
+		code   int
+		userID int64
+	}{
+		{echo.GET, "/courses", "Bearer good", http.StatusOK, 4},
+		{echo.GET, "/courses", "Bearer bad", http.StatusUnauthorized, 0},
+		{echo.GET, "/courses", "Bearer mero_good", http.StatusOK, 0},
+		{echo.GET, "/courses", "Bearer mero_revoked", http.StatusUnauthorized, 0},
+		{echo.GET, "/courses", "", http.StatusUnauthorized, 0},
+		{echo.GET, "/swagger/index.html", "", http.StatusOK, 0},
+		{echo.POST, "/auth/login", "", http.StatusOK, 0},
+	}
+	for _, tt := range tests {
+		seen = 0
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.header != "" {
+			req.Header.Set(echo.HeaderAuthorization, tt.header)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, tt.code, rec.Code, tt.path)
+		assert.Equal(t, tt.userID, seen, tt.path)
+	}
+	mockSessions.AssertNumberOfCalls(t, "Touch", 2)
+}
@@ -0,0 +1,102 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the auth's Repository interface
+func Init(db *sql.DB) domain.AuthRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func (m *mysqlRepository) CreateRefreshToken(ctx context.Context, t *domain.RefreshToken) (err error) {
+	query := `INSERT refresh_tokens SET user_id=?,organization_id=?,session_id=?,token_hash=?,expires_at=?,created_at=?`
+	sessionID := sql.NullInt64{Int64: t.SessionID, Valid: t.SessionID != 0}
+	res, err := m.conn.ExecContext(ctx, query, t.UserID, t.OrganizationID, sessionID, t.TokenHash, t.ExpiresAt, t.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	t.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+	}
+	return
+}
+
+// GetRefreshToken looks the token up across every organization, as it is presented before the caller is known.
+func (m *mysqlRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	query := `SELECT id,user_id,organization_id,session_id,token_hash,expires_at,revoked_at,created_at FROM refresh_tokens WHERE token_hash = ?`
+	t := domain.RefreshToken{}
+	sessionID, revokedAt := sql.NullInt64{}, sql.NullInt64{}
+	err := m.conn.QueryRowContext(ctx, query, tokenHash).
+		Scan(&t.ID, &t.UserID, &t.OrganizationID, &sessionID, &t.TokenHash, &t.ExpiresAt, &revokedAt, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	t.SessionID = sessionID.Int64
+	t.RevokedAt = revokedAt.Int64
+	return &t, nil
+}
+
+// RevokeRefreshToken revokes a single token. It returns ErrNotFound when the token was already revoked,
+// so two concurrent refreshes with the same token cannot both succeed.
+func (m *mysqlRepository) RevokeRefreshToken(ctx context.Context, id int64, revokedAt int64) error {
+	query := `UPDATE refresh_tokens SET revoked_at=? WHERE id = ? AND organization_id = ? AND revoked_at IS NULL`
+	res, err := m.conn.ExecContext(ctx, query, revokedAt, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// RevokeUserTokens revokes every refresh token and every session of the user, signing it out everywhere
+func (m *mysqlRepository) RevokeUserTokens(ctx context.Context, userID int64, revokedAt int64) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	for _, query := range []string{
+		`UPDATE refresh_tokens SET revoked_at=? WHERE user_id = ? AND organization_id = ? AND revoked_at IS NULL`,
+		`UPDATE sessions SET revoked_at=? WHERE user_id = ? AND organization_id = ? AND revoked_at IS NULL`,
+	} {
+		if _, err = tx.ExecContext(ctx, query, revokedAt, userID, domain.OrganizationIDFromContext(ctx)); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,67 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	repository "github.com/meroedu/meroedu/internal/auth/repository/mysql"
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+func TestGetRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "user_id", "organization_id", "session_id", "token_hash", "expires_at", "revoked_at", "created_at"}).
+		AddRow(1, 4, 2, 7, "abc", 200, nil, 100)
+	mock.ExpectQuery("SELECT (.+) FROM refresh_tokens WHERE token_hash = \\?").WithArgs("abc").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM refresh_tokens WHERE token_hash = \\?").WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	r := repository.Init(db)
+	token, err := r.GetRefreshToken(context.TODO(), "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), token.UserID)
+	assert.Equal(t, int64(2), token.OrganizationID)
+	assert.Equal(t, int64(7), token.SessionID)
+	assert.Equal(t, int64(0), token.RevokedAt)
+
+	_, err = r.GetRefreshToken(context.TODO(), "missing")
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestRevokeRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := "UPDATE refresh_tokens SET revoked_at=\\? WHERE id = \\? AND organization_id = \\? AND revoked_at IS NULL"
+	mock.ExpectExec(query).WithArgs(int64(300), int64(1), int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(int64(300), int64(1), int64(2)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	r := repository.Init(db)
+	ctx := domain.WithOrganizationID(context.TODO(), 2)
+	assert.NoError(t, r.RevokeRefreshToken(ctx, 1, 300))
+	assert.Equal(t, domain.ErrNotFound, r.RevokeRefreshToken(ctx, 1, 300))
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE refresh_tokens SET revoked_at=\\? WHERE user_id = \\? AND organization_id = \\? AND revoked_at IS NULL").
+		WithArgs(int64(300), int64(4), int64(2)).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("UPDATE sessions SET revoked_at=\\? WHERE user_id = \\? AND organization_id = \\? AND revoked_at IS NULL").
+		WithArgs(int64(300), int64(4), int64(2)).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	r := repository.Init(db)
+	assert.NoError(t, r.RevokeUserTokens(domain.WithOrganizationID(context.TODO(), 2), 4, 300))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
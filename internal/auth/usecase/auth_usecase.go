@@ -0,0 +1,478 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/password"
+)
+
+// challengeTTL is the time a user has for the second step of a login
+const challengeTTL = 5 * time.Minute
+
+// challengePurpose marks the tokens of a two-factor login challenge, which are not access tokens
+const challengePurpose = "2fa"
+
+// maxDeviceLength is the length of the sessions.device column the user agent is cut to
+const maxDeviceLength = 255
+
+// AuthUseCase ...
+type AuthUseCase struct {
+	authRepo        domain.AuthRepository
+	userRepo        domain.UserRepository
+	roleRepo        domain.RoleRepository
+	twoFactorRepo   domain.TwoFactorRepository
+	orgRepo         domain.OrganizationRepository
+	sessionRepo     domain.SessionRepository
+	secret          []byte
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	maxFailedLogins int
+	lockoutDuration time.Duration
+	contextTimeOut  time.Duration
+}
+
+// NewAuthUseCase will create new an AuthUseCase. Access tokens are signed with secret using HS256. After
+// maxFailedLogins wrong passwords or two-factor codes in a row the account is locked for lockoutDuration;
+// 0 disables the lockout. Every login opens a session, kept by the refresh tokens rotated from it.
+func NewAuthUseCase(a domain.AuthRepository, u domain.UserRepository, r domain.RoleRepository, t domain.TwoFactorRepository,
+	o domain.OrganizationRepository, s domain.SessionRepository, secret string, accessTokenTTL time.Duration, refreshTokenTTL time.Duration, maxFailedLogins int,
+	lockoutDuration time.Duration, timeout time.Duration) domain.AuthUseCase {
+	return &AuthUseCase{
+		authRepo:        a,
+		userRepo:        u,
+		roleRepo:        r,
+		twoFactorRepo:   t,
+		orgRepo:         o,
+		sessionRepo:     s,
+		secret:          []byte(secret),
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+		maxFailedLogins: maxFailedLogins,
+		lockoutDuration: lockoutDuration,
+		contextTimeOut:  timeout,
+	}
+}
+
+// claims of the access token. The subject is the ID of the user.
+type claims struct {
+	OrganizationID int64 `json:"org"`
+	// SessionID is 0 for the tokens issued before sessions were tracked
+	SessionID int64 `json:"sid,omitempty"`
+	// Purpose is empty for access tokens
+	Purpose string `json:"purpose,omitempty"`
+	jwt.StandardClaims
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// issue signs a new access token and stores a new refresh token for the user. Without a session, a new one
+// is opened for the client of the request; otherwise the session is extended to the new refresh token.
+func (usecase *AuthUseCase) issue(ctx context.Context, user *domain.User, sessionID int64) (*domain.TokenPair, error) {
+	now := time.Now()
+	expiresAt := now.Add(usecase.refreshTokenTTL).Unix()
+	if sessionID == 0 {
+		client := domain.ClientFromContext(ctx)
+		device := client.UserAgent
+		if len(device) > maxDeviceLength {
+			device = device[:maxDeviceLength]
+		}
+		session := &domain.Session{
+			UserID:         user.ID,
+			OrganizationID: user.OrganizationID,
+			Device:         device,
+			IP:             client.IP,
+			LastSeenAt:     now.Unix(),
+			ExpiresAt:      expiresAt,
+			CreatedAt:      now.Unix(),
+		}
+		if err := usecase.sessionRepo.CreateSession(ctx, session); err != nil {
+			return nil, err
+		}
+		sessionID = session.ID
+	} else if err := usecase.sessionRepo.ExtendSession(ctx, sessionID, expiresAt); err != nil {
+		return nil, err
+	}
+
+	accessClaims := claims{
+		OrganizationID: user.OrganizationID,
+		SessionID:      sessionID,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   strconv.FormatInt(user.ID, 10),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(usecase.accessTokenTTL).Unix(),
+		},
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims).SignedString(usecase.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 32)
+	if _, err = rand.Read(raw); err != nil {
+		return nil, err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(raw)
+	err = usecase.authRepo.CreateRefreshToken(ctx, &domain.RefreshToken{
+		UserID:         user.ID,
+		OrganizationID: user.OrganizationID,
+		SessionID:      sessionID,
+		TokenHash:      hashToken(refreshToken),
+		ExpiresAt:      expiresAt,
+		CreatedAt:      now.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(usecase.accessTokenTTL.Seconds()),
+	}, nil
+}
+
+// Login checks the password of the user identified by email or username and completes the login.
+// Wrong passwords are counted and lock the account when they reach the limit; a locked account gives
+// ErrAccountLocked even with the right password.
+func (usecase *AuthUseCase) Login(c context.Context, login string, plain string) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+
+	var user *domain.User
+	var err error
+	if strings.Contains(login, "@") {
+		user, err = usecase.userRepo.GetByEmail(ctx, login)
+	} else {
+		user, err = usecase.userRepo.GetByUsername(ctx, login)
+	}
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != domain.UserActive {
+		return nil, domain.ErrInvalidCredentials
+	}
+	now := time.Now()
+	if user.LockedUntil > now.Unix() {
+		return nil, domain.ErrAccountLocked
+	}
+	ctx = domain.WithOrganizationID(ctx, user.OrganizationID)
+	hash, err := usecase.userRepo.GetPassword(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if hash == "" || !password.Compare(hash, plain) {
+		if hash != "" {
+			if err = usecase.recordFailure(ctx, user); err != nil {
+				return nil, err
+			}
+		}
+		return nil, domain.ErrInvalidCredentials
+	}
+	return usecase.completeLogin(ctx, user)
+}
+
+// CompleteLogin finishes the login of a user whose password was checked. It issues a token pair, or a
+// challenge when the user has to pass a second factor first.
+func (usecase *AuthUseCase) CompleteLogin(c context.Context, user *domain.User) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if user.Status != domain.UserActive {
+		return nil, domain.ErrInvalidCredentials
+	}
+	return usecase.completeLogin(domain.WithOrganizationID(ctx, user.OrganizationID), user)
+}
+
+func (usecase *AuthUseCase) completeLogin(ctx context.Context, user *domain.User) (*domain.TokenPair, error) {
+	challenge, err := usecase.challenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != "" {
+		// the failures are only reset once the second factor is passed, so they keep counting wrong codes
+		now := time.Now()
+		challengeClaims := claims{
+			OrganizationID: user.OrganizationID,
+			Purpose:        challengePurpose,
+			StandardClaims: jwt.StandardClaims{
+				Subject:   strconv.FormatInt(user.ID, 10),
+				IssuedAt:  now.Unix(),
+				ExpiresAt: now.Add(challengeTTL).Unix(),
+			},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, challengeClaims).SignedString(usecase.secret)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.TokenPair{Challenge: challenge, ChallengeToken: token, ExpiresIn: int64(challengeTTL.Seconds())}, nil
+	}
+	if user.FailedLogins > 0 || user.LockedUntil != 0 {
+		if err = usecase.userRepo.ResetLoginFailures(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+	return usecase.issue(ctx, user, 0)
+}
+
+// challenge returns the second step the user has to pass, if any
+func (usecase *AuthUseCase) challenge(ctx context.Context, user *domain.User) (string, error) {
+	twoFactor, err := usecase.twoFactorRepo.Get(ctx, user.ID)
+	if err == nil && twoFactor.EnabledAt != 0 {
+		return domain.ChallengeTOTP, nil
+	}
+	if err != nil && err != domain.ErrNotFound {
+		return "", err
+	}
+	org, err := usecase.orgRepo.GetByID(ctx, user.OrganizationID)
+	if err != nil {
+		return "", err
+	}
+	if org.RequireTwoFactor {
+		return domain.ChallengeTOTPEnrollment, nil
+	}
+	return "", nil
+}
+
+// ParseChallenge validates the token of a login challenge and returns the user it was issued to.
+// A locked account gives ErrAccountLocked.
+func (usecase *AuthUseCase) ParseChallenge(c context.Context, challengeToken string) (*domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	userID, tokenClaims, err := usecase.parse(challengeToken, challengePurpose)
+	if err != nil {
+		return nil, err
+	}
+	user, err := usecase.userRepo.GetByID(domain.WithOrganizationID(ctx, tokenClaims.OrganizationID), userID)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != domain.UserActive {
+		return nil, domain.ErrUnauthorized
+	}
+	if user.LockedUntil > time.Now().Unix() {
+		return nil, domain.ErrAccountLocked
+	}
+	return user, nil
+}
+
+// RecordLoginFailure counts a failed login of the user, locking the account when the failures reach the limit
+func (usecase *AuthUseCase) RecordLoginFailure(c context.Context, user *domain.User) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.recordFailure(domain.WithOrganizationID(ctx, user.OrganizationID), user)
+}
+
+func (usecase *AuthUseCase) recordFailure(ctx context.Context, user *domain.User) error {
+	if usecase.maxFailedLogins <= 0 {
+		return nil
+	}
+	lockedUntil := time.Now().Add(usecase.lockoutDuration).Unix()
+	return usecase.userRepo.RecordLoginFailure(ctx, user.ID, usecase.maxFailedLogins, lockedUntil)
+}
+
+// Refresh rotates the refresh token: the given token is revoked and a new pair is issued in the same session.
+// Presenting a token that was already revoked revokes every token of the user, and the token of a revoked
+// session is rejected.
+func (usecase *AuthUseCase) Refresh(c context.Context, refreshToken string) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+
+	now := time.Now().Unix()
+	token, err := usecase.authRepo.GetRefreshToken(ctx, hashToken(refreshToken))
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	ctx = domain.WithOrganizationID(ctx, token.OrganizationID)
+	if token.RevokedAt != 0 {
+		if err = usecase.authRepo.RevokeUserTokens(ctx, token.UserID, now); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrUnauthorized
+	}
+	if token.ExpiresAt <= now {
+		return nil, domain.ErrUnauthorized
+	}
+	if token.SessionID != 0 {
+		session, err := usecase.sessionRepo.GetByID(ctx, token.SessionID)
+		if err == domain.ErrNotFound {
+			return nil, domain.ErrUnauthorized
+		}
+		if err != nil {
+			return nil, err
+		}
+		if session.RevokedAt != 0 {
+			return nil, domain.ErrUnauthorized
+		}
+	}
+	err = usecase.authRepo.RevokeRefreshToken(ctx, token.ID, now)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	user, err := usecase.userRepo.GetByID(ctx, token.UserID)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != domain.UserActive {
+		return nil, domain.ErrUnauthorized
+	}
+	return usecase.issue(ctx, user, token.SessionID)
+}
+
+// Logout revokes the given refresh token and its session. Revoking an already revoked token is not an error.
+func (usecase *AuthUseCase) Logout(c context.Context, refreshToken string) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+
+	token, err := usecase.authRepo.GetRefreshToken(ctx, hashToken(refreshToken))
+	if err == domain.ErrNotFound {
+		return domain.ErrUnauthorized
+	}
+	if err != nil {
+		return err
+	}
+	ctx = domain.WithOrganizationID(ctx, token.OrganizationID)
+	now := time.Now().Unix()
+	if token.SessionID != 0 {
+		err = usecase.sessionRepo.RevokeSession(ctx, token.SessionID, now)
+		if err == domain.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+	err = usecase.authRepo.RevokeRefreshToken(ctx, token.ID, now)
+	if err == domain.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// IssueTokens issues a token pair for a user that was authenticated by other means, such as single sign-on
+func (usecase *AuthUseCase) IssueTokens(c context.Context, user *domain.User) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if user.Status != domain.UserActive {
+		return nil, domain.ErrInvalidCredentials
+	}
+	return usecase.issue(domain.WithOrganizationID(ctx, user.OrganizationID), user, 0)
+}
+
+// RevokeAll revokes every refresh token of the user
+func (usecase *AuthUseCase) RevokeAll(c context.Context, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.authRepo.RevokeUserTokens(ctx, userID, time.Now().Unix())
+}
+
+// ChangePassword replaces the password of the user after checking the current one, and signs out every session
+func (usecase *AuthUseCase) ChangePassword(c context.Context, userID int64, change *domain.PasswordChange) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+
+	hash, err := usecase.userRepo.GetPassword(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if hash == "" || !password.Compare(hash, change.CurrentPassword) {
+		return domain.ErrInvalidCredentials
+	}
+	if hash, err = password.Hash(change.NewPassword); err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	if err = usecase.userRepo.UpdatePassword(ctx, userID, hash, now); err != nil {
+		return err
+	}
+	return usecase.authRepo.RevokeUserTokens(ctx, userID, now)
+}
+
+// Authenticate validates the access token and returns the caller it was issued to, with the
+// organization and the permissions of the caller's role. Tokens of users deactivated since, and of
+// sessions revoked since, are rejected.
+func (usecase *AuthUseCase) Authenticate(c context.Context, accessToken string) (*domain.Principal, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+
+	userID, tokenClaims, err := usecase.parse(accessToken, "")
+	if err != nil {
+		return nil, err
+	}
+	ctx = domain.WithOrganizationID(ctx, tokenClaims.OrganizationID)
+	user, err := usecase.userRepo.GetByID(ctx, userID)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != domain.UserActive {
+		return nil, domain.ErrUnauthorized
+	}
+	if tokenClaims.SessionID != 0 {
+		session, err := usecase.sessionRepo.GetByID(ctx, tokenClaims.SessionID)
+		if err == domain.ErrNotFound {
+			return nil, domain.ErrUnauthorized
+		}
+		if err != nil {
+			return nil, err
+		}
+		if session.RevokedAt != 0 || session.UserID != userID {
+			return nil, domain.ErrUnauthorized
+		}
+	}
+	permissions, err := usecase.roleRepo.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Principal{
+		UserID:         userID,
+		SessionID:      tokenClaims.SessionID,
+		OrganizationID: user.OrganizationID,
+		Permissions:    permissions,
+	}, nil
+}
+
+// parse validates a signed token of the purpose and returns the user it was issued to with its claims
+func (usecase *AuthUseCase) parse(token string, purpose string) (int64, *claims, error) {
+	tokenClaims := claims{}
+	_, err := jwt.ParseWithClaims(token, &tokenClaims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, domain.ErrUnauthorized
+		}
+		return usecase.secret, nil
+	})
+	if err != nil || tokenClaims.Purpose != purpose {
+		return 0, nil, domain.ErrUnauthorized
+	}
+	userID, err := strconv.ParseInt(tokenClaims.Subject, 10, 64)
+	if err != nil || userID == 0 || tokenClaims.OrganizationID == 0 {
+		return 0, nil, domain.ErrUnauthorized
+	}
+	return userID, &tokenClaims, nil
+}
@@ -0,0 +1,407 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	ucase "github.com/meroedu/meroedu/internal/auth/usecase"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	"github.com/meroedu/meroedu/pkg/password"
+)
+
+const secret = "test-secret"
+
+// withoutTwoFactor returns a two-factor repository without any authenticator
+func withoutTwoFactor() *mocks.TwoFactorRepository {
+	m := new(mocks.TwoFactorRepository)
+	m.On("Get", mock.Anything, mock.Anything).Return(nil, domain.ErrNotFound)
+	return m
+}
+
+// optionalTwoFactor returns an organization repository whose organizations do not require two-factor authentication
+func optionalTwoFactor() *mocks.OrganizationRepository {
+	m := new(mocks.OrganizationRepository)
+	m.On("GetByID", mock.Anything, mock.Anything).Return(&domain.Organization{ID: 2}, nil)
+	return m
+}
+
+// activeSessions returns a session repository opening session 7, which stays active, for user 4
+func activeSessions() *mocks.SessionRepository {
+	m := new(mocks.SessionRepository)
+	m.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.Session).ID = 7 }).Maybe()
+	m.On("ExtendSession", mock.Anything, int64(7), mock.AnythingOfType("int64")).Return(nil).Maybe()
+	m.On("GetByID", mock.Anything, int64(7)).Return(&domain.Session{ID: 7, UserID: 4, OrganizationID: 2}, nil).Maybe()
+	return m
+}
+
+func TestLogin(t *testing.T) {
+	hash, err := password.Hash("s3cret-pass")
+	assert.NoError(t, err)
+	user := &domain.User{ID: 4, Email: "dinesh@example.com", OrganizationID: 2, Status: domain.UserActive}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockUserRepo.On("GetPassword", mock.Anything, user.ID).Return(hash, nil).Once()
+		mockAuthRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(rt *domain.RefreshToken) bool {
+			return rt.UserID == user.ID && rt.OrganizationID == user.OrganizationID && len(rt.TokenHash) == 64
+		})).Return(nil).Once()
+		inOrganization := mock.MatchedBy(func(ctx context.Context) bool {
+			return domain.OrganizationIDFromContext(ctx) == user.OrganizationID
+		})
+		mockUserRepo.On("GetByID", inOrganization, user.ID).Return(user, nil).Once()
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockRoleRepo.On("GetUserPermissions", inOrganization, user.ID).Return([]domain.Permission{domain.PermCourseView}, nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, mockRoleRepo, withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		tokens, err := u.Login(context.TODO(), user.Email, "s3cret-pass")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.RefreshToken)
+		assert.Equal(t, int64(60), tokens.ExpiresIn)
+
+		principal, err := u.Authenticate(context.TODO(), tokens.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, principal.UserID)
+		assert.Equal(t, user.OrganizationID, principal.OrganizationID)
+		assert.Equal(t, []domain.Permission{domain.PermCourseView}, principal.Permissions)
+		mockAuthRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+		mockRoleRepo.AssertExpectations(t)
+	})
+	t.Run("wrong-password", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserRepo.On("GetByUsername", mock.Anything, "dinesh").Return(user, nil).Once()
+		mockUserRepo.On("GetPassword", mock.Anything, user.ID).Return(hash, nil).Once()
+		lockedUntil := time.Now().Add(15 * time.Minute).Unix()
+		mockUserRepo.On("RecordLoginFailure", mock.Anything, user.ID, 5, mock.MatchedBy(func(until int64) bool {
+			return until >= lockedUntil && until <= lockedUntil+2
+		})).Return(nil).Once()
+
+		u := ucase.NewAuthUseCase(new(mocks.AuthRepository), mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		_, err := u.Login(context.TODO(), "dinesh", "wrong-pass")
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+	t.Run("locked", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		locked := *user
+		locked.LockedUntil = time.Now().Add(time.Minute).Unix()
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(&locked, nil).Once()
+
+		u := ucase.NewAuthUseCase(new(mocks.AuthRepository), mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		_, err := u.Login(context.TODO(), user.Email, "s3cret-pass")
+		assert.Equal(t, domain.ErrAccountLocked, err)
+		mockUserRepo.AssertNotCalled(t, "GetPassword", mock.Anything, mock.Anything)
+	})
+	t.Run("lock-expired", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		unlocked := *user
+		unlocked.FailedLogins = 0
+		unlocked.LockedUntil = time.Now().Add(-time.Minute).Unix()
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(&unlocked, nil).Once()
+		mockUserRepo.On("GetPassword", mock.Anything, user.ID).Return(hash, nil).Once()
+		mockUserRepo.On("ResetLoginFailures", mock.Anything, user.ID).Return(nil).Once()
+		mockAuthRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		_, err := u.Login(context.TODO(), user.Email, "s3cret-pass")
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+	t.Run("inactive", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(&domain.User{ID: 4, Status: domain.UserInactive}, nil).Once()
+
+		u := ucase.NewAuthUseCase(new(mocks.AuthRepository), mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		_, err := u.Login(context.TODO(), user.Email, "s3cret-pass")
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+		mockUserRepo.AssertNotCalled(t, "GetPassword", mock.Anything, mock.Anything)
+	})
+	t.Run("unknown-user", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserRepo.On("GetByEmail", mock.Anything, "nobody@example.com").Return(nil, domain.ErrNotFound).Once()
+
+		u := ucase.NewAuthUseCase(new(mocks.AuthRepository), mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		_, err := u.Login(context.TODO(), "nobody@example.com", "s3cret-pass")
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+	})
+}
+
+func TestLoginChallenge(t *testing.T) {
+	hash, err := password.Hash("s3cret-pass")
+	assert.NoError(t, err)
+	user := &domain.User{ID: 4, Email: "dinesh@example.com", OrganizationID: 2, Status: domain.UserActive, FailedLogins: 2}
+
+	t.Run("two-factor-enabled", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockUserRepo.On("GetPassword", mock.Anything, user.ID).Return(hash, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockTwoFactorRepo.On("Get", mock.Anything, user.ID).Return(&domain.TwoFactor{UserID: user.ID, EnabledAt: 100}, nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, new(mocks.RoleRepository), mockTwoFactorRepo, new(mocks.OrganizationRepository), activeSessions(), secret,
+			time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		tokens, err := u.Login(context.TODO(), user.Email, "s3cret-pass")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ChallengeTOTP, tokens.Challenge)
+		assert.Empty(t, tokens.AccessToken)
+		assert.Empty(t, tokens.RefreshToken)
+		mockAuthRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "ResetLoginFailures", mock.Anything, mock.Anything)
+
+		_, err = u.Authenticate(context.TODO(), tokens.ChallengeToken)
+		assert.Equal(t, domain.ErrUnauthorized, err, "a challenge is not an access token")
+		challenged, err := u.ParseChallenge(context.TODO(), tokens.ChallengeToken)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, challenged.ID)
+	})
+	t.Run("required-by-organization", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockUserRepo.On("GetPassword", mock.Anything, user.ID).Return(hash, nil).Once()
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		mockOrgRepo.On("GetByID", mock.Anything, user.OrganizationID).Return(&domain.Organization{ID: 2, RequireTwoFactor: true}, nil).Once()
+
+		u := ucase.NewAuthUseCase(new(mocks.AuthRepository), mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), mockOrgRepo, activeSessions(), secret,
+			time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		tokens, err := u.Login(context.TODO(), user.Email, "s3cret-pass")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ChallengeTOTPEnrollment, tokens.Challenge)
+		assert.NotEmpty(t, tokens.ChallengeToken)
+	})
+	t.Run("access-token-is-no-challenge", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockAuthRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil).Once()
+		u := ucase.NewAuthUseCase(mockAuthRepo, new(mocks.UserRepository), new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret,
+			time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		tokens, err := u.IssueTokens(context.TODO(), user)
+		assert.NoError(t, err)
+		_, err = u.ParseChallenge(context.TODO(), tokens.AccessToken)
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+}
+
+func TestIssueTokens(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockAuthRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(rt *domain.RefreshToken) bool {
+			return rt.UserID == 4 && rt.OrganizationID == 2
+		})).Return(nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, new(mocks.UserRepository), new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		tokens, err := u.IssueTokens(context.TODO(), &domain.User{ID: 4, OrganizationID: 2, Status: domain.UserActive})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+		mockAuthRepo.AssertExpectations(t)
+	})
+	t.Run("inactive", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, new(mocks.UserRepository), new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		_, err := u.IssueTokens(context.TODO(), &domain.User{ID: 4, OrganizationID: 2, Status: domain.UserInactive})
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+		mockAuthRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	now := time.Now().Unix()
+	t.Run("rotates", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthRepo.On("GetRefreshToken", mock.Anything, mock.AnythingOfType("string")).
+			Return(&domain.RefreshToken{ID: 9, UserID: 4, OrganizationID: 2, ExpiresAt: now + 3600}, nil).Once()
+		mockAuthRepo.On("RevokeRefreshToken", mock.Anything, int64(9), mock.AnythingOfType("int64")).Return(nil).Once()
+		mockUserRepo.On("GetByID", mock.MatchedBy(func(ctx context.Context) bool {
+			return domain.OrganizationIDFromContext(ctx) == 2
+		}), int64(4)).Return(&domain.User{ID: 4, OrganizationID: 2, Status: domain.UserActive}, nil).Once()
+		mockAuthRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		tokens, err := u.Refresh(context.TODO(), "old-token")
+		assert.NoError(t, err)
+		assert.NotEqual(t, "old-token", tokens.RefreshToken)
+		mockAuthRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+	t.Run("reuse-revokes-all", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockAuthRepo.On("GetRefreshToken", mock.Anything, mock.AnythingOfType("string")).
+			Return(&domain.RefreshToken{ID: 9, UserID: 4, ExpiresAt: now + 3600, RevokedAt: now - 10}, nil).Once()
+		mockAuthRepo.On("RevokeUserTokens", mock.Anything, int64(4), mock.AnythingOfType("int64")).Return(nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, new(mocks.UserRepository), new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		_, err := u.Refresh(context.TODO(), "old-token")
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockAuthRepo.AssertExpectations(t)
+	})
+	t.Run("expired", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockAuthRepo.On("GetRefreshToken", mock.Anything, mock.AnythingOfType("string")).
+			Return(&domain.RefreshToken{ID: 9, UserID: 4, ExpiresAt: now - 1}, nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, new(mocks.UserRepository), new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		_, err := u.Refresh(context.TODO(), "old-token")
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockAuthRepo.AssertNotCalled(t, "RevokeRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockAuthRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+	mockUserRepo := new(mocks.UserRepository)
+	hash, _ := password.Hash("s3cret-pass")
+	mockUserRepo.On("GetByUsername", mock.Anything, "dinesh").Return(&domain.User{ID: 4, OrganizationID: 2, Status: domain.UserActive}, nil)
+	mockUserRepo.On("GetPassword", mock.Anything, int64(4)).Return(hash, nil)
+
+	expired := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, -time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+	tokens, err := expired.Login(context.TODO(), "dinesh", "s3cret-pass")
+	assert.NoError(t, err)
+	_, err = expired.Authenticate(context.TODO(), tokens.AccessToken)
+	assert.Equal(t, domain.ErrUnauthorized, err)
+
+	other := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), "other-secret", time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+	tokens, err = other.Login(context.TODO(), "dinesh", "s3cret-pass")
+	assert.NoError(t, err)
+	u := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+	_, err = u.Authenticate(context.TODO(), tokens.AccessToken)
+	assert.Equal(t, domain.ErrUnauthorized, err)
+
+	_, err = u.Authenticate(context.TODO(), "not-a-token")
+	assert.Equal(t, domain.ErrUnauthorized, err)
+
+	tokens, err = u.Login(context.TODO(), "dinesh", "s3cret-pass")
+	assert.NoError(t, err)
+	mockUserRepo.On("GetByID", mock.Anything, int64(4)).Return(&domain.User{ID: 4, OrganizationID: 2, Status: domain.UserInactive}, nil).Once()
+	_, err = u.Authenticate(context.TODO(), tokens.AccessToken)
+	assert.Equal(t, domain.ErrUnauthorized, err)
+}
+
+func TestChangePassword(t *testing.T) {
+	hash, _ := password.Hash("s3cret-pass")
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	mockUserRepo.On("GetPassword", mock.Anything, int64(4)).Return(hash, nil)
+	mockUserRepo.On("UpdatePassword", mock.Anything, int64(4), mock.AnythingOfType("string"), mock.AnythingOfType("int64")).Return(nil).Once()
+	mockAuthRepo.On("RevokeUserTokens", mock.Anything, int64(4), mock.AnythingOfType("int64")).Return(nil).Once()
+
+	u := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), activeSessions(), secret, time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+	err := u.ChangePassword(context.TODO(), 4, &domain.PasswordChange{CurrentPassword: "wrong-pass", NewPassword: "n3w-password"})
+	assert.Equal(t, domain.ErrInvalidCredentials, err)
+
+	err = u.ChangePassword(context.TODO(), 4, &domain.PasswordChange{CurrentPassword: "s3cret-pass", NewPassword: "n3w-password"})
+	assert.NoError(t, err)
+	mockAuthRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestSessions(t *testing.T) {
+	now := time.Now().Unix()
+	hash, _ := password.Hash("s3cret-pass")
+	user := &domain.User{ID: 4, OrganizationID: 2, Status: domain.UserActive}
+
+	t.Run("login-opens-session", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockAuthRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(rt *domain.RefreshToken) bool {
+			return rt.SessionID == 7
+		})).Return(nil).Once()
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserRepo.On("GetByUsername", mock.Anything, "dinesh").Return(user, nil)
+		mockUserRepo.On("GetPassword", mock.Anything, int64(4)).Return(hash, nil)
+		mockUserRepo.On("GetByID", mock.Anything, int64(4)).Return(user, nil)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockRoleRepo.On("GetUserPermissions", mock.Anything, int64(4)).Return([]domain.Permission{domain.PermCourseView}, nil)
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionRepo.On("CreateSession", mock.Anything, mock.MatchedBy(func(s *domain.Session) bool {
+			return s.UserID == 4 && s.OrganizationID == 2 && s.Device == "Firefox" && s.IP == "10.0.0.1" && s.ExpiresAt > now
+		})).Return(nil).Run(func(args mock.Arguments) { args.Get(1).(*domain.Session).ID = 7 }).Once()
+		mockSessionRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Session{ID: 7, UserID: 4}, nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, mockRoleRepo, withoutTwoFactor(), optionalTwoFactor(), mockSessionRepo, secret,
+			time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		ctx := domain.WithClient(context.TODO(), domain.Client{IP: "10.0.0.1", UserAgent: "Firefox"})
+		tokens, err := u.Login(ctx, "dinesh", "s3cret-pass")
+		assert.NoError(t, err)
+		principal, err := u.Authenticate(context.TODO(), tokens.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), principal.SessionID)
+		mockAuthRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+	t.Run("revoked-session-rejects-access-token", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockAuthRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil).Once()
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserRepo.On("GetByID", mock.Anything, int64(4)).Return(user, nil)
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil).
+			Run(func(args mock.Arguments) { args.Get(1).(*domain.Session).ID = 7 }).Once()
+		mockSessionRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Session{ID: 7, UserID: 4, RevokedAt: now}, nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), mockSessionRepo, secret,
+			time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		tokens, err := u.IssueTokens(context.TODO(), user)
+		assert.NoError(t, err)
+		_, err = u.Authenticate(context.TODO(), tokens.AccessToken)
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+	t.Run("refresh-extends-session", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockAuthRepo.On("GetRefreshToken", mock.Anything, mock.AnythingOfType("string")).
+			Return(&domain.RefreshToken{ID: 9, UserID: 4, OrganizationID: 2, SessionID: 7, ExpiresAt: now + 3600}, nil).Once()
+		mockAuthRepo.On("RevokeRefreshToken", mock.Anything, int64(9), mock.AnythingOfType("int64")).Return(nil).Once()
+		mockAuthRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(rt *domain.RefreshToken) bool {
+			return rt.SessionID == 7
+		})).Return(nil).Once()
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserRepo.On("GetByID", mock.Anything, int64(4)).Return(user, nil).Once()
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Session{ID: 7, UserID: 4}, nil).Once()
+		mockSessionRepo.On("ExtendSession", mock.Anything, int64(7), mock.AnythingOfType("int64")).Return(nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, mockUserRepo, new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), mockSessionRepo, secret,
+			time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		_, err := u.Refresh(context.TODO(), "old-token")
+		assert.NoError(t, err)
+		mockAuthRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+	t.Run("refresh-in-revoked-session", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockAuthRepo.On("GetRefreshToken", mock.Anything, mock.AnythingOfType("string")).
+			Return(&domain.RefreshToken{ID: 9, UserID: 4, OrganizationID: 2, SessionID: 7, ExpiresAt: now + 3600}, nil).Once()
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Session{ID: 7, UserID: 4, RevokedAt: now}, nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, new(mocks.UserRepository), new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), mockSessionRepo, secret,
+			time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		_, err := u.Refresh(context.TODO(), "old-token")
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockAuthRepo.AssertNotCalled(t, "RevokeRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("logout-revokes-session", func(t *testing.T) {
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockAuthRepo.On("GetRefreshToken", mock.Anything, mock.AnythingOfType("string")).
+			Return(&domain.RefreshToken{ID: 9, UserID: 4, OrganizationID: 2, SessionID: 7, ExpiresAt: now + 3600}, nil).Once()
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionRepo.On("RevokeSession", mock.Anything, int64(7), mock.AnythingOfType("int64")).Return(nil).Once()
+
+		u := ucase.NewAuthUseCase(mockAuthRepo, new(mocks.UserRepository), new(mocks.RoleRepository), withoutTwoFactor(), optionalTwoFactor(), mockSessionRepo, secret,
+			time.Minute, time.Hour, 5, 15*time.Minute, time.Second*2)
+		err := u.Logout(context.TODO(), "refresh-token")
+		assert.NoError(t, err)
+		mockSessionRepo.AssertExpectations(t)
+		mockAuthRepo.AssertNotCalled(t, "RevokeRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
 	"github.com/meroedu/meroedu/internal/util"
 )
 
@@ -27,19 +28,19 @@ func NewCategoryHandler(e *echo.Echo, us domain.CategoryUseCase) {
 		CategoryUseCase: us,
 	}
 	// Get Operation
-	e.GET("/categories", handler.GetAll)
-	e.GET("/categories/:id", handler.GetByID)
-	e.GET("/categories/:id/", handler.GetByID)
+	e.GET("/categories", handler.GetAll, rbac.Require(domain.PermCourseView))
+	e.GET("/categories/:id", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.GET("/categories/:id/", handler.GetByID, rbac.Require(domain.PermCourseView))
 
 	// Create/Add Operation
-	e.POST("/categories", handler.CreateCategory)
+	e.POST("/categories", handler.CreateCategory, rbac.Require(domain.PermCategoryManage))
 
 	// Update Operation
-	e.PUT("/categories/:id", handler.UpdateCategory)
-	e.PUT("/categories/actions", handler.GetByID)
+	e.PUT("/categories/:id", handler.UpdateCategory, rbac.Require(domain.PermCategoryManage))
+	e.PUT("/categories/actions", handler.BulkAction, rbac.Require(domain.PermCategoryManage))
 
 	// Remove/Delete Operation
-	e.DELETE("/categories/:id", handler.DeleteCategory)
+	e.DELETE("/categories/:id", handler.DeleteCategory, rbac.Require(domain.PermCategoryManage))
 }
 
 // GetAll godoc
@@ -200,3 +201,36 @@ func (c *CategoryHandler) DeleteCategory(echoContext echo.Context) error {
 
 	return echoContext.NoContent(http.StatusNoContent)
 }
+
+// BulkAction godoc
+// @Summary Run an action on many categories.
+// @Description Run delete on a list of category IDs in a single transaction. The outcome is reported for each ID.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param action body domain.BulkAction true "Action and IDs"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Unsupported action or missing parameter"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /categories/actions [put]
+func (c *CategoryHandler) BulkAction(echoContext echo.Context) error {
+	var action domain.BulkAction
+	err := echoContext.Bind(&action)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&action); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	results, err := c.CategoryUseCase.BulkAction(ctx, &action)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    results,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
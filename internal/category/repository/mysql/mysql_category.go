@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/repository/sqlbulk"
 	"github.com/meroedu/meroedu/pkg/log"
 )
 
@@ -54,18 +55,18 @@ func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...inter
 }
 
 func (m *mysqlRepository) GetAll(ctx context.Context, start int, limit int) (res []domain.Category, err error) {
-	query := `SELECT id,name,updated_at,created_at FROM categories ORDER BY created_at DESC LIMIT ?,?`
+	query := `SELECT id,name,updated_at,created_at FROM categories WHERE organization_id = ? ORDER BY created_at DESC LIMIT ?,?`
 
-	res, err = m.fetch(ctx, query, start, limit)
+	res, err = m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (res *domain.Category, err error) {
-	query := `SELECT id,name,updated_at,created_at FROM categories WHERE ID = ?`
+	query := `SELECT id,name,updated_at,created_at FROM categories WHERE ID = ? AND organization_id = ?`
 
-	list, err := m.fetch(ctx, query, id)
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -79,8 +80,8 @@ func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (res *domain.Ca
 	return &category, nil
 }
 func (m *mysqlRepository) GetByName(ctx context.Context, name string) (res *domain.Category, err error) {
-	query := `SELECT id,name,updated_at,created_at FROM categories WHERE name = ?`
-	list, err := m.fetch(ctx, query, name)
+	query := `SELECT id,name,updated_at,created_at FROM categories WHERE name = ? AND organization_id = ?`
+	list, err := m.fetch(ctx, query, name, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -95,13 +96,13 @@ func (m *mysqlRepository) GetByName(ctx context.Context, name string) (res *doma
 }
 
 func (m *mysqlRepository) CreateCategory(ctx context.Context, a *domain.Category) (err error) {
-	query := `INSERT categories SET name=?,updated_at=?,created_at=?`
+	query := `INSERT categories SET name=?,organization_id=?,updated_at=?,created_at=?`
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		log.Error("Error while preparing statement ", err)
 		return
 	}
-	res, err := stmt.ExecContext(ctx, a.Name, a.UpdatedAt, a.CreatedAt)
+	res, err := stmt.ExecContext(ctx, a.Name, domain.OrganizationIDFromContext(ctx), a.UpdatedAt, a.CreatedAt)
 	if err != nil {
 		log.Error("Error while executing statement ", err)
 		return
@@ -116,14 +117,14 @@ func (m *mysqlRepository) CreateCategory(ctx context.Context, a *domain.Category
 }
 
 func (m *mysqlRepository) DeleteCategory(ctx context.Context, id int64) (err error) {
-	query := "DELETE FROM categories WHERE id = ?"
+	query := "DELETE FROM categories WHERE id = ? AND organization_id = ?"
 
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	res, err := stmt.ExecContext(ctx, id, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return
 	}
@@ -141,14 +142,14 @@ func (m *mysqlRepository) DeleteCategory(ctx context.Context, id int64) (err err
 	return
 }
 func (m *mysqlRepository) UpdateCategory(ctx context.Context, ar *domain.Category) (err error) {
-	query := `UPDATE categories set name=?,updated_at=? WHERE ID = ?`
+	query := `UPDATE categories set name=?,updated_at=? WHERE ID = ? AND organization_id = ?`
 
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, ar.Name, ar.UpdatedAt, ar.ID)
+	res, err := stmt.ExecContext(ctx, ar.Name, ar.UpdatedAt, ar.ID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return
 	}
@@ -163,3 +164,16 @@ func (m *mysqlRepository) UpdateCategory(ctx context.Context, ar *domain.Categor
 
 	return
 }
+
+// BulkDelete removes many categories in a single transaction. A category still holding courses, trashed ones
+// included, is refused since deleting it would delete its courses.
+func (m *mysqlRepository) BulkDelete(ctx context.Context, ids []int64) ([]domain.BulkResult, error) {
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	return sqlbulk.Run(ctx, m.conn, ids, func(tx *sql.Tx, id int64) error {
+		query := `SELECT 1 FROM courses c JOIN categories g ON g.id = c.category_id WHERE g.id = ? AND g.organization_id = ?`
+		if err := sqlbulk.Unused(ctx, tx, query, id, organizationID); err != nil {
+			return err
+		}
+		return sqlbulk.ExecItem(ctx, tx, "DELETE FROM categories WHERE id = ? AND organization_id = ?", id, organizationID)
+	})
+}
@@ -11,6 +11,8 @@ import (
 	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
+var orgCtx = domain.WithOrganizationID(context.TODO(), 1)
+
 func TestGetAll(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -24,11 +26,11 @@ func TestGetAll(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"}).
 		AddRow(mockCategories[0].ID, mockCategories[0].Name, mockCategories[0].UpdatedAt, mockCategories[0].CreatedAt)
 
-	query := `SELECT id,name,updated_at,created_at FROM categories ORDER BY created_at DESC LIMIT \?,\?`
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	query := `SELECT id,name,updated_at,created_at FROM categories WHERE organization_id = \? ORDER BY created_at DESC LIMIT \?,\?`
+	mock.ExpectQuery(query).WithArgs(int64(1), 0, 10).WillReturnRows(rows)
 	c := mysqlrepo.Init(db)
 	start, limit := 0, 10
-	list, err := c.GetAll(context.TODO(), start, limit)
+	list, err := c.GetAll(orgCtx, start, limit)
 	assert.NoError(t, err)
 	assert.Len(t, list, 1)
 
@@ -42,10 +44,10 @@ func TestGetByID(t *testing.T) {
 	row := sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"}).
 		AddRow("1", "testing-2", time.Now().Unix(), time.Now().Unix())
 
-	query := `SELECT id,name,updated_at,created_at FROM categories WHERE ID = \?`
-	mock.ExpectQuery(query).WillReturnRows(row)
+	query := `SELECT id,name,updated_at,created_at FROM categories WHERE ID = \? AND organization_id = \?`
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(1)).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	category, err := c.GetByID(context.TODO(), 1)
+	category, err := c.GetByID(orgCtx, 1)
 	assert.NoError(t, err)
 	assert.NotNil(t, category)
 }
@@ -58,10 +60,10 @@ func TestGetByName(t *testing.T) {
 	row := sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"}).
 		AddRow("1", "testing-2", time.Now().Unix(), time.Now().Unix())
 
-	query := `SELECT id,name,updated_at,created_at FROM categories WHERE name = \?`
-	mock.ExpectQuery(query).WillReturnRows(row)
+	query := `SELECT id,name,updated_at,created_at FROM categories WHERE name = \? AND organization_id = \?`
+	mock.ExpectQuery(query).WithArgs("testing-2", int64(1)).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	category, err := c.GetByName(context.TODO(), "testing-2")
+	category, err := c.GetByName(orgCtx, "testing-2")
 	assert.NoError(t, err)
 	assert.NotNil(t, category)
 }
@@ -76,12 +78,12 @@ func TestCreateCategory(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `INSERT categories SET name=\?,updated_at=\?,created_at=\?`
+	query := `INSERT categories SET name=\?,organization_id=\?,updated_at=\?,created_at=\?`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(c.Name, c.UpdatedAt, c.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(c.Name, int64(1), c.UpdatedAt, c.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.CreateCategory(context.TODO(), c)
+	err = repo.CreateCategory(orgCtx, c)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(12), c.ID)
 }
@@ -92,12 +94,12 @@ func TestDeleteCategory(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `DELETE FROM categories WHERE id = \?`
+	query := `DELETE FROM categories WHERE id = \? AND organization_id = \?`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(category_id).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(category_id, int64(1)).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.DeleteCategory(context.TODO(), int64(category_id))
+	err = repo.DeleteCategory(orgCtx, int64(category_id))
 	assert.NoError(t, err)
 }
 
@@ -112,11 +114,34 @@ func TestUpdateCategory(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `UPDATE categories set name=\?,updated_at=\? WHERE ID = \?`
+	query := `UPDATE categories set name=\?,updated_at=\? WHERE ID = \? AND organization_id = \?`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(c.Name, c.UpdatedAt, c.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(c.Name, c.UpdatedAt, c.ID, int64(1)).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.UpdateCategory(orgCtx, c)
+	assert.NoError(t, err)
+}
+
+func TestBulkDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	used := `SELECT EXISTS\(SELECT 1 FROM courses c JOIN categories g ON g.id = c.category_id WHERE g.id = \? AND g.organization_id = \?\)`
+	query := `DELETE FROM categories WHERE id = \? AND organization_id = \?`
+	mock.ExpectBegin()
+	mock.ExpectQuery(used).WithArgs(1, int64(1)).WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(true))
+	mock.ExpectQuery(used).WithArgs(2, int64(1)).WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(false))
+	mock.ExpectExec(query).WithArgs(2, int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(used).WithArgs(3, int64(1)).WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(false))
+	mock.ExpectExec(query).WithArgs(3, int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
 
 	repo := mysqlrepo.Init(db)
-	err = repo.UpdateCategory(context.TODO(), c)
+	results, err := repo.BulkDelete(orgCtx, []int64{1, 2, 3})
 	assert.NoError(t, err)
+	assert.Equal(t, []domain.BulkResult{{ID: 1, Error: domain.ErrConflict.Error()}, {ID: 2, Success: true},
+		{ID: 3, Error: domain.ErrNotFound.Error()}}, results, "a category still holding courses is kept")
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -107,3 +107,13 @@ func (usecase *CategoryUseCase) DeleteCategory(c context.Context, id int64) (err
 	}
 	return usecase.categoryRepo.DeleteCategory(ctx, id)
 }
+
+// BulkAction will run the same action on many categories at once. Only delete is supported.
+func (usecase *CategoryUseCase) BulkAction(c context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if action.Action != domain.BulkDelete {
+		return nil, domain.ErrBadParamInput
+	}
+	return usecase.categoryRepo.BulkDelete(ctx, action.IDs)
+}
@@ -210,3 +210,24 @@ func TestDeleteCategory(t *testing.T) {
 	})
 
 }
+
+func TestBulkAction(t *testing.T) {
+	mockCategoryRepo := new(mocks.CategoryRepository)
+	t.Run("delete", func(t *testing.T) {
+		mockCategoryRepo.On("BulkDelete", mock.Anything, []int64{1, 2}).Return([]domain.BulkResult{{ID: 1, Success: true}, {ID: 2, Success: true}}, nil).Once()
+		u := ucase.NewCategoryUseCase(mockCategoryRepo, time.Second*2)
+
+		results, err := u.BulkAction(context.TODO(), &domain.BulkAction{Action: domain.BulkDelete, IDs: []int64{1, 2}})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("unsupported-action", func(t *testing.T) {
+		u := ucase.NewCategoryUseCase(mockCategoryRepo, time.Second*2)
+
+		_, err := u.BulkAction(context.TODO(), &domain.BulkAction{Action: domain.BulkArchive, IDs: []int64{1}})
+
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+}
@@ -0,0 +1,170 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// CollaboratorHandler ...
+type CollaboratorHandler struct {
+	CollaboratorUseCase domain.CollaboratorUseCase
+}
+
+// NewCollaboratorHandler ...
+func NewCollaboratorHandler(e *echo.Echo, us domain.CollaboratorUseCase) {
+	handler := &CollaboratorHandler{
+		CollaboratorUseCase: us,
+	}
+	e.GET("/courses/:id/collaborators", handler.GetByCourse, rbac.Require(domain.PermCourseUpdate))
+	e.POST("/courses/:id/collaborators", handler.AddCollaborator, rbac.Require(domain.PermCourseUpdate))
+	e.PUT("/courses/:id/collaborators/:user_id", handler.UpdateCollaborator, rbac.Require(domain.PermCourseUpdate))
+	e.DELETE("/courses/:id/collaborators/:user_id", handler.RemoveCollaborator, rbac.Require(domain.PermCourseUpdate))
+}
+
+func collaboratorParams(echoContext echo.Context) (courseID int64, userID int64, err error) {
+	id, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return
+	}
+	uid, err := strconv.Atoi(echoContext.Param("user_id"))
+	return int64(id), int64(uid), err
+}
+
+// GetByCourse godoc
+// @Summary Get the collaborators of a course.
+// @Description Get the users working on a course besides its author, with their permission, by name.
+// @Tags courses
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/collaborators [get]
+func (c *CollaboratorHandler) GetByCourse(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	list, err := c.CollaboratorUseCase.GetByCourse(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: list, Message: domain.Success})
+}
+
+// AddCollaborator godoc
+// @Summary Add a collaborator to a course.
+// @Description Give a user access to a course as an editor, who edits the course, its lessons and contents,
+// @Description or as a co-author, who also publishes the course and manages its collaborators.
+// @Description Only the author, the co-authors and the course managers of the organization add collaborators.
+// @Tags courses
+// @Accept json
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param collaborator body domain.CourseCollaborator true "collaborator Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Unknown permission, or a user who can not update courses"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Already a collaborator or the author"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/collaborators [post]
+func (c *CollaboratorHandler) AddCollaborator(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var collaborator domain.CourseCollaborator
+	err = echoContext.Bind(&collaborator)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&collaborator); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	collaborator.CourseID = int64(idParam)
+	ctx := echoContext.Request().Context()
+	err = c.CollaboratorUseCase.AddCollaborator(ctx, &collaborator)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusCreated, domain.Response{Data: collaborator, Message: domain.Success})
+}
+
+// UpdateCollaborator godoc
+// @Summary Change the permission of a collaborator.
+// @Description Make a collaborator of a course an editor or a co-author.
+// @Tags courses
+// @Accept json
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param user_id path int true "User Id"
+// @Param permission body domain.CollaboratorUpdate true "permission"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/collaborators/{user_id} [put]
+func (c *CollaboratorHandler) UpdateCollaborator(echoContext echo.Context) error {
+	courseID, userID, err := collaboratorParams(echoContext)
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var update domain.CollaboratorUpdate
+	err = echoContext.Bind(&update)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&update); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.CollaboratorUseCase.UpdateCollaborator(ctx, courseID, userID, update.Permission)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// RemoveCollaborator godoc
+// @Summary Remove a collaborator from a course.
+// @Description Take the access to a course away from a collaborator. A collaborator can also leave a course.
+// @Tags courses
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param user_id path int true "User Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/collaborators/{user_id} [delete]
+func (c *CollaboratorHandler) RemoveCollaborator(echoContext echo.Context) error {
+	courseID, userID, err := collaboratorParams(echoContext)
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.CollaboratorUseCase.RemoveCollaborator(ctx, courseID, userID)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
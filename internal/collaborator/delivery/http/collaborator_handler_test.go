@@ -0,0 +1,154 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	collaboratorHTTP "github.com/meroedu/meroedu/internal/collaborator/delivery/http"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+)
+
+func TestGetByCourse(t *testing.T) {
+	mockUCase := new(mocks.CollaboratorUseCase)
+	mockUCase.On("GetByCourse", mock.Anything, int64(12)).Return([]domain.CourseCollaborator{{CourseID: 12, UserID: 3, Permission: domain.CollaboratorEditor}}, nil).Once()
+	mockUCase.On("GetByCourse", mock.Anything, int64(13)).Return(nil, domain.ErrForbidden).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"12", http.StatusOK},
+		{"13", http.StatusForbidden},
+		{"course", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/courses/"+tt.id+"/collaborators", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/courses/:id/collaborators")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := collaboratorHTTP.CollaboratorHandler{
+			CollaboratorUseCase: mockUCase,
+		}
+		err = handler.GetByCourse(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestAddCollaborator(t *testing.T) {
+	mockUCase := new(mocks.CollaboratorUseCase)
+	mockUCase.On("AddCollaborator", mock.Anything, &domain.CourseCollaborator{CourseID: 12, UserID: 3, Permission: domain.CollaboratorEditor}).Return(nil).Once()
+	mockUCase.On("AddCollaborator", mock.Anything, &domain.CourseCollaborator{CourseID: 12, UserID: 4, Permission: domain.CollaboratorEditor}).Return(domain.ErrConflict).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"user_id":3,"permission":"editor"}`, http.StatusCreated},
+		{`{"user_id":4,"permission":"editor"}`, http.StatusConflict},
+		{`{"user_id":"3"}`, http.StatusUnprocessableEntity},
+		{`{"user_id":3}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/courses/12/collaborators", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/courses/:id/collaborators")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := collaboratorHTTP.CollaboratorHandler{
+			CollaboratorUseCase: mockUCase,
+		}
+		err = handler.AddCollaborator(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdateCollaborator(t *testing.T) {
+	mockUCase := new(mocks.CollaboratorUseCase)
+	mockUCase.On("UpdateCollaborator", mock.Anything, int64(12), int64(3), domain.CollaboratorCoAuthor).Return(nil).Once()
+	mockUCase.On("UpdateCollaborator", mock.Anything, int64(12), int64(3), domain.CourseOwner).Return(domain.ErrBadParamInput).Once()
+
+	tests := []struct {
+		userID string
+		body   string
+		code   int
+	}{
+		{"3", `{"permission":"co-author"}`, http.StatusNoContent},
+		{"3", `{"permission":"owner"}`, http.StatusBadRequest},
+		{"3", `{}`, http.StatusBadRequest},
+		{"3", `{"permission":`, http.StatusUnprocessableEntity},
+		{"me", `{"permission":"co-author"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/courses/12/collaborators/"+tt.userID, strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/courses/:id/collaborators/:user_id")
+		c.SetParamNames("id", "user_id")
+		c.SetParamValues("12", tt.userID)
+		handler := collaboratorHTTP.CollaboratorHandler{
+			CollaboratorUseCase: mockUCase,
+		}
+		err = handler.UpdateCollaborator(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRemoveCollaborator(t *testing.T) {
+	mockUCase := new(mocks.CollaboratorUseCase)
+	mockUCase.On("RemoveCollaborator", mock.Anything, int64(12), int64(3)).Return(nil).Once()
+	mockUCase.On("RemoveCollaborator", mock.Anything, int64(12), int64(4)).Return(domain.ErrNotFound).Once()
+
+	tests := []struct {
+		userID string
+		code   int
+	}{
+		{"3", http.StatusNoContent},
+		{"4", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/courses/12/collaborators/"+tt.userID, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/courses/:id/collaborators/:user_id")
+		c.SetParamNames("id", "user_id")
+		c.SetParamValues("12", tt.userID)
+		handler := collaboratorHTTP.CollaboratorHandler{
+			CollaboratorUseCase: mockUCase,
+		}
+		err = handler.RemoveCollaborator(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.userID)
+	}
+	mockUCase.AssertExpectations(t)
+}
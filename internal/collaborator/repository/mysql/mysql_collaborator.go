@@ -0,0 +1,148 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the collaborator's Repository interface
+func Init(db *sql.DB) domain.CollaboratorRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+// GetByCourse returns the collaborators of a course of the caller's organization, by name
+func (m *mysqlRepository) GetByCourse(ctx context.Context, courseID int64) ([]domain.CourseCollaborator, error) {
+	query := `SELECT cc.course_id,cc.user_id,u.firstName,u.lastName,u.email,cc.permission,cc.added_by,cc.updated_at,cc.created_at
+		FROM courses_collaborators cc JOIN courses c ON c.id = cc.course_id JOIN users u ON u.id = cc.user_id
+		WHERE cc.course_id = ? AND c.organization_id = ? ORDER BY u.lastName, u.firstName, u.id`
+	rows, err := m.conn.QueryContext(ctx, query, courseID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result := make([]domain.CourseCollaborator, 0)
+	for rows.Next() {
+		cc := domain.CourseCollaborator{}
+		var firstName, lastName, email sql.NullString
+		var addedBy sql.NullInt64
+		err = rows.Scan(&cc.CourseID, &cc.UserID, &firstName, &lastName, &email, &cc.Permission, &addedBy, &cc.UpdatedAt, &cc.CreatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		cc.FirstName = firstName.String
+		cc.LastName = lastName.String
+		cc.Email = email.String
+		cc.AddedBy = addedBy.Int64
+		result = append(result, cc)
+	}
+	return result, nil
+}
+
+// AddCollaborator adds a user of the caller's organization to a course of the same organization.
+// It returns ErrConflict when the user already collaborates on the course.
+func (m *mysqlRepository) AddCollaborator(ctx context.Context, cc *domain.CourseCollaborator) error {
+	query := `INSERT INTO courses_collaborators (course_id,user_id,permission,added_by,updated_at,created_at)
+		SELECT c.id,u.id,?,?,?,? FROM courses c JOIN users u ON u.organization_id = c.organization_id
+		WHERE c.id = ? AND u.id = ? AND c.organization_id = ?
+		AND NOT EXISTS (SELECT 1 FROM courses_collaborators cc WHERE cc.course_id = c.id AND cc.user_id = u.id)`
+	addedBy := sql.NullInt64{Int64: cc.AddedBy, Valid: cc.AddedBy != 0}
+	res, err := m.conn.ExecContext(ctx, query, cc.Permission, addedBy, cc.UpdatedAt, cc.CreatedAt, cc.CourseID, cc.UserID,
+		domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+func (m *mysqlRepository) UpdateCollaborator(ctx context.Context, cc *domain.CourseCollaborator) error {
+	query := `UPDATE courses_collaborators cc JOIN courses c ON c.id = cc.course_id SET cc.permission=?,cc.updated_at=?
+		WHERE cc.course_id = ? AND cc.user_id = ? AND c.organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, cc.Permission, cc.UpdatedAt, cc.CourseID, cc.UserID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mysqlRepository) RemoveCollaborator(ctx context.Context, courseID int64, userID int64) error {
+	query := `DELETE cc FROM courses_collaborators cc JOIN courses c ON c.id = cc.course_id
+		WHERE cc.course_id = ? AND cc.user_id = ? AND c.organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, courseID, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// access returns the access of the user to the course c of the item whose column is id, out of the tables from
+func (m *mysqlRepository) access(ctx context.Context, from string, column string, id int64, userID int64) (domain.CollaboratorPermission, error) {
+	query := `SELECT c.author_id,cc.permission FROM ` + from + ` LEFT JOIN courses_collaborators cc ON cc.course_id = c.id AND cc.user_id = ?
+		WHERE ` + column + ` = ? AND c.organization_id = ?`
+	var authorID sql.NullInt64
+	var permission sql.NullString
+	err := m.conn.QueryRowContext(ctx, query, userID, id, domain.OrganizationIDFromContext(ctx)).Scan(&authorID, &permission)
+	if err == sql.ErrNoRows {
+		return "", domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return "", err
+	}
+	if authorID.Valid && authorID.Int64 == userID {
+		return domain.CourseOwner, nil
+	}
+	return domain.CollaboratorPermission(permission.String), nil
+}
+
+func (m *mysqlRepository) GetCourseAccess(ctx context.Context, courseID int64, userID int64) (domain.CollaboratorPermission, error) {
+	return m.access(ctx, `courses c`, "c.id", courseID, userID)
+}
+
+func (m *mysqlRepository) GetLessonAccess(ctx context.Context, lessonID int64, userID int64) (domain.CollaboratorPermission, error) {
+	return m.access(ctx, `lessons l JOIN courses c ON c.id = l.course_id`, "l.id", lessonID, userID)
+}
+
+func (m *mysqlRepository) GetContentAccess(ctx context.Context, contentID int64, userID int64) (domain.CollaboratorPermission, error) {
+	return m.access(ctx, `contents ct JOIN lessons l ON l.id = ct.lesson_id JOIN courses c ON c.id = l.course_id`, "ct.id", contentID, userID)
+}
@@ -0,0 +1,127 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	mysqlrepo "github.com/meroedu/meroedu/internal/collaborator/repository/mysql"
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+func TestGetByCourse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM courses_collaborators cc JOIN courses c ON c.id = cc.course_id JOIN users u ON u.id = cc.user_id\s+WHERE cc.course_id = \? AND c.organization_id = \?`).
+		WithArgs(3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"course_id", "user_id", "firstName", "lastName", "email", "permission", "added_by", "updated_at", "created_at"}).
+			AddRow(3, 4, "Sita", "Sharma", "sita@school.local", "editor", 5, 100, 100).
+			AddRow(3, 6, nil, "Rai", nil, "co-author", nil, 110, 110))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetByCourse(orgCtx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CourseCollaborator{
+		{CourseID: 3, UserID: 4, FirstName: "Sita", LastName: "Sharma", Email: "sita@school.local", Permission: domain.CollaboratorEditor,
+			AddedBy: 5, UpdatedAt: 100, CreatedAt: 100},
+		{CourseID: 3, UserID: 6, LastName: "Rai", Permission: domain.CollaboratorCoAuthor, UpdatedAt: 110, CreatedAt: 110},
+	}, list)
+}
+
+func TestAddCollaborator(t *testing.T) {
+	query := `INSERT INTO courses_collaborators \(course_id,user_id,permission,added_by,updated_at,created_at\)\s+SELECT c.id,u.id,\?,\?,\?,\? FROM courses c JOIN users u ON u.organization_id = c.organization_id\s+WHERE c.id = \? AND u.id = \? AND c.organization_id = \?\s+AND NOT EXISTS`
+	cc := &domain.CourseCollaborator{CourseID: 3, UserID: 4, Permission: domain.CollaboratorEditor, AddedBy: 5, UpdatedAt: 100, CreatedAt: 100}
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectExec(query).WithArgs("editor", 5, 100, 100, 3, 4, 2).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		repo := mysqlrepo.Init(db)
+		assert.NoError(t, repo.AddCollaborator(orgCtx, cc))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("already-collaborator", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectExec(query).WithArgs("editor", 5, 100, 100, 3, 4, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := mysqlrepo.Init(db)
+		assert.Equal(t, domain.ErrConflict, repo.AddCollaborator(orgCtx, cc))
+	})
+}
+
+func TestRemoveCollaborator(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`DELETE cc FROM courses_collaborators cc JOIN courses c ON c.id = cc.course_id\s+WHERE cc.course_id = \? AND cc.user_id = \? AND c.organization_id = \?`).
+		WithArgs(3, 4, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	assert.Equal(t, domain.ErrNotFound, repo.RemoveCollaborator(orgCtx, 3, 4))
+}
+
+func TestGetAccess(t *testing.T) {
+	columns := []string{"author_id", "permission"}
+	t.Run("author", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`SELECT c.author_id,cc.permission FROM courses c LEFT JOIN courses_collaborators cc ON cc.course_id = c.id AND cc.user_id = \?\s+WHERE c.id = \? AND c.organization_id = \?`).
+			WithArgs(4, 3, 2).WillReturnRows(sqlmock.NewRows(columns).AddRow(4, nil))
+
+		repo := mysqlrepo.Init(db)
+		access, err := repo.GetCourseAccess(orgCtx, 3, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CourseOwner, access)
+	})
+	t.Run("lesson-collaborator", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`FROM lessons l JOIN courses c ON c.id = l.course_id LEFT JOIN courses_collaborators cc .+ WHERE l.id = \? AND c.organization_id = \?`).
+			WithArgs(4, 8, 2).WillReturnRows(sqlmock.NewRows(columns).AddRow(5, "editor"))
+
+		repo := mysqlrepo.Init(db)
+		access, err := repo.GetLessonAccess(orgCtx, 8, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CollaboratorEditor, access)
+	})
+	t.Run("content-without-access", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`FROM contents ct JOIN lessons l ON l.id = ct.lesson_id JOIN courses c ON c.id = l.course_id .+ WHERE ct.id = \?`).
+			WithArgs(4, 9, 2).WillReturnRows(sqlmock.NewRows(columns).AddRow(nil, nil))
+
+		repo := mysqlrepo.Init(db)
+		access, err := repo.GetContentAccess(orgCtx, 9, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CollaboratorPermission(""), access)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`FROM courses c`).WithArgs(4, 3, 2).WillReturnRows(sqlmock.NewRows(columns))
+
+		repo := mysqlrepo.Init(db)
+		_, err = repo.GetCourseAccess(orgCtx, 3, 4)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
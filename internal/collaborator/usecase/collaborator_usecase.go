@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// CollaboratorUseCase ...
+type CollaboratorUseCase struct {
+	collaboratorRepo domain.CollaboratorRepository
+	userRepo         domain.UserRepository
+	roleRepo         domain.RoleRepository
+	contextTimeOut   time.Duration
+}
+
+// NewCollaboratorUseCase will create new an CollaboratorUseCase
+func NewCollaboratorUseCase(cr domain.CollaboratorRepository, u domain.UserRepository, r domain.RoleRepository, timeout time.Duration) domain.CollaboratorUseCase {
+	return &CollaboratorUseCase{
+		collaboratorRepo: cr,
+		userRepo:         u,
+		roleRepo:         r,
+		contextTimeOut:   timeout,
+	}
+}
+
+// authorize checks the caller has at least the level of access returned by access for the caller
+func authorize(ctx context.Context, level domain.CollaboratorPermission,
+	access func(userID int64) (domain.CollaboratorPermission, error)) error {
+	if domain.HasPermission(ctx, domain.PermCourseManage) {
+		return nil
+	}
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return domain.ErrForbidden
+	}
+	permission, err := access(userID)
+	if err != nil {
+		return err
+	}
+	if !permission.Allows(level) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// AuthorizeCourse checks the caller has at least the level of access to the course
+func (usecase *CollaboratorUseCase) AuthorizeCourse(c context.Context, courseID int64, level domain.CollaboratorPermission) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return authorize(ctx, level, func(userID int64) (domain.CollaboratorPermission, error) {
+		return usecase.collaboratorRepo.GetCourseAccess(ctx, courseID, userID)
+	})
+}
+
+// AuthorizeLesson checks the caller has at least the level of access to the course of the lesson
+func (usecase *CollaboratorUseCase) AuthorizeLesson(c context.Context, lessonID int64, level domain.CollaboratorPermission) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return authorize(ctx, level, func(userID int64) (domain.CollaboratorPermission, error) {
+		return usecase.collaboratorRepo.GetLessonAccess(ctx, lessonID, userID)
+	})
+}
+
+// AuthorizeContent checks the caller has at least the level of access to the course of the content
+func (usecase *CollaboratorUseCase) AuthorizeContent(c context.Context, contentID int64, level domain.CollaboratorPermission) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return authorize(ctx, level, func(userID int64) (domain.CollaboratorPermission, error) {
+		return usecase.collaboratorRepo.GetContentAccess(ctx, contentID, userID)
+	})
+}
+
+// GetByCourse returns the collaborators of a course to the ones working on it
+func (usecase *CollaboratorUseCase) GetByCourse(c context.Context, courseID int64) ([]domain.CourseCollaborator, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.AuthorizeCourse(ctx, courseID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	return usecase.collaboratorRepo.GetByCourse(ctx, courseID)
+}
+
+// AddCollaborator gives a user access to a course. The user must be active, must not be the author of the
+// course and its role must allow updating courses.
+func (usecase *CollaboratorUseCase) AddCollaborator(c context.Context, collaborator *domain.CourseCollaborator) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if !collaborator.Permission.IsGrantable() {
+		return domain.ErrBadParamInput
+	}
+	if err := usecase.AuthorizeCourse(ctx, collaborator.CourseID, domain.CollaboratorCoAuthor); err != nil {
+		return err
+	}
+	user, err := usecase.userRepo.GetByID(ctx, collaborator.UserID)
+	if err != nil {
+		return err
+	}
+	if user.Status != domain.UserActive {
+		return domain.ErrBadParamInput
+	}
+	access, err := usecase.collaboratorRepo.GetCourseAccess(ctx, collaborator.CourseID, collaborator.UserID)
+	if err != nil {
+		return err
+	}
+	if access == domain.CourseOwner {
+		return domain.ErrConflict
+	}
+	permissions, err := usecase.roleRepo.GetUserPermissions(ctx, collaborator.UserID)
+	if err != nil {
+		return err
+	}
+	if !hasPermission(permissions, domain.PermCourseUpdate) {
+		return domain.ErrBadParamInput
+	}
+	collaborator.AddedBy = domain.UserIDFromContext(ctx)
+	collaborator.UpdatedAt = time.Now().Unix()
+	collaborator.CreatedAt = collaborator.UpdatedAt
+	return usecase.collaboratorRepo.AddCollaborator(ctx, collaborator)
+}
+
+// UpdateCollaborator changes the access of a collaborator to a course
+func (usecase *CollaboratorUseCase) UpdateCollaborator(c context.Context, courseID int64, userID int64, permission domain.CollaboratorPermission) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if !permission.IsGrantable() {
+		return domain.ErrBadParamInput
+	}
+	if err := usecase.AuthorizeCourse(ctx, courseID, domain.CollaboratorCoAuthor); err != nil {
+		return err
+	}
+	return usecase.collaboratorRepo.UpdateCollaborator(ctx, &domain.CourseCollaborator{
+		CourseID:   courseID,
+		UserID:     userID,
+		Permission: permission,
+		UpdatedAt:  time.Now().Unix(),
+	})
+}
+
+// RemoveCollaborator takes the access to a course away from a collaborator. Collaborators can always leave a course.
+func (usecase *CollaboratorUseCase) RemoveCollaborator(c context.Context, courseID int64, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if userID != domain.UserIDFromContext(ctx) {
+		if err := usecase.AuthorizeCourse(ctx, courseID, domain.CollaboratorCoAuthor); err != nil {
+			return err
+		}
+	}
+	return usecase.collaboratorRepo.RemoveCollaborator(ctx, courseID, userID)
+}
+
+func hasPermission(permissions []domain.Permission, permission domain.Permission) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
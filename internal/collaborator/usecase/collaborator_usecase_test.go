@@ -0,0 +1,149 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	ucase "github.com/meroedu/meroedu/internal/collaborator/usecase"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+)
+
+var instructorCtx = domain.WithUserID(domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2),
+	[]domain.Permission{domain.PermCourseUpdate}), 4)
+
+func TestAuthorizeCourse(t *testing.T) {
+	tests := []struct {
+		name   string
+		access domain.CollaboratorPermission
+		level  domain.CollaboratorPermission
+		err    error
+	}{
+		{"editor-edits", domain.CollaboratorEditor, domain.CollaboratorEditor, nil},
+		{"editor-does-not-publish", domain.CollaboratorEditor, domain.CollaboratorCoAuthor, domain.ErrForbidden},
+		{"co-author-publishes", domain.CollaboratorCoAuthor, domain.CollaboratorCoAuthor, nil},
+		{"co-author-does-not-delete", domain.CollaboratorCoAuthor, domain.CourseOwner, domain.ErrForbidden},
+		{"author-deletes", domain.CourseOwner, domain.CourseOwner, nil},
+		{"others-do-not-edit", "", domain.CollaboratorEditor, domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+			mockUserRepo := new(mocks.UserRepository)
+			mockRoleRepo := new(mocks.RoleRepository)
+			u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+			mockCollaboratorRepo.On("GetCourseAccess", mock.Anything, int64(3), int64(4)).Return(tt.access, nil).Once()
+			assert.Equal(t, tt.err, u.AuthorizeCourse(instructorCtx, 3, tt.level))
+		})
+	}
+	t.Run("course-manager", func(t *testing.T) {
+		mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+		ctx := domain.WithPermissions(instructorCtx, []domain.Permission{domain.PermCourseManage})
+		assert.NoError(t, u.AuthorizeCourse(ctx, 3, domain.CourseOwner))
+		mockCollaboratorRepo.AssertNotCalled(t, "GetCourseAccess", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("api-key", func(t *testing.T) {
+		mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+		ctx := domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2), []domain.Permission{domain.PermCourseUpdate})
+		assert.Equal(t, domain.ErrForbidden, u.AuthorizeCourse(ctx, 3, domain.CollaboratorEditor))
+	})
+	t.Run("content-not-found", func(t *testing.T) {
+		mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+		mockCollaboratorRepo.On("GetContentAccess", mock.Anything, int64(9), int64(4)).Return(domain.CollaboratorPermission(""), domain.ErrNotFound).Once()
+		assert.Equal(t, domain.ErrNotFound, u.AuthorizeContent(instructorCtx, 9, domain.CollaboratorEditor))
+	})
+}
+
+func TestAddCollaborator(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+		mockCollaboratorRepo.On("GetCourseAccess", mock.Anything, int64(3), int64(4)).Return(domain.CollaboratorCoAuthor, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, int64(6)).Return(&domain.User{ID: 6, Status: domain.UserActive}, nil).Once()
+		mockCollaboratorRepo.On("GetCourseAccess", mock.Anything, int64(3), int64(6)).Return(domain.CollaboratorPermission(""), nil).Once()
+		mockRoleRepo.On("GetUserPermissions", mock.Anything, int64(6)).Return([]domain.Permission{domain.PermCourseUpdate}, nil).Once()
+		mockCollaboratorRepo.On("AddCollaborator", mock.Anything, mock.MatchedBy(func(cc *domain.CourseCollaborator) bool {
+			return cc.CourseID == 3 && cc.UserID == 6 && cc.AddedBy == 4 && cc.CreatedAt > 0
+		})).Return(nil).Once()
+
+		err := u.AddCollaborator(instructorCtx, &domain.CourseCollaborator{CourseID: 3, UserID: 6, Permission: domain.CollaboratorEditor})
+		assert.NoError(t, err)
+		mockCollaboratorRepo.AssertExpectations(t)
+	})
+	t.Run("owner-is-not-grantable", func(t *testing.T) {
+		mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+		err := u.AddCollaborator(instructorCtx, &domain.CourseCollaborator{CourseID: 3, UserID: 6, Permission: domain.CourseOwner})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+	t.Run("editor-does-not-add", func(t *testing.T) {
+		mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+		mockCollaboratorRepo.On("GetCourseAccess", mock.Anything, int64(3), int64(4)).Return(domain.CollaboratorEditor, nil).Once()
+		err := u.AddCollaborator(instructorCtx, &domain.CourseCollaborator{CourseID: 3, UserID: 6, Permission: domain.CollaboratorEditor})
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+	t.Run("author", func(t *testing.T) {
+		mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+		mockCollaboratorRepo.On("GetCourseAccess", mock.Anything, int64(3), int64(4)).Return(domain.CourseOwner, nil).Twice()
+		mockUserRepo.On("GetByID", mock.Anything, int64(4)).Return(&domain.User{ID: 4, Status: domain.UserActive}, nil).Once()
+		err := u.AddCollaborator(instructorCtx, &domain.CourseCollaborator{CourseID: 3, UserID: 4, Permission: domain.CollaboratorEditor})
+		assert.Equal(t, domain.ErrConflict, err)
+	})
+	t.Run("learner", func(t *testing.T) {
+		mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+		mockCollaboratorRepo.On("GetCourseAccess", mock.Anything, int64(3), int64(4)).Return(domain.CourseOwner, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, int64(6)).Return(&domain.User{ID: 6, Status: domain.UserActive}, nil).Once()
+		mockCollaboratorRepo.On("GetCourseAccess", mock.Anything, int64(3), int64(6)).Return(domain.CollaboratorPermission(""), nil).Once()
+		mockRoleRepo.On("GetUserPermissions", mock.Anything, int64(6)).Return([]domain.Permission{domain.PermCourseView}, nil).Once()
+		err := u.AddCollaborator(instructorCtx, &domain.CourseCollaborator{CourseID: 3, UserID: 6, Permission: domain.CollaboratorEditor})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockCollaboratorRepo.AssertNotCalled(t, "AddCollaborator", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRemoveCollaborator(t *testing.T) {
+	t.Run("leave", func(t *testing.T) {
+		mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+		mockCollaboratorRepo.On("RemoveCollaborator", mock.Anything, int64(3), int64(4)).Return(nil).Once()
+		assert.NoError(t, u.RemoveCollaborator(instructorCtx, 3, 4))
+		mockCollaboratorRepo.AssertNotCalled(t, "GetCourseAccess", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("editor-does-not-remove-others", func(t *testing.T) {
+		mockCollaboratorRepo := new(mocks.CollaboratorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		u := ucase.NewCollaboratorUseCase(mockCollaboratorRepo, mockUserRepo, mockRoleRepo, time.Second*2)
+		mockCollaboratorRepo.On("GetCourseAccess", mock.Anything, int64(3), int64(4)).Return(domain.CollaboratorEditor, nil).Once()
+		assert.Equal(t, domain.ErrForbidden, u.RemoveCollaborator(instructorCtx, 3, 6))
+		mockCollaboratorRepo.AssertNotCalled(t, "RemoveCollaborator", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -6,7 +6,8 @@ import (
 
 type config struct {
 	Filesystem struct {
-		RelativePath string
+		RelativePath    string
+		SubmissionsPath string
 	}
 	Database struct {
 		User                 string
@@ -11,6 +11,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
 	"github.com/meroedu/meroedu/internal/util"
 	"github.com/meroedu/meroedu/pkg/log"
 )
@@ -31,20 +32,25 @@ func NewContentHandler(e *echo.Echo, us domain.ContentUseCase) {
 		ContentUseCase: us,
 	}
 	// Get Operation
-	e.GET("/contents", handler.GetAll)
-	e.GET("/contents/:id", handler.GetByID)
-	e.GET("/contents/:id/", handler.GetByID)
-	e.GET("/contents/download", handler.DownloadContent)
+	e.GET("/contents", handler.GetAll, rbac.Require(domain.PermCourseView))
+	e.GET("/contents/trash", handler.GetTrash, rbac.Require(domain.PermCourseDelete))
+	e.GET("/contents/:id", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.GET("/contents/:id/", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.GET("/contents/download", handler.DownloadContent, rbac.Require(domain.PermCourseView))
+	e.GET("/contents/:id/revisions", handler.GetRevisions, rbac.Require(domain.PermCourseView))
+	e.GET("/contents/:id/revisions/:revision", handler.GetRevision, rbac.Require(domain.PermCourseView))
 
 	// Create/Add Operation
-	e.POST("/contents", handler.CreateContent)
+	e.POST("/contents", handler.CreateContent, rbac.Require(domain.PermContentUpload))
+	e.POST("/contents/:id/revisions/:revision/restore", handler.RestoreRevision, rbac.Require(domain.PermCourseUpdate))
+	e.POST("/contents/:id/restore", handler.RestoreContent, rbac.Require(domain.PermCourseDelete))
 
 	// Update Operation
-	e.PUT("/contents/:id", handler.GetByID)
-	e.PUT("/contents/actions", handler.GetByID)
+	e.PUT("/contents/:id", handler.UpdateContent, rbac.Require(domain.PermCourseUpdate))
+	e.PUT("/contents/actions", handler.BulkAction, rbac.Require(domain.PermCourseDelete))
 
 	// Remove/Delete Operation
-	e.DELETE("/contents/:id", handler.GetByID)
+	e.DELETE("/contents/:id", handler.DeleteContent, rbac.Require(domain.PermCourseDelete))
 }
 
 // GetAll godoc
@@ -285,3 +291,208 @@ func (c *ContentHandler) DownloadContent(echoContext echo.Context) error {
 	}
 	return echoContext.File(filePath)
 }
+
+// GetRevisions godoc
+// @Summary Get revision history of a Content.
+// @Description Get previous states of a content with the author and diff of each update.
+// @Tags contents
+// @Accept */*
+// @Produce json
+// @Param id path int true "Content Id"
+// @Success 200 {object} domain.Summaries
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /contents/{id}/revisions [get]
+func (c *ContentHandler) GetRevisions(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	revisions, err := c.ContentUseCase.GetRevisions(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    revisions,
+		},
+		Total: int64(len(revisions)),
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetRevision godoc
+// @Summary Get a Content revision.
+// @Description Get a specific previous state of a content.
+// @Tags contents
+// @Accept */*
+// @Produce json
+// @Param id path int true "Content Id"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /contents/{id}/revisions/{revision} [get]
+func (c *ContentHandler) GetRevision(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	revisionParam, err := strconv.Atoi(echoContext.Param("revision"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	revision, err := c.ContentUseCase.GetRevision(ctx, int64(idParam), revisionParam)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    revision,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// RestoreRevision godoc
+// @Summary Restore a Content revision.
+// @Description Bring a content back to a previous state. The replaced state is kept as a new revision.
+// @Tags contents
+// @Accept */*
+// @Produce json
+// @Param id path int true "Content Id"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /contents/{id}/revisions/{revision}/restore [post]
+func (c *ContentHandler) RestoreRevision(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	revisionParam, err := strconv.Atoi(echoContext.Param("revision"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	content, err := c.ContentUseCase.RestoreRevision(ctx, int64(idParam), revisionParam)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    content,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetTrash godoc
+// @Summary Get Contents in the trash.
+// @Description Get deleted contents, most recently deleted first. They are purged permanently after the retention period.
+// @Tags contents
+// @Accept */*
+// @Produce json
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /contents/trash [get]
+func (c *ContentHandler) GetTrash(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	start, limit := 0, 10
+	var err error
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		}
+	}
+
+	list, err := c.ContentUseCase.GetTrash(ctx, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// RestoreContent godoc
+// @Summary Restore a Content from the trash.
+// @Description Restore a deleted content.
+// @Tags contents
+// @Accept */*
+// @Produce json
+// @Param id path int true "Content Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /contents/{id}/restore [post]
+func (c *ContentHandler) RestoreContent(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	content, err := c.ContentUseCase.RestoreContent(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    content,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// BulkAction godoc
+// @Summary Run an action on many contents.
+// @Description Run delete on a list of content IDs in a single transaction. The outcome is reported for each ID.
+// @Tags contents
+// @Accept json
+// @Produce json
+// @Param action body domain.BulkAction true "Action and IDs"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Unsupported action or missing parameter"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /contents/actions [put]
+func (c *ContentHandler) BulkAction(echoContext echo.Context) error {
+	var action domain.BulkAction
+	err := echoContext.Bind(&action)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&action); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	results, err := c.ContentUseCase.BulkAction(ctx, &action)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    results,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
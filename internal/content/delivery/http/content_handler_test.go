@@ -168,3 +168,109 @@ func TestDeleteContent(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 
 }
+
+func TestDeleteContentRoute(t *testing.T) {
+	mockUCase := new(mocks.ContentUseCase)
+	mockUCase.On("DeleteContent", mock.Anything, int64(7)).Return(nil).Once()
+
+	e := echo.New()
+	contentHTTP.NewContentHandler(e, mockUCase)
+	req, err := http.NewRequest(echo.DELETE, "/contents/7", strings.NewReader(""))
+	assert.NoError(t, err)
+	req = req.WithContext(domain.WithPermissions(req.Context(), []domain.Permission{domain.PermCourseDelete}))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetRevisions(t *testing.T) {
+	mockUCase := new(mocks.ContentUseCase)
+	mockUCase.On("GetRevisions", mock.Anything, int64(12)).Return([]domain.ContentRevision{{ID: 1, ContentID: 12, Revision: 1}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/contents/12/revisions", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/contents/:id/revisions")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := contentHTTP.ContentHandler{
+		ContentUseCase: mockUCase,
+	}
+	err = handler.GetRevisions(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRestoreRevision(t *testing.T) {
+	mockUCase := new(mocks.ContentUseCase)
+	mockUCase.On("RestoreRevision", mock.Anything, int64(12), 3).Return(&domain.Content{ID: 12, Title: "Title"}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/contents/12/revisions/3/restore", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/contents/:id/revisions/:revision/restore")
+	c.SetParamNames("id", "revision")
+	c.SetParamValues("12", "3")
+	handler := contentHTTP.ContentHandler{
+		ContentUseCase: mockUCase,
+	}
+	err = handler.RestoreRevision(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRestoreRevisionNotFound(t *testing.T) {
+	mockUCase := new(mocks.ContentUseCase)
+	mockUCase.On("RestoreRevision", mock.Anything, int64(12), 9).Return(nil, domain.ErrNotFound)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/contents/12/revisions/9/restore", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/contents/:id/revisions/:revision/restore")
+	c.SetParamNames("id", "revision")
+	c.SetParamValues("12", "9")
+	handler := contentHTTP.ContentHandler{
+		ContentUseCase: mockUCase,
+	}
+	err = handler.RestoreRevision(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetTrash(t *testing.T) {
+	mockUCase := new(mocks.ContentUseCase)
+	mockUCase.On("GetTrash", mock.Anything, 0, 10).Return([]domain.Content{{ID: 12, Title: "Title", DeletedAt: time.Now().Unix()}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/contents/trash", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := contentHTTP.ContentHandler{
+		ContentUseCase: mockUCase,
+	}
+	err = handler.GetTrash(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
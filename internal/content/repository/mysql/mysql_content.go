@@ -3,12 +3,21 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/repository/sqlbulk"
 	"github.com/meroedu/meroedu/pkg/log"
 )
 
+// inOrganization limits contents to the lessons of the caller's organization, out of the trash along with their
+// course. The contents of a trashed lesson or course are left alone and come back with it.
+const inOrganization = "lesson_id IN (SELECT l.id FROM lessons l JOIN courses c ON c.id = l.course_id WHERE c.organization_id = ? AND l.deleted_at IS NULL AND c.deleted_at IS NULL)"
+
+// revisionInOrganization limits content revisions to the contents of the caller's organization
+const revisionInOrganization = "content_id IN (SELECT ct.id FROM contents ct JOIN lessons l ON l.id = ct.lesson_id JOIN courses c ON c.id = l.course_id WHERE c.organization_id = ? AND l.deleted_at IS NULL AND c.deleted_at IS NULL)"
+
 type mysqlRepository struct {
 	conn *sql.DB
 }
@@ -36,18 +45,39 @@ func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...inter
 	result = make([]domain.Content, 0)
 	for rows.Next() {
 		t := domain.Content{}
+		description, content, contentType, name := sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}
+		fileHeader, embedURL, caption, size := sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullInt64{}
+		deletedAt := sql.NullInt64{}
 		err = rows.Scan(
 			&t.ID,
+			&t.LessonID,
 			&t.Title,
-			&t.Description,
+			&description,
+			&content,
+			&contentType,
+			&name,
+			&fileHeader,
+			&embedURL,
+			&caption,
+			&size,
 			&t.UpdatedAt,
 			&t.CreatedAt,
+			&deletedAt,
 		)
 
 		if err != nil {
 			log.Error(err)
 			return nil, err
 		}
+		t.Description = description.String
+		t.Content = content.String
+		t.ContentType = domain.ContentType{Type: contentType.String}
+		t.Name = name.String
+		t.FileHeader = fileHeader.String
+		t.EmbedURL = embedURL.String
+		t.Caption = caption.String
+		t.Size = size.Int64
+		t.DeletedAt = deletedAt.Int64
 		result = append(result, t)
 	}
 
@@ -55,18 +85,18 @@ func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...inter
 }
 
 func (m *mysqlRepository) GetAll(ctx context.Context, start int, limit int) (res []domain.Content, err error) {
-	query := `SELECT id,title,description,updated_at,created_at FROM contents ORDER BY created_at DESC LIMIT ?,?`
+	query := `SELECT id,lesson_id,title,description,content,content_type,name,fileheader,embed_url,caption,size,updated_at,created_at,deleted_at FROM contents WHERE ` + inOrganization + ` AND deleted_at IS NULL ORDER BY created_at DESC LIMIT ?,?`
 
-	res, err = m.fetch(ctx, query, start, limit)
+	res, err = m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (res *domain.Content, err error) {
-	query := `SELECT id,title,description,updated_at,created_at FROM contents WHERE ID = ?`
+	query := `SELECT id,lesson_id,title,description,content,content_type,name,fileheader,embed_url,caption,size,updated_at,created_at,deleted_at FROM contents WHERE ID = ? AND ` + inOrganization + ` AND deleted_at IS NULL`
 
-	list, err := m.fetch(ctx, query, id)
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -80,18 +110,28 @@ func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (res *domain.Co
 	return &content, nil
 }
 
+// CreateContent adds the content to a lesson of the caller's organization.
 func (m *mysqlRepository) CreateContent(ctx context.Context, a *domain.Content) (err error) {
-	query := `INSERT contents SET title=?,description=?,fileheader=?,lesson_id=?,updated_at=?,created_at=?`
+	query := `INSERT INTO contents (title,description,content,content_type,name,fileheader,embed_url,caption,size,lesson_id,updated_at,created_at)
+		SELECT ?,?,?,?,?,?,?,?,?,l.id,?,? FROM lessons l JOIN courses c ON c.id = l.course_id WHERE l.id = ? AND c.organization_id = ?
+		AND l.deleted_at IS NULL AND c.deleted_at IS NULL`
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		log.Error("Error while preparing statement ", err)
 		return
 	}
-	res, err := stmt.ExecContext(ctx, a.Title, a.Description, a.FileHeader, a.LessonID, a.UpdatedAt, a.CreatedAt)
+	res, err := stmt.ExecContext(ctx, a.Title, a.Description, a.Content, a.ContentType.Type, a.Name, a.FileHeader, a.EmbedURL, a.Caption, a.Size, a.UpdatedAt, a.CreatedAt, a.LessonID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		log.Error("Error while executing statement ", err)
 		return
 	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
 	lastID, err := res.LastInsertId()
 	if err != nil {
 		log.Error("Got Error from LastInsertId method: ", err)
@@ -101,15 +141,16 @@ func (m *mysqlRepository) CreateContent(ctx context.Context, a *domain.Content)
 	return
 }
 
-func (m *mysqlRepository) DeleteContent(ctx context.Context, id int64) (err error) {
-	query := "DELETE FROM contents WHERE id = ?"
+// DeleteContent moves the content to the trash.
+func (m *mysqlRepository) DeleteContent(ctx context.Context, id int64, deletedAt int64) (err error) {
+	query := "UPDATE contents SET deleted_at = ? WHERE id = ? AND " + inOrganization + " AND deleted_at IS NULL"
 
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	res, err := stmt.ExecContext(ctx, deletedAt, id, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return
 	}
@@ -126,16 +167,64 @@ func (m *mysqlRepository) DeleteContent(ctx context.Context, id int64) (err erro
 
 	return
 }
-func (m *mysqlRepository) UpdateContent(ctx context.Context, ar *domain.Content) (err error) {
-	query := `UPDATE contents set title=?,updated_at=? WHERE ID = ?`
 
-	stmt, err := m.conn.PrepareContext(ctx, query)
+// UpdateContent saves the previous state of the content as the next revision and updates it in a single transaction.
+func (m *mysqlRepository) UpdateContent(ctx context.Context, ar *domain.Content, revision *domain.ContentRevision) (err error) {
+	snapshot, err := json.Marshal(revision.Content)
+	if err != nil {
+		return
+	}
+	tx, err := m.conn.BeginTx(ctx, nil)
 	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	var count int
+	query := `SELECT count(*) FROM contents WHERE id = ? AND ` + inOrganization + ` AND deleted_at IS NULL`
+	if err = tx.QueryRowContext(ctx, query, ar.ID, domain.OrganizationIDFromContext(ctx)).Scan(&count); err != nil {
+		log.Error(err)
 		return
 	}
+	if count == 0 {
+		return domain.ErrNotFound
+	}
 
-	res, err := stmt.ExecContext(ctx, ar.Title, ar.UpdatedAt, ar.ID)
+	query = `SELECT COALESCE(MAX(revision),0) FROM content_revisions WHERE content_id = ? FOR UPDATE`
+	var latest int
+	if err = tx.QueryRowContext(ctx, query, ar.ID).Scan(&latest); err != nil {
+		log.Error(err)
+		return
+	}
+	revision.ContentID = ar.ID
+	revision.Revision = latest + 1
+
+	query = `INSERT content_revisions SET content_id=?,revision=?,author_id=?,snapshot=?,diff=?,created_at=?`
+	authorID := sql.NullInt64{Int64: revision.AuthorID, Valid: revision.AuthorID != 0}
+	res, err := tx.ExecContext(ctx, query, revision.ContentID, revision.Revision, authorID, string(snapshot), revision.Diff, revision.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	revision.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return
+	}
+
+	query = `UPDATE contents set title=?,description=?,content=?,embed_url=?,caption=?,updated_at=? WHERE ID = ? AND deleted_at IS NULL`
+	res, err = tx.ExecContext(ctx, query, ar.Title, ar.Description, ar.Content, ar.EmbedURL, ar.Caption, ar.UpdatedAt, ar.ID)
 	if err != nil {
+		log.Error("Error while executing statement ", err)
 		return
 	}
 	affect, err := res.RowsAffected()
@@ -151,9 +240,9 @@ func (m *mysqlRepository) UpdateContent(ctx context.Context, ar *domain.Content)
 }
 
 func (m *mysqlRepository) GetContentCountByLesson(ctx context.Context, lessonID int64) (int, error) {
-	query := `SELECT count(*) FROM contents WHERE lesson_id = ?`
+	query := `SELECT count(*) FROM contents WHERE lesson_id = ? AND ` + inOrganization + ` AND deleted_at IS NULL`
 
-	rows, err := m.conn.QueryContext(ctx, query, lessonID)
+	rows, err := m.conn.QueryContext(ctx, query, lessonID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		log.Error(err)
 		return 0, nil
@@ -171,10 +260,115 @@ func (m *mysqlRepository) GetContentCountByLesson(ctx context.Context, lessonID
 }
 
 func (m *mysqlRepository) GetContentByLesson(ctx context.Context, lessonID int64) ([]domain.Content, error) {
-	query := `SELECT id,title,description,updated_at,created_at FROM contents WHERE lesson_id = ?`
-	list, err := m.fetch(ctx, query, lessonID)
+	query := `SELECT id,lesson_id,title,description,content,content_type,name,fileheader,embed_url,caption,size,updated_at,created_at,deleted_at FROM contents WHERE lesson_id = ? AND ` + inOrganization + ` AND deleted_at IS NULL`
+	list, err := m.fetch(ctx, query, lessonID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	return list, nil
 }
+
+func (m *mysqlRepository) fetchRevisions(ctx context.Context, query string, args ...interface{}) (result []domain.ContentRevision, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.ContentRevision, 0)
+	for rows.Next() {
+		t := domain.ContentRevision{}
+		authorID, snapshot, diff := sql.NullInt64{}, "", sql.NullString{}
+		err = rows.Scan(
+			&t.ID,
+			&t.ContentID,
+			&t.Revision,
+			&authorID,
+			&snapshot,
+			&diff,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.AuthorID = authorID.Int64
+		t.Diff = diff.String
+		t.Content = &domain.Content{}
+		if err = json.Unmarshal([]byte(snapshot), t.Content); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) GetRevisions(ctx context.Context, contentID int64) ([]domain.ContentRevision, error) {
+	query := `SELECT id,content_id,revision,author_id,snapshot,diff,created_at FROM content_revisions WHERE content_id = ? AND ` + revisionInOrganization + ` ORDER BY revision DESC`
+	return m.fetchRevisions(ctx, query, contentID, domain.OrganizationIDFromContext(ctx))
+}
+
+func (m *mysqlRepository) GetRevision(ctx context.Context, contentID int64, revision int) (*domain.ContentRevision, error) {
+	query := `SELECT id,content_id,revision,author_id,snapshot,diff,created_at FROM content_revisions WHERE content_id = ? AND revision = ? AND ` + revisionInOrganization
+	list, err := m.fetchRevisions(ctx, query, contentID, revision, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// GetTrash returns the contents in the trash, most recently deleted first.
+func (m *mysqlRepository) GetTrash(ctx context.Context, start int, limit int) ([]domain.Content, error) {
+	query := `SELECT id,lesson_id,title,description,content,content_type,name,fileheader,embed_url,caption,size,updated_at,created_at,deleted_at FROM contents WHERE ` + inOrganization + ` AND deleted_at IS NOT NULL ORDER BY deleted_at DESC LIMIT ?,?`
+	return m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
+}
+
+// RestoreContent takes the content out of the trash.
+func (m *mysqlRepository) RestoreContent(ctx context.Context, id int64, updatedAt int64) error {
+	query := `UPDATE contents SET deleted_at = NULL, updated_at = ? WHERE id = ? AND ` + inOrganization + ` AND deleted_at IS NOT NULL`
+	res, err := m.conn.ExecContext(ctx, query, updatedAt, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// PurgeTrash permanently removes the contents of every organization trashed before the given time.
+func (m *mysqlRepository) PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error) {
+	query := `DELETE FROM contents WHERE deleted_at IS NOT NULL AND deleted_at < ?`
+	res, err := m.conn.ExecContext(ctx, query, deletedBefore)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// BulkDelete moves many contents to the trash in a single transaction.
+func (m *mysqlRepository) BulkDelete(ctx context.Context, ids []int64, deletedAt int64) ([]domain.BulkResult, error) {
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	return sqlbulk.Run(ctx, m.conn, ids, func(tx *sql.Tx, id int64) error {
+		query := `UPDATE contents SET deleted_at=? WHERE id = ? AND ` + inOrganization + ` AND deleted_at IS NULL`
+		return sqlbulk.ExecItem(ctx, tx, query, deletedAt, id, organizationID)
+	})
+}
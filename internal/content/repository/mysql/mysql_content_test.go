@@ -11,6 +11,10 @@ import (
 	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
+var orgCtx = domain.WithOrganizationID(context.TODO(), 1)
+
+const inOrganization = `lesson_id IN \(SELECT l.id FROM lessons l JOIN courses c ON c.id = l.course_id WHERE c.organization_id = \? AND l.deleted_at IS NULL AND c.deleted_at IS NULL\)`
+
 func TestGetAll(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -21,14 +25,14 @@ func TestGetAll(t *testing.T) {
 			ID: 1, Title: "IT", UpdatedAt: time.Now().Unix(), CreatedAt: time.Now().Unix(),
 		},
 	}
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "updated_at", "created_at"}).
-		AddRow(mockContents[0].ID, mockContents[0].Title, mockContents[0].Description, mockContents[0].UpdatedAt, mockContents[0].CreatedAt)
+	rows := sqlmock.NewRows([]string{"id", "lesson_id", "title", "description", "content", "content_type", "name", "fileheader", "embed_url", "caption", "size", "updated_at", "created_at", "deleted_at"}).
+		AddRow(mockContents[0].ID, 2, mockContents[0].Title, mockContents[0].Description, nil, "video", nil, nil, nil, nil, nil, mockContents[0].UpdatedAt, mockContents[0].CreatedAt, nil)
 
-	query := `SELECT id,title,description,updated_at,created_at FROM contents ORDER BY created_at DESC LIMIT \?,\?`
+	query := `SELECT id,lesson_id,title,description,content,content_type,name,fileheader,embed_url,caption,size,updated_at,created_at,deleted_at FROM contents WHERE ` + inOrganization + ` AND deleted_at IS NULL ORDER BY created_at DESC LIMIT \?,\?`
 	mock.ExpectQuery(query).WillReturnRows(rows)
 	c := mysqlrepo.Init(db)
 	start, limit := 0, 10
-	list, err := c.GetAll(context.TODO(), start, limit)
+	list, err := c.GetAll(orgCtx, start, limit)
 	assert.NoError(t, err)
 	assert.Len(t, list, 1)
 
@@ -39,13 +43,13 @@ func TestGetByID(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
-	row := sqlmock.NewRows([]string{"id", "title", "description", "updated_at", "created_at"}).
-		AddRow("1", "testing-2", "description", time.Now().Unix(), time.Now().Unix())
+	row := sqlmock.NewRows([]string{"id", "lesson_id", "title", "description", "content", "content_type", "name", "fileheader", "embed_url", "caption", "size", "updated_at", "created_at", "deleted_at"}).
+		AddRow("1", "2", "testing-2", "description", "body", "text", nil, nil, nil, nil, nil, time.Now().Unix(), time.Now().Unix(), nil)
 
-	query := `SELECT id,title,description,updated_at,created_at FROM contents WHERE ID = \?`
+	query := `SELECT id,lesson_id,title,description,content,content_type,name,fileheader,embed_url,caption,size,updated_at,created_at,deleted_at FROM contents WHERE ID = \? AND ` + inOrganization + ` AND deleted_at IS NULL`
 	mock.ExpectQuery(query).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	content, err := c.GetByID(context.TODO(), 1)
+	content, err := c.GetByID(orgCtx, 1)
 	assert.NoError(t, err)
 	assert.NotNil(t, content)
 }
@@ -60,12 +64,12 @@ func TestCreateContent(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `INSERT contents SET title=\?,description=\?,fileheader=\?,lesson_id=\?,updated_at=\?,created_at=\?`
+	query := `INSERT INTO contents \(.+\)\s+SELECT .+,l.id,\?,\? FROM lessons l JOIN courses c ON c.id = l.course_id WHERE l.id = \? AND c.organization_id = \?`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(c.Title, c.Description, c.FileHeader, c.LessonID, c.UpdatedAt, c.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(c.Title, c.Description, c.Content, c.ContentType.Type, c.Name, c.FileHeader, c.EmbedURL, c.Caption, c.Size, c.UpdatedAt, c.CreatedAt, c.LessonID, 1).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.CreateContent(context.TODO(), c)
+	err = repo.CreateContent(orgCtx, c)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(12), c.ID)
 }
@@ -76,13 +80,46 @@ func TestDeleteContent(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `DELETE FROM contents WHERE id = \?`
+	deletedAt := time.Now().Unix()
+	query := `UPDATE contents SET deleted_at = \? WHERE id = \? AND ` + inOrganization + ` AND deleted_at IS NULL`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(content_id).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(deletedAt, content_id, 1).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.DeleteContent(orgCtx, int64(content_id), deletedAt)
+	assert.NoError(t, err)
+}
+
+func TestRestoreContent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	updatedAt := time.Now().Unix()
+	query := `UPDATE contents SET deleted_at = NULL, updated_at = \? WHERE id = \? AND ` + inOrganization + ` AND deleted_at IS NOT NULL`
+	mock.ExpectExec(query).WithArgs(updatedAt, 12, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(updatedAt, 13, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.RestoreContent(orgCtx, 12, updatedAt)
+	assert.NoError(t, err)
+	err = repo.RestoreContent(orgCtx, 13, updatedAt)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestContentPurgeTrash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	deletedBefore := time.Now().Unix()
+	query := `DELETE FROM contents WHERE deleted_at IS NOT NULL AND deleted_at < \?`
+	mock.ExpectExec(query).WithArgs(deletedBefore).WillReturnResult(sqlmock.NewResult(0, 3))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.DeleteContent(context.TODO(), int64(content_id))
+	count, err := repo.PurgeTrash(orgCtx, deletedBefore)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
 }
 
 func TestUpdateContent(t *testing.T) {
@@ -96,13 +133,83 @@ func TestUpdateContent(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `UPDATE contents set title=\?,updated_at=\? WHERE ID = \?`
-	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(c.Title, c.UpdatedAt, c.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+	revision := &domain.ContentRevision{
+		AuthorID:  3,
+		Content:   &domain.Content{ID: 12, Title: "Basics"},
+		Diff:      "-Basics\n+Programming",
+		CreatedAt: c.UpdatedAt,
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM contents WHERE id = \? AND `+inOrganization+` AND deleted_at IS NULL`).
+		WithArgs(c.ID, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(revision\),0\) FROM content_revisions WHERE content_id = \? FOR UPDATE`).
+		WithArgs(c.ID).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(2))
+	mock.ExpectExec(`INSERT content_revisions SET content_id=\?,revision=\?,author_id=\?,snapshot=\?,diff=\?,created_at=\?`).
+		WithArgs(c.ID, 3, revision.AuthorID, sqlmock.AnyArg(), revision.Diff, revision.CreatedAt).WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec(`UPDATE contents set title=\?,description=\?,content=\?,embed_url=\?,caption=\?,updated_at=\? WHERE ID = \? AND deleted_at IS NULL`).
+		WithArgs(c.Title, c.Description, c.Content, c.EmbedURL, c.Caption, c.UpdatedAt, c.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectCommit()
 
 	repo := mysqlrepo.Init(db)
-	err = repo.UpdateContent(context.TODO(), c)
+	err = repo.UpdateContent(orgCtx, c, revision)
 	assert.NoError(t, err)
+	assert.Equal(t, 3, revision.Revision)
+	assert.Equal(t, int64(5), revision.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateContentRollback(t *testing.T) {
+	c := &domain.Content{ID: 12, Title: "Programming"}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM contents`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(revision\),0\) FROM content_revisions`).
+		WithArgs(c.ID).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(0))
+	mock.ExpectExec(`INSERT content_revisions`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE contents`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	repo := mysqlrepo.Init(db)
+	err = repo.UpdateContent(orgCtx, c, &domain.ContentRevision{Content: &domain.Content{ID: 12}})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetContentRevisions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "content_id", "revision", "author_id", "snapshot", "diff", "created_at"}).
+		AddRow(2, 12, 2, 3, `{"id":12,"title":"Basics"}`, "-a\n+b", time.Now().Unix()).
+		AddRow(1, 12, 1, nil, `{"id":12,"title":"Intro"}`, nil, time.Now().Unix())
+
+	query := `SELECT id,content_id,revision,author_id,snapshot,diff,created_at FROM content_revisions WHERE content_id = \? AND content_id IN \(.+\) ORDER BY revision DESC`
+	mock.ExpectQuery(query).WithArgs(12, 1).WillReturnRows(rows)
+	c := mysqlrepo.Init(db)
+	list, err := c.GetRevisions(orgCtx, 12)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "Basics", list[0].Content.Title)
+	assert.Equal(t, int64(0), list[1].AuthorID)
+}
+
+func TestGetContentRevisionNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "content_id", "revision", "author_id", "snapshot", "diff", "created_at"})
+
+	query := `SELECT id,content_id,revision,author_id,snapshot,diff,created_at FROM content_revisions WHERE content_id = \? AND revision = \? AND content_id IN \(.+\)`
+	mock.ExpectQuery(query).WithArgs(12, 4, 1).WillReturnRows(rows)
+	c := mysqlrepo.Init(db)
+	revision, err := c.GetRevision(orgCtx, 12, 4)
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.Nil(t, revision)
 }
 
 func TestGetContentByLesson(t *testing.T) {
@@ -110,13 +217,13 @@ func TestGetContentByLesson(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
-	row := sqlmock.NewRows([]string{"id", "title", "description", "updated_at", "created_at"}).
-		AddRow("1", "testing-2", "description", time.Now().Unix(), time.Now().Unix())
+	row := sqlmock.NewRows([]string{"id", "lesson_id", "title", "description", "content", "content_type", "name", "fileheader", "embed_url", "caption", "size", "updated_at", "created_at", "deleted_at"}).
+		AddRow("1", "2", "testing-2", "description", "body", "text", nil, nil, nil, nil, nil, time.Now().Unix(), time.Now().Unix(), nil)
 
-	query := `SELECT id,title,description,updated_at,created_at FROM contents WHERE lesson_id = \?`
+	query := `SELECT id,lesson_id,title,description,content,content_type,name,fileheader,embed_url,caption,size,updated_at,created_at,deleted_at FROM contents WHERE lesson_id = \? AND ` + inOrganization + ` AND deleted_at IS NULL`
 	mock.ExpectQuery(query).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	content, err := c.GetContentByLesson(context.TODO(), 1)
+	content, err := c.GetContentByLesson(orgCtx, 1)
 	assert.NoError(t, err)
 	assert.NotNil(t, content)
 }
@@ -129,10 +236,10 @@ func TestGetContentCountByLesson(t *testing.T) {
 	row := sqlmock.NewRows([]string{"count"}).
 		AddRow(10)
 
-	query := `SELECT count\(\*\) FROM contents WHERE lesson_id = \?`
+	query := `SELECT count\(\*\) FROM contents WHERE lesson_id = \? AND ` + inOrganization + ` AND deleted_at IS NULL`
 	mock.ExpectQuery(query).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	content, err := c.GetContentCountByLesson(context.TODO(), 1)
+	content, err := c.GetContentCountByLesson(orgCtx, 1)
 	assert.NoError(t, err)
 	assert.NotNil(t, content)
 }
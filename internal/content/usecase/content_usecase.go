@@ -7,22 +7,25 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/diff"
 	"github.com/meroedu/meroedu/pkg/log"
 )
 
 // ContentUseCase ...
 type ContentUseCase struct {
-	contentStore   domain.ContentStorage
-	contentRepo    domain.ContentRepository
-	contextTimeOut time.Duration
+	contentStore        domain.ContentStorage
+	contentRepo         domain.ContentRepository
+	collaboratorUseCase domain.CollaboratorUseCase
+	contextTimeOut      time.Duration
 }
 
-// NewContentUseCase will create new an
-func NewContentUseCase(c domain.ContentRepository, s domain.ContentStorage, timeout time.Duration) domain.ContentUseCase {
+// NewContentUseCase will create new an. Contents are only edited by the ones working on their course.
+func NewContentUseCase(c domain.ContentRepository, s domain.ContentStorage, cu domain.CollaboratorUseCase, timeout time.Duration) domain.ContentUseCase {
 	return &ContentUseCase{
-		contentRepo:    c,
-		contentStore:   s,
-		contextTimeOut: timeout,
+		contentRepo:         c,
+		contentStore:        s,
+		collaboratorUseCase: cu,
+		contextTimeOut:      timeout,
 	}
 }
 
@@ -56,6 +59,9 @@ func (usecase *ContentUseCase) GetByID(c context.Context, id int64) (res *domain
 func (usecase *ContentUseCase) CreateContent(c context.Context, content *domain.Content) (*domain.Content, error) {
 	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
 	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeLesson(ctx, content.LessonID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
 	if content.FileHeader != "" {
 		filename := getFileName(content.FileHeader)
 		if filename == "" {
@@ -82,20 +88,28 @@ func (usecase *ContentUseCase) CreateContent(c context.Context, content *domain.
 func (usecase *ContentUseCase) UpdateContent(c context.Context, content *domain.Content, id int64) (*domain.Content, error) {
 	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
 	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeContent(ctx, id, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
 	existingContent, err := usecase.GetByID(ctx, id)
 	if existingContent == nil {
 		return nil, domain.ErrNotFound
 	}
-	if content.FileHeader != "" {
-		filename := getFileName(content.FileHeader)
-		if filename == "" {
-			return nil, domain.ErrUnsupportedFileType
-		}
-		content.Name = filename
-	}
+	// files are only uploaded on creation, so the stored file and type never change
+	content.LessonID = existingContent.LessonID
+	content.ContentType = existingContent.ContentType
+	content.Name = existingContent.Name
+	content.FileHeader = existingContent.FileHeader
+	content.Size = existingContent.Size
 	content.ID = id
 	content.UpdatedAt = time.Now().Unix()
-	err = usecase.contentRepo.UpdateContent(ctx, content)
+	revision := &domain.ContentRevision{
+		AuthorID:  domain.UserIDFromContext(ctx),
+		Content:   existingContent,
+		Diff:      diff.Lines(existingContent.Content, content.Content),
+		CreatedAt: content.UpdatedAt,
+	}
+	err = usecase.contentRepo.UpdateContent(ctx, content, revision)
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +120,9 @@ func (usecase *ContentUseCase) UpdateContent(c context.Context, content *domain.
 func (usecase *ContentUseCase) DeleteContent(c context.Context, id int64) (err error) {
 	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
 	defer cancel()
+	if err = usecase.collaboratorUseCase.AuthorizeContent(ctx, id, domain.CollaboratorEditor); err != nil {
+		return err
+	}
 	existedTag, err := usecase.GetByID(ctx, id)
 	if err != nil {
 		return err
@@ -113,7 +130,7 @@ func (usecase *ContentUseCase) DeleteContent(c context.Context, id int64) (err e
 	if existedTag == nil {
 		return domain.ErrNotFound
 	}
-	return usecase.contentRepo.DeleteContent(ctx, id)
+	return usecase.contentRepo.DeleteContent(ctx, id, time.Now().Unix())
 }
 
 // GetContentByLesson ...
@@ -160,3 +177,80 @@ func (usecase *ContentUseCase) DownloadContent(ctx context.Context, fileName str
 	}
 	return filePath, nil
 }
+
+// GetRevisions ...
+func (usecase *ContentUseCase) GetRevisions(c context.Context, contentID int64) ([]domain.ContentRevision, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.contentRepo.GetRevisions(ctx, contentID)
+}
+
+// GetRevision ...
+func (usecase *ContentUseCase) GetRevision(c context.Context, contentID int64, revision int) (*domain.ContentRevision, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.contentRepo.GetRevision(ctx, contentID, revision)
+}
+
+// RestoreRevision will bring the content back to the state saved in the given revision.
+// The state being replaced is kept as a new revision, so a restore can be undone as well.
+func (usecase *ContentUseCase) RestoreRevision(c context.Context, contentID int64, revision int) (*domain.Content, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existingRevision, err := usecase.contentRepo.GetRevision(ctx, contentID, revision)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.UpdateContent(ctx, existingRevision.Content, contentID)
+}
+
+// GetTrash ...
+func (usecase *ContentUseCase) GetTrash(c context.Context, start int, limit int) ([]domain.Content, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.contentRepo.GetTrash(ctx, start, limit)
+}
+
+// RestoreContent will take a content out of the trash
+func (usecase *ContentUseCase) RestoreContent(c context.Context, id int64) (*domain.Content, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeContent(ctx, id, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	if err := usecase.contentRepo.RestoreContent(ctx, id, time.Now().Unix()); err != nil {
+		return nil, err
+	}
+	return usecase.contentRepo.GetByID(ctx, id)
+}
+
+// PurgeTrash will permanently remove the contents trashed before deletedBefore
+func (usecase *ContentUseCase) PurgeTrash(c context.Context, deletedBefore int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.contentRepo.PurgeTrash(ctx, deletedBefore)
+}
+
+// BulkAction will run the same action on many contents at once. Only delete is supported.
+// The contents of courses the caller does not work on fail.
+func (usecase *ContentUseCase) BulkAction(c context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if action.Action != domain.BulkDelete {
+		return nil, domain.ErrBadParamInput
+	}
+	allowed, denied, err := domain.FilterBulk(action.IDs, func(id int64) error {
+		return usecase.collaboratorUseCase.AuthorizeContent(ctx, id, domain.CollaboratorEditor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(allowed) == 0 {
+		return denied, nil
+	}
+	results, err := usecase.contentRepo.BulkDelete(ctx, allowed, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	return domain.MergeBulk(action.IDs, denied, results), nil
+}
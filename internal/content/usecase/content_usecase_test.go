@@ -13,6 +13,15 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// anyCollaborator returns a collaborator usecase giving the caller every access
+func anyCollaborator() *mocks.CollaboratorUseCase {
+	m := new(mocks.CollaboratorUseCase)
+	m.On("AuthorizeCourse", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("AuthorizeLesson", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("AuthorizeContent", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
 func TestGetAll(t *testing.T) {
 	mockContentRepo := new(mocks.ContentRepository)
 	mockContentStore := new(mocks.ContentStorage)
@@ -27,7 +36,7 @@ func TestGetAll(t *testing.T) {
 
 		start := int(0)
 		limit := int(1)
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 		list, err := u.GetAll(context.TODO(), start, limit)
 		assert.NoError(t, err)
 		assert.Len(t, list, len(mockListContent))
@@ -38,7 +47,7 @@ func TestGetAll(t *testing.T) {
 		mockContentRepo.On("GetAll", mock.Anything, mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(nil, errors.New("Unexpected Error")).Once()
 
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 		start := int(0)
 		limit := int(1)
 		list, err := u.GetAll(context.TODO(), start, limit)
@@ -56,7 +65,7 @@ func TestGetByID(t *testing.T) {
 	}
 	t.Run("success", func(t *testing.T) {
 		mockContentRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(&mockContent, nil).Once()
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 
 		a, err := u.GetByID(context.TODO(), mockContent.ID)
 
@@ -68,7 +77,7 @@ func TestGetByID(t *testing.T) {
 	t.Run("error-failed", func(t *testing.T) {
 		mockContentRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, errors.New("Unexpected")).Once()
 
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 
 		a, err := u.GetByID(context.TODO(), mockContent.ID)
 
@@ -89,7 +98,7 @@ func TestCreateContent(t *testing.T) {
 		tempmockContent := mockContent
 		tempmockContent.ID = 0
 		mockContentRepo.On("CreateContent", mock.Anything, mock.AnythingOfType("*domain.Content")).Return(nil).Once()
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 
 		content, err := u.CreateContent(context.TODO(), &tempmockContent)
 
@@ -99,7 +108,7 @@ func TestCreateContent(t *testing.T) {
 	})
 	t.Run("error-failed", func(t *testing.T) {
 		mockContentRepo.On("CreateContent", mock.Anything, mock.AnythingOfType("*domain.Content")).Return(errors.New("unexpected error occur")).Once()
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 
 		content, err := u.CreateContent(context.TODO(), &mockContent)
 
@@ -119,8 +128,8 @@ func TestUpdateContent(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		tempmockContent := mockContent
 		mockContentRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(&mockContent, nil).Once()
-		mockContentRepo.On("UpdateContent", mock.Anything, mock.AnythingOfType("*domain.Content")).Return(nil).Once()
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		mockContentRepo.On("UpdateContent", mock.Anything, mock.AnythingOfType("*domain.Content"), mock.AnythingOfType("*domain.ContentRevision")).Return(nil).Once()
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 
 		content, err := u.UpdateContent(context.TODO(), &tempmockContent, tempmockContent.ID)
 
@@ -131,8 +140,8 @@ func TestUpdateContent(t *testing.T) {
 	})
 	t.Run("error-failed", func(t *testing.T) {
 		mockContentRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, nil).Once()
-		mockContentRepo.On("UpdateContent", mock.Anything, mock.AnythingOfType("*domain.Content")).Return(domain.ErrNotFound).Once()
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		mockContentRepo.On("UpdateContent", mock.Anything, mock.AnythingOfType("*domain.Content"), mock.AnythingOfType("*domain.ContentRevision")).Return(domain.ErrNotFound).Once()
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 
 		content, err := u.UpdateContent(context.TODO(), &mockContent, mockContent.ID)
 
@@ -152,9 +161,9 @@ func TestDeleteContent(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockContentRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(&mockContent, nil).Once()
 
-		mockContentRepo.On("DeleteContent", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Once()
+		mockContentRepo.On("DeleteContent", mock.Anything, mock.AnythingOfType("int64"), mock.AnythingOfType("int64")).Return(nil).Once()
 
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 
 		err := u.DeleteContent(context.TODO(), mockContent.ID)
 
@@ -164,7 +173,7 @@ func TestDeleteContent(t *testing.T) {
 	t.Run("content-is-not-exist", func(t *testing.T) {
 		mockContentRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, nil).Once()
 
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 
 		err := u.DeleteContent(context.TODO(), mockContent.ID)
 
@@ -174,7 +183,7 @@ func TestDeleteContent(t *testing.T) {
 	t.Run("error-happens-in-db", func(t *testing.T) {
 		mockContentRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, errors.New("Unexpected Error")).Once()
 
-		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, time.Second*2)
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
 
 		err := u.DeleteContent(context.TODO(), mockContent.ID)
 
@@ -182,3 +191,51 @@ func TestDeleteContent(t *testing.T) {
 		mockContentRepo.AssertExpectations(t)
 	})
 }
+
+func TestRestoreRevision(t *testing.T) {
+	mockContentRepo := new(mocks.ContentRepository)
+	mockContentStore := new(mocks.ContentStorage)
+	current := domain.Content{ID: 1, LessonID: 4, Title: "Current", Content: "line 1\nline 2"}
+	previous := domain.Content{ID: 1, LessonID: 4, Title: "Previous", Content: "line 1"}
+
+	t.Run("success", func(t *testing.T) {
+		mockContentRepo.On("GetRevision", mock.Anything, int64(1), 2).Return(&domain.ContentRevision{ContentID: 1, Revision: 2, Content: &previous}, nil).Once()
+		mockContentRepo.On("GetByID", mock.Anything, int64(1)).Return(&current, nil).Once()
+		mockContentRepo.On("UpdateContent", mock.Anything, mock.AnythingOfType("*domain.Content"), mock.MatchedBy(func(revision *domain.ContentRevision) bool {
+			return revision.AuthorID == 7 && revision.Content.Title == "Current" && revision.Diff == " line 1\n-line 2\n"
+		})).Return(nil).Once()
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
+
+		content, err := u.RestoreRevision(domain.WithUserID(context.TODO(), 7), 1, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Previous", content.Title)
+		mockContentRepo.AssertExpectations(t)
+	})
+	t.Run("revision-is-not-exist", func(t *testing.T) {
+		mockContentRepo.On("GetRevision", mock.Anything, int64(1), 9).Return(nil, domain.ErrNotFound).Once()
+		u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, anyCollaborator(), time.Second*2)
+
+		content, err := u.RestoreRevision(context.TODO(), 1, 9)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.Nil(t, content)
+		mockContentRepo.AssertExpectations(t)
+	})
+}
+
+func TestContentAuthorship(t *testing.T) {
+	mockContentRepo := new(mocks.ContentRepository)
+	mockContentStore := new(mocks.ContentStorage)
+	mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+	mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(3), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+	mockCollaboratorUseCase.On("AuthorizeContent", mock.Anything, int64(5), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+	u := ucase.NewContentUseCase(mockContentRepo, mockContentStore, mockCollaboratorUseCase, time.Second*2)
+
+	_, err := u.CreateContent(context.TODO(), &domain.Content{LessonID: 3, Title: "Slides"})
+	assert.Equal(t, domain.ErrForbidden, err)
+	err = u.DeleteContent(context.TODO(), 5)
+	assert.Equal(t, domain.ErrForbidden, err)
+	mockContentRepo.AssertNotCalled(t, "CreateContent", mock.Anything, mock.Anything)
+	mockContentRepo.AssertNotCalled(t, "DeleteContent", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
 	"github.com/meroedu/meroedu/internal/util"
 )
 
@@ -27,28 +28,31 @@ func NewCourseHandler(e *echo.Echo, us domain.CourseUseCase) {
 		CourseUseCase: us,
 	}
 	// Get Operation
-	e.GET("/courses", handler.GetAll)
-	e.GET("/courses/:id", handler.GetByID)
-	e.GET("/courses/:id/stats", handler.GetByID)
-	e.GET("/courses/:id/lessons", handler.GetByID)
-	e.GET("/courses/:id/users", handler.GetByID)
-	e.GET("/courses/:id/teams", handler.GetByID)
+	e.GET("/courses", handler.GetAll, rbac.Require(domain.PermCourseView))
+	e.GET("/courses/trash", handler.GetTrash, rbac.Require(domain.PermCourseDelete))
+	e.GET("/courses/:id", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.GET("/courses/:id/stats", handler.GetByID, rbac.Require(domain.PermReportView))
+	e.GET("/courses/:id/lessons", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.GET("/courses/:id/versions", handler.GetVersions, rbac.Require(domain.PermCourseView))
+	e.GET("/courses/:id/versions/:version", handler.GetVersion, rbac.Require(domain.PermCourseView))
+	e.GET("/courses/:id/published", handler.GetPublishedVersion, rbac.Require(domain.PermCourseView))
 
 	// Create/Add Operation
-	e.POST("/courses", handler.CreateCourse)
-	e.POST("/courses/import", handler.GetByID)
-	e.POST("/courses/:id/lessons", handler.GetByID)
-	e.POST("/courses/:id/users", handler.GetByID)
-	e.POST("/courses/:id/teams", handler.GetByID)
+	e.POST("/courses", handler.CreateCourse, rbac.Require(domain.PermCourseCreate))
+	e.POST("/courses/import", handler.GetByID, rbac.Require(domain.PermCourseCreate))
+	e.POST("/courses/:id/lessons", handler.GetByID, rbac.Require(domain.PermCourseUpdate))
+	e.POST("/courses/:id/publish", handler.PublishCourse, rbac.Require(domain.PermCoursePublish))
+	e.POST("/courses/:id/clone", handler.CloneCourse, rbac.Require(domain.PermCourseCreate))
+	e.POST("/courses/:id/restore", handler.RestoreCourse, rbac.Require(domain.PermCourseDelete))
 
 	// Update Operation
-	e.PUT("/courses/:id", handler.UpdateCourse)
-	e.PUT("/courses/:id/lessons/:id", handler.GetByID)
-	e.PUT("/courses/actions", handler.GetByID)
+	e.PUT("/courses/:id", handler.UpdateCourse, rbac.Require(domain.PermCourseUpdate))
+	e.PUT("/courses/:id/lessons/:id", handler.GetByID, rbac.Require(domain.PermCourseUpdate))
+	e.PUT("/courses/actions", handler.BulkAction, rbac.Require(domain.PermCourseUpdate, domain.PermCourseDelete, domain.PermCoursePublish))
 
 	// Remove/Delete Operation
-	e.DELETE("/courses/:id", handler.DeleteCourse)
-	e.DELETE("/courses/:id/lessons/:id", handler.GetByID)
+	e.DELETE("/courses/:id", handler.DeleteCourse, rbac.Require(domain.PermCourseDelete))
+	e.DELETE("/courses/:id/lessons/:id", handler.GetByID, rbac.Require(domain.PermCourseUpdate))
 }
 
 // GetAll godoc
@@ -226,3 +230,283 @@ func (c *CourseHandler) DeleteCourse(echoContext echo.Context) error {
 
 	return echoContext.NoContent(http.StatusNoContent)
 }
+
+// CloneCourse godoc
+// @Summary Clone existing Course
+// @Description Copy a course with its lessons, contents, tags and attachments into a new draft course
+// @Tags courses
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param course body domain.CourseClone true "Clone Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/clone [post]
+func (c *CourseHandler) CloneCourse(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var clone domain.CourseClone
+	err = echoContext.Bind(&clone)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&clone); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	course, err := c.CourseUseCase.CloneCourse(ctx, int64(idParam), clone.Title)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    course,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusCreated, res)
+}
+
+// PublishCourse godoc
+// @Summary Publish a new Course version
+// @Description Snapshot the current draft of a course as a new published version
+// @Tags courses
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param publish body domain.CoursePublish true "Publish Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/publish [post]
+func (c *CourseHandler) PublishCourse(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var publish domain.CoursePublish
+	err = echoContext.Bind(&publish)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&publish); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	version, err := c.CourseUseCase.PublishCourse(ctx, int64(idParam), publish.ChangeNote)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    version,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusCreated, res)
+}
+
+// GetVersions godoc
+// @Summary Get published versions of a Course.
+// @Description Get the list of published versions with their change notes.
+// @Tags courses
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Success 200 {object} domain.Summaries
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/versions [get]
+func (c *CourseHandler) GetVersions(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	versions, err := c.CourseUseCase.GetVersions(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    versions,
+		},
+		Total: int64(len(versions)),
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetVersion godoc
+// @Summary Get a published Course version.
+// @Description Get the snapshot of a specific published course version.
+// @Tags courses
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param version path int true "Version number"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/versions/{version} [get]
+func (c *CourseHandler) GetVersion(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	versionParam, err := strconv.Atoi(echoContext.Param("version"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	version, err := c.CourseUseCase.GetVersion(ctx, int64(idParam), versionParam)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    version,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetPublishedVersion godoc
+// @Summary Get the published Course.
+// @Description Get the snapshot of the latest published course version, as seen by learners.
+// @Tags courses
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Course is not published yet"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/published [get]
+func (c *CourseHandler) GetPublishedVersion(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	version, err := c.CourseUseCase.GetPublishedVersion(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    version,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetTrash godoc
+// @Summary Get Courses in the trash.
+// @Description Get deleted courses, most recently deleted first. They are purged permanently after the retention period.
+// @Tags courses
+// @Accept */*
+// @Produce json
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/trash [get]
+func (c *CourseHandler) GetTrash(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	start, limit := 0, 10
+	var err error
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		}
+	}
+
+	list, err := c.CourseUseCase.GetTrash(ctx, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// RestoreCourse godoc
+// @Summary Restore a Course from the trash.
+// @Description Restore a deleted course.
+// @Tags courses
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 409 {object} domain.APIResponseError "Another course has the same title"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/restore [post]
+func (c *CourseHandler) RestoreCourse(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	course, err := c.CourseUseCase.RestoreCourse(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    course,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// BulkAction godoc
+// @Summary Run an action on many courses.
+// @Description Run publish, archive, delete, assign_category, add_tag or remove_tag on a list of course IDs in a single transaction. The outcome is reported for each ID.
+// @Tags courses
+// @Accept json
+// @Produce json
+// @Param action body domain.BulkAction true "Action and IDs"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Unsupported action or missing parameter"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/actions [put]
+func (c *CourseHandler) BulkAction(echoContext echo.Context) error {
+	var action domain.BulkAction
+	err := echoContext.Bind(&action)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&action); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	results, err := c.CourseUseCase.BulkAction(ctx, &action)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    results,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
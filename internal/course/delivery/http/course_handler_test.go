@@ -197,3 +197,191 @@ func TestDeleteCourse(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 
 }
+
+func TestCloneCourse(t *testing.T) {
+	mockUCase := new(mocks.CourseUseCase)
+	j, err := json.Marshal(domain.CourseClone{Title: "Title (Spring)"})
+	assert.NoError(t, err)
+	mockUCase.On("CloneCourse", mock.Anything, int64(124), "Title (Spring)").Return(&domain.Course{ID: 125, Title: "Title (Spring)"}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/courses/124/clone", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/clone")
+	c.SetParamNames("id")
+	c.SetParamValues("124")
+
+	handler := courseHTTP.CourseHandler{
+		CourseUseCase: mockUCase,
+	}
+	err = handler.CloneCourse(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestPublishCourse(t *testing.T) {
+	mockUCase := new(mocks.CourseUseCase)
+	j, err := json.Marshal(domain.CoursePublish{ChangeNote: "Added a lesson"})
+	assert.NoError(t, err)
+	mockUCase.On("PublishCourse", mock.Anything, int64(124), "Added a lesson").Return(&domain.CourseVersion{ID: 1, CourseID: 124, Version: 1}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/courses/124/publish", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/publish")
+	c.SetParamNames("id")
+	c.SetParamValues("124")
+
+	handler := courseHTTP.CourseHandler{
+		CourseUseCase: mockUCase,
+	}
+	err = handler.PublishCourse(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetVersion(t *testing.T) {
+	mockUCase := new(mocks.CourseUseCase)
+	mockUCase.On("GetVersion", mock.Anything, int64(124), 2).Return(&domain.CourseVersion{ID: 1, CourseID: 124, Version: 2}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/courses/124/versions/2", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/versions/:version")
+	c.SetParamNames("id", "version")
+	c.SetParamValues("124", "2")
+
+	handler := courseHTTP.CourseHandler{
+		CourseUseCase: mockUCase,
+	}
+	err = handler.GetVersion(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetPublishedVersionNotPublished(t *testing.T) {
+	mockUCase := new(mocks.CourseUseCase)
+	mockUCase.On("GetPublishedVersion", mock.Anything, int64(124)).Return(nil, domain.ErrCourseNotPublished)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/courses/124/published", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/published")
+	c.SetParamNames("id")
+	c.SetParamValues("124")
+
+	handler := courseHTTP.CourseHandler{
+		CourseUseCase: mockUCase,
+	}
+	err = handler.GetPublishedVersion(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetTrash(t *testing.T) {
+	mockUCase := new(mocks.CourseUseCase)
+	mockUCase.On("GetTrash", mock.Anything, 0, 10).Return([]domain.Course{{ID: 124, Title: "Title", DeletedAt: time.Now().Unix()}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/courses/trash?start=0&limit=10", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := courseHTTP.CourseHandler{
+		CourseUseCase: mockUCase,
+	}
+	err = handler.GetTrash(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRestoreCourseConflict(t *testing.T) {
+	mockUCase := new(mocks.CourseUseCase)
+	mockUCase.On("RestoreCourse", mock.Anything, int64(124)).Return(nil, domain.ErrConflict)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/courses/124/restore", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/restore")
+	c.SetParamNames("id")
+	c.SetParamValues("124")
+
+	handler := courseHTTP.CourseHandler{
+		CourseUseCase: mockUCase,
+	}
+	err = handler.RestoreCourse(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestBulkAction(t *testing.T) {
+	mockUCase := new(mocks.CourseUseCase)
+	j, err := json.Marshal(domain.BulkAction{Action: domain.BulkDelete, IDs: []int64{1, 2}})
+	assert.NoError(t, err)
+	mockUCase.On("BulkAction", mock.Anything, &domain.BulkAction{Action: domain.BulkDelete, IDs: []int64{1, 2}}).
+		Return([]domain.BulkResult{{ID: 1, Success: true}, {ID: 2, Success: true}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/courses/actions", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/actions")
+
+	handler := courseHTTP.CourseHandler{
+		CourseUseCase: mockUCase,
+	}
+	err = handler.BulkAction(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestBulkActionWithoutIDs(t *testing.T) {
+	mockUCase := new(mocks.CourseUseCase)
+	j, err := json.Marshal(domain.BulkAction{Action: domain.BulkDelete})
+	assert.NoError(t, err)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/courses/actions", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/actions")
+
+	handler := courseHTTP.CourseHandler{
+		CourseUseCase: mockUCase,
+	}
+	err = handler.BulkAction(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertNotCalled(t, "BulkAction", mock.Anything, mock.Anything)
+}
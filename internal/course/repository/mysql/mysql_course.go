@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/repository/sqlbulk"
 	"github.com/meroedu/meroedu/pkg/log"
 )
 
@@ -36,7 +37,7 @@ func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...inter
 	result = make([]domain.Course, 0)
 	for rows.Next() {
 		t := domain.Course{}
-		authorID := int64(0)
+		deletedAt := sql.NullInt64{}
 		err = rows.Scan(
 			&t.ID,
 			&t.Title,
@@ -48,13 +49,15 @@ func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...inter
 			&t.CategoryID,
 			&t.UpdatedAt,
 			&t.CreatedAt,
+			&deletedAt,
 		)
 		if err != nil {
 			log.Error(err)
 			return nil, err
 		}
+		t.DeletedAt = deletedAt.Int64
 		t.Author = domain.User{
-			ID: authorID,
+			ID: t.AuthorID.Int64,
 		}
 		result = append(result, t)
 	}
@@ -63,18 +66,18 @@ func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...inter
 }
 
 func (m *mysqlRepository) GetAll(ctx context.Context, start int, limit int) (res []domain.Course, err error) {
-	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at FROM courses ORDER BY created_at DESC LIMIT ?,? `
+	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at,deleted_at FROM courses WHERE organization_id = ? AND deleted_at IS NULL ORDER BY created_at DESC LIMIT ?,?`
 
-	res, err = m.fetch(ctx, query, start, limit)
+	res, err = m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.Course, error) {
-	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at FROM courses WHERE ID = ?`
+	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at,deleted_at FROM courses WHERE ID = ? AND organization_id = ? AND deleted_at IS NULL`
 
-	list, err := m.fetch(ctx, query, id)
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -89,9 +92,9 @@ func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.Course
 }
 
 func (m *mysqlRepository) GetByTitle(ctx context.Context, title string) (*domain.Course, error) {
-	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at FROM courses WHERE title = ?`
+	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at,deleted_at FROM courses WHERE title = ? AND organization_id = ? AND deleted_at IS NULL`
 
-	list, err := m.fetch(ctx, query, title)
+	list, err := m.fetch(ctx, query, title, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -104,8 +107,14 @@ func (m *mysqlRepository) GetByTitle(ctx context.Context, title string) (*domain
 	return &course, nil
 }
 
+// CreateCourse creates the course in the caller's organization. The category must belong to the same organization.
 func (m *mysqlRepository) CreateCourse(ctx context.Context, a *domain.Course) (err error) {
-	query := `INSERT courses SET title=?,description=?,duration=?,status=?,image_url=?,author_id=?,category_id=?,updated_at=?,created_at=?`
+	if a.Category.ID != 0 {
+		if err = m.checkExists(ctx, `SELECT count(*) FROM categories WHERE id = ? AND organization_id = ?`, a.Category.ID); err != nil {
+			return
+		}
+	}
+	query := `INSERT courses SET title=?,description=?,duration=?,status=?,image_url=?,author_id=?,category_id=?,organization_id=?,updated_at=?,created_at=?`
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		log.Error("Error while preparing statement ", err)
@@ -127,6 +136,7 @@ func (m *mysqlRepository) CreateCourse(ctx context.Context, a *domain.Course) (e
 	} else {
 		fields = append(fields, a.Category.ID)
 	}
+	fields = append(fields, domain.OrganizationIDFromContext(ctx))
 
 	fields = append(fields, a.CreatedAt)
 	fields = append(fields, a.UpdatedAt)
@@ -145,15 +155,17 @@ func (m *mysqlRepository) CreateCourse(ctx context.Context, a *domain.Course) (e
 	return
 }
 
-func (m *mysqlRepository) DeleteCourse(ctx context.Context, id int64) (err error) {
-	query := "DELETE FROM courses WHERE id = ?"
+// DeleteCourse moves the course to the trash. Its lessons, contents, tags and attachments are kept
+// untouched until the course is purged.
+func (m *mysqlRepository) DeleteCourse(ctx context.Context, id int64, deletedAt int64) (err error) {
+	query := "UPDATE courses SET deleted_at = ? WHERE id = ? AND organization_id = ? AND deleted_at IS NULL"
 
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	res, err := stmt.ExecContext(ctx, deletedAt, id, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return
 	}
@@ -170,15 +182,16 @@ func (m *mysqlRepository) DeleteCourse(ctx context.Context, id int64) (err error
 
 	return
 }
+
 func (m *mysqlRepository) UpdateCourse(ctx context.Context, ar *domain.Course) (err error) {
-	query := `UPDATE courses set title=?,description=?,updated_at=? WHERE ID = ?`
+	query := `UPDATE courses set title=?,description=?,updated_at=? WHERE ID = ? AND organization_id = ? AND deleted_at IS NULL`
 
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, ar.Title, ar.Description, ar.UpdatedAt, ar.ID)
+	res, err := stmt.ExecContext(ctx, ar.Title, ar.Description, ar.UpdatedAt, ar.ID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return
 	}
@@ -195,9 +208,9 @@ func (m *mysqlRepository) UpdateCourse(ctx context.Context, ar *domain.Course) (
 }
 
 func (m *mysqlRepository) GetCourseCount(ctx context.Context) (count int64, err error) {
-	query := `SELECT count(*) FROM courses`
+	query := `SELECT count(*) FROM courses WHERE organization_id = ? AND deleted_at IS NULL`
 
-	rows, err := m.conn.QueryContext(ctx, query)
+	rows, err := m.conn.QueryContext(ctx, query, domain.OrganizationIDFromContext(ctx))
 	defer rows.Close()
 	if err != nil {
 		log.Error(err)
@@ -210,3 +223,263 @@ func (m *mysqlRepository) GetCourseCount(ctx context.Context) (count int64, err
 	}
 	return
 }
+
+// CloneCourse copies the course identified by id together with its lessons, contents,
+// course and lesson tags and attachments in a single transaction. Stored files are shared
+// by reference, since uploaded file names are unique and never overwritten.
+func (m *mysqlRepository) CloneCourse(ctx context.Context, id int64, course *domain.Course) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `INSERT INTO courses (title,description,long_description,image_url,duration,author_id,category_id,organization_id,status,updated_at,created_at)
+		SELECT ?,description,long_description,image_url,duration,?,category_id,organization_id,?,?,? FROM courses WHERE id = ? AND organization_id = ? AND deleted_at IS NULL`
+	authorID := sql.NullInt64{Int64: course.Author.ID, Valid: course.Author.ID != 0}
+	res, err := tx.ExecContext(ctx, query, course.Title, authorID, course.Status, course.UpdatedAt, course.CreatedAt, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = domain.ErrNotFound
+		return
+	}
+	course.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return
+	}
+
+	query = `INSERT INTO courses_tags (course_id,tag_id,created_at) SELECT ?,tag_id,? FROM courses_tags WHERE course_id = ?`
+	if _, err = tx.ExecContext(ctx, query, course.ID, course.CreatedAt, id); err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+
+	query = `INSERT INTO attachments (title,description,name,size,type,course_id,organization_id,status,updated_at,created_at)
+		SELECT title,description,name,size,type,?,organization_id,status,?,? FROM attachments WHERE course_id = ?`
+	if _, err = tx.ExecContext(ctx, query, course.ID, course.UpdatedAt, course.CreatedAt, id); err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+
+	lessonIDs, err := fetchIDs(ctx, tx, "SELECT id FROM lessons WHERE course_id = ? AND deleted_at IS NULL ORDER BY `order`,id", id)
+	if err != nil {
+		return
+	}
+	for _, lessonID := range lessonIDs {
+		if err = cloneLesson(ctx, tx, lessonID, course); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func cloneLesson(ctx context.Context, tx *sql.Tx, lessonID int64, course *domain.Course) error {
+	query := "INSERT INTO lessons (title,description,course_id,`order`,status,updated_at,created_at) " +
+		"SELECT title,description,?,`order`,status,?,? FROM lessons WHERE id = ?"
+	res, err := tx.ExecContext(ctx, query, course.ID, course.UpdatedAt, course.CreatedAt, lessonID)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	newLessonID, err := res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return err
+	}
+
+	query = "INSERT INTO contents (title,description,content,content_type,name,fileheader,embed_url,image_url,lesson_id,size,caption,`order`,updated_at,created_at) " +
+		"SELECT title,description,content,content_type,name,fileheader,embed_url,image_url,?,size,caption,`order`,?,? FROM contents WHERE lesson_id = ? AND deleted_at IS NULL ORDER BY id"
+	if _, err = tx.ExecContext(ctx, query, newLessonID, course.UpdatedAt, course.CreatedAt, lessonID); err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+
+	query = `INSERT INTO lessons_tags (lesson_id,tag_id,created_at) SELECT ?,tag_id,? FROM lessons_tags WHERE lesson_id = ?`
+	if _, err = tx.ExecContext(ctx, query, newLessonID, course.CreatedAt, lessonID); err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	return nil
+}
+
+func fetchIDs(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// GetTrash returns the courses in the trash, most recently deleted first.
+func (m *mysqlRepository) GetTrash(ctx context.Context, start int, limit int) ([]domain.Course, error) {
+	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at,deleted_at FROM courses WHERE organization_id = ? AND deleted_at IS NOT NULL ORDER BY deleted_at DESC LIMIT ?,?`
+	return m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
+}
+
+// GetTrashedByID returns a course from the trash.
+func (m *mysqlRepository) GetTrashedByID(ctx context.Context, id int64) (*domain.Course, error) {
+	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at,deleted_at FROM courses WHERE ID = ? AND organization_id = ? AND deleted_at IS NOT NULL`
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// RestoreCourse takes the course out of the trash.
+func (m *mysqlRepository) RestoreCourse(ctx context.Context, id int64, updatedAt int64) error {
+	query := `UPDATE courses SET deleted_at = NULL, updated_at = ? WHERE id = ? AND organization_id = ? AND deleted_at IS NOT NULL`
+	res, err := m.conn.ExecContext(ctx, query, updatedAt, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// PurgeTrash permanently removes the courses of every organization trashed before the given time.
+// Lessons, contents, tags, attachments, versions and enrollments are removed by the foreign key cascades.
+func (m *mysqlRepository) PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error) {
+	query := `DELETE FROM courses WHERE deleted_at IS NOT NULL AND deleted_at < ?`
+	res, err := m.conn.ExecContext(ctx, query, deletedBefore)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// BulkAction runs an archive, delete, assign_category, add_tag or remove_tag action on many courses
+// in a single transaction.
+func (m *mysqlRepository) BulkAction(ctx context.Context, action *domain.BulkAction, updatedAt int64) ([]domain.BulkResult, error) {
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	var fn func(tx *sql.Tx, id int64) error
+	switch action.Action {
+	case domain.BulkArchive:
+		fn = func(tx *sql.Tx, id int64) error {
+			query := `UPDATE courses SET status=?,updated_at=? WHERE id = ? AND organization_id = ? AND deleted_at IS NULL`
+			return sqlbulk.ExecItem(ctx, tx, query, domain.CourseArchived, updatedAt, id, organizationID)
+		}
+	case domain.BulkDelete:
+		fn = func(tx *sql.Tx, id int64) error {
+			query := `UPDATE courses SET deleted_at=? WHERE id = ? AND organization_id = ? AND deleted_at IS NULL`
+			return sqlbulk.ExecItem(ctx, tx, query, updatedAt, id, organizationID)
+		}
+	case domain.BulkAssignCategory:
+		if err := m.checkExists(ctx, `SELECT count(*) FROM categories WHERE id = ? AND organization_id = ?`, action.CategoryID); err != nil {
+			return nil, err
+		}
+		fn = func(tx *sql.Tx, id int64) error {
+			query := `UPDATE courses SET category_id=?,updated_at=? WHERE id = ? AND organization_id = ? AND deleted_at IS NULL`
+			return sqlbulk.ExecItem(ctx, tx, query, action.CategoryID, updatedAt, id, organizationID)
+		}
+	case domain.BulkAddTag:
+		if err := m.checkExists(ctx, `SELECT count(*) FROM tags WHERE id = ? AND organization_id = ?`, action.TagID); err != nil {
+			return nil, err
+		}
+		fn = func(tx *sql.Tx, id int64) error {
+			query := `INSERT INTO courses_tags (course_id,tag_id,created_at) SELECT id,?,? FROM courses
+				WHERE id = ? AND organization_id = ? AND deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM courses_tags WHERE course_id = ? AND tag_id = ?)`
+			err := sqlbulk.ExecItem(ctx, tx, query, action.TagID, updatedAt, id, organizationID, id, action.TagID)
+			if err == domain.ErrNotFound {
+				// nothing inserted: either the course is missing or it already has the tag
+				return courseExists(ctx, tx, id)
+			}
+			return err
+		}
+	case domain.BulkRemoveTag:
+		fn = func(tx *sql.Tx, id int64) error {
+			if err := courseExists(ctx, tx, id); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `DELETE FROM courses_tags WHERE course_id = ? AND tag_id = ?`, id, action.TagID)
+			return err
+		}
+	default:
+		return nil, domain.ErrBadParamInput
+	}
+	return sqlbulk.Run(ctx, m.conn, action.IDs, fn)
+}
+
+// BulkPublish stores the snapshots as the next versions of their courses in a single transaction.
+func (m *mysqlRepository) BulkPublish(ctx context.Context, versions []*domain.CourseVersion) ([]domain.BulkResult, error) {
+	ids := make([]int64, 0, len(versions))
+	byCourse := make(map[int64]*domain.CourseVersion, len(versions))
+	for _, v := range versions {
+		ids = append(ids, v.CourseID)
+		byCourse[v.CourseID] = v
+	}
+	return sqlbulk.Run(ctx, m.conn, ids, func(tx *sql.Tx, id int64) error {
+		return publishCourse(ctx, tx, byCourse[id])
+	})
+}
+
+// checkExists runs a count query taking the id and the caller's organization
+func (m *mysqlRepository) checkExists(ctx context.Context, query string, id int64) error {
+	var count int
+	if err := m.conn.QueryRowContext(ctx, query, id, domain.OrganizationIDFromContext(ctx)).Scan(&count); err != nil {
+		log.Error(err)
+		return err
+	}
+	if count == 0 {
+		return domain.ErrBadParamInput
+	}
+	return nil
+}
+
+func courseExists(ctx context.Context, tx *sql.Tx, id int64) error {
+	var count int
+	query := `SELECT count(*) FROM courses WHERE id = ? AND organization_id = ? AND deleted_at IS NULL`
+	if err := tx.QueryRowContext(ctx, query, id, domain.OrganizationIDFromContext(ctx)).Scan(&count); err != nil {
+		log.Error(err)
+		return err
+	}
+	if count == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
+var orgCtx = domain.WithOrganizationID(context.TODO(), 1)
+
 func TestGetAll(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -26,14 +28,14 @@ func TestGetAll(t *testing.T) {
 			UpdatedAt:   time.Now().Unix(), CreatedAt: time.Now().Unix(),
 		},
 	}
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "duration", "image_url", "status", "author_id", "category_id", "updated_at", "created_at"}).
-		AddRow(mockCourses[0].ID, mockCourses[0].Title, mockCourses[0].Description, 20, "https://", domain.CourseInDraft, mockCourses[0].Author.ID, mockCourses[0].Category.ID, mockCourses[0].UpdatedAt, mockCourses[0].CreatedAt)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "duration", "image_url", "status", "author_id", "category_id", "updated_at", "created_at", "deleted_at"}).
+		AddRow(mockCourses[0].ID, mockCourses[0].Title, mockCourses[0].Description, 20, "https://", domain.CourseInDraft, mockCourses[0].Author.ID, mockCourses[0].Category.ID, mockCourses[0].UpdatedAt, mockCourses[0].CreatedAt, nil)
 
-	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at FROM courses ORDER BY created_at DESC LIMIT \?,\?`
+	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at,deleted_at FROM courses WHERE organization_id = \? AND deleted_at IS NULL ORDER BY created_at DESC LIMIT \?,\?`
 	mock.ExpectQuery(query).WillReturnRows(rows)
 	c := mysqlrepo.Init(db)
 	start, limit := 0, 10
-	list, err := c.GetAll(context.TODO(), start, limit)
+	list, err := c.GetAll(orgCtx, start, limit)
 	assert.NoError(t, err)
 	assert.Len(t, list, 1)
 
@@ -44,13 +46,13 @@ func TestGetByID(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
-	row := sqlmock.NewRows([]string{"id", "title", "description", "duration", "image_url", "status", "author_id", "category_id", "updated_at", "created_at"}).
-		AddRow("1", "testing-2", "description", 20, "https://gogole.com/3432.jpg", domain.CourseInDraft, 0, 0, time.Now().Unix(), time.Now().Unix())
+	row := sqlmock.NewRows([]string{"id", "title", "description", "duration", "image_url", "status", "author_id", "category_id", "updated_at", "created_at", "deleted_at"}).
+		AddRow("1", "testing-2", "description", 20, "https://gogole.com/3432.jpg", domain.CourseInDraft, 0, 0, time.Now().Unix(), time.Now().Unix(), nil)
 
-	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at FROM courses WHERE ID = \?`
+	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at,deleted_at FROM courses WHERE ID = \? AND organization_id = \? AND deleted_at IS NULL`
 	mock.ExpectQuery(query).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	course, err := c.GetByID(context.TODO(), 1)
+	course, err := c.GetByID(orgCtx, 1)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, *course)
@@ -61,13 +63,13 @@ func TestGetByTitle(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
-	row := sqlmock.NewRows([]string{"id", "title", "description", "duration", "image_url", "status", "author_id", "category_id", "updated_at", "created_at"}).
-		AddRow("1", "testing-2", "description", 20, "https://", domain.CourseArchived, 0, 0, time.Now().Unix(), time.Now().Unix())
+	row := sqlmock.NewRows([]string{"id", "title", "description", "duration", "image_url", "status", "author_id", "category_id", "updated_at", "created_at", "deleted_at"}).
+		AddRow("1", "testing-2", "description", 20, "https://", domain.CourseArchived, 0, 0, time.Now().Unix(), time.Now().Unix(), nil)
 
-	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at FROM courses WHERE title = \?`
+	query := `SELECT id,title,description,duration,image_url,status,author_id,category_id,updated_at,created_at,deleted_at FROM courses WHERE title = \? AND organization_id = \? AND deleted_at IS NULL`
 	mock.ExpectQuery(query).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	course, err := c.GetByTitle(context.TODO(), "testing-2")
+	course, err := c.GetByTitle(orgCtx, "testing-2")
 	assert.NoError(t, err)
 	assert.NotNil(t, course)
 }
@@ -80,8 +82,8 @@ func TestCreateCourse(t *testing.T) {
 			ID: 1,
 		},
 		Author: domain.User{
-			ID:   1,
-			Name: "Nepal kathmandu",
+			ID:       1,
+			LastName: "Nepal kathmandu",
 		},
 		UpdatedAt: date,
 		CreatedAt: date,
@@ -90,12 +92,14 @@ func TestCreateCourse(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `INSERT courses SET title=\?,description=\?,duration=\?,status=\?,image_url=\?,author_id=\?,category_id=\?,updated_at=\?,created_at=\?`
+	mock.ExpectQuery(`SELECT count\(\*\) FROM categories WHERE id = \? AND organization_id = \?`).WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	query := `INSERT courses SET title=\?,description=\?,duration=\?,status=\?,image_url=\?,author_id=\?,category_id=\?,organization_id=\?,updated_at=\?,created_at=\?`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(c.Title, c.Description, c.Duration, c.Status, c.ImageURL, c.Author.ID, c.Category.ID, c.UpdatedAt, c.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(c.Title, c.Description, c.Duration, c.Status, c.ImageURL, c.Author.ID, c.Category.ID, 1, c.UpdatedAt, c.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.CreateCourse(context.TODO(), c)
+	err = repo.CreateCourse(orgCtx, c)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(12), c.ID)
 }
@@ -106,13 +110,46 @@ func TestDeleteCourse(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `DELETE FROM courses WHERE id = \?`
+	deletedAt := time.Now().Unix()
+	query := `UPDATE courses SET deleted_at = \? WHERE id = \? AND organization_id = \? AND deleted_at IS NULL`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(course_id).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(deletedAt, course_id, 1).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.DeleteCourse(orgCtx, int64(course_id), deletedAt)
+	assert.NoError(t, err)
+}
+
+func TestRestoreCourse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	updatedAt := time.Now().Unix()
+	query := `UPDATE courses SET deleted_at = NULL, updated_at = \? WHERE id = \? AND organization_id = \? AND deleted_at IS NOT NULL`
+	mock.ExpectExec(query).WithArgs(updatedAt, 12, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(updatedAt, 13, 1).WillReturnResult(sqlmock.NewResult(0, 0))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.DeleteCourse(context.TODO(), int64(course_id))
+	err = repo.RestoreCourse(orgCtx, 12, updatedAt)
 	assert.NoError(t, err)
+	err = repo.RestoreCourse(orgCtx, 13, updatedAt)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestCoursePurgeTrash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	deletedBefore := time.Now().Unix()
+	query := `DELETE FROM courses WHERE deleted_at IS NOT NULL AND deleted_at < \?`
+	mock.ExpectExec(query).WithArgs(deletedBefore).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	repo := mysqlrepo.Init(db)
+	count, err := repo.PurgeTrash(orgCtx, deletedBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
 }
 
 func TestUpdateCourse(t *testing.T) {
@@ -125,20 +162,20 @@ func TestUpdateCourse(t *testing.T) {
 			ID: 1,
 		},
 		Author: domain.User{
-			ID:   1,
-			Name: "Nepal kathmandu",
+			ID:       1,
+			LastName: "Nepal kathmandu",
 		},
 	}
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `UPDATE courses set title=\?,description=\?,updated_at=\? WHERE ID = \?`
+	query := `UPDATE courses set title=\?,description=\?,updated_at=\? WHERE ID = \? AND organization_id = \? AND deleted_at IS NULL`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(c.Title, c.Description, c.UpdatedAt, c.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(c.Title, c.Description, c.UpdatedAt, c.ID, 1).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.UpdateCourse(context.TODO(), c)
+	err = repo.UpdateCourse(orgCtx, c)
 	assert.NoError(t, err)
 }
 
@@ -150,10 +187,124 @@ func TestGetCourseCount(t *testing.T) {
 	row := sqlmock.NewRows([]string{"count"}).
 		AddRow(10)
 
-	query := `SELECT count\(\*\) FROM courses`
+	query := `SELECT count\(\*\) FROM courses WHERE organization_id = \? AND deleted_at IS NULL`
 	mock.ExpectQuery(query).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
 	content, err := c.GetCourseCount(context.TODO())
 	assert.NoError(t, err)
 	assert.NotNil(t, content)
 }
+
+func TestCloneCourse(t *testing.T) {
+	date := time.Now().Unix()
+	c := &domain.Course{
+		Title:     "Java Programming (Spring)",
+		Author:    domain.User{ID: 4},
+		Status:    domain.CourseInDraft,
+		UpdatedAt: date,
+		CreatedAt: date,
+	}
+	sourceID := int64(7)
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO courses \(.+\) SELECT \?,description,.+ FROM courses WHERE id = \? AND organization_id = \?`).
+		WithArgs(c.Title, c.Author.ID, c.Status, c.UpdatedAt, c.CreatedAt, sourceID, 1).WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectExec(`INSERT INTO courses_tags \(.+\) SELECT \?,tag_id,\? FROM courses_tags WHERE course_id = \?`).
+		WithArgs(int64(12), c.CreatedAt, sourceID).WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec(`INSERT INTO attachments \(.+\) SELECT .+ FROM attachments WHERE course_id = \?`).
+		WithArgs(int64(12), c.UpdatedAt, c.CreatedAt, sourceID).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT id FROM lessons WHERE course_id = \\? AND deleted_at IS NULL ORDER BY `order`,id").
+		WithArgs(sourceID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4))
+	for i, lessonID := range []int64{3, 4} {
+		newLessonID := int64(30 + i)
+		mock.ExpectExec(`INSERT INTO lessons \(.+\) SELECT .+ FROM lessons WHERE id = \?`).
+			WithArgs(int64(12), c.UpdatedAt, c.CreatedAt, lessonID).WillReturnResult(sqlmock.NewResult(newLessonID, 1))
+		mock.ExpectExec(`INSERT INTO contents \(.+\) SELECT .+ FROM contents WHERE lesson_id = \? AND deleted_at IS NULL ORDER BY id`).
+			WithArgs(newLessonID, c.UpdatedAt, c.CreatedAt, lessonID).WillReturnResult(sqlmock.NewResult(1, 3))
+		mock.ExpectExec(`INSERT INTO lessons_tags \(.+\) SELECT \?,tag_id,\? FROM lessons_tags WHERE lesson_id = \?`).
+			WithArgs(newLessonID, c.CreatedAt, lessonID).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+
+	repo := mysqlrepo.Init(db)
+	err = repo.CloneCourse(orgCtx, sourceID, c)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), c.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCloneCourseNotFound(t *testing.T) {
+	c := &domain.Course{Title: "Missing"}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO courses \(.+\) SELECT .+ FROM courses WHERE id = \?`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	repo := mysqlrepo.Init(db)
+	err = repo.CloneCourse(orgCtx, 99, c)
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkAction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	updatedAt := time.Now().Unix()
+	query := `UPDATE courses SET status=\?,updated_at=\? WHERE id = \? AND organization_id = \? AND deleted_at IS NULL`
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs(domain.CourseArchived, updatedAt, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(domain.CourseArchived, updatedAt, 2, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := mysqlrepo.Init(db)
+	results, err := repo.BulkAction(orgCtx, &domain.BulkAction{Action: domain.BulkArchive, IDs: []int64{1, 2}}, updatedAt)
+	assert.NoError(t, err)
+	assert.True(t, results[0].Success)
+	assert.False(t, results[1].Success)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkActionAddTag(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	updatedAt := time.Now().Unix()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM tags WHERE id = \? AND organization_id = \?`).WithArgs(5, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO courses_tags \(course_id,tag_id,created_at\) SELECT id,\?,\? FROM courses`).
+		WithArgs(5, updatedAt, 1, 1, 1, 5).WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(`INSERT INTO courses_tags`).WithArgs(5, updatedAt, 2, 1, 2, 5).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM courses WHERE id = \? AND organization_id = \? AND deleted_at IS NULL`).WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectCommit()
+
+	repo := mysqlrepo.Init(db)
+	results, err := repo.BulkAction(orgCtx, &domain.BulkAction{Action: domain.BulkAddTag, IDs: []int64{1, 2}, TagID: 5}, updatedAt)
+	assert.NoError(t, err)
+	assert.True(t, results[0].Success)
+	// course 2 already had the tag
+	assert.True(t, results[1].Success)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkActionUnknownTag(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT count\(\*\) FROM tags WHERE id = \? AND organization_id = \?`).WithArgs(5, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	repo := mysqlrepo.Init(db)
+	results, err := repo.BulkAction(orgCtx, &domain.BulkAction{Action: domain.BulkAddTag, IDs: []int64{1}, TagID: 5}, time.Now().Unix())
+	assert.Equal(t, domain.ErrBadParamInput, err)
+	assert.Nil(t, results)
+}
@@ -0,0 +1,159 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+func (m *mysqlRepository) fetchVersions(ctx context.Context, query string, args ...interface{}) (result []domain.CourseVersion, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.CourseVersion, 0)
+	for rows.Next() {
+		t := domain.CourseVersion{}
+		changeNote := sql.NullString{}
+		err = rows.Scan(
+			&t.ID,
+			&t.CourseID,
+			&t.Version,
+			&changeNote,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.ChangeNote = changeNote.String
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) getVersion(ctx context.Context, query string, args ...interface{}) (*domain.CourseVersion, error) {
+	row := m.conn.QueryRowContext(ctx, query, args...)
+	version := domain.CourseVersion{}
+	changeNote := sql.NullString{}
+	snapshot := ""
+	err := row.Scan(
+		&version.ID,
+		&version.CourseID,
+		&version.Version,
+		&changeNote,
+		&snapshot,
+		&version.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	version.ChangeNote = changeNote.String
+	version.Course = &domain.Course{}
+	if err = json.Unmarshal([]byte(snapshot), version.Course); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return &version, nil
+}
+
+// PublishCourse stores the snapshot as the next version of the course and marks the course as published.
+func (m *mysqlRepository) PublishCourse(ctx context.Context, v *domain.CourseVersion) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+	return publishCourse(ctx, tx, v)
+}
+
+func publishCourse(ctx context.Context, tx *sql.Tx, v *domain.CourseVersion) error {
+	snapshot, err := json.Marshal(v.Course)
+	if err != nil {
+		return err
+	}
+
+	if err = courseExists(ctx, tx, v.CourseID); err != nil {
+		return err
+	}
+	query := `SELECT COALESCE(MAX(version),0) FROM course_versions WHERE course_id = ? FOR UPDATE`
+	var latest int
+	if err = tx.QueryRowContext(ctx, query, v.CourseID).Scan(&latest); err != nil {
+		log.Error(err)
+		return err
+	}
+	v.Version = latest + 1
+
+	query = `INSERT course_versions SET course_id=?,version=?,change_note=?,snapshot=?,created_at=?`
+	res, err := tx.ExecContext(ctx, query, v.CourseID, v.Version, v.ChangeNote, string(snapshot), v.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	v.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return err
+	}
+
+	query = `UPDATE courses set status=?,published_at=?,updated_at=? WHERE ID = ? AND organization_id = ?`
+	res, err = tx.ExecContext(ctx, query, domain.CoursePublished, v.CreatedAt, time.Now().Unix(), v.CourseID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		return fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
+	}
+	return nil
+}
+
+func (m *mysqlRepository) GetVersions(ctx context.Context, courseID int64) ([]domain.CourseVersion, error) {
+	query := `SELECT v.id,v.course_id,v.version,v.change_note,v.created_at FROM course_versions v
+		JOIN courses c ON c.id = v.course_id WHERE v.course_id = ? AND c.organization_id = ? AND c.deleted_at IS NULL ORDER BY v.version DESC`
+	return m.fetchVersions(ctx, query, courseID, domain.OrganizationIDFromContext(ctx))
+}
+
+func (m *mysqlRepository) GetVersion(ctx context.Context, courseID int64, version int) (*domain.CourseVersion, error) {
+	query := `SELECT v.id,v.course_id,v.version,v.change_note,v.snapshot,v.created_at FROM course_versions v
+		JOIN courses c ON c.id = v.course_id WHERE v.course_id = ? AND v.version = ? AND c.organization_id = ? AND c.deleted_at IS NULL`
+	return m.getVersion(ctx, query, courseID, version, domain.OrganizationIDFromContext(ctx))
+}
+
+func (m *mysqlRepository) GetLatestVersion(ctx context.Context, courseID int64) (*domain.CourseVersion, error) {
+	query := `SELECT v.id,v.course_id,v.version,v.change_note,v.snapshot,v.created_at FROM course_versions v
+		JOIN courses c ON c.id = v.course_id WHERE v.course_id = ? AND c.organization_id = ? AND c.deleted_at IS NULL ORDER BY v.version DESC LIMIT 1`
+	return m.getVersion(ctx, query, courseID, domain.OrganizationIDFromContext(ctx))
+}
@@ -0,0 +1,95 @@
+package mysql_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	mysqlrepo "github.com/meroedu/meroedu/internal/course/repository/mysql"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestPublishCourse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	v := &domain.CourseVersion{
+		CourseID:   12,
+		ChangeNote: "Fixed typos",
+		Course:     &domain.Course{ID: 12, Title: "Java Programming", Status: domain.CoursePublished},
+		CreatedAt:  time.Now().Unix(),
+	}
+	snapshot, err := json.Marshal(v.Course)
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM courses WHERE id = \? AND organization_id = \? AND deleted_at IS NULL`).
+		WithArgs(v.CourseID, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(version\),0\) FROM course_versions WHERE course_id = \? FOR UPDATE`).
+		WithArgs(v.CourseID).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+	mock.ExpectExec(`INSERT course_versions SET course_id=\?,version=\?,change_note=\?,snapshot=\?,created_at=\?`).
+		WithArgs(v.CourseID, 3, v.ChangeNote, string(snapshot), v.CreatedAt).WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec(`UPDATE courses set status=\?,published_at=\?,updated_at=\? WHERE ID = \? AND organization_id = \?`).
+		WithArgs(domain.CoursePublished, v.CreatedAt, sqlmock.AnyArg(), v.CourseID, 1).WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectCommit()
+
+	repo := mysqlrepo.Init(db)
+	err = repo.PublishCourse(orgCtx, v)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v.Version)
+	assert.Equal(t, int64(5), v.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetVersions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "course_id", "version", "change_note", "created_at"}).
+		AddRow(2, 12, 2, "Added lesson", time.Now().Unix()).
+		AddRow(1, 12, 1, nil, time.Now().Unix())
+
+	query := `SELECT v.id,v.course_id,v.version,v.change_note,v.created_at FROM course_versions v\s+JOIN courses c ON c.id = v.course_id WHERE v.course_id = \? AND c.organization_id = \? AND c.deleted_at IS NULL ORDER BY v.version DESC`
+	mock.ExpectQuery(query).WithArgs(12, 1).WillReturnRows(rows)
+	c := mysqlrepo.Init(db)
+	list, err := c.GetVersions(orgCtx, 12)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "Added lesson", list[0].ChangeNote)
+}
+
+func TestGetVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "course_id", "version", "change_note", "snapshot", "created_at"}).
+		AddRow(2, 12, 2, "Added lesson", `{"id":12,"title":"Java Programming","lessons":[{"id":3,"title":"Intro"}]}`, time.Now().Unix())
+
+	query := `SELECT v.id,v.course_id,v.version,v.change_note,v.snapshot,v.created_at FROM course_versions v\s+JOIN courses c ON c.id = v.course_id WHERE v.course_id = \? AND v.version = \? AND c.organization_id = \? AND c.deleted_at IS NULL`
+	mock.ExpectQuery(query).WithArgs(12, 2, 1).WillReturnRows(rows)
+	c := mysqlrepo.Init(db)
+	version, err := c.GetVersion(orgCtx, 12, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "Java Programming", version.Course.Title)
+	assert.Len(t, version.Course.Lessons, 1)
+}
+
+func TestGetLatestVersionNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "course_id", "version", "change_note", "snapshot", "created_at"})
+
+	query := `SELECT v.id,v.course_id,v.version,v.change_note,v.snapshot,v.created_at FROM course_versions v\s+JOIN courses c ON c.id = v.course_id WHERE v.course_id = \? AND c.organization_id = \? AND c.deleted_at IS NULL ORDER BY v.version DESC LIMIT 1`
+	mock.ExpectQuery(query).WithArgs(12, 1).WillReturnRows(rows)
+	c := mysqlrepo.Init(db)
+	version, err := c.GetLatestVersion(orgCtx, 12)
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.Nil(t, version)
+}
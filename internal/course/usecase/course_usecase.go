@@ -10,22 +10,26 @@ import (
 
 // CourseUseCase ...
 type CourseUseCase struct {
-	courseRepo        domain.CourseRepository
-	userRepo          domain.UserRepository
-	lessonUseCase     domain.LessonUseCase
-	attachmentUseCase domain.AttachmentUseCase
-	tagRepo           domain.TagRepository
-	categoryRepo      domain.CategoryRepository
-	contextTimeOut    time.Duration
+	courseRepo          domain.CourseRepository
+	userRepo            domain.UserRepository
+	lessonUseCase       domain.LessonUseCase
+	attachmentUseCase   domain.AttachmentUseCase
+	tagRepo             domain.TagRepository
+	categoryRepo        domain.CategoryRepository
+	collaboratorUseCase domain.CollaboratorUseCase
+	contextTimeOut      time.Duration
 }
 
-// NewCourseUseCase will create new an
-func NewCourseUseCase(c domain.CourseRepository, l domain.LessonUseCase, a domain.AttachmentUseCase, timeout time.Duration) domain.CourseUseCase {
+// NewCourseUseCase will create new an. Courses are only changed by their author, their collaborators and the
+// course managers of the organization.
+func NewCourseUseCase(c domain.CourseRepository, l domain.LessonUseCase, a domain.AttachmentUseCase, cu domain.CollaboratorUseCase,
+	timeout time.Duration) domain.CourseUseCase {
 	return &CourseUseCase{
-		courseRepo:        c,
-		lessonUseCase:     l,
-		attachmentUseCase: a,
-		contextTimeOut:    timeout,
+		courseRepo:          c,
+		lessonUseCase:       l,
+		attachmentUseCase:   a,
+		collaboratorUseCase: cu,
+		contextTimeOut:      timeout,
 	}
 }
 
@@ -80,7 +84,7 @@ func (usecase *CourseUseCase) GetByTitle(c context.Context, title string) (*doma
 	return res, nil
 }
 
-// CreateCourse ..
+// CreateCourse creates a course authored by the caller
 func (usecase *CourseUseCase) CreateCourse(c context.Context, course *domain.Course) (err error) {
 	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
 	defer cancel()
@@ -88,6 +92,7 @@ func (usecase *CourseUseCase) CreateCourse(c context.Context, course *domain.Cou
 	if existedCourse != nil {
 		return domain.ErrConflict
 	}
+	course.Author = domain.User{ID: domain.UserIDFromContext(ctx)}
 	course.UpdatedAt = time.Now().Unix()
 	course.CreatedAt = time.Now().Unix()
 	err = usecase.courseRepo.CreateCourse(ctx, course)
@@ -102,9 +107,11 @@ func (usecase *CourseUseCase) CreateCourse(c context.Context, course *domain.Cou
 func (usecase *CourseUseCase) UpdateCourse(c context.Context, course *domain.Course, id int64) (err error) {
 	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
 	defer cancel()
-	existedCourse, err := usecase.GetByID(ctx, id)
-	if &existedCourse == nil {
-		return domain.ErrNotFound
+	if err = usecase.collaboratorUseCase.AuthorizeCourse(ctx, id, domain.CollaboratorEditor); err != nil {
+		return
+	}
+	if _, err = usecase.GetByID(ctx, id); err != nil {
+		return
 	}
 	course.ID = id
 	course.UpdatedAt = time.Now().Unix()
@@ -116,10 +123,13 @@ func (usecase *CourseUseCase) UpdateCourse(c context.Context, course *domain.Cou
 
 }
 
-// DeleteCourse ...
+// DeleteCourse moves a course to the trash. Only its author and the course managers delete it.
 func (usecase *CourseUseCase) DeleteCourse(c context.Context, id int64) (err error) {
 	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
 	defer cancel()
+	if err = usecase.collaboratorUseCase.AuthorizeCourse(ctx, id, domain.CourseOwner); err != nil {
+		return
+	}
 	existedCourse, err := usecase.courseRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
@@ -127,5 +137,214 @@ func (usecase *CourseUseCase) DeleteCourse(c context.Context, id int64) (err err
 	if existedCourse == nil {
 		return domain.ErrNotFound
 	}
-	return usecase.courseRepo.DeleteCourse(ctx, id)
+	return usecase.courseRepo.DeleteCourse(ctx, id, time.Now().Unix())
+}
+
+// CloneCourse will copy an existing course with its lessons, contents, tags and attachments
+// into a new draft course with the given title, authored by the caller
+func (usecase *CourseUseCase) CloneCourse(c context.Context, id int64, title string) (*domain.Course, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.courseRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	existedCourse, _ := usecase.courseRepo.GetByTitle(ctx, title)
+	if existedCourse != nil {
+		return nil, domain.ErrConflict
+	}
+	course := &domain.Course{
+		Title:     title,
+		Author:    domain.User{ID: domain.UserIDFromContext(ctx)},
+		Status:    domain.CourseInDraft,
+		UpdatedAt: time.Now().Unix(),
+		CreatedAt: time.Now().Unix(),
+	}
+	if err := usecase.courseRepo.CloneCourse(ctx, id, course); err != nil {
+		return nil, err
+	}
+	return usecase.GetByID(ctx, course.ID)
+}
+
+// snapshot loads a course with its lessons, their contents and its attachments to publish it. Unlike GetByID it
+// fails when any of them can not be loaded, since a published version can not be changed afterwards.
+func (usecase *CourseUseCase) snapshot(ctx context.Context, id int64) (*domain.Course, error) {
+	course, err := usecase.courseRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if course.Lessons, err = usecase.lessonUseCase.GetLessonByCourse(ctx, id); err != nil {
+		return nil, err
+	}
+	course.LessonCount = len(course.Lessons)
+	if course.Attachments, err = usecase.attachmentUseCase.GetAttachmentByCourse(ctx, id); err != nil {
+		return nil, err
+	}
+	return course, nil
+}
+
+// PublishCourse will snapshot the current draft of a course, its lessons and contents
+// as a new immutable version that learners are enrolled into
+func (usecase *CourseUseCase) PublishCourse(c context.Context, id int64, changeNote string) (*domain.CourseVersion, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeCourse(ctx, id, domain.CollaboratorCoAuthor); err != nil {
+		return nil, err
+	}
+	course, err := usecase.snapshot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	course.Status = domain.CoursePublished
+	version := &domain.CourseVersion{
+		CourseID:   id,
+		ChangeNote: changeNote,
+		Course:     course,
+		CreatedAt:  time.Now().Unix(),
+	}
+	if err = usecase.courseRepo.PublishCourse(ctx, version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// GetVersions ...
+func (usecase *CourseUseCase) GetVersions(c context.Context, courseID int64) ([]domain.CourseVersion, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.courseRepo.GetVersions(ctx, courseID)
+}
+
+// GetVersion ...
+func (usecase *CourseUseCase) GetVersion(c context.Context, courseID int64, version int) (*domain.CourseVersion, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.courseRepo.GetVersion(ctx, courseID, version)
+}
+
+// GetPublishedVersion will return the latest published version of a course
+func (usecase *CourseUseCase) GetPublishedVersion(c context.Context, courseID int64) (*domain.CourseVersion, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	version, err := usecase.courseRepo.GetLatestVersion(ctx, courseID)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrCourseNotPublished
+	}
+	return version, err
+}
+
+// GetTrash ...
+func (usecase *CourseUseCase) GetTrash(c context.Context, start int, limit int) ([]domain.Course, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.courseRepo.GetTrash(ctx, start, limit)
+}
+
+// RestoreCourse will take a course out of the trash, unless another course took its title meanwhile
+func (usecase *CourseUseCase) RestoreCourse(c context.Context, id int64) (*domain.Course, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeCourse(ctx, id, domain.CourseOwner); err != nil {
+		return nil, err
+	}
+	trashedCourse, err := usecase.courseRepo.GetTrashedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	existingCourse, _ := usecase.courseRepo.GetByTitle(ctx, trashedCourse.Title)
+	if existingCourse != nil {
+		return nil, domain.ErrConflict
+	}
+	if err = usecase.courseRepo.RestoreCourse(ctx, id, time.Now().Unix()); err != nil {
+		return nil, err
+	}
+	return usecase.GetByID(ctx, id)
+}
+
+// PurgeTrash will permanently remove the courses trashed before deletedBefore
+func (usecase *CourseUseCase) PurgeTrash(c context.Context, deletedBefore int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.courseRepo.PurgeTrash(ctx, deletedBefore)
+}
+
+// BulkAction will run the same action on many courses at once and report the outcome for each of them.
+// The courses the caller may not run the action on fail: publishing takes a co-author, archiving and
+// deleting take the author, and the other actions take an editor.
+func (usecase *CourseUseCase) BulkAction(c context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	level := domain.CollaboratorEditor
+	switch action.Action {
+	case domain.BulkPublish:
+		level = domain.CollaboratorCoAuthor
+	case domain.BulkAssignCategory:
+		if action.CategoryID == 0 {
+			return nil, domain.ErrBadParamInput
+		}
+	case domain.BulkAddTag, domain.BulkRemoveTag:
+		if action.TagID == 0 {
+			return nil, domain.ErrBadParamInput
+		}
+	case domain.BulkArchive, domain.BulkDelete:
+		level = domain.CourseOwner
+	default:
+		return nil, domain.ErrBadParamInput
+	}
+	allowed, denied, err := domain.FilterBulk(action.IDs, func(id int64) error {
+		return usecase.collaboratorUseCase.AuthorizeCourse(ctx, id, level)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(allowed) == 0 {
+		return denied, nil
+	}
+	allowedAction := *action
+	allowedAction.IDs = allowed
+	var results []domain.BulkResult
+	if action.Action == domain.BulkPublish {
+		results, err = usecase.bulkPublish(ctx, &allowedAction)
+	} else {
+		results, err = usecase.courseRepo.BulkAction(ctx, &allowedAction, time.Now().Unix())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return domain.MergeBulk(action.IDs, denied, results), nil
+}
+
+func (usecase *CourseUseCase) bulkPublish(ctx context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	results := make(map[int64]domain.BulkResult, len(action.IDs))
+	versions := make([]*domain.CourseVersion, 0, len(action.IDs))
+	for _, id := range action.IDs {
+		course, err := usecase.snapshot(ctx, id)
+		if err == domain.ErrNotFound {
+			results[id] = domain.BulkResult{ID: id, Error: err.Error()}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		course.Status = domain.CoursePublished
+		versions = append(versions, &domain.CourseVersion{
+			CourseID:   id,
+			ChangeNote: action.ChangeNote,
+			Course:     course,
+			CreatedAt:  time.Now().Unix(),
+		})
+	}
+	if len(versions) > 0 {
+		published, err := usecase.courseRepo.BulkPublish(ctx, versions)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range published {
+			results[result.ID] = result
+		}
+	}
+	res := make([]domain.BulkResult, 0, len(action.IDs))
+	for _, id := range action.IDs {
+		res = append(res, results[id])
+	}
+	return res, nil
 }
@@ -13,6 +13,15 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// anyCollaborator returns a collaborator usecase giving the caller every access
+func anyCollaborator() *mocks.CollaboratorUseCase {
+	m := new(mocks.CollaboratorUseCase)
+	m.On("AuthorizeCourse", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("AuthorizeLesson", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("AuthorizeContent", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
 func TestGetAll(t *testing.T) {
 	mockCourseRepo := new(mocks.CourseRepository)
 	mockLessonUseCase := new(mocks.LessonUseCase)
@@ -29,7 +38,7 @@ func TestGetAll(t *testing.T) {
 
 		start := int(0)
 		limit := int(1)
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 		list, err := u.GetAll(context.TODO(), start, limit)
 		assert.NoError(t, err)
 		assert.Len(t, list, len(mockListCourse))
@@ -40,7 +49,7 @@ func TestGetAll(t *testing.T) {
 		mockCourseRepo.On("GetAll", mock.Anything, mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(nil, errors.New("Unexpected Error")).Once()
 
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 		start := int(0)
 		limit := int(1)
 		list, err := u.GetAll(context.TODO(), start, limit)
@@ -63,7 +72,7 @@ func TestGetByID(t *testing.T) {
 		mockLessonUseCase.On("GetLessonCountByCourse", mock.Anything, mock.AnythingOfType("int64")).Return(12, nil).Once()
 		mockLessonUseCase.On("GetLessonByCourse", mock.Anything, mock.AnythingOfType("int64")).Return([]domain.Lesson{}, nil).Once()
 		mockAttachmentUseCase.On("GetAttachmentByCourse", mock.Anything, mock.AnythingOfType("int64")).Return([]domain.Attachment{}, nil).Once()
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 
 		a, err := u.GetByID(context.TODO(), mockCourse.ID)
 
@@ -76,7 +85,7 @@ func TestGetByID(t *testing.T) {
 	})
 	t.Run("error-failed", func(t *testing.T) {
 		mockCourseRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, errors.New("Unexpected")).Once()
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 
 		a, err := u.GetByID(context.TODO(), mockCourse.ID)
 
@@ -96,7 +105,7 @@ func TestGetByTitle(t *testing.T) {
 	}
 	t.Run("success", func(t *testing.T) {
 		mockCourseRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(&mockCourse, nil).Once()
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 
 		a, err := u.GetByTitle(context.TODO(), mockCourse.Title)
 
@@ -108,7 +117,7 @@ func TestGetByTitle(t *testing.T) {
 	t.Run("error-failed", func(t *testing.T) {
 		mockCourseRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("Unexpected")).Once()
 
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 
 		a, err := u.GetByTitle(context.TODO(), "random")
 
@@ -132,7 +141,7 @@ func TestCreateCourse(t *testing.T) {
 		tempMockCourse.ID = 1
 		mockCourseRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(nil, nil).Once()
 		mockCourseRepo.On("CreateCourse", mock.Anything, mock.AnythingOfType("*domain.Course")).Return(nil).Once()
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 		//
 		err := u.CreateCourse(context.TODO(), &tempMockCourse)
 		assert.NoError(t, err)
@@ -143,7 +152,7 @@ func TestCreateCourse(t *testing.T) {
 		existingCourse := mockCourse
 		mockCourseRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(&existingCourse, nil).Once()
 		mockCourseRepo.On("CreateCourse", mock.Anything, mock.AnythingOfType("*domain.Course")).Return(domain.ErrConflict).Once()
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 		err := u.CreateCourse(context.TODO(), &mockCourse)
 		assert.Error(t, err)
 	})
@@ -165,7 +174,7 @@ func TestUpdateCourse(t *testing.T) {
 		mockCourseRepo.On("UpdateCourse", mock.Anything, mock.AnythingOfType("*domain.Course")).Return(nil).Once()
 		mockLessonUseCase.On("GetLessonCountByCourse", mock.Anything, mock.AnythingOfType("int64")).Return(0, nil).Once()
 		mockLessonUseCase.On("GetLessonByCourse", mock.Anything, mock.AnythingOfType("int64")).Return([]domain.Lesson{}, nil).Once()
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.UpdateCourse(context.TODO(), &tempMockCourse, tempMockCourse.ID)
 
@@ -181,7 +190,7 @@ func TestUpdateCourse(t *testing.T) {
 		mockCourseRepo.On("UpdateCourse", mock.Anything, mock.AnythingOfType("*domain.Course")).Return(domain.ErrNotFound).Once()
 		mockLessonUseCase.On("GetLessonCountByCourse", mock.Anything, mock.AnythingOfType("int64")).Return(0, nil).Once()
 		mockLessonUseCase.On("GetLessonByCourse", mock.Anything, mock.AnythingOfType("int64")).Return([]domain.Lesson{}, nil).Once()
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.UpdateCourse(context.TODO(), &mockCourse, existingCourse.ID)
 
@@ -204,8 +213,8 @@ func TestDeleteCourse(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockCourseRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(&mockCourse, nil).Once()
 		// mockLessonUseCase.On("GetLessonCountByCourse", mock.Anything, mock.AnythingOfType("int64")).Return(0, nil).Once()
-		mockCourseRepo.On("DeleteCourse", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Once()
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		mockCourseRepo.On("DeleteCourse", mock.Anything, mock.AnythingOfType("int64"), mock.AnythingOfType("int64")).Return(nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.DeleteCourse(context.TODO(), mockCourse.ID)
 
@@ -216,7 +225,7 @@ func TestDeleteCourse(t *testing.T) {
 	t.Run("course-is-not-exist", func(t *testing.T) {
 		mockCourseRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, nil).Once()
 		mockLessonUseCase.On("GetLessonCountByCourse", mock.Anything, mock.AnythingOfType("int64")).Return(0, nil).Once()
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.DeleteCourse(context.TODO(), mockCourse.ID)
 
@@ -226,7 +235,7 @@ func TestDeleteCourse(t *testing.T) {
 	t.Run("error-happens-in-db", func(t *testing.T) {
 		mockCourseRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(&domain.Course{}, errors.New("Unexpected Error")).Once()
 		mockLessonUseCase.On("GetLessonCountByCourse", mock.Anything, mock.AnythingOfType("int64")).Return(0, nil).Once()
-		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, time.Second*2)
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.DeleteCourse(context.TODO(), mockCourse.ID)
 
@@ -235,3 +244,274 @@ func TestDeleteCourse(t *testing.T) {
 	})
 
 }
+
+func TestCloneCourse(t *testing.T) {
+	mockCourseRepo := new(mocks.CourseRepository)
+	mockLessonUseCase := new(mocks.LessonUseCase)
+	mockAttachmentUseCase := new(mocks.AttachmentUseCase)
+	mockCourse := domain.Course{
+		ID:     1,
+		Title:  "Hello",
+		Status: domain.CoursePublished,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockCourseRepo.On("GetByID", mock.Anything, int64(1)).Return(&mockCourse, nil).Once()
+		mockCourseRepo.On("GetByTitle", mock.Anything, "Hello 2").Return(nil, domain.ErrNotFound).Once()
+		mockCourseRepo.On("CloneCourse", mock.Anything, int64(1), mock.MatchedBy(func(c *domain.Course) bool {
+			return c.Title == "Hello 2" && c.Status == domain.CourseInDraft
+		})).Run(func(args mock.Arguments) {
+			args.Get(2).(*domain.Course).ID = 2
+		}).Return(nil).Once()
+		mockCourseRepo.On("GetByID", mock.Anything, int64(2)).Return(&domain.Course{ID: 2, Title: "Hello 2", Status: domain.CourseInDraft}, nil).Once()
+		mockLessonUseCase.On("GetLessonCountByCourse", mock.Anything, int64(2)).Return(1, nil).Once()
+		mockLessonUseCase.On("GetLessonByCourse", mock.Anything, int64(2)).Return([]domain.Lesson{{ID: 5}}, nil).Once()
+		mockAttachmentUseCase.On("GetAttachmentByCourse", mock.Anything, int64(2)).Return([]domain.Attachment{}, nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		course, err := u.CloneCourse(context.TODO(), 1, "Hello 2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), course.ID)
+		assert.Equal(t, 1, course.LessonCount)
+		mockCourseRepo.AssertExpectations(t)
+		mockLessonUseCase.AssertExpectations(t)
+		mockAttachmentUseCase.AssertExpectations(t)
+	})
+	t.Run("existing-title", func(t *testing.T) {
+		mockCourseRepo.On("GetByID", mock.Anything, int64(1)).Return(&mockCourse, nil).Once()
+		mockCourseRepo.On("GetByTitle", mock.Anything, "Hello").Return(&mockCourse, nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		course, err := u.CloneCourse(context.TODO(), 1, "Hello")
+
+		assert.Equal(t, domain.ErrConflict, err)
+		assert.Nil(t, course)
+		mockCourseRepo.AssertExpectations(t)
+	})
+	t.Run("course-is-not-exist", func(t *testing.T) {
+		mockCourseRepo.On("GetByID", mock.Anything, int64(3)).Return(nil, domain.ErrNotFound).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		course, err := u.CloneCourse(context.TODO(), 3, "Hello 3")
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.Nil(t, course)
+		mockCourseRepo.AssertExpectations(t)
+	})
+}
+
+func TestPublishCourse(t *testing.T) {
+	mockCourseRepo := new(mocks.CourseRepository)
+	mockLessonUseCase := new(mocks.LessonUseCase)
+	mockAttachmentUseCase := new(mocks.AttachmentUseCase)
+	mockCourse := domain.Course{
+		ID:     1,
+		Title:  "Hello",
+		Status: domain.CourseInDraft,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		tempMockCourse := mockCourse
+		mockCourseRepo.On("GetByID", mock.Anything, int64(1)).Return(&tempMockCourse, nil).Once()
+		mockLessonUseCase.On("GetLessonByCourse", mock.Anything, int64(1)).Return([]domain.Lesson{{ID: 5, Title: "Intro"}}, nil).Once()
+		mockAttachmentUseCase.On("GetAttachmentByCourse", mock.Anything, int64(1)).Return([]domain.Attachment{}, nil).Once()
+		mockCourseRepo.On("PublishCourse", mock.Anything, mock.AnythingOfType("*domain.CourseVersion")).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.CourseVersion).Version = 1
+		}).Return(nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		version, err := u.PublishCourse(context.TODO(), 1, "First release")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, version.Version)
+		assert.Equal(t, "First release", version.ChangeNote)
+		assert.Equal(t, domain.CoursePublished, version.Course.Status)
+		assert.Len(t, version.Course.Lessons, 1)
+		assert.Equal(t, 1, version.Course.LessonCount)
+		mockCourseRepo.AssertExpectations(t)
+		mockLessonUseCase.AssertExpectations(t)
+	})
+	t.Run("lessons-not-loaded", func(t *testing.T) {
+		mockCourseRepo := new(mocks.CourseRepository)
+		tempMockCourse := mockCourse
+		mockCourseRepo.On("GetByID", mock.Anything, int64(1)).Return(&tempMockCourse, nil).Once()
+		mockLessonUseCase.On("GetLessonByCourse", mock.Anything, int64(1)).Return(nil, errors.New("Unexpected")).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		version, err := u.PublishCourse(context.TODO(), 1, "First release")
+
+		assert.Error(t, err)
+		assert.Nil(t, version)
+		mockCourseRepo.AssertNotCalled(t, "PublishCourse", mock.Anything, mock.Anything)
+	})
+	t.Run("course-is-not-exist", func(t *testing.T) {
+		mockCourseRepo.On("GetByID", mock.Anything, int64(2)).Return(nil, domain.ErrNotFound).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		version, err := u.PublishCourse(context.TODO(), 2, "First release")
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.Nil(t, version)
+		mockCourseRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetPublishedVersion(t *testing.T) {
+	mockCourseRepo := new(mocks.CourseRepository)
+	mockLessonUseCase := new(mocks.LessonUseCase)
+	mockAttachmentUseCase := new(mocks.AttachmentUseCase)
+
+	t.Run("success", func(t *testing.T) {
+		mockCourseRepo.On("GetLatestVersion", mock.Anything, int64(1)).Return(&domain.CourseVersion{ID: 3, CourseID: 1, Version: 2}, nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		version, err := u.GetPublishedVersion(context.TODO(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, version.Version)
+		mockCourseRepo.AssertExpectations(t)
+	})
+	t.Run("not-published", func(t *testing.T) {
+		mockCourseRepo.On("GetLatestVersion", mock.Anything, int64(2)).Return(nil, domain.ErrNotFound).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		version, err := u.GetPublishedVersion(context.TODO(), 2)
+
+		assert.Equal(t, domain.ErrCourseNotPublished, err)
+		assert.Nil(t, version)
+		mockCourseRepo.AssertExpectations(t)
+	})
+}
+
+func TestRestoreCourse(t *testing.T) {
+	mockCourseRepo := new(mocks.CourseRepository)
+	mockLessonUseCase := new(mocks.LessonUseCase)
+	mockAttachmentUseCase := new(mocks.AttachmentUseCase)
+	trashedCourse := domain.Course{ID: 1, Title: "Go", DeletedAt: time.Now().Unix()}
+
+	t.Run("success", func(t *testing.T) {
+		mockCourseRepo.On("GetTrashedByID", mock.Anything, int64(1)).Return(&trashedCourse, nil).Once()
+		mockCourseRepo.On("GetByTitle", mock.Anything, "Go").Return(nil, domain.ErrNotFound).Once()
+		mockCourseRepo.On("RestoreCourse", mock.Anything, int64(1), mock.AnythingOfType("int64")).Return(nil).Once()
+		mockCourseRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Course{ID: 1, Title: "Go"}, nil).Once()
+		mockLessonUseCase.On("GetLessonCountByCourse", mock.Anything, int64(1)).Return(0, nil).Once()
+		mockLessonUseCase.On("GetLessonByCourse", mock.Anything, int64(1)).Return([]domain.Lesson{}, nil).Once()
+		mockAttachmentUseCase.On("GetAttachmentByCourse", mock.Anything, int64(1)).Return([]domain.Attachment{}, nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		course, err := u.RestoreCourse(context.TODO(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), course.DeletedAt)
+		mockCourseRepo.AssertExpectations(t)
+	})
+	t.Run("title-taken", func(t *testing.T) {
+		mockCourseRepo.On("GetTrashedByID", mock.Anything, int64(1)).Return(&trashedCourse, nil).Once()
+		mockCourseRepo.On("GetByTitle", mock.Anything, "Go").Return(&domain.Course{ID: 2, Title: "Go"}, nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		course, err := u.RestoreCourse(context.TODO(), 1)
+
+		assert.Equal(t, domain.ErrConflict, err)
+		assert.Nil(t, course)
+		mockCourseRepo.AssertExpectations(t)
+	})
+}
+
+func TestBulkAction(t *testing.T) {
+	mockCourseRepo := new(mocks.CourseRepository)
+	mockLessonUseCase := new(mocks.LessonUseCase)
+	mockAttachmentUseCase := new(mocks.AttachmentUseCase)
+
+	t.Run("publish", func(t *testing.T) {
+		mockCourseRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Course{ID: 1, Title: "Go"}, nil).Once()
+		mockCourseRepo.On("GetByID", mock.Anything, int64(2)).Return(nil, domain.ErrNotFound).Once()
+		mockLessonUseCase.On("GetLessonByCourse", mock.Anything, int64(1)).Return([]domain.Lesson{}, nil).Once()
+		mockAttachmentUseCase.On("GetAttachmentByCourse", mock.Anything, int64(1)).Return([]domain.Attachment{}, nil).Once()
+		mockCourseRepo.On("BulkPublish", mock.Anything, mock.MatchedBy(func(versions []*domain.CourseVersion) bool {
+			return len(versions) == 1 && versions[0].CourseID == 1 && versions[0].ChangeNote == "Spring release"
+		})).Return([]domain.BulkResult{{ID: 1, Success: true}}, nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		results, err := u.BulkAction(context.TODO(), &domain.BulkAction{Action: domain.BulkPublish, IDs: []int64{2, 1}, ChangeNote: "Spring release"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{{ID: 2, Error: domain.ErrNotFound.Error()}, {ID: 1, Success: true}}, results)
+		mockCourseRepo.AssertExpectations(t)
+	})
+	t.Run("archive", func(t *testing.T) {
+		action := &domain.BulkAction{Action: domain.BulkArchive, IDs: []int64{1, 2}}
+		mockCourseRepo.On("BulkAction", mock.Anything, action, mock.AnythingOfType("int64")).Return([]domain.BulkResult{{ID: 1, Success: true}, {ID: 2, Success: true}}, nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		results, err := u.BulkAction(context.TODO(), action)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		mockCourseRepo.AssertExpectations(t)
+	})
+	t.Run("missing-category", func(t *testing.T) {
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		_, err := u.BulkAction(context.TODO(), &domain.BulkAction{Action: domain.BulkAssignCategory, IDs: []int64{1}})
+
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+	t.Run("unknown-action", func(t *testing.T) {
+		u := ucase.NewCourseUseCase(mockCourseRepo, mockLessonUseCase, mockAttachmentUseCase, anyCollaborator(), time.Second*2)
+
+		_, err := u.BulkAction(context.TODO(), &domain.BulkAction{Action: "feature", IDs: []int64{1}})
+
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+}
+
+func TestAuthorship(t *testing.T) {
+	t.Run("author-is-the-caller", func(t *testing.T) {
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockCourseRepo.On("GetByTitle", mock.Anything, "Go").Return(nil, domain.ErrNotFound).Once()
+		mockCourseRepo.On("CreateCourse", mock.Anything, mock.MatchedBy(func(c *domain.Course) bool {
+			return c.Author.ID == 4 && c.Author.Email == ""
+		})).Return(nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, new(mocks.LessonUseCase), new(mocks.AttachmentUseCase), anyCollaborator(), time.Second*2)
+
+		course := &domain.Course{Title: "Go", Author: domain.User{ID: 9, Email: "someone@example.com"}}
+		assert.NoError(t, u.CreateCourse(domain.WithUserID(context.TODO(), 4), course))
+		mockCourseRepo.AssertExpectations(t)
+	})
+	t.Run("update-by-other-user", func(t *testing.T) {
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockCollaboratorUseCase.On("AuthorizeCourse", mock.Anything, int64(1), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, new(mocks.LessonUseCase), new(mocks.AttachmentUseCase), mockCollaboratorUseCase, time.Second*2)
+
+		err := u.UpdateCourse(context.TODO(), &domain.Course{Title: "Go"}, 1)
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockCourseRepo.AssertNotCalled(t, "UpdateCourse", mock.Anything, mock.Anything)
+	})
+	t.Run("delete-takes-the-author", func(t *testing.T) {
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockCollaboratorUseCase.On("AuthorizeCourse", mock.Anything, int64(1), domain.CourseOwner).Return(domain.ErrForbidden).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, new(mocks.LessonUseCase), new(mocks.AttachmentUseCase), mockCollaboratorUseCase, time.Second*2)
+
+		assert.Equal(t, domain.ErrForbidden, u.DeleteCourse(context.TODO(), 1))
+		mockCourseRepo.AssertNotCalled(t, "DeleteCourse", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("bulk-skips-other-courses", func(t *testing.T) {
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockCollaboratorUseCase.On("AuthorizeCourse", mock.Anything, int64(1), domain.CourseOwner).Return(domain.ErrForbidden).Once()
+		mockCollaboratorUseCase.On("AuthorizeCourse", mock.Anything, int64(2), domain.CourseOwner).Return(nil).Once()
+		mockCourseRepo.On("BulkAction", mock.Anything, mock.MatchedBy(func(a *domain.BulkAction) bool {
+			return len(a.IDs) == 1 && a.IDs[0] == 2
+		}), mock.AnythingOfType("int64")).Return([]domain.BulkResult{{ID: 2, Success: true}}, nil).Once()
+		u := ucase.NewCourseUseCase(mockCourseRepo, new(mocks.LessonUseCase), new(mocks.AttachmentUseCase), mockCollaboratorUseCase, time.Second*2)
+
+		results, err := u.BulkAction(context.TODO(), &domain.BulkAction{Action: domain.BulkArchive, IDs: []int64{1, 2}})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{{ID: 1, Error: domain.ErrForbidden.Error()}, {ID: 2, Success: true}}, results)
+		mockCourseRepo.AssertExpectations(t)
+	})
+}
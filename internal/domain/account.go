@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+)
+
+// Purposes of the single use tokens emailed to users
+const (
+	TokenPasswordReset     = "password_reset"
+	TokenEmailVerification = "email_verification"
+)
+
+// UserToken is a single use token emailed to a user. Only the hash of the token is stored.
+type UserToken struct {
+	ID             int64
+	UserID         int64
+	OrganizationID int64
+	Purpose        string
+	// Email is the address the token was sent to; a verification token only verifies that address
+	Email     string
+	TokenHash string
+	ExpiresAt int64
+	UsedAt    int64
+	CreatedAt int64
+}
+
+// PasswordForgot is the request body asking for a password reset link
+type PasswordForgot struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// PasswordReset is the request body setting a new password with the token of a reset link
+type PasswordReset struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// EmailVerification is the request body verifying an email with the token of a verification link
+type EmailVerification struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// AccountUseCase represent the self service account usecases: password reset and email verification
+type AccountUseCase interface {
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, reset *PasswordReset) error
+	SendVerification(ctx context.Context, user *User) error
+	ResendVerification(ctx context.Context, userID int64) error
+	VerifyEmail(ctx context.Context, token string) error
+}
+
+// AccountRepository represent the user token repository
+type AccountRepository interface {
+	CreateToken(ctx context.Context, token *UserToken) error
+	GetToken(ctx context.Context, tokenHash string) (*UserToken, error)
+	UseToken(ctx context.Context, token *UserToken, usedAt int64) error
+	CountTokens(ctx context.Context, userID int64, purpose string, since int64) (int, error)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+)
+
+// APIKeyPrefix starts every API key, telling them apart from access tokens
+const APIKeyPrefix = "mero_"
+
+// APIKey lets a service call the API on behalf of an organization, with the permissions of its scopes.
+// Only the hash of the key is stored; Prefix is the start of the key, to recognise it in lists.
+type APIKey struct {
+	ID             int64        `json:"id"`
+	OrganizationID int64        `json:"organization_id"`
+	Name           string       `json:"name" validate:"required,max=100"`
+	Prefix         string       `json:"prefix"`
+	KeyHash        string       `json:"-"`
+	Scopes         []Permission `json:"scopes" validate:"required,min=1"`
+	CreatedBy      int64        `json:"created_by,omitempty"`
+	ExpiresAt      int64        `json:"expires_at,omitempty"`
+	LastUsedAt     int64        `json:"last_used_at,omitempty"`
+	RevokedAt      int64        `json:"revoked_at,omitempty"`
+	UpdatedAt      int64        `json:"updated_at"`
+	CreatedAt      int64        `json:"created_at"`
+}
+
+// NewAPIKey is returned when an API key is created. It is the only time the key is shown.
+type NewAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyUseCase represent the API key usecases
+type APIKeyUseCase interface {
+	GetAll(ctx context.Context, start int, limit int) ([]APIKey, error)
+	GetByID(ctx context.Context, id int64) (*APIKey, error)
+	CreateAPIKey(ctx context.Context, key *APIKey) (*NewAPIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	Authenticate(ctx context.Context, key string) (*Principal, error)
+}
+
+// APIKeyRepository represent the API key repository
+type APIKeyRepository interface {
+	GetAll(ctx context.Context, start int, limit int) ([]APIKey, error)
+	GetByID(ctx context.Context, id int64) (*APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	CreateAPIKey(ctx context.Context, key *APIKey) error
+	RevokeAPIKey(ctx context.Context, id int64, revokedAt int64) error
+	TouchAPIKey(ctx context.Context, id int64, usedAt int64) error
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"context"
+	"mime/multipart"
+)
+
+// LatePolicy is how an assignment treats the submissions after its due date
+type LatePolicy string
+
+// Late Policies
+const (
+	// LateAccept accepts the late submissions, marked late
+	LateAccept LatePolicy = "accept"
+	// LatePenalize deducts the late penalty from the score of the late submissions, for each day started after the due date
+	LatePenalize LatePolicy = "penalize"
+	// LateReject refuses the submissions after the due date
+	LateReject LatePolicy = "reject"
+)
+
+// IsValid reports whether p is a known late policy
+func (p LatePolicy) IsValid() bool {
+	switch p {
+	case LateAccept, LatePenalize, LateReject:
+		return true
+	}
+	return false
+}
+
+// AssignmentCriterion is a part of the work scored when grading an assignment. Its ID is its position, from 1.
+type AssignmentCriterion struct {
+	ID          int     `json:"id"`
+	Title       string  `json:"title" validate:"required"`
+	Description string  `json:"description,omitempty"`
+	Points      float64 `json:"points" validate:"gt=0"`
+}
+
+// Assignment is work the learners hand in, attached to a lesson
+type Assignment struct {
+	ID           int64  `json:"id"`
+	LessonID     int64  `json:"lesson_id"`
+	CourseID     int64  `json:"course_id"`
+	Title        string `json:"title" validate:"required,max=255"`
+	Instructions string `json:"instructions,omitempty"`
+	// DueAt is the time the submissions are due. 0 means no due date.
+	DueAt      int64      `json:"due_at,omitempty" validate:"gte=0"`
+	LatePolicy LatePolicy `json:"late_policy"`
+	// LatePenalty is the percentage of the score deducted for each day late, with the penalize policy
+	LatePenalty float64 `json:"late_penalty" validate:"gte=0,lte=100"`
+	// MaxPoints is the best score. It is the total of the points of the criteria when there are criteria, and the
+	// best total of the rubric with a rubric.
+	MaxPoints float64               `json:"max_points" validate:"gte=0"`
+	Criteria  []AssignmentCriterion `json:"criteria" validate:"dive"`
+	// RubricID marks the submissions with a rubric of the organization, instead of the criteria
+	RubricID int64 `json:"rubric_id,omitempty"`
+	// PeerReview has the learners review each other's submissions against the rubric after the due date
+	PeerReview *PeerReviewSettings `json:"peer_review,omitempty"`
+	// AllowResubmission lets the learners submit again until their submission is graded
+	AllowResubmission bool `json:"allow_resubmission"`
+	// MaxSubmissions is the number of submissions of a learner. 0 means unlimited.
+	MaxSubmissions int   `json:"max_submissions" validate:"gte=0"`
+	CreatedBy      int64 `json:"created_by,omitempty"`
+	UpdatedAt      int64 `json:"updated_at"`
+	CreatedAt      int64 `json:"created_at"`
+}
+
+// SubmissionStatus is the state of a submission
+type SubmissionStatus string
+
+// Submission Status
+const (
+	SubmissionSubmitted SubmissionStatus = "submitted"
+	SubmissionGraded    SubmissionStatus = "graded"
+	// SubmissionReturned is a graded submission the learner is asked to submit again
+	SubmissionReturned SubmissionStatus = "returned"
+)
+
+// SubmissionFile is a file handed in with a submission
+type SubmissionFile struct {
+	// Name is the name of the stored file
+	Name string `json:"name"`
+	// Filename is the name of the file uploaded by the learner
+	Filename string         `json:"filename"`
+	Type     string         `json:"file_type"`
+	Size     int64          `json:"file_size"`
+	File     multipart.File `json:"-" faker:"-"`
+}
+
+// CriterionScore is the score given for a criterion of an assignment or of a rubric. With a rubric, the level
+// of the criterion is given and sets the score.
+type CriterionScore struct {
+	CriterionID int     `json:"criterion_id" validate:"required"`
+	LevelID     int     `json:"level_id,omitempty" validate:"gte=0"`
+	Score       float64 `json:"score" validate:"gte=0"`
+	Comment     string  `json:"comment,omitempty"`
+}
+
+// Submission is the work a learner hands in for an assignment
+type Submission struct {
+	ID           int64 `json:"id"`
+	AssignmentID int64 `json:"assignment_id"`
+	UserID       int64 `json:"user_id"`
+	// Number counts the submissions of the learner to the assignment, from 1
+	Number      int              `json:"number"`
+	Text        string           `json:"text,omitempty"`
+	Files       []SubmissionFile `json:"files"`
+	Status      SubmissionStatus `json:"status"`
+	Late        bool             `json:"late"`
+	SubmittedAt int64            `json:"submitted_at"`
+	// Score, Scores, Penalty, FinalScore and Feedback are set when the submission is graded
+	Score  *float64         `json:"score,omitempty"`
+	Scores []CriterionScore `json:"scores,omitempty"`
+	// Penalty is the percentage of the score deducted for lateness
+	Penalty    float64  `json:"penalty,omitempty"`
+	FinalScore *float64 `json:"final_score,omitempty"`
+	Feedback   string   `json:"feedback,omitempty"`
+	GradedBy   int64    `json:"graded_by,omitempty"`
+	GradedAt   int64    `json:"graded_at,omitempty"`
+}
+
+// SubmissionGrade is the grading of a submission. The score is the total of the scores of the criteria when the
+// assignment has criteria.
+type SubmissionGrade struct {
+	Score    float64          `json:"score" validate:"gte=0"`
+	Scores   []CriterionScore `json:"scores" validate:"dive"`
+	Feedback string           `json:"feedback"`
+	// Return asks the learner to submit again
+	Return bool `json:"return"`
+}
+
+// AssignmentUseCase represent the Assignment's usecases
+type AssignmentUseCase interface {
+	GetByLesson(ctx context.Context, lessonID int64) ([]Assignment, error)
+	GetByID(ctx context.Context, id int64) (*Assignment, error)
+	CreateAssignment(ctx context.Context, assignment *Assignment) error
+	UpdateAssignment(ctx context.Context, assignment *Assignment, id int64) error
+	DeleteAssignment(ctx context.Context, id int64) error
+	Submit(ctx context.Context, submission *Submission) error
+	GetSubmission(ctx context.Context, id int64) (*Submission, error)
+	GetSubmissions(ctx context.Context, assignmentID int64) ([]Submission, error)
+	GetResults(ctx context.Context, assignmentID int64, start int, limit int) ([]Submission, error)
+	GradeSubmission(ctx context.Context, id int64, grade *SubmissionGrade) (*Submission, error)
+	DownloadFile(ctx context.Context, submissionID int64, name string) (string, error)
+}
+
+// AssignmentRepository represent the Assignment's repository
+type AssignmentRepository interface {
+	GetByLesson(ctx context.Context, lessonID int64) ([]Assignment, error)
+	GetByID(ctx context.Context, id int64) (*Assignment, error)
+	CreateAssignment(ctx context.Context, assignment *Assignment) error
+	UpdateAssignment(ctx context.Context, assignment *Assignment) error
+	DeleteAssignment(ctx context.Context, id int64) error
+	CreateSubmission(ctx context.Context, submission *Submission) error
+	GetSubmission(ctx context.Context, id int64) (*Submission, error)
+	GetSubmissions(ctx context.Context, assignmentID int64, userID int64) ([]Submission, error)
+	GetResults(ctx context.Context, assignmentID int64, start int, limit int) ([]Submission, error)
+	GradeSubmission(ctx context.Context, submission *Submission) error
+}
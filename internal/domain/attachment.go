@@ -37,4 +37,6 @@ type AttachmentRepository interface {
 type AttachmentStorage interface {
 	CreateAttachment(ctx context.Context, attachment Attachment) error
 	DownloadAttachment(ctx context.Context, fileName string) (string, error)
+	// DeleteAttachment removes the file. A missing file is not an error.
+	DeleteAttachment(ctx context.Context, fileName string) error
 }
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+)
+
+// Login is the request body for password authentication. Login is either the email or the username.
+type Login struct {
+	Login    string `json:"login" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// RefreshRequest is the request body carrying a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// PasswordChange is the request body for changing the password of the current user
+type PasswordChange struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
+}
+
+// TokenPair is returned on login and refresh. When the login needs a second step, Challenge and
+// ChallengeToken are set instead of the tokens, and ExpiresIn is the lifetime of the challenge.
+type TokenPair struct {
+	AccessToken    string `json:"access_token,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	TokenType      string `json:"token_type,omitempty"`
+	ExpiresIn      int64  `json:"expires_in"`
+	Challenge      string `json:"challenge,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+}
+
+// RefreshToken is the server side record of an issued refresh token. Only the hash of the token is stored.
+// SessionID is 0 for the tokens issued before sessions were tracked.
+type RefreshToken struct {
+	ID             int64
+	UserID         int64
+	OrganizationID int64
+	SessionID      int64
+	TokenHash      string
+	ExpiresAt      int64
+	RevokedAt      int64
+	CreatedAt      int64
+}
+
+// Principal is the authenticated caller of a request. Callers authenticated by an API key have no user.
+type Principal struct {
+	UserID         int64
+	SessionID      int64
+	APIKeyID       int64
+	OrganizationID int64
+	Permissions    []Permission
+}
+
+// AuthUseCase represent the authentication usecases
+type AuthUseCase interface {
+	Login(ctx context.Context, login string, password string) (*TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	RevokeAll(ctx context.Context, userID int64) error
+	ChangePassword(ctx context.Context, userID int64, change *PasswordChange) error
+	Authenticate(ctx context.Context, accessToken string) (*Principal, error)
+	IssueTokens(ctx context.Context, user *User) (*TokenPair, error)
+	CompleteLogin(ctx context.Context, user *User) (*TokenPair, error)
+	ParseChallenge(ctx context.Context, challengeToken string) (*User, error)
+	RecordLoginFailure(ctx context.Context, user *User) error
+}
+
+// AuthRepository represent the refresh token repository
+type AuthRepository interface {
+	CreateRefreshToken(ctx context.Context, token *RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id int64, revokedAt int64) error
+	RevokeUserTokens(ctx context.Context, userID int64, revokedAt int64) error
+}
@@ -0,0 +1,61 @@
+package domain
+
+// Bulk actions
+const (
+	BulkPublish        = "publish"
+	BulkArchive        = "archive"
+	BulkDelete         = "delete"
+	BulkAssignCategory = "assign_category"
+	BulkAddTag         = "add_tag"
+	BulkRemoveTag      = "remove_tag"
+)
+
+// BulkAction is the request body for running one action on many items at once
+type BulkAction struct {
+	Action     string  `json:"action" validate:"required"`
+	IDs        []int64 `json:"ids" validate:"required,min=1,max=500"`
+	CategoryID int64   `json:"category_id,omitempty"`
+	TagID      int64   `json:"tag_id,omitempty"`
+	ChangeNote string  `json:"change_note,omitempty"`
+}
+
+// BulkResult is the outcome of a bulk action for a single item
+type BulkResult struct {
+	ID      int64  `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// FilterBulk runs allow on every id and returns the ids it allows, with a failed result for each of the others.
+// ErrNotFound and ErrForbidden only fail their item; any other error is returned.
+func FilterBulk(ids []int64, allow func(id int64) error) ([]int64, []BulkResult, error) {
+	allowed := make([]int64, 0, len(ids))
+	denied := make([]BulkResult, 0)
+	for _, id := range ids {
+		err := allow(id)
+		if err == ErrNotFound || err == ErrForbidden {
+			denied = append(denied, BulkResult{ID: id, Error: err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		allowed = append(allowed, id)
+	}
+	return allowed, denied, nil
+}
+
+// MergeBulk returns the result of each of the ids, in their order, out of the results of parts of them
+func MergeBulk(ids []int64, parts ...[]BulkResult) []BulkResult {
+	results := make(map[int64]BulkResult, len(ids))
+	for _, part := range parts {
+		for _, result := range part {
+			results[result.ID] = result
+		}
+	}
+	res := make([]BulkResult, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, results[id])
+	}
+	return res
+}
@@ -20,6 +20,7 @@ type CategoryUseCase interface {
 	UpdateCategory(ctx context.Context, Category *Category, id int64) error
 	CreateCategory(ctx context.Context, Category *Category) error
 	DeleteCategory(ctx context.Context, id int64) error
+	BulkAction(ctx context.Context, action *BulkAction) ([]BulkResult, error)
 }
 
 // CategoryRepository represent the Category's repository
@@ -30,4 +31,5 @@ type CategoryRepository interface {
 	UpdateCategory(ctx context.Context, Category *Category) error
 	CreateCategory(ctx context.Context, Category *Category) error
 	DeleteCategory(ctx context.Context, id int64) error
+	BulkDelete(ctx context.Context, ids []int64) ([]BulkResult, error)
 }
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+)
+
+// CollaboratorPermission is the level of access a user has to a course
+type CollaboratorPermission string
+
+// Collaborator permissions, from the lowest to the highest
+const (
+	// CollaboratorEditor edits the course, its lessons and its contents
+	CollaboratorEditor CollaboratorPermission = "editor"
+	// CollaboratorCoAuthor edits and publishes the course, and manages its collaborators
+	CollaboratorCoAuthor CollaboratorPermission = "co-author"
+	// CourseOwner is the access of the author of the course, who can also delete and restore it. It can not be granted.
+	CourseOwner CollaboratorPermission = "owner"
+)
+
+// rank orders the permissions, 0 being no access
+func (p CollaboratorPermission) rank() int {
+	switch p {
+	case CollaboratorEditor:
+		return 1
+	case CollaboratorCoAuthor:
+		return 2
+	case CourseOwner:
+		return 3
+	}
+	return 0
+}
+
+// Allows reports whether p gives at least the access of level
+func (p CollaboratorPermission) Allows(level CollaboratorPermission) bool {
+	return p.rank() > 0 && p.rank() >= level.rank()
+}
+
+// IsGrantable reports whether p can be given to a collaborator
+func (p CollaboratorPermission) IsGrantable() bool {
+	return p == CollaboratorEditor || p == CollaboratorCoAuthor
+}
+
+// CourseCollaborator is a user other than the author allowed to work on a course
+type CourseCollaborator struct {
+	CourseID   int64                  `json:"course_id"`
+	UserID     int64                  `json:"user_id" validate:"required"`
+	FirstName  string                 `json:"first_name,omitempty"`
+	LastName   string                 `json:"last_name,omitempty"`
+	Email      string                 `json:"email,omitempty"`
+	Permission CollaboratorPermission `json:"permission" validate:"required"`
+	AddedBy    int64                  `json:"added_by,omitempty"`
+	UpdatedAt  int64                  `json:"updated_at,omitempty"`
+	CreatedAt  int64                  `json:"created_at,omitempty"`
+}
+
+// CollaboratorUpdate is the request body changing the permission of a collaborator
+type CollaboratorUpdate struct {
+	Permission CollaboratorPermission `json:"permission" validate:"required"`
+}
+
+// CollaboratorUseCase represent the usecases of the course collaborators. The Authorize methods check the caller
+// has at least the given access to a course, the course of a lesson or the course of a content: they return
+// ErrNotFound when there is no such item in the caller's organization and ErrForbidden when the access is missing.
+// Callers with PermCourseManage have every access.
+type CollaboratorUseCase interface {
+	GetByCourse(ctx context.Context, courseID int64) ([]CourseCollaborator, error)
+	AddCollaborator(ctx context.Context, collaborator *CourseCollaborator) error
+	UpdateCollaborator(ctx context.Context, courseID int64, userID int64, permission CollaboratorPermission) error
+	RemoveCollaborator(ctx context.Context, courseID int64, userID int64) error
+	AuthorizeCourse(ctx context.Context, courseID int64, level CollaboratorPermission) error
+	AuthorizeLesson(ctx context.Context, lessonID int64, level CollaboratorPermission) error
+	AuthorizeContent(ctx context.Context, contentID int64, level CollaboratorPermission) error
+}
+
+// CollaboratorRepository represent the repository of the course collaborators. The Get*Access methods return
+// the access of the user to the course, the course of a lesson or the course of a content, trashed or not,
+// and an empty permission when the user has none.
+type CollaboratorRepository interface {
+	GetByCourse(ctx context.Context, courseID int64) ([]CourseCollaborator, error)
+	AddCollaborator(ctx context.Context, collaborator *CourseCollaborator) error
+	UpdateCollaborator(ctx context.Context, collaborator *CourseCollaborator) error
+	RemoveCollaborator(ctx context.Context, courseID int64, userID int64) error
+	GetCourseAccess(ctx context.Context, courseID int64, userID int64) (CollaboratorPermission, error)
+	GetLessonAccess(ctx context.Context, lessonID int64, userID int64) (CollaboratorPermission, error)
+	GetContentAccess(ctx context.Context, contentID int64, userID int64) (CollaboratorPermission, error)
+}
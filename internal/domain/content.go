@@ -57,6 +57,19 @@ type Content struct {
 	Caption     string         `json:"caption,omitempty"`
 	UpdatedAt   int64          `json:"updated_at,omitempty"`
 	CreatedAt   int64          `json:"created_at,omitempty"`
+	DeletedAt   int64          `json:"deleted_at,omitempty"`
+}
+
+// ContentRevision is the state of a Content saved before it was updated, with the
+// diff of that update and the user who made it
+type ContentRevision struct {
+	ID        int64    `json:"id"`
+	ContentID int64    `json:"content_id"`
+	Revision  int      `json:"revision"`
+	AuthorID  int64    `json:"author_id,omitempty"`
+	Content   *Content `json:"content,omitempty"`
+	Diff      string   `json:"diff,omitempty"`
+	CreatedAt int64    `json:"created_at"`
 }
 
 // ContentUseCase represent the Content's repository contract
@@ -68,17 +81,30 @@ type ContentUseCase interface {
 	DeleteContent(ctx context.Context, id int64) error
 	GetContentByLesson(ctx context.Context, lessonID int64) ([]Content, error)
 	DownloadContent(ctx context.Context, fileName string) (string, error)
+	GetRevisions(ctx context.Context, contentID int64) ([]ContentRevision, error)
+	GetRevision(ctx context.Context, contentID int64, revision int) (*ContentRevision, error)
+	RestoreRevision(ctx context.Context, contentID int64, revision int) (*Content, error)
+	GetTrash(ctx context.Context, start int, limit int) ([]Content, error)
+	RestoreContent(ctx context.Context, id int64) (*Content, error)
+	PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error)
+	BulkAction(ctx context.Context, action *BulkAction) ([]BulkResult, error)
 }
 
 // ContentRepository represent the Content's repository
 type ContentRepository interface {
 	GetAll(ctx context.Context, start int, limit int) ([]Content, error)
 	GetByID(ctx context.Context, id int64) (*Content, error)
-	UpdateContent(ctx context.Context, Content *Content) error
+	UpdateContent(ctx context.Context, Content *Content, revision *ContentRevision) error
 	CreateContent(ctx context.Context, Content *Content) error
-	DeleteContent(ctx context.Context, id int64) error
+	DeleteContent(ctx context.Context, id int64, deletedAt int64) error
 	GetContentCountByLesson(ctx context.Context, lessonID int64) (int, error)
 	GetContentByLesson(ctx context.Context, lessonID int64) ([]Content, error)
+	GetRevisions(ctx context.Context, contentID int64) ([]ContentRevision, error)
+	GetRevision(ctx context.Context, contentID int64, revision int) (*ContentRevision, error)
+	GetTrash(ctx context.Context, start int, limit int) ([]Content, error)
+	RestoreContent(ctx context.Context, id int64, updatedAt int64) error
+	PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error)
+	BulkDelete(ctx context.Context, ids []int64, deletedAt int64) ([]BulkResult, error)
 }
 
 // ContentStorage represent the content's storage contract
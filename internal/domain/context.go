@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+)
+
+type contextKey string
+
+const userIDContextKey contextKey = "user_id"
+
+// WithUserID returns a copy of ctx carrying the ID of the user performing the request
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDContextKey, userID)
+}
+
+// UserIDFromContext returns the ID of the user performing the request, or 0 when unknown
+func UserIDFromContext(ctx context.Context) int64 {
+	userID, _ := ctx.Value(userIDContextKey).(int64)
+	return userID
+}
+
+const permissionsContextKey contextKey = "permissions"
+
+// WithPermissions returns a copy of ctx carrying the permissions of the user performing the request
+func WithPermissions(ctx context.Context, permissions []Permission) context.Context {
+	return context.WithValue(ctx, permissionsContextKey, permissions)
+}
+
+// PermissionsFromContext returns the permissions of the user performing the request
+func PermissionsFromContext(ctx context.Context) []Permission {
+	permissions, _ := ctx.Value(permissionsContextKey).([]Permission)
+	return permissions
+}
+
+// HasPermission reports whether the user performing the request was granted permission
+func HasPermission(ctx context.Context, permission Permission) bool {
+	for _, p := range PermissionsFromContext(ctx) {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermissions reports whether the user performing the request was granted every one of permissions,
+// so that a role or a scope granting them can be handed out without escalating the caller's privileges
+func HasPermissions(ctx context.Context, permissions []Permission) bool {
+	for _, p := range permissions {
+		if !HasPermission(ctx, p) {
+			return false
+		}
+	}
+	return true
+}
+
+const organizationIDContextKey contextKey = "organization_id"
+
+// WithOrganizationID returns a copy of ctx carrying the organization the request is scoped to
+func WithOrganizationID(ctx context.Context, organizationID int64) context.Context {
+	return context.WithValue(ctx, organizationIDContextKey, organizationID)
+}
+
+// OrganizationIDFromContext returns the organization the request is scoped to, or 0 when unknown.
+// Repositories match no rows for organization 0, so an unscoped request sees no data.
+func OrganizationIDFromContext(ctx context.Context) int64 {
+	organizationID, _ := ctx.Value(organizationIDContextKey).(int64)
+	return organizationID
+}
+
+const sessionIDContextKey contextKey = "session_id"
+
+// WithSessionID returns a copy of ctx carrying the login session of the request
+func WithSessionID(ctx context.Context, sessionID int64) context.Context {
+	return context.WithValue(ctx, sessionIDContextKey, sessionID)
+}
+
+// SessionIDFromContext returns the login session of the request, or 0 when unknown
+func SessionIDFromContext(ctx context.Context) int64 {
+	sessionID, _ := ctx.Value(sessionIDContextKey).(int64)
+	return sessionID
+}
+
+const clientContextKey contextKey = "client"
+
+// WithClient returns a copy of ctx carrying the IP address and the user agent of the request
+func WithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientContextKey, client)
+}
+
+// ClientFromContext returns the IP address and the user agent of the request
+func ClientFromContext(ctx context.Context) Client {
+	client, _ := ctx.Value(clientContextKey).(Client)
+	return client
+}
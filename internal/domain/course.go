@@ -28,11 +28,11 @@ type Course struct {
 	Description string       `json:"description,omitempty"`
 	ImageURL    string       `json:"image_url,omitempty"`
 	Duration    uint16       `json:"duration,omitempty"`
-	CategoryID  NullInt64    `json:"-,omitempty"`
+	CategoryID  NullInt64    `json:"-"`
 	Category    Category     `json:"categories,omitempty"`
 	Tags        []Tag        `json:"tags,omitempty"`
-	AuthorID    NullInt64    `json:"-,omitempty"`
-	Author      User         `json:"author,omitempty"`
+	AuthorID    NullInt64    `json:"-"`
+	Author      User         `json:"author,omitempty" validate:"-"`
 	Users       []User       `json:"users,omitempty"`
 	LessonCount int          `json:"lesson_count,omitempty"`
 	Lessons     []Lesson     `json:"lessons,omitempty"`
@@ -40,6 +40,7 @@ type Course struct {
 	Status      Status       `json:"status,omitempty"`
 	UpdatedAt   int64        `json:"updated_at,omitempty"`
 	CreatedAt   int64        `json:"created_at,omitempty"`
+	DeletedAt   int64        `json:"deleted_at,omitempty"`
 }
 
 // CourseStats is a struct representing the statistics for a single Course
@@ -61,6 +62,26 @@ type Tags struct {
 	Tag Tag `json:"tag"`
 }
 
+// CourseVersion is an immutable published snapshot of a Course, its lessons and contents
+type CourseVersion struct {
+	ID         int64   `json:"id"`
+	CourseID   int64   `json:"course_id"`
+	Version    int     `json:"version"`
+	ChangeNote string  `json:"change_note,omitempty"`
+	Course     *Course `json:"course,omitempty"`
+	CreatedAt  int64   `json:"created_at"`
+}
+
+// CoursePublish is the request body for publishing a new Course version
+type CoursePublish struct {
+	ChangeNote string `json:"change_note" validate:"required"`
+}
+
+// CourseClone is the request body for cloning an existing Course
+type CourseClone struct {
+	Title string `json:"title" validate:"required"`
+}
+
 // CourseUseCase represent the course's usecases
 type CourseUseCase interface {
 	GetAll(ctx context.Context, start int, limit int) ([]Course, error)
@@ -69,6 +90,15 @@ type CourseUseCase interface {
 	UpdateCourse(ctx context.Context, course *Course, id int64) error
 	CreateCourse(ctx context.Context, course *Course) error
 	DeleteCourse(ctx context.Context, id int64) error
+	CloneCourse(ctx context.Context, id int64, title string) (*Course, error)
+	PublishCourse(ctx context.Context, id int64, changeNote string) (*CourseVersion, error)
+	GetVersions(ctx context.Context, courseID int64) ([]CourseVersion, error)
+	GetVersion(ctx context.Context, courseID int64, version int) (*CourseVersion, error)
+	GetPublishedVersion(ctx context.Context, courseID int64) (*CourseVersion, error)
+	GetTrash(ctx context.Context, start int, limit int) ([]Course, error)
+	RestoreCourse(ctx context.Context, id int64) (*Course, error)
+	PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error)
+	BulkAction(ctx context.Context, action *BulkAction) ([]BulkResult, error)
 	// Archive(ctx context.Context, course *Course) error
 	// AssignToUser(ctx context.Context, course *Course, user *User)
 }
@@ -80,6 +110,17 @@ type CourseRepository interface {
 	GetByTitle(ctx context.Context, title string) (*Course, error)
 	UpdateCourse(ctx context.Context, course *Course) error
 	CreateCourse(ctx context.Context, course *Course) error
-	DeleteCourse(ctx context.Context, id int64) error
+	DeleteCourse(ctx context.Context, id int64, deletedAt int64) error
 	GetCourseCount(ctx context.Context) (int64, error)
+	CloneCourse(ctx context.Context, id int64, course *Course) error
+	PublishCourse(ctx context.Context, version *CourseVersion) error
+	GetVersions(ctx context.Context, courseID int64) ([]CourseVersion, error)
+	GetVersion(ctx context.Context, courseID int64, version int) (*CourseVersion, error)
+	GetLatestVersion(ctx context.Context, courseID int64) (*CourseVersion, error)
+	GetTrash(ctx context.Context, start int, limit int) ([]Course, error)
+	GetTrashedByID(ctx context.Context, id int64) (*Course, error)
+	RestoreCourse(ctx context.Context, id int64, updatedAt int64) error
+	PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error)
+	BulkAction(ctx context.Context, action *BulkAction, updatedAt int64) ([]BulkResult, error)
+	BulkPublish(ctx context.Context, versions []*CourseVersion) ([]BulkResult, error)
 }
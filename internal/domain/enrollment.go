@@ -1,5 +1,37 @@
 package domain
 
-// Enrollment ...
+import (
+	"context"
+)
+
+// Enrollment Status
+const (
+	EnrollmentActive    = 1
+	EnrollmentCompleted = 2
+)
+
+// Enrollment represents a learner enrolled in a specific published version of a Course
 type Enrollment struct {
+	ID              int64          `json:"id,omitempty"`
+	CourseID        int64          `json:"course_id,omitempty"`
+	UserID          int64          `json:"user_id" validate:"required"`
+	CourseVersionID int64          `json:"course_version_id,omitempty"`
+	Version         int            `json:"version,omitempty"`
+	CourseVersion   *CourseVersion `json:"course_version,omitempty"`
+	Status          int            `json:"status,omitempty"`
+	CreatedAt       int64          `json:"created_at,omitempty"`
+}
+
+// EnrollmentUseCase represent the Enrollment's usecases
+type EnrollmentUseCase interface {
+	EnrollUser(ctx context.Context, enrollment *Enrollment) error
+	GetByCourse(ctx context.Context, courseID int64, start int, limit int) ([]Enrollment, error)
+	GetEnrollment(ctx context.Context, courseID int64, userID int64) (*Enrollment, error)
+}
+
+// EnrollmentRepository represent the Enrollment's repository
+type EnrollmentRepository interface {
+	CreateEnrollment(ctx context.Context, enrollment *Enrollment) error
+	GetByCourse(ctx context.Context, courseID int64, start int, limit int) ([]Enrollment, error)
+	GetEnrollment(ctx context.Context, courseID int64, userID int64) (*Enrollment, error)
 }
@@ -17,4 +17,22 @@ var (
 	ErrBadInput = errors.New("Given Input is not valid")
 	// ErrFileEmpty will throw if the file is empty
 	ErrFileEmpty = errors.New("Given input file is empty")
+	// ErrCourseNotPublished will throw if the course has no published version yet
+	ErrCourseNotPublished = errors.New("Course is not published yet")
+	// ErrUnauthorized will throw if the request has no valid access or refresh token
+	ErrUnauthorized = errors.New("You are not authorized")
+	// ErrForbidden will throw if the user lacks the permission for the requested action
+	ErrForbidden = errors.New("You are not allowed to perform this action")
+	// ErrInvalidCredentials will throw if the login or password is wrong, or the user is inactive
+	ErrInvalidCredentials = errors.New("Invalid login or password")
+	// ErrAccountLocked will throw if the login is refused after too many wrong passwords
+	ErrAccountLocked = errors.New("Account is locked after too many failed logins, try again later")
+	// ErrTooManyRequests will throw if the caller repeats the action too often
+	ErrTooManyRequests = errors.New("Too many requests, try again later")
+	// ErrNoAttemptsLeft will throw if the learner used every attempt of a quiz
+	ErrNoAttemptsLeft = errors.New("No attempts left for this quiz")
+	// ErrTimeLimitExceeded will throw if an attempt is submitted after the time limit of the quiz
+	ErrTimeLimitExceeded = errors.New("The time limit of the attempt is exceeded")
+	// ErrSubmissionClosed will throw if the learner can not submit to an assignment anymore
+	ErrSubmissionClosed = errors.New("Submissions to this assignment are closed")
 )
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+)
+
+// Invitation Status
+const (
+	InvitationPending  = 1
+	InvitationAccepted = 2
+	InvitationRevoked  = 3
+)
+
+// Invitation invites someone by email to join the organization. The account is created with the role,
+// the teams and the course enrollments of the invitation when it is accepted.
+type Invitation struct {
+	ID             int64   `json:"id"`
+	OrganizationID int64   `json:"organization_id"`
+	Email          string  `json:"email" validate:"required,email,max=255"`
+	FirstName      string  `json:"first_name,omitempty" validate:"max=100"`
+	LastName       string  `json:"last_name,omitempty" validate:"max=100"`
+	RoleID         int64   `json:"role_id" validate:"required"`
+	TeamIDs        []int64 `json:"team_ids,omitempty"`
+	CourseIDs      []int64 `json:"course_ids,omitempty"`
+	InvitedBy      int64   `json:"invited_by"`
+	UserID         int64   `json:"user_id,omitempty"`
+	Status         int     `json:"status"`
+	// TokenHash is the SHA-256 of the token sent by email; the token itself is not stored
+	TokenHash  string `json:"-"`
+	ExpiresAt  int64  `json:"expires_at"`
+	AcceptedAt int64  `json:"accepted_at,omitempty"`
+	UpdatedAt  int64  `json:"updated_at"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+// InvitationAcceptance is the request body accepting an invitation. The names default to those of the invitation.
+type InvitationAcceptance struct {
+	Token     string `json:"token" validate:"required"`
+	Password  string `json:"password" validate:"required,min=8"`
+	Username  string `json:"username,omitempty" validate:"max=255"`
+	FirstName string `json:"first_name,omitempty" validate:"max=100"`
+	LastName  string `json:"last_name,omitempty" validate:"max=100"`
+}
+
+// InvitationUseCase represent the Invitation's usecases
+type InvitationUseCase interface {
+	GetAll(ctx context.Context, status int, start int, limit int) ([]Invitation, error)
+	GetByID(ctx context.Context, id int64) (*Invitation, error)
+	CreateInvitation(ctx context.Context, invitation *Invitation) error
+	ResendInvitation(ctx context.Context, id int64) error
+	RevokeInvitation(ctx context.Context, id int64) error
+	AcceptInvitation(ctx context.Context, acceptance *InvitationAcceptance) (*TokenPair, error)
+}
+
+// InvitationRepository represent the Invitation's repository contract
+type InvitationRepository interface {
+	GetAll(ctx context.Context, status int, start int, limit int) ([]Invitation, error)
+	GetByID(ctx context.Context, id int64) (*Invitation, error)
+	GetPendingByEmail(ctx context.Context, email string) (*Invitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Invitation, error)
+	CreateInvitation(ctx context.Context, invitation *Invitation) error
+	RenewToken(ctx context.Context, id int64, tokenHash string, expiresAt int64, updatedAt int64) error
+	RevokeInvitation(ctx context.Context, id int64, updatedAt int64) error
+	AcceptInvitation(ctx context.Context, invitation *Invitation, user *User) error
+}
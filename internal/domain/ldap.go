@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// LDAPDirectory is the LDAP directory (e.g. Active Directory) of an organization. Its users can log in
+// with their directory password, and a periodic sync imports them and follows their group membership
+// with one team per group.
+type LDAPDirectory struct {
+	ID             int64 `json:"id"`
+	OrganizationID int64 `json:"organization_id"`
+	// URL is ldap://host[:port] or ldaps://host[:port]
+	URL    string `json:"url" validate:"required,url,max=255"`
+	BindDN string `json:"bind_dn" validate:"required,max=255"`
+	// BindPassword is never returned by the API
+	BindPassword string `json:"bind_password,omitempty" validate:"max=255"`
+	BaseDN       string `json:"base_dn" validate:"required,max=255"`
+	// UserFilter selects the users below BaseDN, (objectClass=person) by default
+	UserFilter         string `json:"user_filter,omitempty" validate:"max=255"`
+	UsernameAttribute  string `json:"username_attribute,omitempty" validate:"max=100"`
+	EmailAttribute     string `json:"email_attribute,omitempty" validate:"max=100"`
+	FirstNameAttribute string `json:"first_name_attribute,omitempty" validate:"max=100"`
+	LastNameAttribute  string `json:"last_name_attribute,omitempty" validate:"max=100"`
+	// GroupAttribute lists the DNs of the groups of a user, memberOf by default. Only the groups
+	// below GroupBaseDN are synced to teams when it is set.
+	GroupAttribute string `json:"group_attribute,omitempty" validate:"max=100"`
+	GroupBaseDN    string `json:"group_base_dn,omitempty" validate:"max=255"`
+	// DefaultRole is given to the imported users and to the teams of the groups
+	DefaultRole string `json:"default_role" validate:"required,max=50"`
+	Enabled     bool   `json:"enabled"`
+	LastSyncAt  int64  `json:"last_sync_at,omitempty"`
+	UpdatedAt   int64  `json:"updated_at,omitempty"`
+	CreatedAt   int64  `json:"created_at,omitempty"`
+}
+
+// LDAPLogin is the request body of a login with a directory password
+type LDAPLogin struct {
+	OrganizationID int64  `json:"organization_id" validate:"required"`
+	Username       string `json:"username" validate:"required"`
+	Password       string `json:"password" validate:"required"`
+}
+
+// LDAPEntry is an entry returned by a directory search. Attribute names are lower case.
+type LDAPEntry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Values returns the values of the attribute. Attribute names are case insensitive.
+func (e *LDAPEntry) Values(attribute string) []string {
+	return e.Attributes[strings.ToLower(attribute)]
+}
+
+// Value returns the first value of the attribute
+func (e *LDAPEntry) Value(attribute string) string {
+	if values := e.Values(attribute); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// LDAPSyncResult sums up a directory sync
+type LDAPSyncResult struct {
+	Users   int `json:"users"`
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+	Teams   int `json:"teams"`
+}
+
+// LDAPUseCase represent the LDAP directory usecases
+type LDAPUseCase interface {
+	GetDirectory(ctx context.Context) (*LDAPDirectory, error)
+	SaveDirectory(ctx context.Context, directory *LDAPDirectory) error
+	DeleteDirectory(ctx context.Context) error
+	Login(ctx context.Context, login *LDAPLogin) (*TokenPair, error)
+	Sync(ctx context.Context) (*LDAPSyncResult, error)
+	SyncAll(ctx context.Context) error
+}
+
+// LDAPRepository represent the LDAP directory repository contract
+type LDAPRepository interface {
+	GetDirectory(ctx context.Context) (*LDAPDirectory, error)
+	GetEnabledDirectories(ctx context.Context) ([]LDAPDirectory, error)
+	SaveDirectory(ctx context.Context, directory *LDAPDirectory) error
+	DeleteDirectory(ctx context.Context) error
+	UpdateLastSync(ctx context.Context, syncedAt int64) error
+	SaveGroupTeam(ctx context.Context, groupDN string, name string, roleID int64, now int64) (int64, error)
+	SetGroupTeams(ctx context.Context, userID int64, teamIDs []int64, now int64) error
+	// GetLinkedUser returns the id of the user imported from the directory entry
+	GetLinkedUser(ctx context.Context, dn string) (int64, error)
+	LinkUser(ctx context.Context, userID int64, dn string, now int64) error
+}
+
+// LDAPClient talks to an LDAP directory
+type LDAPClient interface {
+	// Authenticate looks up the user by username and binds with the password
+	Authenticate(ctx context.Context, directory *LDAPDirectory, username string, password string) (*LDAPEntry, error)
+	// Users returns every user matching the user filter
+	Users(ctx context.Context, directory *LDAPDirectory) ([]LDAPEntry, error)
+}
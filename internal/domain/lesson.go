@@ -14,6 +14,19 @@ type Lesson struct {
 	Contents    []Content `json:"contents,omitempty"`
 	UpdatedAt   int64     `json:"updated_at,omitempty"`
 	CreatedAt   int64     `json:"created_at,omitempty"`
+	DeletedAt   int64     `json:"deleted_at,omitempty"`
+}
+
+// LessonRevision is the state of a Lesson saved before it was updated, with the
+// diff of that update and the user who made it
+type LessonRevision struct {
+	ID        int64   `json:"id"`
+	LessonID  int64   `json:"lesson_id"`
+	Revision  int     `json:"revision"`
+	AuthorID  int64   `json:"author_id,omitempty"`
+	Lesson    *Lesson `json:"lesson,omitempty"`
+	Diff      string  `json:"diff,omitempty"`
+	CreatedAt int64   `json:"created_at"`
 }
 
 // LessonUseCase represent the Lesson's repository contract
@@ -25,15 +38,28 @@ type LessonUseCase interface {
 	DeleteLesson(ctx context.Context, id int64) error
 	GetLessonCountByCourse(ctx context.Context, courseID int64) (int, error)
 	GetLessonByCourse(ctx context.Context, courseID int64) ([]Lesson, error)
+	GetRevisions(ctx context.Context, lessonID int64) ([]LessonRevision, error)
+	GetRevision(ctx context.Context, lessonID int64, revision int) (*LessonRevision, error)
+	RestoreRevision(ctx context.Context, lessonID int64, revision int) (*Lesson, error)
+	GetTrash(ctx context.Context, start int, limit int) ([]Lesson, error)
+	RestoreLesson(ctx context.Context, id int64) (*Lesson, error)
+	PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error)
+	BulkAction(ctx context.Context, action *BulkAction) ([]BulkResult, error)
 }
 
 // LessonRepository represent the Lesson's repository
 type LessonRepository interface {
 	GetAll(ctx context.Context, start int, limit int) ([]Lesson, error)
 	GetByID(ctx context.Context, id int64) (*Lesson, error)
-	UpdateLesson(ctx context.Context, Lesson *Lesson) error
+	UpdateLesson(ctx context.Context, Lesson *Lesson, revision *LessonRevision) error
 	CreateLesson(ctx context.Context, Lesson *Lesson) error
-	DeleteLesson(ctx context.Context, id int64) error
+	DeleteLesson(ctx context.Context, id int64, deletedAt int64) error
 	GetLessonCountByCourse(ctx context.Context, courseID int64) (int, error)
 	GetLessonByCourse(ctx context.Context, courseID int64) ([]Lesson, error)
+	GetRevisions(ctx context.Context, lessonID int64) ([]LessonRevision, error)
+	GetRevision(ctx context.Context, lessonID int64, revision int) (*LessonRevision, error)
+	GetTrash(ctx context.Context, start int, limit int) ([]Lesson, error)
+	RestoreLesson(ctx context.Context, id int64, updatedAt int64) error
+	PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error)
+	BulkAction(ctx context.Context, action *BulkAction, updatedAt int64) ([]BulkResult, error)
 }
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"context"
+)
+
+// Mail is a plain text email
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails. The implementation is chosen in the configuration.
+type Mailer interface {
+	Send(ctx context.Context, mail *Mail) error
+}
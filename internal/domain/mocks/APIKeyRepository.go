@@ -0,0 +1,126 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+// CreateAPIKey provides a mock function with given fields: ctx, key
+func (_m *APIKeyRepository) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.APIKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, start, limit
+func (_m *APIKeyRepository) GetAll(ctx context.Context, start int, limit int) ([]domain.APIKey, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.APIKey); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByHash provides a mock function with given fields: ctx, keyHash
+func (_m *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	ret := _m.Called(ctx, keyHash)
+
+	var r0 *domain.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.APIKey); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *APIKeyRepository) GetByID(ctx context.Context, id int64) (*domain.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAPIKey provides a mock function with given fields: ctx, id, revokedAt
+func (_m *APIKeyRepository) RevokeAPIKey(ctx context.Context, id int64, revokedAt int64) error {
+	ret := _m.Called(ctx, id, revokedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, revokedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TouchAPIKey provides a mock function with given fields: ctx, id, usedAt
+func (_m *APIKeyRepository) TouchAPIKey(ctx context.Context, id int64, usedAt int64) error {
+	ret := _m.Called(ctx, id, usedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,121 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyUseCase is an autogenerated mock type for the APIKeyUseCase type
+type APIKeyUseCase struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, key
+func (_m *APIKeyUseCase) Authenticate(ctx context.Context, key string) (*domain.Principal, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *domain.Principal
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Principal); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Principal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAPIKey provides a mock function with given fields: ctx, key
+func (_m *APIKeyUseCase) CreateAPIKey(ctx context.Context, key *domain.APIKey) (*domain.NewAPIKey, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *domain.NewAPIKey
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.APIKey) *domain.NewAPIKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.NewAPIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.APIKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, start, limit
+func (_m *APIKeyUseCase) GetAll(ctx context.Context, start int, limit int) ([]domain.APIKey, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.APIKey); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *APIKeyUseCase) GetByID(ctx context.Context, id int64) (*domain.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAPIKey provides a mock function with given fields: ctx, id
+func (_m *APIKeyUseCase) RevokeAPIKey(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccountRepository is an autogenerated mock type for the AccountRepository type
+type AccountRepository struct {
+	mock.Mock
+}
+
+// CountTokens provides a mock function with given fields: ctx, userID, purpose, since
+func (_m *AccountRepository) CountTokens(ctx context.Context, userID int64, purpose string, since int64) (int, error) {
+	ret := _m.Called(ctx, userID, purpose, since)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) int); ok {
+		r0 = rf(ctx, userID, purpose, since)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, int64) error); ok {
+		r1 = rf(ctx, userID, purpose, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateToken provides a mock function with given fields: ctx, token
+func (_m *AccountRepository) CreateToken(ctx context.Context, token *domain.UserToken) error {
+	ret := _m.Called(ctx, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetToken provides a mock function with given fields: ctx, tokenHash
+func (_m *AccountRepository) GetToken(ctx context.Context, tokenHash string) (*domain.UserToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 *domain.UserToken
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.UserToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseToken provides a mock function with given fields: ctx, token, usedAt
+func (_m *AccountRepository) UseToken(ctx context.Context, token *domain.UserToken, usedAt int64) error {
+	ret := _m.Called(ctx, token, usedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserToken, int64) error); ok {
+		r0 = rf(ctx, token, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccountUseCase is an autogenerated mock type for the AccountUseCase type
+type AccountUseCase struct {
+	mock.Mock
+}
+
+// ForgotPassword provides a mock function with given fields: ctx, email
+func (_m *AccountUseCase) ForgotPassword(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResendVerification provides a mock function with given fields: ctx, userID
+func (_m *AccountUseCase) ResendVerification(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, reset
+func (_m *AccountUseCase) ResetPassword(ctx context.Context, reset *domain.PasswordReset) error {
+	ret := _m.Called(ctx, reset)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.PasswordReset) error); ok {
+		r0 = rf(ctx, reset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendVerification provides a mock function with given fields: ctx, user
+func (_m *AccountUseCase) SendVerification(ctx context.Context, user *domain.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *AccountUseCase) VerifyEmail(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,230 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AssignmentUseCase is an autogenerated mock type for the AssignmentUseCase type
+type AssignmentUseCase struct {
+	mock.Mock
+}
+
+// CreateAssignment provides a mock function with given fields: ctx, assignment
+func (_m *AssignmentUseCase) CreateAssignment(ctx context.Context, assignment *domain.Assignment) error {
+	ret := _m.Called(ctx, assignment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Assignment) error); ok {
+		r0 = rf(ctx, assignment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAssignment provides a mock function with given fields: ctx, id
+func (_m *AssignmentUseCase) DeleteAssignment(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DownloadFile provides a mock function with given fields: ctx, submissionID, name
+func (_m *AssignmentUseCase) DownloadFile(ctx context.Context, submissionID int64, name string) (string, error) {
+	ret := _m.Called(ctx, submissionID, name)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) string); ok {
+		r0 = rf(ctx, submissionID, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, submissionID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *AssignmentUseCase) GetByID(ctx context.Context, id int64) (*domain.Assignment, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Assignment
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Assignment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Assignment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *AssignmentUseCase) GetByLesson(ctx context.Context, lessonID int64) ([]domain.Assignment, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	var r0 []domain.Assignment
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Assignment); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Assignment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResults provides a mock function with given fields: ctx, assignmentID, start, limit
+func (_m *AssignmentUseCase) GetResults(ctx context.Context, assignmentID int64, start int, limit int) ([]domain.Submission, error) {
+	ret := _m.Called(ctx, assignmentID, start, limit)
+
+	var r0 []domain.Submission
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.Submission); ok {
+		r0 = rf(ctx, assignmentID, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Submission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(ctx, assignmentID, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubmission provides a mock function with given fields: ctx, id
+func (_m *AssignmentUseCase) GetSubmission(ctx context.Context, id int64) (*domain.Submission, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Submission
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Submission); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Submission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubmissions provides a mock function with given fields: ctx, assignmentID
+func (_m *AssignmentUseCase) GetSubmissions(ctx context.Context, assignmentID int64) ([]domain.Submission, error) {
+	ret := _m.Called(ctx, assignmentID)
+
+	var r0 []domain.Submission
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Submission); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Submission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GradeSubmission provides a mock function with given fields: ctx, id, grade
+func (_m *AssignmentUseCase) GradeSubmission(ctx context.Context, id int64, grade *domain.SubmissionGrade) (*domain.Submission, error) {
+	ret := _m.Called(ctx, id, grade)
+
+	var r0 *domain.Submission
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.SubmissionGrade) *domain.Submission); ok {
+		r0 = rf(ctx, id, grade)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Submission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *domain.SubmissionGrade) error); ok {
+		r1 = rf(ctx, id, grade)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Submit provides a mock function with given fields: ctx, submission
+func (_m *AssignmentUseCase) Submit(ctx context.Context, submission *domain.Submission) error {
+	ret := _m.Called(ctx, submission)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Submission) error); ok {
+		r0 = rf(ctx, submission)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAssignment provides a mock function with given fields: ctx, assignment, id
+func (_m *AssignmentUseCase) UpdateAssignment(ctx context.Context, assignment *domain.Assignment, id int64) error {
+	ret := _m.Called(ctx, assignment, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Assignment, int64) error); ok {
+		r0 = rf(ctx, assignment, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -28,6 +28,20 @@ func (_m *AttachmentStorage) CreateAttachment(ctx context.Context, attachment do
 	return r0
 }
 
+// DeleteAttachment provides a mock function with given fields: ctx, fileName
+func (_m *AttachmentStorage) DeleteAttachment(ctx context.Context, fileName string) error {
+	ret := _m.Called(ctx, fileName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, fileName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DownloadAttachment provides a mock function with given fields: ctx, fileName
 func (_m *AttachmentStorage) DownloadAttachment(ctx context.Context, fileName string) (string, error) {
 	ret := _m.Called(ctx, fileName)
@@ -0,0 +1,209 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthUseCase is an autogenerated mock type for the AuthUseCase type
+type AuthUseCase struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, accessToken
+func (_m *AuthUseCase) Authenticate(ctx context.Context, accessToken string) (*domain.Principal, error) {
+	ret := _m.Called(ctx, accessToken)
+
+	var r0 *domain.Principal
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Principal); ok {
+		r0 = rf(ctx, accessToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Principal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accessToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangePassword provides a mock function with given fields: ctx, userID, change
+func (_m *AuthUseCase) ChangePassword(ctx context.Context, userID int64, change *domain.PasswordChange) error {
+	ret := _m.Called(ctx, userID, change)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.PasswordChange) error); ok {
+		r0 = rf(ctx, userID, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteLogin provides a mock function with given fields: ctx, user
+func (_m *AuthUseCase) CompleteLogin(ctx context.Context, user *domain.User) (*domain.TokenPair, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 *domain.TokenPair
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) *domain.TokenPair); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TokenPair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueTokens provides a mock function with given fields: ctx, user
+func (_m *AuthUseCase) IssueTokens(ctx context.Context, user *domain.User) (*domain.TokenPair, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 *domain.TokenPair
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) *domain.TokenPair); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TokenPair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, login, password
+func (_m *AuthUseCase) Login(ctx context.Context, login string, password string) (*domain.TokenPair, error) {
+	ret := _m.Called(ctx, login, password)
+
+	var r0 *domain.TokenPair
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.TokenPair); ok {
+		r0 = rf(ctx, login, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TokenPair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, login, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthUseCase) Logout(ctx context.Context, refreshToken string) error {
+	ret := _m.Called(ctx, refreshToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ParseChallenge provides a mock function with given fields: ctx, challengeToken
+func (_m *AuthUseCase) ParseChallenge(ctx context.Context, challengeToken string) (*domain.User, error) {
+	ret := _m.Called(ctx, challengeToken)
+
+	var r0 *domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(ctx, challengeToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, challengeToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordLoginFailure provides a mock function with given fields: ctx, user
+func (_m *AuthUseCase) RecordLoginFailure(ctx context.Context, user *domain.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthUseCase) Refresh(ctx context.Context, refreshToken string) (*domain.TokenPair, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	var r0 *domain.TokenPair
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.TokenPair); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TokenPair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAll provides a mock function with given fields: ctx, userID
+func (_m *AuthUseCase) RevokeAll(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -14,6 +14,29 @@ type CategoryRepository struct {
 	mock.Mock
 }
 
+// BulkDelete provides a mock function with given fields: ctx, ids
+func (_m *CategoryRepository) BulkDelete(ctx context.Context, ids []int64) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []domain.BulkResult); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateCategory provides a mock function with given fields: ctx, Category
 func (_m *CategoryRepository) CreateCategory(ctx context.Context, Category *domain.Category) error {
 	ret := _m.Called(ctx, Category)
@@ -14,6 +14,29 @@ type CategoryUseCase struct {
 	mock.Mock
 }
 
+// BulkAction provides a mock function with given fields: ctx, action
+func (_m *CategoryUseCase) BulkAction(ctx context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, action)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BulkAction) []domain.BulkResult); ok {
+		r0 = rf(ctx, action)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.BulkAction) error); ok {
+		r1 = rf(ctx, action)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateCategory provides a mock function with given fields: ctx, Category
 func (_m *CategoryUseCase) CreateCategory(ctx context.Context, Category *domain.Category) error {
 	ret := _m.Called(ctx, Category)
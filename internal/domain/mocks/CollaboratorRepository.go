@@ -0,0 +1,143 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CollaboratorRepository is an autogenerated mock type for the CollaboratorRepository type
+type CollaboratorRepository struct {
+	mock.Mock
+}
+
+// AddCollaborator provides a mock function with given fields: ctx, collaborator
+func (_m *CollaboratorRepository) AddCollaborator(ctx context.Context, collaborator *domain.CourseCollaborator) error {
+	ret := _m.Called(ctx, collaborator)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CourseCollaborator) error); ok {
+		r0 = rf(ctx, collaborator)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByCourse provides a mock function with given fields: ctx, courseID
+func (_m *CollaboratorRepository) GetByCourse(ctx context.Context, courseID int64) ([]domain.CourseCollaborator, error) {
+	ret := _m.Called(ctx, courseID)
+
+	var r0 []domain.CourseCollaborator
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.CourseCollaborator); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CourseCollaborator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContentAccess provides a mock function with given fields: ctx, contentID, userID
+func (_m *CollaboratorRepository) GetContentAccess(ctx context.Context, contentID int64, userID int64) (domain.CollaboratorPermission, error) {
+	ret := _m.Called(ctx, contentID, userID)
+
+	var r0 domain.CollaboratorPermission
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.CollaboratorPermission); ok {
+		r0 = rf(ctx, contentID, userID)
+	} else {
+		r0 = ret.Get(0).(domain.CollaboratorPermission)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, contentID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseAccess provides a mock function with given fields: ctx, courseID, userID
+func (_m *CollaboratorRepository) GetCourseAccess(ctx context.Context, courseID int64, userID int64) (domain.CollaboratorPermission, error) {
+	ret := _m.Called(ctx, courseID, userID)
+
+	var r0 domain.CollaboratorPermission
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.CollaboratorPermission); ok {
+		r0 = rf(ctx, courseID, userID)
+	} else {
+		r0 = ret.Get(0).(domain.CollaboratorPermission)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, courseID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLessonAccess provides a mock function with given fields: ctx, lessonID, userID
+func (_m *CollaboratorRepository) GetLessonAccess(ctx context.Context, lessonID int64, userID int64) (domain.CollaboratorPermission, error) {
+	ret := _m.Called(ctx, lessonID, userID)
+
+	var r0 domain.CollaboratorPermission
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.CollaboratorPermission); ok {
+		r0 = rf(ctx, lessonID, userID)
+	} else {
+		r0 = ret.Get(0).(domain.CollaboratorPermission)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, lessonID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveCollaborator provides a mock function with given fields: ctx, courseID, userID
+func (_m *CollaboratorRepository) RemoveCollaborator(ctx context.Context, courseID int64, userID int64) error {
+	ret := _m.Called(ctx, courseID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, courseID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCollaborator provides a mock function with given fields: ctx, collaborator
+func (_m *CollaboratorRepository) UpdateCollaborator(ctx context.Context, collaborator *domain.CourseCollaborator) error {
+	ret := _m.Called(ctx, collaborator)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CourseCollaborator) error); ok {
+		r0 = rf(ctx, collaborator)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
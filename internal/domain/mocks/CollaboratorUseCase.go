@@ -0,0 +1,122 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CollaboratorUseCase is an autogenerated mock type for the CollaboratorUseCase type
+type CollaboratorUseCase struct {
+	mock.Mock
+}
+
+// AddCollaborator provides a mock function with given fields: ctx, collaborator
+func (_m *CollaboratorUseCase) AddCollaborator(ctx context.Context, collaborator *domain.CourseCollaborator) error {
+	ret := _m.Called(ctx, collaborator)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CourseCollaborator) error); ok {
+		r0 = rf(ctx, collaborator)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthorizeContent provides a mock function with given fields: ctx, contentID, level
+func (_m *CollaboratorUseCase) AuthorizeContent(ctx context.Context, contentID int64, level domain.CollaboratorPermission) error {
+	ret := _m.Called(ctx, contentID, level)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.CollaboratorPermission) error); ok {
+		r0 = rf(ctx, contentID, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthorizeCourse provides a mock function with given fields: ctx, courseID, level
+func (_m *CollaboratorUseCase) AuthorizeCourse(ctx context.Context, courseID int64, level domain.CollaboratorPermission) error {
+	ret := _m.Called(ctx, courseID, level)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.CollaboratorPermission) error); ok {
+		r0 = rf(ctx, courseID, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthorizeLesson provides a mock function with given fields: ctx, lessonID, level
+func (_m *CollaboratorUseCase) AuthorizeLesson(ctx context.Context, lessonID int64, level domain.CollaboratorPermission) error {
+	ret := _m.Called(ctx, lessonID, level)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.CollaboratorPermission) error); ok {
+		r0 = rf(ctx, lessonID, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByCourse provides a mock function with given fields: ctx, courseID
+func (_m *CollaboratorUseCase) GetByCourse(ctx context.Context, courseID int64) ([]domain.CourseCollaborator, error) {
+	ret := _m.Called(ctx, courseID)
+
+	var r0 []domain.CourseCollaborator
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.CourseCollaborator); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CourseCollaborator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveCollaborator provides a mock function with given fields: ctx, courseID, userID
+func (_m *CollaboratorUseCase) RemoveCollaborator(ctx context.Context, courseID int64, userID int64) error {
+	ret := _m.Called(ctx, courseID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, courseID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCollaborator provides a mock function with given fields: ctx, courseID, userID, permission
+func (_m *CollaboratorUseCase) UpdateCollaborator(ctx context.Context, courseID int64, userID int64, permission domain.CollaboratorPermission) error {
+	ret := _m.Called(ctx, courseID, userID, permission)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, domain.CollaboratorPermission) error); ok {
+		r0 = rf(ctx, courseID, userID, permission)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
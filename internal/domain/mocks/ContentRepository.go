@@ -14,6 +14,29 @@ type ContentRepository struct {
 	mock.Mock
 }
 
+// BulkDelete provides a mock function with given fields: ctx, ids, deletedAt
+func (_m *ContentRepository) BulkDelete(ctx context.Context, ids []int64, deletedAt int64) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, ids, deletedAt)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, int64) []domain.BulkResult); ok {
+		r0 = rf(ctx, ids, deletedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64, int64) error); ok {
+		r1 = rf(ctx, ids, deletedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateContent provides a mock function with given fields: ctx, Content
 func (_m *ContentRepository) CreateContent(ctx context.Context, Content *domain.Content) error {
 	ret := _m.Called(ctx, Content)
@@ -28,13 +51,13 @@ func (_m *ContentRepository) CreateContent(ctx context.Context, Content *domain.
 	return r0
 }
 
-// DeleteContent provides a mock function with given fields: ctx, id
-func (_m *ContentRepository) DeleteContent(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// DeleteContent provides a mock function with given fields: ctx, id, deletedAt
+func (_m *ContentRepository) DeleteContent(ctx context.Context, id int64, deletedAt int64) error {
+	ret := _m.Called(ctx, id, deletedAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, deletedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -132,13 +155,117 @@ func (_m *ContentRepository) GetContentCountByLesson(ctx context.Context, lesson
 	return r0, r1
 }
 
-// UpdateContent provides a mock function with given fields: ctx, Content
-func (_m *ContentRepository) UpdateContent(ctx context.Context, Content *domain.Content) error {
-	ret := _m.Called(ctx, Content)
+// GetRevision provides a mock function with given fields: ctx, contentID, revision
+func (_m *ContentRepository) GetRevision(ctx context.Context, contentID int64, revision int) (*domain.ContentRevision, error) {
+	ret := _m.Called(ctx, contentID, revision)
+
+	var r0 *domain.ContentRevision
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) *domain.ContentRevision); ok {
+		r0 = rf(ctx, contentID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ContentRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, contentID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: ctx, contentID
+func (_m *ContentRepository) GetRevisions(ctx context.Context, contentID int64) ([]domain.ContentRevision, error) {
+	ret := _m.Called(ctx, contentID)
+
+	var r0 []domain.ContentRevision
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.ContentRevision); ok {
+		r0 = rf(ctx, contentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ContentRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, contentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrash provides a mock function with given fields: ctx, start, limit
+func (_m *ContentRepository) GetTrash(ctx context.Context, start int, limit int) ([]domain.Content, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.Content
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Content); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Content)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, deletedBefore
+func (_m *ContentRepository) PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreContent provides a mock function with given fields: ctx, id, updatedAt
+func (_m *ContentRepository) RestoreContent(ctx context.Context, id int64, updatedAt int64) error {
+	ret := _m.Called(ctx, id, updatedAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.Content) error); ok {
-		r0 = rf(ctx, Content)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateContent provides a mock function with given fields: ctx, Content, revision
+func (_m *ContentRepository) UpdateContent(ctx context.Context, Content *domain.Content, revision *domain.ContentRevision) error {
+	ret := _m.Called(ctx, Content, revision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Content, *domain.ContentRevision) error); ok {
+		r0 = rf(ctx, Content, revision)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -14,6 +14,29 @@ type ContentUseCase struct {
 	mock.Mock
 }
 
+// BulkAction provides a mock function with given fields: ctx, action
+func (_m *ContentUseCase) BulkAction(ctx context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, action)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BulkAction) []domain.BulkResult); ok {
+		r0 = rf(ctx, action)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.BulkAction) error); ok {
+		r1 = rf(ctx, action)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateContent provides a mock function with given fields: ctx, Content
 func (_m *ContentUseCase) CreateContent(ctx context.Context, Content *domain.Content) (*domain.Content, error) {
 	ret := _m.Called(ctx, Content)
@@ -141,6 +164,142 @@ func (_m *ContentUseCase) GetContentByLesson(ctx context.Context, lessonID int64
 	return r0, r1
 }
 
+// GetRevision provides a mock function with given fields: ctx, contentID, revision
+func (_m *ContentUseCase) GetRevision(ctx context.Context, contentID int64, revision int) (*domain.ContentRevision, error) {
+	ret := _m.Called(ctx, contentID, revision)
+
+	var r0 *domain.ContentRevision
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) *domain.ContentRevision); ok {
+		r0 = rf(ctx, contentID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ContentRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, contentID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: ctx, contentID
+func (_m *ContentUseCase) GetRevisions(ctx context.Context, contentID int64) ([]domain.ContentRevision, error) {
+	ret := _m.Called(ctx, contentID)
+
+	var r0 []domain.ContentRevision
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.ContentRevision); ok {
+		r0 = rf(ctx, contentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ContentRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, contentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrash provides a mock function with given fields: ctx, start, limit
+func (_m *ContentUseCase) GetTrash(ctx context.Context, start int, limit int) ([]domain.Content, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.Content
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Content); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Content)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, deletedBefore
+func (_m *ContentUseCase) PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreContent provides a mock function with given fields: ctx, id
+func (_m *ContentUseCase) RestoreContent(ctx context.Context, id int64) (*domain.Content, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Content
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Content); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Content)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRevision provides a mock function with given fields: ctx, contentID, revision
+func (_m *ContentUseCase) RestoreRevision(ctx context.Context, contentID int64, revision int) (*domain.Content, error) {
+	ret := _m.Called(ctx, contentID, revision)
+
+	var r0 *domain.Content
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) *domain.Content); ok {
+		r0 = rf(ctx, contentID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Content)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, contentID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateContent provides a mock function with given fields: ctx, Content, id
 func (_m *ContentUseCase) UpdateContent(ctx context.Context, Content *domain.Content, id int64) (*domain.Content, error) {
 	ret := _m.Called(ctx, Content, id)
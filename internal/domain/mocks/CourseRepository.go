@@ -14,6 +14,66 @@ type CourseRepository struct {
 	mock.Mock
 }
 
+// BulkAction provides a mock function with given fields: ctx, action, updatedAt
+func (_m *CourseRepository) BulkAction(ctx context.Context, action *domain.BulkAction, updatedAt int64) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, action, updatedAt)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BulkAction, int64) []domain.BulkResult); ok {
+		r0 = rf(ctx, action, updatedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.BulkAction, int64) error); ok {
+		r1 = rf(ctx, action, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkPublish provides a mock function with given fields: ctx, versions
+func (_m *CourseRepository) BulkPublish(ctx context.Context, versions []*domain.CourseVersion) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, versions)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.CourseVersion) []domain.BulkResult); ok {
+		r0 = rf(ctx, versions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.CourseVersion) error); ok {
+		r1 = rf(ctx, versions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloneCourse provides a mock function with given fields: ctx, id, course
+func (_m *CourseRepository) CloneCourse(ctx context.Context, id int64, course *domain.Course) error {
+	ret := _m.Called(ctx, id, course)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.Course) error); ok {
+		r0 = rf(ctx, id, course)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateCourse provides a mock function with given fields: ctx, course
 func (_m *CourseRepository) CreateCourse(ctx context.Context, course *domain.Course) error {
 	ret := _m.Called(ctx, course)
@@ -28,13 +88,13 @@ func (_m *CourseRepository) CreateCourse(ctx context.Context, course *domain.Cou
 	return r0
 }
 
-// DeleteCourse provides a mock function with given fields: ctx, id
-func (_m *CourseRepository) DeleteCourse(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// DeleteCourse provides a mock function with given fields: ctx, id, deletedAt
+func (_m *CourseRepository) DeleteCourse(ctx context.Context, id int64, deletedAt int64) error {
+	ret := _m.Called(ctx, id, deletedAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, deletedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -132,6 +192,170 @@ func (_m *CourseRepository) GetCourseCount(ctx context.Context) (int64, error) {
 	return r0, r1
 }
 
+// GetLatestVersion provides a mock function with given fields: ctx, courseID
+func (_m *CourseRepository) GetLatestVersion(ctx context.Context, courseID int64) (*domain.CourseVersion, error) {
+	ret := _m.Called(ctx, courseID)
+
+	var r0 *domain.CourseVersion
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.CourseVersion); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CourseVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrash provides a mock function with given fields: ctx, start, limit
+func (_m *CourseRepository) GetTrash(ctx context.Context, start int, limit int) ([]domain.Course, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.Course
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Course); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Course)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrashedByID provides a mock function with given fields: ctx, id
+func (_m *CourseRepository) GetTrashedByID(ctx context.Context, id int64) (*domain.Course, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Course
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Course); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Course)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVersion provides a mock function with given fields: ctx, courseID, version
+func (_m *CourseRepository) GetVersion(ctx context.Context, courseID int64, version int) (*domain.CourseVersion, error) {
+	ret := _m.Called(ctx, courseID, version)
+
+	var r0 *domain.CourseVersion
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) *domain.CourseVersion); ok {
+		r0 = rf(ctx, courseID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CourseVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, courseID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVersions provides a mock function with given fields: ctx, courseID
+func (_m *CourseRepository) GetVersions(ctx context.Context, courseID int64) ([]domain.CourseVersion, error) {
+	ret := _m.Called(ctx, courseID)
+
+	var r0 []domain.CourseVersion
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.CourseVersion); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CourseVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishCourse provides a mock function with given fields: ctx, version
+func (_m *CourseRepository) PublishCourse(ctx context.Context, version *domain.CourseVersion) error {
+	ret := _m.Called(ctx, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CourseVersion) error); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, deletedBefore
+func (_m *CourseRepository) PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreCourse provides a mock function with given fields: ctx, id, updatedAt
+func (_m *CourseRepository) RestoreCourse(ctx context.Context, id int64, updatedAt int64) error {
+	ret := _m.Called(ctx, id, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateCourse provides a mock function with given fields: ctx, course
 func (_m *CourseRepository) UpdateCourse(ctx context.Context, course *domain.Course) error {
 	ret := _m.Called(ctx, course)
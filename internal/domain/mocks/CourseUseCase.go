@@ -14,6 +14,52 @@ type CourseUseCase struct {
 	mock.Mock
 }
 
+// BulkAction provides a mock function with given fields: ctx, action
+func (_m *CourseUseCase) BulkAction(ctx context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, action)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BulkAction) []domain.BulkResult); ok {
+		r0 = rf(ctx, action)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.BulkAction) error); ok {
+		r1 = rf(ctx, action)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloneCourse provides a mock function with given fields: ctx, id, title
+func (_m *CourseUseCase) CloneCourse(ctx context.Context, id int64, title string) (*domain.Course, error) {
+	ret := _m.Called(ctx, id, title)
+
+	var r0 *domain.Course
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.Course); ok {
+		r0 = rf(ctx, id, title)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Course)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, id, title)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateCourse provides a mock function with given fields: ctx, course
 func (_m *CourseUseCase) CreateCourse(ctx context.Context, course *domain.Course) error {
 	ret := _m.Called(ctx, course)
@@ -111,6 +157,165 @@ func (_m *CourseUseCase) GetByTitle(ctx context.Context, title string) (*domain.
 	return r0, r1
 }
 
+// GetPublishedVersion provides a mock function with given fields: ctx, courseID
+func (_m *CourseUseCase) GetPublishedVersion(ctx context.Context, courseID int64) (*domain.CourseVersion, error) {
+	ret := _m.Called(ctx, courseID)
+
+	var r0 *domain.CourseVersion
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.CourseVersion); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CourseVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrash provides a mock function with given fields: ctx, start, limit
+func (_m *CourseUseCase) GetTrash(ctx context.Context, start int, limit int) ([]domain.Course, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.Course
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Course); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Course)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVersion provides a mock function with given fields: ctx, courseID, version
+func (_m *CourseUseCase) GetVersion(ctx context.Context, courseID int64, version int) (*domain.CourseVersion, error) {
+	ret := _m.Called(ctx, courseID, version)
+
+	var r0 *domain.CourseVersion
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) *domain.CourseVersion); ok {
+		r0 = rf(ctx, courseID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CourseVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, courseID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVersions provides a mock function with given fields: ctx, courseID
+func (_m *CourseUseCase) GetVersions(ctx context.Context, courseID int64) ([]domain.CourseVersion, error) {
+	ret := _m.Called(ctx, courseID)
+
+	var r0 []domain.CourseVersion
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.CourseVersion); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CourseVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishCourse provides a mock function with given fields: ctx, id, changeNote
+func (_m *CourseUseCase) PublishCourse(ctx context.Context, id int64, changeNote string) (*domain.CourseVersion, error) {
+	ret := _m.Called(ctx, id, changeNote)
+
+	var r0 *domain.CourseVersion
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.CourseVersion); ok {
+		r0 = rf(ctx, id, changeNote)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CourseVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, id, changeNote)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, deletedBefore
+func (_m *CourseUseCase) PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreCourse provides a mock function with given fields: ctx, id
+func (_m *CourseUseCase) RestoreCourse(ctx context.Context, id int64) (*domain.Course, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Course
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Course); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Course)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateCourse provides a mock function with given fields: ctx, course, id
 func (_m *CourseUseCase) UpdateCourse(ctx context.Context, course *domain.Course, id int64) error {
 	ret := _m.Called(ctx, course, id)
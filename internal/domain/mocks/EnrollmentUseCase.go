@@ -0,0 +1,75 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EnrollmentUseCase is an autogenerated mock type for the EnrollmentUseCase type
+type EnrollmentUseCase struct {
+	mock.Mock
+}
+
+// EnrollUser provides a mock function with given fields: ctx, enrollment
+func (_m *EnrollmentUseCase) EnrollUser(ctx context.Context, enrollment *domain.Enrollment) error {
+	ret := _m.Called(ctx, enrollment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Enrollment) error); ok {
+		r0 = rf(ctx, enrollment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByCourse provides a mock function with given fields: ctx, courseID, start, limit
+func (_m *EnrollmentUseCase) GetByCourse(ctx context.Context, courseID int64, start int, limit int) ([]domain.Enrollment, error) {
+	ret := _m.Called(ctx, courseID, start, limit)
+
+	var r0 []domain.Enrollment
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.Enrollment); ok {
+		r0 = rf(ctx, courseID, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Enrollment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(ctx, courseID, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnrollment provides a mock function with given fields: ctx, courseID, userID
+func (_m *EnrollmentUseCase) GetEnrollment(ctx context.Context, courseID int64, userID int64) (*domain.Enrollment, error) {
+	ret := _m.Called(ctx, courseID, userID)
+
+	var r0 *domain.Enrollment
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.Enrollment); ok {
+		r0 = rf(ctx, courseID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Enrollment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, courseID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,163 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// InvitationRepository is an autogenerated mock type for the InvitationRepository type
+type InvitationRepository struct {
+	mock.Mock
+}
+
+// AcceptInvitation provides a mock function with given fields: ctx, invitation, user
+func (_m *InvitationRepository) AcceptInvitation(ctx context.Context, invitation *domain.Invitation, user *domain.User) error {
+	ret := _m.Called(ctx, invitation, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Invitation, *domain.User) error); ok {
+		r0 = rf(ctx, invitation, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateInvitation provides a mock function with given fields: ctx, invitation
+func (_m *InvitationRepository) CreateInvitation(ctx context.Context, invitation *domain.Invitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Invitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, status, start, limit
+func (_m *InvitationRepository) GetAll(ctx context.Context, status int, start int, limit int) ([]domain.Invitation, error) {
+	ret := _m.Called(ctx, status, start, limit)
+
+	var r0 []domain.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []domain.Invitation); ok {
+		r0 = rf(ctx, status, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, status, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *InvitationRepository) GetByID(ctx context.Context, id int64) (*domain.Invitation, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Invitation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *InvitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 *domain.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Invitation); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingByEmail provides a mock function with given fields: ctx, email
+func (_m *InvitationRepository) GetPendingByEmail(ctx context.Context, email string) (*domain.Invitation, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 *domain.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Invitation); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenewToken provides a mock function with given fields: ctx, id, tokenHash, expiresAt, updatedAt
+func (_m *InvitationRepository) RenewToken(ctx context.Context, id int64, tokenHash string, expiresAt int64, updatedAt int64) error {
+	ret := _m.Called(ctx, id, tokenHash, expiresAt, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64, int64) error); ok {
+		r0 = rf(ctx, id, tokenHash, expiresAt, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeInvitation provides a mock function with given fields: ctx, id, updatedAt
+func (_m *InvitationRepository) RevokeInvitation(ctx context.Context, id int64, updatedAt int64) error {
+	ret := _m.Called(ctx, id, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,126 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// InvitationUseCase is an autogenerated mock type for the InvitationUseCase type
+type InvitationUseCase struct {
+	mock.Mock
+}
+
+// AcceptInvitation provides a mock function with given fields: ctx, acceptance
+func (_m *InvitationUseCase) AcceptInvitation(ctx context.Context, acceptance *domain.InvitationAcceptance) (*domain.TokenPair, error) {
+	ret := _m.Called(ctx, acceptance)
+
+	var r0 *domain.TokenPair
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.InvitationAcceptance) *domain.TokenPair); ok {
+		r0 = rf(ctx, acceptance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TokenPair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.InvitationAcceptance) error); ok {
+		r1 = rf(ctx, acceptance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInvitation provides a mock function with given fields: ctx, invitation
+func (_m *InvitationUseCase) CreateInvitation(ctx context.Context, invitation *domain.Invitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Invitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, status, start, limit
+func (_m *InvitationUseCase) GetAll(ctx context.Context, status int, start int, limit int) ([]domain.Invitation, error) {
+	ret := _m.Called(ctx, status, start, limit)
+
+	var r0 []domain.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []domain.Invitation); ok {
+		r0 = rf(ctx, status, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, status, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *InvitationUseCase) GetByID(ctx context.Context, id int64) (*domain.Invitation, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Invitation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResendInvitation provides a mock function with given fields: ctx, id
+func (_m *InvitationUseCase) ResendInvitation(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeInvitation provides a mock function with given fields: ctx, id
+func (_m *InvitationUseCase) RevokeInvitation(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,61 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LDAPClient is an autogenerated mock type for the LDAPClient type
+type LDAPClient struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, directory, username, password
+func (_m *LDAPClient) Authenticate(ctx context.Context, directory *domain.LDAPDirectory, username string, password string) (*domain.LDAPEntry, error) {
+	ret := _m.Called(ctx, directory, username, password)
+
+	var r0 *domain.LDAPEntry
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LDAPDirectory, string, string) *domain.LDAPEntry); ok {
+		r0 = rf(ctx, directory, username, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LDAPEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.LDAPDirectory, string, string) error); ok {
+		r1 = rf(ctx, directory, username, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Users provides a mock function with given fields: ctx, directory
+func (_m *LDAPClient) Users(ctx context.Context, directory *domain.LDAPDirectory) ([]domain.LDAPEntry, error) {
+	ret := _m.Called(ctx, directory)
+
+	var r0 []domain.LDAPEntry
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LDAPDirectory) []domain.LDAPEntry); ok {
+		r0 = rf(ctx, directory)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LDAPEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.LDAPDirectory) error); ok {
+		r1 = rf(ctx, directory)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
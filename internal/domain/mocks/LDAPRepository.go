@@ -0,0 +1,173 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LDAPRepository is an autogenerated mock type for the LDAPRepository type
+type LDAPRepository struct {
+	mock.Mock
+}
+
+// DeleteDirectory provides a mock function with given fields: ctx
+func (_m *LDAPRepository) DeleteDirectory(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDirectory provides a mock function with given fields: ctx
+func (_m *LDAPRepository) GetDirectory(ctx context.Context) (*domain.LDAPDirectory, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.LDAPDirectory
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.LDAPDirectory); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LDAPDirectory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnabledDirectories provides a mock function with given fields: ctx
+func (_m *LDAPRepository) GetEnabledDirectories(ctx context.Context) ([]domain.LDAPDirectory, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.LDAPDirectory
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.LDAPDirectory); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LDAPDirectory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLinkedUser provides a mock function with given fields: ctx, dn
+func (_m *LDAPRepository) GetLinkedUser(ctx context.Context, dn string) (int64, error) {
+	ret := _m.Called(ctx, dn)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, dn)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, dn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LinkUser provides a mock function with given fields: ctx, userID, dn, now
+func (_m *LDAPRepository) LinkUser(ctx context.Context, userID int64, dn string, now int64) error {
+	ret := _m.Called(ctx, userID, dn, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) error); ok {
+		r0 = rf(ctx, userID, dn, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveDirectory provides a mock function with given fields: ctx, directory
+func (_m *LDAPRepository) SaveDirectory(ctx context.Context, directory *domain.LDAPDirectory) error {
+	ret := _m.Called(ctx, directory)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LDAPDirectory) error); ok {
+		r0 = rf(ctx, directory)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveGroupTeam provides a mock function with given fields: ctx, groupDN, name, roleID, now
+func (_m *LDAPRepository) SaveGroupTeam(ctx context.Context, groupDN string, name string, roleID int64, now int64) (int64, error) {
+	ret := _m.Called(ctx, groupDN, name, roleID, now)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, int64) int64); ok {
+		r0 = rf(ctx, groupDN, name, roleID, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, int64) error); ok {
+		r1 = rf(ctx, groupDN, name, roleID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetGroupTeams provides a mock function with given fields: ctx, userID, teamIDs, now
+func (_m *LDAPRepository) SetGroupTeams(ctx context.Context, userID int64, teamIDs []int64, now int64) error {
+	ret := _m.Called(ctx, userID, teamIDs, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64, int64) error); ok {
+		r0 = rf(ctx, userID, teamIDs, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLastSync provides a mock function with given fields: ctx, syncedAt
+func (_m *LDAPRepository) UpdateLastSync(ctx context.Context, syncedAt int64) error {
+	ret := _m.Called(ctx, syncedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, syncedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
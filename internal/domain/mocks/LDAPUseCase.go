@@ -0,0 +1,126 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LDAPUseCase is an autogenerated mock type for the LDAPUseCase type
+type LDAPUseCase struct {
+	mock.Mock
+}
+
+// DeleteDirectory provides a mock function with given fields: ctx
+func (_m *LDAPUseCase) DeleteDirectory(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDirectory provides a mock function with given fields: ctx
+func (_m *LDAPUseCase) GetDirectory(ctx context.Context) (*domain.LDAPDirectory, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.LDAPDirectory
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.LDAPDirectory); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LDAPDirectory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, login
+func (_m *LDAPUseCase) Login(ctx context.Context, login *domain.LDAPLogin) (*domain.TokenPair, error) {
+	ret := _m.Called(ctx, login)
+
+	var r0 *domain.TokenPair
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LDAPLogin) *domain.TokenPair); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TokenPair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.LDAPLogin) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveDirectory provides a mock function with given fields: ctx, directory
+func (_m *LDAPUseCase) SaveDirectory(ctx context.Context, directory *domain.LDAPDirectory) error {
+	ret := _m.Called(ctx, directory)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LDAPDirectory) error); ok {
+		r0 = rf(ctx, directory)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Sync provides a mock function with given fields: ctx
+func (_m *LDAPUseCase) Sync(ctx context.Context) (*domain.LDAPSyncResult, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.LDAPSyncResult
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.LDAPSyncResult); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LDAPSyncResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncAll provides a mock function with given fields: ctx
+func (_m *LDAPUseCase) SyncAll(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
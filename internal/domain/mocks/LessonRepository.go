@@ -14,6 +14,29 @@ type LessonRepository struct {
 	mock.Mock
 }
 
+// BulkAction provides a mock function with given fields: ctx, action, updatedAt
+func (_m *LessonRepository) BulkAction(ctx context.Context, action *domain.BulkAction, updatedAt int64) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, action, updatedAt)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BulkAction, int64) []domain.BulkResult); ok {
+		r0 = rf(ctx, action, updatedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.BulkAction, int64) error); ok {
+		r1 = rf(ctx, action, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateLesson provides a mock function with given fields: ctx, Lesson
 func (_m *LessonRepository) CreateLesson(ctx context.Context, Lesson *domain.Lesson) error {
 	ret := _m.Called(ctx, Lesson)
@@ -28,13 +51,13 @@ func (_m *LessonRepository) CreateLesson(ctx context.Context, Lesson *domain.Les
 	return r0
 }
 
-// DeleteLesson provides a mock function with given fields: ctx, id
-func (_m *LessonRepository) DeleteLesson(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// DeleteLesson provides a mock function with given fields: ctx, id, deletedAt
+func (_m *LessonRepository) DeleteLesson(ctx context.Context, id int64, deletedAt int64) error {
+	ret := _m.Called(ctx, id, deletedAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, deletedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -132,13 +155,117 @@ func (_m *LessonRepository) GetLessonCountByCourse(ctx context.Context, courseID
 	return r0, r1
 }
 
-// UpdateLesson provides a mock function with given fields: ctx, Lesson
-func (_m *LessonRepository) UpdateLesson(ctx context.Context, Lesson *domain.Lesson) error {
-	ret := _m.Called(ctx, Lesson)
+// GetRevision provides a mock function with given fields: ctx, lessonID, revision
+func (_m *LessonRepository) GetRevision(ctx context.Context, lessonID int64, revision int) (*domain.LessonRevision, error) {
+	ret := _m.Called(ctx, lessonID, revision)
+
+	var r0 *domain.LessonRevision
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) *domain.LessonRevision); ok {
+		r0 = rf(ctx, lessonID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LessonRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, lessonID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: ctx, lessonID
+func (_m *LessonRepository) GetRevisions(ctx context.Context, lessonID int64) ([]domain.LessonRevision, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	var r0 []domain.LessonRevision
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.LessonRevision); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LessonRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrash provides a mock function with given fields: ctx, start, limit
+func (_m *LessonRepository) GetTrash(ctx context.Context, start int, limit int) ([]domain.Lesson, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.Lesson
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Lesson); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Lesson)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, deletedBefore
+func (_m *LessonRepository) PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreLesson provides a mock function with given fields: ctx, id, updatedAt
+func (_m *LessonRepository) RestoreLesson(ctx context.Context, id int64, updatedAt int64) error {
+	ret := _m.Called(ctx, id, updatedAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.Lesson) error); ok {
-		r0 = rf(ctx, Lesson)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLesson provides a mock function with given fields: ctx, Lesson, revision
+func (_m *LessonRepository) UpdateLesson(ctx context.Context, Lesson *domain.Lesson, revision *domain.LessonRevision) error {
+	ret := _m.Called(ctx, Lesson, revision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Lesson, *domain.LessonRevision) error); ok {
+		r0 = rf(ctx, Lesson, revision)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -14,6 +14,29 @@ type LessonUseCase struct {
 	mock.Mock
 }
 
+// BulkAction provides a mock function with given fields: ctx, action
+func (_m *LessonUseCase) BulkAction(ctx context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, action)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BulkAction) []domain.BulkResult); ok {
+		r0 = rf(ctx, action)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.BulkAction) error); ok {
+		r1 = rf(ctx, action)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateLesson provides a mock function with given fields: ctx, Lesson
 func (_m *LessonUseCase) CreateLesson(ctx context.Context, Lesson *domain.Lesson) error {
 	ret := _m.Called(ctx, Lesson)
@@ -132,6 +155,142 @@ func (_m *LessonUseCase) GetLessonCountByCourse(ctx context.Context, courseID in
 	return r0, r1
 }
 
+// GetRevision provides a mock function with given fields: ctx, lessonID, revision
+func (_m *LessonUseCase) GetRevision(ctx context.Context, lessonID int64, revision int) (*domain.LessonRevision, error) {
+	ret := _m.Called(ctx, lessonID, revision)
+
+	var r0 *domain.LessonRevision
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) *domain.LessonRevision); ok {
+		r0 = rf(ctx, lessonID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LessonRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, lessonID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: ctx, lessonID
+func (_m *LessonUseCase) GetRevisions(ctx context.Context, lessonID int64) ([]domain.LessonRevision, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	var r0 []domain.LessonRevision
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.LessonRevision); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LessonRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrash provides a mock function with given fields: ctx, start, limit
+func (_m *LessonUseCase) GetTrash(ctx context.Context, start int, limit int) ([]domain.Lesson, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.Lesson
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Lesson); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Lesson)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, deletedBefore
+func (_m *LessonUseCase) PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreLesson provides a mock function with given fields: ctx, id
+func (_m *LessonUseCase) RestoreLesson(ctx context.Context, id int64) (*domain.Lesson, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Lesson
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Lesson); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Lesson)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRevision provides a mock function with given fields: ctx, lessonID, revision
+func (_m *LessonUseCase) RestoreRevision(ctx context.Context, lessonID int64, revision int) (*domain.Lesson, error) {
+	ret := _m.Called(ctx, lessonID, revision)
+
+	var r0 *domain.Lesson
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) *domain.Lesson); ok {
+		r0 = rf(ctx, lessonID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Lesson)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, lessonID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateLesson provides a mock function with given fields: ctx, Lesson, id
 func (_m *LessonUseCase) UpdateLesson(ctx context.Context, Lesson *domain.Lesson, id int64) error {
 	ret := _m.Called(ctx, Lesson, id)
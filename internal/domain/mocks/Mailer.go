@@ -0,0 +1,29 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Mailer is an autogenerated mock type for the Mailer type
+type Mailer struct {
+	mock.Mock
+}
+
+// Send provides a mock function with given fields: ctx, mail
+func (_m *Mailer) Send(ctx context.Context, mail *domain.Mail) error {
+	ret := _m.Called(ctx, mail)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Mail) error); ok {
+		r0 = rf(ctx, mail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
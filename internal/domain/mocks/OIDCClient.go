@@ -0,0 +1,59 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCClient is an autogenerated mock type for the OIDCClient type
+type OIDCClient struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, provider, state, nonce, codeChallenge
+func (_m *OIDCClient) AuthCodeURL(ctx context.Context, provider *domain.OIDCProvider, state string, nonce string, codeChallenge string) (string, error) {
+	ret := _m.Called(ctx, provider, state, nonce, codeChallenge)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.OIDCProvider, string, string, string) string); ok {
+		r0 = rf(ctx, provider, state, nonce, codeChallenge)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.OIDCProvider, string, string, string) error); ok {
+		r1 = rf(ctx, provider, state, nonce, codeChallenge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Exchange provides a mock function with given fields: ctx, provider, code, codeVerifier, nonce
+func (_m *OIDCClient) Exchange(ctx context.Context, provider *domain.OIDCProvider, code string, codeVerifier string, nonce string) (*domain.OIDCClaims, error) {
+	ret := _m.Called(ctx, provider, code, codeVerifier, nonce)
+
+	var r0 *domain.OIDCClaims
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.OIDCProvider, string, string, string) *domain.OIDCClaims); ok {
+		r0 = rf(ctx, provider, code, codeVerifier, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCClaims)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.OIDCProvider, string, string, string) error); ok {
+		r1 = rf(ctx, provider, code, codeVerifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,140 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCRepository is an autogenerated mock type for the OIDCRepository type
+type OIDCRepository struct {
+	mock.Mock
+}
+
+// ConsumeLoginState provides a mock function with given fields: ctx, stateHash
+func (_m *OIDCRepository) ConsumeLoginState(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error) {
+	ret := _m.Called(ctx, stateHash)
+
+	var r0 *domain.OIDCLoginState
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.OIDCLoginState); ok {
+		r0 = rf(ctx, stateHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCLoginState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stateHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateIdentity provides a mock function with given fields: ctx, identity
+func (_m *OIDCRepository) CreateIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	ret := _m.Called(ctx, identity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserIdentity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateLoginState provides a mock function with given fields: ctx, state
+func (_m *OIDCRepository) CreateLoginState(ctx context.Context, state *domain.OIDCLoginState) error {
+	ret := _m.Called(ctx, state)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.OIDCLoginState) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteProvider provides a mock function with given fields: ctx
+func (_m *OIDCRepository) DeleteProvider(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetIdentity provides a mock function with given fields: ctx, providerID, subject
+func (_m *OIDCRepository) GetIdentity(ctx context.Context, providerID int64, subject string) (*domain.UserIdentity, error) {
+	ret := _m.Called(ctx, providerID, subject)
+
+	var r0 *domain.UserIdentity
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.UserIdentity); ok {
+		r0 = rf(ctx, providerID, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserIdentity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, providerID, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProvider provides a mock function with given fields: ctx
+func (_m *OIDCRepository) GetProvider(ctx context.Context) (*domain.OIDCProvider, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.OIDCProvider
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.OIDCProvider); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCProvider)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveProvider provides a mock function with given fields: ctx, provider
+func (_m *OIDCRepository) SaveProvider(ctx context.Context, provider *domain.OIDCProvider) error {
+	ret := _m.Called(ctx, provider)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.OIDCProvider) error); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,110 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCUseCase is an autogenerated mock type for the OIDCUseCase type
+type OIDCUseCase struct {
+	mock.Mock
+}
+
+// Callback provides a mock function with given fields: ctx, state, code
+func (_m *OIDCUseCase) Callback(ctx context.Context, state string, code string) (*domain.TokenPair, error) {
+	ret := _m.Called(ctx, state, code)
+
+	var r0 *domain.TokenPair
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.TokenPair); ok {
+		r0 = rf(ctx, state, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TokenPair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, state, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteProvider provides a mock function with given fields: ctx
+func (_m *OIDCUseCase) DeleteProvider(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetProvider provides a mock function with given fields: ctx
+func (_m *OIDCUseCase) GetProvider(ctx context.Context) (*domain.OIDCProvider, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.OIDCProvider
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.OIDCProvider); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCProvider)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginURL provides a mock function with given fields: ctx, organizationID
+func (_m *OIDCUseCase) LoginURL(ctx context.Context, organizationID int64) (string, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, int64) string); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveProvider provides a mock function with given fields: ctx, provider
+func (_m *OIDCUseCase) SaveProvider(ctx context.Context, provider *domain.OIDCProvider) error {
+	ret := _m.Called(ctx, provider)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.OIDCProvider) error); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,117 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationRepository is an autogenerated mock type for the OrganizationRepository type
+type OrganizationRepository struct {
+	mock.Mock
+}
+
+// CreateOrganization provides a mock function with given fields: ctx, organization
+func (_m *OrganizationRepository) CreateOrganization(ctx context.Context, organization *domain.Organization) error {
+	ret := _m.Called(ctx, organization)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Organization) error); ok {
+		r0 = rf(ctx, organization)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOrganization provides a mock function with given fields: ctx, id
+func (_m *OrganizationRepository) DeleteOrganization(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, searchQuery, start, limit
+func (_m *OrganizationRepository) GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]domain.Organization, error) {
+	ret := _m.Called(ctx, searchQuery, start, limit)
+
+	var r0 []domain.Organization
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.Organization); ok {
+		r0 = rf(ctx, searchQuery, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Organization)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, searchQuery, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *OrganizationRepository) GetByID(ctx context.Context, id int64) (*domain.Organization, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Organization
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Organization); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Organization)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTwoFactorRequired provides a mock function with given fields: ctx, id, required, updatedAt
+func (_m *OrganizationRepository) SetTwoFactorRequired(ctx context.Context, id int64, required bool, updatedAt int64) error {
+	ret := _m.Called(ctx, id, required, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool, int64) error); ok {
+		r0 = rf(ctx, id, required, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOrganization provides a mock function with given fields: ctx, organization
+func (_m *OrganizationRepository) UpdateOrganization(ctx context.Context, organization *domain.Organization) error {
+	ret := _m.Called(ctx, organization)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Organization) error); ok {
+		r0 = rf(ctx, organization)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationUseCase is an autogenerated mock type for the OrganizationUseCase type
+type OrganizationUseCase struct {
+	mock.Mock
+}
+
+// CreateOrganization provides a mock function with given fields: ctx, organization
+func (_m *OrganizationUseCase) CreateOrganization(ctx context.Context, organization *domain.Organization) error {
+	ret := _m.Called(ctx, organization)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Organization) error); ok {
+		r0 = rf(ctx, organization)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOrganization provides a mock function with given fields: ctx, id
+func (_m *OrganizationUseCase) DeleteOrganization(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, searchQuery, start, limit
+func (_m *OrganizationUseCase) GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]domain.Organization, error) {
+	ret := _m.Called(ctx, searchQuery, start, limit)
+
+	var r0 []domain.Organization
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.Organization); ok {
+		r0 = rf(ctx, searchQuery, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Organization)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, searchQuery, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *OrganizationUseCase) GetByID(ctx context.Context, id int64) (*domain.Organization, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Organization
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Organization); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Organization)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOrganization provides a mock function with given fields: ctx, organization, id
+func (_m *OrganizationUseCase) UpdateOrganization(ctx context.Context, organization *domain.Organization, id int64) error {
+	ret := _m.Called(ctx, organization, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Organization, int64) error); ok {
+		r0 = rf(ctx, organization, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
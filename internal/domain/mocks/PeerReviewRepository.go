@@ -0,0 +1,181 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PeerReviewRepository is an autogenerated mock type for the PeerReviewRepository type
+type PeerReviewRepository struct {
+	mock.Mock
+}
+
+// Allocate provides a mock function with given fields: ctx, assignmentID, allocatedAt, reviews
+func (_m *PeerReviewRepository) Allocate(ctx context.Context, assignmentID int64, allocatedAt int64, reviews []domain.PeerReview) error {
+	ret := _m.Called(ctx, assignmentID, allocatedAt, reviews)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, []domain.PeerReview) error); ok {
+		r0 = rf(ctx, assignmentID, allocatedAt, reviews)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByAssignment provides a mock function with given fields: ctx, assignmentID
+func (_m *PeerReviewRepository) GetByAssignment(ctx context.Context, assignmentID int64) ([]domain.PeerReview, error) {
+	ret := _m.Called(ctx, assignmentID)
+
+	var r0 []domain.PeerReview
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PeerReview); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PeerReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *PeerReviewRepository) GetByID(ctx context.Context, id int64) (*domain.PeerReview, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.PeerReview
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.PeerReview); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PeerReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByReviewer provides a mock function with given fields: ctx, assignmentID, reviewerID
+func (_m *PeerReviewRepository) GetByReviewer(ctx context.Context, assignmentID int64, reviewerID int64) ([]domain.PeerReview, error) {
+	ret := _m.Called(ctx, assignmentID, reviewerID)
+
+	var r0 []domain.PeerReview
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []domain.PeerReview); ok {
+		r0 = rf(ctx, assignmentID, reviewerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PeerReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, assignmentID, reviewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBySubmission provides a mock function with given fields: ctx, submissionID
+func (_m *PeerReviewRepository) GetBySubmission(ctx context.Context, submissionID int64) ([]domain.PeerReview, error) {
+	ret := _m.Called(ctx, submissionID)
+
+	var r0 []domain.PeerReview
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PeerReview); ok {
+		r0 = rf(ctx, submissionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PeerReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, submissionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestSubmissions provides a mock function with given fields: ctx, assignmentID
+func (_m *PeerReviewRepository) GetLatestSubmissions(ctx context.Context, assignmentID int64) ([]domain.Submission, error) {
+	ret := _m.Called(ctx, assignmentID)
+
+	var r0 []domain.Submission
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Submission); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Submission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnallocated provides a mock function with given fields: ctx, dueBefore
+func (_m *PeerReviewRepository) GetUnallocated(ctx context.Context, dueBefore int64) ([]domain.PeerReviewAllocation, error) {
+	ret := _m.Called(ctx, dueBefore)
+
+	var r0 []domain.PeerReviewAllocation
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PeerReviewAllocation); ok {
+		r0 = rf(ctx, dueBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PeerReviewAllocation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, dueBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitReview provides a mock function with given fields: ctx, review
+func (_m *PeerReviewRepository) SubmitReview(ctx context.Context, review *domain.PeerReview) error {
+	ret := _m.Called(ctx, review)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.PeerReview) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
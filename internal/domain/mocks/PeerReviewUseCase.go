@@ -0,0 +1,188 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PeerReviewUseCase is an autogenerated mock type for the PeerReviewUseCase type
+type PeerReviewUseCase struct {
+	mock.Mock
+}
+
+// Allocate provides a mock function with given fields: ctx, assignmentID
+func (_m *PeerReviewUseCase) Allocate(ctx context.Context, assignmentID int64) ([]domain.PeerReview, error) {
+	ret := _m.Called(ctx, assignmentID)
+
+	var r0 []domain.PeerReview
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PeerReview); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PeerReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AllocateDue provides a mock function with given fields: ctx
+func (_m *PeerReviewUseCase) AllocateDue(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DownloadFile provides a mock function with given fields: ctx, reviewID, name
+func (_m *PeerReviewUseCase) DownloadFile(ctx context.Context, reviewID int64, name string) (string, error) {
+	ret := _m.Called(ctx, reviewID, name)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) string); ok {
+		r0 = rf(ctx, reviewID, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, reviewID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAssigned provides a mock function with given fields: ctx, assignmentID
+func (_m *PeerReviewUseCase) GetAssigned(ctx context.Context, assignmentID int64) ([]domain.PeerReview, error) {
+	ret := _m.Called(ctx, assignmentID)
+
+	var r0 []domain.PeerReview
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PeerReview); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PeerReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *PeerReviewUseCase) GetByID(ctx context.Context, id int64) (*domain.PeerReview, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.PeerReview
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.PeerReview); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PeerReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGrades provides a mock function with given fields: ctx, assignmentID
+func (_m *PeerReviewUseCase) GetGrades(ctx context.Context, assignmentID int64) ([]domain.PeerGrade, error) {
+	ret := _m.Called(ctx, assignmentID)
+
+	var r0 []domain.PeerGrade
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PeerGrade); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PeerGrade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceived provides a mock function with given fields: ctx, submissionID
+func (_m *PeerReviewUseCase) GetReceived(ctx context.Context, submissionID int64) ([]domain.PeerReview, error) {
+	ret := _m.Called(ctx, submissionID)
+
+	var r0 []domain.PeerReview
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PeerReview); ok {
+		r0 = rf(ctx, submissionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PeerReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, submissionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitReview provides a mock function with given fields: ctx, id, grade
+func (_m *PeerReviewUseCase) SubmitReview(ctx context.Context, id int64, grade *domain.PeerReviewGrade) (*domain.PeerReview, error) {
+	ret := _m.Called(ctx, id, grade)
+
+	var r0 *domain.PeerReview
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.PeerReviewGrade) *domain.PeerReview); ok {
+		r0 = rf(ctx, id, grade)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PeerReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *domain.PeerReviewGrade) error); ok {
+		r1 = rf(ctx, id, grade)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
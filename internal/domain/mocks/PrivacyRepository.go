@@ -0,0 +1,190 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PrivacyRepository is an autogenerated mock type for the PrivacyRepository type
+type PrivacyRepository struct {
+	mock.Mock
+}
+
+// EraseUser provides a mock function with given fields: ctx, userID, email, erasedAt
+func (_m *PrivacyRepository) EraseUser(ctx context.Context, userID int64, email string, erasedAt int64) error {
+	ret := _m.Called(ctx, userID, email, erasedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) error); ok {
+		r0 = rf(ctx, userID, email, erasedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetEnrollments provides a mock function with given fields: ctx, userID
+func (_m *PrivacyRepository) GetEnrollments(ctx context.Context, userID int64) ([]domain.Enrollment, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.Enrollment
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Enrollment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Enrollment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdentities provides a mock function with given fields: ctx, userID
+func (_m *PrivacyRepository) GetIdentities(ctx context.Context, userID int64) ([]domain.PersonalIdentity, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.PersonalIdentity
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PersonalIdentity); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PersonalIdentity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitations provides a mock function with given fields: ctx, userID, email
+func (_m *PrivacyRepository) GetInvitations(ctx context.Context, userID int64, email string) ([]domain.Invitation, error) {
+	ret := _m.Called(ctx, userID, email)
+
+	var r0 []domain.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) []domain.Invitation); ok {
+		r0 = rf(ctx, userID, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPeerReviews provides a mock function with given fields: ctx, userID
+func (_m *PrivacyRepository) GetPeerReviews(ctx context.Context, userID int64) ([]domain.PeerReview, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.PeerReview
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PeerReview); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PeerReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuizAttempts provides a mock function with given fields: ctx, userID
+func (_m *PrivacyRepository) GetQuizAttempts(ctx context.Context, userID int64) ([]domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.QuizAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.QuizAttempt); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.QuizAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessions provides a mock function with given fields: ctx, userID
+func (_m *PrivacyRepository) GetSessions(ctx context.Context, userID int64) ([]domain.PersonalSession, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.PersonalSession
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PersonalSession); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PersonalSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubmissions provides a mock function with given fields: ctx, userID
+func (_m *PrivacyRepository) GetSubmissions(ctx context.Context, userID int64) ([]domain.Submission, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.Submission
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Submission); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Submission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
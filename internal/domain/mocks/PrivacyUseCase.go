@@ -0,0 +1,52 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PrivacyUseCase is an autogenerated mock type for the PrivacyUseCase type
+type PrivacyUseCase struct {
+	mock.Mock
+}
+
+// EraseUser provides a mock function with given fields: ctx, userID
+func (_m *PrivacyUseCase) EraseUser(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportUser provides a mock function with given fields: ctx, userID
+func (_m *PrivacyUseCase) ExportUser(ctx context.Context, userID int64) (*domain.PersonalData, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *domain.PersonalData
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.PersonalData); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PersonalData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,371 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// QuizRepository is an autogenerated mock type for the QuizRepository type
+type QuizRepository struct {
+	mock.Mock
+}
+
+// CreateAttempt provides a mock function with given fields: ctx, attempt
+func (_m *QuizRepository) CreateAttempt(ctx context.Context, attempt *domain.QuizAttempt) error {
+	ret := _m.Called(ctx, attempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.QuizAttempt) error); ok {
+		r0 = rf(ctx, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateQuestion provides a mock function with given fields: ctx, question
+func (_m *QuizRepository) CreateQuestion(ctx context.Context, question *domain.Question) error {
+	ret := _m.Called(ctx, question)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Question) error); ok {
+		r0 = rf(ctx, question)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateQuiz provides a mock function with given fields: ctx, quiz
+func (_m *QuizRepository) CreateQuiz(ctx context.Context, quiz *domain.Quiz) error {
+	ret := _m.Called(ctx, quiz)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Quiz) error); ok {
+		r0 = rf(ctx, quiz)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteQuestion provides a mock function with given fields: ctx, id
+func (_m *QuizRepository) DeleteQuestion(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteQuiz provides a mock function with given fields: ctx, id
+func (_m *QuizRepository) DeleteQuiz(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FinishAttempt provides a mock function with given fields: ctx, attempt
+func (_m *QuizRepository) FinishAttempt(ctx context.Context, attempt *domain.QuizAttempt) error {
+	ret := _m.Called(ctx, attempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.QuizAttempt) error); ok {
+		r0 = rf(ctx, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAttempt provides a mock function with given fields: ctx, id
+func (_m *QuizRepository) GetAttempt(ctx context.Context, id int64) (*domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.QuizAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.QuizAttempt); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.QuizAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAttempts provides a mock function with given fields: ctx, quizID, userID
+func (_m *QuizRepository) GetAttempts(ctx context.Context, quizID int64, userID int64) ([]domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, quizID, userID)
+
+	var r0 []domain.QuizAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []domain.QuizAttempt); ok {
+		r0 = rf(ctx, quizID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.QuizAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, quizID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *QuizRepository) GetByID(ctx context.Context, id int64) (*domain.Quiz, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Quiz
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Quiz); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Quiz)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *QuizRepository) GetByLesson(ctx context.Context, lessonID int64) ([]domain.Quiz, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	var r0 []domain.Quiz
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Quiz); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Quiz)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPoolQuestions provides a mock function with given fields: ctx, courseID, tagID
+func (_m *QuizRepository) GetPoolQuestions(ctx context.Context, courseID int64, tagID int64) ([]domain.Question, error) {
+	ret := _m.Called(ctx, courseID, tagID)
+
+	var r0 []domain.Question
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []domain.Question); ok {
+		r0 = rf(ctx, courseID, tagID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Question)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, courseID, tagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuestion provides a mock function with given fields: ctx, id
+func (_m *QuizRepository) GetQuestion(ctx context.Context, id int64) (*domain.Question, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Question
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Question); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Question)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuestions provides a mock function with given fields: ctx, courseID, tagID, start, limit
+func (_m *QuizRepository) GetQuestions(ctx context.Context, courseID int64, tagID int64, start int, limit int) ([]domain.Question, error) {
+	ret := _m.Called(ctx, courseID, tagID, start, limit)
+
+	var r0 []domain.Question
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int, int) []domain.Question); ok {
+		r0 = rf(ctx, courseID, tagID, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Question)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int, int) error); ok {
+		r1 = rf(ctx, courseID, tagID, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuestionsByIDs provides a mock function with given fields: ctx, courseID, ids
+func (_m *QuizRepository) GetQuestionsByIDs(ctx context.Context, courseID int64, ids []int64) ([]domain.Question, error) {
+	ret := _m.Called(ctx, courseID, ids)
+
+	var r0 []domain.Question
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64) []domain.Question); ok {
+		r0 = rf(ctx, courseID, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Question)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []int64) error); ok {
+		r1 = rf(ctx, courseID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuizQuestions provides a mock function with given fields: ctx, quizID
+func (_m *QuizRepository) GetQuizQuestions(ctx context.Context, quizID int64) ([]domain.Question, error) {
+	ret := _m.Called(ctx, quizID)
+
+	var r0 []domain.Question
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Question); ok {
+		r0 = rf(ctx, quizID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Question)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, quizID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResults provides a mock function with given fields: ctx, quizID, start, limit
+func (_m *QuizRepository) GetResults(ctx context.Context, quizID int64, start int, limit int) ([]domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, quizID, start, limit)
+
+	var r0 []domain.QuizAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.QuizAttempt); ok {
+		r0 = rf(ctx, quizID, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.QuizAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(ctx, quizID, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GradeAttempt provides a mock function with given fields: ctx, attempt
+func (_m *QuizRepository) GradeAttempt(ctx context.Context, attempt *domain.QuizAttempt) error {
+	ret := _m.Called(ctx, attempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.QuizAttempt) error); ok {
+		r0 = rf(ctx, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateQuestion provides a mock function with given fields: ctx, question
+func (_m *QuizRepository) UpdateQuestion(ctx context.Context, question *domain.Question) error {
+	ret := _m.Called(ctx, question)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Question) error); ok {
+		r0 = rf(ctx, question)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateQuiz provides a mock function with given fields: ctx, quiz
+func (_m *QuizRepository) UpdateQuiz(ctx context.Context, quiz *domain.Quiz) error {
+	ret := _m.Called(ctx, quiz)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Quiz) error); ok {
+		r0 = rf(ctx, quiz)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,329 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// QuizUseCase is an autogenerated mock type for the QuizUseCase type
+type QuizUseCase struct {
+	mock.Mock
+}
+
+// CreateQuestion provides a mock function with given fields: ctx, question
+func (_m *QuizUseCase) CreateQuestion(ctx context.Context, question *domain.Question) error {
+	ret := _m.Called(ctx, question)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Question) error); ok {
+		r0 = rf(ctx, question)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateQuiz provides a mock function with given fields: ctx, quiz
+func (_m *QuizUseCase) CreateQuiz(ctx context.Context, quiz *domain.Quiz) error {
+	ret := _m.Called(ctx, quiz)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Quiz) error); ok {
+		r0 = rf(ctx, quiz)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteQuestion provides a mock function with given fields: ctx, id
+func (_m *QuizUseCase) DeleteQuestion(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteQuiz provides a mock function with given fields: ctx, id
+func (_m *QuizUseCase) DeleteQuiz(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAttempt provides a mock function with given fields: ctx, id
+func (_m *QuizUseCase) GetAttempt(ctx context.Context, id int64) (*domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.QuizAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.QuizAttempt); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.QuizAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAttempts provides a mock function with given fields: ctx, quizID
+func (_m *QuizUseCase) GetAttempts(ctx context.Context, quizID int64) ([]domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, quizID)
+
+	var r0 []domain.QuizAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.QuizAttempt); ok {
+		r0 = rf(ctx, quizID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.QuizAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, quizID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *QuizUseCase) GetByID(ctx context.Context, id int64) (*domain.Quiz, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Quiz
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Quiz); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Quiz)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *QuizUseCase) GetByLesson(ctx context.Context, lessonID int64) ([]domain.Quiz, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	var r0 []domain.Quiz
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Quiz); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Quiz)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuestion provides a mock function with given fields: ctx, id
+func (_m *QuizUseCase) GetQuestion(ctx context.Context, id int64) (*domain.Question, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Question
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Question); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Question)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuestions provides a mock function with given fields: ctx, courseID, tagID, start, limit
+func (_m *QuizUseCase) GetQuestions(ctx context.Context, courseID int64, tagID int64, start int, limit int) ([]domain.Question, error) {
+	ret := _m.Called(ctx, courseID, tagID, start, limit)
+
+	var r0 []domain.Question
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int, int) []domain.Question); ok {
+		r0 = rf(ctx, courseID, tagID, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Question)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int, int) error); ok {
+		r1 = rf(ctx, courseID, tagID, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResults provides a mock function with given fields: ctx, quizID, start, limit
+func (_m *QuizUseCase) GetResults(ctx context.Context, quizID int64, start int, limit int) ([]domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, quizID, start, limit)
+
+	var r0 []domain.QuizAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.QuizAttempt); ok {
+		r0 = rf(ctx, quizID, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.QuizAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(ctx, quizID, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GradeAnswer provides a mock function with given fields: ctx, attemptID, questionID, grade
+func (_m *QuizUseCase) GradeAnswer(ctx context.Context, attemptID int64, questionID int64, grade *domain.AnswerGrade) (*domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, attemptID, questionID, grade)
+
+	var r0 *domain.QuizAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *domain.AnswerGrade) *domain.QuizAttempt); ok {
+		r0 = rf(ctx, attemptID, questionID, grade)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.QuizAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, *domain.AnswerGrade) error); ok {
+		r1 = rf(ctx, attemptID, questionID, grade)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartAttempt provides a mock function with given fields: ctx, quizID
+func (_m *QuizUseCase) StartAttempt(ctx context.Context, quizID int64) (*domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, quizID)
+
+	var r0 *domain.QuizAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.QuizAttempt); ok {
+		r0 = rf(ctx, quizID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.QuizAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, quizID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitAttempt provides a mock function with given fields: ctx, attemptID, submission
+func (_m *QuizUseCase) SubmitAttempt(ctx context.Context, attemptID int64, submission *domain.AttemptSubmission) (*domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, attemptID, submission)
+
+	var r0 *domain.QuizAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.AttemptSubmission) *domain.QuizAttempt); ok {
+		r0 = rf(ctx, attemptID, submission)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.QuizAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *domain.AttemptSubmission) error); ok {
+		r1 = rf(ctx, attemptID, submission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateQuestion provides a mock function with given fields: ctx, question, id
+func (_m *QuizUseCase) UpdateQuestion(ctx context.Context, question *domain.Question, id int64) error {
+	ret := _m.Called(ctx, question, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Question, int64) error); ok {
+		r0 = rf(ctx, question, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateQuiz provides a mock function with given fields: ctx, quiz, id
+func (_m *QuizUseCase) UpdateQuiz(ctx context.Context, quiz *domain.Quiz, id int64) error {
+	ret := _m.Called(ctx, quiz, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Quiz, int64) error); ok {
+		r0 = rf(ctx, quiz, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RoleUseCase is an autogenerated mock type for the RoleUseCase type
+type RoleUseCase struct {
+	mock.Mock
+}
+
+// CreateRole provides a mock function with given fields: ctx, role
+func (_m *RoleUseCase) CreateRole(ctx context.Context, role *domain.Role) error {
+	ret := _m.Called(ctx, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Role) error); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRole provides a mock function with given fields: ctx, id
+func (_m *RoleUseCase) DeleteRole(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, start, limit
+func (_m *RoleUseCase) GetAll(ctx context.Context, start int, limit int) ([]domain.Role, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.Role
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Role); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *RoleUseCase) GetByID(ctx context.Context, id int64) (*domain.Role, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Role
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Role); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRole provides a mock function with given fields: ctx, role, id
+func (_m *RoleUseCase) UpdateRole(ctx context.Context, role *domain.Role, id int64) error {
+	ret := _m.Called(ctx, role, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Role, int64) error); ok {
+		r0 = rf(ctx, role, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RubricRepository is an autogenerated mock type for the RubricRepository type
+type RubricRepository struct {
+	mock.Mock
+}
+
+// CreateRubric provides a mock function with given fields: ctx, rubric
+func (_m *RubricRepository) CreateRubric(ctx context.Context, rubric *domain.Rubric) error {
+	ret := _m.Called(ctx, rubric)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Rubric) error); ok {
+		r0 = rf(ctx, rubric)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRubric provides a mock function with given fields: ctx, id
+func (_m *RubricRepository) DeleteRubric(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, start, limit
+func (_m *RubricRepository) GetAll(ctx context.Context, start int, limit int) ([]domain.Rubric, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.Rubric
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Rubric); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Rubric)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *RubricRepository) GetByID(ctx context.Context, id int64) (*domain.Rubric, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Rubric
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Rubric); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Rubric)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatistics provides a mock function with given fields: ctx, rubricID, courseID
+func (_m *RubricRepository) GetStatistics(ctx context.Context, rubricID int64, courseID int64) (*domain.RubricStatistics, error) {
+	ret := _m.Called(ctx, rubricID, courseID)
+
+	var r0 *domain.RubricStatistics
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.RubricStatistics); ok {
+		r0 = rf(ctx, rubricID, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RubricStatistics)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, rubricID, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsUsed provides a mock function with given fields: ctx, id
+func (_m *RubricRepository) IsUsed(ctx context.Context, id int64) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Record provides a mock function with given fields: ctx, work, scores
+func (_m *RubricRepository) Record(ctx context.Context, work *domain.RubricWork, scores []domain.CriterionScore) error {
+	ret := _m.Called(ctx, work, scores)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.RubricWork, []domain.CriterionScore) error); ok {
+		r0 = rf(ctx, work, scores)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRubric provides a mock function with given fields: ctx, rubric
+func (_m *RubricRepository) UpdateRubric(ctx context.Context, rubric *domain.Rubric) error {
+	ret := _m.Called(ctx, rubric)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Rubric) error); ok {
+		r0 = rf(ctx, rubric)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,168 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RubricUseCase is an autogenerated mock type for the RubricUseCase type
+type RubricUseCase struct {
+	mock.Mock
+}
+
+// Apply provides a mock function with given fields: ctx, rubricID, scores
+func (_m *RubricUseCase) Apply(ctx context.Context, rubricID int64, scores []domain.CriterionScore) (float64, float64, error) {
+	ret := _m.Called(ctx, rubricID, scores)
+
+	var r0 float64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []domain.CriterionScore) float64); ok {
+		r0 = rf(ctx, rubricID, scores)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	var r1 float64
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []domain.CriterionScore) float64); ok {
+		r1 = rf(ctx, rubricID, scores)
+	} else {
+		r1 = ret.Get(1).(float64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, []domain.CriterionScore) error); ok {
+		r2 = rf(ctx, rubricID, scores)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateRubric provides a mock function with given fields: ctx, rubric
+func (_m *RubricUseCase) CreateRubric(ctx context.Context, rubric *domain.Rubric) error {
+	ret := _m.Called(ctx, rubric)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Rubric) error); ok {
+		r0 = rf(ctx, rubric)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRubric provides a mock function with given fields: ctx, id
+func (_m *RubricUseCase) DeleteRubric(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, start, limit
+func (_m *RubricUseCase) GetAll(ctx context.Context, start int, limit int) ([]domain.Rubric, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.Rubric
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Rubric); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Rubric)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *RubricUseCase) GetByID(ctx context.Context, id int64) (*domain.Rubric, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Rubric
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Rubric); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Rubric)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatistics provides a mock function with given fields: ctx, rubricID, courseID
+func (_m *RubricUseCase) GetStatistics(ctx context.Context, rubricID int64, courseID int64) (*domain.RubricStatistics, error) {
+	ret := _m.Called(ctx, rubricID, courseID)
+
+	var r0 *domain.RubricStatistics
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.RubricStatistics); ok {
+		r0 = rf(ctx, rubricID, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RubricStatistics)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, rubricID, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Record provides a mock function with given fields: ctx, work, scores
+func (_m *RubricUseCase) Record(ctx context.Context, work *domain.RubricWork, scores []domain.CriterionScore) error {
+	ret := _m.Called(ctx, work, scores)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.RubricWork, []domain.CriterionScore) error); ok {
+		r0 = rf(ctx, work, scores)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRubric provides a mock function with given fields: ctx, rubric, id
+func (_m *RubricUseCase) UpdateRubric(ctx context.Context, rubric *domain.Rubric, id int64) error {
+	ret := _m.Called(ctx, rubric, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Rubric, int64) error); ok {
+		r0 = rf(ctx, rubric, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,152 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionRepository is an autogenerated mock type for the SessionRepository type
+type SessionRepository struct {
+	mock.Mock
+}
+
+// CountOnline provides a mock function with given fields: ctx, roleCode, since
+func (_m *SessionRepository) CountOnline(ctx context.Context, roleCode string, since int64) (int64, error) {
+	ret := _m.Called(ctx, roleCode, since)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = rf(ctx, roleCode, since)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, roleCode, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSession provides a mock function with given fields: ctx, session
+func (_m *SessionRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+	ret := _m.Called(ctx, session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExtendSession provides a mock function with given fields: ctx, id, expiresAt
+func (_m *SessionRepository) ExtendSession(ctx context.Context, id int64, expiresAt int64) error {
+	ret := _m.Called(ctx, id, expiresAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *SessionRepository) GetByID(ctx context.Context, id int64) (*domain.Session, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Session
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Session); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUser provides a mock function with given fields: ctx, userID, now
+func (_m *SessionRepository) GetByUser(ctx context.Context, userID int64, now int64) ([]domain.Session, error) {
+	ret := _m.Called(ctx, userID, now)
+
+	var r0 []domain.Session
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []domain.Session); ok {
+		r0 = rf(ctx, userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: ctx, id, revokedAt
+func (_m *SessionRepository) RevokeSession(ctx context.Context, id int64, revokedAt int64) error {
+	ret := _m.Called(ctx, id, revokedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, revokedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLastOnline provides a mock function with given fields: ctx, seen
+func (_m *SessionRepository) UpdateLastOnline(ctx context.Context, seen map[int64]int64) error {
+	ret := _m.Called(ctx, seen)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[int64]int64) error); ok {
+		r0 = rf(ctx, seen)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLastSeen provides a mock function with given fields: ctx, seen
+func (_m *SessionRepository) UpdateLastSeen(ctx context.Context, seen map[int64]int64) error {
+	ret := _m.Called(ctx, seen)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[int64]int64) error); ok {
+		r0 = rf(ctx, seen)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionUseCase is an autogenerated mock type for the SessionUseCase type
+type SessionUseCase struct {
+	mock.Mock
+}
+
+// Flush provides a mock function with given fields: ctx
+func (_m *SessionUseCase) Flush(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByUser provides a mock function with given fields: ctx, userID
+func (_m *SessionUseCase) GetByUser(ctx context.Context, userID int64) ([]domain.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.Session
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPresence provides a mock function with given fields: ctx
+func (_m *SessionUseCase) GetPresence(ctx context.Context) (*domain.Presence, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.Presence
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.Presence); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Presence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, id
+func (_m *SessionUseCase) RevokeSession(ctx context.Context, userID int64, id int64) error {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Touch provides a mock function with given fields: ctx, userID, sessionID
+func (_m *SessionUseCase) Touch(ctx context.Context, userID int64, sessionID int64) {
+	_m.Called(ctx, userID, sessionID)
+}
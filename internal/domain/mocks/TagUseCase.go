@@ -14,6 +14,29 @@ type TagUseCase struct {
 	mock.Mock
 }
 
+// BulkAction provides a mock function with given fields: ctx, action
+func (_m *TagUseCase) BulkAction(ctx context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, action)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BulkAction) []domain.BulkResult); ok {
+		r0 = rf(ctx, action)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.BulkAction) error); ok {
+		r1 = rf(ctx, action)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateCourseTag provides a mock function with given fields: ctx, tagID, courseID
 func (_m *TagUseCase) CreateCourseTag(ctx context.Context, tagID int64, courseID int64) error {
 	ret := _m.Called(ctx, tagID, courseID)
@@ -42,6 +65,20 @@ func (_m *TagUseCase) CreateLessonTag(ctx context.Context, tagID int64, lessonID
 	return r0
 }
 
+// CreateQuestionTag provides a mock function with given fields: ctx, tagID, questionID
+func (_m *TagUseCase) CreateQuestionTag(ctx context.Context, tagID int64, questionID int64) error {
+	ret := _m.Called(ctx, tagID, questionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, tagID, questionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateTag provides a mock function with given fields: ctx, Tag
 func (_m *TagUseCase) CreateTag(ctx context.Context, Tag *domain.Tag) error {
 	ret := _m.Called(ctx, Tag)
@@ -84,6 +121,20 @@ func (_m *TagUseCase) DeleteLessonTag(ctx context.Context, tagID int64, lessonID
 	return r0
 }
 
+// DeleteQuestionTag provides a mock function with given fields: ctx, tagID, questionID
+func (_m *TagUseCase) DeleteQuestionTag(ctx context.Context, tagID int64, questionID int64) error {
+	ret := _m.Called(ctx, tagID, questionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, tagID, questionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTag provides a mock function with given fields: ctx, id
 func (_m *TagUseCase) DeleteTag(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -213,6 +264,29 @@ func (_m *TagUseCase) GetLessonTags(ctx context.Context, lessonID int64) ([]doma
 	return r0, r1
 }
 
+// GetQuestionTags provides a mock function with given fields: ctx, questionID
+func (_m *TagUseCase) GetQuestionTags(ctx context.Context, questionID int64) ([]domain.Tag, error) {
+	ret := _m.Called(ctx, questionID)
+
+	var r0 []domain.Tag
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Tag); ok {
+		r0 = rf(ctx, questionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Tag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, questionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateTag provides a mock function with given fields: ctx, Tag, id
 func (_m *TagUseCase) UpdateTag(ctx context.Context, Tag *domain.Tag, id int64) error {
 	ret := _m.Called(ctx, Tag, id)
@@ -0,0 +1,297 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TeamRepository is an autogenerated mock type for the TeamRepository type
+type TeamRepository struct {
+	mock.Mock
+}
+
+// AddMember provides a mock function with given fields: ctx, teamID, userID, createdAt
+func (_m *TeamRepository) AddMember(ctx context.Context, teamID int64, userID int64, createdAt int64) error {
+	ret := _m.Called(ctx, teamID, userID, createdAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, teamID, userID, createdAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTeam provides a mock function with given fields: ctx, team
+func (_m *TeamRepository) CreateTeam(ctx context.Context, team *domain.Team) error {
+	ret := _m.Called(ctx, team)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Team) error); ok {
+		r0 = rf(ctx, team)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTeam provides a mock function with given fields: ctx, id
+func (_m *TeamRepository) DeleteTeam(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTeam provides a mock function with given fields: ctx, enrollment
+func (_m *TeamRepository) EnrollTeam(ctx context.Context, enrollment *domain.TeamEnrollment) error {
+	ret := _m.Called(ctx, enrollment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TeamEnrollment) error); ok {
+		r0 = rf(ctx, enrollment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, searchQuery, start, limit
+func (_m *TeamRepository) GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]domain.Team, error) {
+	ret := _m.Called(ctx, searchQuery, start, limit)
+
+	var r0 []domain.Team
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.Team); ok {
+		r0 = rf(ctx, searchQuery, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Team)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, searchQuery, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCourse provides a mock function with given fields: ctx, courseID
+func (_m *TeamRepository) GetByCourse(ctx context.Context, courseID int64) ([]domain.Team, error) {
+	ret := _m.Called(ctx, courseID)
+
+	var r0 []domain.Team
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Team); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Team)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *TeamRepository) GetByID(ctx context.Context, id int64) (*domain.Team, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Team
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Team); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Team)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByName provides a mock function with given fields: ctx, name
+func (_m *TeamRepository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *domain.Team
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Team); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Team)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUser provides a mock function with given fields: ctx, userID
+func (_m *TeamRepository) GetByUser(ctx context.Context, userID int64) ([]domain.Team, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.Team
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Team); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Team)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseIDs provides a mock function with given fields: ctx, teamID
+func (_m *TeamRepository) GetCourseIDs(ctx context.Context, teamID int64) ([]int64, error) {
+	ret := _m.Called(ctx, teamID)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []int64); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMemberIDs provides a mock function with given fields: ctx, teamID
+func (_m *TeamRepository) GetMemberIDs(ctx context.Context, teamID int64) ([]int64, error) {
+	ret := _m.Called(ctx, teamID)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []int64); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembers provides a mock function with given fields: ctx, teamID, start, limit
+func (_m *TeamRepository) GetMembers(ctx context.Context, teamID int64, start int, limit int) ([]domain.User, error) {
+	ret := _m.Called(ctx, teamID, start, limit)
+
+	var r0 []domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.User); ok {
+		r0 = rf(ctx, teamID, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(ctx, teamID, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, teamID, userID
+func (_m *TeamRepository) RemoveMember(ctx context.Context, teamID int64, userID int64) error {
+	ret := _m.Called(ctx, teamID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, teamID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnenrollTeam provides a mock function with given fields: ctx, courseID, teamID
+func (_m *TeamRepository) UnenrollTeam(ctx context.Context, courseID int64, teamID int64) error {
+	ret := _m.Called(ctx, courseID, teamID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, courseID, teamID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTeam provides a mock function with given fields: ctx, team
+func (_m *TeamRepository) UpdateTeam(ctx context.Context, team *domain.Team) error {
+	ret := _m.Called(ctx, team)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Team) error); ok {
+		r0 = rf(ctx, team)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,228 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TeamUseCase is an autogenerated mock type for the TeamUseCase type
+type TeamUseCase struct {
+	mock.Mock
+}
+
+// AddMember provides a mock function with given fields: ctx, teamID, userID
+func (_m *TeamUseCase) AddMember(ctx context.Context, teamID int64, userID int64) error {
+	ret := _m.Called(ctx, teamID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, teamID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTeam provides a mock function with given fields: ctx, team
+func (_m *TeamUseCase) CreateTeam(ctx context.Context, team *domain.Team) error {
+	ret := _m.Called(ctx, team)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Team) error); ok {
+		r0 = rf(ctx, team)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTeam provides a mock function with given fields: ctx, id
+func (_m *TeamUseCase) DeleteTeam(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTeam provides a mock function with given fields: ctx, enrollment
+func (_m *TeamUseCase) EnrollTeam(ctx context.Context, enrollment *domain.TeamEnrollment) error {
+	ret := _m.Called(ctx, enrollment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TeamEnrollment) error); ok {
+		r0 = rf(ctx, enrollment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, searchQuery, start, limit
+func (_m *TeamUseCase) GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]domain.Team, error) {
+	ret := _m.Called(ctx, searchQuery, start, limit)
+
+	var r0 []domain.Team
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.Team); ok {
+		r0 = rf(ctx, searchQuery, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Team)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, searchQuery, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCourse provides a mock function with given fields: ctx, courseID
+func (_m *TeamUseCase) GetByCourse(ctx context.Context, courseID int64) ([]domain.Team, error) {
+	ret := _m.Called(ctx, courseID)
+
+	var r0 []domain.Team
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Team); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Team)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *TeamUseCase) GetByID(ctx context.Context, id int64) (*domain.Team, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Team
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Team); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Team)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUser provides a mock function with given fields: ctx, userID
+func (_m *TeamUseCase) GetByUser(ctx context.Context, userID int64) ([]domain.Team, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.Team
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Team); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Team)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembers provides a mock function with given fields: ctx, teamID, start, limit
+func (_m *TeamUseCase) GetMembers(ctx context.Context, teamID int64, start int, limit int) ([]domain.User, error) {
+	ret := _m.Called(ctx, teamID, start, limit)
+
+	var r0 []domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []domain.User); ok {
+		r0 = rf(ctx, teamID, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(ctx, teamID, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, teamID, userID
+func (_m *TeamUseCase) RemoveMember(ctx context.Context, teamID int64, userID int64) error {
+	ret := _m.Called(ctx, teamID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, teamID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnenrollTeam provides a mock function with given fields: ctx, courseID, teamID
+func (_m *TeamUseCase) UnenrollTeam(ctx context.Context, courseID int64, teamID int64) error {
+	ret := _m.Called(ctx, courseID, teamID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, courseID, teamID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTeam provides a mock function with given fields: ctx, team, id
+func (_m *TeamUseCase) UpdateTeam(ctx context.Context, team *domain.Team, id int64) error {
+	ret := _m.Called(ctx, team, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Team, int64) error); ok {
+		r0 = rf(ctx, team, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
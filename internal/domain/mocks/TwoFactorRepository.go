@@ -0,0 +1,143 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TwoFactorRepository is an autogenerated mock type for the TwoFactorRepository type
+type TwoFactorRepository struct {
+	mock.Mock
+}
+
+// CountRecoveryCodes provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID int64) (int, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorRepository) Delete(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enable provides a mock function with given fields: ctx, userID, step, enabledAt, codeHashes
+func (_m *TwoFactorRepository) Enable(ctx context.Context, userID int64, step int64, enabledAt int64, codeHashes []string) error {
+	ret := _m.Called(ctx, userID, step, enabledAt, codeHashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, []string) error); ok {
+		r0 = rf(ctx, userID, step, enabledAt, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorRepository) Get(ctx context.Context, userID int64) (*domain.TwoFactor, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *domain.TwoFactor
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.TwoFactor); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TwoFactor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceRecoveryCodes provides a mock function with given fields: ctx, userID, codeHashes, createdAt
+func (_m *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string, createdAt int64) error {
+	ret := _m.Called(ctx, userID, codeHashes, createdAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, int64) error); ok {
+		r0 = rf(ctx, userID, codeHashes, createdAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: ctx, twoFactor
+func (_m *TwoFactorRepository) Save(ctx context.Context, twoFactor *domain.TwoFactor) error {
+	ret := _m.Called(ctx, twoFactor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TwoFactor) error); ok {
+		r0 = rf(ctx, twoFactor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, codeHash, usedAt
+func (_m *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string, usedAt int64) error {
+	ret := _m.Called(ctx, userID, codeHash, usedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) error); ok {
+		r0 = rf(ctx, userID, codeHash, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseStep provides a mock function with given fields: ctx, userID, step
+func (_m *TwoFactorRepository) UseStep(ctx context.Context, userID int64, step int64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,241 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TwoFactorUseCase is an autogenerated mock type for the TwoFactorUseCase type
+type TwoFactorUseCase struct {
+	mock.Mock
+}
+
+// Disable provides a mock function with given fields: ctx, userID, code
+func (_m *TwoFactorUseCase) Disable(ctx context.Context, userID int64, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enable provides a mock function with given fields: ctx, userID, code
+func (_m *TwoFactorUseCase) Enable(ctx context.Context, userID int64, code string) (*domain.RecoveryCodes, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	var r0 *domain.RecoveryCodes
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.RecoveryCodes); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RecoveryCodes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnableChallenge provides a mock function with given fields: ctx, login
+func (_m *TwoFactorUseCase) EnableChallenge(ctx context.Context, login *domain.TwoFactorLogin) (*domain.RecoveryCodes, error) {
+	ret := _m.Called(ctx, login)
+
+	var r0 *domain.RecoveryCodes
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TwoFactorLogin) *domain.RecoveryCodes); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RecoveryCodes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TwoFactorLogin) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Enroll provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorUseCase) Enroll(ctx context.Context, userID int64) (*domain.TwoFactorEnrollment, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *domain.TwoFactorEnrollment
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.TwoFactorEnrollment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TwoFactorEnrollment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnrollChallenge provides a mock function with given fields: ctx, challengeToken
+func (_m *TwoFactorUseCase) EnrollChallenge(ctx context.Context, challengeToken string) (*domain.TwoFactorEnrollment, error) {
+	ret := _m.Called(ctx, challengeToken)
+
+	var r0 *domain.TwoFactorEnrollment
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.TwoFactorEnrollment); ok {
+		r0 = rf(ctx, challengeToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TwoFactorEnrollment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, challengeToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPolicy provides a mock function with given fields: ctx
+func (_m *TwoFactorUseCase) GetPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.TwoFactorPolicy
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.TwoFactorPolicy); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TwoFactorPolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatus provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorUseCase) GetStatus(ctx context.Context, userID int64) (*domain.TwoFactorStatus, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *domain.TwoFactorStatus
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.TwoFactorStatus); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TwoFactorStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, login
+func (_m *TwoFactorUseCase) Login(ctx context.Context, login *domain.TwoFactorLogin) (*domain.TokenPair, error) {
+	ret := _m.Called(ctx, login)
+
+	var r0 *domain.TokenPair
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TwoFactorLogin) *domain.TokenPair); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TokenPair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TwoFactorLogin) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: ctx, userID, code
+func (_m *TwoFactorUseCase) RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) (*domain.RecoveryCodes, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	var r0 *domain.RecoveryCodes
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.RecoveryCodes); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RecoveryCodes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reset provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorUseCase) Reset(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPolicy provides a mock function with given fields: ctx, policy
+func (_m *TwoFactorUseCase) SetPolicy(ctx context.Context, policy *domain.TwoFactorPolicy) error {
+	ret := _m.Called(ctx, policy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TwoFactorPolicy) error); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,218 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserImportRepository is an autogenerated mock type for the UserImportRepository type
+type UserImportRepository struct {
+	mock.Mock
+}
+
+// AddTeamMembers provides a mock function with given fields: ctx, userID, teamIDs, createdAt
+func (_m *UserImportRepository) AddTeamMembers(ctx context.Context, userID int64, teamIDs []int64, createdAt int64) error {
+	ret := _m.Called(ctx, userID, teamIDs, createdAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64, int64) error); ok {
+		r0 = rf(ctx, userID, teamIDs, createdAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimPending provides a mock function with given fields: ctx, staleBefore, startedAt
+func (_m *UserImportRepository) ClaimPending(ctx context.Context, staleBefore int64, startedAt int64) (*domain.UserImport, error) {
+	ret := _m.Called(ctx, staleBefore, startedAt)
+
+	var r0 *domain.UserImport
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.UserImport); ok {
+		r0 = rf(ctx, staleBefore, startedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserImport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, staleBefore, startedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateImport provides a mock function with given fields: ctx, userImport
+func (_m *UserImportRepository) CreateImport(ctx context.Context, userImport *domain.UserImport) error {
+	ret := _m.Called(ctx, userImport)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserImport) error); ok {
+		r0 = rf(ctx, userImport)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, start, limit
+func (_m *UserImportRepository) GetAll(ctx context.Context, start int, limit int) ([]domain.UserImport, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.UserImport
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.UserImport); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserImport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *UserImportRepository) GetByID(ctx context.Context, id int64) (*domain.UserImport, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.UserImport
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.UserImport); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserImport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCountryIDs provides a mock function with given fields: ctx
+func (_m *UserImportRepository) GetCountryIDs(ctx context.Context) (map[string]int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExistingEmails provides a mock function with given fields: ctx, emails
+func (_m *UserImportRepository) GetExistingEmails(ctx context.Context, emails []string) (map[string]bool, error) {
+	ret := _m.Called(ctx, emails)
+
+	var r0 map[string]bool
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]bool); ok {
+		r0 = rf(ctx, emails)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, emails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExistingUsernames provides a mock function with given fields: ctx, usernames
+func (_m *UserImportRepository) GetExistingUsernames(ctx context.Context, usernames []string) (map[string]bool, error) {
+	ret := _m.Called(ctx, usernames)
+
+	var r0 map[string]bool
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]bool); ok {
+		r0 = rf(ctx, usernames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, usernames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamIDs provides a mock function with given fields: ctx
+func (_m *UserImportRepository) GetTeamIDs(ctx context.Context) (map[string]int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProgress provides a mock function with given fields: ctx, userImport
+func (_m *UserImportRepository) UpdateProgress(ctx context.Context, userImport *domain.UserImport) error {
+	ret := _m.Called(ctx, userImport)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserImport) error); ok {
+		r0 = rf(ctx, userImport)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserImportUseCase is an autogenerated mock type for the UserImportUseCase type
+type UserImportUseCase struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, start, limit
+func (_m *UserImportUseCase) GetAll(ctx context.Context, start int, limit int) ([]domain.UserImport, error) {
+	ret := _m.Called(ctx, start, limit)
+
+	var r0 []domain.UserImport
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.UserImport); ok {
+		r0 = rf(ctx, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserImport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *UserImportUseCase) GetByID(ctx context.Context, id int64) (*domain.UserImport, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.UserImport
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.UserImport); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserImport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportUsers provides a mock function with given fields: ctx, fileName, file, dryRun
+func (_m *UserImportUseCase) ImportUsers(ctx context.Context, fileName string, file io.Reader, dryRun bool) (*domain.ImportReport, error) {
+	ret := _m.Called(ctx, fileName, file, dryRun)
+
+	var r0 *domain.ImportReport
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, bool) *domain.ImportReport); ok {
+		r0 = rf(ctx, fileName, file, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Reader, bool) error); ok {
+		r1 = rf(ctx, fileName, file, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunPending provides a mock function with given fields: ctx
+func (_m *UserImportUseCase) RunPending(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -14,15 +14,121 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *UserRepository) CreateUser(ctx context.Context, user *domain.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, searchQuery, start, limit
+func (_m *UserRepository) GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]domain.User, error) {
+	ret := _m.Called(ctx, searchQuery, start, limit)
+
+	var r0 []domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.User); ok {
+		r0 = rf(ctx, searchQuery, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, searchQuery, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByEmail provides a mock function with given fields: ctx, email
+func (_m *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 *domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
-func (_m *UserRepository) GetByID(ctx context.Context, id int64) (domain.User, error) {
+func (_m *UserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUsername provides a mock function with given fields: ctx, username
+func (_m *UserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 *domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPassword provides a mock function with given fields: ctx, id
+func (_m *UserRepository) GetPassword(ctx context.Context, id int64) (string, error) {
 	ret := _m.Called(ctx, id)
 
-	var r0 domain.User
-	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.User); ok {
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, int64) string); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(domain.User)
+		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
@@ -34,3 +140,87 @@ func (_m *UserRepository) GetByID(ctx context.Context, id int64) (domain.User, e
 
 	return r0, r1
 }
+
+// MarkEmailVerified provides a mock function with given fields: ctx, id, email, verifiedAt
+func (_m *UserRepository) MarkEmailVerified(ctx context.Context, id int64, email string, verifiedAt int64) error {
+	ret := _m.Called(ctx, id, email, verifiedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) error); ok {
+		r0 = rf(ctx, id, email, verifiedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordLoginFailure provides a mock function with given fields: ctx, id, maxFailures, lockedUntil
+func (_m *UserRepository) RecordLoginFailure(ctx context.Context, id int64, maxFailures int, lockedUntil int64) error {
+	ret := _m.Called(ctx, id, maxFailures, lockedUntil)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int64) error); ok {
+		r0 = rf(ctx, id, maxFailures, lockedUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetLoginFailures provides a mock function with given fields: ctx, id
+func (_m *UserRepository) ResetLoginFailures(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, id, hash, updatedAt
+func (_m *UserRepository) UpdatePassword(ctx context.Context, id int64, hash string, updatedAt int64) error {
+	ret := _m.Called(ctx, id, hash, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) error); ok {
+		r0 = rf(ctx, id, hash, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, status, updatedAt
+func (_m *UserRepository) UpdateStatus(ctx context.Context, id int64, status int, updatedAt int64) error {
+	ret := _m.Called(ctx, id, status, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int64) error); ok {
+		r0 = rf(ctx, id, status, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user
+func (_m *UserRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.2.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/meroedu/meroedu/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserUseCase is an autogenerated mock type for the UserUseCase type
+type UserUseCase struct {
+	mock.Mock
+}
+
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *UserUseCase) CreateUser(ctx context.Context, user *domain.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeactivateUser provides a mock function with given fields: ctx, id
+func (_m *UserUseCase) DeactivateUser(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, searchQuery, start, limit
+func (_m *UserUseCase) GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]domain.User, error) {
+	ret := _m.Called(ctx, searchQuery, start, limit)
+
+	var r0 []domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.User); ok {
+		r0 = rf(ctx, searchQuery, start, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, searchQuery, start, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *UserUseCase) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user, id
+func (_m *UserUseCase) UpdateUser(ctx context.Context, user *domain.User, id int64) error {
+	ret := _m.Called(ctx, user, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, int64) error); ok {
+		r0 = rf(ctx, user, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+)
+
+// OIDCProvider is the OpenID Connect identity provider of an organization. Users signing in through it
+// are provisioned into that organization on their first login.
+type OIDCProvider struct {
+	ID             int64  `json:"id"`
+	OrganizationID int64  `json:"organization_id"`
+	Issuer         string `json:"issuer" validate:"required,url,max=255"`
+	ClientID       string `json:"client_id" validate:"required,max=255"`
+	// ClientSecret is never returned by the API
+	ClientSecret string   `json:"client_secret,omitempty" validate:"max=255"`
+	RedirectURL  string   `json:"redirect_url" validate:"required,url,max=255"`
+	Scopes       []string `json:"scopes,omitempty"`
+	// RoleClaim names the ID token claim whose values are looked up in RoleMapping. The first
+	// value found in the mapping gives the role code; DefaultRole is used when none is found.
+	RoleClaim   string            `json:"role_claim,omitempty" validate:"max=100"`
+	RoleMapping map[string]string `json:"role_mapping,omitempty"`
+	DefaultRole string            `json:"default_role" validate:"required,max=50"`
+	Enabled     bool              `json:"enabled"`
+	UpdatedAt   int64             `json:"updated_at,omitempty"`
+	CreatedAt   int64             `json:"created_at,omitempty"`
+}
+
+// OIDCLoginState is kept between the redirect to the identity provider and the callback. It is
+// looked up by the hash of the state parameter and can be used once.
+type OIDCLoginState struct {
+	StateHash      string
+	ProviderID     int64
+	OrganizationID int64
+	Nonce          string
+	CodeVerifier   string
+	ExpiresAt      int64
+	CreatedAt      int64
+}
+
+// OIDCClaims are the claims of a verified ID token
+type OIDCClaims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	GivenName         string
+	FamilyName        string
+	Name              string
+	PreferredUsername string
+	// Raw holds every claim of the ID token, for the role claim lookup
+	Raw map[string]interface{}
+}
+
+// UserIdentity links a user to the subject of an identity provider
+type UserIdentity struct {
+	ID         int64
+	UserID     int64
+	ProviderID int64
+	Subject    string
+	CreatedAt  int64
+}
+
+// OIDCUseCase represent the OpenID Connect single sign-on usecases
+type OIDCUseCase interface {
+	GetProvider(ctx context.Context) (*OIDCProvider, error)
+	SaveProvider(ctx context.Context, provider *OIDCProvider) error
+	DeleteProvider(ctx context.Context) error
+	LoginURL(ctx context.Context, organizationID int64) (string, error)
+	Callback(ctx context.Context, state string, code string) (*TokenPair, error)
+}
+
+// OIDCRepository represent the OpenID Connect repository contract
+type OIDCRepository interface {
+	GetProvider(ctx context.Context) (*OIDCProvider, error)
+	SaveProvider(ctx context.Context, provider *OIDCProvider) error
+	DeleteProvider(ctx context.Context) error
+	CreateLoginState(ctx context.Context, state *OIDCLoginState) error
+	ConsumeLoginState(ctx context.Context, stateHash string) (*OIDCLoginState, error)
+	GetIdentity(ctx context.Context, providerID int64, subject string) (*UserIdentity, error)
+	CreateIdentity(ctx context.Context, identity *UserIdentity) error
+}
+
+// OIDCClient speaks the OpenID Connect authorization code flow with an identity provider
+type OIDCClient interface {
+	AuthCodeURL(ctx context.Context, provider *OIDCProvider, state string, nonce string, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, provider *OIDCProvider, code string, codeVerifier string, nonce string) (*OIDCClaims, error)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+)
+
+// Organization Status
+const (
+	OrganizationInactive = 0
+	OrganizationActive   = 1
+)
+
+// Organization is a tenant. Every course, category, tag, attachment, user and custom role belongs to one.
+type Organization struct {
+	ID          int64  `json:"id,omitempty"`
+	Name        string `json:"name" validate:"required,max=200"`
+	Description string `json:"description,omitempty" validate:"max=255"`
+	Website     string `json:"website,omitempty" validate:"max=50"`
+	Address1    string `json:"address1,omitempty"`
+	Address2    string `json:"address2,omitempty"`
+	CountryID   int64  `json:"country_id,omitempty"`
+	Status      int    `json:"status"`
+	// RequireTwoFactor makes every user of the organization log in with a second factor
+	RequireTwoFactor bool  `json:"require_two_factor"`
+	UpdatedAt        int64 `json:"updated_at,omitempty"`
+	CreatedAt        int64 `json:"created_at,omitempty"`
+}
+
+// OrganizationUseCase represent the Organization's usecases
+type OrganizationUseCase interface {
+	GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]Organization, error)
+	GetByID(ctx context.Context, id int64) (*Organization, error)
+	CreateOrganization(ctx context.Context, organization *Organization) error
+	UpdateOrganization(ctx context.Context, organization *Organization, id int64) error
+	DeleteOrganization(ctx context.Context, id int64) error
+}
+
+// OrganizationRepository represent the Organization's repository
+type OrganizationRepository interface {
+	GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]Organization, error)
+	GetByID(ctx context.Context, id int64) (*Organization, error)
+	CreateOrganization(ctx context.Context, organization *Organization) error
+	UpdateOrganization(ctx context.Context, organization *Organization) error
+	SetTwoFactorRequired(ctx context.Context, id int64, required bool, updatedAt int64) error
+	DeleteOrganization(ctx context.Context, id int64) error
+}
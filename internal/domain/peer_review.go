@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+)
+
+// PeerReviewSettings are the settings of the peer review of an assignment
+type PeerReviewSettings struct {
+	// Reviews is the number of learners reviewing each submission, and of submissions each learner reviews
+	Reviews int `json:"reviews" validate:"gte=1,lte=10"`
+	// Anonymous hides the reviewers from the authors and the authors from the reviewers
+	Anonymous bool `json:"anonymous"`
+	// DueAt is the time the reviews are due. 0 means no due date.
+	DueAt int64 `json:"due_at,omitempty" validate:"gte=0"`
+	// AllocatedAt is the time the reviewers were allocated, once the assignment was due
+	AllocatedAt int64 `json:"allocated_at,omitempty"`
+}
+
+// PeerReviewStatus is the state of a peer review
+type PeerReviewStatus string
+
+// Peer Review Status
+const (
+	PeerReviewAssigned  PeerReviewStatus = "assigned"
+	PeerReviewSubmitted PeerReviewStatus = "submitted"
+)
+
+// PeerReview is the review of a submission by another learner, scored with the rubric of the assignment
+type PeerReview struct {
+	ID           int64 `json:"id"`
+	AssignmentID int64 `json:"assignment_id"`
+	SubmissionID int64 `json:"submission_id"`
+	// AuthorID and ReviewerID are hidden from the learners when the reviews are anonymous
+	AuthorID   int64            `json:"author_id,omitempty"`
+	ReviewerID int64            `json:"reviewer_id,omitempty"`
+	Status     PeerReviewStatus `json:"status"`
+	Scores     []CriterionScore `json:"scores,omitempty"`
+	Score      *float64         `json:"score,omitempty"`
+	Feedback   string           `json:"feedback,omitempty"`
+	// Submission is the work to review, shown to the reviewer without its grade
+	Submission  *Submission `json:"submission,omitempty"`
+	AssignedAt  int64       `json:"assigned_at"`
+	SubmittedAt int64       `json:"submitted_at,omitempty"`
+}
+
+// PeerReviewGrade is the scores a reviewer gives with the rubric of the assignment, and their feedback
+type PeerReviewGrade struct {
+	Scores   []CriterionScore `json:"scores" validate:"min=1,dive"`
+	Feedback string           `json:"feedback"`
+}
+
+// PeerCriterionGrade is the mean of the scores the reviewers gave for a criterion of the rubric
+type PeerCriterionGrade struct {
+	CriterionID int     `json:"criterion_id"`
+	Mean        float64 `json:"mean"`
+}
+
+// PeerGrade is the grade of a submission aggregated from the reviews submitted for it
+type PeerGrade struct {
+	SubmissionID int64 `json:"submission_id"`
+	AuthorID     int64 `json:"author_id"`
+	// Reviews is the number of reviewers allocated, Completed the number of reviews submitted
+	Reviews   int                  `json:"reviews"`
+	Completed int                  `json:"completed"`
+	MaxPoints float64              `json:"max_points"`
+	Mean      float64              `json:"mean"`
+	Median    float64              `json:"median"`
+	Min       float64              `json:"min"`
+	Max       float64              `json:"max"`
+	Criteria  []PeerCriterionGrade `json:"criteria"`
+}
+
+// PeerReviewAllocation is an assignment of an organization due with its reviewers not allocated yet
+type PeerReviewAllocation struct {
+	AssignmentID   int64
+	OrganizationID int64
+}
+
+// PeerReviewUseCase represent the PeerReview's usecases
+type PeerReviewUseCase interface {
+	// AllocateDue allocates the reviewers of the assignments of every organization past their due date
+	AllocateDue(ctx context.Context) error
+	Allocate(ctx context.Context, assignmentID int64) ([]PeerReview, error)
+	GetAssigned(ctx context.Context, assignmentID int64) ([]PeerReview, error)
+	GetByID(ctx context.Context, id int64) (*PeerReview, error)
+	SubmitReview(ctx context.Context, id int64, grade *PeerReviewGrade) (*PeerReview, error)
+	GetReceived(ctx context.Context, submissionID int64) ([]PeerReview, error)
+	DownloadFile(ctx context.Context, reviewID int64, name string) (string, error)
+	GetGrades(ctx context.Context, assignmentID int64) ([]PeerGrade, error)
+}
+
+// PeerReviewRepository represent the PeerReview's repository
+type PeerReviewRepository interface {
+	GetUnallocated(ctx context.Context, dueBefore int64) ([]PeerReviewAllocation, error)
+	GetLatestSubmissions(ctx context.Context, assignmentID int64) ([]Submission, error)
+	Allocate(ctx context.Context, assignmentID int64, allocatedAt int64, reviews []PeerReview) error
+	GetByID(ctx context.Context, id int64) (*PeerReview, error)
+	GetByReviewer(ctx context.Context, assignmentID int64, reviewerID int64) ([]PeerReview, error)
+	GetBySubmission(ctx context.Context, submissionID int64) ([]PeerReview, error)
+	GetByAssignment(ctx context.Context, assignmentID int64) ([]PeerReview, error)
+	SubmitReview(ctx context.Context, review *PeerReview) error
+}
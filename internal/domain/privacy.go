@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+)
+
+// ErasedUserName is the last name left to an erased user
+const ErasedUserName = "Erased user"
+
+// PersonalData is everything stored about a user, exported to answer a data subject request
+type PersonalData struct {
+	ExportedAt  int64              `json:"exported_at"`
+	Profile     *User              `json:"profile"`
+	Teams       []Team             `json:"teams"`
+	Enrollments []Enrollment       `json:"enrollments"`
+	Identities  []PersonalIdentity `json:"identities"`
+	Sessions    []PersonalSession  `json:"sessions"`
+	Invitations []Invitation       `json:"invitations"`
+	Submissions []Submission       `json:"submissions"`
+	// PeerReviews are the reviews written by the user and those of their submissions, without the other learner
+	PeerReviews  []PeerReview  `json:"peer_reviews"`
+	QuizAttempts []QuizAttempt `json:"quiz_attempts"`
+}
+
+// PersonalIdentity is a single sign-on or directory identity linked to the user
+type PersonalIdentity struct {
+	Issuer    string `json:"issuer"`
+	Subject   string `json:"subject"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// PersonalSession is a login session of the user
+type PersonalSession struct {
+	Device     string `json:"device,omitempty"`
+	IP         string `json:"ip,omitempty"`
+	LastSeenAt int64  `json:"last_seen_at"`
+	ExpiresAt  int64  `json:"expires_at"`
+	RevokedAt  int64  `json:"revoked_at,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+// PrivacyUseCase represent the usecases answering data subject requests
+type PrivacyUseCase interface {
+	ExportUser(ctx context.Context, userID int64) (*PersonalData, error)
+	EraseUser(ctx context.Context, userID int64) error
+}
+
+// PrivacyRepository represent the repository of the personal data of users
+type PrivacyRepository interface {
+	GetEnrollments(ctx context.Context, userID int64) ([]Enrollment, error)
+	GetIdentities(ctx context.Context, userID int64) ([]PersonalIdentity, error)
+	GetSessions(ctx context.Context, userID int64) ([]PersonalSession, error)
+	GetInvitations(ctx context.Context, userID int64, email string) ([]Invitation, error)
+	GetSubmissions(ctx context.Context, userID int64) ([]Submission, error)
+	GetPeerReviews(ctx context.Context, userID int64) ([]PeerReview, error)
+	GetQuizAttempts(ctx context.Context, userID int64) ([]QuizAttempt, error)
+	EraseUser(ctx context.Context, userID int64, email string, erasedAt int64) error
+}
@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"context"
+)
+
+// QuestionType is the kind of answer a question expects
+type QuestionType string
+
+// Question Types
+const (
+	QuestionSingleChoice   QuestionType = "single-choice"
+	QuestionMultipleChoice QuestionType = "multiple-choice"
+	QuestionTrueFalse      QuestionType = "true-false"
+	QuestionShortAnswer    QuestionType = "short-answer"
+	QuestionNumeric        QuestionType = "numeric"
+	QuestionMatching       QuestionType = "matching"
+	QuestionOrdering       QuestionType = "ordering"
+)
+
+// IsValid reports whether t is a known question type
+func (t QuestionType) IsValid() bool {
+	switch t {
+	case QuestionSingleChoice, QuestionMultipleChoice, QuestionTrueFalse, QuestionShortAnswer, QuestionNumeric,
+		QuestionMatching, QuestionOrdering:
+		return true
+	}
+	return false
+}
+
+// Question Difficulties
+const (
+	DifficultyEasy   = 1
+	DifficultyMedium = 2
+	DifficultyHard   = 3
+)
+
+// QuestionOption is a choice, an item to match or an item to order. Its ID is its position, from 1.
+type QuestionOption struct {
+	ID   int    `json:"id"`
+	Text string `json:"text" validate:"required"`
+}
+
+// MatchPair pairs an option of a matching question with one of its matches
+type MatchPair struct {
+	OptionID int `json:"option_id"`
+	MatchID  int `json:"match_id"`
+}
+
+// AnswerKey is the correct answer of a question. Only the fields of the question type are used.
+type AnswerKey struct {
+	// Choices are the correct options of the choice questions
+	Choices []int `json:"choices,omitempty"`
+	// Truth is the answer of the true/false questions
+	Truth *bool `json:"truth,omitempty"`
+	// Texts are the accepted short answers, compared regardless of case and surrounding spaces
+	Texts []string `json:"texts,omitempty"`
+	// Number is the answer of the numeric questions, accepted within Tolerance
+	Number    *float64 `json:"number,omitempty"`
+	Tolerance float64  `json:"tolerance,omitempty"`
+	// Pairs are the correct pairs of the matching questions
+	Pairs []MatchPair `json:"pairs,omitempty"`
+	// Order is the options of the ordering questions in the correct order. It defaults to the order of the options.
+	Order []int `json:"order,omitempty"`
+}
+
+// Question is a question of the question bank of a course
+type Question struct {
+	ID       int64        `json:"id"`
+	CourseID int64        `json:"course_id"`
+	Type     QuestionType `json:"type" validate:"required"`
+	Text     string       `json:"text" validate:"required"`
+	Points   float64      `json:"points" validate:"gt=0"`
+	// Difficulty weights the draw of the question from a pool. It is medium by default.
+	Difficulty int `json:"difficulty" validate:"gte=0,lte=3"`
+	// Options are the choices, the items to match or the items to order
+	Options []QuestionOption `json:"options,omitempty" validate:"dive"`
+	// Matches are the items the options of a matching question are matched with
+	Matches []QuestionOption `json:"matches,omitempty" validate:"dive"`
+	// Key is only shown to the ones working on the course
+	Key         *AnswerKey `json:"key,omitempty"`
+	Explanation string     `json:"explanation,omitempty"`
+	// Tags put the question in the pools the quizzes draw questions from
+	Tags []Tag `json:"tags,omitempty"`
+	// RubricID marks the answers to a short-answer question with a rubric of the organization, instead of the key
+	RubricID  int64 `json:"rubric_id,omitempty"`
+	CreatedBy int64 `json:"created_by,omitempty"`
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+	CreatedAt int64 `json:"created_at,omitempty"`
+}
+
+// QuizPool draws Count questions of the question bank with a tag. The weights of the difficulties make the
+// questions of a difficulty more or less likely to be drawn, and are all equal when left to 0.
+type QuizPool struct {
+	TagID        int64 `json:"tag_id" validate:"required"`
+	Count        int   `json:"count" validate:"gt=0"`
+	EasyWeight   int   `json:"easy_weight" validate:"gte=0"`
+	MediumWeight int   `json:"medium_weight" validate:"gte=0"`
+	HardWeight   int   `json:"hard_weight" validate:"gte=0"`
+}
+
+// Quiz is a set of questions of the question bank, attached to a lesson. Its questions are the given
+// questions followed by the ones drawn from its pools for each attempt.
+type Quiz struct {
+	ID          int64  `json:"id"`
+	LessonID    int64  `json:"lesson_id"`
+	CourseID    int64  `json:"course_id"`
+	Title       string `json:"title" validate:"required,max=255"`
+	Description string `json:"description,omitempty"`
+	// TimeLimit is the time given to submit an attempt, in seconds. 0 means no limit.
+	TimeLimit int `json:"time_limit" validate:"gte=0"`
+	// MaxAttempts is the number of attempts of a learner. 0 means unlimited.
+	MaxAttempts int `json:"max_attempts" validate:"gte=0"`
+	// PassingScore is the percentage of the points needed to pass
+	PassingScore     float64 `json:"passing_score" validate:"gte=0,lte=100"`
+	ShuffleQuestions bool    `json:"shuffle_questions"`
+	ShuffleOptions   bool    `json:"shuffle_options"`
+	// QuestionIDs are the questions of the quiz, in order
+	QuestionIDs []int64    `json:"question_ids"`
+	Pools       []QuizPool `json:"pools" validate:"dive"`
+	// Questions are only returned to the ones working on the course
+	Questions []Question `json:"questions,omitempty"`
+	CreatedBy int64      `json:"created_by,omitempty"`
+	UpdatedAt int64      `json:"updated_at"`
+	CreatedAt int64      `json:"created_at"`
+}
+
+// AttemptStatus is the state of a quiz attempt
+type AttemptStatus string
+
+// Attempt Status
+const (
+	AttemptInProgress AttemptStatus = "in-progress"
+	AttemptSubmitted  AttemptStatus = "submitted"
+	// AttemptExpired is an attempt not submitted within the time limit of the quiz
+	AttemptExpired AttemptStatus = "expired"
+	// AttemptGrading is an attempt submitted with answers left to mark with a rubric
+	AttemptGrading AttemptStatus = "grading"
+)
+
+// QuestionResponse is the answer of a learner to a question, in the fields of the question type
+type QuestionResponse struct {
+	QuestionID int64       `json:"question_id" validate:"required"`
+	Choices    []int       `json:"choices,omitempty"`
+	Truth      *bool       `json:"truth,omitempty"`
+	Text       string      `json:"text,omitempty"`
+	Number     *float64    `json:"number,omitempty"`
+	Pairs      []MatchPair `json:"pairs,omitempty"`
+	Order      []int       `json:"order,omitempty"`
+	// Score and Correct are set when the attempt is graded
+	Score   float64 `json:"score"`
+	Correct bool    `json:"correct"`
+	// Pending is set for the answers left to mark with a rubric, and Scores are the scores of its criteria once marked
+	Pending bool             `json:"pending,omitempty"`
+	Scores  []CriterionScore `json:"scores,omitempty"`
+}
+
+// AnswerGrade is the scores given to an answer with the rubric of its question
+type AnswerGrade struct {
+	Scores []CriterionScore `json:"scores" validate:"min=1,dive"`
+}
+
+// AttemptSubmission is the answers of a learner submitting an attempt
+type AttemptSubmission struct {
+	Answers []QuestionResponse `json:"answers" validate:"dive"`
+}
+
+// QuizAttempt is a learner taking a quiz
+type QuizAttempt struct {
+	ID     int64 `json:"id"`
+	QuizID int64 `json:"quiz_id"`
+	UserID int64 `json:"user_id"`
+	// Number counts the attempts of the learner at the quiz, from 1
+	Number int           `json:"number"`
+	Status AttemptStatus `json:"status"`
+	// Seed draws the questions from the pools of the quiz, and shuffles the questions and their options
+	Seed int64 `json:"-"`
+	// QuestionIDs are the questions of the attempt, kept while the quiz changes
+	QuestionIDs []int64 `json:"question_ids,omitempty"`
+	// Questions are the questions of the attempt as shown to the learner, without their keys
+	Questions  []Question         `json:"questions,omitempty"`
+	Answers    []QuestionResponse `json:"answers,omitempty"`
+	Score      float64            `json:"score"`
+	MaxScore   float64            `json:"max_score"`
+	Percentage float64            `json:"percentage"`
+	Passed     bool               `json:"passed"`
+	StartedAt  int64              `json:"started_at"`
+	// ExpiresAt is set for the quizzes with a time limit
+	ExpiresAt   int64 `json:"expires_at,omitempty"`
+	SubmittedAt int64 `json:"submitted_at,omitempty"`
+}
+
+// QuizUseCase represent the Quiz's usecases
+type QuizUseCase interface {
+	GetQuestions(ctx context.Context, courseID int64, tagID int64, start int, limit int) ([]Question, error)
+	GetQuestion(ctx context.Context, id int64) (*Question, error)
+	CreateQuestion(ctx context.Context, question *Question) error
+	UpdateQuestion(ctx context.Context, question *Question, id int64) error
+	DeleteQuestion(ctx context.Context, id int64) error
+	GetByLesson(ctx context.Context, lessonID int64) ([]Quiz, error)
+	GetByID(ctx context.Context, id int64) (*Quiz, error)
+	CreateQuiz(ctx context.Context, quiz *Quiz) error
+	UpdateQuiz(ctx context.Context, quiz *Quiz, id int64) error
+	DeleteQuiz(ctx context.Context, id int64) error
+	StartAttempt(ctx context.Context, quizID int64) (*QuizAttempt, error)
+	SubmitAttempt(ctx context.Context, attemptID int64, submission *AttemptSubmission) (*QuizAttempt, error)
+	GetAttempt(ctx context.Context, id int64) (*QuizAttempt, error)
+	GetAttempts(ctx context.Context, quizID int64) ([]QuizAttempt, error)
+	GetResults(ctx context.Context, quizID int64, start int, limit int) ([]QuizAttempt, error)
+	GradeAnswer(ctx context.Context, attemptID int64, questionID int64, grade *AnswerGrade) (*QuizAttempt, error)
+}
+
+// QuizRepository represent the Quiz's repository
+type QuizRepository interface {
+	GetQuestions(ctx context.Context, courseID int64, tagID int64, start int, limit int) ([]Question, error)
+	GetQuestion(ctx context.Context, id int64) (*Question, error)
+	GetPoolQuestions(ctx context.Context, courseID int64, tagID int64) ([]Question, error)
+	GetQuestionsByIDs(ctx context.Context, courseID int64, ids []int64) ([]Question, error)
+	CreateQuestion(ctx context.Context, question *Question) error
+	UpdateQuestion(ctx context.Context, question *Question) error
+	DeleteQuestion(ctx context.Context, id int64) error
+	GetByLesson(ctx context.Context, lessonID int64) ([]Quiz, error)
+	GetByID(ctx context.Context, id int64) (*Quiz, error)
+	GetQuizQuestions(ctx context.Context, quizID int64) ([]Question, error)
+	CreateQuiz(ctx context.Context, quiz *Quiz) error
+	UpdateQuiz(ctx context.Context, quiz *Quiz) error
+	DeleteQuiz(ctx context.Context, id int64) error
+	CreateAttempt(ctx context.Context, attempt *QuizAttempt) error
+	GetAttempt(ctx context.Context, id int64) (*QuizAttempt, error)
+	GetAttempts(ctx context.Context, quizID int64, userID int64) ([]QuizAttempt, error)
+	GetResults(ctx context.Context, quizID int64, start int, limit int) ([]QuizAttempt, error)
+	FinishAttempt(ctx context.Context, attempt *QuizAttempt) error
+	GradeAttempt(ctx context.Context, attempt *QuizAttempt) error
+}
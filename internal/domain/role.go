@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+)
+
+// Permission is an action a role is allowed to perform
+type Permission string
+
+// Permissions
+const (
+	PermCourseView       Permission = "course:view"
+	PermCourseCreate     Permission = "course:create"
+	PermCourseUpdate     Permission = "course:update"
+	PermCourseDelete     Permission = "course:delete"
+	PermCoursePublish    Permission = "course:publish"
+	PermContentUpload    Permission = "content:upload"
+	PermCategoryManage   Permission = "category:manage"
+	PermEnrollmentManage Permission = "enrollment:manage"
+	PermUserManage       Permission = "user:manage"
+	PermUserInvite       Permission = "user:invite"
+	PermRoleManage       Permission = "role:manage"
+	PermReportView       Permission = "report:view"
+	PermSSOManage        Permission = "sso:manage"
+	PermAPIKeyManage     Permission = "apikey:manage"
+	// PermCourseManage allows working on every course of the organization, and not only on the courses the user
+	// authored or collaborates on
+	PermCourseManage Permission = "course:manage"
+	// PermOrganizationManage allows managing every organization. It is only granted to the superadmin role.
+	PermOrganizationManage Permission = "organization:manage"
+)
+
+// Permissions lists every permission a role can be granted
+var Permissions = []Permission{
+	PermCourseView,
+	PermCourseCreate,
+	PermCourseUpdate,
+	PermCourseDelete,
+	PermCoursePublish,
+	PermCourseManage,
+	PermContentUpload,
+	PermCategoryManage,
+	PermEnrollmentManage,
+	PermUserManage,
+	PermUserInvite,
+	PermRoleManage,
+	PermReportView,
+	PermSSOManage,
+	PermAPIKeyManage,
+	PermOrganizationManage,
+}
+
+// IsValid reports whether p is a known permission
+func (p Permission) IsValid() bool {
+	for _, known := range Permissions {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Default role codes. They are created by the migrations, shared by every organization and can not be changed.
+const (
+	RoleSuperAdmin = "superadmin"
+	RoleAdmin      = "admin"
+	RoleInstructor = "instructor"
+	RoleLearner    = "learner"
+)
+
+// Role ...
+type Role struct {
+	ID          int64  `json:"id"`
+	Code        string `json:"code" validate:"required,max=50"`
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description,omitempty"`
+	// OrganizationID is 0 for the default roles shared by every organization
+	OrganizationID int64        `json:"organization_id,omitempty"`
+	Permissions    []Permission `json:"permissions"`
+	CreatedBy      int64        `json:"created_by,omitempty"`
+	UpdatedAt      int64        `json:"updated_at,omitempty"`
+	CreatedAt      int64        `json:"created_at,omitempty"`
+}
+
+// RoleUseCase represent the Role's usecases
+type RoleUseCase interface {
+	GetAll(ctx context.Context, start int, limit int) ([]Role, error)
+	GetByID(ctx context.Context, id int64) (*Role, error)
+	CreateRole(ctx context.Context, role *Role) error
+	UpdateRole(ctx context.Context, role *Role, id int64) error
+	DeleteRole(ctx context.Context, id int64) error
+}
+
+// RoleRepository represent the Role's repository
+type RoleRepository interface {
+	GetAll(ctx context.Context, start int, limit int) ([]Role, error)
+	GetByID(ctx context.Context, id int64) (*Role, error)
+	GetByCode(ctx context.Context, code string) (*Role, error)
+	CreateRole(ctx context.Context, role *Role) error
+	UpdateRole(ctx context.Context, role *Role) error
+	DeleteRole(ctx context.Context, id int64) error
+	GetUserCount(ctx context.Context, roleID int64) (int64, error)
+	GetUserPermissions(ctx context.Context, userID int64) ([]Permission, error)
+}
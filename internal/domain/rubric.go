@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+)
+
+// RubricLevel is a level of performance of a rubric criterion. Its ID is its position, from 1.
+type RubricLevel struct {
+	ID          int     `json:"id"`
+	Title       string  `json:"title" validate:"required"`
+	Description string  `json:"description,omitempty"`
+	Points      float64 `json:"points" validate:"gte=0"`
+}
+
+// RubricCriterion is a part of the work marked with a rubric. Its ID is its position, from 1.
+type RubricCriterion struct {
+	ID          int           `json:"id"`
+	Title       string        `json:"title" validate:"required"`
+	Description string        `json:"description,omitempty"`
+	Levels      []RubricLevel `json:"levels" validate:"min=1,dive"`
+}
+
+// Rubric is a marking scheme of an organization, reused by the assignments and the short-answer questions of its courses
+type Rubric struct {
+	ID          int64             `json:"id"`
+	Title       string            `json:"title" validate:"required,max=255"`
+	Description string            `json:"description,omitempty"`
+	Criteria    []RubricCriterion `json:"criteria" validate:"min=1,dive"`
+	// MaxPoints is the total of the best levels of the criteria
+	MaxPoints float64 `json:"max_points"`
+	CreatedBy int64   `json:"created_by,omitempty"`
+	UpdatedAt int64   `json:"updated_at"`
+	CreatedAt int64   `json:"created_at"`
+}
+
+// RubricWork is a work marked with a rubric: a submission to an assignment, or the answer to a question of a quiz attempt
+type RubricWork struct {
+	RubricID     int64
+	CourseID     int64
+	SubmissionID int64
+	AttemptID    int64
+	QuestionID   int64
+	GradedBy     int64
+	GradedAt     int64
+}
+
+// LevelCount is the number of times a level of a criterion was given
+type LevelCount struct {
+	LevelID int    `json:"level_id"`
+	Title   string `json:"title"`
+	Count   int64  `json:"count"`
+}
+
+// CriterionStatistics are the statistics of the scores given for a criterion of a rubric
+type CriterionStatistics struct {
+	CriterionID int          `json:"criterion_id"`
+	Title       string       `json:"title"`
+	MaxPoints   float64      `json:"max_points"`
+	Count       int64        `json:"count"`
+	Mean        float64      `json:"mean"`
+	StdDev      float64      `json:"std_dev"`
+	Min         float64      `json:"min"`
+	Max         float64      `json:"max"`
+	Levels      []LevelCount `json:"levels"`
+}
+
+// RubricStatistics are the statistics of the works of a course marked with a rubric, in total and for each criterion
+type RubricStatistics struct {
+	RubricID  int64                 `json:"rubric_id"`
+	CourseID  int64                 `json:"course_id"`
+	MaxPoints float64               `json:"max_points"`
+	Count     int64                 `json:"count"`
+	Mean      float64               `json:"mean"`
+	StdDev    float64               `json:"std_dev"`
+	Min       float64               `json:"min"`
+	Max       float64               `json:"max"`
+	Criteria  []CriterionStatistics `json:"criteria"`
+}
+
+// RubricUseCase represent the Rubric's usecases
+type RubricUseCase interface {
+	GetAll(ctx context.Context, start int, limit int) ([]Rubric, error)
+	GetByID(ctx context.Context, id int64) (*Rubric, error)
+	CreateRubric(ctx context.Context, rubric *Rubric) error
+	UpdateRubric(ctx context.Context, rubric *Rubric, id int64) error
+	DeleteRubric(ctx context.Context, id int64) error
+	// Apply scores every criterion of a rubric with the points of the given level, and returns the total and the best total
+	Apply(ctx context.Context, rubricID int64, scores []CriterionScore) (total float64, maxPoints float64, err error)
+	// Record keeps the scores given to a work, replacing the ones given before
+	Record(ctx context.Context, work *RubricWork, scores []CriterionScore) error
+	GetStatistics(ctx context.Context, rubricID int64, courseID int64) (*RubricStatistics, error)
+}
+
+// RubricRepository represent the Rubric's repository
+type RubricRepository interface {
+	GetAll(ctx context.Context, start int, limit int) ([]Rubric, error)
+	GetByID(ctx context.Context, id int64) (*Rubric, error)
+	CreateRubric(ctx context.Context, rubric *Rubric) error
+	UpdateRubric(ctx context.Context, rubric *Rubric) error
+	DeleteRubric(ctx context.Context, id int64) error
+	IsUsed(ctx context.Context, id int64) (bool, error)
+	Record(ctx context.Context, work *RubricWork, scores []CriterionScore) error
+	GetStatistics(ctx context.Context, rubricID int64, courseID int64) (*RubricStatistics, error)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+)
+
+// Client is the IP address and the user agent a request comes from
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// Session is a login of a user on a device. It lasts as long as its refresh tokens, and revoking it
+// rejects its access tokens right away.
+type Session struct {
+	ID             int64  `json:"id"`
+	UserID         int64  `json:"user_id"`
+	OrganizationID int64  `json:"organization_id"`
+	Device         string `json:"device,omitempty"`
+	IP             string `json:"ip,omitempty"`
+	LastSeenAt     int64  `json:"last_seen_at"`
+	ExpiresAt      int64  `json:"expires_at"`
+	RevokedAt      int64  `json:"revoked_at,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+	// Current is set on the session of the caller
+	Current bool `json:"current"`
+}
+
+// Presence counts the users seen lately
+type Presence struct {
+	ActiveLearners int64 `json:"active_learners"`
+	// Since is when the users counted were last seen at the earliest
+	Since int64 `json:"since"`
+}
+
+// SessionUseCase represent the Session's usecases
+type SessionUseCase interface {
+	GetByUser(ctx context.Context, userID int64) ([]Session, error)
+	RevokeSession(ctx context.Context, userID int64, id int64) error
+	Touch(ctx context.Context, userID int64, sessionID int64)
+	Flush(ctx context.Context) error
+	GetPresence(ctx context.Context) (*Presence, error)
+}
+
+// SessionRepository represent the Session's repository
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session *Session) error
+	GetByID(ctx context.Context, id int64) (*Session, error)
+	GetByUser(ctx context.Context, userID int64, now int64) ([]Session, error)
+	ExtendSession(ctx context.Context, id int64, expiresAt int64) error
+	RevokeSession(ctx context.Context, id int64, revokedAt int64) error
+	UpdateLastSeen(ctx context.Context, seen map[int64]int64) error
+	UpdateLastOnline(ctx context.Context, seen map[int64]int64) error
+	CountOnline(ctx context.Context, roleCode string, since int64) (int64, error)
+}
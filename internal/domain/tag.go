@@ -26,6 +26,10 @@ type TagUseCase interface {
 	CreateLessonTag(ctx context.Context, tagID int64, lessonID int64) error
 	DeleteLessonTag(ctx context.Context, tagID int64, lessonID int64) error
 	GetLessonTags(ctx context.Context, lessonID int64) ([]Tag, error)
+	CreateQuestionTag(ctx context.Context, tagID int64, questionID int64) error
+	DeleteQuestionTag(ctx context.Context, tagID int64, questionID int64) error
+	GetQuestionTags(ctx context.Context, questionID int64) ([]Tag, error)
+	BulkAction(ctx context.Context, action *BulkAction) ([]BulkResult, error)
 }
 
 // TagRepository represent the Tag's repository
@@ -42,4 +46,8 @@ type TagRepository interface {
 	CreateLessonTag(ctx context.Context, tagID int64, lessonID int64) error
 	DeleteLessonTag(ctx context.Context, tagID int64, lessonID int64) error
 	GetLessonTags(ctx context.Context, lessonID int64) ([]Tag, error)
+	CreateQuestionTag(ctx context.Context, tagID int64, questionID int64) error
+	DeleteQuestionTag(ctx context.Context, tagID int64, questionID int64) error
+	GetQuestionTags(ctx context.Context, questionID int64) ([]Tag, error)
+	BulkDelete(ctx context.Context, ids []int64) ([]BulkResult, error)
 }
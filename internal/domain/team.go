@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+)
+
+// Team groups users of an organization, to manage and enroll them together
+type Team struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name" validate:"required,max=150"`
+	Description string `json:"description,omitempty" validate:"max=255"`
+	ImageURL    string `json:"image_url,omitempty" validate:"max=255"`
+	// RoleID is the role of the team, learner by default
+	RoleID         int64 `json:"role_id"`
+	OrganizationID int64 `json:"organization_id"`
+	// LDAPGroupDN is set for the teams synced from a directory group. Their members follow the group.
+	LDAPGroupDN string `json:"ldap_group_dn,omitempty"`
+	Members     int64  `json:"members"`
+	UpdatedAt   int64  `json:"updated_at"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+// TeamEnrollment enrolls a team in a course
+type TeamEnrollment struct {
+	CourseID  int64 `json:"course_id"`
+	TeamID    int64 `json:"team_id" validate:"required"`
+	Status    int   `json:"status"`
+	CreatedAt int64 `json:"created_at"`
+}
+
+// TeamUseCase represent the Team's usecases
+type TeamUseCase interface {
+	GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]Team, error)
+	GetByID(ctx context.Context, id int64) (*Team, error)
+	GetByUser(ctx context.Context, userID int64) ([]Team, error)
+	CreateTeam(ctx context.Context, team *Team) error
+	UpdateTeam(ctx context.Context, team *Team, id int64) error
+	DeleteTeam(ctx context.Context, id int64) error
+	GetMembers(ctx context.Context, teamID int64, start int, limit int) ([]User, error)
+	AddMember(ctx context.Context, teamID int64, userID int64) error
+	RemoveMember(ctx context.Context, teamID int64, userID int64) error
+	GetByCourse(ctx context.Context, courseID int64) ([]Team, error)
+	EnrollTeam(ctx context.Context, enrollment *TeamEnrollment) error
+	UnenrollTeam(ctx context.Context, courseID int64, teamID int64) error
+}
+
+// TeamRepository represent the Team's repository
+type TeamRepository interface {
+	GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]Team, error)
+	GetByID(ctx context.Context, id int64) (*Team, error)
+	GetByName(ctx context.Context, name string) (*Team, error)
+	GetByUser(ctx context.Context, userID int64) ([]Team, error)
+	CreateTeam(ctx context.Context, team *Team) error
+	UpdateTeam(ctx context.Context, team *Team) error
+	DeleteTeam(ctx context.Context, id int64) error
+	GetMembers(ctx context.Context, teamID int64, start int, limit int) ([]User, error)
+	GetMemberIDs(ctx context.Context, teamID int64) ([]int64, error)
+	AddMember(ctx context.Context, teamID int64, userID int64, createdAt int64) error
+	RemoveMember(ctx context.Context, teamID int64, userID int64) error
+	GetByCourse(ctx context.Context, courseID int64) ([]Team, error)
+	GetCourseIDs(ctx context.Context, teamID int64) ([]int64, error)
+	EnrollTeam(ctx context.Context, enrollment *TeamEnrollment) error
+	UnenrollTeam(ctx context.Context, courseID int64, teamID int64) error
+}
@@ -0,0 +1,10 @@
+package domain
+
+import (
+	"context"
+)
+
+// TrashPurger represent the usecases that keep deleted items in a trash until they are purged
+type TrashPurger interface {
+	PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error)
+}
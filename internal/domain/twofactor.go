@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+)
+
+// Login challenges, asking for a second step after the password
+const (
+	// ChallengeTOTP asks for a code of the authenticator app or a recovery code
+	ChallengeTOTP = "totp"
+	// ChallengeTOTPEnrollment asks to enroll an authenticator app first, as the organization requires one
+	ChallengeTOTPEnrollment = "totp_enrollment"
+)
+
+// TwoFactor is the TOTP authenticator of a user. It is pending until a first code confirms it.
+type TwoFactor struct {
+	UserID         int64
+	OrganizationID int64
+	Secret         string
+	EnabledAt      int64
+	// LastStep is the time step of the last code used; codes of that step or before are refused
+	LastStep  int64
+	UpdatedAt int64
+	CreatedAt int64
+}
+
+// TwoFactorStatus tells whether two-factor authentication is enabled for the user
+type TwoFactorStatus struct {
+	Enabled           bool  `json:"enabled"`
+	EnabledAt         int64 `json:"enabled_at,omitempty"`
+	Required          bool  `json:"required"`
+	RecoveryCodesLeft int   `json:"recovery_codes_left"`
+}
+
+// TwoFactorEnrollment is the secret of a new authenticator. Authenticator apps read the URI from a QR code.
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// TwoFactorCode is the request body carrying a code of the authenticator app or a recovery code
+type TwoFactorCode struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// TwoFactorChallenge is the request body carrying the challenge token of a login
+type TwoFactorChallenge struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+}
+
+// TwoFactorLogin is the request body of the second login step
+type TwoFactorLogin struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+// RecoveryCodes are shown once when two-factor authentication is enabled. Each can replace a code of the
+// authenticator app once. Tokens is set when enabling completed a login.
+type RecoveryCodes struct {
+	Codes  []string   `json:"recovery_codes"`
+	Tokens *TokenPair `json:"tokens,omitempty"`
+}
+
+// TwoFactorPolicy tells whether the organization requires two-factor authentication of every user
+type TwoFactorPolicy struct {
+	Required bool `json:"required"`
+}
+
+// TwoFactorUseCase represent the two-factor authentication usecases
+type TwoFactorUseCase interface {
+	GetStatus(ctx context.Context, userID int64) (*TwoFactorStatus, error)
+	Enroll(ctx context.Context, userID int64) (*TwoFactorEnrollment, error)
+	Enable(ctx context.Context, userID int64, code string) (*RecoveryCodes, error)
+	Disable(ctx context.Context, userID int64, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) (*RecoveryCodes, error)
+	Reset(ctx context.Context, userID int64) error
+	Login(ctx context.Context, login *TwoFactorLogin) (*TokenPair, error)
+	EnrollChallenge(ctx context.Context, challengeToken string) (*TwoFactorEnrollment, error)
+	EnableChallenge(ctx context.Context, login *TwoFactorLogin) (*RecoveryCodes, error)
+	GetPolicy(ctx context.Context) (*TwoFactorPolicy, error)
+	SetPolicy(ctx context.Context, policy *TwoFactorPolicy) error
+}
+
+// TwoFactorRepository represent the two-factor authentication repository. Recovery codes are stored hashed.
+type TwoFactorRepository interface {
+	Get(ctx context.Context, userID int64) (*TwoFactor, error)
+	Save(ctx context.Context, twoFactor *TwoFactor) error
+	Enable(ctx context.Context, userID int64, step int64, enabledAt int64, codeHashes []string) error
+	UseStep(ctx context.Context, userID int64, step int64) error
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string, usedAt int64) error
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string, createdAt int64) error
+	CountRecoveryCodes(ctx context.Context, userID int64) (int, error)
+	Delete(ctx context.Context, userID int64) error
+}
@@ -4,15 +4,60 @@ import (
 	"context"
 )
 
+// User Status
+const (
+	UserInactive = 0
+	UserActive   = 1
+	// UserErased is the status of a user whose personal data was erased. The user only remains for the statistics.
+	UserErased = 2
+)
+
 // User ...
 type User struct {
-	ID        int64  `json:"id,omitempty"`
-	Name      string `json:"name,omitempty"`
-	CreatedAt int64  `json:"created_at,omitempty"`
-	UpdatedAt int64  `json:"updated_at,omitempty"`
+	ID              int64  `json:"id,omitempty"`
+	FirstName       string `json:"first_name,omitempty"`
+	LastName        string `json:"last_name,omitempty" validate:"required"`
+	Email           string `json:"email,omitempty" validate:"required,email"`
+	Username        string `json:"username,omitempty"`
+	Password        string `json:"password,omitempty" validate:"omitempty,min=8"`
+	Phone           string `json:"phone,omitempty"`
+	OrganizationID  int64  `json:"organization_id,omitempty"`
+	RoleID          int64  `json:"role_id,omitempty" validate:"required"`
+	CountryID       int64  `json:"country_id,omitempty"`
+	Address1        string `json:"address1,omitempty"`
+	Address2        string `json:"address2,omitempty"`
+	ProfileURL      string `json:"profile_url,omitempty"`
+	Status          int    `json:"status"`
+	JoinedDate      int64  `json:"joined_date,omitempty"`
+	LastOnline      int64  `json:"last_online,omitempty"`
+	EmailVerifiedAt int64  `json:"email_verified_at,omitempty"`
+	FailedLogins    int    `json:"-"`
+	LockedUntil     int64  `json:"locked_until,omitempty"`
+	UpdatedAt       int64  `json:"updated_at,omitempty"`
+	CreatedAt       int64  `json:"created_at,omitempty"`
+}
+
+// UserUseCase represent the User's usecases
+type UserUseCase interface {
+	GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]User, error)
+	GetByID(ctx context.Context, id int64) (*User, error)
+	CreateUser(ctx context.Context, user *User) error
+	UpdateUser(ctx context.Context, user *User, id int64) error
+	DeactivateUser(ctx context.Context, id int64) error
 }
 
 // UserRepository represent the User's repository contract
 type UserRepository interface {
-	GetByID(ctx context.Context, id int64) (User, error)
+	GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]User, error)
+	GetByID(ctx context.Context, id int64) (*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	GetByUsername(ctx context.Context, username string) (*User, error)
+	CreateUser(ctx context.Context, user *User) error
+	UpdateUser(ctx context.Context, user *User) error
+	UpdateStatus(ctx context.Context, id int64, status int, updatedAt int64) error
+	GetPassword(ctx context.Context, id int64) (string, error)
+	UpdatePassword(ctx context.Context, id int64, hash string, updatedAt int64) error
+	MarkEmailVerified(ctx context.Context, id int64, email string, verifiedAt int64) error
+	RecordLoginFailure(ctx context.Context, id int64, maxFailures int, lockedUntil int64) error
+	ResetLoginFailures(ctx context.Context, id int64) error
 }
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+// User import Status
+const (
+	ImportPending   = 1
+	ImportRunning   = 2
+	ImportCompleted = 3
+)
+
+// ImportRow is a user to create, read from a row of an import file, with its country, role and teams
+// resolved to IDs
+type ImportRow struct {
+	Line      int     `json:"line"`
+	Email     string  `json:"email"`
+	FirstName string  `json:"first_name,omitempty"`
+	LastName  string  `json:"last_name"`
+	Username  string  `json:"username,omitempty"`
+	Phone     string  `json:"phone,omitempty"`
+	CountryID int64   `json:"country_id,omitempty"`
+	RoleID    int64   `json:"role_id"`
+	TeamIDs   []int64 `json:"team_ids,omitempty"`
+	CourseIDs []int64 `json:"course_ids,omitempty"`
+}
+
+// ImportError is why a row of an import file was not imported, or only partly
+type ImportError struct {
+	Line  int    `json:"line"`
+	Email string `json:"email,omitempty"`
+	Error string `json:"error"`
+}
+
+// UserImport is the background job creating the users of an import file
+type UserImport struct {
+	ID             int64         `json:"id"`
+	OrganizationID int64         `json:"organization_id"`
+	FileName       string        `json:"file_name"`
+	Status         int           `json:"status"`
+	Total          int           `json:"total"`
+	Processed      int           `json:"processed"`
+	Created        int           `json:"created"`
+	Failed         int           `json:"failed"`
+	Errors         []ImportError `json:"errors"`
+	Rows           []ImportRow   `json:"-"`
+	// Permissions are those of the caller who started the import; the users are created on their behalf
+	Permissions []Permission `json:"-"`
+	CreatedBy   int64        `json:"created_by,omitempty"`
+	StartedAt   int64        `json:"started_at,omitempty"`
+	FinishedAt  int64        `json:"finished_at,omitempty"`
+	UpdatedAt   int64        `json:"updated_at"`
+	CreatedAt   int64        `json:"created_at"`
+}
+
+// ImportReport is the outcome of validating an import file. Import is the queued job, unless the file was
+// only validated or has errors.
+type ImportReport struct {
+	Total  int           `json:"total"`
+	Valid  int           `json:"valid"`
+	Errors []ImportError `json:"errors"`
+	Import *UserImport   `json:"import,omitempty"`
+}
+
+// UserImportUseCase represent the user import's usecases
+type UserImportUseCase interface {
+	GetAll(ctx context.Context, start int, limit int) ([]UserImport, error)
+	GetByID(ctx context.Context, id int64) (*UserImport, error)
+	ImportUsers(ctx context.Context, fileName string, file io.Reader, dryRun bool) (*ImportReport, error)
+	RunPending(ctx context.Context) error
+}
+
+// UserImportRepository represent the user import's repository contract
+type UserImportRepository interface {
+	GetAll(ctx context.Context, start int, limit int) ([]UserImport, error)
+	GetByID(ctx context.Context, id int64) (*UserImport, error)
+	CreateImport(ctx context.Context, userImport *UserImport) error
+	ClaimPending(ctx context.Context, staleBefore int64, startedAt int64) (*UserImport, error)
+	UpdateProgress(ctx context.Context, userImport *UserImport) error
+	GetExistingEmails(ctx context.Context, emails []string) (map[string]bool, error)
+	GetExistingUsernames(ctx context.Context, usernames []string) (map[string]bool, error)
+	GetCountryIDs(ctx context.Context) (map[string]int64, error)
+	GetTeamIDs(ctx context.Context) (map[string]int64, error)
+	AddTeamMembers(ctx context.Context, userID int64, teamIDs []int64, createdAt int64) error
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// EnrollmentHandler ...
+type EnrollmentHandler struct {
+	EnrollmentUseCase domain.EnrollmentUseCase
+}
+
+// NewEnrollmentHandler ...
+func NewEnrollmentHandler(e *echo.Echo, us domain.EnrollmentUseCase) {
+	handler := &EnrollmentHandler{
+		EnrollmentUseCase: us,
+	}
+	// Get Operation
+	e.GET("/courses/:id/users", handler.GetByCourse, rbac.Require(domain.PermReportView))
+	e.GET("/courses/:id/users/:user_id", handler.GetEnrollment, rbac.Require(domain.PermReportView))
+
+	// Create/Add Operation
+	e.POST("/courses/:id/users", handler.EnrollUser, rbac.Require(domain.PermEnrollmentManage))
+}
+
+// GetByCourse godoc
+// @Summary Get enrollments of a Course.
+// @Description Get learners enrolled in a course with the version they took.
+// @Tags enrollments
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/users [get]
+func (c *EnrollmentHandler) GetByCourse(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	start, limit := 0, 10
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		}
+	}
+
+	list, err := c.EnrollmentUseCase.GetByCourse(ctx, int64(idParam), start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetEnrollment godoc
+// @Summary Get a learner's enrollment.
+// @Description Get the enrollment of a learner including the course version snapshot they took.
+// @Tags enrollments
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param user_id path int true "User Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/users/{user_id} [get]
+func (c *EnrollmentHandler) GetEnrollment(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	userID, err := strconv.Atoi(echoContext.Param("user_id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	enrollment, err := c.EnrollmentUseCase.GetEnrollment(ctx, int64(idParam), int64(userID))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    enrollment,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// EnrollUser godoc
+// @Summary Enroll a learner into a Course
+// @Description Enroll a learner into the latest published version of a course
+// @Tags enrollments
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param enrollment body domain.Enrollment true "Enrollment Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Course is not published yet"
+// @Failure 409 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/users [post]
+func (c *EnrollmentHandler) EnrollUser(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var enrollment domain.Enrollment
+	err = echoContext.Bind(&enrollment)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&enrollment); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	enrollment.CourseID = int64(idParam)
+	ctx := echoContext.Request().Context()
+	err = c.EnrollmentUseCase.EnrollUser(ctx, &enrollment)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    enrollment,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusCreated, res)
+}
@@ -0,0 +1,118 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	enrollmentHTTP "github.com/meroedu/meroedu/internal/enrollment/delivery/http"
+)
+
+func TestGetByCourse(t *testing.T) {
+	mockUCase := new(mocks.EnrollmentUseCase)
+	mockUCase.On("GetByCourse", mock.Anything, int64(12), 0, 10).Return([]domain.Enrollment{{ID: 7, CourseID: 12, UserID: 3}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/courses/12/users?start=0&limit=10", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/users")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := enrollmentHTTP.EnrollmentHandler{
+		EnrollmentUseCase: mockUCase,
+	}
+	err = handler.GetByCourse(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetEnrollment(t *testing.T) {
+	mockUCase := new(mocks.EnrollmentUseCase)
+	mockUCase.On("GetEnrollment", mock.Anything, int64(12), int64(3)).Return(&domain.Enrollment{ID: 7, CourseID: 12, UserID: 3}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/courses/12/users/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/users/:user_id")
+	c.SetParamNames("id", "user_id")
+	c.SetParamValues("12", "3")
+	handler := enrollmentHTTP.EnrollmentHandler{
+		EnrollmentUseCase: mockUCase,
+	}
+	err = handler.GetEnrollment(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestEnrollUser(t *testing.T) {
+	mockUCase := new(mocks.EnrollmentUseCase)
+	j, err := json.Marshal(domain.Enrollment{UserID: 3})
+	assert.NoError(t, err)
+	mockUCase.On("EnrollUser", mock.Anything, mock.MatchedBy(func(e *domain.Enrollment) bool {
+		return e.CourseID == 12 && e.UserID == 3
+	})).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/courses/12/users", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/users")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := enrollmentHTTP.EnrollmentHandler{
+		EnrollmentUseCase: mockUCase,
+	}
+	err = handler.EnrollUser(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestEnrollUserNotPublished(t *testing.T) {
+	mockUCase := new(mocks.EnrollmentUseCase)
+	j, err := json.Marshal(domain.Enrollment{UserID: 3})
+	assert.NoError(t, err)
+	mockUCase.On("EnrollUser", mock.Anything, mock.AnythingOfType("*domain.Enrollment")).Return(domain.ErrCourseNotPublished)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/courses/12/users", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/users")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := enrollmentHTTP.EnrollmentHandler{
+		EnrollmentUseCase: mockUCase,
+	}
+	err = handler.EnrollUser(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,111 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the enrollment's Repository interface
+func Init(db *sql.DB) domain.EnrollmentRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Enrollment, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Enrollment, 0)
+	for rows.Next() {
+		t := domain.Enrollment{}
+		versionID, version, status := sql.NullInt64{}, sql.NullInt64{}, sql.NullInt64{}
+		err = rows.Scan(
+			&t.ID,
+			&t.CourseID,
+			&t.UserID,
+			&versionID,
+			&version,
+			&status,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.CourseVersionID = versionID.Int64
+		t.Version = int(version.Int64)
+		t.Status = int(status.Int64)
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// CreateEnrollment enrolls the user in a course of the caller's organization.
+func (m *mysqlRepository) CreateEnrollment(ctx context.Context, e *domain.Enrollment) (err error) {
+	query := `INSERT INTO courses_users_enrollments (course_id,userID,course_version_id,status,created_at)
+		SELECT id,?,?,?,? FROM courses WHERE id = ? AND organization_id = ?`
+	stmt, err := m.conn.PrepareContext(ctx, query)
+	if err != nil {
+		log.Error("Error while preparing statement ", err)
+		return
+	}
+	res, err := stmt.ExecContext(ctx, e.UserID, e.CourseVersionID, e.Status, e.CreatedAt, e.CourseID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return
+	}
+	e.ID = lastID
+	return
+}
+
+func (m *mysqlRepository) GetByCourse(ctx context.Context, courseID int64, start int, limit int) ([]domain.Enrollment, error) {
+	query := `SELECT e.id,e.course_id,e.userID,e.course_version_id,v.version,e.status,e.created_at FROM courses_users_enrollments e
+		JOIN courses c ON c.id = e.course_id LEFT JOIN course_versions v ON v.id = e.course_version_id
+		WHERE e.course_id = ? AND c.organization_id = ? ORDER BY e.created_at DESC LIMIT ?,?`
+	return m.fetch(ctx, query, courseID, domain.OrganizationIDFromContext(ctx), start, limit)
+}
+
+func (m *mysqlRepository) GetEnrollment(ctx context.Context, courseID int64, userID int64) (*domain.Enrollment, error) {
+	query := `SELECT e.id,e.course_id,e.userID,e.course_version_id,v.version,e.status,e.created_at FROM courses_users_enrollments e
+		JOIN courses c ON c.id = e.course_id LEFT JOIN course_versions v ON v.id = e.course_version_id
+		WHERE e.course_id = ? AND e.userID = ? AND c.organization_id = ?`
+	list, err := m.fetch(ctx, query, courseID, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
@@ -0,0 +1,75 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/enrollment/repository/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 1)
+
+func TestCreateEnrollment(t *testing.T) {
+	e := &domain.Enrollment{
+		CourseID:        12,
+		UserID:          3,
+		CourseVersionID: 5,
+		Status:          domain.EnrollmentActive,
+		CreatedAt:       time.Now().Unix(),
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	query := `INSERT INTO courses_users_enrollments \(course_id,userID,course_version_id,status,created_at\)
+		SELECT id,\?,\?,\?,\? FROM courses WHERE id = \? AND organization_id = \?`
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(e.UserID, e.CourseVersionID, e.Status, e.CreatedAt, e.CourseID, 1).WillReturnResult(sqlmock.NewResult(7, 1))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.CreateEnrollment(orgCtx, e)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), e.ID)
+}
+
+func TestGetByCourse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "course_id", "userID", "course_version_id", "version", "status", "created_at"}).
+		AddRow(7, 12, 3, 5, 2, domain.EnrollmentActive, time.Now().Unix()).
+		AddRow(6, 12, 4, nil, nil, nil, time.Now().Unix())
+
+	query := `SELECT e.id,e.course_id,e.userID,e.course_version_id,v.version,e.status,e.created_at FROM courses_users_enrollments e
+		JOIN courses c ON c.id = e.course_id LEFT JOIN course_versions v ON v.id = e.course_version_id
+		WHERE e.course_id = \? AND c.organization_id = \? ORDER BY e.created_at DESC LIMIT \?,\?`
+	mock.ExpectQuery(query).WithArgs(12, 1, 0, 10).WillReturnRows(rows)
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetByCourse(orgCtx, 12, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, 2, list[0].Version)
+	assert.Equal(t, int64(0), list[1].CourseVersionID)
+}
+
+func TestGetEnrollment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "course_id", "userID", "course_version_id", "version", "status", "created_at"})
+
+	query := `SELECT e.id,e.course_id,e.userID,e.course_version_id,v.version,e.status,e.created_at FROM courses_users_enrollments e
+		JOIN courses c ON c.id = e.course_id LEFT JOIN course_versions v ON v.id = e.course_version_id
+		WHERE e.course_id = \? AND e.userID = \? AND c.organization_id = \?`
+	mock.ExpectQuery(query).WithArgs(12, 3, 1).WillReturnRows(rows)
+	repo := mysqlrepo.Init(db)
+	enrollment, err := repo.GetEnrollment(orgCtx, 12, 3)
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.Nil(t, enrollment)
+}
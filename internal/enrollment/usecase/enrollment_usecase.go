@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// EnrollmentUseCase ...
+type EnrollmentUseCase struct {
+	enrollmentRepo domain.EnrollmentRepository
+	courseRepo     domain.CourseRepository
+	contextTimeOut time.Duration
+}
+
+// NewEnrollmentUseCase will create new an
+func NewEnrollmentUseCase(e domain.EnrollmentRepository, c domain.CourseRepository, timeout time.Duration) domain.EnrollmentUseCase {
+	return &EnrollmentUseCase{
+		enrollmentRepo: e,
+		courseRepo:     c,
+		contextTimeOut: timeout,
+	}
+}
+
+// EnrollUser will enroll a learner into the latest published version of a course
+func (usecase *EnrollmentUseCase) EnrollUser(c context.Context, enrollment *domain.Enrollment) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existedEnrollment, _ := usecase.enrollmentRepo.GetEnrollment(ctx, enrollment.CourseID, enrollment.UserID)
+	if existedEnrollment != nil {
+		return domain.ErrConflict
+	}
+	version, err := usecase.courseRepo.GetLatestVersion(ctx, enrollment.CourseID)
+	if err == domain.ErrNotFound {
+		return domain.ErrCourseNotPublished
+	}
+	if err != nil {
+		return err
+	}
+	enrollment.CourseVersionID = version.ID
+	enrollment.Version = version.Version
+	enrollment.Status = domain.EnrollmentActive
+	enrollment.CreatedAt = time.Now().Unix()
+	return usecase.enrollmentRepo.CreateEnrollment(ctx, enrollment)
+}
+
+// GetByCourse ...
+func (usecase *EnrollmentUseCase) GetByCourse(c context.Context, courseID int64, start int, limit int) ([]domain.Enrollment, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	res, err := usecase.enrollmentRepo.GetByCourse(ctx, courseID, start, limit)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetEnrollment will return the enrollment with the course snapshot the learner was enrolled into
+func (usecase *EnrollmentUseCase) GetEnrollment(c context.Context, courseID int64, userID int64) (*domain.Enrollment, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	enrollment, err := usecase.enrollmentRepo.GetEnrollment(ctx, courseID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment.CourseVersionID != 0 {
+		version, err := usecase.courseRepo.GetVersion(ctx, courseID, enrollment.Version)
+		if err != nil {
+			return nil, err
+		}
+		enrollment.CourseVersion = version
+	}
+	return enrollment, nil
+}
@@ -0,0 +1,85 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/enrollment/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEnrollUser(t *testing.T) {
+	mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+	mockCourseRepo := new(mocks.CourseRepository)
+
+	t.Run("success", func(t *testing.T) {
+		enrollment := domain.Enrollment{CourseID: 12, UserID: 3}
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(12), int64(3)).Return(nil, domain.ErrNotFound).Once()
+		mockCourseRepo.On("GetLatestVersion", mock.Anything, int64(12)).Return(&domain.CourseVersion{ID: 5, CourseID: 12, Version: 2}, nil).Once()
+		mockEnrollmentRepo.On("CreateEnrollment", mock.Anything, mock.AnythingOfType("*domain.Enrollment")).Return(nil).Once()
+		u := ucase.NewEnrollmentUseCase(mockEnrollmentRepo, mockCourseRepo, time.Second*2)
+
+		err := u.EnrollUser(context.TODO(), &enrollment)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), enrollment.CourseVersionID)
+		assert.Equal(t, 2, enrollment.Version)
+		assert.Equal(t, domain.EnrollmentActive, enrollment.Status)
+		mockEnrollmentRepo.AssertExpectations(t)
+		mockCourseRepo.AssertExpectations(t)
+	})
+	t.Run("already-enrolled", func(t *testing.T) {
+		enrollment := domain.Enrollment{CourseID: 12, UserID: 3}
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(12), int64(3)).Return(&domain.Enrollment{ID: 7}, nil).Once()
+		u := ucase.NewEnrollmentUseCase(mockEnrollmentRepo, mockCourseRepo, time.Second*2)
+
+		err := u.EnrollUser(context.TODO(), &enrollment)
+
+		assert.Equal(t, domain.ErrConflict, err)
+		mockEnrollmentRepo.AssertExpectations(t)
+	})
+	t.Run("not-published", func(t *testing.T) {
+		enrollment := domain.Enrollment{CourseID: 13, UserID: 3}
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(13), int64(3)).Return(nil, domain.ErrNotFound).Once()
+		mockCourseRepo.On("GetLatestVersion", mock.Anything, int64(13)).Return(nil, domain.ErrNotFound).Once()
+		u := ucase.NewEnrollmentUseCase(mockEnrollmentRepo, mockCourseRepo, time.Second*2)
+
+		err := u.EnrollUser(context.TODO(), &enrollment)
+
+		assert.Equal(t, domain.ErrCourseNotPublished, err)
+		mockEnrollmentRepo.AssertExpectations(t)
+		mockCourseRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetEnrollment(t *testing.T) {
+	mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+	mockCourseRepo := new(mocks.CourseRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(12), int64(3)).Return(&domain.Enrollment{ID: 7, CourseID: 12, UserID: 3, CourseVersionID: 5, Version: 1}, nil).Once()
+		mockCourseRepo.On("GetVersion", mock.Anything, int64(12), 1).Return(&domain.CourseVersion{ID: 5, Version: 1, Course: &domain.Course{Title: "v1"}}, nil).Once()
+		u := ucase.NewEnrollmentUseCase(mockEnrollmentRepo, mockCourseRepo, time.Second*2)
+
+		enrollment, err := u.GetEnrollment(context.TODO(), 12, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", enrollment.CourseVersion.Course.Title)
+		mockEnrollmentRepo.AssertExpectations(t)
+		mockCourseRepo.AssertExpectations(t)
+	})
+	t.Run("not-enrolled", func(t *testing.T) {
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(12), int64(4)).Return(nil, domain.ErrNotFound).Once()
+		u := ucase.NewEnrollmentUseCase(mockEnrollmentRepo, mockCourseRepo, time.Second*2)
+
+		enrollment, err := u.GetEnrollment(context.TODO(), 12, 4)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.Nil(t, enrollment)
+		mockEnrollmentRepo.AssertExpectations(t)
+	})
+}
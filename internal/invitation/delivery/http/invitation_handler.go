@@ -0,0 +1,235 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// InvitationHandler ...
+type InvitationHandler struct {
+	InvitationUseCase domain.InvitationUseCase
+}
+
+// NewInvitationHandler ...
+func NewInvitationHandler(e *echo.Echo, us domain.InvitationUseCase) {
+	handler := &InvitationHandler{
+		InvitationUseCase: us,
+	}
+	e.GET("/invitations", handler.GetAll, rbac.Require(domain.PermUserInvite))
+	e.GET("/invitations/:id", handler.GetByID, rbac.Require(domain.PermUserInvite))
+	e.POST("/invitations", handler.CreateInvitation, rbac.Require(domain.PermUserInvite))
+	e.POST("/invitations/:id/resend", handler.ResendInvitation, rbac.Require(domain.PermUserInvite))
+	e.DELETE("/invitations/:id", handler.RevokeInvitation, rbac.Require(domain.PermUserInvite))
+	e.POST("/auth/invitations/accept", handler.AcceptInvitation)
+}
+
+// GetAll godoc
+// @Summary Get All Invitations.
+// @Description Get the invitations of the organization, optionally filtered by status (1 pending, 2 accepted, 3 revoked).
+// @Tags invitations
+// @Accept */*
+// @Produce json
+// @Param status query int false "status"
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /invitations [get]
+func (c *InvitationHandler) GetAll(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	status, start, limit := 0, 0, 10
+	var err error
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "status":
+			val := strings.TrimSpace(v[0])
+			if status, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		}
+	}
+
+	list, err := c.InvitationUseCase.GetAll(ctx, status, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary Get invitation by ID.
+// @Description Get Specific invitation details.
+// @Tags invitations
+// @Accept */*
+// @Produce json
+// @Param id path int true "invitation Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /invitations/{id} [get]
+func (c *InvitationHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	invitation, err := c.InvitationUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    invitation,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// CreateInvitation godoc
+// @Summary Invite a user
+// @Description Invite someone by email to join the organization with a role, teams and course enrollments. The invitation link expires.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param invitation body domain.Invitation true "invitation Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Invalid data, unknown role, team or course, or course not published"
+// @Failure 403 {object} domain.APIResponseError "The role has permissions the caller lacks"
+// @Failure 409 {object} domain.APIResponseError "The email has an account or a pending invitation"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /invitations [post]
+func (c *InvitationHandler) CreateInvitation(echoContext echo.Context) error {
+	var invitation domain.Invitation
+	err := echoContext.Bind(&invitation)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&invitation); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.InvitationUseCase.CreateInvitation(ctx, &invitation)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    invitation,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusCreated, res)
+}
+
+// ResendInvitation godoc
+// @Summary Resend an invitation
+// @Description Send a pending invitation again with a new link. The previous link stops working.
+// @Tags invitations
+// @Accept */*
+// @Produce json
+// @Param id path int true "invitation Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 409 {object} domain.APIResponseError "The invitation is not pending"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /invitations/{id}/resend [post]
+func (c *InvitationHandler) ResendInvitation(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.InvitationUseCase.ResendInvitation(ctx, int64(idParam)); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke an invitation
+// @Description Revoke a pending invitation. Its link stops working.
+// @Tags invitations
+// @Accept */*
+// @Produce json
+// @Param id path int true "invitation Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 409 {object} domain.APIResponseError "The invitation is not pending"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /invitations/{id} [delete]
+func (c *InvitationHandler) RevokeInvitation(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.InvitationUseCase.RevokeInvitation(ctx, int64(idParam)); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation.
+// @Description Accept an invitation with the token of the emailed link and choose a password. The account is activated and signed in.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param acceptance body domain.InvitationAcceptance true "Acceptance"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Unknown, used, revoked or expired invitation"
+// @Failure 409 {object} domain.APIResponseError "Email or username already exists"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/invitations/accept [post]
+func (c *InvitationHandler) AcceptInvitation(echoContext echo.Context) error {
+	var acceptance domain.InvitationAcceptance
+	err := echoContext.Bind(&acceptance)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&acceptance); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	tokens, err := c.InvitationUseCase.AcceptInvitation(ctx, &acceptance)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    tokens,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
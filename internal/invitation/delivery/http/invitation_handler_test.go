@@ -0,0 +1,210 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	invitationHTTP "github.com/meroedu/meroedu/internal/invitation/delivery/http"
+)
+
+func TestGetAll(t *testing.T) {
+	mockUCase := new(mocks.InvitationUseCase)
+	mockUCase.On("GetAll", mock.Anything, domain.InvitationPending, 0, 10).Return([]domain.Invitation{{ID: 4, Email: "ram@meroedu.com"}}, nil).Once()
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"status=1", http.StatusOK},
+		{"status=pending", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/invitations?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := invitationHTTP.InvitationHandler{
+			InvitationUseCase: mockUCase,
+		}
+		err = handler.GetAll(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.query)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	mockUCase := new(mocks.InvitationUseCase)
+	mockUCase.On("GetByID", mock.Anything, int64(4)).Return(&domain.Invitation{ID: 4, Email: "ram@meroedu.com"}, nil).Once()
+	mockUCase.On("GetByID", mock.Anything, int64(5)).Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"4", http.StatusOK},
+		{"5", http.StatusNotFound},
+		{"ram", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/invitations/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/invitations/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := invitationHTTP.InvitationHandler{
+			InvitationUseCase: mockUCase,
+		}
+		err = handler.GetByID(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateInvitation(t *testing.T) {
+	mockUCase := new(mocks.InvitationUseCase)
+	mockUCase.On("CreateInvitation", mock.Anything, mock.MatchedBy(func(i *domain.Invitation) bool { return i.Email == "ram@meroedu.com" })).Return(nil).Once()
+	mockUCase.On("CreateInvitation", mock.Anything, mock.MatchedBy(func(i *domain.Invitation) bool { return i.Email == "sita@meroedu.com" })).Return(domain.ErrConflict).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"email":"ram@meroedu.com","role_id":2,"team_ids":[1]}`, http.StatusCreated},
+		{`{"email":"sita@meroedu.com","role_id":2}`, http.StatusConflict},
+		{`{"email":"ram","role_id":2}`, http.StatusBadRequest},
+		{`{"email":"ram@meroedu.com"}`, http.StatusBadRequest},
+		{`{"email":"ram@meroedu.com","role_id":"learner"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/invitations", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := invitationHTTP.InvitationHandler{
+			InvitationUseCase: mockUCase,
+		}
+		err = handler.CreateInvitation(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestResendInvitation(t *testing.T) {
+	mockUCase := new(mocks.InvitationUseCase)
+	mockUCase.On("ResendInvitation", mock.Anything, int64(4)).Return(nil).Once()
+	mockUCase.On("ResendInvitation", mock.Anything, int64(5)).Return(domain.ErrConflict).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"4", http.StatusNoContent},
+		{"5", http.StatusConflict},
+		{"ram", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/invitations/"+tt.id+"/resend", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/invitations/:id/resend")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := invitationHTTP.InvitationHandler{
+			InvitationUseCase: mockUCase,
+		}
+		err = handler.ResendInvitation(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	mockUCase := new(mocks.InvitationUseCase)
+	mockUCase.On("RevokeInvitation", mock.Anything, int64(4)).Return(nil).Once()
+	mockUCase.On("RevokeInvitation", mock.Anything, int64(5)).Return(domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"4", http.StatusNoContent},
+		{"5", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/invitations/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/invitations/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := invitationHTTP.InvitationHandler{
+			InvitationUseCase: mockUCase,
+		}
+		err = handler.RevokeInvitation(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	mockUCase := new(mocks.InvitationUseCase)
+	mockUCase.On("AcceptInvitation", mock.Anything, &domain.InvitationAcceptance{Token: "valid", Password: "password"}).
+		Return(&domain.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil).Once()
+	mockUCase.On("AcceptInvitation", mock.Anything, &domain.InvitationAcceptance{Token: "revoked", Password: "password"}).
+		Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"token":"valid","password":"password"}`, http.StatusOK},
+		{`{"token":"revoked","password":"password"}`, http.StatusNotFound},
+		{`{"token":"valid","password":"short"}`, http.StatusBadRequest},
+		{`{"token":["valid"]}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/invitations/accept", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := invitationHTTP.InvitationHandler{
+			InvitationUseCase: mockUCase,
+		}
+		err = handler.AcceptInvitation(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,293 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const invitationQuery = `SELECT i.id,i.organization_id,i.email,i.first_name,i.last_name,i.role_id,i.invited_by,i.user_id,i.status,
+	i.token_hash,i.expires_at,i.accepted_at,i.updated_at,i.created_at,
+	COALESCE((SELECT GROUP_CONCAT(it.team_id ORDER BY it.team_id) FROM invitations_teams it WHERE it.invitation_id = i.id),''),
+	COALESCE((SELECT GROUP_CONCAT(ic.course_id ORDER BY ic.course_id) FROM invitations_courses ic WHERE ic.invitation_id = i.id),'')
+	FROM invitations i`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the invitation's Repository interface
+func Init(db *sql.DB) domain.InvitationRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func splitIDs(s string) ([]int64, error) {
+	ids := make([]int64, 0)
+	if s == "" {
+		return ids, nil
+	}
+	for _, v := range strings.Split(s, ",") {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Invitation, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Invitation, 0)
+	for rows.Next() {
+		t := domain.Invitation{}
+		var firstName, lastName sql.NullString
+		var userID, acceptedAt sql.NullInt64
+		var teamIDs, courseIDs string
+		err = rows.Scan(
+			&t.ID,
+			&t.OrganizationID,
+			&t.Email,
+			&firstName,
+			&lastName,
+			&t.RoleID,
+			&t.InvitedBy,
+			&userID,
+			&t.Status,
+			&t.TokenHash,
+			&t.ExpiresAt,
+			&acceptedAt,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&teamIDs,
+			&courseIDs,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.FirstName = firstName.String
+		t.LastName = lastName.String
+		t.UserID = userID.Int64
+		t.AcceptedAt = acceptedAt.Int64
+		if t.TeamIDs, err = splitIDs(teamIDs); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if t.CourseIDs, err = splitIDs(courseIDs); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) getOne(ctx context.Context, query string, args ...interface{}) (*domain.Invitation, error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// GetAll returns the invitations of the caller's organization, of every status when status is 0
+func (m *mysqlRepository) GetAll(ctx context.Context, status int, start int, limit int) ([]domain.Invitation, error) {
+	query := invitationQuery + ` WHERE i.organization_id = ? AND (? = 0 OR i.status = ?) ORDER BY i.created_at DESC LIMIT ?,?`
+	return m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), status, status, start, limit)
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.Invitation, error) {
+	query := invitationQuery + ` WHERE i.id = ? AND i.organization_id = ?`
+	return m.getOne(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+}
+
+// GetPendingByEmail returns the pending invitation of the email in the caller's organization
+func (m *mysqlRepository) GetPendingByEmail(ctx context.Context, email string) (*domain.Invitation, error) {
+	query := invitationQuery + ` WHERE i.email = ? AND i.organization_id = ? AND i.status = ?`
+	return m.getOne(ctx, query, email, domain.OrganizationIDFromContext(ctx), domain.InvitationPending)
+}
+
+// GetByTokenHash looks the invitation up across every organization: the token is all the invitee has.
+func (m *mysqlRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	query := invitationQuery + ` WHERE i.token_hash = ?`
+	return m.getOne(ctx, query, tokenHash)
+}
+
+// CreateInvitation stores the invitation with its teams and courses, which must belong to the caller's
+// organization. An unknown team or course gives ErrBadParamInput.
+func (m *mysqlRepository) CreateInvitation(ctx context.Context, inv *domain.Invitation) (err error) {
+	inv.OrganizationID = domain.OrganizationIDFromContext(ctx)
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `INSERT invitations SET organization_id=?,email=?,first_name=?,last_name=?,role_id=?,invited_by=?,status=?,token_hash=?,
+		expires_at=?,updated_at=?,created_at=?`
+	res, err := tx.ExecContext(ctx, query, inv.OrganizationID, inv.Email, sql.NullString{String: inv.FirstName, Valid: inv.FirstName != ""},
+		sql.NullString{String: inv.LastName, Valid: inv.LastName != ""}, inv.RoleID, inv.InvitedBy, inv.Status, inv.TokenHash,
+		inv.ExpiresAt, inv.UpdatedAt, inv.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	if inv.ID, err = res.LastInsertId(); err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return
+	}
+
+	query = `INSERT INTO invitations_teams (invitation_id,team_id) SELECT ?,t.id FROM teams t WHERE t.id = ? AND t.organization_id = ?`
+	for _, id := range inv.TeamIDs {
+		if err = m.insertOne(ctx, tx, query, inv.ID, id, inv.OrganizationID); err != nil {
+			return
+		}
+	}
+	query = `INSERT INTO invitations_courses (invitation_id,course_id) SELECT ?,c.id FROM courses c
+		WHERE c.id = ? AND c.organization_id = ? AND c.deleted_at IS NULL`
+	for _, id := range inv.CourseIDs {
+		if err = m.insertOne(ctx, tx, query, inv.ID, id, inv.OrganizationID); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// insertOne runs an INSERT ... SELECT which must insert a row, giving ErrBadParamInput otherwise
+func (m *mysqlRepository) insertOne(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) error {
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrBadParamInput
+	}
+	return nil
+}
+
+// RenewToken replaces the token of a pending invitation, invalidating the one sent before
+func (m *mysqlRepository) RenewToken(ctx context.Context, id int64, tokenHash string, expiresAt int64, updatedAt int64) error {
+	query := `UPDATE invitations SET token_hash = ?, expires_at = ?, updated_at = ? WHERE id = ? AND organization_id = ? AND status = ?`
+	return m.update(ctx, query, tokenHash, expiresAt, updatedAt, id, domain.OrganizationIDFromContext(ctx), domain.InvitationPending)
+}
+
+// RevokeInvitation revokes a pending invitation
+func (m *mysqlRepository) RevokeInvitation(ctx context.Context, id int64, updatedAt int64) error {
+	query := `UPDATE invitations SET status = ?, updated_at = ? WHERE id = ? AND organization_id = ? AND status = ?`
+	return m.update(ctx, query, domain.InvitationRevoked, updatedAt, id, domain.OrganizationIDFromContext(ctx), domain.InvitationPending)
+}
+
+func (m *mysqlRepository) update(ctx context.Context, query string, args ...interface{}) error {
+	res, err := m.conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation creates the user, marks the invitation accepted and adds the user to the teams of the
+// invitation, in one transaction. An invitation which is no longer pending, or whose token was renewed
+// meanwhile, gives ErrNotFound and nothing is created.
+func (m *mysqlRepository) AcceptInvitation(ctx context.Context, inv *domain.Invitation, u *domain.User) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `INSERT users SET firstName=?,lastName=?,email=?,email_verified_at=?,username=?,password=?,organization_id=?,role_id=?,
+		inviteBy=?,status=?,joinedDate=?,updated_at=?,created_at=?`
+	res, err := tx.ExecContext(ctx, query, sql.NullString{String: u.FirstName, Valid: u.FirstName != ""}, u.LastName, u.Email,
+		sql.NullInt64{Int64: u.EmailVerifiedAt, Valid: u.EmailVerifiedAt != 0}, sql.NullString{String: u.Username, Valid: u.Username != ""},
+		u.Password, inv.OrganizationID, inv.RoleID, inv.InvitedBy, u.Status, u.JoinedDate, u.UpdatedAt, u.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	if u.ID, err = res.LastInsertId(); err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return
+	}
+	u.OrganizationID = inv.OrganizationID
+	u.RoleID = inv.RoleID
+
+	query = `UPDATE invitations SET status = ?, user_id = ?, accepted_at = ?, updated_at = ? WHERE id = ? AND status = ? AND token_hash = ?`
+	res, err = tx.ExecContext(ctx, query, domain.InvitationAccepted, u.ID, u.CreatedAt, u.CreatedAt, inv.ID, domain.InvitationPending, inv.TokenHash)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		err = domain.ErrNotFound
+		return
+	}
+
+	query = `INSERT INTO teams_users (team_id,user_id,created_at) SELECT t.id,?,? FROM invitations_teams it
+		JOIN teams t ON t.id = it.team_id WHERE it.invitation_id = ? AND t.organization_id = ?`
+	if _, err = tx.ExecContext(ctx, query, u.ID, u.CreatedAt, inv.ID, inv.OrganizationID); err != nil {
+		log.Error(err)
+		return
+	}
+	inv.Status = domain.InvitationAccepted
+	inv.UserID = u.ID
+	inv.AcceptedAt = u.CreatedAt
+	return
+}
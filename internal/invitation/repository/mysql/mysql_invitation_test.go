@@ -0,0 +1,144 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/invitation/repository/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+var columns = []string{"id", "organization_id", "email", "first_name", "last_name", "role_id", "invited_by", "user_id", "status",
+	"token_hash", "expires_at", "accepted_at", "updated_at", "created_at", "team_ids", "course_ids"}
+
+func TestGetByTokenHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now().Unix()
+	rows := sqlmock.NewRows(columns).AddRow(1, 2, "sita@school.local", "Sita", nil, 4, 9, nil, domain.InvitationPending,
+		"abc", now+3600, nil, now, now, "3,5", "")
+	mock.ExpectQuery(`SELECT .+ FROM invitations i WHERE i.token_hash = \?`).WithArgs("abc").WillReturnRows(rows)
+
+	repo := mysqlrepo.Init(db)
+	inv, err := repo.GetByTokenHash(context.TODO(), "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 5}, inv.TeamIDs)
+	assert.Equal(t, []int64{}, inv.CourseIDs)
+	assert.Equal(t, "Sita", inv.FirstName)
+	assert.Empty(t, inv.LastName)
+}
+
+func TestCreateInvitation(t *testing.T) {
+	now := time.Now().Unix()
+	inv := func() *domain.Invitation {
+		return &domain.Invitation{Email: "sita@school.local", RoleID: 4, InvitedBy: 9, Status: domain.InvitationPending, TokenHash: "abc",
+			ExpiresAt: now + 3600, UpdatedAt: now, CreatedAt: now, TeamIDs: []int64{3}, CourseIDs: []int64{7}}
+	}
+	insert := `INSERT invitations SET organization_id=\?,email=\?`
+	teams := `INSERT INTO invitations_teams \(invitation_id,team_id\) SELECT \?,t.id FROM teams t WHERE t.id = \? AND t.organization_id = \?`
+	courses := `INSERT INTO invitations_courses \(invitation_id,course_id\) SELECT \?,c.id FROM courses c`
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectExec(teams).WithArgs(11, 3, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(courses).WithArgs(11, 7, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		i := inv()
+		err = repo.CreateInvitation(orgCtx, i)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(11), i.ID)
+		assert.Equal(t, int64(2), i.OrganizationID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("team-of-another-organization", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectExec(teams).WithArgs(11, 3, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := mysqlrepo.Init(db)
+		err = repo.CreateInvitation(orgCtx, inv())
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now().Unix()
+	mock.ExpectExec(`UPDATE invitations SET status = \?, updated_at = \? WHERE id = \? AND organization_id = \? AND status = \?`).
+		WithArgs(domain.InvitationRevoked, now, 1, 2, domain.InvitationPending).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.RevokeInvitation(orgCtx, 1, now)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	now := time.Now().Unix()
+	inv := &domain.Invitation{ID: 1, OrganizationID: 2, RoleID: 4, InvitedBy: 9, TokenHash: "abc"}
+	user := func() *domain.User {
+		return &domain.User{LastName: "Sharma", Email: "sita@school.local", Password: "hash", Status: domain.UserActive,
+			JoinedDate: now, UpdatedAt: now, CreatedAt: now}
+	}
+	insert := `INSERT users SET firstName=\?,lastName=\?,email=\?,email_verified_at=\?,username=\?,password=\?,organization_id=\?,role_id=\?`
+	accept := `UPDATE invitations SET status = \?, user_id = \?, accepted_at = \?, updated_at = \? WHERE id = \? AND status = \? AND token_hash = \?`
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(20, 1))
+		mock.ExpectExec(accept).WithArgs(domain.InvitationAccepted, 20, now, now, 1, domain.InvitationPending, "abc").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO teams_users \(team_id,user_id,created_at\) SELECT t.id,\?,\? FROM invitations_teams it`).
+			WithArgs(20, now, 1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		u := user()
+		err = repo.AcceptInvitation(context.TODO(), inv, u)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20), u.ID)
+		assert.Equal(t, int64(4), u.RoleID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("accepted-concurrently", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(20, 1))
+		mock.ExpectExec(accept).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := mysqlrepo.Init(db)
+		err = repo.AcceptInvitation(context.TODO(), inv, user())
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
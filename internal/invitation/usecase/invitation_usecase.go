@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+	"github.com/meroedu/meroedu/pkg/password"
+)
+
+// InvitationUseCase ...
+type InvitationUseCase struct {
+	invitationRepo    domain.InvitationRepository
+	userRepo          domain.UserRepository
+	roleRepo          domain.RoleRepository
+	courseRepo        domain.CourseRepository
+	enrollmentUseCase domain.EnrollmentUseCase
+	authUseCase       domain.AuthUseCase
+	mailer            domain.Mailer
+	acceptURL         string
+	ttl               time.Duration
+	contextTimeOut    time.Duration
+}
+
+// NewInvitationUseCase will create new an InvitationUseCase. Invitations expire after ttl; the emailed link
+// is acceptURL with the token as query parameter.
+func NewInvitationUseCase(i domain.InvitationRepository, u domain.UserRepository, r domain.RoleRepository, c domain.CourseRepository,
+	e domain.EnrollmentUseCase, a domain.AuthUseCase, mailer domain.Mailer, acceptURL string, ttl time.Duration, timeout time.Duration) domain.InvitationUseCase {
+	return &InvitationUseCase{
+		invitationRepo:    i,
+		userRepo:          u,
+		roleRepo:          r,
+		courseRepo:        c,
+		enrollmentUseCase: e,
+		authUseCase:       a,
+		mailer:            mailer,
+		acceptURL:         acceptURL,
+		ttl:               ttl,
+		contextTimeOut:    timeout,
+	}
+}
+
+func newToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetAll ...
+func (usecase *InvitationUseCase) GetAll(c context.Context, status int, start int, limit int) ([]domain.Invitation, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.invitationRepo.GetAll(ctx, status, start, limit)
+}
+
+// GetByID ...
+func (usecase *InvitationUseCase) GetByID(c context.Context, id int64) (*domain.Invitation, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.invitationRepo.GetByID(ctx, id)
+}
+
+// CreateInvitation invites the email to the caller's organization and sends the invitation. The caller must
+// hold every permission of the role, and the courses must be published.
+func (usecase *InvitationUseCase) CreateInvitation(c context.Context, inv *domain.Invitation) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.userRepo.GetByEmail(ctx, inv.Email); err != domain.ErrNotFound {
+		if err == nil {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	if _, err := usecase.invitationRepo.GetPendingByEmail(ctx, inv.Email); err != domain.ErrNotFound {
+		if err == nil {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	role, err := usecase.roleRepo.GetByID(ctx, inv.RoleID)
+	if err == domain.ErrNotFound {
+		return domain.ErrBadParamInput
+	}
+	if err != nil {
+		return err
+	}
+	for _, p := range role.Permissions {
+		if !domain.HasPermission(ctx, p) {
+			return domain.ErrForbidden
+		}
+	}
+	for _, id := range inv.CourseIDs {
+		if _, err = usecase.courseRepo.GetLatestVersion(ctx, id); err != nil {
+			if err == domain.ErrNotFound {
+				return domain.ErrCourseNotPublished
+			}
+			return err
+		}
+	}
+
+	token, tokenHash, err := newToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	inv.InvitedBy = domain.UserIDFromContext(ctx)
+	inv.Status = domain.InvitationPending
+	inv.TokenHash = tokenHash
+	inv.ExpiresAt = now.Add(usecase.ttl).Unix()
+	inv.UpdatedAt = now.Unix()
+	inv.CreatedAt = now.Unix()
+	if err = usecase.invitationRepo.CreateInvitation(ctx, inv); err != nil {
+		return err
+	}
+	return usecase.send(ctx, inv, token)
+}
+
+// ResendInvitation sends a pending invitation again with a new token and expiry. The previous link stops working.
+func (usecase *InvitationUseCase) ResendInvitation(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	inv, err := usecase.invitationRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if inv.Status != domain.InvitationPending {
+		return domain.ErrConflict
+	}
+	token, tokenHash, err := newToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	inv.TokenHash = tokenHash
+	inv.ExpiresAt = now.Add(usecase.ttl).Unix()
+	inv.UpdatedAt = now.Unix()
+	if err = usecase.invitationRepo.RenewToken(ctx, id, inv.TokenHash, inv.ExpiresAt, inv.UpdatedAt); err != nil {
+		return err
+	}
+	return usecase.send(ctx, inv, token)
+}
+
+// RevokeInvitation revokes a pending invitation
+func (usecase *InvitationUseCase) RevokeInvitation(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	inv, err := usecase.invitationRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if inv.Status != domain.InvitationPending {
+		return domain.ErrConflict
+	}
+	return usecase.invitationRepo.RevokeInvitation(ctx, id, time.Now().Unix())
+}
+
+func (usecase *InvitationUseCase) send(ctx context.Context, inv *domain.Invitation, token string) error {
+	link := usecase.acceptURL + "?token=" + url.QueryEscape(token)
+	mail := &domain.Mail{
+		To:      inv.Email,
+		Subject: "You are invited to Meroedu",
+		Body: fmt.Sprintf("Hello,\n\nYou have been invited to join Meroedu. Open the link below to choose your password "+
+			"and activate your account:\n\n%s\n\nThe link expires on %s.\n",
+			link, time.Unix(inv.ExpiresAt, 0).UTC().Format("2 January 2006 15:04 MST")),
+	}
+	return usecase.mailer.Send(ctx, mail)
+}
+
+// AcceptInvitation creates the active account of the invitation with the chosen password and a verified email,
+// enrolls it in the courses of the invitation and completes its login. Unknown, used, revoked and expired tokens give ErrUnauthorized.
+func (usecase *InvitationUseCase) AcceptInvitation(c context.Context, acceptance *domain.InvitationAcceptance) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	inv, err := usecase.invitationRepo.GetByTokenHash(ctx, hashToken(acceptance.Token))
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	if inv.Status != domain.InvitationPending || inv.ExpiresAt < now {
+		return nil, domain.ErrUnauthorized
+	}
+	ctx = domain.WithOrganizationID(ctx, inv.OrganizationID)
+
+	if _, err = usecase.userRepo.GetByEmail(ctx, inv.Email); err != domain.ErrNotFound {
+		if err == nil {
+			return nil, domain.ErrConflict
+		}
+		return nil, err
+	}
+	if acceptance.Username != "" {
+		if _, err = usecase.userRepo.GetByUsername(ctx, acceptance.Username); err != domain.ErrNotFound {
+			if err == nil {
+				return nil, domain.ErrConflict
+			}
+			return nil, err
+		}
+	}
+	hash, err := password.Hash(acceptance.Password)
+	if err != nil {
+		return nil, err
+	}
+	user := &domain.User{
+		FirstName:  acceptance.FirstName,
+		LastName:   acceptance.LastName,
+		Email:      inv.Email,
+		Username:   acceptance.Username,
+		Password:   hash,
+		Status:     domain.UserActive,
+		JoinedDate: now,
+		UpdatedAt:  now,
+		CreatedAt:  now,
+	}
+	// the invitation link reached the inbox, so the email is verified
+	user.EmailVerifiedAt = now
+	if user.FirstName == "" {
+		user.FirstName = inv.FirstName
+	}
+	if user.LastName == "" {
+		user.LastName = inv.LastName
+	}
+	if user.LastName == "" {
+		user.LastName = inv.Email
+	}
+	if err = usecase.invitationRepo.AcceptInvitation(ctx, inv, user); err != nil {
+		if err == domain.ErrNotFound {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+	user.Password = ""
+
+	for _, courseID := range inv.CourseIDs {
+		if err = usecase.enrollmentUseCase.EnrollUser(ctx, &domain.Enrollment{CourseID: courseID, UserID: user.ID}); err != nil {
+			log.Errorf("Enrollment of invited user %d in course %d failed: %v", user.ID, courseID, err)
+		}
+	}
+	return usecase.authUseCase.CompleteLogin(ctx, user)
+}
@@ -0,0 +1,276 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/invitation/usecase"
+	"github.com/meroedu/meroedu/pkg/password"
+)
+
+const acceptURL = "https://school.local/invitations/accept"
+
+var learner = &domain.Role{ID: 3, Code: domain.RoleLearner, Permissions: []domain.Permission{domain.PermCourseView}}
+
+// tokenOf returns the token of the link in the mail
+func tokenOf(t *testing.T, mail *domain.Mail) string {
+	i := strings.Index(mail.Body, acceptURL+"?")
+	if !assert.True(t, i >= 0, "no link in %q", mail.Body) {
+		return ""
+	}
+	link, err := url.Parse(strings.Fields(mail.Body[i:])[0])
+	assert.NoError(t, err)
+	return link.Query().Get("token")
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestCreateInvitation(t *testing.T) {
+	ctx := domain.WithUserID(domain.WithPermissions(context.TODO(), []domain.Permission{domain.PermUserInvite, domain.PermCourseView}), 9)
+
+	t.Run("success", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		mockUserRepo.On("GetByEmail", mock.Anything, "sita@school.local").Return(nil, domain.ErrNotFound).Once()
+		mockInvitationRepo.On("GetPendingByEmail", mock.Anything, "sita@school.local").Return(nil, domain.ErrNotFound).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, learner.ID).Return(learner, nil).Once()
+		mockCourseRepo.On("GetLatestVersion", mock.Anything, int64(7)).Return(&domain.CourseVersion{ID: 1}, nil).Once()
+		mockInvitationRepo.On("CreateInvitation", mock.Anything, mock.AnythingOfType("*domain.Invitation")).Return(nil).Once()
+		var sent *domain.Mail
+		mockMailer.On("Send", mock.Anything, mock.AnythingOfType("*domain.Mail")).
+			Run(func(args mock.Arguments) { sent = args.Get(1).(*domain.Mail) }).Return(nil).Once()
+
+		inv := &domain.Invitation{Email: "sita@school.local", RoleID: learner.ID, CourseIDs: []int64{7}}
+		err := u.CreateInvitation(ctx, inv)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9), inv.InvitedBy)
+		assert.Equal(t, domain.InvitationPending, inv.Status)
+		assert.InDelta(t, time.Now().Add(72*time.Hour).Unix(), inv.ExpiresAt, 2)
+		assert.Equal(t, "sita@school.local", sent.To)
+		assert.Equal(t, inv.TokenHash, hash(tokenOf(t, sent)))
+		mockInvitationRepo.AssertExpectations(t)
+	})
+	t.Run("email-has-an-account", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		mockUserRepo.On("GetByEmail", mock.Anything, "sita@school.local").Return(&domain.User{ID: 4}, nil).Once()
+
+		err := u.CreateInvitation(ctx, &domain.Invitation{Email: "sita@school.local", RoleID: learner.ID})
+		assert.Equal(t, domain.ErrConflict, err)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+	t.Run("role-with-more-permissions", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		admin := &domain.Role{ID: 1, Code: domain.RoleAdmin, Permissions: []domain.Permission{domain.PermUserManage}}
+		mockUserRepo.On("GetByEmail", mock.Anything, "sita@school.local").Return(nil, domain.ErrNotFound).Once()
+		mockInvitationRepo.On("GetPendingByEmail", mock.Anything, "sita@school.local").Return(nil, domain.ErrNotFound).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, admin.ID).Return(admin, nil).Once()
+
+		err := u.CreateInvitation(ctx, &domain.Invitation{Email: "sita@school.local", RoleID: admin.ID})
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockInvitationRepo.AssertNotCalled(t, "CreateInvitation", mock.Anything, mock.Anything)
+	})
+	t.Run("course-not-published", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		mockUserRepo.On("GetByEmail", mock.Anything, "sita@school.local").Return(nil, domain.ErrNotFound).Once()
+		mockInvitationRepo.On("GetPendingByEmail", mock.Anything, "sita@school.local").Return(nil, domain.ErrNotFound).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, learner.ID).Return(learner, nil).Once()
+		mockCourseRepo.On("GetLatestVersion", mock.Anything, int64(7)).Return(nil, domain.ErrNotFound).Once()
+
+		err := u.CreateInvitation(ctx, &domain.Invitation{Email: "sita@school.local", RoleID: learner.ID, CourseIDs: []int64{7}})
+		assert.Equal(t, domain.ErrCourseNotPublished, err)
+	})
+}
+
+func TestResendInvitation(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		mockInvitationRepo.On("GetByID", mock.Anything, int64(1)).
+			Return(&domain.Invitation{ID: 1, Email: "sita@school.local", Status: domain.InvitationPending, TokenHash: "old"}, nil).Once()
+		var tokenHash string
+		mockInvitationRepo.On("RenewToken", mock.Anything, int64(1), mock.AnythingOfType("string"), mock.AnythingOfType("int64"), mock.AnythingOfType("int64")).
+			Run(func(args mock.Arguments) { tokenHash = args.String(2) }).Return(nil).Once()
+		var sent *domain.Mail
+		mockMailer.On("Send", mock.Anything, mock.AnythingOfType("*domain.Mail")).
+			Run(func(args mock.Arguments) { sent = args.Get(1).(*domain.Mail) }).Return(nil).Once()
+
+		err := u.ResendInvitation(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.NotEqual(t, "old", tokenHash)
+		assert.Equal(t, tokenHash, hash(tokenOf(t, sent)))
+	})
+	t.Run("accepted", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		mockInvitationRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Invitation{ID: 1, Status: domain.InvitationAccepted}, nil).Once()
+
+		err := u.ResendInvitation(context.TODO(), 1)
+		assert.Equal(t, domain.ErrConflict, err)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	pending := func() *domain.Invitation {
+		return &domain.Invitation{ID: 1, OrganizationID: 2, Email: "sita@school.local", FirstName: "Sita", LastName: "Sharma", RoleID: 3,
+			CourseIDs: []int64{7, 8}, Status: domain.InvitationPending, TokenHash: hash("the-token"), ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	}
+	acceptance := &domain.InvitationAcceptance{Token: "the-token", Password: "s3cret-pass", Username: "sita"}
+	inOrganization := mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.OrganizationIDFromContext(ctx) == 2
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		mockInvitationRepo.On("GetByTokenHash", mock.Anything, hash("the-token")).Return(pending(), nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, "sita@school.local").Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("GetByUsername", mock.Anything, "sita").Return(nil, domain.ErrNotFound).Once()
+		mockInvitationRepo.On("AcceptInvitation", inOrganization, mock.AnythingOfType("*domain.Invitation"), mock.MatchedBy(func(user *domain.User) bool {
+			return user.FirstName == "Sita" && user.LastName == "Sharma" && user.Username == "sita" && user.Status == domain.UserActive &&
+				password.Compare(user.Password, "s3cret-pass")
+		})).Run(func(args mock.Arguments) { args.Get(2).(*domain.User).ID = 20 }).Return(nil).Once()
+		mockEnrollmentUseCase.On("EnrollUser", inOrganization, &domain.Enrollment{CourseID: 7, UserID: 20}).Return(nil).Once()
+		mockEnrollmentUseCase.On("EnrollUser", inOrganization, &domain.Enrollment{CourseID: 8, UserID: 20}).Return(domain.ErrCourseNotPublished).Once()
+		tokens := &domain.TokenPair{AccessToken: "at"}
+		mockAuthUseCase.On("CompleteLogin", inOrganization, mock.AnythingOfType("*domain.User")).Return(tokens, nil).Once()
+
+		res, err := u.AcceptInvitation(context.TODO(), acceptance)
+		assert.NoError(t, err)
+		assert.Equal(t, tokens, res)
+		mockInvitationRepo.AssertExpectations(t)
+		mockEnrollmentUseCase.AssertExpectations(t)
+	})
+	t.Run("expired", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		expired := pending()
+		expired.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+		mockInvitationRepo.On("GetByTokenHash", mock.Anything, hash("the-token")).Return(expired, nil).Once()
+
+		_, err := u.AcceptInvitation(context.TODO(), acceptance)
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockInvitationRepo.AssertNotCalled(t, "AcceptInvitation", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("revoked", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		revoked := pending()
+		revoked.Status = domain.InvitationRevoked
+		mockInvitationRepo.On("GetByTokenHash", mock.Anything, hash("the-token")).Return(revoked, nil).Once()
+
+		_, err := u.AcceptInvitation(context.TODO(), acceptance)
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+	t.Run("unknown-token", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		mockInvitationRepo.On("GetByTokenHash", mock.Anything, hash("the-token")).Return(nil, domain.ErrNotFound).Once()
+
+		_, err := u.AcceptInvitation(context.TODO(), acceptance)
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+	t.Run("username-taken", func(t *testing.T) {
+		mockInvitationRepo := new(mocks.InvitationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		mockMailer := new(mocks.Mailer)
+		u := ucase.NewInvitationUseCase(mockInvitationRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase,
+			mockAuthUseCase, mockMailer, acceptURL, 72*time.Hour, time.Second*2)
+		mockInvitationRepo.On("GetByTokenHash", mock.Anything, hash("the-token")).Return(pending(), nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, "sita@school.local").Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("GetByUsername", mock.Anything, "sita").Return(&domain.User{ID: 5}, nil).Once()
+
+		_, err := u.AcceptInvitation(context.TODO(), acceptance)
+		assert.Equal(t, domain.ErrConflict, err)
+	})
+}
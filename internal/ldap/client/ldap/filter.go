@@ -0,0 +1,140 @@
+package ldap
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/meroedu/meroedu/pkg/ber"
+)
+
+// Filter choices of a search request
+const (
+	filterAnd       = ber.ClassContext | ber.Constructed | 0
+	filterOr        = ber.ClassContext | ber.Constructed | 1
+	filterNot       = ber.ClassContext | ber.Constructed | 2
+	filterEquality  = ber.ClassContext | ber.Constructed | 3
+	filterSubstring = ber.ClassContext | ber.Constructed | 4
+	filterPresent   = ber.ClassContext | 7
+)
+
+var errFilter = errors.New("ldap: invalid filter")
+
+// escapeFilter escapes a value for use in a filter (RFC 4515)
+func escapeFilter(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '*', '(', ')', '\\', 0:
+			fmt.Fprintf(&b, "\\%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func unescapeFilter(value string) (string, error) {
+	if !strings.Contains(value, "\\") {
+		return value, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i+2 >= len(value) {
+			return "", errFilter
+		}
+		c, err := hex.DecodeString(value[i+1 : i+3])
+		if err != nil {
+			return "", errFilter
+		}
+		b.Write(c)
+		i += 2
+	}
+	return b.String(), nil
+}
+
+// compileFilter encodes a string filter (RFC 4515). The and, or, not, equality, substring and
+// presence filters are supported.
+func compileFilter(filter string) ([]byte, error) {
+	encoded, rest, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, errFilter
+	}
+	return encoded, nil
+}
+
+func parseFilter(s string) ([]byte, string, error) {
+	if len(s) < 2 || s[0] != '(' {
+		return nil, "", errFilter
+	}
+	s = s[1:]
+	switch s[0] {
+	case '&', '|', '!':
+		op := s[0]
+		s = s[1:]
+		var children [][]byte
+		for strings.HasPrefix(s, "(") {
+			child, rest, err := parseFilter(s)
+			if err != nil {
+				return nil, "", err
+			}
+			children = append(children, child)
+			s = rest
+		}
+		if !strings.HasPrefix(s, ")") || len(children) == 0 || (op == '!' && len(children) != 1) {
+			return nil, "", errFilter
+		}
+		tag := map[byte]byte{'&': filterAnd, '|': filterOr, '!': filterNot}[op]
+		return ber.Wrap(tag, children...), s[1:], nil
+	}
+
+	end := strings.IndexByte(s, ')')
+	if end < 0 {
+		return nil, "", errFilter
+	}
+	item, rest := s[:end], s[end+1:]
+	eq := strings.IndexByte(item, '=')
+	if eq <= 0 || strings.ContainsAny(item[:eq], "~<>:*") {
+		return nil, "", errFilter
+	}
+	attribute, value := item[:eq], item[eq+1:]
+	if value == "*" {
+		return ber.Encode(filterPresent, []byte(attribute)), rest, nil
+	}
+	if !strings.Contains(value, "*") {
+		v, err := unescapeFilter(value)
+		if err != nil {
+			return nil, "", err
+		}
+		return ber.Wrap(filterEquality, ber.OctetString(attribute), ber.OctetString(v)), rest, nil
+	}
+
+	parts := strings.Split(value, "*")
+	var substrings [][]byte
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		v, err := unescapeFilter(part)
+		if err != nil {
+			return nil, "", err
+		}
+		choice := byte(1) // any
+		switch i {
+		case 0:
+			choice = 0 // initial
+		case len(parts) - 1:
+			choice = 2 // final
+		}
+		substrings = append(substrings, ber.Encode(ber.ClassContext|choice, []byte(v)))
+	}
+	return ber.Wrap(filterSubstring, ber.OctetString(attribute), ber.Sequence(substrings...)), rest, nil
+}
@@ -0,0 +1,383 @@
+package ldap
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/ber"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// Protocol operations
+const (
+	opBindRequest      = ber.ClassApplication | ber.Constructed | 0
+	opBindResponse     = ber.ClassApplication | ber.Constructed | 1
+	opUnbindRequest    = ber.ClassApplication | 2
+	opSearchRequest    = ber.ClassApplication | ber.Constructed | 3
+	opSearchEntry      = ber.ClassApplication | ber.Constructed | 4
+	opSearchDone       = ber.ClassApplication | ber.Constructed | 5
+	opSearchReference  = ber.ClassApplication | ber.Constructed | 19
+	tagControls        = ber.ClassContext | ber.Constructed | 0
+	tagSimplePassword  = ber.ClassContext | 0
+	scopeWholeSubtree  = 2
+	derefAliasesNever  = 0
+	resultSuccess      = 0
+	resultSizeLimit    = 4
+	resultInvalidCreds = 49
+)
+
+// pagedResultsOID is the simple paged results control (RFC 2696). Active Directory returns at most
+// 1000 entries per search without it.
+const pagedResultsOID = "1.2.840.113556.1.4.319"
+
+// pageSize is the number of entries requested per page
+const pageSize = 500
+
+// ResultError is a failed LDAP operation
+type ResultError struct {
+	Code    int64
+	Message string
+}
+
+func (e *ResultError) Error() string {
+	return fmt.Sprintf("ldap: result code %d: %s", e.Code, e.Message)
+}
+
+type ldapClient struct {
+	timeout time.Duration
+}
+
+// Init will create an object that represent the LDAP client interface
+func Init(timeout time.Duration) domain.LDAPClient {
+	return &ldapClient{
+		timeout: timeout,
+	}
+}
+
+type conn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	messageID int64
+	deadline  func() time.Time
+}
+
+func (c *ldapClient) dial(ctx context.Context, d *domain.LDAPDirectory) (*conn, error) {
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Host
+	dialer := &net.Dialer{Timeout: c.timeout}
+	var nc net.Conn
+	switch u.Scheme {
+	case "ldap":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "389")
+		}
+		nc, err = dialer.DialContext(ctx, "tcp", host)
+	case "ldaps":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "636")
+		}
+		nc, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{ServerName: u.Hostname()})
+	default:
+		return nil, fmt.Errorf("ldap: unsupported scheme %q", u.Scheme)
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return &conn{
+		conn:   nc,
+		reader: bufio.NewReader(nc),
+		deadline: func() time.Time {
+			deadline := time.Now().Add(c.timeout)
+			if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+				return ctxDeadline
+			}
+			return deadline
+		},
+	}, nil
+}
+
+func (c *conn) send(op []byte, controls ...[]byte) (int64, error) {
+	c.messageID++
+	message := [][]byte{ber.Integer(c.messageID), op}
+	if len(controls) > 0 {
+		message = append(message, ber.Wrap(tagControls, controls...))
+	}
+	if err := c.conn.SetDeadline(c.deadline()); err != nil {
+		return 0, err
+	}
+	_, err := c.conn.Write(ber.Sequence(message...))
+	return c.messageID, err
+}
+
+// receive reads the next message of the request, returning its operation and controls
+func (c *conn) receive(messageID int64) (ber.Element, []ber.Element, error) {
+	for {
+		message, err := ber.Read(c.reader)
+		if err != nil {
+			return ber.Element{}, nil, err
+		}
+		children, err := message.Children()
+		if err != nil {
+			return ber.Element{}, nil, err
+		}
+		if len(children) < 2 {
+			return ber.Element{}, nil, ber.ErrInvalid
+		}
+		id, err := children[0].Int()
+		if err != nil {
+			return ber.Element{}, nil, err
+		}
+		if id != messageID {
+			// e.g. a notice of disconnection
+			continue
+		}
+		var controls []ber.Element
+		if len(children) > 2 && children[2].Tag == tagControls {
+			if controls, err = children[2].Children(); err != nil {
+				return ber.Element{}, nil, err
+			}
+		}
+		return children[1], controls, nil
+	}
+}
+
+func result(op ber.Element) (int64, error) {
+	children, err := op.Children()
+	if err != nil {
+		return 0, err
+	}
+	if len(children) < 3 {
+		return 0, ber.ErrInvalid
+	}
+	code, err := children[0].Int()
+	if err != nil {
+		return 0, err
+	}
+	if code != resultSuccess {
+		return code, &ResultError{Code: code, Message: children[2].String()}
+	}
+	return code, nil
+}
+
+func (c *conn) bind(dn string, password string) error {
+	op := ber.Wrap(opBindRequest, ber.Integer(3), ber.OctetString(dn), ber.Encode(tagSimplePassword, []byte(password)))
+	id, err := c.send(op)
+	if err != nil {
+		return err
+	}
+	res, _, err := c.receive(id)
+	if err != nil {
+		return err
+	}
+	if res.Tag != opBindResponse {
+		return ber.ErrInvalid
+	}
+	_, err = result(res)
+	return err
+}
+
+// search runs one search request and returns the entries with the cookie of the next page
+func (c *conn) search(baseDN string, filter []byte, sizeLimit int64, attributes []string, paged bool, cookie string) ([]domain.LDAPEntry, string, error) {
+	var requested [][]byte
+	for _, a := range attributes {
+		requested = append(requested, ber.OctetString(a))
+	}
+	op := ber.Wrap(opSearchRequest,
+		ber.OctetString(baseDN),
+		ber.Enumerated(scopeWholeSubtree),
+		ber.Enumerated(derefAliasesNever),
+		ber.Integer(sizeLimit),
+		ber.Integer(0),
+		ber.Boolean(false),
+		filter,
+		ber.Sequence(requested...),
+	)
+	var controls [][]byte
+	if paged {
+		controls = append(controls, ber.Sequence(ber.OctetString(pagedResultsOID),
+			ber.OctetString(string(ber.Sequence(ber.Integer(pageSize), ber.OctetString(cookie))))))
+	}
+	id, err := c.send(op, controls...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var entries []domain.LDAPEntry
+	for {
+		res, resControls, err := c.receive(id)
+		if err != nil {
+			return nil, "", err
+		}
+		switch res.Tag {
+		case opSearchEntry:
+			entry, err := parseEntry(res)
+			if err != nil {
+				return nil, "", err
+			}
+			entries = append(entries, entry)
+		case opSearchReference:
+			// referrals to other servers are not followed
+		case opSearchDone:
+			code, err := result(res)
+			if err != nil && code != resultSizeLimit {
+				return nil, "", err
+			}
+			if code == resultSizeLimit {
+				return entries, "", err
+			}
+			return entries, nextCookie(resControls), nil
+		default:
+			return nil, "", ber.ErrInvalid
+		}
+	}
+}
+
+func parseEntry(op ber.Element) (domain.LDAPEntry, error) {
+	children, err := op.Children()
+	if err != nil || len(children) < 2 {
+		return domain.LDAPEntry{}, ber.ErrInvalid
+	}
+	entry := domain.LDAPEntry{DN: children[0].String(), Attributes: make(map[string][]string)}
+	attributes, err := children[1].Children()
+	if err != nil {
+		return domain.LDAPEntry{}, err
+	}
+	for _, a := range attributes {
+		parts, err := a.Children()
+		if err != nil || len(parts) < 2 {
+			return domain.LDAPEntry{}, ber.ErrInvalid
+		}
+		values, err := parts[1].Children()
+		if err != nil {
+			return domain.LDAPEntry{}, err
+		}
+		name := strings.ToLower(parts[0].String())
+		for _, v := range values {
+			entry.Attributes[name] = append(entry.Attributes[name], v.String())
+		}
+	}
+	return entry, nil
+}
+
+// nextCookie returns the cookie of the paged results control, empty on the last page
+func nextCookie(controls []ber.Element) string {
+	for _, control := range controls {
+		parts, err := control.Children()
+		if err != nil || len(parts) < 2 || parts[0].String() != pagedResultsOID {
+			continue
+		}
+		value, _, err := ber.Parse(parts[len(parts)-1].Value)
+		if err != nil {
+			return ""
+		}
+		fields, err := value.Children()
+		if err != nil || len(fields) < 2 {
+			return ""
+		}
+		return fields[1].String()
+	}
+	return ""
+}
+
+func (c *conn) close() {
+	if _, err := c.send(ber.Encode(opUnbindRequest, nil)); err != nil {
+		log.Error(err)
+	}
+	if err := c.conn.Close(); err != nil {
+		log.Error(err)
+	}
+}
+
+// open connects and binds with the service account of the directory
+func (c *ldapClient) open(ctx context.Context, d *domain.LDAPDirectory) (*conn, error) {
+	conn, err := c.dial(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.bind(d.BindDN, d.BindPassword); err != nil {
+		log.Errorf("bind as %s failed: %v", d.BindDN, err)
+		conn.close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func attributes(d *domain.LDAPDirectory) []string {
+	return []string{d.UsernameAttribute, d.EmailAttribute, d.FirstNameAttribute, d.LastNameAttribute, d.GroupAttribute}
+}
+
+// Authenticate looks up the user with the service account, then binds as the user to check the password
+func (c *ldapClient) Authenticate(ctx context.Context, d *domain.LDAPDirectory, username string, password string) (*domain.LDAPEntry, error) {
+	// a simple bind without password is an unauthenticated bind and always succeeds
+	if username == "" || password == "" {
+		return nil, domain.ErrInvalidCredentials
+	}
+	filter, err := compileFilter(fmt.Sprintf("(&%s(%s=%s))", d.UserFilter, d.UsernameAttribute, escapeFilter(username)))
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.open(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.close()
+
+	entries, _, err := conn.search(d.BaseDN, filter, 2, attributes(d), false, "")
+	if err != nil && !isResult(err, resultSizeLimit) {
+		return nil, err
+	}
+	if len(entries) != 1 {
+		return nil, domain.ErrInvalidCredentials
+	}
+	err = conn.bind(entries[0].DN, password)
+	if isResult(err, resultInvalidCreds) {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
+// Users returns every user matching the user filter, page by page
+func (c *ldapClient) Users(ctx context.Context, d *domain.LDAPDirectory) ([]domain.LDAPEntry, error) {
+	filter, err := compileFilter(d.UserFilter)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.open(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.close()
+
+	var users []domain.LDAPEntry
+	cookie := ""
+	for {
+		entries, next, err := conn.search(d.BaseDN, filter, 0, attributes(d), true, cookie)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, entries...)
+		if next == "" {
+			return users, nil
+		}
+		cookie = next
+	}
+}
+
+func isResult(err error, code int64) bool {
+	e, ok := err.(*ResultError)
+	return ok && e.Code == code
+}
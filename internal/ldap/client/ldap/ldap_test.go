@@ -0,0 +1,125 @@
+package ldap_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	_ldapClient "github.com/meroedu/meroedu/internal/ldap/client/ldap"
+	"github.com/meroedu/meroedu/internal/ldap/client/ldap/ldaptest"
+)
+
+func newDirectory() (*ldaptest.Server, *domain.LDAPDirectory) {
+	server := ldaptest.NewServer()
+	server.AddEntry("CN=meroedu,OU=Service,DC=school,DC=local", "bind-s3cret", nil)
+	server.AddEntry("CN=Dinesh Katwal,OU=Staff,DC=school,DC=local", "dinesh-pass", map[string][]string{
+		"objectClass":    {"top", "person"},
+		"sAMAccountName": {"dinesh"},
+		"mail":           {"dinesh@school.local"},
+		"givenName":      {"Dinesh"},
+		"sn":             {"Katwal"},
+		"memberOf":       {"CN=Teachers,OU=Groups,DC=school,DC=local", "CN=Staff,OU=Groups,DC=school,DC=local"},
+		"description":    {"not requested"},
+	})
+	server.AddEntry("CN=Disabled,OU=Staff,DC=school,DC=local", "", map[string][]string{
+		"objectClass":    {"top", "computer"},
+		"sAMAccountName": {"disabled"},
+	})
+	return server, &domain.LDAPDirectory{
+		URL:                server.URL,
+		BindDN:             "CN=meroedu,OU=Service,DC=school,DC=local",
+		BindPassword:       "bind-s3cret",
+		BaseDN:             "DC=school,DC=local",
+		UserFilter:         "(objectClass=person)",
+		UsernameAttribute:  "sAMAccountName",
+		EmailAttribute:     "mail",
+		FirstNameAttribute: "givenName",
+		LastNameAttribute:  "sn",
+		GroupAttribute:     "memberOf",
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	server, directory := newDirectory()
+	defer server.Close()
+	client := _ldapClient.Init(time.Second)
+
+	t.Run("success", func(t *testing.T) {
+		entry, err := client.Authenticate(context.TODO(), directory, "DINESH", "dinesh-pass")
+		assert.NoError(t, err)
+		assert.Equal(t, "CN=Dinesh Katwal,OU=Staff,DC=school,DC=local", entry.DN)
+		assert.Equal(t, "dinesh@school.local", entry.Value("mail"))
+		assert.Equal(t, "dinesh", entry.Value("sAMAccountName"))
+		assert.Len(t, entry.Values("memberof"), 2)
+		assert.Empty(t, entry.Values("description"))
+	})
+	t.Run("wrong-password", func(t *testing.T) {
+		_, err := client.Authenticate(context.TODO(), directory, "dinesh", "wrong-pass")
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+	})
+	t.Run("empty-password", func(t *testing.T) {
+		_, err := client.Authenticate(context.TODO(), directory, "dinesh", "")
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+	})
+	t.Run("filter-injection", func(t *testing.T) {
+		_, err := client.Authenticate(context.TODO(), directory, "*", "dinesh-pass")
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+		_, err = client.Authenticate(context.TODO(), directory, "d*)(objectClass=*", "dinesh-pass")
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+	})
+	t.Run("not-matching-user-filter", func(t *testing.T) {
+		_, err := client.Authenticate(context.TODO(), directory, "disabled", "any")
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+	})
+	t.Run("wrong-bind-password", func(t *testing.T) {
+		d := *directory
+		d.BindPassword = "wrong"
+		_, err := client.Authenticate(context.TODO(), &d, "dinesh", "dinesh-pass")
+		assert.Error(t, err)
+		assert.NotEqual(t, domain.ErrInvalidCredentials, err)
+	})
+}
+
+func TestUsers(t *testing.T) {
+	server, directory := newDirectory()
+	defer server.Close()
+	for i := 0; i < 5; i++ {
+		server.AddEntry(fmt.Sprintf("CN=Student %d,OU=Students,DC=school,DC=local", i), "", map[string][]string{
+			"objectClass":    {"person"},
+			"sAMAccountName": {fmt.Sprintf("student%d", i)},
+		})
+	}
+	server.PageSize = 2
+	client := _ldapClient.Init(time.Second)
+
+	t.Run("paged", func(t *testing.T) {
+		users, err := client.Users(context.TODO(), directory)
+		assert.NoError(t, err)
+		assert.Len(t, users, 6)
+	})
+	t.Run("substring-filter", func(t *testing.T) {
+		d := *directory
+		d.UserFilter = "(&(objectClass=person)(|(sAMAccountName=stud*1)(cn=*Katwal)))"
+		users, err := client.Users(context.TODO(), &d)
+		assert.NoError(t, err)
+		assert.Len(t, users, 1)
+		assert.Equal(t, "student1", users[0].Value("sAMAccountName"))
+	})
+	t.Run("negation", func(t *testing.T) {
+		d := *directory
+		d.UserFilter = "(&(objectClass=person)(!(mail=*)))"
+		users, err := client.Users(context.TODO(), &d)
+		assert.NoError(t, err)
+		assert.Len(t, users, 5)
+	})
+	t.Run("invalid-filter", func(t *testing.T) {
+		d := *directory
+		d.UserFilter = "(objectClass=person"
+		_, err := client.Users(context.TODO(), &d)
+		assert.Error(t, err)
+	})
+}
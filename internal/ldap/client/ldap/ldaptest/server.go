@@ -0,0 +1,336 @@
+// Package ldaptest provides an in-process LDAP server for tests. It supports simple binds and subtree
+// searches with the and, or, not, equality, substring and presence filters, the size limit and the
+// paged results control.
+package ldaptest
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/ber"
+)
+
+const (
+	resultSuccess            = 0
+	resultSizeLimitExceeded  = 4
+	resultInvalidCredentials = 49
+	resultInsufficientAccess = 50
+	resultUnwillingToPerform = 53
+	pagedResultsOID          = "1.2.840.113556.1.4.319"
+	tagControls              = ber.ClassContext | ber.Constructed | 0
+	opBindRequest            = ber.ClassApplication | ber.Constructed | 0
+	opUnbindRequest          = ber.ClassApplication | 2
+	opSearchRequest          = ber.ClassApplication | ber.Constructed | 3
+	filterAnd                = ber.ClassContext | ber.Constructed | 0
+	filterOr                 = ber.ClassContext | ber.Constructed | 1
+	filterNot                = ber.ClassContext | ber.Constructed | 2
+	filterEquality           = ber.ClassContext | ber.Constructed | 3
+	filterSubstring          = ber.ClassContext | ber.Constructed | 4
+	filterPresent            = ber.ClassContext | 7
+	substringInitial         = ber.ClassContext | 0
+	substringFinal           = ber.ClassContext | 2
+)
+
+// Server is an LDAP server listening on the loopback interface
+type Server struct {
+	// URL is ldap://127.0.0.1:port
+	URL string
+	// PageSize caps the page size of paged searches when set
+	PageSize int
+
+	listener  net.Listener
+	mutex     sync.Mutex
+	entries   []domain.LDAPEntry
+	passwords map[string]string
+}
+
+// NewServer starts a server. Close it when done.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("ldaptest: failed to listen: " + err.Error())
+	}
+	s := &Server{
+		URL:       "ldap://" + listener.Addr().String(),
+		listener:  listener,
+		passwords: make(map[string]string),
+	}
+	go s.serve()
+	return s
+}
+
+// AddEntry adds an entry; it can bind with the password when one is given. Attribute names are case insensitive.
+func (s *Server) AddEntry(dn string, password string, attributes map[string][]string) {
+	entry := domain.LDAPEntry{DN: dn, Attributes: make(map[string][]string)}
+	for name, values := range attributes {
+		entry.Attributes[strings.ToLower(name)] = values
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries = append(s.entries, entry)
+	if password != "" {
+		s.passwords[strings.ToLower(dn)] = password
+	}
+}
+
+// Close stops the server
+func (s *Server) Close() {
+	s.listener.Close()
+}
+
+func (s *Server) serve() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(c)
+	}
+}
+
+func message(id int64, op []byte, controls ...[]byte) []byte {
+	if len(controls) > 0 {
+		return ber.Sequence(ber.Integer(id), op, ber.Wrap(tagControls, controls...))
+	}
+	return ber.Sequence(ber.Integer(id), op)
+}
+
+func response(tag byte, code int64, diagnostic string) []byte {
+	return ber.Wrap(ber.ClassApplication|tag, ber.Enumerated(code), ber.OctetString(""), ber.OctetString(diagnostic))
+}
+
+func (s *Server) handle(c net.Conn) {
+	defer c.Close()
+	reader := bufio.NewReader(c)
+	bound := false
+	for {
+		msg, err := ber.Read(reader)
+		if err != nil {
+			return
+		}
+		parts, err := msg.Children()
+		if err != nil || len(parts) < 2 {
+			return
+		}
+		id, _ := parts[0].Int()
+		var controls []ber.Element
+		if len(parts) > 2 && parts[2].Tag == tagControls {
+			controls, _ = parts[2].Children()
+		}
+		var out [][]byte
+		switch parts[1].Tag {
+		case opBindRequest:
+			var code int64
+			code, bound = s.bind(parts[1])
+			out = append(out, message(id, response(1, code, "")))
+		case opUnbindRequest:
+			return
+		case opSearchRequest:
+			if !bound {
+				out = append(out, message(id, response(5, resultInsufficientAccess, "bind required")))
+				break
+			}
+			out = s.search(id, parts[1], controls)
+		default:
+			out = append(out, message(id, response(1, resultUnwillingToPerform, "unsupported operation")))
+		}
+		for _, b := range out {
+			if _, err = c.Write(b); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// bind checks a simple bind. A bind without password is unauthenticated and succeeds, like on most
+// servers, but searches stay refused.
+func (s *Server) bind(op ber.Element) (int64, bool) {
+	fields, err := op.Children()
+	if err != nil || len(fields) < 3 {
+		return resultUnwillingToPerform, false
+	}
+	dn, password := fields[1].String(), fields[2].String()
+	if password == "" {
+		return resultSuccess, false
+	}
+	s.mutex.Lock()
+	expected, ok := s.passwords[strings.ToLower(dn)]
+	s.mutex.Unlock()
+	if !ok || expected != password {
+		return resultInvalidCredentials, false
+	}
+	return resultSuccess, true
+}
+
+func (s *Server) search(id int64, op ber.Element, controls []ber.Element) [][]byte {
+	fields, err := op.Children()
+	if err != nil || len(fields) < 8 {
+		return [][]byte{message(id, response(5, resultUnwillingToPerform, "invalid search"))}
+	}
+	base := strings.ToLower(fields[0].String())
+	sizeLimit, _ := fields[3].Int()
+	var attributes []string
+	requested, _ := fields[7].Children()
+	for _, a := range requested {
+		attributes = append(attributes, strings.ToLower(a.String()))
+	}
+
+	s.mutex.Lock()
+	var matches []domain.LDAPEntry
+	for _, e := range s.entries {
+		dn := strings.ToLower(e.DN)
+		if (dn == base || strings.HasSuffix(dn, ","+base)) && match(fields[6], &e) {
+			matches = append(matches, e)
+		}
+	}
+	s.mutex.Unlock()
+
+	var out [][]byte
+	code := int64(resultSuccess)
+	var doneControls [][]byte
+	if size, cookie, ok := pagedControl(controls); ok {
+		if s.PageSize > 0 && (size == 0 || size > s.PageSize) {
+			size = s.PageSize
+		}
+		offset, _ := strconv.Atoi(cookie)
+		if offset > len(matches) {
+			offset = len(matches)
+		}
+		matches = matches[offset:]
+		next := ""
+		if size > 0 && len(matches) > size {
+			matches = matches[:size]
+			next = strconv.Itoa(offset + size)
+		}
+		doneControls = append(doneControls, ber.Sequence(ber.OctetString(pagedResultsOID),
+			ber.OctetString(string(ber.Sequence(ber.Integer(0), ber.OctetString(next))))))
+	}
+	if sizeLimit > 0 && int64(len(matches)) > sizeLimit {
+		matches = matches[:sizeLimit]
+		code = resultSizeLimitExceeded
+	}
+	for _, e := range matches {
+		out = append(out, message(id, encodeEntry(&e, attributes)))
+	}
+	return append(out, message(id, response(5, code, ""), doneControls...))
+}
+
+func pagedControl(controls []ber.Element) (int, string, bool) {
+	for _, control := range controls {
+		parts, err := control.Children()
+		if err != nil || len(parts) < 2 || parts[0].String() != pagedResultsOID {
+			continue
+		}
+		value, _, err := ber.Parse(parts[len(parts)-1].Value)
+		if err != nil {
+			return 0, "", false
+		}
+		fields, err := value.Children()
+		if err != nil || len(fields) < 2 {
+			return 0, "", false
+		}
+		size, _ := fields[0].Int()
+		return int(size), fields[1].String(), true
+	}
+	return 0, "", false
+}
+
+func encodeEntry(e *domain.LDAPEntry, attributes []string) []byte {
+	var encoded [][]byte
+	for name, values := range e.Attributes {
+		if len(attributes) > 0 && !contains(attributes, name) {
+			continue
+		}
+		var vals [][]byte
+		for _, v := range values {
+			vals = append(vals, ber.OctetString(v))
+		}
+		encoded = append(encoded, ber.Sequence(ber.OctetString(name), ber.Wrap(ber.TagSet, vals...)))
+	}
+	return ber.Wrap(ber.ClassApplication|4, ber.OctetString(e.DN), ber.Sequence(encoded...))
+}
+
+// match evaluates the filter against the entry, comparing values case insensitively
+func match(filter ber.Element, e *domain.LDAPEntry) bool {
+	children, _ := filter.Children()
+	switch filter.Tag {
+	case filterAnd:
+		for _, c := range children {
+			if !match(c, e) {
+				return false
+			}
+		}
+		return true
+	case filterOr:
+		for _, c := range children {
+			if match(c, e) {
+				return true
+			}
+		}
+		return false
+	case filterNot:
+		return len(children) == 1 && !match(children[0], e)
+	case filterEquality:
+		if len(children) != 2 {
+			return false
+		}
+		for _, v := range e.Values(children[0].String()) {
+			if strings.EqualFold(v, children[1].String()) {
+				return true
+			}
+		}
+		return false
+	case filterSubstring:
+		if len(children) != 2 {
+			return false
+		}
+		parts, _ := children[1].Children()
+		for _, v := range e.Values(children[0].String()) {
+			if matchSubstrings(strings.ToLower(v), parts) {
+				return true
+			}
+		}
+		return false
+	case filterPresent:
+		return len(e.Values(filter.String())) > 0
+	}
+	return false
+}
+
+func matchSubstrings(v string, parts []ber.Element) bool {
+	for _, p := range parts {
+		s := strings.ToLower(p.String())
+		switch p.Tag {
+		case substringInitial:
+			if !strings.HasPrefix(v, s) {
+				return false
+			}
+			v = v[len(s):]
+		case substringFinal:
+			if !strings.HasSuffix(v, s) {
+				return false
+			}
+			v = v[:len(v)-len(s)]
+		default:
+			i := strings.Index(v, s)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(s):]
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// LDAPHandler ...
+type LDAPHandler struct {
+	LDAPUseCase domain.LDAPUseCase
+}
+
+// NewLDAPHandler ...
+func NewLDAPHandler(e *echo.Echo, us domain.LDAPUseCase) {
+	handler := &LDAPHandler{
+		LDAPUseCase: us,
+	}
+	e.POST("/auth/ldap/login", handler.Login)
+	e.GET("/sso/ldap", handler.GetDirectory, rbac.Require(domain.PermSSOManage))
+	e.PUT("/sso/ldap", handler.SaveDirectory, rbac.Require(domain.PermSSOManage))
+	e.DELETE("/sso/ldap", handler.DeleteDirectory, rbac.Require(domain.PermSSOManage))
+	e.POST("/sso/ldap/sync", handler.Sync, rbac.Require(domain.PermSSOManage))
+}
+
+// Login godoc
+// @Summary Log in with the directory password.
+// @Description Log in with the username and password of the LDAP directory of the organization. Users logging in for the first time are imported. Returns a short lived access token and a refresh token, or a two-factor challenge.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param login body domain.LDAPLogin true "Credentials"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Invalid login or password"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/ldap/login [post]
+func (c *LDAPHandler) Login(echoContext echo.Context) error {
+	var login domain.LDAPLogin
+	err := echoContext.Bind(&login)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&login); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	tokens, err := c.LDAPUseCase.Login(ctx, &login)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    tokens,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetDirectory godoc
+// @Summary Get the LDAP directory.
+// @Description Get the LDAP directory of the organization. The bind password is not returned.
+// @Tags sso
+// @Accept */*
+// @Produce json
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "No directory"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /sso/ldap [get]
+func (c *LDAPHandler) GetDirectory(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	directory, err := c.LDAPUseCase.GetDirectory(ctx)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    directory,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// SaveDirectory godoc
+// @Summary Configure the LDAP directory.
+// @Description Create or replace the LDAP directory of the organization. The bind password is kept when omitted; attributes left empty get the Active Directory defaults.
+// @Tags sso
+// @Accept json
+// @Produce json
+// @Param directory body domain.LDAPDirectory true "LDAP directory"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Invalid data or unknown role"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /sso/ldap [put]
+func (c *LDAPHandler) SaveDirectory(echoContext echo.Context) error {
+	var directory domain.LDAPDirectory
+	err := echoContext.Bind(&directory)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&directory); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.LDAPUseCase.SaveDirectory(ctx, &directory); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    directory,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// DeleteDirectory godoc
+// @Summary Remove the LDAP directory.
+// @Description Remove the LDAP directory of the organization. Imported users and the teams of the groups are kept.
+// @Tags sso
+// @Accept */*
+// @Produce json
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "No directory"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /sso/ldap [delete]
+func (c *LDAPHandler) DeleteDirectory(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	if err := c.LDAPUseCase.DeleteDirectory(ctx); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// Sync godoc
+// @Summary Sync the LDAP directory now.
+// @Description Import the users of the LDAP directory of the organization and update the teams of their groups, without waiting for the periodic sync.
+// @Tags sso
+// @Accept */*
+// @Produce json
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "No directory"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /sso/ldap/sync [post]
+func (c *LDAPHandler) Sync(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	result, err := c.LDAPUseCase.Sync(ctx)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    result,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,146 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ldapHTTP "github.com/meroedu/meroedu/internal/ldap/delivery/http"
+)
+
+const directory = `{"url":"ldaps://dc.meroedu.com","bind_dn":"cn=meroedu,dc=meroedu,dc=com","bind_password":"secret","base_dn":"ou=people,dc=meroedu,dc=com","default_role":"learner","enabled":true}`
+
+func TestLogin(t *testing.T) {
+	mockUCase := new(mocks.LDAPUseCase)
+	mockUCase.On("Login", mock.Anything, &domain.LDAPLogin{OrganizationID: 1, Username: "ram", Password: "secret"}).
+		Return(&domain.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil).Once()
+	mockUCase.On("Login", mock.Anything, &domain.LDAPLogin{OrganizationID: 1, Username: "ram", Password: "wrong"}).
+		Return(nil, domain.ErrInvalidCredentials).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"organization_id":1,"username":"ram","password":"secret"}`, http.StatusOK},
+		{`{"organization_id":1,"username":"ram","password":"wrong"}`, http.StatusUnauthorized},
+		{`{"username":"ram","password":"secret"}`, http.StatusBadRequest},
+		{`{"organization_id":"meroedu"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/ldap/login", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := ldapHTTP.LDAPHandler{
+			LDAPUseCase: mockUCase,
+		}
+		err = handler.Login(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetDirectory(t *testing.T) {
+	mockUCase := new(mocks.LDAPUseCase)
+	mockUCase.On("GetDirectory", mock.Anything).Return(&domain.LDAPDirectory{ID: 1, URL: "ldaps://dc.meroedu.com"}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/sso/ldap", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := ldapHTTP.LDAPHandler{
+		LDAPUseCase: mockUCase,
+	}
+	err = handler.GetDirectory(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSaveDirectory(t *testing.T) {
+	mockUCase := new(mocks.LDAPUseCase)
+	mockUCase.On("SaveDirectory", mock.Anything, mock.MatchedBy(func(d *domain.LDAPDirectory) bool { return d.DefaultRole == "learner" })).Return(nil).Once()
+	mockUCase.On("SaveDirectory", mock.Anything, mock.MatchedBy(func(d *domain.LDAPDirectory) bool { return d.DefaultRole == "wizard" })).Return(domain.ErrBadParamInput).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{directory, http.StatusOK},
+		{strings.Replace(directory, "learner", "wizard", 1), http.StatusBadRequest},
+		{`{"url":"ldaps://dc.meroedu.com","default_role":"learner"}`, http.StatusBadRequest},
+		{`{"enabled":"yes"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/sso/ldap", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := ldapHTTP.LDAPHandler{
+			LDAPUseCase: mockUCase,
+		}
+		err = handler.SaveDirectory(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteDirectory(t *testing.T) {
+	mockUCase := new(mocks.LDAPUseCase)
+	mockUCase.On("DeleteDirectory", mock.Anything).Return(domain.ErrNotFound).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/sso/ldap", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := ldapHTTP.LDAPHandler{
+		LDAPUseCase: mockUCase,
+	}
+	err = handler.DeleteDirectory(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSync(t *testing.T) {
+	mockUCase := new(mocks.LDAPUseCase)
+	mockUCase.On("Sync", mock.Anything).Return(&domain.LDAPSyncResult{Users: 3, Created: 1, Teams: 2}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/sso/ldap/sync", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := ldapHTTP.LDAPHandler{
+		LDAPUseCase: mockUCase,
+	}
+	err = handler.Sync(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"created":1`)
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,228 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const directoryColumns = `id,organization_id,url,bind_dn,bind_password,base_dn,user_filter,username_attribute,email_attribute,
+	first_name_attribute,last_name_attribute,group_attribute,group_base_dn,default_role,enabled,last_sync_at,updated_at,created_at`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the LDAP Repository interface
+func Init(db *sql.DB) domain.LDAPRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]domain.LDAPDirectory, error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer func() {
+		if errRow := rows.Close(); errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result := make([]domain.LDAPDirectory, 0)
+	for rows.Next() {
+		d := domain.LDAPDirectory{}
+		var bindPassword, groupBaseDN sql.NullString
+		var lastSyncAt sql.NullInt64
+		err = rows.Scan(
+			&d.ID,
+			&d.OrganizationID,
+			&d.URL,
+			&d.BindDN,
+			&bindPassword,
+			&d.BaseDN,
+			&d.UserFilter,
+			&d.UsernameAttribute,
+			&d.EmailAttribute,
+			&d.FirstNameAttribute,
+			&d.LastNameAttribute,
+			&d.GroupAttribute,
+			&groupBaseDN,
+			&d.DefaultRole,
+			&d.Enabled,
+			&lastSyncAt,
+			&d.UpdatedAt,
+			&d.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		d.BindPassword = bindPassword.String
+		d.GroupBaseDN = groupBaseDN.String
+		d.LastSyncAt = lastSyncAt.Int64
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// GetDirectory returns the directory of the caller's organization
+func (m *mysqlRepository) GetDirectory(ctx context.Context) (*domain.LDAPDirectory, error) {
+	query := `SELECT ` + directoryColumns + ` FROM ldap_directories WHERE organization_id = ?`
+	list, err := m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// GetEnabledDirectories returns the enabled directories of every organization, for the periodic sync
+func (m *mysqlRepository) GetEnabledDirectories(ctx context.Context) ([]domain.LDAPDirectory, error) {
+	query := `SELECT ` + directoryColumns + ` FROM ldap_directories WHERE enabled = 1 ORDER BY organization_id`
+	return m.fetch(ctx, query)
+}
+
+// SaveDirectory creates or replaces the directory of the caller's organization
+func (m *mysqlRepository) SaveDirectory(ctx context.Context, d *domain.LDAPDirectory) error {
+	d.OrganizationID = domain.OrganizationIDFromContext(ctx)
+	query := `INSERT INTO ldap_directories (organization_id,url,bind_dn,bind_password,base_dn,user_filter,username_attribute,email_attribute,
+		first_name_attribute,last_name_attribute,group_attribute,group_base_dn,default_role,enabled,updated_at,created_at)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE id=LAST_INSERT_ID(id),url=VALUES(url),bind_dn=VALUES(bind_dn),
+		bind_password=VALUES(bind_password),base_dn=VALUES(base_dn),user_filter=VALUES(user_filter),username_attribute=VALUES(username_attribute),
+		email_attribute=VALUES(email_attribute),first_name_attribute=VALUES(first_name_attribute),last_name_attribute=VALUES(last_name_attribute),
+		group_attribute=VALUES(group_attribute),group_base_dn=VALUES(group_base_dn),default_role=VALUES(default_role),enabled=VALUES(enabled),
+		updated_at=VALUES(updated_at)`
+	res, err := m.conn.ExecContext(ctx, query, d.OrganizationID, d.URL, d.BindDN,
+		sql.NullString{String: d.BindPassword, Valid: d.BindPassword != ""}, d.BaseDN, d.UserFilter, d.UsernameAttribute, d.EmailAttribute,
+		d.FirstNameAttribute, d.LastNameAttribute, d.GroupAttribute, sql.NullString{String: d.GroupBaseDN, Valid: d.GroupBaseDN != ""},
+		d.DefaultRole, d.Enabled, d.UpdatedAt, d.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	d.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+	}
+	return err
+}
+
+// DeleteDirectory removes the directory of the caller's organization. The teams of its groups are kept.
+func (m *mysqlRepository) DeleteDirectory(ctx context.Context) error {
+	res, err := m.conn.ExecContext(ctx, `DELETE FROM ldap_directories WHERE organization_id = ?`, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateLastSync records the time of the last successful sync of the caller's organization
+func (m *mysqlRepository) UpdateLastSync(ctx context.Context, syncedAt int64) error {
+	_, err := m.conn.ExecContext(ctx, `UPDATE ldap_directories SET last_sync_at = ? WHERE organization_id = ?`,
+		syncedAt, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+	}
+	return err
+}
+
+// GetLinkedUser returns the id of the user imported from the entry of the caller's organization's directory.
+// Links outlive the directory, so a replaced directory keeps its users.
+func (m *mysqlRepository) GetLinkedUser(ctx context.Context, dn string) (int64, error) {
+	var userID int64
+	err := m.conn.QueryRowContext(ctx, `SELECT user_id FROM ldap_identities WHERE organization_id = ? AND dn = ?`,
+		domain.OrganizationIDFromContext(ctx), dn).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+	return userID, nil
+}
+
+// LinkUser records the user as imported from the entry of the caller's organization's directory
+func (m *mysqlRepository) LinkUser(ctx context.Context, userID int64, dn string, now int64) error {
+	_, err := m.conn.ExecContext(ctx, `INSERT INTO ldap_identities (user_id,organization_id,dn,created_at) VALUES (?,?,?,?)`,
+		userID, domain.OrganizationIDFromContext(ctx), dn, now)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+	}
+	return err
+}
+
+// SaveGroupTeam returns the team of the group in the caller's organization, creating it on the first sync.
+// The name follows the group.
+func (m *mysqlRepository) SaveGroupTeam(ctx context.Context, groupDN string, name string, roleID int64, now int64) (int64, error) {
+	query := `INSERT INTO teams (name,role_id,organization_id,ldap_group_dn,updated_at,created_at) VALUES (?,?,?,?,?,?)
+		ON DUPLICATE KEY UPDATE id=LAST_INSERT_ID(id),updated_at=IF(name=VALUES(name),updated_at,VALUES(updated_at)),name=VALUES(name)`
+	res, err := m.conn.ExecContext(ctx, query, name, roleID, domain.OrganizationIDFromContext(ctx), groupDN, now, now)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// SetGroupTeams makes the user a member of exactly the given teams among the group teams of the caller's
+// organization. Memberships of other teams are left alone.
+func (m *mysqlRepository) SetGroupTeams(ctx context.Context, userID int64, teamIDs []int64, now int64) (err error) {
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `DELETE tu FROM teams_users tu JOIN teams t ON t.id = tu.team_id
+		WHERE tu.user_id = ? AND t.organization_id = ? AND t.ldap_group_dn IS NOT NULL`
+	args := []interface{}{userID, organizationID}
+	if len(teamIDs) > 0 {
+		query += ` AND tu.team_id NOT IN (?` + strings.Repeat(",?", len(teamIDs)-1) + `)`
+		for _, id := range teamIDs {
+			args = append(args, id)
+		}
+	}
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		log.Error(err)
+		return
+	}
+
+	query = `INSERT INTO teams_users (team_id,user_id,created_at) SELECT t.id,?,? FROM teams t
+		WHERE t.id = ? AND t.organization_id = ? AND t.ldap_group_dn IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM teams_users tu WHERE tu.team_id = t.id AND tu.user_id = ?)`
+	for _, id := range teamIDs {
+		if _, err = tx.ExecContext(ctx, query, userID, now, id, organizationID, userID); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+	return
+}
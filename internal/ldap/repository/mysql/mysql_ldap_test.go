@@ -0,0 +1,132 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/ldap/repository/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+func TestGetDirectory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	columns := []string{"id", "organization_id", "url", "bind_dn", "bind_password", "base_dn", "user_filter", "username_attribute",
+		"email_attribute", "first_name_attribute", "last_name_attribute", "group_attribute", "group_base_dn", "default_role", "enabled",
+		"last_sync_at", "updated_at", "created_at"}
+	rows := sqlmock.NewRows(columns).AddRow(1, 2, "ldaps://dc.school.local", "CN=meroedu", "s3cret", "DC=school,DC=local",
+		"(objectClass=person)", "sAMAccountName", "mail", "givenName", "sn", "memberOf", nil, domain.RoleLearner, true, nil,
+		time.Now().Unix(), time.Now().Unix())
+	mock.ExpectQuery(`SELECT .+ FROM ldap_directories WHERE organization_id = \?`).WithArgs(2).WillReturnRows(rows)
+
+	repo := mysqlrepo.Init(db)
+	d, err := repo.GetDirectory(orgCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", d.BindPassword)
+	assert.Empty(t, d.GroupBaseDN)
+	assert.Equal(t, int64(0), d.LastSyncAt)
+}
+
+func TestGetLinkedUser(t *testing.T) {
+	query := `SELECT user_id FROM ldap_identities WHERE organization_id = \? AND dn = \?`
+	dn := "CN=Dinesh Katwal,OU=Staff,DC=school,DC=local"
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(query).WithArgs(2, dn).WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(9))
+
+		repo := mysqlrepo.Init(db)
+		userID, err := repo.GetLinkedUser(orgCtx, dn)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9), userID)
+	})
+	t.Run("not-linked", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(query).WithArgs(2, dn).WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+		repo := mysqlrepo.Init(db)
+		_, err = repo.GetLinkedUser(orgCtx, dn)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestLinkUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now().Unix()
+	dn := "CN=Dinesh Katwal,OU=Staff,DC=school,DC=local"
+	mock.ExpectExec(`INSERT INTO ldap_identities \(user_id,organization_id,dn,created_at\) VALUES`).
+		WithArgs(9, 2, dn, now).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := mysqlrepo.Init(db)
+	assert.NoError(t, repo.LinkUser(orgCtx, 9, dn, now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveGroupTeam(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now().Unix()
+	mock.ExpectExec(`INSERT INTO teams \(name,role_id,organization_id,ldap_group_dn,updated_at,created_at\) VALUES .+ ON DUPLICATE KEY UPDATE id=LAST_INSERT_ID\(id\)`).
+		WithArgs("Teachers", 3, 2, "CN=Teachers,DC=school,DC=local", now, now).WillReturnResult(sqlmock.NewResult(4, 1))
+
+	repo := mysqlrepo.Init(db)
+	id, err := repo.SaveGroupTeam(orgCtx, "CN=Teachers,DC=school,DC=local", "Teachers", 3, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), id)
+}
+
+func TestSetGroupTeams(t *testing.T) {
+	now := time.Now().Unix()
+	insert := `INSERT INTO teams_users \(team_id,user_id,created_at\) SELECT t.id,\?,\? FROM teams t WHERE t.id = \? AND t.organization_id = \?`
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE tu FROM teams_users tu JOIN teams t ON t.id = tu.team_id .+ AND t.ldap_group_dn IS NOT NULL AND tu.team_id NOT IN \(\?,\?\)`).
+			WithArgs(9, 2, 4, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(insert).WithArgs(9, now, 4, 2, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(insert).WithArgs(9, now, 5, 2, 9).WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		err = repo.SetGroupTeams(orgCtx, 9, []int64{4, 5}, now)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("no-groups", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE tu FROM teams_users tu .+ AND t.ldap_group_dn IS NOT NULL$`).
+			WithArgs(9, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		err = repo.SetGroupTeams(orgCtx, 9, []int64{}, now)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,39 @@
+package ldap
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// SyncJob syncs the LDAP directories of every organization periodically
+type SyncJob struct {
+	ldapUseCase domain.LDAPUseCase
+	interval    time.Duration
+}
+
+// NewSyncJob will create a job syncing the enabled directories every interval
+func NewSyncJob(us domain.LDAPUseCase, interval time.Duration) *SyncJob {
+	return &SyncJob{
+		ldapUseCase: us,
+		interval:    interval,
+	}
+}
+
+// Start runs the job every interval until ctx is done
+func (j *SyncJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		if err := j.ldapUseCase.SyncAll(ctx); err != nil {
+			log.Errorf("Error while syncing the LDAP directories: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// Defaults of the directory settings, matching Active Directory
+const (
+	defaultUserFilter         = "(objectClass=person)"
+	defaultUsernameAttribute  = "sAMAccountName"
+	defaultEmailAttribute     = "mail"
+	defaultFirstNameAttribute = "givenName"
+	defaultLastNameAttribute  = "sn"
+	defaultGroupAttribute     = "memberOf"
+)
+
+// LDAPUseCase ...
+type LDAPUseCase struct {
+	ldapRepo       domain.LDAPRepository
+	client         domain.LDAPClient
+	userRepo       domain.UserRepository
+	roleRepo       domain.RoleRepository
+	authUseCase    domain.AuthUseCase
+	contextTimeOut time.Duration
+	syncTimeOut    time.Duration
+}
+
+// NewLDAPUseCase will create new an LDAPUseCase. A sync of a directory may run for syncTimeout.
+func NewLDAPUseCase(l domain.LDAPRepository, client domain.LDAPClient, u domain.UserRepository, r domain.RoleRepository, a domain.AuthUseCase, timeout time.Duration, syncTimeout time.Duration) domain.LDAPUseCase {
+	return &LDAPUseCase{
+		ldapRepo:       l,
+		client:         client,
+		userRepo:       u,
+		roleRepo:       r,
+		authUseCase:    a,
+		contextTimeOut: timeout,
+		syncTimeOut:    syncTimeout,
+	}
+}
+
+// GetDirectory returns the directory of the caller's organization, without the bind password
+func (usecase *LDAPUseCase) GetDirectory(c context.Context) (*domain.LDAPDirectory, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	directory, err := usecase.ldapRepo.GetDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	directory.BindPassword = ""
+	return directory, nil
+}
+
+// SaveDirectory creates or replaces the directory of the caller's organization. The bind password is kept
+// when none is given and the attributes left empty get the Active Directory defaults. The default role can not
+// grant a permission the caller does not hold.
+func (usecase *LDAPUseCase) SaveDirectory(c context.Context, directory *domain.LDAPDirectory) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	role, err := usecase.roleRepo.GetByCode(ctx, directory.DefaultRole)
+	if err == domain.ErrNotFound {
+		return domain.ErrBadParamInput
+	}
+	if err != nil {
+		return err
+	}
+	if !domain.HasPermissions(ctx, role.Permissions) {
+		return domain.ErrForbidden
+	}
+
+	setDefault(&directory.UserFilter, defaultUserFilter)
+	setDefault(&directory.UsernameAttribute, defaultUsernameAttribute)
+	setDefault(&directory.EmailAttribute, defaultEmailAttribute)
+	setDefault(&directory.FirstNameAttribute, defaultFirstNameAttribute)
+	setDefault(&directory.LastNameAttribute, defaultLastNameAttribute)
+	setDefault(&directory.GroupAttribute, defaultGroupAttribute)
+	now := time.Now().Unix()
+	existing, err := usecase.ldapRepo.GetDirectory(ctx)
+	switch err {
+	case nil:
+		directory.CreatedAt = existing.CreatedAt
+		directory.LastSyncAt = existing.LastSyncAt
+		if directory.BindPassword == "" {
+			directory.BindPassword = existing.BindPassword
+		}
+	case domain.ErrNotFound:
+		directory.CreatedAt = now
+	default:
+		return err
+	}
+	directory.UpdatedAt = now
+	if err = usecase.ldapRepo.SaveDirectory(ctx, directory); err != nil {
+		return err
+	}
+	directory.BindPassword = ""
+	return nil
+}
+
+func setDefault(value *string, def string) {
+	if strings.TrimSpace(*value) == "" {
+		*value = def
+	}
+}
+
+// DeleteDirectory removes the directory of the caller's organization. Imported users and teams are kept.
+func (usecase *LDAPUseCase) DeleteDirectory(c context.Context) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.ldapRepo.DeleteDirectory(ctx)
+}
+
+// Login checks the password against the directory of the organization and issues a token pair. The
+// user is imported, and their teams updated, on the way.
+func (usecase *LDAPUseCase) Login(c context.Context, login *domain.LDAPLogin) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	ctx = domain.WithOrganizationID(ctx, login.OrganizationID)
+	directory, err := usecase.ldapRepo.GetDirectory(ctx)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !directory.Enabled {
+		return nil, domain.ErrInvalidCredentials
+	}
+	entry, err := usecase.client.Authenticate(ctx, directory, login.Username, login.Password)
+	if err != nil {
+		return nil, err
+	}
+	s, err := usecase.newSync(ctx, directory)
+	if err != nil {
+		return nil, err
+	}
+	user, _, err := s.importUser(ctx, entry)
+	if err == domain.ErrBadParamInput || err == domain.ErrConflict {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	return usecase.authUseCase.CompleteLogin(ctx, user)
+}
+
+// Sync imports the users of the directory of the caller's organization and updates their group teams
+func (usecase *LDAPUseCase) Sync(c context.Context) (*domain.LDAPSyncResult, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.syncTimeOut)
+	defer cancel()
+	directory, err := usecase.ldapRepo.GetDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.sync(ctx, directory)
+}
+
+// SyncAll syncs the enabled directory of every organization. A failing directory does not stop the others.
+func (usecase *LDAPUseCase) SyncAll(c context.Context) error {
+	directories, err := usecase.ldapRepo.GetEnabledDirectories(c)
+	if err != nil {
+		return err
+	}
+	for i := range directories {
+		ctx, cancel := context.WithTimeout(domain.WithOrganizationID(c, directories[i].OrganizationID), usecase.syncTimeOut)
+		res, err := usecase.sync(ctx, &directories[i])
+		cancel()
+		if err != nil {
+			log.Errorf("LDAP sync of organization %d failed: %v", directories[i].OrganizationID, err)
+			continue
+		}
+		log.Infof("LDAP sync of organization %d: %d users, %d created, %d skipped, %d teams",
+			directories[i].OrganizationID, res.Users, res.Created, res.Skipped, res.Teams)
+	}
+	return nil
+}
+
+func (usecase *LDAPUseCase) sync(ctx context.Context, directory *domain.LDAPDirectory) (*domain.LDAPSyncResult, error) {
+	entries, err := usecase.client.Users(ctx, directory)
+	if err != nil {
+		return nil, err
+	}
+	s, err := usecase.newSync(ctx, directory)
+	if err != nil {
+		return nil, err
+	}
+	res := &domain.LDAPSyncResult{}
+	for i := range entries {
+		res.Users++
+		_, created, err := s.importUser(ctx, &entries[i])
+		switch err {
+		case nil:
+			if created {
+				res.Created++
+			}
+		case domain.ErrBadParamInput, domain.ErrConflict:
+			log.Infof("LDAP sync of organization %d skipped %s: %v", directory.OrganizationID, entries[i].DN, err)
+			res.Skipped++
+		default:
+			return nil, err
+		}
+	}
+	res.Teams = len(s.teams)
+	if err = usecase.ldapRepo.UpdateLastSync(ctx, s.now); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// directorySync imports the entries of one directory, remembering the teams of the groups seen
+type directorySync struct {
+	usecase   *LDAPUseCase
+	directory *domain.LDAPDirectory
+	role      *domain.Role
+	teams     map[string]int64
+	now       int64
+}
+
+func (usecase *LDAPUseCase) newSync(ctx context.Context, directory *domain.LDAPDirectory) (*directorySync, error) {
+	role, err := usecase.roleRepo.GetByCode(ctx, directory.DefaultRole)
+	if err != nil {
+		return nil, err
+	}
+	return &directorySync{
+		usecase:   usecase,
+		directory: directory,
+		role:      role,
+		teams:     make(map[string]int64),
+		now:       time.Now().Unix(),
+	}, nil
+}
+
+// importUser returns the user linked to the entry, creating and linking one when there is none. Only
+// users imported from the directory are linked: an email already in use gives ErrConflict, as the directory
+// does not prove the entry owns the local account. Entries without email give ErrBadParamInput.
+func (s *directorySync) importUser(ctx context.Context, entry *domain.LDAPEntry) (*domain.User, bool, error) {
+	d := s.directory
+	created := false
+	var user *domain.User
+	userID, err := s.usecase.ldapRepo.GetLinkedUser(ctx, entry.DN)
+	switch err {
+	case nil:
+		if user, err = s.usecase.userRepo.GetByID(ctx, userID); err != nil {
+			return nil, false, err
+		}
+	case domain.ErrNotFound:
+		if user, err = s.createUser(ctx, entry); err != nil {
+			return nil, false, err
+		}
+		created = true
+	default:
+		return nil, false, err
+	}
+
+	teamIDs := make([]int64, 0)
+	for _, group := range entry.Values(d.GroupAttribute) {
+		if d.GroupBaseDN != "" && !strings.HasSuffix(strings.ToLower(group), ","+strings.ToLower(d.GroupBaseDN)) {
+			continue
+		}
+		id, ok := s.teams[strings.ToLower(group)]
+		if !ok {
+			if id, err = s.usecase.ldapRepo.SaveGroupTeam(ctx, group, groupName(group), s.role.ID, s.now); err != nil {
+				return nil, false, err
+			}
+			s.teams[strings.ToLower(group)] = id
+		}
+		teamIDs = append(teamIDs, id)
+	}
+	if err = s.usecase.ldapRepo.SetGroupTeams(ctx, user.ID, teamIDs, s.now); err != nil {
+		return nil, false, err
+	}
+	return user, created, nil
+}
+
+// createUser creates the user of an entry not linked yet and links it
+func (s *directorySync) createUser(ctx context.Context, entry *domain.LDAPEntry) (*domain.User, error) {
+	d := s.directory
+	email := entry.Value(d.EmailAttribute)
+	if email == "" {
+		return nil, domain.ErrBadParamInput
+	}
+	_, err := s.usecase.userRepo.GetByEmail(ctx, email)
+	if err == nil {
+		return nil, domain.ErrConflict
+	}
+	if err != domain.ErrNotFound {
+		return nil, err
+	}
+	user := &domain.User{
+		FirstName:      entry.Value(d.FirstNameAttribute),
+		LastName:       entry.Value(d.LastNameAttribute),
+		Email:          email,
+		OrganizationID: d.OrganizationID,
+		RoleID:         s.role.ID,
+		Status:         domain.UserActive,
+		JoinedDate:     s.now,
+		UpdatedAt:      s.now,
+		CreatedAt:      s.now,
+	}
+	if user.LastName == "" {
+		user.LastName = email
+	}
+	if username := entry.Value(d.UsernameAttribute); username != "" {
+		if _, err = s.usecase.userRepo.GetByUsername(ctx, username); err == domain.ErrNotFound {
+			user.Username = username
+		}
+	}
+	if err = s.usecase.userRepo.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	if err = s.usecase.ldapRepo.LinkUser(ctx, user.ID, entry.DN, s.now); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// groupName returns the value of the first component of the group DN, e.g. Teachers for CN=Teachers,OU=Groups,DC=school,DC=local
+func groupName(dn string) string {
+	var b strings.Builder
+	value := false
+	for i := 0; i < len(dn); i++ {
+		switch c := dn[i]; {
+		case c == '\\' && i+1 < len(dn):
+			i++
+			if value {
+				b.WriteByte(dn[i])
+			}
+		case c == ',' || c == '+':
+			i = len(dn)
+		case c == '=' && !value:
+			value = true
+		case value:
+			b.WriteByte(c)
+		}
+	}
+	name := strings.TrimSpace(b.String())
+	if name == "" {
+		name = dn
+	}
+	if len(name) > 150 {
+		name = name[:150]
+	}
+	return name
+}
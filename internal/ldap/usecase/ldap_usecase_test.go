@@ -0,0 +1,239 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	_ldapClient "github.com/meroedu/meroedu/internal/ldap/client/ldap"
+	"github.com/meroedu/meroedu/internal/ldap/client/ldap/ldaptest"
+	ucase "github.com/meroedu/meroedu/internal/ldap/usecase"
+)
+
+var learner = &domain.Role{ID: 3, Code: domain.RoleLearner}
+
+const (
+	teachers = "CN=Teachers,OU=Groups,DC=school,DC=local"
+	grade5   = "CN=Grade 5\\, Section A,OU=Groups,DC=school,DC=local"
+	vpn      = "CN=VPN Users,OU=Infrastructure,DC=school,DC=local"
+	dinesh   = "CN=Dinesh Katwal,OU=Staff,DC=school,DC=local"
+)
+
+// newServer returns a directory with a teacher, a student without email and a user of another school
+func newServer() (*ldaptest.Server, *domain.LDAPDirectory) {
+	server := ldaptest.NewServer()
+	server.AddEntry("CN=meroedu,OU=Service,DC=school,DC=local", "bind-s3cret", nil)
+	server.AddEntry(dinesh, "dinesh-pass", map[string][]string{
+		"objectClass":    {"person"},
+		"sAMAccountName": {"dinesh"},
+		"mail":           {"dinesh@school.local"},
+		"givenName":      {"Dinesh"},
+		"sn":             {"Katwal"},
+		"memberOf":       {teachers, grade5, vpn},
+	})
+	server.AddEntry("CN=No Mail,OU=Students,DC=school,DC=local", "", map[string][]string{
+		"objectClass":    {"person"},
+		"sAMAccountName": {"nomail"},
+	})
+	server.AddEntry("CN=Ram Thapa,OU=Staff,DC=school,DC=local", "ram-pass", map[string][]string{
+		"objectClass": {"person"},
+		"mail":        {"ram@other.local"},
+		"memberOf":    {teachers},
+	})
+	return server, &domain.LDAPDirectory{
+		ID:                 1,
+		OrganizationID:     2,
+		URL:                server.URL,
+		BindDN:             "CN=meroedu,OU=Service,DC=school,DC=local",
+		BindPassword:       "bind-s3cret",
+		BaseDN:             "DC=school,DC=local",
+		UserFilter:         "(objectClass=person)",
+		UsernameAttribute:  "sAMAccountName",
+		EmailAttribute:     "mail",
+		FirstNameAttribute: "givenName",
+		LastNameAttribute:  "sn",
+		GroupAttribute:     "memberOf",
+		GroupBaseDN:        "OU=Groups,DC=school,DC=local",
+		DefaultRole:        domain.RoleLearner,
+		Enabled:            true,
+	}
+}
+
+func TestLogin(t *testing.T) {
+	server, directory := newServer()
+	defer server.Close()
+	inOrganization := mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.OrganizationIDFromContext(ctx) == directory.OrganizationID
+	})
+	login := &domain.LDAPLogin{OrganizationID: 2, Username: "dinesh", Password: "dinesh-pass"}
+
+	t.Run("imports-user-and-teams", func(t *testing.T) {
+		mockLDAPRepo := new(mocks.LDAPRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewLDAPUseCase(mockLDAPRepo, _ldapClient.Init(time.Second), mockUserRepo, mockRoleRepo, mockAuthUseCase,
+			time.Second*2, time.Second*5)
+		mockLDAPRepo.On("GetDirectory", inOrganization).Return(directory, nil).Once()
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(learner, nil).Once()
+		mockLDAPRepo.On("GetLinkedUser", inOrganization, dinesh).Return(int64(0), domain.ErrNotFound).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, "dinesh@school.local").Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("GetByUsername", mock.Anything, "dinesh").Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+			return user.OrganizationID == 2 && user.RoleID == learner.ID && user.FirstName == "Dinesh" && user.LastName == "Katwal" &&
+				user.Username == "dinesh" && user.Status == domain.UserActive
+		})).Run(func(args mock.Arguments) { args.Get(1).(*domain.User).ID = 9 }).Return(nil).Once()
+		mockLDAPRepo.On("LinkUser", inOrganization, int64(9), dinesh, mock.AnythingOfType("int64")).Return(nil).Once()
+		mockLDAPRepo.On("SaveGroupTeam", inOrganization, teachers, "Teachers", learner.ID, mock.AnythingOfType("int64")).Return(int64(4), nil).Once()
+		mockLDAPRepo.On("SaveGroupTeam", inOrganization, grade5, "Grade 5, Section A", learner.ID, mock.AnythingOfType("int64")).Return(int64(5), nil).Once()
+		mockLDAPRepo.On("SetGroupTeams", inOrganization, int64(9), []int64{4, 5}, mock.AnythingOfType("int64")).Return(nil).Once()
+		tokens := &domain.TokenPair{AccessToken: "at"}
+		mockAuthUseCase.On("CompleteLogin", inOrganization, mock.AnythingOfType("*domain.User")).Return(tokens, nil).Once()
+
+		res, err := u.Login(context.TODO(), login)
+		assert.NoError(t, err)
+		assert.Equal(t, tokens, res)
+		mockLDAPRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+	t.Run("linked-user", func(t *testing.T) {
+		mockLDAPRepo := new(mocks.LDAPRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewLDAPUseCase(mockLDAPRepo, _ldapClient.Init(time.Second), mockUserRepo, mockRoleRepo, mockAuthUseCase,
+			time.Second*2, time.Second*5)
+		mockLDAPRepo.On("GetDirectory", inOrganization).Return(directory, nil).Once()
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(learner, nil).Once()
+		mockLDAPRepo.On("GetLinkedUser", inOrganization, dinesh).Return(int64(9), nil).Once()
+		mockUserRepo.On("GetByID", inOrganization, int64(9)).Return(&domain.User{ID: 9, OrganizationID: 2}, nil).Once()
+		mockLDAPRepo.On("SaveGroupTeam", inOrganization, teachers, "Teachers", learner.ID, mock.AnythingOfType("int64")).Return(int64(4), nil).Once()
+		mockLDAPRepo.On("SaveGroupTeam", inOrganization, grade5, "Grade 5, Section A", learner.ID, mock.AnythingOfType("int64")).Return(int64(5), nil).Once()
+		mockLDAPRepo.On("SetGroupTeams", inOrganization, int64(9), []int64{4, 5}, mock.AnythingOfType("int64")).Return(nil).Once()
+		tokens := &domain.TokenPair{AccessToken: "at"}
+		mockAuthUseCase.On("CompleteLogin", inOrganization, mock.AnythingOfType("*domain.User")).Return(tokens, nil).Once()
+
+		res, err := u.Login(context.TODO(), login)
+		assert.NoError(t, err)
+		assert.Equal(t, tokens, res)
+		mockUserRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+		mockLDAPRepo.AssertNotCalled(t, "LinkUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("unlinked-local-account", func(t *testing.T) {
+		mockLDAPRepo := new(mocks.LDAPRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewLDAPUseCase(mockLDAPRepo, _ldapClient.Init(time.Second), mockUserRepo, mockRoleRepo, mockAuthUseCase,
+			time.Second*2, time.Second*5)
+		mockLDAPRepo.On("GetDirectory", inOrganization).Return(directory, nil).Once()
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(learner, nil).Once()
+		mockLDAPRepo.On("GetLinkedUser", inOrganization, dinesh).Return(int64(0), domain.ErrNotFound).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, "dinesh@school.local").Return(&domain.User{ID: 9, OrganizationID: 2}, nil).Once()
+
+		_, err := u.Login(context.TODO(), login)
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+		mockLDAPRepo.AssertNotCalled(t, "LinkUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockLDAPRepo.AssertNotCalled(t, "SetGroupTeams", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockAuthUseCase.AssertNotCalled(t, "CompleteLogin", mock.Anything, mock.Anything)
+	})
+	t.Run("wrong-password", func(t *testing.T) {
+		mockLDAPRepo := new(mocks.LDAPRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewLDAPUseCase(mockLDAPRepo, _ldapClient.Init(time.Second), mockUserRepo, mockRoleRepo, mockAuthUseCase,
+			time.Second*2, time.Second*5)
+		mockLDAPRepo.On("GetDirectory", inOrganization).Return(directory, nil).Once()
+
+		_, err := u.Login(context.TODO(), &domain.LDAPLogin{OrganizationID: 2, Username: "dinesh", Password: "wrong"})
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+		mockAuthUseCase.AssertNotCalled(t, "CompleteLogin", mock.Anything, mock.Anything)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		mockLDAPRepo := new(mocks.LDAPRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewLDAPUseCase(mockLDAPRepo, _ldapClient.Init(time.Second), mockUserRepo, mockRoleRepo, mockAuthUseCase,
+			time.Second*2, time.Second*5)
+		disabled := *directory
+		disabled.Enabled = false
+		mockLDAPRepo.On("GetDirectory", inOrganization).Return(&disabled, nil).Once()
+
+		_, err := u.Login(context.TODO(), login)
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+	})
+}
+
+func TestSync(t *testing.T) {
+	server, directory := newServer()
+	defer server.Close()
+	ctx := domain.WithOrganizationID(context.TODO(), 2)
+
+	mockLDAPRepo := new(mocks.LDAPRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	mockAuthUseCase := new(mocks.AuthUseCase)
+	u := ucase.NewLDAPUseCase(mockLDAPRepo, _ldapClient.Init(time.Second), mockUserRepo, mockRoleRepo, mockAuthUseCase,
+		time.Second*2, time.Second*5)
+	mockLDAPRepo.On("GetDirectory", mock.Anything).Return(directory, nil).Once()
+	mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(learner, nil).Once()
+	mockLDAPRepo.On("GetLinkedUser", mock.Anything, dinesh).Return(int64(9), nil).Once()
+	mockUserRepo.On("GetByID", mock.Anything, int64(9)).Return(&domain.User{ID: 9, OrganizationID: 2}, nil).Once()
+	mockLDAPRepo.On("GetLinkedUser", mock.Anything, mock.Anything).Return(int64(0), domain.ErrNotFound).Twice()
+	mockUserRepo.On("GetByEmail", mock.Anything, "ram@other.local").Return(&domain.User{ID: 12, OrganizationID: 7}, nil).Once()
+	mockLDAPRepo.On("SaveGroupTeam", mock.Anything, teachers, "Teachers", learner.ID, mock.AnythingOfType("int64")).Return(int64(4), nil).Once()
+	mockLDAPRepo.On("SaveGroupTeam", mock.Anything, grade5, "Grade 5, Section A", learner.ID, mock.AnythingOfType("int64")).Return(int64(5), nil).Once()
+	mockLDAPRepo.On("SetGroupTeams", mock.Anything, int64(9), []int64{4, 5}, mock.AnythingOfType("int64")).Return(nil).Once()
+	mockLDAPRepo.On("UpdateLastSync", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Once()
+
+	res, err := u.Sync(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.LDAPSyncResult{Users: 3, Created: 0, Skipped: 2, Teams: 2}, res)
+	mockLDAPRepo.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestSaveDirectory(t *testing.T) {
+	mockLDAPRepo := new(mocks.LDAPRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	mockAuthUseCase := new(mocks.AuthUseCase)
+	u := ucase.NewLDAPUseCase(mockLDAPRepo, _ldapClient.Init(time.Second), mockUserRepo, mockRoleRepo, mockAuthUseCase,
+		time.Second*2, time.Second*5)
+	d := &domain.LDAPDirectory{URL: "ldaps://dc.school.local", BindDN: "CN=meroedu", BaseDN: "DC=school,DC=local", DefaultRole: domain.RoleLearner}
+	mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(learner, nil).Once()
+	mockLDAPRepo.On("GetDirectory", mock.Anything).Return(&domain.LDAPDirectory{ID: 1, BindPassword: "bind-s3cret", CreatedAt: 100, LastSyncAt: 200}, nil).Once()
+	mockLDAPRepo.On("SaveDirectory", mock.Anything, mock.MatchedBy(func(saved *domain.LDAPDirectory) bool {
+		return saved.BindPassword == "bind-s3cret" && saved.CreatedAt == 100 && saved.UserFilter == "(objectClass=person)" &&
+			saved.UsernameAttribute == "sAMAccountName" && saved.GroupAttribute == "memberOf"
+	})).Return(nil).Once()
+
+	err := u.SaveDirectory(context.TODO(), d)
+	assert.NoError(t, err)
+	assert.Empty(t, d.BindPassword)
+	assert.Equal(t, int64(200), d.LastSyncAt)
+	mockLDAPRepo.AssertExpectations(t)
+}
+
+func TestSaveDirectoryRoleAboveCaller(t *testing.T) {
+	mockLDAPRepo := new(mocks.LDAPRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	mockAuthUseCase := new(mocks.AuthUseCase)
+	u := ucase.NewLDAPUseCase(mockLDAPRepo, _ldapClient.Init(time.Second), mockUserRepo, mockRoleRepo, mockAuthUseCase,
+		time.Second*2, time.Second*5)
+	d := &domain.LDAPDirectory{URL: "ldaps://dc.school.local", BindDN: "CN=meroedu", BaseDN: "DC=school,DC=local", DefaultRole: domain.RoleAdmin}
+	mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleAdmin).
+		Return(&domain.Role{ID: 2, Code: domain.RoleAdmin, Permissions: []domain.Permission{domain.PermSSOManage, domain.PermAPIKeyManage}}, nil).Once()
+
+	err := u.SaveDirectory(domain.WithPermissions(context.TODO(), []domain.Permission{domain.PermSSOManage}), d)
+	assert.Equal(t, domain.ErrForbidden, err)
+	mockLDAPRepo.AssertNotCalled(t, "SaveDirectory", mock.Anything, mock.Anything)
+}
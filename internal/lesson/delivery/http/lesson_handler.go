@@ -8,6 +8,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
 	"github.com/meroedu/meroedu/internal/util"
 )
 
@@ -27,19 +28,24 @@ func NewLessonHandler(e *echo.Echo, us domain.LessonUseCase) {
 		LessonUseCase: us,
 	}
 	// Get Operation
-	e.GET("/lessons", handler.GetAll)
-	e.GET("/lessons/:id", handler.GetByID)
-	e.GET("/lessons/:id/", handler.GetByID)
+	e.GET("/lessons", handler.GetAll, rbac.Require(domain.PermCourseView))
+	e.GET("/lessons/trash", handler.GetTrash, rbac.Require(domain.PermCourseDelete))
+	e.GET("/lessons/:id", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.GET("/lessons/:id/", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.GET("/lessons/:id/revisions", handler.GetRevisions, rbac.Require(domain.PermCourseView))
+	e.GET("/lessons/:id/revisions/:revision", handler.GetRevision, rbac.Require(domain.PermCourseView))
 
 	// Create/Add Operation
-	e.POST("/lessons", handler.CreateLesson)
+	e.POST("/lessons", handler.CreateLesson, rbac.Require(domain.PermCourseUpdate))
+	e.POST("/lessons/:id/revisions/:revision/restore", handler.RestoreRevision, rbac.Require(domain.PermCourseUpdate))
+	e.POST("/lessons/:id/restore", handler.RestoreLesson, rbac.Require(domain.PermCourseDelete))
 
 	// Update Operation
-	e.PUT("/lessons/:id", handler.UpdateLesson)
-	e.PUT("/lessons/actions", handler.GetByID)
+	e.PUT("/lessons/:id", handler.UpdateLesson, rbac.Require(domain.PermCourseUpdate))
+	e.PUT("/lessons/actions", handler.BulkAction, rbac.Require(domain.PermCourseUpdate, domain.PermCourseDelete))
 
 	// Remove/Delete Operation
-	e.DELETE("/lessons/:id", handler.DeleteLesson)
+	e.DELETE("/lessons/:id", handler.DeleteLesson, rbac.Require(domain.PermCourseDelete))
 }
 
 // GetAll godoc
@@ -218,3 +224,208 @@ func (c *LessonHandler) DeleteLesson(echoContext echo.Context) error {
 
 	return echoContext.NoContent(http.StatusNoContent)
 }
+
+// GetRevisions godoc
+// @Summary Get revision history of a Lesson.
+// @Description Get previous states of a lesson with the author and diff of each update.
+// @Tags lessons
+// @Accept */*
+// @Produce json
+// @Param id path int true "Lesson Id"
+// @Success 200 {object} domain.Summaries
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /lessons/{id}/revisions [get]
+func (c *LessonHandler) GetRevisions(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	revisions, err := c.LessonUseCase.GetRevisions(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    revisions,
+		},
+		Total: int64(len(revisions)),
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetRevision godoc
+// @Summary Get a Lesson revision.
+// @Description Get a specific previous state of a lesson.
+// @Tags lessons
+// @Accept */*
+// @Produce json
+// @Param id path int true "Lesson Id"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /lessons/{id}/revisions/{revision} [get]
+func (c *LessonHandler) GetRevision(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	revisionParam, err := strconv.Atoi(echoContext.Param("revision"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	revision, err := c.LessonUseCase.GetRevision(ctx, int64(idParam), revisionParam)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    revision,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// RestoreRevision godoc
+// @Summary Restore a Lesson revision.
+// @Description Bring a lesson back to a previous state. The replaced state is kept as a new revision.
+// @Tags lessons
+// @Accept */*
+// @Produce json
+// @Param id path int true "Lesson Id"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /lessons/{id}/revisions/{revision}/restore [post]
+func (c *LessonHandler) RestoreRevision(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	revisionParam, err := strconv.Atoi(echoContext.Param("revision"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	lesson, err := c.LessonUseCase.RestoreRevision(ctx, int64(idParam), revisionParam)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    lesson,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetTrash godoc
+// @Summary Get Lessons in the trash.
+// @Description Get deleted lessons, most recently deleted first. They are purged permanently after the retention period.
+// @Tags lessons
+// @Accept */*
+// @Produce json
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /lessons/trash [get]
+func (c *LessonHandler) GetTrash(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	start, limit := 0, 10
+	var err error
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		}
+	}
+
+	list, err := c.LessonUseCase.GetTrash(ctx, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// RestoreLesson godoc
+// @Summary Restore a Lesson from the trash.
+// @Description Restore a deleted lesson.
+// @Tags lessons
+// @Accept */*
+// @Produce json
+// @Param id path int true "Lesson Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /lessons/{id}/restore [post]
+func (c *LessonHandler) RestoreLesson(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	lesson, err := c.LessonUseCase.RestoreLesson(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    lesson,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// BulkAction godoc
+// @Summary Run an action on many lessons.
+// @Description Run delete, add_tag or remove_tag on a list of lesson IDs in a single transaction. The outcome is reported for each ID.
+// @Tags lessons
+// @Accept json
+// @Produce json
+// @Param action body domain.BulkAction true "Action and IDs"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Unsupported action or missing parameter"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /lessons/actions [put]
+func (c *LessonHandler) BulkAction(echoContext echo.Context) error {
+	var action domain.BulkAction
+	err := echoContext.Bind(&action)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&action); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	results, err := c.LessonUseCase.BulkAction(ctx, &action)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    results,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
@@ -196,3 +196,72 @@ func TestDeleteLesson(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 
 }
+
+func TestGetRevision(t *testing.T) {
+	mockUCase := new(mocks.LessonUseCase)
+	mockUCase.On("GetRevision", mock.Anything, int64(124), 2).Return(&domain.LessonRevision{ID: 1, LessonID: 124, Revision: 2}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/lessons/124/revisions/2", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/lessons/:id/revisions/:revision")
+	c.SetParamNames("id", "revision")
+	c.SetParamValues("124", "2")
+
+	handler := lessonHTTP.LessonHandler{
+		LessonUseCase: mockUCase,
+	}
+	err = handler.GetRevision(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRestoreRevision(t *testing.T) {
+	mockUCase := new(mocks.LessonUseCase)
+	mockUCase.On("RestoreRevision", mock.Anything, int64(124), 2).Return(&domain.Lesson{ID: 124, Title: "Title"}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/lessons/124/revisions/2/restore", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/lessons/:id/revisions/:revision/restore")
+	c.SetParamNames("id", "revision")
+	c.SetParamValues("124", "2")
+
+	handler := lessonHTTP.LessonHandler{
+		LessonUseCase: mockUCase,
+	}
+	err = handler.RestoreRevision(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRestoreLesson(t *testing.T) {
+	mockUCase := new(mocks.LessonUseCase)
+	mockUCase.On("RestoreLesson", mock.Anything, int64(124)).Return(&domain.Lesson{ID: 124, Title: "Title"}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/lessons/124/restore", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/lessons/:id/restore")
+	c.SetParamNames("id")
+	c.SetParamValues("124")
+
+	handler := lessonHTTP.LessonHandler{
+		LessonUseCase: mockUCase,
+	}
+	err = handler.RestoreLesson(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
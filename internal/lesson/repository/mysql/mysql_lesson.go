@@ -3,12 +3,21 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/repository/sqlbulk"
 	"github.com/meroedu/meroedu/pkg/log"
 )
 
+// inOrganization limits lessons to the courses of the caller's organization, out of the trash. The lessons of a
+// trashed course are left alone and come back with it.
+const inOrganization = "course_id IN (SELECT id FROM courses WHERE organization_id = ? AND deleted_at IS NULL)"
+
+// revisionInOrganization limits lesson revisions to the lessons of the caller's organization
+const revisionInOrganization = "lesson_id IN (SELECT l.id FROM lessons l JOIN courses c ON c.id = l.course_id WHERE c.organization_id = ? AND c.deleted_at IS NULL)"
+
 type mysqlRepository struct {
 	conn *sql.DB
 }
@@ -36,17 +45,23 @@ func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...inter
 	result = make([]domain.Lesson, 0)
 	for rows.Next() {
 		t := domain.Lesson{}
+		description, deletedAt := sql.NullString{}, sql.NullInt64{}
 		err = rows.Scan(
 			&t.ID,
+			&t.CourseID,
 			&t.Title,
+			&description,
 			&t.UpdatedAt,
 			&t.CreatedAt,
+			&deletedAt,
 		)
 
 		if err != nil {
 			log.Error(err)
 			return nil, err
 		}
+		t.Description = description.String
+		t.DeletedAt = deletedAt.Int64
 		result = append(result, t)
 	}
 
@@ -54,18 +69,18 @@ func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...inter
 }
 
 func (m *mysqlRepository) GetAll(ctx context.Context, start int, limit int) (res []domain.Lesson, err error) {
-	query := `SELECT id,title,updated_at,created_at FROM lessons ORDER BY created_at DESC LIMIT ?,?`
+	query := `SELECT id,course_id,title,description,updated_at,created_at,deleted_at FROM lessons WHERE ` + inOrganization + ` AND deleted_at IS NULL ORDER BY created_at DESC LIMIT ?,?`
 
-	res, err = m.fetch(ctx, query, start, limit)
+	res, err = m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (res *domain.Lesson, err error) {
-	query := `SELECT id,title,updated_at,created_at FROM lessons WHERE ID = ?`
+	query := `SELECT id,course_id,title,description,updated_at,created_at,deleted_at FROM lessons WHERE ID = ? AND ` + inOrganization + ` AND deleted_at IS NULL`
 
-	list, err := m.fetch(ctx, query, id)
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -79,18 +94,26 @@ func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (res *domain.Le
 	return &lesson, nil
 }
 
+// CreateLesson adds the lesson to a course of the caller's organization.
 func (m *mysqlRepository) CreateLesson(ctx context.Context, a *domain.Lesson) (err error) {
-	query := `INSERT lessons SET title=?,course_id=?,description=?,updated_at=?,created_at=?`
+	query := `INSERT INTO lessons (title,course_id,description,updated_at,created_at) SELECT ?,id,?,?,? FROM courses WHERE id = ? AND organization_id = ? AND deleted_at IS NULL`
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		log.Error("Error while preparing statement ", err)
 		return
 	}
-	res, err := stmt.ExecContext(ctx, a.Title, a.CourseID, a.Description, a.UpdatedAt, a.CreatedAt)
+	res, err := stmt.ExecContext(ctx, a.Title, a.Description, a.UpdatedAt, a.CreatedAt, a.CourseID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		log.Error("Error while executing statement ", err)
 		return
 	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
 	lastID, err := res.LastInsertId()
 	if err != nil {
 		log.Error("Got Error from LastInsertId method: ", err)
@@ -100,15 +123,16 @@ func (m *mysqlRepository) CreateLesson(ctx context.Context, a *domain.Lesson) (e
 	return
 }
 
-func (m *mysqlRepository) DeleteLesson(ctx context.Context, id int64) (err error) {
-	query := "DELETE FROM lessons WHERE id = ?"
+// DeleteLesson moves the lesson to the trash.
+func (m *mysqlRepository) DeleteLesson(ctx context.Context, id int64, deletedAt int64) (err error) {
+	query := "UPDATE lessons SET deleted_at = ? WHERE id = ? AND " + inOrganization + " AND deleted_at IS NULL"
 
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	res, err := stmt.ExecContext(ctx, deletedAt, id, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return
 	}
@@ -125,16 +149,57 @@ func (m *mysqlRepository) DeleteLesson(ctx context.Context, id int64) (err error
 
 	return
 }
-func (m *mysqlRepository) UpdateLesson(ctx context.Context, ar *domain.Lesson) (err error) {
-	query := `UPDATE lessons set title=?,updated_at=? WHERE ID = ?`
 
-	stmt, err := m.conn.PrepareContext(ctx, query)
+// UpdateLesson saves the previous state of the lesson as the next revision and updates it in a single transaction.
+func (m *mysqlRepository) UpdateLesson(ctx context.Context, ar *domain.Lesson, revision *domain.LessonRevision) (err error) {
+	snapshot, err := json.Marshal(revision.Lesson)
+	if err != nil {
+		return
+	}
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if err = lessonExists(ctx, tx, ar.ID); err != nil {
+		return
+	}
+	query := `SELECT COALESCE(MAX(revision),0) FROM lesson_revisions WHERE lesson_id = ? FOR UPDATE`
+	var latest int
+	if err = tx.QueryRowContext(ctx, query, ar.ID).Scan(&latest); err != nil {
+		log.Error(err)
+		return
+	}
+	revision.LessonID = ar.ID
+	revision.Revision = latest + 1
+
+	query = `INSERT lesson_revisions SET lesson_id=?,revision=?,author_id=?,snapshot=?,diff=?,created_at=?`
+	authorID := sql.NullInt64{Int64: revision.AuthorID, Valid: revision.AuthorID != 0}
+	res, err := tx.ExecContext(ctx, query, revision.LessonID, revision.Revision, authorID, string(snapshot), revision.Diff, revision.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	revision.ID, err = res.LastInsertId()
 	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, ar.Title, ar.UpdatedAt, ar.ID)
+	query = `UPDATE lessons set title=?,description=?,updated_at=? WHERE ID = ? AND deleted_at IS NULL`
+	res, err = tx.ExecContext(ctx, query, ar.Title, ar.Description, ar.UpdatedAt, ar.ID)
 	if err != nil {
+		log.Error("Error while executing statement ", err)
 		return
 	}
 	affect, err := res.RowsAffected()
@@ -150,9 +215,9 @@ func (m *mysqlRepository) UpdateLesson(ctx context.Context, ar *domain.Lesson) (
 }
 
 func (m *mysqlRepository) GetLessonCountByCourse(ctx context.Context, courseID int64) (int, error) {
-	query := `SELECT count(*) FROM lessons WHERE course_id = ?`
+	query := `SELECT count(*) FROM lessons WHERE course_id = ? AND ` + inOrganization + ` AND deleted_at IS NULL`
 
-	rows, err := m.conn.QueryContext(ctx, query, courseID)
+	rows, err := m.conn.QueryContext(ctx, query, courseID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		log.Error(err)
 		return 0, nil
@@ -170,10 +235,163 @@ func (m *mysqlRepository) GetLessonCountByCourse(ctx context.Context, courseID i
 }
 
 func (m *mysqlRepository) GetLessonByCourse(ctx context.Context, courseID int64) ([]domain.Lesson, error) {
-	query := `SELECT id,title,updated_at,created_at FROM lessons WHERE course_id = ?`
-	list, err := m.fetch(ctx, query, courseID)
+	query := `SELECT id,course_id,title,description,updated_at,created_at,deleted_at FROM lessons WHERE course_id = ? AND ` + inOrganization + ` AND deleted_at IS NULL`
+	list, err := m.fetch(ctx, query, courseID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	return list, nil
 }
+
+func (m *mysqlRepository) fetchRevisions(ctx context.Context, query string, args ...interface{}) (result []domain.LessonRevision, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.LessonRevision, 0)
+	for rows.Next() {
+		t := domain.LessonRevision{}
+		authorID, snapshot, diff := sql.NullInt64{}, "", sql.NullString{}
+		err = rows.Scan(
+			&t.ID,
+			&t.LessonID,
+			&t.Revision,
+			&authorID,
+			&snapshot,
+			&diff,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.AuthorID = authorID.Int64
+		t.Diff = diff.String
+		t.Lesson = &domain.Lesson{}
+		if err = json.Unmarshal([]byte(snapshot), t.Lesson); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) GetRevisions(ctx context.Context, lessonID int64) ([]domain.LessonRevision, error) {
+	query := `SELECT id,lesson_id,revision,author_id,snapshot,diff,created_at FROM lesson_revisions WHERE lesson_id = ? AND ` + revisionInOrganization + ` ORDER BY revision DESC`
+	return m.fetchRevisions(ctx, query, lessonID, domain.OrganizationIDFromContext(ctx))
+}
+
+func (m *mysqlRepository) GetRevision(ctx context.Context, lessonID int64, revision int) (*domain.LessonRevision, error) {
+	query := `SELECT id,lesson_id,revision,author_id,snapshot,diff,created_at FROM lesson_revisions WHERE lesson_id = ? AND revision = ? AND ` + revisionInOrganization
+	list, err := m.fetchRevisions(ctx, query, lessonID, revision, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// GetTrash returns the lessons in the trash, most recently deleted first.
+func (m *mysqlRepository) GetTrash(ctx context.Context, start int, limit int) ([]domain.Lesson, error) {
+	query := `SELECT id,course_id,title,description,updated_at,created_at,deleted_at FROM lessons WHERE ` + inOrganization + ` AND deleted_at IS NOT NULL ORDER BY deleted_at DESC LIMIT ?,?`
+	return m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
+}
+
+// RestoreLesson takes the lesson out of the trash.
+func (m *mysqlRepository) RestoreLesson(ctx context.Context, id int64, updatedAt int64) error {
+	query := `UPDATE lessons SET deleted_at = NULL, updated_at = ? WHERE id = ? AND ` + inOrganization + ` AND deleted_at IS NOT NULL`
+	res, err := m.conn.ExecContext(ctx, query, updatedAt, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// PurgeTrash permanently removes the lessons of every organization trashed before the given time.
+func (m *mysqlRepository) PurgeTrash(ctx context.Context, deletedBefore int64) (int64, error) {
+	query := `DELETE FROM lessons WHERE deleted_at IS NOT NULL AND deleted_at < ?`
+	res, err := m.conn.ExecContext(ctx, query, deletedBefore)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// BulkAction runs a delete, add_tag or remove_tag action on many lessons in a single transaction.
+func (m *mysqlRepository) BulkAction(ctx context.Context, action *domain.BulkAction, updatedAt int64) ([]domain.BulkResult, error) {
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	var fn func(tx *sql.Tx, id int64) error
+	switch action.Action {
+	case domain.BulkDelete:
+		fn = func(tx *sql.Tx, id int64) error {
+			query := `UPDATE lessons SET deleted_at=? WHERE id = ? AND ` + inOrganization + ` AND deleted_at IS NULL`
+			return sqlbulk.ExecItem(ctx, tx, query, updatedAt, id, organizationID)
+		}
+	case domain.BulkAddTag:
+		var count int
+		query := `SELECT count(*) FROM tags WHERE id = ? AND organization_id = ?`
+		if err := m.conn.QueryRowContext(ctx, query, action.TagID, organizationID).Scan(&count); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if count == 0 {
+			return nil, domain.ErrBadParamInput
+		}
+		fn = func(tx *sql.Tx, id int64) error {
+			query := `INSERT INTO lessons_tags (lesson_id,tag_id,created_at) SELECT id,?,? FROM lessons
+				WHERE id = ? AND ` + inOrganization + ` AND deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM lessons_tags WHERE lesson_id = ? AND tag_id = ?)`
+			err := sqlbulk.ExecItem(ctx, tx, query, action.TagID, updatedAt, id, organizationID, id, action.TagID)
+			if err == domain.ErrNotFound {
+				// nothing inserted: either the lesson is missing or it already has the tag
+				return lessonExists(ctx, tx, id)
+			}
+			return err
+		}
+	case domain.BulkRemoveTag:
+		fn = func(tx *sql.Tx, id int64) error {
+			if err := lessonExists(ctx, tx, id); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `DELETE FROM lessons_tags WHERE lesson_id = ? AND tag_id = ?`, id, action.TagID)
+			return err
+		}
+	default:
+		return nil, domain.ErrBadParamInput
+	}
+	return sqlbulk.Run(ctx, m.conn, action.IDs, fn)
+}
+
+func lessonExists(ctx context.Context, tx *sql.Tx, id int64) error {
+	var count int
+	query := `SELECT count(*) FROM lessons WHERE id = ? AND ` + inOrganization + ` AND deleted_at IS NULL`
+	if err := tx.QueryRowContext(ctx, query, id, domain.OrganizationIDFromContext(ctx)).Scan(&count); err != nil {
+		log.Error(err)
+		return err
+	}
+	if count == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -11,6 +11,10 @@ import (
 	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
+var orgCtx = domain.WithOrganizationID(context.TODO(), 1)
+
+const inOrganization = `course_id IN \(SELECT id FROM courses WHERE organization_id = \? AND deleted_at IS NULL\)`
+
 func TestGetAll(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -21,14 +25,14 @@ func TestGetAll(t *testing.T) {
 			ID: 1, Title: "IT", UpdatedAt: time.Now().Unix(), CreatedAt: time.Now().Unix(),
 		},
 	}
-	rows := sqlmock.NewRows([]string{"id", "title", "updated_at", "created_at"}).
-		AddRow(mockLessons[0].ID, mockLessons[0].Title, mockLessons[0].UpdatedAt, mockLessons[0].CreatedAt)
+	rows := sqlmock.NewRows([]string{"id", "course_id", "title", "description", "updated_at", "created_at", "deleted_at"}).
+		AddRow(mockLessons[0].ID, 1, mockLessons[0].Title, nil, mockLessons[0].UpdatedAt, mockLessons[0].CreatedAt, nil)
 
-	query := `SELECT id,title,updated_at,created_at FROM lessons ORDER BY created_at DESC LIMIT \?,\?`
+	query := `SELECT id,course_id,title,description,updated_at,created_at,deleted_at FROM lessons WHERE ` + inOrganization + ` AND deleted_at IS NULL ORDER BY created_at DESC LIMIT \?,\?`
 	mock.ExpectQuery(query).WillReturnRows(rows)
 	c := mysqlrepo.Init(db)
 	start, limit := 0, 10
-	list, err := c.GetAll(context.TODO(), start, limit)
+	list, err := c.GetAll(orgCtx, start, limit)
 	assert.NoError(t, err)
 	assert.Len(t, list, 1)
 
@@ -39,13 +43,13 @@ func TestGetByID(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
-	row := sqlmock.NewRows([]string{"id", "title", "updated_at", "created_at"}).
-		AddRow("1", "testing-2", time.Now().Unix(), time.Now().Unix())
+	row := sqlmock.NewRows([]string{"id", "course_id", "title", "description", "updated_at", "created_at", "deleted_at"}).
+		AddRow("1", "1", "testing-2", "description", time.Now().Unix(), time.Now().Unix(), nil)
 
-	query := `SELECT id,title,updated_at,created_at FROM lessons WHERE ID = \?`
+	query := `SELECT id,course_id,title,description,updated_at,created_at,deleted_at FROM lessons WHERE ID = \? AND ` + inOrganization + ` AND deleted_at IS NULL`
 	mock.ExpectQuery(query).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	lesson, err := c.GetByID(context.TODO(), 1)
+	lesson, err := c.GetByID(orgCtx, 1)
 	assert.NoError(t, err)
 	assert.NotNil(t, lesson)
 }
@@ -61,12 +65,12 @@ func TestCreateLesson(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `INSERT lessons SET title=\?,course_id=\?,description=\?,updated_at=\?,created_at=\?`
+	query := `INSERT INTO lessons \(title,course_id,description,updated_at,created_at\) SELECT \?,id,\?,\?,\? FROM courses WHERE id = \? AND organization_id = \? AND deleted_at IS NULL`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(c.Title, c.CourseID, c.Description, c.UpdatedAt, c.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(c.Title, c.Description, c.UpdatedAt, c.CreatedAt, c.CourseID, 1).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.CreateLesson(context.TODO(), c)
+	err = repo.CreateLesson(orgCtx, c)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(12), c.ID)
 }
@@ -77,13 +81,46 @@ func TestDeleteLesson(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `DELETE FROM lessons WHERE id = \?`
+	deletedAt := time.Now().Unix()
+	query := `UPDATE lessons SET deleted_at = \? WHERE id = \? AND ` + inOrganization + ` AND deleted_at IS NULL`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(lesson_id).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(deletedAt, lesson_id, 1).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.DeleteLesson(orgCtx, int64(lesson_id), deletedAt)
+	assert.NoError(t, err)
+}
+
+func TestRestoreLesson(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	updatedAt := time.Now().Unix()
+	query := `UPDATE lessons SET deleted_at = NULL, updated_at = \? WHERE id = \? AND ` + inOrganization + ` AND deleted_at IS NOT NULL`
+	mock.ExpectExec(query).WithArgs(updatedAt, 12, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(updatedAt, 13, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.RestoreLesson(orgCtx, 12, updatedAt)
+	assert.NoError(t, err)
+	err = repo.RestoreLesson(orgCtx, 13, updatedAt)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestLessonPurgeTrash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	deletedBefore := time.Now().Unix()
+	query := `DELETE FROM lessons WHERE deleted_at IS NOT NULL AND deleted_at < \?`
+	mock.ExpectExec(query).WithArgs(deletedBefore).WillReturnResult(sqlmock.NewResult(0, 3))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.DeleteLesson(context.TODO(), int64(lesson_id))
+	count, err := repo.PurgeTrash(orgCtx, deletedBefore)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
 }
 
 func TestUpdateLesson(t *testing.T) {
@@ -97,13 +134,44 @@ func TestUpdateLesson(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `UPDATE lessons set title=\?,updated_at=\? WHERE ID = \?`
-	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(c.Title, c.UpdatedAt, c.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+	revision := &domain.LessonRevision{
+		AuthorID:  3,
+		Lesson:    &domain.Lesson{ID: 12, Title: "Basics"},
+		CreatedAt: c.UpdatedAt,
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM lessons WHERE id = \? AND `+inOrganization+` AND deleted_at IS NULL`).
+		WithArgs(c.ID, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(revision\),0\) FROM lesson_revisions WHERE lesson_id = \? FOR UPDATE`).
+		WithArgs(c.ID).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(0))
+	mock.ExpectExec(`INSERT lesson_revisions SET lesson_id=\?,revision=\?,author_id=\?,snapshot=\?,diff=\?,created_at=\?`).
+		WithArgs(c.ID, 1, revision.AuthorID, sqlmock.AnyArg(), revision.Diff, revision.CreatedAt).WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectExec(`UPDATE lessons set title=\?,description=\?,updated_at=\? WHERE ID = \? AND deleted_at IS NULL`).
+		WithArgs(c.Title, c.Description, c.UpdatedAt, c.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectCommit()
 
 	repo := mysqlrepo.Init(db)
-	err = repo.UpdateLesson(context.TODO(), c)
+	err = repo.UpdateLesson(orgCtx, c, revision)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, revision.Revision)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLessonRevision(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "lesson_id", "revision", "author_id", "snapshot", "diff", "created_at"}).
+		AddRow(4, 12, 1, 3, `{"id":12,"title":"Basics"}`, nil, time.Now().Unix())
+
+	query := `SELECT id,lesson_id,revision,author_id,snapshot,diff,created_at FROM lesson_revisions WHERE lesson_id = \? AND revision = \? AND lesson_id IN \(SELECT l.id FROM lessons l JOIN courses c ON c.id = l.course_id WHERE c.organization_id = \? AND c.deleted_at IS NULL\)`
+	mock.ExpectQuery(query).WithArgs(12, 1, 1).WillReturnRows(rows)
+	c := mysqlrepo.Init(db)
+	revision, err := c.GetRevision(orgCtx, 12, 1)
 	assert.NoError(t, err)
+	assert.Equal(t, "Basics", revision.Lesson.Title)
+	assert.Equal(t, int64(3), revision.AuthorID)
 }
 
 func TestGetLessonCountByCourse(t *testing.T) {
@@ -114,10 +182,10 @@ func TestGetLessonCountByCourse(t *testing.T) {
 	row := sqlmock.NewRows([]string{"count"}).
 		AddRow(12)
 
-	query := `SELECT count\(\*\) FROM lessons WHERE course_id = \?`
+	query := `SELECT count\(\*\) FROM lessons WHERE course_id = \? AND ` + inOrganization + ` AND deleted_at IS NULL`
 	mock.ExpectQuery(query).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	count, err := c.GetLessonCountByCourse(context.TODO(), 24)
+	count, err := c.GetLessonCountByCourse(orgCtx, 24)
 	assert.NoError(t, err)
 	assert.Equal(t, 12, count)
 }
@@ -132,14 +200,35 @@ func TestGetLessonByCourse(t *testing.T) {
 			ID: 1, Title: "IT", UpdatedAt: time.Now().Unix(), CreatedAt: time.Now().Unix(),
 		},
 	}
-	rows := sqlmock.NewRows([]string{"id", "title", "updated_at", "created_at"}).
-		AddRow(mockLessons[0].ID, mockLessons[0].Title, mockLessons[0].UpdatedAt, mockLessons[0].CreatedAt)
+	rows := sqlmock.NewRows([]string{"id", "course_id", "title", "description", "updated_at", "created_at", "deleted_at"}).
+		AddRow(mockLessons[0].ID, 1, mockLessons[0].Title, nil, mockLessons[0].UpdatedAt, mockLessons[0].CreatedAt, nil)
 
-	query := `SELECT id,title,updated_at,created_at FROM lessons WHERE course_id = \?`
+	query := `SELECT id,course_id,title,description,updated_at,created_at,deleted_at FROM lessons WHERE course_id = \? AND ` + inOrganization + ` AND deleted_at IS NULL`
 	mock.ExpectQuery(query).WillReturnRows(rows)
 	c := mysqlrepo.Init(db)
-	list, err := c.GetLessonByCourse(context.TODO(), 1)
+	list, err := c.GetLessonByCourse(orgCtx, 1)
 	assert.NoError(t, err)
 	assert.Len(t, list, 1)
 
 }
+
+func TestBulkActionRemoveTag(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM lessons WHERE id = \? AND `+inOrganization+` AND deleted_at IS NULL`).WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(`DELETE FROM lessons_tags WHERE lesson_id = \? AND tag_id = \?`).WithArgs(1, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM lessons WHERE id = \? AND `+inOrganization+` AND deleted_at IS NULL`).WithArgs(9, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectCommit()
+
+	repo := mysqlrepo.Init(db)
+	results, err := repo.BulkAction(orgCtx, &domain.BulkAction{Action: domain.BulkRemoveTag, IDs: []int64{1, 9}, TagID: 4}, time.Now().Unix())
+	assert.NoError(t, err)
+	assert.True(t, results[0].Success)
+	assert.Equal(t, domain.ErrNotFound.Error(), results[1].Error)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -5,21 +5,24 @@ import (
 	"time"
 
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/diff"
 )
 
 // LessonUseCase ...
 type LessonUseCase struct {
-	lessonRepo     domain.LessonRepository
-	contentUseCase domain.ContentUseCase
-	contextTimeOut time.Duration
+	lessonRepo          domain.LessonRepository
+	contentUseCase      domain.ContentUseCase
+	collaboratorUseCase domain.CollaboratorUseCase
+	contextTimeOut      time.Duration
 }
 
-// NewLessonUseCase will create new an
-func NewLessonUseCase(l domain.LessonRepository, c domain.ContentUseCase, timeout time.Duration) domain.LessonUseCase {
+// NewLessonUseCase will create new an. Lessons are only edited by the ones working on their course.
+func NewLessonUseCase(l domain.LessonRepository, c domain.ContentUseCase, cu domain.CollaboratorUseCase, timeout time.Duration) domain.LessonUseCase {
 	return &LessonUseCase{
-		lessonRepo:     l,
-		contentUseCase: c,
-		contextTimeOut: timeout,
+		lessonRepo:          l,
+		contentUseCase:      c,
+		collaboratorUseCase: cu,
+		contextTimeOut:      timeout,
 	}
 }
 
@@ -53,6 +56,9 @@ func (usecase *LessonUseCase) GetByID(c context.Context, id int64) (res *domain.
 func (usecase *LessonUseCase) CreateLesson(c context.Context, lesson *domain.Lesson) (err error) {
 	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
 	defer cancel()
+	if err = usecase.collaboratorUseCase.AuthorizeCourse(ctx, lesson.CourseID, domain.CollaboratorEditor); err != nil {
+		return
+	}
 	lesson.UpdatedAt = time.Now().Unix()
 	lesson.CreatedAt = time.Now().Unix()
 	err = usecase.lessonRepo.CreateLesson(ctx, lesson)
@@ -67,13 +73,23 @@ func (usecase *LessonUseCase) CreateLesson(c context.Context, lesson *domain.Les
 func (usecase *LessonUseCase) UpdateLesson(c context.Context, lesson *domain.Lesson, id int64) (err error) {
 	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
 	defer cancel()
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, id, domain.CollaboratorEditor); err != nil {
+		return
+	}
 	existingLesson, err := usecase.GetByID(ctx, id)
 	if existingLesson == nil {
 		return domain.ErrNotFound
 	}
 	lesson.ID = id
+	lesson.CourseID = existingLesson.CourseID
 	lesson.UpdatedAt = time.Now().Unix()
-	err = usecase.lessonRepo.UpdateLesson(ctx, lesson)
+	revision := &domain.LessonRevision{
+		AuthorID:  domain.UserIDFromContext(ctx),
+		Lesson:    existingLesson,
+		Diff:      diff.Lines(existingLesson.Description, lesson.Description),
+		CreatedAt: lesson.UpdatedAt,
+	}
+	err = usecase.lessonRepo.UpdateLesson(ctx, lesson, revision)
 	if err != nil {
 		return
 	}
@@ -85,6 +101,9 @@ func (usecase *LessonUseCase) UpdateLesson(c context.Context, lesson *domain.Les
 func (usecase *LessonUseCase) DeleteLesson(c context.Context, id int64) (err error) {
 	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
 	defer cancel()
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, id, domain.CollaboratorEditor); err != nil {
+		return
+	}
 	existedCourse, err := usecase.GetByID(ctx, id)
 	if err != nil {
 		return err
@@ -92,7 +111,7 @@ func (usecase *LessonUseCase) DeleteLesson(c context.Context, id int64) (err err
 	if existedCourse == nil {
 		return domain.ErrNotFound
 	}
-	return usecase.lessonRepo.DeleteLesson(ctx, id)
+	return usecase.lessonRepo.DeleteLesson(ctx, id, time.Now().Unix())
 }
 
 // GetLessonByCourse ...
@@ -127,3 +146,92 @@ func (usecase *LessonUseCase) GetLessonCountByCourse(c context.Context, courseID
 
 	return res, nil
 }
+
+// GetRevisions ...
+func (usecase *LessonUseCase) GetRevisions(c context.Context, lessonID int64) ([]domain.LessonRevision, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.lessonRepo.GetRevisions(ctx, lessonID)
+}
+
+// GetRevision ...
+func (usecase *LessonUseCase) GetRevision(c context.Context, lessonID int64, revision int) (*domain.LessonRevision, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.lessonRepo.GetRevision(ctx, lessonID, revision)
+}
+
+// RestoreRevision will bring the lesson back to the state saved in the given revision.
+// The state being replaced is kept as a new revision, so a restore can be undone as well.
+func (usecase *LessonUseCase) RestoreRevision(c context.Context, lessonID int64, revision int) (*domain.Lesson, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existingRevision, err := usecase.lessonRepo.GetRevision(ctx, lessonID, revision)
+	if err != nil {
+		return nil, err
+	}
+	lesson := existingRevision.Lesson
+	if err = usecase.UpdateLesson(ctx, lesson, lessonID); err != nil {
+		return nil, err
+	}
+	return lesson, nil
+}
+
+// GetTrash ...
+func (usecase *LessonUseCase) GetTrash(c context.Context, start int, limit int) ([]domain.Lesson, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.lessonRepo.GetTrash(ctx, start, limit)
+}
+
+// RestoreLesson will take a lesson out of the trash
+func (usecase *LessonUseCase) RestoreLesson(c context.Context, id int64) (*domain.Lesson, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeLesson(ctx, id, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	if err := usecase.lessonRepo.RestoreLesson(ctx, id, time.Now().Unix()); err != nil {
+		return nil, err
+	}
+	return usecase.lessonRepo.GetByID(ctx, id)
+}
+
+// PurgeTrash will permanently remove the lessons trashed before deletedBefore
+func (usecase *LessonUseCase) PurgeTrash(c context.Context, deletedBefore int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.lessonRepo.PurgeTrash(ctx, deletedBefore)
+}
+
+// BulkAction will run the same action on many lessons at once and report the outcome for each of them.
+// The lessons of courses the caller does not work on fail.
+func (usecase *LessonUseCase) BulkAction(c context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	switch action.Action {
+	case domain.BulkAddTag, domain.BulkRemoveTag:
+		if action.TagID == 0 {
+			return nil, domain.ErrBadParamInput
+		}
+	case domain.BulkDelete:
+	default:
+		return nil, domain.ErrBadParamInput
+	}
+	allowed, denied, err := domain.FilterBulk(action.IDs, func(id int64) error {
+		return usecase.collaboratorUseCase.AuthorizeLesson(ctx, id, domain.CollaboratorEditor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(allowed) == 0 {
+		return denied, nil
+	}
+	allowedAction := *action
+	allowedAction.IDs = allowed
+	results, err := usecase.lessonRepo.BulkAction(ctx, &allowedAction, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	return domain.MergeBulk(action.IDs, denied, results), nil
+}
@@ -13,6 +13,15 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// anyCollaborator returns a collaborator usecase giving the caller every access
+func anyCollaborator() *mocks.CollaboratorUseCase {
+	m := new(mocks.CollaboratorUseCase)
+	m.On("AuthorizeCourse", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("AuthorizeLesson", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("AuthorizeContent", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
 func TestGetAll(t *testing.T) {
 	mockLessonRepo := new(mocks.LessonRepository)
 	mockContentUseCase := new(mocks.ContentUseCase)
@@ -27,7 +36,7 @@ func TestGetAll(t *testing.T) {
 
 		start := int(0)
 		limit := int(1)
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 		list, err := u.GetAll(context.TODO(), start, limit)
 		assert.NoError(t, err)
 		assert.Len(t, list, len(mockListLesson))
@@ -38,7 +47,7 @@ func TestGetAll(t *testing.T) {
 		mockLessonRepo.On("GetAll", mock.Anything, mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(nil, errors.New("Unexpected Error")).Once()
 
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 		start := int(0)
 		limit := int(1)
 		list, err := u.GetAll(context.TODO(), start, limit)
@@ -56,7 +65,7 @@ func TestGetByID(t *testing.T) {
 	}
 	t.Run("success", func(t *testing.T) {
 		mockLessonRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(&mockLesson, nil).Once()
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 
 		a, err := u.GetByID(context.TODO(), mockLesson.ID)
 
@@ -68,7 +77,7 @@ func TestGetByID(t *testing.T) {
 	t.Run("error-failed", func(t *testing.T) {
 		mockLessonRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, errors.New("Unexpected")).Once()
 
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 
 		a, err := u.GetByID(context.TODO(), mockLesson.ID)
 
@@ -89,7 +98,7 @@ func TestCreateLesson(t *testing.T) {
 		tempmockLesson := mockLesson
 		tempmockLesson.ID = 0
 		mockLessonRepo.On("CreateLesson", mock.Anything, mock.AnythingOfType("*domain.Lesson")).Return(nil).Once()
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.CreateLesson(context.TODO(), &tempmockLesson)
 
@@ -99,7 +108,7 @@ func TestCreateLesson(t *testing.T) {
 	})
 	t.Run("error", func(t *testing.T) {
 		mockLessonRepo.On("CreateLesson", mock.Anything, mock.AnythingOfType("*domain.Lesson")).Return(errors.New("unexpected error occur")).Once()
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.CreateLesson(context.TODO(), &mockLesson)
 
@@ -118,8 +127,8 @@ func TestUpdateLesson(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		tempmockLesson := mockLesson
 		mockLessonRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(&tempmockLesson, nil).Once()
-		mockLessonRepo.On("UpdateLesson", mock.Anything, mock.AnythingOfType("*domain.Lesson")).Return(nil).Once()
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		mockLessonRepo.On("UpdateLesson", mock.Anything, mock.AnythingOfType("*domain.Lesson"), mock.AnythingOfType("*domain.LessonRevision")).Return(nil).Once()
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.UpdateLesson(context.TODO(), &tempmockLesson, tempmockLesson.ID)
 
@@ -130,8 +139,8 @@ func TestUpdateLesson(t *testing.T) {
 	t.Run("error-lesson", func(t *testing.T) {
 		existingLesson := mockLesson
 		mockLessonRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, nil).Once()
-		mockLessonRepo.On("UpdateLesson", mock.Anything, mock.AnythingOfType("*domain.Lesson")).Return(domain.ErrNotFound).Once()
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		mockLessonRepo.On("UpdateLesson", mock.Anything, mock.AnythingOfType("*domain.Lesson"), mock.AnythingOfType("*domain.LessonRevision")).Return(domain.ErrNotFound).Once()
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.UpdateLesson(context.TODO(), &mockLesson, existingLesson.ID)
 
@@ -151,9 +160,9 @@ func TestDeleteLesson(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockLessonRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(&mockLesson, nil).Once()
 
-		mockLessonRepo.On("DeleteLesson", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Once()
+		mockLessonRepo.On("DeleteLesson", mock.Anything, mock.AnythingOfType("int64"), mock.AnythingOfType("int64")).Return(nil).Once()
 
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.DeleteLesson(context.TODO(), mockLesson.ID)
 
@@ -163,7 +172,7 @@ func TestDeleteLesson(t *testing.T) {
 	t.Run("course-is-not-exist", func(t *testing.T) {
 		mockLessonRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, nil).Once()
 
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.DeleteLesson(context.TODO(), mockLesson.ID)
 
@@ -173,7 +182,7 @@ func TestDeleteLesson(t *testing.T) {
 	t.Run("error-happens-in-db", func(t *testing.T) {
 		mockLessonRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, errors.New("Unexpected Error")).Once()
 
-		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, time.Second*2)
+		u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
 
 		err := u.DeleteLesson(context.TODO(), mockLesson.ID)
 
@@ -182,3 +191,50 @@ func TestDeleteLesson(t *testing.T) {
 	})
 
 }
+
+func TestRestoreRevision(t *testing.T) {
+	mockLessonRepo := new(mocks.LessonRepository)
+	mockContentUseCase := new(mocks.ContentUseCase)
+	current := domain.Lesson{ID: 1, CourseID: 3, Title: "Current", Description: "new"}
+	previous := domain.Lesson{ID: 1, CourseID: 3, Title: "Previous", Description: "old"}
+
+	mockLessonRepo.On("GetRevision", mock.Anything, int64(1), 1).Return(&domain.LessonRevision{LessonID: 1, Revision: 1, Lesson: &previous}, nil).Once()
+	mockLessonRepo.On("GetByID", mock.Anything, int64(1)).Return(&current, nil).Once()
+	mockLessonRepo.On("UpdateLesson", mock.Anything, mock.AnythingOfType("*domain.Lesson"), mock.MatchedBy(func(revision *domain.LessonRevision) bool {
+		return revision.Lesson.Title == "Current" && revision.Diff == "-new\n+old\n"
+	})).Return(nil).Once()
+	u := ucase.NewLessonUseCase(mockLessonRepo, mockContentUseCase, anyCollaborator(), time.Second*2)
+
+	lesson, err := u.RestoreRevision(context.TODO(), 1, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Previous", lesson.Title)
+	mockLessonRepo.AssertExpectations(t)
+}
+
+func TestLessonAuthorship(t *testing.T) {
+	t.Run("create-in-other-course", func(t *testing.T) {
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockCollaboratorUseCase.On("AuthorizeCourse", mock.Anything, int64(3), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+		u := ucase.NewLessonUseCase(mockLessonRepo, new(mocks.ContentUseCase), mockCollaboratorUseCase, time.Second*2)
+
+		err := u.CreateLesson(context.TODO(), &domain.Lesson{CourseID: 3, Title: "Intro"})
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockLessonRepo.AssertNotCalled(t, "CreateLesson", mock.Anything, mock.Anything)
+	})
+	t.Run("bulk-skips-other-courses", func(t *testing.T) {
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(1), domain.CollaboratorEditor).Return(nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(2), domain.CollaboratorEditor).Return(domain.ErrNotFound).Once()
+		mockLessonRepo.On("BulkAction", mock.Anything, mock.MatchedBy(func(a *domain.BulkAction) bool {
+			return len(a.IDs) == 1 && a.IDs[0] == 1
+		}), mock.AnythingOfType("int64")).Return([]domain.BulkResult{{ID: 1, Success: true}}, nil).Once()
+		u := ucase.NewLessonUseCase(mockLessonRepo, new(mocks.ContentUseCase), mockCollaboratorUseCase, time.Second*2)
+
+		results, err := u.BulkAction(context.TODO(), &domain.BulkAction{Action: domain.BulkDelete, IDs: []int64{1, 2}})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{{ID: 1, Success: true}, {ID: 2, Error: domain.ErrNotFound.Error()}}, results)
+	})
+}
@@ -0,0 +1,20 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+type logMailer struct{}
+
+// Init will create a Mailer writing the emails to the log instead of sending them, for development
+func Init() domain.Mailer {
+	return &logMailer{}
+}
+
+func (m *logMailer) Send(ctx context.Context, mail *domain.Mail) error {
+	log.Infof("Mail to %s: %s\n%s", mail.To, mail.Subject, mail.Body)
+	return nil
+}
@@ -0,0 +1,103 @@
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	netsmtp "net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+type smtpMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+// Init will create a Mailer sending through the SMTP server. STARTTLS is used when the server offers it,
+// and the credentials are only sent when a username is configured.
+func Init(host string, port int, username string, password string, from string) domain.Mailer {
+	return &smtpMailer{
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// header removes line breaks, which would let a value inject headers
+func header(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+func (m *smtpMailer) Send(ctx context.Context, mail *domain.Mail) (err error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+	client, err := netsmtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		log.Error(err)
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+	if m.username != "" {
+		if err = client.Auth(netsmtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+	to := header(mail.To)
+	if err = client.Mail(header(m.from)); err != nil {
+		log.Error(err)
+		return err
+	}
+	if err = client.Rcpt(to); err != nil {
+		log.Error(err)
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	body := strings.ReplaceAll(strings.ReplaceAll(mail.Body, "\r\n", "\n"), "\n", "\r\n")
+	_, err = fmt.Fprintf(w, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n%s", header(m.from), to, header(mail.Subject),
+		time.Now().Format(time.RFC1123Z), body)
+	if err != nil {
+		w.Close()
+		log.Error(err)
+		return err
+	}
+	if err = w.Close(); err != nil {
+		log.Error(err)
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,48 @@
+package smtp_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	_smtp "github.com/meroedu/meroedu/internal/mail/smtp"
+	"github.com/meroedu/meroedu/internal/mail/smtp/smtptest"
+)
+
+func TestSend(t *testing.T) {
+	server := smtptest.NewServer()
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.TODO(), 2*time.Second)
+	defer cancel()
+
+	t.Run("success", func(t *testing.T) {
+		mailer := _smtp.Init(server.Host, server.Port, "meroedu", "s3cret", "no-reply@school.local")
+		err := mailer.Send(ctx, &domain.Mail{To: "sita@school.local", Subject: "Hello", Body: "Line one\n.hidden dot\n"})
+		assert.NoError(t, err)
+
+		messages := server.Messages()
+		if assert.Len(t, messages, 1) {
+			assert.Equal(t, "meroedu", messages[0].Username)
+			assert.Equal(t, "no-reply@school.local", messages[0].From)
+			assert.Equal(t, []string{"sita@school.local"}, messages[0].To)
+			assert.Contains(t, messages[0].Data, "Subject: Hello\r\n")
+			assert.True(t, strings.HasSuffix(messages[0].Data, "\r\n\r\nLine one\r\n.hidden dot\r\n"), messages[0].Data)
+		}
+	})
+	t.Run("header-injection", func(t *testing.T) {
+		mailer := _smtp.Init(server.Host, server.Port, "", "", "no-reply@school.local")
+		err := mailer.Send(ctx, &domain.Mail{To: "sita@school.local", Subject: "Hello\r\nBcc: eve@evil.local", Body: "Hi"})
+		assert.NoError(t, err)
+
+		messages := server.Messages()
+		if assert.Len(t, messages, 2) {
+			assert.Empty(t, messages[1].Username)
+			assert.Contains(t, messages[1].Data, "Subject: HelloBcc: eve@evil.local\r\n")
+			assert.NotContains(t, messages[1].Data, "\r\nBcc:")
+		}
+	})
+}
@@ -0,0 +1,162 @@
+// Package smtptest provides an in-process SMTP sink for tests. It accepts every message, without TLS,
+// and keeps them for inspection. Any AUTH PLAIN credentials are accepted and recorded.
+package smtptest
+
+import (
+	"bufio"
+	"encoding/base64"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Message is a message received by the sink
+type Message struct {
+	// Username is the user of the AUTH PLAIN command, if any
+	Username string
+	From     string
+	To       []string
+	// Data is the message with its headers, with CRLF line endings and the dots unstuffed
+	Data string
+}
+
+// Server is an SMTP sink listening on the loopback interface
+type Server struct {
+	Host string
+	Port int
+
+	listener net.Listener
+	mutex    sync.Mutex
+	messages []Message
+}
+
+// NewServer starts a sink. Close it when done.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("smtptest: failed to listen: " + err.Error())
+	}
+	addr := listener.Addr().(*net.TCPAddr)
+	s := &Server{
+		Host:     addr.IP.String(),
+		Port:     addr.Port,
+		listener: listener,
+	}
+	go s.serve()
+	return s
+}
+
+// Close stops the sink
+func (s *Server) Close() {
+	s.listener.Close()
+}
+
+// Messages returns the messages received so far
+func (s *Server) Messages() []Message {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) bool {
+		_, err := conn.Write([]byte(line + "\r\n"))
+		return err == nil
+	}
+	if !reply("220 smtptest ready") {
+		return
+	}
+	var msg Message
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(line)
+		if i := strings.IndexByte(verb, ' '); i >= 0 {
+			verb = verb[:i]
+		}
+		ok := true
+		switch verb {
+		case "EHLO":
+			ok = reply("250-smtptest") && reply("250-8BITMIME") && reply("250 AUTH PLAIN")
+		case "HELO", "NOOP":
+			ok = reply("250 OK")
+		case "AUTH":
+			fields := strings.Fields(line)
+			if len(fields) != 3 || strings.ToUpper(fields[1]) != "PLAIN" {
+				ok = reply("504 Unrecognized authentication type")
+				break
+			}
+			raw, err := base64.StdEncoding.DecodeString(fields[2])
+			parts := strings.Split(string(raw), "\x00")
+			if err != nil || len(parts) != 3 {
+				ok = reply("501 Malformed credentials")
+				break
+			}
+			msg.Username = parts[1]
+			ok = reply("235 Authentication successful")
+		case "MAIL":
+			msg.From = address(line)
+			msg.To = nil
+			ok = reply("250 OK")
+		case "RCPT":
+			msg.To = append(msg.To, address(line))
+			ok = reply("250 OK")
+		case "DATA":
+			if !reply("354 End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			msg.Data = data.String()
+			s.mutex.Lock()
+			s.messages = append(s.messages, msg)
+			s.mutex.Unlock()
+			msg = Message{Username: msg.Username}
+			ok = reply("250 OK")
+		case "RSET":
+			msg = Message{Username: msg.Username}
+			ok = reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			ok = reply("502 Command not implemented")
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// address returns the address of a MAIL FROM:<a> or RCPT TO:<a> command
+func address(line string) string {
+	start, end := strings.IndexByte(line, '<'), strings.LastIndexByte(line, '>')
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
@@ -0,0 +1,287 @@
+package http
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// discoveryTTL is how long the discovery document of an issuer is cached
+const discoveryTTL = time.Hour
+
+// discovery is the part of the OpenID Provider metadata the client needs, with the signing keys
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	fetchedAt             time.Time
+	keys                  map[string]*rsa.PublicKey
+}
+
+type httpClient struct {
+	client *http.Client
+	mutex  sync.Mutex
+	cache  map[string]*discovery
+}
+
+// Init will create an object that represent the OpenID Connect client interface
+func Init(timeout time.Duration) domain.OIDCClient {
+	return &httpClient{
+		client: &http.Client{Timeout: timeout},
+		cache:  make(map[string]*discovery),
+	}
+}
+
+func (c *httpClient) getJSON(ctx context.Context, endpoint string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	res, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		log.Errorf("GET %s returned %d", endpoint, res.StatusCode)
+		return domain.ErrInternalServerError
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// discover returns the metadata of the issuer, fetching it when it is not cached yet or is stale
+func (c *httpClient) discover(ctx context.Context, issuer string) (*discovery, error) {
+	c.mutex.Lock()
+	d, ok := c.cache[issuer]
+	c.mutex.Unlock()
+	if ok && time.Since(d.fetchedAt) < discoveryTTL {
+		return d, nil
+	}
+	d = &discovery{}
+	if err := c.getJSON(ctx, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", d); err != nil {
+		return nil, err
+	}
+	if d.Issuer != issuer || d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		log.Errorf("invalid discovery document of %s", issuer)
+		return nil, domain.ErrInternalServerError
+	}
+	d.fetchedAt = time.Now()
+	c.mutex.Lock()
+	c.cache[issuer] = d
+	c.mutex.Unlock()
+	return d, nil
+}
+
+// key returns the RSA signing key with the given id. The key set is fetched again when the key is
+// unknown, so keys rotated by the provider are picked up.
+func (c *httpClient) key(ctx context.Context, d *discovery, kid string) (*rsa.PublicKey, error) {
+	c.mutex.Lock()
+	keys := d.keys
+	c.mutex.Unlock()
+	if k := findKey(keys, kid); k != nil {
+		return k, nil
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := c.getJSON(ctx, d.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+	keys = make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	c.mutex.Lock()
+	d.keys = keys
+	c.mutex.Unlock()
+	if k := findKey(keys, kid); k != nil {
+		return k, nil
+	}
+	return nil, domain.ErrUnauthorized
+}
+
+// findKey looks up the key by id. A token without key id is accepted when the set holds a single key.
+func findKey(keys map[string]*rsa.PublicKey, kid string) *rsa.PublicKey {
+	if kid == "" && len(keys) == 1 {
+		for _, k := range keys {
+			return k
+		}
+	}
+	return keys[kid]
+}
+
+func scopes(p *domain.OIDCProvider) string {
+	list := []string{"openid"}
+	for _, s := range p.Scopes {
+		if s != "openid" {
+			list = append(list, s)
+		}
+	}
+	return strings.Join(list, " ")
+}
+
+// AuthCodeURL builds the authorization request of the authorization code flow with PKCE (S256)
+func (c *httpClient) AuthCodeURL(ctx context.Context, p *domain.OIDCProvider, state string, nonce string, codeChallenge string) (string, error) {
+	d, err := c.discover(ctx, p.Issuer)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.ClientID)
+	q.Set("redirect_uri", p.RedirectURL)
+	q.Set("scope", scopes(p))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// Exchange redeems the authorization code at the token endpoint and returns the claims of the verified ID token
+func (c *httpClient) Exchange(ctx context.Context, p *domain.OIDCProvider, code string, codeVerifier string, nonce string) (*domain.OIDCClaims, error) {
+	d, err := c.discover(ctx, p.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.ClientID)
+	req, err := http.NewRequest(http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if p.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+	}
+	res, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		log.Errorf("token request to %s returned %d: %s", d.TokenEndpoint, res.StatusCode, body)
+		return nil, domain.ErrUnauthorized
+	}
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&tokens); err != nil {
+		log.Error(err)
+		return nil, domain.ErrUnauthorized
+	}
+	if tokens.IDToken == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	return c.verify(ctx, d, p, tokens.IDToken, nonce)
+}
+
+// verify checks the signature, issuer, audience, expiry and nonce of the ID token
+func (c *httpClient) verify(ctx context.Context, d *discovery, p *domain.OIDCProvider, idToken string, nonce string) (*domain.OIDCClaims, error) {
+	mapClaims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, mapClaims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, domain.ErrUnauthorized
+		}
+		kid, _ := t.Header["kid"].(string)
+		return c.key(ctx, d, kid)
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, domain.ErrUnauthorized
+	}
+	audience := stringList(mapClaims["aud"])
+	azp, _ := mapClaims["azp"].(string)
+	claims := &domain.OIDCClaims{
+		Subject:           stringClaim(mapClaims, "sub"),
+		Email:             stringClaim(mapClaims, "email"),
+		EmailVerified:     mapClaims["email_verified"] == true || mapClaims["email_verified"] == "true",
+		GivenName:         stringClaim(mapClaims, "given_name"),
+		FamilyName:        stringClaim(mapClaims, "family_name"),
+		Name:              stringClaim(mapClaims, "name"),
+		PreferredUsername: stringClaim(mapClaims, "preferred_username"),
+		Raw:               mapClaims,
+	}
+	switch {
+	case !mapClaims.VerifyIssuer(d.Issuer, true),
+		!contains(audience, p.ClientID),
+		len(audience) > 1 && azp != p.ClientID,
+		!mapClaims.VerifyExpiresAt(time.Now().Unix(), true),
+		stringClaim(mapClaims, "nonce") != nonce,
+		claims.Subject == "":
+		return nil, domain.ErrUnauthorized
+	}
+	return claims, nil
+}
+
+func stringClaim(m jwt.MapClaims, name string) string {
+	s, _ := m[name].(string)
+	return s
+}
+
+// stringList reads a claim that is either a single string or an array of strings
+func stringList(v interface{}) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		list := make([]string, 0, len(t))
+		for _, e := range t {
+			if s, ok := e.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package http_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	_oidcClient "github.com/meroedu/meroedu/internal/oidc/client/http"
+)
+
+// mockProvider is a local OpenID Connect provider. It issues an ID token with the claims of the
+// next login for a code, after checking the PKCE code verifier.
+type mockProvider struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	claims    jwt.MapClaims
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	m := &mockProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+			"jwks_uri":               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, secret, _ := r.BasicAuth()
+		sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if clientID != "meroedu" || secret != "s3cret" || r.FormValue("code") != "the-code" ||
+			base64.RawURLEncoding.EncodeToString(sum[:]) != m.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, m.claims)
+		token.Header["kid"] = "key-1"
+		signed, err := token.SignedString(m.key)
+		assert.NoError(t, err)
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "at", "token_type": "Bearer", "id_token": signed})
+	})
+	m.server = httptest.NewServer(mux)
+	return m
+}
+
+func (m *mockProvider) provider() *domain.OIDCProvider {
+	return &domain.OIDCProvider{
+		Issuer:       m.server.URL,
+		ClientID:     "meroedu",
+		ClientSecret: "s3cret",
+		RedirectURL:  "https://meroedu.com/auth/oidc/callback",
+		Scopes:       []string{"email", "profile"},
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	m := newMockProvider(t)
+	defer m.server.Close()
+
+	client := _oidcClient.Init(time.Second)
+	loginURL, err := client.AuthCodeURL(context.TODO(), m.provider(), "the-state", "the-nonce", "the-challenge")
+	assert.NoError(t, err)
+	u, err := url.Parse(loginURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "/authorize", u.Path)
+	q := u.Query()
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+	assert.Equal(t, "the-state", q.Get("state"))
+	assert.Equal(t, "the-nonce", q.Get("nonce"))
+	assert.Equal(t, "the-challenge", q.Get("code_challenge"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+}
+
+func TestExchange(t *testing.T) {
+	m := newMockProvider(t)
+	defer m.server.Close()
+	verifier := "a-very-long-code-verifier-of-the-login"
+	sum := sha256.Sum256([]byte(verifier))
+	m.challenge = base64.RawURLEncoding.EncodeToString(sum[:])
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            m.server.URL,
+			"sub":            "user-42",
+			"aud":            "meroedu",
+			"exp":            time.Now().Add(time.Minute).Unix(),
+			"iat":            time.Now().Unix(),
+			"nonce":          "the-nonce",
+			"email":          "dinesh@example.com",
+			"email_verified": true,
+			"given_name":     "Dinesh",
+			"family_name":    "Katwal",
+			"groups":         []string{"teachers"},
+		}
+	}
+	client := _oidcClient.Init(time.Second)
+
+	t.Run("success", func(t *testing.T) {
+		m.claims = valid()
+		claims, err := client.Exchange(context.TODO(), m.provider(), "the-code", verifier, "the-nonce")
+		assert.NoError(t, err)
+		assert.Equal(t, "user-42", claims.Subject)
+		assert.Equal(t, "dinesh@example.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+		assert.Equal(t, "Katwal", claims.FamilyName)
+		assert.Equal(t, []interface{}{"teachers"}, claims.Raw["groups"])
+	})
+	t.Run("wrong-code-verifier", func(t *testing.T) {
+		m.claims = valid()
+		_, err := client.Exchange(context.TODO(), m.provider(), "the-code", "another-verifier", "the-nonce")
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+
+	invalid := map[string]func(c jwt.MapClaims){
+		"wrong-nonce":    func(c jwt.MapClaims) { c["nonce"] = "replayed" },
+		"wrong-issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"wrong-audience": func(c jwt.MapClaims) { c["aud"] = "another-client" },
+		"no-azp":         func(c jwt.MapClaims) { c["aud"] = []string{"meroedu", "another-client"} },
+		"expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+		"no-subject":     func(c jwt.MapClaims) { delete(c, "sub") },
+	}
+	for name, change := range invalid {
+		t.Run(name, func(t *testing.T) {
+			m.claims = valid()
+			change(m.claims)
+			_, err := client.Exchange(context.TODO(), m.provider(), "the-code", verifier, "the-nonce")
+			assert.Equal(t, domain.ErrUnauthorized, err)
+		})
+	}
+	t.Run("multiple-audiences-with-azp", func(t *testing.T) {
+		m.claims = valid()
+		m.claims["aud"] = []string{"meroedu", "another-client"}
+		m.claims["azp"] = "meroedu"
+		_, err := client.Exchange(context.TODO(), m.provider(), "the-code", verifier, "the-nonce")
+		assert.NoError(t, err)
+	})
+	t.Run("forged-signature", func(t *testing.T) {
+		m.claims = valid()
+		original := m.key
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		m.key = other
+		defer func() { m.key = original }()
+		_, err = client.Exchange(context.TODO(), m.provider(), "the-code", verifier, "the-nonce")
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// OIDCHandler ...
+type OIDCHandler struct {
+	OIDCUseCase domain.OIDCUseCase
+}
+
+// NewOIDCHandler ...
+func NewOIDCHandler(e *echo.Echo, us domain.OIDCUseCase) {
+	handler := &OIDCHandler{
+		OIDCUseCase: us,
+	}
+	e.GET("/auth/oidc/:organization_id/login", handler.Login)
+	e.GET("/auth/oidc/callback", handler.Callback)
+	e.GET("/sso/oidc", handler.GetProvider, rbac.Require(domain.PermSSOManage))
+	e.PUT("/sso/oidc", handler.SaveProvider, rbac.Require(domain.PermSSOManage))
+	e.DELETE("/sso/oidc", handler.DeleteProvider, rbac.Require(domain.PermSSOManage))
+}
+
+// Login godoc
+// @Summary Log in with single sign-on.
+// @Description Redirect to the OpenID Connect identity provider of the organization.
+// @Tags auth
+// @Accept */*
+// @Produce json
+// @Param organization_id path int true "organization Id"
+// @Success 302
+// @Failure 404 {object} domain.APIResponseError "No enabled identity provider"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/oidc/{organization_id}/login [get]
+func (c *OIDCHandler) Login(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("organization_id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	loginURL, err := c.OIDCUseCase.LoginURL(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.Redirect(http.StatusFound, loginURL)
+}
+
+// Callback godoc
+// @Summary Complete a single sign-on login.
+// @Description Redirect target of the identity provider. Users signing in for the first time are provisioned. Returns a short lived access token and a refresh token.
+// @Tags auth
+// @Accept */*
+// @Produce json
+// @Param state query string true "state"
+// @Param code query string true "authorization code"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Login failed or expired"
+// @Failure 409 {object} domain.APIResponseError "Email used by another account"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/oidc/callback [get]
+func (c *OIDCHandler) Callback(echoContext echo.Context) error {
+	if reason := echoContext.QueryParam("error"); reason != "" {
+		return echoContext.JSON(http.StatusUnauthorized, ResponseError{Message: reason + ": " + echoContext.QueryParam("error_description")})
+	}
+	state, code := echoContext.QueryParam("state"), echoContext.QueryParam("code")
+	if state == "" || code == "" {
+		return echoContext.JSON(http.StatusBadRequest, ResponseError{Message: domain.ErrBadParamInput.Error()})
+	}
+	ctx := echoContext.Request().Context()
+	tokens, err := c.OIDCUseCase.Callback(ctx, state, code)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    tokens,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetProvider godoc
+// @Summary Get the identity provider.
+// @Description Get the OpenID Connect identity provider of the organization. The client secret is not returned.
+// @Tags sso
+// @Accept */*
+// @Produce json
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "No identity provider"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /sso/oidc [get]
+func (c *OIDCHandler) GetProvider(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	provider, err := c.OIDCUseCase.GetProvider(ctx)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    provider,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// SaveProvider godoc
+// @Summary Configure the identity provider.
+// @Description Create or replace the OpenID Connect identity provider of the organization. The client secret is kept when omitted.
+// @Tags sso
+// @Accept json
+// @Produce json
+// @Param provider body domain.OIDCProvider true "identity provider"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Invalid data or unknown role"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /sso/oidc [put]
+func (c *OIDCHandler) SaveProvider(echoContext echo.Context) error {
+	var provider domain.OIDCProvider
+	err := echoContext.Bind(&provider)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&provider); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.OIDCUseCase.SaveProvider(ctx, &provider); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    provider,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// DeleteProvider godoc
+// @Summary Remove the identity provider.
+// @Description Remove the OpenID Connect identity provider of the organization. Users provisioned through it keep their accounts.
+// @Tags sso
+// @Accept */*
+// @Produce json
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "No identity provider"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /sso/oidc [delete]
+func (c *OIDCHandler) DeleteProvider(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	if err := c.OIDCUseCase.DeleteProvider(ctx); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,158 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	oidcHTTP "github.com/meroedu/meroedu/internal/oidc/delivery/http"
+)
+
+const provider = `{"issuer":"https://login.meroedu.com","client_id":"meroedu","client_secret":"secret","redirect_url":"https://meroedu.com/auth/oidc/callback","default_role":"learner","enabled":true}`
+
+func TestLogin(t *testing.T) {
+	mockUCase := new(mocks.OIDCUseCase)
+	mockUCase.On("LoginURL", mock.Anything, int64(1)).Return("https://login.meroedu.com/authorize?state=abc", nil).Once()
+	mockUCase.On("LoginURL", mock.Anything, int64(2)).Return("", domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"1", http.StatusFound},
+		{"2", http.StatusNotFound},
+		{"meroedu", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/auth/oidc/"+tt.id+"/login", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/auth/oidc/:organization_id/login")
+		c.SetParamNames("organization_id")
+		c.SetParamValues(tt.id)
+		handler := oidcHTTP.OIDCHandler{
+			OIDCUseCase: mockUCase,
+		}
+		err = handler.Login(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCallback(t *testing.T) {
+	mockUCase := new(mocks.OIDCUseCase)
+	mockUCase.On("Callback", mock.Anything, "abc", "good").Return(&domain.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil).Once()
+	mockUCase.On("Callback", mock.Anything, "abc", "taken").Return(nil, domain.ErrConflict).Once()
+	mockUCase.On("Callback", mock.Anything, "expired", "good").Return(nil, domain.ErrUnauthorized).Once()
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"state=abc&code=good", http.StatusOK},
+		{"state=abc&code=taken", http.StatusConflict},
+		{"state=expired&code=good", http.StatusUnauthorized},
+		{"state=abc", http.StatusBadRequest},
+		{"error=access_denied&error_description=cancelled", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/auth/oidc/callback?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := oidcHTTP.OIDCHandler{
+			OIDCUseCase: mockUCase,
+		}
+		err = handler.Callback(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.query)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetProvider(t *testing.T) {
+	mockUCase := new(mocks.OIDCUseCase)
+	mockUCase.On("GetProvider", mock.Anything).Return(nil, domain.ErrNotFound).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/sso/oidc", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := oidcHTTP.OIDCHandler{
+		OIDCUseCase: mockUCase,
+	}
+	err = handler.GetProvider(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSaveProvider(t *testing.T) {
+	mockUCase := new(mocks.OIDCUseCase)
+	mockUCase.On("SaveProvider", mock.Anything, mock.MatchedBy(func(p *domain.OIDCProvider) bool { return p.DefaultRole == "learner" })).Return(nil).Once()
+	mockUCase.On("SaveProvider", mock.Anything, mock.MatchedBy(func(p *domain.OIDCProvider) bool { return p.DefaultRole == "wizard" })).Return(domain.ErrBadParamInput).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{provider, http.StatusOK},
+		{strings.Replace(provider, "learner", "wizard", 1), http.StatusBadRequest},
+		{strings.Replace(provider, "https://login.meroedu.com", "login", 1), http.StatusBadRequest},
+		{`{"issuer":"https://login.meroedu.com","client_id":"meroedu"}`, http.StatusBadRequest},
+		{`{"enabled":"yes"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/sso/oidc", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := oidcHTTP.OIDCHandler{
+			OIDCUseCase: mockUCase,
+		}
+		err = handler.SaveProvider(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteProvider(t *testing.T) {
+	mockUCase := new(mocks.OIDCUseCase)
+	mockUCase.On("DeleteProvider", mock.Anything).Return(nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/sso/oidc", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := oidcHTTP.OIDCHandler{
+		OIDCUseCase: mockUCase,
+	}
+	err = handler.DeleteProvider(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
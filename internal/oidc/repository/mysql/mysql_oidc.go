@@ -0,0 +1,200 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the OpenID Connect Repository interface
+func Init(db *sql.DB) domain.OIDCRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+// GetProvider returns the identity provider of the caller's organization
+func (m *mysqlRepository) GetProvider(ctx context.Context) (*domain.OIDCProvider, error) {
+	query := `SELECT id,organization_id,issuer,client_id,client_secret,redirect_url,scopes,role_claim,role_mapping,default_role,enabled,updated_at,created_at
+		FROM oidc_providers WHERE organization_id = ?`
+	p := domain.OIDCProvider{}
+	var clientSecret, scopes, roleClaim, roleMapping sql.NullString
+	err := m.conn.QueryRowContext(ctx, query, domain.OrganizationIDFromContext(ctx)).Scan(
+		&p.ID,
+		&p.OrganizationID,
+		&p.Issuer,
+		&p.ClientID,
+		&clientSecret,
+		&p.RedirectURL,
+		&scopes,
+		&roleClaim,
+		&roleMapping,
+		&p.DefaultRole,
+		&p.Enabled,
+		&p.UpdatedAt,
+		&p.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	p.ClientSecret = clientSecret.String
+	p.Scopes = strings.Fields(scopes.String)
+	p.RoleClaim = roleClaim.String
+	if roleMapping.String != "" {
+		if err = json.Unmarshal([]byte(roleMapping.String), &p.RoleMapping); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+	}
+	return &p, nil
+}
+
+// SaveProvider creates or replaces the identity provider of the caller's organization
+func (m *mysqlRepository) SaveProvider(ctx context.Context, p *domain.OIDCProvider) (err error) {
+	roleMapping := sql.NullString{}
+	if len(p.RoleMapping) > 0 {
+		var raw []byte
+		if raw, err = json.Marshal(p.RoleMapping); err != nil {
+			return
+		}
+		roleMapping = sql.NullString{String: string(raw), Valid: true}
+	}
+	p.OrganizationID = domain.OrganizationIDFromContext(ctx)
+	query := `INSERT INTO oidc_providers (organization_id,issuer,client_id,client_secret,redirect_url,scopes,role_claim,role_mapping,default_role,enabled,updated_at,created_at)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE id=LAST_INSERT_ID(id),issuer=VALUES(issuer),client_id=VALUES(client_id),
+		client_secret=VALUES(client_secret),redirect_url=VALUES(redirect_url),scopes=VALUES(scopes),role_claim=VALUES(role_claim),
+		role_mapping=VALUES(role_mapping),default_role=VALUES(default_role),enabled=VALUES(enabled),updated_at=VALUES(updated_at)`
+	res, err := m.conn.ExecContext(ctx, query, p.OrganizationID, p.Issuer, p.ClientID,
+		sql.NullString{String: p.ClientSecret, Valid: p.ClientSecret != ""}, p.RedirectURL, strings.Join(p.Scopes, " "),
+		sql.NullString{String: p.RoleClaim, Valid: p.RoleClaim != ""}, roleMapping, p.DefaultRole, p.Enabled, p.UpdatedAt, p.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	p.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+	}
+	return
+}
+
+// DeleteProvider removes the identity provider of the caller's organization together with its identity links
+func (m *mysqlRepository) DeleteProvider(ctx context.Context) error {
+	res, err := m.conn.ExecContext(ctx, `DELETE FROM oidc_providers WHERE organization_id = ?`, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// CreateLoginState stores the state of a new login and drops the states that expired unused
+func (m *mysqlRepository) CreateLoginState(ctx context.Context, s *domain.OIDCLoginState) error {
+	if _, err := m.conn.ExecContext(ctx, `DELETE FROM oidc_login_states WHERE expires_at < ?`, time.Now().Unix()); err != nil {
+		log.Error(err)
+		return err
+	}
+	query := `INSERT oidc_login_states SET state_hash=?,provider_id=?,organization_id=?,nonce=?,code_verifier=?,expires_at=?,created_at=?`
+	_, err := m.conn.ExecContext(ctx, query, s.StateHash, s.ProviderID, s.OrganizationID, s.Nonce, s.CodeVerifier, s.ExpiresAt, s.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+	}
+	return err
+}
+
+// ConsumeLoginState returns and deletes the login state. It is looked up in every organization since the
+// callback is not authenticated; a state already consumed by a concurrent callback is not found.
+func (m *mysqlRepository) ConsumeLoginState(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error) {
+	query := `SELECT state_hash,provider_id,organization_id,nonce,code_verifier,expires_at,created_at FROM oidc_login_states WHERE state_hash = ?`
+	s := domain.OIDCLoginState{}
+	err := m.conn.QueryRowContext(ctx, query, stateHash).Scan(
+		&s.StateHash,
+		&s.ProviderID,
+		&s.OrganizationID,
+		&s.Nonce,
+		&s.CodeVerifier,
+		&s.ExpiresAt,
+		&s.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	res, err := m.conn.ExecContext(ctx, `DELETE FROM oidc_login_states WHERE state_hash = ?`, stateHash)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affect == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &s, nil
+}
+
+// GetIdentity returns the link of the subject to a user of the caller's organization
+func (m *mysqlRepository) GetIdentity(ctx context.Context, providerID int64, subject string) (*domain.UserIdentity, error) {
+	query := `SELECT i.id,i.user_id,i.provider_id,i.subject,i.created_at FROM user_identities i JOIN oidc_providers p ON p.id = i.provider_id
+		WHERE i.provider_id = ? AND i.subject = ? AND p.organization_id = ?`
+	i := domain.UserIdentity{}
+	err := m.conn.QueryRowContext(ctx, query, providerID, subject, domain.OrganizationIDFromContext(ctx)).Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProviderID,
+		&i.Subject,
+		&i.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return &i, nil
+}
+
+// CreateIdentity links a user of the caller's organization to the subject
+func (m *mysqlRepository) CreateIdentity(ctx context.Context, i *domain.UserIdentity) error {
+	query := `INSERT INTO user_identities (user_id,provider_id,subject,created_at) SELECT id,?,?,? FROM users WHERE id = ? AND organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, i.ProviderID, i.Subject, i.CreatedAt, i.UserID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	i.ID, err = res.LastInsertId()
+	return err
+}
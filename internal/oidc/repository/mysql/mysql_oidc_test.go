@@ -0,0 +1,100 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/oidc/repository/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+func TestGetProvider(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	columns := []string{"id", "organization_id", "issuer", "client_id", "client_secret", "redirect_url", "scopes", "role_claim", "role_mapping",
+		"default_role", "enabled", "updated_at", "created_at"}
+	rows := sqlmock.NewRows(columns).AddRow(3, 2, "https://idp.example.com", "meroedu", "s3cret", "https://meroedu.com/auth/oidc/callback",
+		"email profile", "groups", `{"teachers":"instructor"}`, domain.RoleLearner, true, time.Now().Unix(), time.Now().Unix())
+	mock.ExpectQuery(`SELECT .+ FROM oidc_providers WHERE organization_id = \?`).WithArgs(2).WillReturnRows(rows)
+
+	repo := mysqlrepo.Init(db)
+	p, err := repo.GetProvider(orgCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"email", "profile"}, p.Scopes)
+	assert.Equal(t, map[string]string{"teachers": "instructor"}, p.RoleMapping)
+	assert.True(t, p.Enabled)
+}
+
+func TestSaveProvider(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	p := &domain.OIDCProvider{Issuer: "https://idp.example.com", ClientID: "meroedu", RedirectURL: "https://meroedu.com/auth/oidc/callback",
+		Scopes: []string{"email"}, DefaultRole: domain.RoleLearner, Enabled: true}
+	mock.ExpectExec(`INSERT INTO oidc_providers .+ ON DUPLICATE KEY UPDATE id=LAST_INSERT_ID\(id\)`).
+		WithArgs(2, p.Issuer, p.ClientID, nil, p.RedirectURL, "email", nil, nil, p.DefaultRole, true, p.UpdatedAt, p.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.SaveProvider(orgCtx, p)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), p.ID)
+	assert.Equal(t, int64(2), p.OrganizationID)
+}
+
+func TestConsumeLoginState(t *testing.T) {
+	columns := []string{"state_hash", "provider_id", "organization_id", "nonce", "code_verifier", "expires_at", "created_at"}
+	query := `SELECT .+ FROM oidc_login_states WHERE state_hash = \?`
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(query).WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("hash", 3, 2, "n", "v", time.Now().Unix(), time.Now().Unix()))
+		mock.ExpectExec(`DELETE FROM oidc_login_states WHERE state_hash = \?`).WithArgs("hash").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := mysqlrepo.Init(db)
+		s, err := repo.ConsumeLoginState(context.TODO(), "hash")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), s.OrganizationID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("consumed-concurrently", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(query).WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("hash", 3, 2, "n", "v", time.Now().Unix(), time.Now().Unix()))
+		mock.ExpectExec(`DELETE FROM oidc_login_states WHERE state_hash = \?`).WithArgs("hash").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := mysqlrepo.Init(db)
+		s, err := repo.ConsumeLoginState(context.TODO(), "hash")
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.Nil(t, s)
+	})
+}
+
+func TestCreateIdentityOtherOrganization(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	i := &domain.UserIdentity{UserID: 9, ProviderID: 3, Subject: "user-42", CreatedAt: time.Now().Unix()}
+	mock.ExpectExec(`INSERT INTO user_identities \(user_id,provider_id,subject,created_at\) SELECT id,\?,\?,\? FROM users WHERE id = \? AND organization_id = \?`).
+		WithArgs(i.ProviderID, i.Subject, i.CreatedAt, i.UserID, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.CreateIdentity(orgCtx, i)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
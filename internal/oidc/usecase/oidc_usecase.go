@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// loginStateTTL is how long the user has to complete the login at the identity provider
+const loginStateTTL = 10 * time.Minute
+
+// OIDCUseCase ...
+type OIDCUseCase struct {
+	oidcRepo       domain.OIDCRepository
+	client         domain.OIDCClient
+	userRepo       domain.UserRepository
+	roleRepo       domain.RoleRepository
+	authUseCase    domain.AuthUseCase
+	contextTimeOut time.Duration
+}
+
+// NewOIDCUseCase will create new an OIDCUseCase. Tokens of users signed in through the identity provider are issued by the AuthUseCase.
+func NewOIDCUseCase(o domain.OIDCRepository, client domain.OIDCClient, u domain.UserRepository, r domain.RoleRepository, a domain.AuthUseCase, timeout time.Duration) domain.OIDCUseCase {
+	return &OIDCUseCase{
+		oidcRepo:       o,
+		client:         client,
+		userRepo:       u,
+		roleRepo:       r,
+		authUseCase:    a,
+		contextTimeOut: timeout,
+	}
+}
+
+func randomString() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetProvider returns the identity provider of the caller's organization, without the client secret
+func (usecase *OIDCUseCase) GetProvider(c context.Context) (*domain.OIDCProvider, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	provider, err := usecase.oidcRepo.GetProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	provider.ClientSecret = ""
+	return provider, nil
+}
+
+// checkRole makes sure the role exists in the caller's organization and does not grant more than the caller holds
+func (usecase *OIDCUseCase) checkRole(ctx context.Context, code string) error {
+	role, err := usecase.roleRepo.GetByCode(ctx, code)
+	if err == domain.ErrNotFound {
+		return domain.ErrBadParamInput
+	}
+	if err != nil {
+		return err
+	}
+	if !domain.HasPermissions(ctx, role.Permissions) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// SaveProvider creates or replaces the identity provider of the caller's organization. The client secret is
+// kept when none is given. Every role the claims can be mapped to must exist.
+func (usecase *OIDCUseCase) SaveProvider(c context.Context, provider *domain.OIDCProvider) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.checkRole(ctx, provider.DefaultRole); err != nil {
+		return err
+	}
+	for _, code := range provider.RoleMapping {
+		if err := usecase.checkRole(ctx, code); err != nil {
+			return err
+		}
+	}
+	now := time.Now().Unix()
+	existing, err := usecase.oidcRepo.GetProvider(ctx)
+	switch err {
+	case nil:
+		provider.CreatedAt = existing.CreatedAt
+		if provider.ClientSecret == "" {
+			provider.ClientSecret = existing.ClientSecret
+		}
+	case domain.ErrNotFound:
+		provider.CreatedAt = now
+	default:
+		return err
+	}
+	provider.UpdatedAt = now
+	if err = usecase.oidcRepo.SaveProvider(ctx, provider); err != nil {
+		return err
+	}
+	provider.ClientSecret = ""
+	return nil
+}
+
+// DeleteProvider removes the identity provider of the caller's organization. Users provisioned through it keep their accounts.
+func (usecase *OIDCUseCase) DeleteProvider(c context.Context) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.oidcRepo.DeleteProvider(ctx)
+}
+
+// LoginURL starts a login at the identity provider of the organization. The state, the nonce and the PKCE
+// code verifier are kept until the callback.
+func (usecase *OIDCUseCase) LoginURL(c context.Context, organizationID int64) (string, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	ctx = domain.WithOrganizationID(ctx, organizationID)
+	provider, err := usecase.oidcRepo.GetProvider(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !provider.Enabled {
+		return "", domain.ErrNotFound
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	err = usecase.oidcRepo.CreateLoginState(ctx, &domain.OIDCLoginState{
+		StateHash:      hashState(state),
+		ProviderID:     provider.ID,
+		OrganizationID: organizationID,
+		Nonce:          nonce,
+		CodeVerifier:   verifier,
+		ExpiresAt:      now.Add(loginStateTTL).Unix(),
+		CreatedAt:      now.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+	return usecase.client.AuthCodeURL(ctx, provider, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+}
+
+// Callback completes the login: the code is exchanged for a verified ID token, the user is looked up or
+// provisioned, and a token pair is issued.
+func (usecase *OIDCUseCase) Callback(c context.Context, state string, code string) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	loginState, err := usecase.oidcRepo.ConsumeLoginState(ctx, hashState(state))
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if loginState.ExpiresAt <= time.Now().Unix() {
+		return nil, domain.ErrUnauthorized
+	}
+	ctx = domain.WithOrganizationID(ctx, loginState.OrganizationID)
+	provider, err := usecase.oidcRepo.GetProvider(ctx)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if provider.ID != loginState.ProviderID || !provider.Enabled {
+		return nil, domain.ErrUnauthorized
+	}
+	claims, err := usecase.client.Exchange(ctx, provider, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	user, err := usecase.provision(ctx, provider, claims)
+	if err != nil {
+		return nil, err
+	}
+	// the identity provider is in charge of the second factor of single sign-on logins
+	return usecase.authUseCase.IssueTokens(ctx, user)
+}
+
+// roleFor returns the role code the claims map to
+func roleFor(provider *domain.OIDCProvider, claims *domain.OIDCClaims) string {
+	if provider.RoleClaim != "" {
+		var values []interface{}
+		switch v := claims.Raw[provider.RoleClaim].(type) {
+		case string:
+			values = []interface{}{v}
+		case []interface{}:
+			values = v
+		}
+		for _, v := range values {
+			if s, ok := v.(string); ok && provider.RoleMapping[s] != "" {
+				return provider.RoleMapping[s]
+			}
+		}
+	}
+	return provider.DefaultRole
+}
+
+// provision returns the user linked to the subject. On the first login the user with the same verified
+// email in the organization is linked, or a new user is created. When the provider maps a role claim,
+// the role of the user follows the claims on every login.
+func (usecase *OIDCUseCase) provision(ctx context.Context, provider *domain.OIDCProvider, claims *domain.OIDCClaims) (*domain.User, error) {
+	role, err := usecase.roleRepo.GetByCode(ctx, roleFor(provider, claims))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+
+	var user *domain.User
+	identity, err := usecase.oidcRepo.GetIdentity(ctx, provider.ID, claims.Subject)
+	switch err {
+	case nil:
+		if user, err = usecase.userRepo.GetByID(ctx, identity.UserID); err != nil {
+			return nil, err
+		}
+	case domain.ErrNotFound:
+		if user, err = usecase.link(ctx, provider, claims, role, now); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if provider.RoleClaim != "" && user.RoleID != role.ID {
+		user.RoleID = role.ID
+		user.UpdatedAt = now
+		if err = usecase.userRepo.UpdateUser(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// link links the subject to the user with the same email, creating the user when there is none
+func (usecase *OIDCUseCase) link(ctx context.Context, provider *domain.OIDCProvider, claims *domain.OIDCClaims, role *domain.Role, now int64) (*domain.User, error) {
+	if claims.Email == "" {
+		log.Errorf("identity provider of organization %d returned no email for %s", provider.OrganizationID, claims.Subject)
+		return nil, domain.ErrUnauthorized
+	}
+	user, err := usecase.userRepo.GetByEmail(ctx, claims.Email)
+	switch err {
+	case nil:
+		// an unverified email could be used to take over the account
+		if user.OrganizationID != provider.OrganizationID || !claims.EmailVerified {
+			return nil, domain.ErrConflict
+		}
+	case domain.ErrNotFound:
+		user = &domain.User{
+			FirstName:      claims.GivenName,
+			LastName:       claims.FamilyName,
+			Email:          claims.Email,
+			OrganizationID: provider.OrganizationID,
+			RoleID:         role.ID,
+			Status:         domain.UserActive,
+			JoinedDate:     now,
+			UpdatedAt:      now,
+			CreatedAt:      now,
+		}
+		if user.LastName == "" {
+			user.LastName = claims.Name
+		}
+		if user.LastName == "" {
+			user.LastName = claims.Email
+		}
+		if claims.PreferredUsername != "" {
+			if _, err = usecase.userRepo.GetByUsername(ctx, claims.PreferredUsername); err == domain.ErrNotFound {
+				user.Username = claims.PreferredUsername
+			}
+		}
+		if err = usecase.userRepo.CreateUser(ctx, user); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+	err = usecase.oidcRepo.CreateIdentity(ctx, &domain.UserIdentity{
+		UserID:     user.ID,
+		ProviderID: provider.ID,
+		Subject:    claims.Subject,
+		CreatedAt:  now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
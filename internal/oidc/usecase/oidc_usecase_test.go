@@ -0,0 +1,252 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/oidc/usecase"
+)
+
+var provider = &domain.OIDCProvider{
+	ID:             3,
+	OrganizationID: 2,
+	Issuer:         "https://idp.example.com",
+	ClientID:       "meroedu",
+	RoleClaim:      "groups",
+	RoleMapping:    map[string]string{"teachers": domain.RoleInstructor},
+	DefaultRole:    domain.RoleLearner,
+	Enabled:        true,
+}
+
+var (
+	learner    = &domain.Role{ID: 3, Code: domain.RoleLearner}
+	instructor = &domain.Role{ID: 2, Code: domain.RoleInstructor}
+)
+
+// login prepares a callback for a valid state returning the given claims
+func login(mockOIDCRepo *mocks.OIDCRepository, mockOIDCClient *mocks.OIDCClient, claims *domain.OIDCClaims) {
+	inOrganization := mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.OrganizationIDFromContext(ctx) == provider.OrganizationID
+	})
+	state := &domain.OIDCLoginState{ProviderID: provider.ID, OrganizationID: provider.OrganizationID, Nonce: "n", CodeVerifier: "v",
+		ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	mockOIDCRepo.On("ConsumeLoginState", mock.Anything, mock.AnythingOfType("string")).Return(state, nil).Once()
+	mockOIDCRepo.On("GetProvider", inOrganization).Return(provider, nil).Once()
+	mockOIDCClient.On("Exchange", inOrganization, provider, "the-code", "v", "n").Return(claims, nil).Once()
+}
+
+func TestLoginURL(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		mockOIDCRepo.On("GetProvider", mock.Anything).Return(provider, nil).Once()
+		var saved *domain.OIDCLoginState
+		mockOIDCRepo.On("CreateLoginState", mock.Anything, mock.AnythingOfType("*domain.OIDCLoginState")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*domain.OIDCLoginState) }).Return(nil).Once()
+		mockOIDCClient.On("AuthCodeURL", mock.Anything, provider, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+			Return("https://idp.example.com/authorize?state=x", nil).Once()
+
+		loginURL, err := u.LoginURL(context.TODO(), provider.OrganizationID)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://idp.example.com/authorize?state=x", loginURL)
+		assert.Len(t, saved.StateHash, 64)
+		assert.Equal(t, provider.ID, saved.ProviderID)
+		assert.NotEmpty(t, saved.CodeVerifier)
+		// the challenge sent is derived from the verifier, never the verifier itself
+		mockOIDCClient.AssertNotCalled(t, "AuthCodeURL", mock.Anything, provider, mock.Anything, mock.Anything, saved.CodeVerifier)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		disabled := *provider
+		disabled.Enabled = false
+		mockOIDCRepo.On("GetProvider", mock.Anything).Return(&disabled, nil).Once()
+
+		_, err := u.LoginURL(context.TODO(), provider.OrganizationID)
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockOIDCRepo.AssertNotCalled(t, "CreateLoginState", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCallback(t *testing.T) {
+	tokens := &domain.TokenPair{AccessToken: "at", RefreshToken: "rt"}
+
+	t.Run("provisions-new-user", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		claims := &domain.OIDCClaims{Subject: "user-42", Email: "dinesh@example.com", EmailVerified: true, GivenName: "Dinesh",
+			FamilyName: "Katwal", PreferredUsername: "dinesh", Raw: map[string]interface{}{"groups": []interface{}{"staff", "teachers"}}}
+		login(mockOIDCRepo, mockOIDCClient, claims)
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleInstructor).Return(instructor, nil).Once()
+		mockOIDCRepo.On("GetIdentity", mock.Anything, provider.ID, "user-42").Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, claims.Email).Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("GetByUsername", mock.Anything, "dinesh").Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+			return user.OrganizationID == provider.OrganizationID && user.RoleID == instructor.ID && user.LastName == "Katwal" &&
+				user.Username == "dinesh" && user.Status == domain.UserActive
+		})).Run(func(args mock.Arguments) { args.Get(1).(*domain.User).ID = 9 }).Return(nil).Once()
+		mockOIDCRepo.On("CreateIdentity", mock.Anything, mock.MatchedBy(func(i *domain.UserIdentity) bool {
+			return i.UserID == 9 && i.ProviderID == provider.ID && i.Subject == "user-42"
+		})).Return(nil).Once()
+		mockAuthUseCase.On("IssueTokens", mock.Anything, mock.AnythingOfType("*domain.User")).Return(tokens, nil).Once()
+
+		res, err := u.Callback(context.TODO(), "the-state", "the-code")
+		assert.NoError(t, err)
+		assert.Equal(t, tokens, res)
+		mockUserRepo.AssertExpectations(t)
+		mockOIDCRepo.AssertExpectations(t)
+	})
+	t.Run("linked-user-follows-role-claim", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		login(mockOIDCRepo, mockOIDCClient, &domain.OIDCClaims{Subject: "user-42", Raw: map[string]interface{}{"groups": "students"}})
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(learner, nil).Once()
+		mockOIDCRepo.On("GetIdentity", mock.Anything, provider.ID, "user-42").Return(&domain.UserIdentity{UserID: 9}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, int64(9)).
+			Return(&domain.User{ID: 9, OrganizationID: 2, RoleID: instructor.ID, Status: domain.UserActive}, nil).Once()
+		mockUserRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+			return user.ID == 9 && user.RoleID == learner.ID
+		})).Return(nil).Once()
+		mockAuthUseCase.On("IssueTokens", mock.Anything, mock.AnythingOfType("*domain.User")).Return(tokens, nil).Once()
+
+		_, err := u.Callback(context.TODO(), "the-state", "the-code")
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockOIDCRepo.AssertNotCalled(t, "CreateIdentity", mock.Anything, mock.Anything)
+	})
+	t.Run("unverified-email-of-existing-user", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		claims := &domain.OIDCClaims{Subject: "user-42", Email: "dinesh@example.com"}
+		login(mockOIDCRepo, mockOIDCClient, claims)
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(learner, nil).Once()
+		mockOIDCRepo.On("GetIdentity", mock.Anything, provider.ID, "user-42").Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, claims.Email).Return(&domain.User{ID: 9, OrganizationID: 2}, nil).Once()
+
+		_, err := u.Callback(context.TODO(), "the-state", "the-code")
+		assert.Equal(t, domain.ErrConflict, err)
+		mockOIDCRepo.AssertNotCalled(t, "CreateIdentity", mock.Anything, mock.Anything)
+		mockAuthUseCase.AssertNotCalled(t, "IssueTokens", mock.Anything, mock.Anything)
+	})
+	t.Run("unknown-state", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		mockOIDCRepo.On("ConsumeLoginState", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+
+		_, err := u.Callback(context.TODO(), "the-state", "the-code")
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockOIDCClient.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("expired-state", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		mockOIDCRepo.On("ConsumeLoginState", mock.Anything, mock.AnythingOfType("string")).
+			Return(&domain.OIDCLoginState{ProviderID: provider.ID, OrganizationID: 2, ExpiresAt: time.Now().Add(-time.Second).Unix()}, nil).Once()
+
+		_, err := u.Callback(context.TODO(), "the-state", "the-code")
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockOIDCClient.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSaveProvider(t *testing.T) {
+	t.Run("keeps-client-secret", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		p := &domain.OIDCProvider{Issuer: provider.Issuer, ClientID: "meroedu", DefaultRole: domain.RoleLearner}
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(learner, nil).Once()
+		mockOIDCRepo.On("GetProvider", mock.Anything).Return(&domain.OIDCProvider{ID: 3, ClientSecret: "s3cret", CreatedAt: 100}, nil).Once()
+		mockOIDCRepo.On("SaveProvider", mock.Anything, mock.MatchedBy(func(saved *domain.OIDCProvider) bool {
+			return saved.ClientSecret == "s3cret" && saved.CreatedAt == 100
+		})).Return(nil).Once()
+
+		err := u.SaveProvider(context.TODO(), p)
+		assert.NoError(t, err)
+		assert.Empty(t, p.ClientSecret)
+		mockOIDCRepo.AssertExpectations(t)
+	})
+	t.Run("unknown-role", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		p := &domain.OIDCProvider{DefaultRole: domain.RoleLearner, RoleMapping: map[string]string{"x": "wizard"}}
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(learner, nil).Once()
+		mockRoleRepo.On("GetByCode", mock.Anything, "wizard").Return(nil, domain.ErrNotFound).Once()
+
+		err := u.SaveProvider(context.TODO(), p)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockOIDCRepo.AssertNotCalled(t, "SaveProvider", mock.Anything, mock.Anything)
+	})
+	t.Run("superadmin-role-needs-holder", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		p := &domain.OIDCProvider{DefaultRole: domain.RoleSuperAdmin}
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleSuperAdmin).
+			Return(&domain.Role{ID: 4, Code: domain.RoleSuperAdmin, Permissions: []domain.Permission{domain.PermOrganizationManage}}, nil).Once()
+
+		err := u.SaveProvider(domain.WithPermissions(context.TODO(), []domain.Permission{domain.PermSSOManage}), p)
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockOIDCRepo.AssertNotCalled(t, "SaveProvider", mock.Anything, mock.Anything)
+	})
+	t.Run("mapped-role-above-caller", func(t *testing.T) {
+		mockOIDCRepo := new(mocks.OIDCRepository)
+		mockOIDCClient := new(mocks.OIDCClient)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewOIDCUseCase(mockOIDCRepo, mockOIDCClient, mockUserRepo, mockRoleRepo, mockAuthUseCase, time.Second*2)
+		p := &domain.OIDCProvider{DefaultRole: domain.RoleLearner, RoleMapping: map[string]string{"staff": domain.RoleAdmin}}
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(learner, nil).Once()
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleAdmin).
+			Return(&domain.Role{ID: 2, Code: domain.RoleAdmin, Permissions: []domain.Permission{domain.PermSSOManage, domain.PermRoleManage}}, nil).Once()
+
+		err := u.SaveProvider(domain.WithPermissions(context.TODO(), []domain.Permission{domain.PermSSOManage}), p)
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockOIDCRepo.AssertNotCalled(t, "SaveProvider", mock.Anything, mock.Anything)
+	})
+}
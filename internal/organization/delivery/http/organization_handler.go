@@ -0,0 +1,210 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// OrganizationHandler ...
+type OrganizationHandler struct {
+	OrganizationUseCase domain.OrganizationUseCase
+}
+
+// NewOrganizationHandler ...
+func NewOrganizationHandler(e *echo.Echo, us domain.OrganizationUseCase) {
+	handler := &OrganizationHandler{
+		OrganizationUseCase: us,
+	}
+	e.GET("/organizations", handler.GetAll, rbac.Require(domain.PermOrganizationManage))
+	e.GET("/organizations/:id", handler.GetByID, rbac.Require(domain.PermOrganizationManage))
+	e.POST("/organizations", handler.CreateOrganization, rbac.Require(domain.PermOrganizationManage))
+	e.PUT("/organizations/:id", handler.UpdateOrganization, rbac.Require(domain.PermOrganizationManage))
+	e.DELETE("/organizations/:id", handler.DeleteOrganization, rbac.Require(domain.PermOrganizationManage))
+}
+
+// GetAll godoc
+// @Summary Get All Organizations.
+// @Description Get all organizations, optionally filtered by name.
+// @Tags organizations
+// @Accept */*
+// @Produce json
+// @Param q query string false "search"
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /organizations [get]
+func (c *OrganizationHandler) GetAll(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	start, limit := 0, 10
+	searchQuery := echoContext.QueryParam("q")
+	var err error
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		}
+	}
+
+	list, err := c.OrganizationUseCase.GetAll(ctx, searchQuery, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary Get organization by ID.
+// @Description Get Specific organization details.
+// @Tags organizations
+// @Accept */*
+// @Produce json
+// @Param id path int true "organization Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /organizations/{id} [get]
+func (c *OrganizationHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	organization, err := c.OrganizationUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    organization,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// CreateOrganization godoc
+// @Summary Create New organization
+// @Description Create New organization. Its first administrator is then created with POST /users and its organization_id.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param organization body domain.Organization true "organization Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /organizations [post]
+func (c *OrganizationHandler) CreateOrganization(echoContext echo.Context) error {
+	var organization domain.Organization
+	err := echoContext.Bind(&organization)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&organization); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.OrganizationUseCase.CreateOrganization(ctx, &organization)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    organization,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusCreated, res)
+}
+
+// UpdateOrganization godoc
+// @Summary Update existing organization
+// @Description Update the organization. Status 0 deactivates it.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "organization Id"
+// @Param organization body domain.Organization true "organization Data"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /organizations/{id} [put]
+func (c *OrganizationHandler) UpdateOrganization(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var organization domain.Organization
+	err = echoContext.Bind(&organization)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&organization); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.OrganizationUseCase.UpdateOrganization(ctx, &organization, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    organization,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// DeleteOrganization godoc
+// @Summary Delete organization
+// @Description Delete the organization with all of its courses, users and roles. The caller's own organization can not be deleted.
+// @Tags organizations
+// @Accept */*
+// @Produce json
+// @Param id path int true "organization Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Own organization"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /organizations/{id} [delete]
+func (c *OrganizationHandler) DeleteOrganization(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.OrganizationUseCase.DeleteOrganization(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,181 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	organizationHTTP "github.com/meroedu/meroedu/internal/organization/delivery/http"
+)
+
+func TestGetAll(t *testing.T) {
+	mockUCase := new(mocks.OrganizationUseCase)
+	mockUCase.On("GetAll", mock.Anything, "mero", 0, 10).Return([]domain.Organization{{ID: 1, Name: "Meroedu"}}, nil).Once()
+	mockUCase.On("GetAll", mock.Anything, "", 0, 10).Return(nil, domain.ErrForbidden).Once()
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"q=mero", http.StatusOK},
+		{"", http.StatusForbidden},
+		{"start=first", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/organizations?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := organizationHTTP.OrganizationHandler{
+			OrganizationUseCase: mockUCase,
+		}
+		err = handler.GetAll(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.query)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	mockUCase := new(mocks.OrganizationUseCase)
+	mockUCase.On("GetByID", mock.Anything, int64(1)).Return(&domain.Organization{ID: 1, Name: "Meroedu"}, nil).Once()
+	mockUCase.On("GetByID", mock.Anything, int64(2)).Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"1", http.StatusOK},
+		{"2", http.StatusNotFound},
+		{"meroedu", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/organizations/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/organizations/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := organizationHTTP.OrganizationHandler{
+			OrganizationUseCase: mockUCase,
+		}
+		err = handler.GetByID(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateOrganization(t *testing.T) {
+	mockUCase := new(mocks.OrganizationUseCase)
+	mockUCase.On("CreateOrganization", mock.Anything, mock.MatchedBy(func(o *domain.Organization) bool { return o.Name == "Meroedu" })).Return(nil).Once()
+	mockUCase.On("CreateOrganization", mock.Anything, mock.MatchedBy(func(o *domain.Organization) bool { return o.Name == "Taken" })).Return(domain.ErrConflict).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"name":"Meroedu","website":"meroedu.com"}`, http.StatusCreated},
+		{`{"name":"Taken"}`, http.StatusConflict},
+		{`{"website":"meroedu.com"}`, http.StatusBadRequest},
+		{`{"name":"Meroedu","status":"active"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/organizations", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := organizationHTTP.OrganizationHandler{
+			OrganizationUseCase: mockUCase,
+		}
+		err = handler.CreateOrganization(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdateOrganization(t *testing.T) {
+	mockUCase := new(mocks.OrganizationUseCase)
+	mockUCase.On("UpdateOrganization", mock.Anything, mock.AnythingOfType("*domain.Organization"), int64(1)).Return(nil).Once()
+	mockUCase.On("UpdateOrganization", mock.Anything, mock.AnythingOfType("*domain.Organization"), int64(2)).Return(domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		body string
+		code int
+	}{
+		{"1", `{"name":"Meroedu","require_two_factor":true}`, http.StatusOK},
+		{"2", `{"name":"Meroedu"}`, http.StatusNotFound},
+		{"1", `{"name":""}`, http.StatusBadRequest},
+		{"1", `{"name":`, http.StatusUnprocessableEntity},
+		{"meroedu", `{"name":"Meroedu"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/organizations/"+tt.id, strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/organizations/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := organizationHTTP.OrganizationHandler{
+			OrganizationUseCase: mockUCase,
+		}
+		err = handler.UpdateOrganization(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteOrganization(t *testing.T) {
+	mockUCase := new(mocks.OrganizationUseCase)
+	mockUCase.On("DeleteOrganization", mock.Anything, int64(2)).Return(nil).Once()
+	mockUCase.On("DeleteOrganization", mock.Anything, int64(1)).Return(domain.ErrConflict).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"2", http.StatusNoContent},
+		{"1", http.StatusConflict},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/organizations/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/organizations/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := organizationHTTP.OrganizationHandler{
+			OrganizationUseCase: mockUCase,
+		}
+		err = handler.DeleteOrganization(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,173 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const organizationColumns = `id,name,description,website,address1,address2,country_id,status,require_two_factor,updated_at,created_at`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the organization's Repository interface
+func Init(db *sql.DB) domain.OrganizationRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: i, Valid: i != 0}
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Organization, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Organization, 0)
+	for rows.Next() {
+		t := domain.Organization{}
+		var description, website, address1, address2 sql.NullString
+		countryID := sql.NullInt64{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&description,
+			&website,
+			&address1,
+			&address2,
+			&countryID,
+			&t.Status,
+			&t.RequireTwoFactor,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.Description = description.String
+		t.Website = website.String
+		t.Address1 = address1.String
+		t.Address2 = address2.String
+		t.CountryID = countryID.Int64
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]domain.Organization, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organizations WHERE name LIKE ? ORDER BY created_at DESC LIMIT ?,?`
+	return m.fetch(ctx, query, "%"+searchQuery+"%", start, limit)
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.Organization, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organizations WHERE id = ?`
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+func (m *mysqlRepository) CreateOrganization(ctx context.Context, o *domain.Organization) (err error) {
+	query := `INSERT organizations SET name=?,description=?,website=?,address1=?,address2=?,country_id=?,status=?,require_two_factor=?,updated_at=?,created_at=?`
+	stmt, err := m.conn.PrepareContext(ctx, query)
+	if err != nil {
+		log.Error("Error while preparing statement ", err)
+		return
+	}
+	res, err := stmt.ExecContext(ctx, o.Name, nullString(o.Description), nullString(o.Website), nullString(o.Address1),
+		nullString(o.Address2), nullInt64(o.CountryID), o.Status, o.RequireTwoFactor, o.UpdatedAt, o.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return
+	}
+	o.ID = lastID
+	return
+}
+
+func (m *mysqlRepository) UpdateOrganization(ctx context.Context, o *domain.Organization) (err error) {
+	query := `UPDATE organizations SET name=?,description=?,website=?,address1=?,address2=?,country_id=?,status=?,require_two_factor=?,updated_at=? WHERE id = ?`
+	stmt, err := m.conn.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+	res, err := stmt.ExecContext(ctx, o.Name, nullString(o.Description), nullString(o.Website), nullString(o.Address1),
+		nullString(o.Address2), nullInt64(o.CountryID), o.Status, o.RequireTwoFactor, o.UpdatedAt, o.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+	return
+}
+
+// SetTwoFactorRequired sets whether the organization requires two-factor authentication of every user
+func (m *mysqlRepository) SetTwoFactorRequired(ctx context.Context, id int64, required bool, updatedAt int64) error {
+	res, err := m.conn.ExecContext(ctx, `UPDATE organizations SET require_two_factor=?,updated_at=? WHERE id = ?`, required, updatedAt, id)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteOrganization deletes the organization together with everything that belongs to it
+func (m *mysqlRepository) DeleteOrganization(ctx context.Context, id int64) error {
+	res, err := m.conn.ExecContext(ctx, `DELETE FROM organizations WHERE id = ?`, id)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
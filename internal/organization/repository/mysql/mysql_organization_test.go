@@ -0,0 +1,97 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/organization/repository/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var organizationColumns = []string{"id", "name", "description", "website", "address1", "address2", "country_id", "status", "require_two_factor", "updated_at", "created_at"}
+
+func TestGetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows(organizationColumns).
+		AddRow(2, "Acme", "Training", nil, nil, nil, nil, domain.OrganizationActive, true, time.Now().Unix(), time.Now().Unix())
+
+	query := `SELECT id,name,description,website,address1,address2,country_id,status,require_two_factor,updated_at,created_at FROM organizations WHERE name LIKE \? ORDER BY created_at DESC LIMIT \?,\?`
+	mock.ExpectQuery(query).WithArgs("%ac%", 0, 10).WillReturnRows(rows)
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetAll(context.TODO(), "ac", 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "Training", list[0].Description)
+	assert.Equal(t, int64(0), list[0].CountryID)
+	assert.True(t, list[0].RequireTwoFactor)
+}
+
+func TestGetByIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `SELECT .+ FROM organizations WHERE id = \?`
+	mock.ExpectQuery(query).WithArgs(9).WillReturnRows(sqlmock.NewRows(organizationColumns))
+	repo := mysqlrepo.Init(db)
+	organization, err := repo.GetByID(context.TODO(), 9)
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.Nil(t, organization)
+}
+
+func TestCreateOrganization(t *testing.T) {
+	o := &domain.Organization{
+		Name:      "Acme",
+		Website:   "https://acme.com",
+		Status:    domain.OrganizationActive,
+		UpdatedAt: time.Now().Unix(),
+		CreatedAt: time.Now().Unix(),
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	query := `INSERT organizations SET name=\?,description=\?,website=\?,address1=\?,address2=\?,country_id=\?,status=\?,require_two_factor=\?,updated_at=\?,created_at=\?`
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(o.Name, nil, o.Website, nil, nil, nil, o.Status, false, o.UpdatedAt, o.CreatedAt).WillReturnResult(sqlmock.NewResult(3, 1))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.CreateOrganization(context.TODO(), o)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), o.ID)
+}
+
+func TestUpdateOrganization(t *testing.T) {
+	o := &domain.Organization{ID: 3, Name: "Acme Inc", Status: domain.OrganizationInactive, UpdatedAt: time.Now().Unix()}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	query := `UPDATE organizations SET name=\?,description=\?,website=\?,address1=\?,address2=\?,country_id=\?,status=\?,require_two_factor=\?,updated_at=\? WHERE id = \?`
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(o.Name, nil, nil, nil, nil, nil, o.Status, false, o.UpdatedAt, o.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := mysqlrepo.Init(db)
+	err = repo.UpdateOrganization(context.TODO(), o)
+	assert.NoError(t, err)
+}
+
+func TestDeleteOrganization(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	query := `DELETE FROM organizations WHERE id = \?`
+	mock.ExpectExec(query).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	assert.NoError(t, repo.DeleteOrganization(context.TODO(), 3))
+	assert.Equal(t, domain.ErrNotFound, repo.DeleteOrganization(context.TODO(), 4))
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// OrganizationUseCase ...
+type OrganizationUseCase struct {
+	organizationRepo domain.OrganizationRepository
+	contextTimeOut   time.Duration
+}
+
+// NewOrganizationUseCase will create new an
+func NewOrganizationUseCase(o domain.OrganizationRepository, timeout time.Duration) domain.OrganizationUseCase {
+	return &OrganizationUseCase{
+		organizationRepo: o,
+		contextTimeOut:   timeout,
+	}
+}
+
+// GetAll ...
+func (usecase *OrganizationUseCase) GetAll(c context.Context, searchQuery string, start int, limit int) ([]domain.Organization, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.organizationRepo.GetAll(ctx, searchQuery, start, limit)
+}
+
+// GetByID ...
+func (usecase *OrganizationUseCase) GetByID(c context.Context, id int64) (*domain.Organization, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.organizationRepo.GetByID(ctx, id)
+}
+
+// CreateOrganization creates an active organization
+func (usecase *OrganizationUseCase) CreateOrganization(c context.Context, organization *domain.Organization) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	now := time.Now().Unix()
+	organization.Status = domain.OrganizationActive
+	organization.UpdatedAt = now
+	organization.CreatedAt = now
+	return usecase.organizationRepo.CreateOrganization(ctx, organization)
+}
+
+// UpdateOrganization ...
+func (usecase *OrganizationUseCase) UpdateOrganization(c context.Context, organization *domain.Organization, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existing, err := usecase.organizationRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if organization.Status != domain.OrganizationActive && organization.Status != domain.OrganizationInactive {
+		return domain.ErrBadParamInput
+	}
+	organization.ID = id
+	organization.CreatedAt = existing.CreatedAt
+	organization.UpdatedAt = time.Now().Unix()
+	return usecase.organizationRepo.UpdateOrganization(ctx, organization)
+}
+
+// DeleteOrganization deletes the organization and all of its data. The caller's own organization can not be deleted.
+func (usecase *OrganizationUseCase) DeleteOrganization(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if id == domain.OrganizationIDFromContext(ctx) {
+		return domain.ErrConflict
+	}
+	return usecase.organizationRepo.DeleteOrganization(ctx, id)
+}
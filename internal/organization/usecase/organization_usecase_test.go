@@ -0,0 +1,81 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/organization/usecase"
+)
+
+func TestCreateOrganization(t *testing.T) {
+	mockOrganizationRepo := new(mocks.OrganizationRepository)
+	organization := domain.Organization{Name: "Acme", Status: domain.OrganizationInactive}
+	mockOrganizationRepo.On("CreateOrganization", mock.Anything, mock.AnythingOfType("*domain.Organization")).Return(nil).Once()
+
+	u := ucase.NewOrganizationUseCase(mockOrganizationRepo, time.Second*2)
+	err := u.CreateOrganization(context.TODO(), &organization)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrganizationActive, organization.Status)
+	assert.NotZero(t, organization.CreatedAt)
+	mockOrganizationRepo.AssertExpectations(t)
+}
+
+func TestUpdateOrganization(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+		existing := &domain.Organization{ID: 3, Name: "Acme", Status: domain.OrganizationActive, CreatedAt: 100}
+		organization := domain.Organization{Name: "Acme Inc", Status: domain.OrganizationInactive}
+		mockOrganizationRepo.On("GetByID", mock.Anything, int64(3)).Return(existing, nil).Once()
+		mockOrganizationRepo.On("UpdateOrganization", mock.Anything, mock.AnythingOfType("*domain.Organization")).Return(nil).Once()
+
+		u := ucase.NewOrganizationUseCase(mockOrganizationRepo, time.Second*2)
+		err := u.UpdateOrganization(context.TODO(), &organization, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), organization.ID)
+		assert.Equal(t, int64(100), organization.CreatedAt)
+		mockOrganizationRepo.AssertExpectations(t)
+	})
+	t.Run("invalid-status", func(t *testing.T) {
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+		mockOrganizationRepo.On("GetByID", mock.Anything, int64(3)).Return(&domain.Organization{ID: 3}, nil).Once()
+
+		u := ucase.NewOrganizationUseCase(mockOrganizationRepo, time.Second*2)
+		err := u.UpdateOrganization(context.TODO(), &domain.Organization{Name: "Acme", Status: 5}, 3)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockOrganizationRepo.AssertNotCalled(t, "UpdateOrganization", mock.Anything, mock.Anything)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+		mockOrganizationRepo.On("GetByID", mock.Anything, int64(9)).Return(nil, domain.ErrNotFound).Once()
+
+		u := ucase.NewOrganizationUseCase(mockOrganizationRepo, time.Second*2)
+		err := u.UpdateOrganization(context.TODO(), &domain.Organization{Name: "Acme"}, 9)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestDeleteOrganization(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+		mockOrganizationRepo.On("DeleteOrganization", mock.Anything, int64(3)).Return(nil).Once()
+
+		u := ucase.NewOrganizationUseCase(mockOrganizationRepo, time.Second*2)
+		err := u.DeleteOrganization(domain.WithOrganizationID(context.TODO(), 1), 3)
+		assert.NoError(t, err)
+		mockOrganizationRepo.AssertExpectations(t)
+	})
+	t.Run("own-organization", func(t *testing.T) {
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+
+		u := ucase.NewOrganizationUseCase(mockOrganizationRepo, time.Second*2)
+		err := u.DeleteOrganization(domain.WithOrganizationID(context.TODO(), 1), 1)
+		assert.Equal(t, domain.ErrConflict, err)
+		mockOrganizationRepo.AssertNotCalled(t, "DeleteOrganization", mock.Anything, mock.Anything)
+	})
+}
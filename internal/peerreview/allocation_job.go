@@ -0,0 +1,39 @@
+package peerreview
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// AllocationJob allocates the peer reviewers of the assignments of every organization once they are due
+type AllocationJob struct {
+	peerReviewUseCase domain.PeerReviewUseCase
+	interval          time.Duration
+}
+
+// NewAllocationJob will create a job looking for due assignments every interval
+func NewAllocationJob(us domain.PeerReviewUseCase, interval time.Duration) *AllocationJob {
+	return &AllocationJob{
+		peerReviewUseCase: us,
+		interval:          interval,
+	}
+}
+
+// Start runs the job every interval until ctx is done
+func (j *AllocationJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		if err := j.peerReviewUseCase.AllocateDue(ctx); err != nil && ctx.Err() == nil {
+			log.Errorf("Error while allocating the peer reviewers: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
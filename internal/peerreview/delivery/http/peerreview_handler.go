@@ -0,0 +1,229 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// PeerReviewHandler ...
+type PeerReviewHandler struct {
+	PeerReviewUseCase domain.PeerReviewUseCase
+}
+
+// NewPeerReviewHandler ...
+func NewPeerReviewHandler(e *echo.Echo, us domain.PeerReviewUseCase) {
+	handler := &PeerReviewHandler{
+		PeerReviewUseCase: us,
+	}
+	e.GET("/assignments/:id/peer-reviews", handler.GetAssigned, rbac.Require(domain.PermCourseView))
+	e.GET("/peer-reviews/:id", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.PUT("/peer-reviews/:id", handler.SubmitReview, rbac.Require(domain.PermCourseView))
+	e.GET("/peer-reviews/:id/files/:name", handler.DownloadFile, rbac.Require(domain.PermCourseView))
+	e.GET("/submissions/:id/peer-reviews", handler.GetReceived, rbac.Require(domain.PermCourseView))
+	e.POST("/assignments/:id/peer-reviews/allocate", handler.Allocate, rbac.Require(domain.PermCourseUpdate))
+	e.GET("/assignments/:id/peer-grades", handler.GetGrades, rbac.Require(domain.PermCourseUpdate))
+}
+
+// GetAssigned godoc
+// @Summary Get the peer reviews allocated to the caller.
+// @Description Get the reviews of an assignment allocated to the caller, with the submissions to review.
+// @Description The authors are hidden when the reviews are anonymous.
+// @Tags peer-reviews
+// @Accept */*
+// @Produce json
+// @Param id path int true "Assignment Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /assignments/{id}/peer-reviews [get]
+func (c *PeerReviewHandler) GetAssigned(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	list, err := c.PeerReviewUseCase.GetAssigned(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: list, Message: domain.Success})
+}
+
+// GetByID godoc
+// @Summary Get a peer review.
+// @Description Get a review to its reviewer, to the author of the submission once submitted and to the ones working on the course.
+// @Tags peer-reviews
+// @Accept */*
+// @Produce json
+// @Param id path int true "Peer Review Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /peer-reviews/{id} [get]
+func (c *PeerReviewHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	review, err := c.PeerReviewUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: review, Message: domain.Success})
+}
+
+// SubmitReview godoc
+// @Summary Submit a peer review.
+// @Description Score the submission of a review allocated to the caller with a level of each criterion of the rubric of the assignment.
+// @Description The review can be submitted again until the reviews are due.
+// @Tags peer-reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Peer Review Id"
+// @Param grade body domain.PeerReviewGrade true "grade"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "The scores do not match the rubric"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /peer-reviews/{id} [put]
+func (c *PeerReviewHandler) SubmitReview(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var grade domain.PeerReviewGrade
+	err = echoContext.Bind(&grade)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&grade); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	review, err := c.PeerReviewUseCase.SubmitReview(ctx, int64(idParam), &grade)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: review, Message: domain.Success})
+}
+
+// DownloadFile godoc
+// @Summary Download a file of a reviewed submission.
+// @Description Download a file of the submission of a review, to its reviewer and to the ones working on the course.
+// @Tags peer-reviews
+// @Accept */*
+// @Param id path int true "Peer Review Id"
+// @Param name path string true "Stored file name"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /peer-reviews/{id}/files/{name} [get]
+func (c *PeerReviewHandler) DownloadFile(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	filePath, err := c.PeerReviewUseCase.DownloadFile(ctx, int64(idParam), echoContext.Param("name"))
+	if err != nil {
+		log.Errorf("error while getting file path %v", err)
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.File(filePath)
+}
+
+// GetReceived godoc
+// @Summary Get the peer reviews of a submission.
+// @Description Get the reviews submitted for a submission to the learner who made it, and every review to the ones working on the course.
+// @Description The reviewers are hidden from the learner when the reviews are anonymous.
+// @Tags peer-reviews
+// @Accept */*
+// @Produce json
+// @Param id path int true "Submission Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /submissions/{id}/peer-reviews [get]
+func (c *PeerReviewHandler) GetReceived(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	list, err := c.PeerReviewUseCase.GetReceived(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: list, Message: domain.Success})
+}
+
+// Allocate godoc
+// @Summary Allocate the peer reviewers of an assignment.
+// @Description Allocate the reviewers of an assignment past its due date without waiting for the job. Every last submission
+// @Description gets the same number of reviewers, and every learner reviews as many submissions, never their own.
+// @Tags peer-reviews
+// @Accept */*
+// @Produce json
+// @Param id path int true "Assignment Id"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "The assignment has no peer review"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "The assignment is not due yet, or its reviewers are allocated"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /assignments/{id}/peer-reviews/allocate [post]
+func (c *PeerReviewHandler) Allocate(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	list, err := c.PeerReviewUseCase.Allocate(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusCreated, domain.Response{Data: list, Message: domain.Success})
+}
+
+// GetGrades godoc
+// @Summary Get the peer grades of an assignment.
+// @Description Get the grades of the submissions to an assignment aggregated from their submitted reviews: mean, median,
+// @Description min, max and the mean of each criterion of the rubric.
+// @Tags peer-reviews
+// @Accept */*
+// @Produce json
+// @Param id path int true "Assignment Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /assignments/{id}/peer-grades [get]
+func (c *PeerReviewHandler) GetGrades(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	list, err := c.PeerReviewUseCase.GetGrades(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: list, Message: domain.Success})
+}
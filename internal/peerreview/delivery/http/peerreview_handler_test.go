@@ -0,0 +1,225 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	peerreviewHTTP "github.com/meroedu/meroedu/internal/peerreview/delivery/http"
+)
+
+func TestGetAssigned(t *testing.T) {
+	mockUCase := new(mocks.PeerReviewUseCase)
+	mockUCase.On("GetAssigned", mock.Anything, int64(6)).Return([]domain.PeerReview{{ID: 9, AssignmentID: 6, Status: domain.PeerReviewAssigned}}, nil).Once()
+	mockUCase.On("GetAssigned", mock.Anything, int64(7)).Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"6", http.StatusOK},
+		{"7", http.StatusNotFound},
+		{"essay", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/assignments/"+tt.id+"/peer-reviews", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/assignments/:id/peer-reviews")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := peerreviewHTTP.PeerReviewHandler{
+			PeerReviewUseCase: mockUCase,
+		}
+		err = handler.GetAssigned(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	mockUCase := new(mocks.PeerReviewUseCase)
+	mockUCase.On("GetByID", mock.Anything, int64(9)).Return(nil, domain.ErrForbidden).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/peer-reviews/9", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/peer-reviews/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("9")
+	handler := peerreviewHTTP.PeerReviewHandler{
+		PeerReviewUseCase: mockUCase,
+	}
+	err = handler.GetByID(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSubmitReview(t *testing.T) {
+	mockUCase := new(mocks.PeerReviewUseCase)
+	mockUCase.On("SubmitReview", mock.Anything, int64(9), &domain.PeerReviewGrade{Scores: []domain.CriterionScore{{CriterionID: 1, LevelID: 2, Score: 4}}, Feedback: "Clear"}).
+		Return(&domain.PeerReview{ID: 9, Status: domain.PeerReviewSubmitted}, nil).Once()
+	mockUCase.On("SubmitReview", mock.Anything, int64(10), mock.AnythingOfType("*domain.PeerReviewGrade")).Return(nil, domain.ErrSubmissionClosed).Once()
+
+	tests := []struct {
+		id   string
+		body string
+		code int
+	}{
+		{"9", `{"scores":[{"criterion_id":1,"level_id":2,"score":4}],"feedback":"Clear"}`, http.StatusOK},
+		{"10", `{"scores":[{"criterion_id":1,"level_id":2,"score":4}]}`, http.StatusConflict},
+		{"9", `{"scores":[]}`, http.StatusBadRequest},
+		{"9", `{"scores":[{"criterion_id":1,"score":-1}]}`, http.StatusBadRequest},
+		{"9", `{"scores":{}}`, http.StatusUnprocessableEntity},
+		{"mine", `{"scores":[{"criterion_id":1,"score":4}]}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/peer-reviews/"+tt.id, strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/peer-reviews/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := peerreviewHTTP.PeerReviewHandler{
+			PeerReviewUseCase: mockUCase,
+		}
+		err = handler.SubmitReview(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDownloadFile(t *testing.T) {
+	rootDirectory, err := os.Getwd()
+	require.NoError(t, err)
+	mockUCase := new(mocks.PeerReviewUseCase)
+	mockUCase.On("DownloadFile", mock.Anything, int64(9), "essay.pdf").Return(rootDirectory+"/peerreview_handler.go", nil).Once()
+	mockUCase.On("DownloadFile", mock.Anything, int64(9), "other.pdf").Return("", domain.ErrNotFound).Once()
+
+	tests := []struct {
+		name string
+		code int
+	}{
+		{"essay.pdf", http.StatusOK},
+		{"other.pdf", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/peer-reviews/9/files/"+tt.name, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/peer-reviews/:id/files/:name")
+		c.SetParamNames("id", "name")
+		c.SetParamValues("9", tt.name)
+		handler := peerreviewHTTP.PeerReviewHandler{
+			PeerReviewUseCase: mockUCase,
+		}
+		err = handler.DownloadFile(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.name)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetReceived(t *testing.T) {
+	mockUCase := new(mocks.PeerReviewUseCase)
+	mockUCase.On("GetReceived", mock.Anything, int64(15)).Return([]domain.PeerReview{{ID: 9, SubmissionID: 15}}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/submissions/15/peer-reviews", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/submissions/:id/peer-reviews")
+	c.SetParamNames("id")
+	c.SetParamValues("15")
+	handler := peerreviewHTTP.PeerReviewHandler{
+		PeerReviewUseCase: mockUCase,
+	}
+	err = handler.GetReceived(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestAllocate(t *testing.T) {
+	mockUCase := new(mocks.PeerReviewUseCase)
+	mockUCase.On("Allocate", mock.Anything, int64(6)).Return([]domain.PeerReview{{ID: 9, AssignmentID: 6}}, nil).Once()
+	mockUCase.On("Allocate", mock.Anything, int64(7)).Return(nil, domain.ErrConflict).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"6", http.StatusCreated},
+		{"7", http.StatusConflict},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/assignments/"+tt.id+"/peer-reviews/allocate", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/assignments/:id/peer-reviews/allocate")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := peerreviewHTTP.PeerReviewHandler{
+			PeerReviewUseCase: mockUCase,
+		}
+		err = handler.Allocate(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetGrades(t *testing.T) {
+	mockUCase := new(mocks.PeerReviewUseCase)
+	mockUCase.On("GetGrades", mock.Anything, int64(6)).Return([]domain.PeerGrade{{SubmissionID: 15, Reviews: 2, Completed: 2, Mean: 3.5}}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/assignments/6/peer-grades", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/assignments/:id/peer-grades")
+	c.SetParamNames("id")
+	c.SetParamValues("6")
+	handler := peerreviewHTTP.PeerReviewHandler{
+		PeerReviewUseCase: mockUCase,
+	}
+	err = handler.GetGrades(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,239 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const reviewQuery = `SELECT r.id,r.assignment_id,r.submission_id,s.user_id,r.reviewer_id,r.status,r.scores,r.score,r.feedback,r.assigned_at,
+	r.submitted_at FROM peer_reviews r JOIN assignment_submissions s ON s.id = r.submission_id JOIN assignments a ON a.id = r.assignment_id
+	JOIN lessons l ON l.id = a.lesson_id JOIN courses c ON c.id = l.course_id`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the peer review's Repository interface
+func Init(db *sql.DB) domain.PeerReviewRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.PeerReview, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.PeerReview, 0)
+	for rows.Next() {
+		t := domain.PeerReview{}
+		var scores, feedback sql.NullString
+		var score sql.NullFloat64
+		var submittedAt sql.NullInt64
+		err = rows.Scan(
+			&t.ID,
+			&t.AssignmentID,
+			&t.SubmissionID,
+			&t.AuthorID,
+			&t.ReviewerID,
+			&t.Status,
+			&scores,
+			&score,
+			&feedback,
+			&t.AssignedAt,
+			&submittedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.Feedback = feedback.String
+		t.SubmittedAt = submittedAt.Int64
+		if score.Valid {
+			t.Score = &score.Float64
+		}
+		if scores.String != "" {
+			if err = json.Unmarshal([]byte(scores.String), &t.Scores); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// GetUnallocated returns the assignments of every organization with a peer review, due before dueBefore and
+// with their reviewers not allocated yet
+func (m *mysqlRepository) GetUnallocated(ctx context.Context, dueBefore int64) (result []domain.PeerReviewAllocation, err error) {
+	query := `SELECT a.id,c.organization_id FROM assignments a JOIN lessons l ON l.id = a.lesson_id JOIN courses c ON c.id = l.course_id
+		WHERE a.peer_reviews > 0 AND a.peer_allocated_at IS NULL AND a.due_at <= ? AND l.deleted_at IS NULL AND c.deleted_at IS NULL ORDER BY a.id`
+	rows, err := m.conn.QueryContext(ctx, query, dueBefore)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.PeerReviewAllocation, 0)
+	for rows.Next() {
+		t := domain.PeerReviewAllocation{}
+		if err = rows.Scan(&t.AssignmentID, &t.OrganizationID); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// GetLatestSubmissions returns the last submission of every learner to an assignment, with only their ids
+func (m *mysqlRepository) GetLatestSubmissions(ctx context.Context, assignmentID int64) (result []domain.Submission, err error) {
+	query := `SELECT s.id,s.assignment_id,s.user_id FROM assignment_submissions s JOIN assignments a ON a.id = s.assignment_id
+		JOIN lessons l ON l.id = a.lesson_id JOIN courses c ON c.id = l.course_id WHERE s.assignment_id = ? AND c.organization_id = ?
+		AND s.number = (SELECT MAX(p.number) FROM assignment_submissions p WHERE p.assignment_id = s.assignment_id AND p.user_id = s.user_id)
+		ORDER BY s.id`
+	rows, err := m.conn.QueryContext(ctx, query, assignmentID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Submission, 0)
+	for rows.Next() {
+		t := domain.Submission{}
+		if err = rows.Scan(&t.ID, &t.AssignmentID, &t.UserID); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// Allocate marks the reviewers of an assignment allocated and adds their reviews. It returns ErrConflict when
+// the reviewers were already allocated.
+func (m *mysqlRepository) Allocate(ctx context.Context, assignmentID int64, allocatedAt int64, reviews []domain.PeerReview) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `UPDATE assignments SET peer_allocated_at=? WHERE id = ? AND peer_allocated_at IS NULL`
+	res, err := tx.ExecContext(ctx, query, allocatedAt, assignmentID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrConflict
+	}
+	query = `INSERT peer_reviews SET assignment_id=?,submission_id=?,reviewer_id=?,status=?,assigned_at=?`
+	for i := range reviews {
+		r := &reviews[i]
+		if res, err = tx.ExecContext(ctx, query, r.AssignmentID, r.SubmissionID, r.ReviewerID, r.Status, r.AssignedAt); err != nil {
+			log.Error(err)
+			return
+		}
+		if r.ID, err = res.LastInsertId(); err != nil {
+			log.Error("Got Error from LastInsertId method: ", err)
+			return
+		}
+	}
+	return
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.PeerReview, error) {
+	query := reviewQuery + ` WHERE r.id = ? AND c.organization_id = ?`
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// GetByReviewer returns the reviews allocated to a learner for an assignment
+func (m *mysqlRepository) GetByReviewer(ctx context.Context, assignmentID int64, reviewerID int64) ([]domain.PeerReview, error) {
+	query := reviewQuery + ` WHERE r.assignment_id = ? AND r.reviewer_id = ? AND c.organization_id = ? ORDER BY r.id`
+	return m.fetch(ctx, query, assignmentID, reviewerID, domain.OrganizationIDFromContext(ctx))
+}
+
+// GetBySubmission returns the reviews of a submission
+func (m *mysqlRepository) GetBySubmission(ctx context.Context, submissionID int64) ([]domain.PeerReview, error) {
+	query := reviewQuery + ` WHERE r.submission_id = ? AND c.organization_id = ? ORDER BY r.id`
+	return m.fetch(ctx, query, submissionID, domain.OrganizationIDFromContext(ctx))
+}
+
+// GetByAssignment returns the reviews of every submission to an assignment, grouped by submission
+func (m *mysqlRepository) GetByAssignment(ctx context.Context, assignmentID int64) ([]domain.PeerReview, error) {
+	query := reviewQuery + ` WHERE r.assignment_id = ? AND c.organization_id = ? ORDER BY r.submission_id,r.id`
+	return m.fetch(ctx, query, assignmentID, domain.OrganizationIDFromContext(ctx))
+}
+
+func (m *mysqlRepository) SubmitReview(ctx context.Context, r *domain.PeerReview) error {
+	scores, err := json.Marshal(r.Scores)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE peer_reviews SET status=?,scores=?,score=?,feedback=?,submitted_at=? WHERE id = ?`
+	_, err = m.conn.ExecContext(ctx, query, r.Status, scores, nullFloat64(r.Score), nullString(r.Feedback), r.SubmittedAt, r.ID)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+	}
+	return err
+}
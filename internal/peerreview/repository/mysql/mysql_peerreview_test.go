@@ -0,0 +1,157 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/peerreview/repository/mysql"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+var reviewColumns = []string{"id", "assignment_id", "submission_id", "user_id", "reviewer_id", "status", "scores", "score", "feedback",
+	"assigned_at", "submitted_at"}
+
+func TestGetByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`SELECT .+ FROM peer_reviews r .+ WHERE r.id = \? AND c.organization_id = \?`).
+			WithArgs(9, 2).
+			WillReturnRows(sqlmock.NewRows(reviewColumns).AddRow(9, 5, 3, 6, 7, "submitted",
+				`[{"criterion_id":1,"level_id":2,"score":4}]`, 4, "Clear.", 100, 200))
+
+		repo := mysqlrepo.Init(db)
+		review, err := repo.GetByID(orgCtx, 9)
+		assert.NoError(t, err)
+		score := float64(4)
+		assert.Equal(t, &domain.PeerReview{ID: 9, AssignmentID: 5, SubmissionID: 3, AuthorID: 6, ReviewerID: 7,
+			Status: domain.PeerReviewSubmitted, Scores: []domain.CriterionScore{{CriterionID: 1, LevelID: 2, Score: 4}}, Score: &score,
+			Feedback: "Clear.", AssignedAt: 100, SubmittedAt: 200}, review)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`FROM peer_reviews r`).WithArgs(9, 2).WillReturnRows(sqlmock.NewRows(reviewColumns))
+
+		repo := mysqlrepo.Init(db)
+		_, err = repo.GetByID(orgCtx, 9)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestGetByReviewer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`FROM peer_reviews r .+ WHERE r.assignment_id = \? AND r.reviewer_id = \? AND c.organization_id = \? ORDER BY r.id`).
+		WithArgs(5, 7, 2).
+		WillReturnRows(sqlmock.NewRows(reviewColumns).AddRow(9, 5, 3, 6, 7, "assigned", nil, nil, nil, 100, nil))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetByReviewer(orgCtx, 5, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PeerReview{{ID: 9, AssignmentID: 5, SubmissionID: 3, AuthorID: 6, ReviewerID: 7,
+		Status: domain.PeerReviewAssigned, AssignedAt: 100}}, list)
+}
+
+func TestGetUnallocated(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT a.id,c.organization_id FROM assignments a .+ WHERE a.peer_reviews > 0 AND a.peer_allocated_at IS NULL AND a.due_at <= \?`).
+		WithArgs(1000).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id"}).AddRow(5, 2).AddRow(8, 3))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetUnallocated(context.TODO(), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PeerReviewAllocation{{AssignmentID: 5, OrganizationID: 2}, {AssignmentID: 8, OrganizationID: 3}}, list)
+}
+
+func TestGetLatestSubmissions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT s.id,s.assignment_id,s.user_id FROM assignment_submissions s .+ AND s.number = \(SELECT MAX\(p.number\)`).
+		WithArgs(5, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "assignment_id", "user_id"}).AddRow(3, 5, 6).AddRow(4, 5, 7))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetLatestSubmissions(orgCtx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Submission{{ID: 3, AssignmentID: 5, UserID: 6}, {ID: 4, AssignmentID: 5, UserID: 7}}, list)
+}
+
+func TestAllocate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE assignments SET peer_allocated_at=\? WHERE id = \? AND peer_allocated_at IS NULL`).
+			WithArgs(1000, 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT peer_reviews SET`).
+			WithArgs(5, 3, 7, "assigned", 1000).
+			WillReturnResult(sqlmock.NewResult(9, 1))
+		mock.ExpectExec(`INSERT peer_reviews SET`).
+			WithArgs(5, 4, 6, "assigned", 1000).
+			WillReturnResult(sqlmock.NewResult(10, 1))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		reviews := []domain.PeerReview{
+			{AssignmentID: 5, SubmissionID: 3, ReviewerID: 7, Status: domain.PeerReviewAssigned, AssignedAt: 1000},
+			{AssignmentID: 5, SubmissionID: 4, ReviewerID: 6, Status: domain.PeerReviewAssigned, AssignedAt: 1000},
+		}
+		assert.NoError(t, repo.Allocate(orgCtx, 5, 1000, reviews))
+		assert.Equal(t, int64(9), reviews[0].ID)
+		assert.Equal(t, int64(10), reviews[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("already-allocated", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE assignments SET peer_allocated_at=\?`).
+			WithArgs(1000, 5).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := mysqlrepo.Init(db)
+		err = repo.Allocate(orgCtx, 5, 1000, []domain.PeerReview{{AssignmentID: 5, SubmissionID: 3, ReviewerID: 7}})
+		assert.Equal(t, domain.ErrConflict, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSubmitReview(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`UPDATE peer_reviews SET status=\?,scores=\?,score=\?,feedback=\?,submitted_at=\? WHERE id = \?`).
+		WithArgs("submitted", []byte(`[{"criterion_id":1,"level_id":2,"score":4}]`), float64(4), "Clear.", 200, 9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := mysqlrepo.Init(db)
+	score := float64(4)
+	err = repo.SubmitReview(orgCtx, &domain.PeerReview{ID: 9, Status: domain.PeerReviewSubmitted,
+		Scores: []domain.CriterionScore{{CriterionID: 1, LevelID: 2, Score: 4}}, Score: &score, Feedback: "Clear.", SubmittedAt: 200})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,401 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// PeerReviewUseCase ...
+type PeerReviewUseCase struct {
+	peerReviewRepo      domain.PeerReviewRepository
+	assignmentRepo      domain.AssignmentRepository
+	collaboratorUseCase domain.CollaboratorUseCase
+	rubricUseCase       domain.RubricUseCase
+	fileStore           domain.AttachmentStorage
+	contextTimeOut      time.Duration
+}
+
+// NewPeerReviewUseCase will create new a PeerReviewUseCase
+func NewPeerReviewUseCase(p domain.PeerReviewRepository, a domain.AssignmentRepository, cu domain.CollaboratorUseCase,
+	ru domain.RubricUseCase, store domain.AttachmentStorage, timeout time.Duration) domain.PeerReviewUseCase {
+	return &PeerReviewUseCase{
+		peerReviewRepo:      p,
+		assignmentRepo:      a,
+		collaboratorUseCase: cu,
+		rubricUseCase:       ru,
+		fileStore:           store,
+		contextTimeOut:      timeout,
+	}
+}
+
+// allocate shuffles the submissions and has the author of each one review the k submissions after theirs, around
+// the circle. Every submission gets k reviewers and every author reviews k submissions, never their own.
+func allocate(submissions []domain.Submission, reviews int, now int64) []domain.PeerReview {
+	n := len(submissions)
+	k := reviews
+	if k > n-1 {
+		k = n - 1
+	}
+	if k <= 0 {
+		return nil
+	}
+	order := rand.Perm(n)
+	result := make([]domain.PeerReview, 0, n*k)
+	for i, reviewer := range order {
+		for j := 1; j <= k; j++ {
+			s := submissions[order[(i+j)%n]]
+			result = append(result, domain.PeerReview{
+				AssignmentID: s.AssignmentID,
+				SubmissionID: s.ID,
+				AuthorID:     s.UserID,
+				ReviewerID:   submissions[reviewer].UserID,
+				Status:       domain.PeerReviewAssigned,
+				AssignedAt:   now,
+			})
+		}
+	}
+	return result
+}
+
+func (usecase *PeerReviewUseCase) allocate(ctx context.Context, assignment *domain.Assignment) ([]domain.PeerReview, error) {
+	submissions, err := usecase.peerReviewRepo.GetLatestSubmissions(ctx, assignment.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	reviews := allocate(submissions, assignment.PeerReview.Reviews, now)
+	if err = usecase.peerReviewRepo.Allocate(ctx, assignment.ID, now, reviews); err != nil {
+		return nil, err
+	}
+	assignment.PeerReview.AllocatedAt = now
+	return reviews, nil
+}
+
+// AllocateDue allocates the reviewers of the assignments of every organization past their due date. An assignment
+// allocated meanwhile, by hand or by another instance, is skipped. A failing assignment does not stop the others.
+func (usecase *PeerReviewUseCase) AllocateDue(c context.Context) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	due, err := usecase.peerReviewRepo.GetUnallocated(ctx, time.Now().Unix())
+	cancel()
+	if err != nil {
+		return err
+	}
+	for _, d := range due {
+		ctx, cancel := context.WithTimeout(domain.WithOrganizationID(c, d.OrganizationID), usecase.contextTimeOut)
+		reviews, err := usecase.allocateDue(ctx, d.AssignmentID)
+		cancel()
+		if err == domain.ErrConflict {
+			continue
+		}
+		if err != nil {
+			log.Errorf("Peer review allocation of assignment %d failed: %v", d.AssignmentID, err)
+			continue
+		}
+		log.Infof("Allocated %d peer reviews of assignment %d", len(reviews), d.AssignmentID)
+	}
+	return nil
+}
+
+func (usecase *PeerReviewUseCase) allocateDue(ctx context.Context, assignmentID int64) ([]domain.PeerReview, error) {
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.allocate(ctx, assignment)
+}
+
+// Allocate allocates the reviewers of an assignment past its due date without waiting for the job, for the ones
+// working on the course
+func (usecase *PeerReviewUseCase) Allocate(c context.Context, assignmentID int64) ([]domain.PeerReview, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, assignment.LessonID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	if assignment.PeerReview == nil {
+		return nil, domain.ErrBadParamInput
+	}
+	if assignment.PeerReview.AllocatedAt != 0 || time.Now().Unix() < assignment.DueAt {
+		return nil, domain.ErrConflict
+	}
+	return usecase.allocate(ctx, assignment)
+}
+
+// forReviewer sets the submission to review on a review, without its grade, and hides its author when the reviews
+// are anonymous
+func (usecase *PeerReviewUseCase) forReviewer(ctx context.Context, assignment *domain.Assignment, review *domain.PeerReview) error {
+	submission, err := usecase.assignmentRepo.GetSubmission(ctx, review.SubmissionID)
+	if err != nil {
+		return err
+	}
+	review.Submission = &domain.Submission{
+		ID:           submission.ID,
+		AssignmentID: submission.AssignmentID,
+		UserID:       submission.UserID,
+		Number:       submission.Number,
+		Text:         submission.Text,
+		Files:        submission.Files,
+		Status:       domain.SubmissionSubmitted,
+		Late:         submission.Late,
+		SubmittedAt:  submission.SubmittedAt,
+	}
+	if assignment.PeerReview.Anonymous {
+		review.AuthorID = 0
+		review.Submission.UserID = 0
+	}
+	return nil
+}
+
+// forAuthor hides the reviewer of a review when the reviews are anonymous
+func forAuthor(assignment *domain.Assignment, review *domain.PeerReview) {
+	if assignment.PeerReview.Anonymous {
+		review.ReviewerID = 0
+	}
+}
+
+// GetAssigned returns the reviews of an assignment allocated to the caller, with the submissions to review
+func (usecase *PeerReviewUseCase) GetAssigned(c context.Context, assignmentID int64) ([]domain.PeerReview, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.PeerReview == nil {
+		return nil, domain.ErrNotFound
+	}
+	reviews, err := usecase.peerReviewRepo.GetByReviewer(ctx, assignmentID, domain.UserIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for i := range reviews {
+		if err = usecase.forReviewer(ctx, assignment, &reviews[i]); err != nil {
+			return nil, err
+		}
+	}
+	return reviews, nil
+}
+
+// review returns a review with its assignment, to its reviewer, to the author of the submission once submitted and
+// to the ones working on the course. The reviewer and the author see it anonymized when the reviews are anonymous.
+func (usecase *PeerReviewUseCase) review(ctx context.Context, id int64) (*domain.PeerReview, *domain.Assignment, error) {
+	review, err := usecase.peerReviewRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, review.AssignmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if assignment.PeerReview == nil {
+		return nil, nil, domain.ErrNotFound
+	}
+	switch userID := domain.UserIDFromContext(ctx); {
+	case userID == review.ReviewerID:
+		err = usecase.forReviewer(ctx, assignment, review)
+	case userID == review.AuthorID && review.Status == domain.PeerReviewSubmitted:
+		forAuthor(assignment, review)
+	default:
+		err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, assignment.LessonID, domain.CollaboratorEditor)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return review, assignment, nil
+}
+
+// GetByID returns a review to its reviewer, to the author of the submission once submitted and to the ones working
+// on the course
+func (usecase *PeerReviewUseCase) GetByID(c context.Context, id int64) (*domain.PeerReview, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	review, _, err := usecase.review(ctx, id)
+	return review, err
+}
+
+// SubmitReview scores the submission of a review allocated to the caller with the rubric of the assignment. The
+// review can be submitted again until the reviews are due.
+func (usecase *PeerReviewUseCase) SubmitReview(c context.Context, id int64, grade *domain.PeerReviewGrade) (*domain.PeerReview, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	review, assignment, err := usecase.review(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.ReviewerID != domain.UserIDFromContext(ctx) {
+		return nil, domain.ErrForbidden
+	}
+	now := time.Now().Unix()
+	if assignment.PeerReview.DueAt != 0 && now > assignment.PeerReview.DueAt {
+		return nil, domain.ErrSubmissionClosed
+	}
+	total, _, err := usecase.rubricUseCase.Apply(ctx, assignment.RubricID, grade.Scores)
+	if err != nil {
+		return nil, err
+	}
+	review.Scores = grade.Scores
+	review.Score = &total
+	review.Feedback = grade.Feedback
+	review.Status = domain.PeerReviewSubmitted
+	review.SubmittedAt = now
+	if err = usecase.peerReviewRepo.SubmitReview(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// GetReceived returns the reviews submitted for a submission to the learner who made it and to the ones working on
+// the course
+func (usecase *PeerReviewUseCase) GetReceived(c context.Context, submissionID int64) ([]domain.PeerReview, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	submission, err := usecase.assignmentRepo.GetSubmission(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, submission.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.PeerReview == nil {
+		return nil, domain.ErrNotFound
+	}
+	author := submission.UserID == domain.UserIDFromContext(ctx)
+	if !author {
+		if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, assignment.LessonID, domain.CollaboratorEditor); err != nil {
+			return nil, err
+		}
+	}
+	reviews, err := usecase.peerReviewRepo.GetBySubmission(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if !author {
+		return reviews, nil
+	}
+	result := make([]domain.PeerReview, 0, len(reviews))
+	for _, r := range reviews {
+		if r.Status == domain.PeerReviewSubmitted {
+			forAuthor(assignment, &r)
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// DownloadFile returns the path of a file of the submission of a review to its reviewer and to the ones working on
+// the course
+func (usecase *PeerReviewUseCase) DownloadFile(c context.Context, reviewID int64, name string) (string, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	review, _, err := usecase.review(ctx, reviewID)
+	if err != nil {
+		return "", err
+	}
+	if review.Submission == nil {
+		if review.Submission, err = usecase.assignmentRepo.GetSubmission(ctx, review.SubmissionID); err != nil {
+			return "", err
+		}
+	}
+	for _, file := range review.Submission.Files {
+		if file.Name == name {
+			return usecase.fileStore.DownloadAttachment(ctx, name)
+		}
+	}
+	return "", domain.ErrNotFound
+}
+
+// median returns the median of scores, sorting them
+func median(scores []float64) float64 {
+	sort.Float64s(scores)
+	n := len(scores)
+	if n%2 == 1 {
+		return scores[n/2]
+	}
+	return (scores[n/2-1] + scores[n/2]) / 2
+}
+
+// grade aggregates the reviews of a submission. The scores are the ones of the submitted reviews.
+func grade(reviews []domain.PeerReview, maxPoints float64) domain.PeerGrade {
+	g := domain.PeerGrade{
+		SubmissionID: reviews[0].SubmissionID,
+		AuthorID:     reviews[0].AuthorID,
+		Reviews:      len(reviews),
+		MaxPoints:    maxPoints,
+		Criteria:     make([]domain.PeerCriterionGrade, 0),
+	}
+	scores := make([]float64, 0, len(reviews))
+	criteria := make(map[int][]float64)
+	for _, r := range reviews {
+		if r.Status != domain.PeerReviewSubmitted || r.Score == nil {
+			continue
+		}
+		scores = append(scores, *r.Score)
+		for _, s := range r.Scores {
+			criteria[s.CriterionID] = append(criteria[s.CriterionID], s.Score)
+		}
+	}
+	g.Completed = len(scores)
+	if g.Completed == 0 {
+		return g
+	}
+	g.Min, g.Max = scores[0], scores[0]
+	for _, s := range scores {
+		g.Mean += s
+		g.Min = math.Min(g.Min, s)
+		g.Max = math.Max(g.Max, s)
+	}
+	g.Mean /= float64(g.Completed)
+	g.Median = median(scores)
+	for id, list := range criteria {
+		c := domain.PeerCriterionGrade{CriterionID: id}
+		for _, s := range list {
+			c.Mean += s
+		}
+		c.Mean /= float64(len(list))
+		g.Criteria = append(g.Criteria, c)
+	}
+	sort.Slice(g.Criteria, func(i, j int) bool { return g.Criteria[i].CriterionID < g.Criteria[j].CriterionID })
+	return g
+}
+
+// GetGrades returns the grades of the submissions to an assignment aggregated from their reviews to the ones
+// working on the course
+func (usecase *PeerReviewUseCase) GetGrades(c context.Context, assignmentID int64) ([]domain.PeerGrade, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	assignment, err := usecase.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, assignment.LessonID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	if assignment.PeerReview == nil {
+		return nil, domain.ErrNotFound
+	}
+	reviews, err := usecase.peerReviewRepo.GetByAssignment(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.PeerGrade, 0)
+	for start := 0; start < len(reviews); {
+		end := start + 1
+		for end < len(reviews) && reviews[end].SubmissionID == reviews[start].SubmissionID {
+			end++
+		}
+		result = append(result, grade(reviews[start:end], assignment.MaxPoints))
+		start = end
+	}
+	return result, nil
+}
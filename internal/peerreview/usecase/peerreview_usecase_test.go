@@ -0,0 +1,378 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/peerreview/usecase"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+var instructorCtx = domain.WithUserID(domain.WithPermissions(orgCtx, []domain.Permission{domain.PermCourseUpdate}), 4)
+
+// learnerCtx is the context of the learner with the id handed in
+func learnerCtx(userID int64) context.Context {
+	return domain.WithUserID(domain.WithPermissions(orgCtx, []domain.Permission{domain.PermCourseView}), userID)
+}
+
+// essay is an assignment marked with a rubric worth 7 points, due at 1000 and reviewed by two learners
+func essay(anonymous bool) *domain.Assignment {
+	return &domain.Assignment{ID: 5, LessonID: 8, CourseID: 3, Title: "Essay", DueAt: 1000, MaxPoints: 7, RubricID: 7,
+		PeerReview: &domain.PeerReviewSettings{Reviews: 2, Anonymous: anonymous}}
+}
+
+// submissions returns the last submissions of n learners, the learners numbered from 10
+func submissions(n int) []domain.Submission {
+	result := make([]domain.Submission, n)
+	for i := range result {
+		result[i] = domain.Submission{ID: int64(100 + i), AssignmentID: 5, UserID: int64(10 + i)}
+	}
+	return result
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name        string
+		submissions int
+		reviews     int
+		expected    int
+	}{
+		{"balanced", 5, 2, 2},
+		{"fewer-learners-than-reviews", 3, 3, 2},
+		{"single-learner", 1, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+			mockAssignmentRepo := new(mocks.AssignmentRepository)
+			mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+			mockRubricUseCase := new(mocks.RubricUseCase)
+			mockAttachmentStorage := new(mocks.AttachmentStorage)
+			u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+				mockAttachmentStorage, time.Second*2)
+			assignment := essay(false)
+			assignment.PeerReview.Reviews = tt.reviews
+			mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(assignment, nil).Once()
+			mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+			mockPeerReviewRepo.On("GetLatestSubmissions", mock.Anything, int64(5)).Return(submissions(tt.submissions), nil).Once()
+			mockPeerReviewRepo.On("Allocate", mock.Anything, int64(5), mock.AnythingOfType("int64"),
+				mock.AnythingOfType("[]domain.PeerReview")).Return(nil).Once()
+
+			reviews, err := u.Allocate(instructorCtx, 5)
+			assert.NoError(t, err)
+			assert.Len(t, reviews, tt.submissions*tt.expected)
+			reviewed := make(map[int64]int)
+			reviewing := make(map[int64]int)
+			pairs := make(map[[2]int64]bool)
+			for _, r := range reviews {
+				assert.NotEqual(t, r.AuthorID, r.ReviewerID, "no learner reviews their own submission")
+				assert.Equal(t, r.SubmissionID-90, r.AuthorID)
+				assert.False(t, pairs[[2]int64{r.SubmissionID, r.ReviewerID}], "a submission is reviewed once by a learner")
+				pairs[[2]int64{r.SubmissionID, r.ReviewerID}] = true
+				assert.Equal(t, domain.PeerReviewAssigned, r.Status)
+				reviewed[r.SubmissionID]++
+				reviewing[r.ReviewerID]++
+			}
+			for _, s := range submissions(tt.submissions) {
+				assert.Equal(t, tt.expected, reviewed[s.ID], "every submission gets as many reviewers")
+				assert.Equal(t, tt.expected, reviewing[s.UserID], "every learner reviews as many submissions")
+			}
+			assert.NotZero(t, assignment.PeerReview.AllocatedAt)
+		})
+	}
+	t.Run("already-allocated", func(t *testing.T) {
+		mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			mockAttachmentStorage, time.Second*2)
+		assignment := essay(false)
+		assignment.PeerReview.AllocatedAt = 1500
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(assignment, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+
+		_, err := u.Allocate(instructorCtx, 5)
+		assert.Equal(t, domain.ErrConflict, err)
+		mockPeerReviewRepo.AssertNotCalled(t, "Allocate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("not-due", func(t *testing.T) {
+		mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			mockAttachmentStorage, time.Second*2)
+		assignment := essay(false)
+		assignment.DueAt = time.Now().Add(time.Hour).Unix()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(assignment, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+
+		_, err := u.Allocate(instructorCtx, 5)
+		assert.Equal(t, domain.ErrConflict, err)
+	})
+	t.Run("no-peer-review", func(t *testing.T) {
+		mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			mockAttachmentStorage, time.Second*2)
+		assignment := essay(false)
+		assignment.PeerReview = nil
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(assignment, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+
+		_, err := u.Allocate(instructorCtx, 5)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+}
+
+func TestAllocateDue(t *testing.T) {
+	mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+	mockAssignmentRepo := new(mocks.AssignmentRepository)
+	mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+	mockRubricUseCase := new(mocks.RubricUseCase)
+	mockAttachmentStorage := new(mocks.AttachmentStorage)
+	u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+		mockAttachmentStorage, time.Second*2)
+	mockPeerReviewRepo.On("GetUnallocated", mock.Anything, mock.AnythingOfType("int64")).
+		Return([]domain.PeerReviewAllocation{{AssignmentID: 5, OrganizationID: 2}, {AssignmentID: 6, OrganizationID: 3}}, nil).Once()
+	organization := func(id int64) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool { return domain.OrganizationIDFromContext(ctx) == id })
+	}
+	second := essay(false)
+	second.ID = 6
+	mockAssignmentRepo.On("GetByID", organization(2), int64(5)).Return(essay(false), nil).Once()
+	mockAssignmentRepo.On("GetByID", organization(3), int64(6)).Return(second, nil).Once()
+	mockPeerReviewRepo.On("GetLatestSubmissions", organization(2), int64(5)).Return(submissions(3), nil).Once()
+	mockPeerReviewRepo.On("GetLatestSubmissions", organization(3), int64(6)).Return(submissions(2), nil).Once()
+	mockPeerReviewRepo.On("Allocate", organization(2), int64(5), mock.Anything, mock.Anything).Return(domain.ErrConflict).Once()
+	mockPeerReviewRepo.On("Allocate", organization(3), int64(6), mock.Anything, mock.Anything).Return(nil).Once()
+
+	assert.NoError(t, u.AllocateDue(context.TODO()), "an assignment allocated meanwhile is skipped")
+	mockPeerReviewRepo.AssertExpectations(t)
+}
+
+func TestAllocateDueFailingAssignment(t *testing.T) {
+	mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+	mockAssignmentRepo := new(mocks.AssignmentRepository)
+	mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+	mockRubricUseCase := new(mocks.RubricUseCase)
+	mockAttachmentStorage := new(mocks.AttachmentStorage)
+	u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+		mockAttachmentStorage, time.Second*2)
+	mockPeerReviewRepo.On("GetUnallocated", mock.Anything, mock.AnythingOfType("int64")).
+		Return([]domain.PeerReviewAllocation{{AssignmentID: 5, OrganizationID: 2}, {AssignmentID: 6, OrganizationID: 3}}, nil).Once()
+	second := essay(false)
+	second.ID = 6
+	mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(nil, errors.New("invalid connection")).Once()
+	mockAssignmentRepo.On("GetByID", mock.Anything, int64(6)).Return(second, nil).Once()
+	mockPeerReviewRepo.On("GetLatestSubmissions", mock.Anything, int64(6)).Return(submissions(2), nil).Once()
+	mockPeerReviewRepo.On("Allocate", mock.Anything, int64(6), mock.Anything, mock.Anything).Return(nil).Once()
+
+	assert.NoError(t, u.AllocateDue(context.TODO()), "a failing assignment does not stop the others")
+	mockPeerReviewRepo.AssertExpectations(t)
+	mockAssignmentRepo.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	review := func() *domain.PeerReview {
+		return &domain.PeerReview{ID: 9, AssignmentID: 5, SubmissionID: 100, AuthorID: 10, ReviewerID: 11,
+			Status: domain.PeerReviewAssigned, AssignedAt: 1000}
+	}
+	t.Run("anonymous-reviewer", func(t *testing.T) {
+		mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			mockAttachmentStorage, time.Second*2)
+		mockPeerReviewRepo.On("GetByID", mock.Anything, int64(9)).Return(review(), nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(essay(true), nil).Once()
+		score := float64(5)
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(100)).Return(&domain.Submission{ID: 100, AssignmentID: 5,
+			UserID: 10, Text: "My essay", Status: domain.SubmissionGraded, Score: &score, Feedback: "Good.", SubmittedAt: 900}, nil).Once()
+
+		result, err := u.GetByID(learnerCtx(11), 9)
+		assert.NoError(t, err)
+		assert.Zero(t, result.AuthorID, "the author is hidden from the reviewer")
+		assert.Equal(t, &domain.Submission{ID: 100, AssignmentID: 5, Text: "My essay", Status: domain.SubmissionSubmitted,
+			SubmittedAt: 900}, result.Submission, "the reviewer does not see the grade")
+	})
+	t.Run("anonymous-author", func(t *testing.T) {
+		mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			mockAttachmentStorage, time.Second*2)
+		submitted := review()
+		submitted.Status = domain.PeerReviewSubmitted
+		mockPeerReviewRepo.On("GetByID", mock.Anything, int64(9)).Return(submitted, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(essay(true), nil).Once()
+
+		result, err := u.GetByID(learnerCtx(10), 9)
+		assert.NoError(t, err)
+		assert.Zero(t, result.ReviewerID, "the reviewer is hidden from the author")
+	})
+	t.Run("author-before-submitted", func(t *testing.T) {
+		mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			mockAttachmentStorage, time.Second*2)
+		mockPeerReviewRepo.On("GetByID", mock.Anything, int64(9)).Return(review(), nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(essay(true), nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+
+		_, err := u.GetByID(learnerCtx(10), 9)
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+	t.Run("instructor", func(t *testing.T) {
+		mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			mockAttachmentStorage, time.Second*2)
+		mockPeerReviewRepo.On("GetByID", mock.Anything, int64(9)).Return(review(), nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(essay(true), nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+
+		result, err := u.GetByID(instructorCtx, 9)
+		assert.NoError(t, err)
+		assert.Equal(t, review(), result, "the ones working on the course see the reviewer and the author")
+	})
+}
+
+func TestSubmitReview(t *testing.T) {
+	scores := func() []domain.CriterionScore {
+		return []domain.CriterionScore{{CriterionID: 1, LevelID: 3}, {CriterionID: 2, LevelID: 2}}
+	}
+	t.Run("success", func(t *testing.T) {
+		mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			mockAttachmentStorage, time.Second*2)
+		mockPeerReviewRepo.On("GetByID", mock.Anything, int64(9)).Return(&domain.PeerReview{ID: 9, AssignmentID: 5,
+			SubmissionID: 100, AuthorID: 10, ReviewerID: 11, Status: domain.PeerReviewAssigned}, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(essay(false), nil).Once()
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(100)).Return(&domain.Submission{ID: 100, UserID: 10}, nil).Once()
+		mockRubricUseCase.On("Apply", mock.Anything, int64(7), scores()).Return(float64(7), float64(7), nil).Once()
+		mockPeerReviewRepo.On("SubmitReview", mock.Anything, mock.AnythingOfType("*domain.PeerReview")).Return(nil).Once()
+
+		review, err := u.SubmitReview(learnerCtx(11), 9, &domain.PeerReviewGrade{Scores: scores(), Feedback: "Clear."})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PeerReviewSubmitted, review.Status)
+		assert.Equal(t, float64(7), *review.Score)
+		assert.Equal(t, "Clear.", review.Feedback)
+		assert.NotZero(t, review.SubmittedAt)
+	})
+	t.Run("past-due", func(t *testing.T) {
+		mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			mockAttachmentStorage, time.Second*2)
+		assignment := essay(false)
+		assignment.PeerReview.DueAt = 2000
+		mockPeerReviewRepo.On("GetByID", mock.Anything, int64(9)).Return(&domain.PeerReview{ID: 9, AssignmentID: 5,
+			SubmissionID: 100, AuthorID: 10, ReviewerID: 11, Status: domain.PeerReviewAssigned}, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(assignment, nil).Once()
+		mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(100)).Return(&domain.Submission{ID: 100, UserID: 10}, nil).Once()
+
+		_, err := u.SubmitReview(learnerCtx(11), 9, &domain.PeerReviewGrade{Scores: scores()})
+		assert.Equal(t, domain.ErrSubmissionClosed, err)
+		mockPeerReviewRepo.AssertNotCalled(t, "SubmitReview", mock.Anything, mock.Anything)
+	})
+	t.Run("not-the-reviewer", func(t *testing.T) {
+		mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+		mockAssignmentRepo := new(mocks.AssignmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			mockAttachmentStorage, time.Second*2)
+		mockPeerReviewRepo.On("GetByID", mock.Anything, int64(9)).Return(&domain.PeerReview{ID: 9, AssignmentID: 5,
+			SubmissionID: 100, AuthorID: 10, ReviewerID: 11, Status: domain.PeerReviewAssigned}, nil).Once()
+		mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(essay(false), nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+
+		_, err := u.SubmitReview(instructorCtx, 9, &domain.PeerReviewGrade{Scores: scores()})
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+}
+
+func TestGetReceived(t *testing.T) {
+	mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+	mockAssignmentRepo := new(mocks.AssignmentRepository)
+	mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+	mockRubricUseCase := new(mocks.RubricUseCase)
+	mockAttachmentStorage := new(mocks.AttachmentStorage)
+	u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+		mockAttachmentStorage, time.Second*2)
+	score := float64(6)
+	mockAssignmentRepo.On("GetSubmission", mock.Anything, int64(100)).Return(&domain.Submission{ID: 100, AssignmentID: 5, UserID: 10}, nil).Once()
+	mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(essay(true), nil).Once()
+	mockPeerReviewRepo.On("GetBySubmission", mock.Anything, int64(100)).Return([]domain.PeerReview{
+		{ID: 9, SubmissionID: 100, AuthorID: 10, ReviewerID: 11, Status: domain.PeerReviewSubmitted, Score: &score},
+		{ID: 10, SubmissionID: 100, AuthorID: 10, ReviewerID: 12, Status: domain.PeerReviewAssigned},
+	}, nil).Once()
+
+	list, err := u.GetReceived(learnerCtx(10), 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PeerReview{{ID: 9, SubmissionID: 100, AuthorID: 10, Status: domain.PeerReviewSubmitted, Score: &score}},
+		list, "the author sees the submitted reviews, without their reviewers")
+}
+
+func TestGetGrades(t *testing.T) {
+	mockPeerReviewRepo := new(mocks.PeerReviewRepository)
+	mockAssignmentRepo := new(mocks.AssignmentRepository)
+	mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+	mockRubricUseCase := new(mocks.RubricUseCase)
+	mockAttachmentStorage := new(mocks.AttachmentStorage)
+	u := ucase.NewPeerReviewUseCase(mockPeerReviewRepo, mockAssignmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+		mockAttachmentStorage, time.Second*2)
+	mockAssignmentRepo.On("GetByID", mock.Anything, int64(5)).Return(essay(false), nil).Once()
+	mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+	review := func(submissionID int64, reviewerID int64, first float64, second float64) domain.PeerReview {
+		score := first + second
+		return domain.PeerReview{SubmissionID: submissionID, AuthorID: submissionID - 90, ReviewerID: reviewerID,
+			Status: domain.PeerReviewSubmitted, Score: &score,
+			Scores: []domain.CriterionScore{{CriterionID: 1, Score: first}, {CriterionID: 2, Score: second}}}
+	}
+	mockPeerReviewRepo.On("GetByAssignment", mock.Anything, int64(5)).Return([]domain.PeerReview{
+		review(100, 11, 4, 3), review(100, 12, 2, 0), review(100, 13, 1, 3),
+		{SubmissionID: 101, AuthorID: 11, ReviewerID: 10, Status: domain.PeerReviewAssigned},
+	}, nil).Once()
+
+	grades, err := u.GetGrades(instructorCtx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PeerGrade{
+		{SubmissionID: 100, AuthorID: 10, Reviews: 3, Completed: 3, MaxPoints: 7, Mean: 13.0 / 3, Median: 4, Min: 2, Max: 7,
+			Criteria: []domain.PeerCriterionGrade{{CriterionID: 1, Mean: 7.0 / 3}, {CriterionID: 2, Mean: 2}}},
+		{SubmissionID: 101, AuthorID: 11, Reviews: 1, MaxPoints: 7, Criteria: []domain.PeerCriterionGrade{}},
+	}, grades)
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// PrivacyHandler ...
+type PrivacyHandler struct {
+	PrivacyUseCase domain.PrivacyUseCase
+}
+
+// NewPrivacyHandler ...
+func NewPrivacyHandler(e *echo.Echo, us domain.PrivacyUseCase) {
+	handler := &PrivacyHandler{
+		PrivacyUseCase: us,
+	}
+	e.GET("/users/:id/export", handler.ExportUser, rbac.Require(domain.PermUserManage))
+	e.POST("/users/:id/erase", handler.EraseUser, rbac.Require(domain.PermUserManage))
+}
+
+// bundle returns a ZIP archive of the personal data, with a JSON file by section
+func bundle(data *domain.PersonalData) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range []struct {
+		name    string
+		content interface{}
+	}{
+		{"profile.json", data.Profile},
+		{"teams.json", data.Teams},
+		{"enrollments.json", data.Enrollments},
+		{"identities.json", data.Identities},
+		{"sessions.json", data.Sessions},
+		{"invitations.json", data.Invitations},
+		{"submissions.json", data.Submissions},
+		{"peer_reviews.json", data.PeerReviews},
+		{"quiz_attempts.json", data.QuizAttempts},
+	} {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		content, err := json.MarshalIndent(file.content, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if _, err = w.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportUser godoc
+// @Summary Export the personal data of a user.
+// @Description Export everything stored about a user: profile, teams, enrollments, single sign-on identities, sessions, invitations,
+// @Description assignment submissions, peer reviews and quiz attempts.
+// @Description The data is returned as JSON, or as a ZIP archive with a JSON file by section when format is zip.
+// @Tags users
+// @Accept */*
+// @Produce json,application/zip
+// @Param id path int true "User Id"
+// @Param format query string false "json (default) or zip"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users/{id}/export [get]
+func (c *PrivacyHandler) ExportUser(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	format := echoContext.QueryParam("format")
+	if format != "" && format != "json" && format != "zip" {
+		return echoContext.JSON(http.StatusBadRequest, ResponseError{Message: domain.ErrBadParamInput.Error()})
+	}
+	ctx := echoContext.Request().Context()
+
+	data, err := c.PrivacyUseCase.ExportUser(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	fileName := fmt.Sprintf("user-%d-personal-data", idParam)
+	if format == "zip" {
+		archive, err := bundle(data)
+		if err != nil {
+			return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+		}
+		echoContext.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.zip"`, fileName))
+		return echoContext.Blob(http.StatusOK, "application/zip", archive)
+	}
+	echoContext.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.json"`, fileName))
+	res := domain.Response{
+		Data:    data,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// EraseUser godoc
+// @Summary Erase the personal data of a user.
+// @Description Anonymize a user: its profile is cleared and its credentials, sessions, identities, team memberships and invitations
+// @Description are deleted. The text and files of its submissions, its quiz answers and its peer review feedback are cleared.
+// @Description Its enrollments, grades and scores stay, anonymous, so that the statistics of the courses do not change.
+// @Tags users
+// @Accept */*
+// @Produce json
+// @Param id path int true "User Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users/{id}/erase [post]
+func (c *PrivacyHandler) EraseUser(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.PrivacyUseCase.EraseUser(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,120 @@
+package http_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	privacyHTTP "github.com/meroedu/meroedu/internal/privacy/delivery/http"
+)
+
+func TestExportUser(t *testing.T) {
+	mockUCase := new(mocks.PrivacyUseCase)
+	mockUCase.On("ExportUser", mock.Anything, int64(3)).Return(&domain.PersonalData{Profile: &domain.User{ID: 3}}, nil).Twice()
+	mockUCase.On("ExportUser", mock.Anything, int64(4)).Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id     string
+		format string
+		code   int
+	}{
+		{"3", "", http.StatusOK},
+		{"3", "zip", http.StatusOK},
+		{"4", "json", http.StatusNotFound},
+		{"3", "csv", http.StatusBadRequest},
+		{"me", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/users/"+tt.id+"/export?format="+tt.format, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/export")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := privacyHTTP.PrivacyHandler{
+			PrivacyUseCase: mockUCase,
+		}
+		err = handler.ExportUser(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id+"?"+tt.format)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestExportUserZip(t *testing.T) {
+	mockUCase := new(mocks.PrivacyUseCase)
+	mockUCase.On("ExportUser", mock.Anything, int64(3)).Return(&domain.PersonalData{Profile: &domain.User{ID: 3}}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/users/3/export?format=zip", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/users/:id/export")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := privacyHTTP.PrivacyHandler{
+		PrivacyUseCase: mockUCase,
+	}
+	err = handler.ExportUser(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `attachment; filename="user-3-personal-data.zip"`, rec.Header().Get(echo.HeaderContentDisposition))
+	archive, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(t, err)
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	assert.Equal(t, []string{"profile.json", "teams.json", "enrollments.json", "identities.json", "sessions.json", "invitations.json",
+		"submissions.json", "peer_reviews.json", "quiz_attempts.json"}, names)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestEraseUser(t *testing.T) {
+	mockUCase := new(mocks.PrivacyUseCase)
+	mockUCase.On("EraseUser", mock.Anything, int64(3)).Return(nil).Once()
+	mockUCase.On("EraseUser", mock.Anything, int64(1)).Return(domain.ErrForbidden).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"3", http.StatusNoContent},
+		{"1", http.StatusForbidden},
+		{"me", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/users/"+tt.id+"/erase", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/erase")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := privacyHTTP.PrivacyHandler{
+			PrivacyUseCase: mockUCase,
+		}
+		err = handler.EraseUser(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
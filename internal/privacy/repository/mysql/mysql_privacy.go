@@ -0,0 +1,385 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the privacy's Repository interface
+func Init(db *sql.DB) domain.PrivacyRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+// query runs the query and scans every row with scan
+func (m *mysqlRepository) query(ctx context.Context, scan func(*sql.Rows) error, query string, args ...interface{}) error {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetEnrollments returns the enrollments of the user in the courses of the caller's organization
+func (m *mysqlRepository) GetEnrollments(ctx context.Context, userID int64) ([]domain.Enrollment, error) {
+	query := `SELECT e.id,e.course_id,e.userID,e.course_version_id,v.version,e.status,e.created_at FROM courses_users_enrollments e
+		JOIN courses c ON c.id = e.course_id LEFT JOIN course_versions v ON v.id = e.course_version_id
+		WHERE e.userID = ? AND c.organization_id = ? ORDER BY e.created_at, e.id`
+	result := make([]domain.Enrollment, 0)
+	err := m.query(ctx, func(rows *sql.Rows) error {
+		e := domain.Enrollment{}
+		versionID, version, status := sql.NullInt64{}, sql.NullInt64{}, sql.NullInt64{}
+		if err := rows.Scan(&e.ID, &e.CourseID, &e.UserID, &versionID, &version, &status, &e.CreatedAt); err != nil {
+			return err
+		}
+		e.CourseVersionID = versionID.Int64
+		e.Version = int(version.Int64)
+		e.Status = int(status.Int64)
+		result = append(result, e)
+		return nil
+	}, query, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetIdentities returns the single sign-on and directory identities linked to the user. The issuer of a directory
+// identity is the URL of the directory, empty once the directory is removed.
+func (m *mysqlRepository) GetIdentities(ctx context.Context, userID int64) ([]domain.PersonalIdentity, error) {
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	query := `SELECT p.issuer,i.subject,i.created_at FROM user_identities i JOIN oidc_providers p ON p.id = i.provider_id
+		WHERE i.user_id = ? AND p.organization_id = ?
+		UNION ALL SELECT IFNULL(d.url,''),i.dn,i.created_at FROM ldap_identities i LEFT JOIN ldap_directories d ON d.organization_id = i.organization_id
+		WHERE i.user_id = ? AND i.organization_id = ? ORDER BY 3`
+	result := make([]domain.PersonalIdentity, 0)
+	err := m.query(ctx, func(rows *sql.Rows) error {
+		i := domain.PersonalIdentity{}
+		if err := rows.Scan(&i.Issuer, &i.Subject, &i.CreatedAt); err != nil {
+			return err
+		}
+		result = append(result, i)
+		return nil
+	}, query, userID, organizationID, userID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSessions returns the login sessions of the user
+func (m *mysqlRepository) GetSessions(ctx context.Context, userID int64) ([]domain.PersonalSession, error) {
+	query := `SELECT device,ip,last_seen_at,expires_at,revoked_at,created_at FROM sessions WHERE user_id = ? AND organization_id = ?
+		ORDER BY created_at, id`
+	result := make([]domain.PersonalSession, 0)
+	err := m.query(ctx, func(rows *sql.Rows) error {
+		s := domain.PersonalSession{}
+		var device, ip sql.NullString
+		var revokedAt sql.NullInt64
+		if err := rows.Scan(&device, &ip, &s.LastSeenAt, &s.ExpiresAt, &revokedAt, &s.CreatedAt); err != nil {
+			return err
+		}
+		s.Device = device.String
+		s.IP = ip.String
+		s.RevokedAt = revokedAt.Int64
+		result = append(result, s)
+		return nil
+	}, query, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetInvitations returns the invitations accepted by the user or sent to their email in the caller's organization
+func (m *mysqlRepository) GetInvitations(ctx context.Context, userID int64, email string) ([]domain.Invitation, error) {
+	query := `SELECT id,organization_id,email,first_name,last_name,role_id,invited_by,user_id,status,expires_at,accepted_at,updated_at,created_at
+		FROM invitations WHERE organization_id = ? AND (user_id = ? OR email = ?) ORDER BY created_at, id`
+	result := make([]domain.Invitation, 0)
+	err := m.query(ctx, func(rows *sql.Rows) error {
+		i := domain.Invitation{}
+		var firstName, lastName sql.NullString
+		var invitedUserID, acceptedAt sql.NullInt64
+		err := rows.Scan(&i.ID, &i.OrganizationID, &i.Email, &firstName, &lastName, &i.RoleID, &i.InvitedBy, &invitedUserID, &i.Status,
+			&i.ExpiresAt, &acceptedAt, &i.UpdatedAt, &i.CreatedAt)
+		if err != nil {
+			return err
+		}
+		i.FirstName = firstName.String
+		i.LastName = lastName.String
+		i.UserID = invitedUserID.Int64
+		i.AcceptedAt = acceptedAt.Int64
+		result = append(result, i)
+		return nil
+	}, query, domain.OrganizationIDFromContext(ctx), userID, email)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSubmissions returns the assignment submissions of the user in the courses of the caller's organization
+func (m *mysqlRepository) GetSubmissions(ctx context.Context, userID int64) ([]domain.Submission, error) {
+	query := `SELECT s.id,s.assignment_id,s.user_id,s.number,s.text,s.files,s.status,s.late,s.submitted_at,s.score,s.scores,s.penalty,
+		s.final_score,s.feedback,s.graded_by,s.graded_at FROM assignment_submissions s JOIN assignments a ON a.id = s.assignment_id
+		JOIN lessons l ON l.id = a.lesson_id JOIN courses c ON c.id = l.course_id
+		WHERE s.user_id = ? AND c.organization_id = ? ORDER BY s.submitted_at, s.id`
+	result := make([]domain.Submission, 0)
+	err := m.query(ctx, func(rows *sql.Rows) error {
+		s := domain.Submission{}
+		var text, scores, feedback sql.NullString
+		var files string
+		var score, finalScore sql.NullFloat64
+		var gradedBy, gradedAt sql.NullInt64
+		err := rows.Scan(&s.ID, &s.AssignmentID, &s.UserID, &s.Number, &text, &files, &s.Status, &s.Late, &s.SubmittedAt, &score, &scores,
+			&s.Penalty, &finalScore, &feedback, &gradedBy, &gradedAt)
+		if err != nil {
+			return err
+		}
+		s.Text = text.String
+		s.Feedback = feedback.String
+		s.GradedBy = gradedBy.Int64
+		s.GradedAt = gradedAt.Int64
+		if score.Valid {
+			s.Score = &score.Float64
+		}
+		if finalScore.Valid {
+			s.FinalScore = &finalScore.Float64
+		}
+		if err = json.Unmarshal([]byte(files), &s.Files); err != nil {
+			return err
+		}
+		if scores.String != "" {
+			if err = json.Unmarshal([]byte(scores.String), &s.Scores); err != nil {
+				return err
+			}
+		}
+		result = append(result, s)
+		return nil
+	}, query, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetPeerReviews returns the peer reviews written by the user and those of their submissions in the courses of the
+// caller's organization. The other learner of a review is left out.
+func (m *mysqlRepository) GetPeerReviews(ctx context.Context, userID int64) ([]domain.PeerReview, error) {
+	query := `SELECT r.id,r.assignment_id,r.submission_id,IF(s.user_id = ?,s.user_id,0),IF(r.reviewer_id = ?,r.reviewer_id,0),r.status,
+		r.scores,r.score,r.feedback,r.assigned_at,r.submitted_at FROM peer_reviews r JOIN assignment_submissions s ON s.id = r.submission_id
+		JOIN assignments a ON a.id = r.assignment_id JOIN lessons l ON l.id = a.lesson_id JOIN courses c ON c.id = l.course_id
+		WHERE (r.reviewer_id = ? OR s.user_id = ?) AND c.organization_id = ? ORDER BY r.assigned_at, r.id`
+	result := make([]domain.PeerReview, 0)
+	err := m.query(ctx, func(rows *sql.Rows) error {
+		r := domain.PeerReview{}
+		var scores, feedback sql.NullString
+		var score sql.NullFloat64
+		var submittedAt sql.NullInt64
+		err := rows.Scan(&r.ID, &r.AssignmentID, &r.SubmissionID, &r.AuthorID, &r.ReviewerID, &r.Status, &scores, &score, &feedback,
+			&r.AssignedAt, &submittedAt)
+		if err != nil {
+			return err
+		}
+		r.Feedback = feedback.String
+		r.SubmittedAt = submittedAt.Int64
+		if score.Valid {
+			r.Score = &score.Float64
+		}
+		if scores.String != "" {
+			if err = json.Unmarshal([]byte(scores.String), &r.Scores); err != nil {
+				return err
+			}
+		}
+		result = append(result, r)
+		return nil
+	}, query, userID, userID, userID, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetQuizAttempts returns the quiz attempts of the user in the courses of the caller's organization
+func (m *mysqlRepository) GetQuizAttempts(ctx context.Context, userID int64) ([]domain.QuizAttempt, error) {
+	query := `SELECT a.id,a.quiz_id,a.user_id,a.number,a.status,a.answers,a.score,a.max_score,a.passed,a.started_at,a.expires_at,a.submitted_at
+		FROM quiz_attempts a JOIN quizzes z ON z.id = a.quiz_id JOIN lessons l ON l.id = z.lesson_id JOIN courses c ON c.id = l.course_id
+		WHERE a.user_id = ? AND c.organization_id = ? ORDER BY a.started_at, a.id`
+	result := make([]domain.QuizAttempt, 0)
+	err := m.query(ctx, func(rows *sql.Rows) error {
+		a := domain.QuizAttempt{}
+		var answers sql.NullString
+		var expiresAt, submittedAt sql.NullInt64
+		err := rows.Scan(&a.ID, &a.QuizID, &a.UserID, &a.Number, &a.Status, &answers, &a.Score, &a.MaxScore, &a.Passed, &a.StartedAt,
+			&expiresAt, &submittedAt)
+		if err != nil {
+			return err
+		}
+		a.ExpiresAt = expiresAt.Int64
+		a.SubmittedAt = submittedAt.Int64
+		if answers.String != "" {
+			if err = json.Unmarshal([]byte(answers.String), &a.Answers); err != nil {
+				return err
+			}
+		}
+		if a.MaxScore > 0 {
+			a.Percentage = a.Score * 100 / a.MaxScore
+		}
+		result = append(result, a)
+		return nil
+	}, query, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EraseUser anonymizes a user of the caller's organization in a single transaction. Its profile is cleared and its credentials,
+// sessions, identities, team memberships and invitations are deleted. The text written by or to the user in submissions,
+// peer reviews and quiz answers is cleared. The user row, its enrollments and the scores stay, so that the statistics of the
+// courses and the grades of the other learners do not change.
+func (m *mysqlRepository) EraseUser(ctx context.Context, userID int64, email string, erasedAt int64) (err error) {
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `UPDATE users SET firstName=NULL,lastName=?,email=NULL,email_verified_at=NULL,username=NULL,password=NULL,phone=NULL,
+		country_id=NULL,address1=NULL,address2=NULL,profileUrl=NULL,lastOnline=NULL,failed_logins=0,locked_until=NULL,status=?,updated_at=?
+		WHERE id = ? AND organization_id = ?`
+	res, err := tx.ExecContext(ctx, query, domain.ErasedUserName, domain.UserErased, erasedAt, userID, organizationID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+
+	for _, query := range []string{
+		`DELETE FROM refresh_tokens WHERE user_id = ?`,
+		`DELETE FROM sessions WHERE user_id = ?`,
+		`DELETE FROM user_tokens WHERE user_id = ?`,
+		`DELETE FROM two_factor_recovery_codes WHERE user_id = ?`,
+		`DELETE FROM two_factor WHERE user_id = ?`,
+		`DELETE FROM user_identities WHERE user_id = ?`,
+		`DELETE FROM ldap_identities WHERE user_id = ?`,
+		`DELETE FROM teams_users WHERE user_id = ?`,
+		`UPDATE assignment_submissions SET text=NULL,files='[]',feedback=NULL WHERE user_id = ?`,
+		`UPDATE quiz_attempts SET answers=NULL WHERE user_id = ?`,
+	} {
+		if _, err = tx.ExecContext(ctx, query, userID); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+	query = `UPDATE peer_reviews r JOIN assignment_submissions s ON s.id = r.submission_id SET r.feedback=NULL
+		WHERE r.reviewer_id = ? OR s.user_id = ?`
+	if _, err = tx.ExecContext(ctx, query, userID, userID); err != nil {
+		log.Error(err)
+		return
+	}
+	query = `SELECT id,scores FROM assignment_submissions WHERE user_id = ? AND scores IS NOT NULL`
+	if err = clearComments(ctx, tx, "assignment_submissions", query, userID); err != nil {
+		return
+	}
+	query = `SELECT r.id,r.scores FROM peer_reviews r JOIN assignment_submissions s ON s.id = r.submission_id
+		WHERE (r.reviewer_id = ? OR s.user_id = ?) AND r.scores IS NOT NULL`
+	if err = clearComments(ctx, tx, "peer_reviews", query, userID, userID); err != nil {
+		return
+	}
+	query = `DELETE FROM invitations WHERE organization_id = ? AND (user_id = ? OR email = ?)`
+	if _, err = tx.ExecContext(ctx, query, organizationID, userID, email); err != nil {
+		log.Error(err)
+		return
+	}
+	return
+}
+
+// clearComments removes the comments from the criterion scores of the rows of the table returned by query, as id and scores
+func clearComments(ctx context.Context, tx *sql.Tx, table string, query string, args ...interface{}) error {
+	type scored struct {
+		id     int64
+		scores []domain.CriterionScore
+	}
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	list := make([]scored, 0)
+	for rows.Next() {
+		var r scored
+		var raw string
+		if err = rows.Scan(&r.id, &raw); err != nil {
+			break
+		}
+		if err = json.Unmarshal([]byte(raw), &r.scores); err != nil {
+			break
+		}
+		list = append(list, r)
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	if errRow := rows.Close(); errRow != nil && err == nil {
+		err = errRow
+	}
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	for _, r := range list {
+		for i := range r.scores {
+			r.scores[i].Comment = ""
+		}
+		raw, err := json.Marshal(r.scores)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx, `UPDATE `+table+` SET scores = ? WHERE id = ?`, raw, r.id); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+	return nil
+}
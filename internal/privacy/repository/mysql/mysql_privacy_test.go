@@ -0,0 +1,224 @@
+package mysql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/privacy/repository/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+const eraseQuery = `UPDATE users SET firstName=NULL,lastName=\?,email=NULL,.+,status=\?,updated_at=\?\s+WHERE id = \? AND organization_id = \?`
+
+func TestGetEnrollments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM courses_users_enrollments e .+ WHERE e.userID = \? AND c.organization_id = \?`).WithArgs(11, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "course_id", "userID", "course_version_id", "version", "status", "created_at"}).
+			AddRow(1, 3, 11, 30, 2, domain.EnrollmentCompleted, 100).
+			AddRow(2, 5, 11, nil, nil, nil, 110))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetEnrollments(orgCtx, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Enrollment{
+		{ID: 1, CourseID: 3, UserID: 11, CourseVersionID: 30, Version: 2, Status: domain.EnrollmentCompleted, CreatedAt: 100},
+		{ID: 2, CourseID: 5, UserID: 11, CreatedAt: 110},
+	}, list)
+}
+
+func TestGetSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM sessions WHERE user_id = \? AND organization_id = \?`).WithArgs(11, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"device", "ip", "last_seen_at", "expires_at", "revoked_at", "created_at"}).
+			AddRow("Firefox", "10.0.0.1", 150, 200, nil, 100).
+			AddRow(nil, nil, 110, 300, 120, 110))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetSessions(orgCtx, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PersonalSession{
+		{Device: "Firefox", IP: "10.0.0.1", LastSeenAt: 150, ExpiresAt: 200, CreatedAt: 100},
+		{LastSeenAt: 110, ExpiresAt: 300, RevokedAt: 120, CreatedAt: 110},
+	}, list)
+}
+
+func TestGetInvitations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM invitations WHERE organization_id = \? AND \(user_id = \? OR email = \?\)`).
+		WithArgs(2, 11, "sita@school.local").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "email", "first_name", "last_name", "role_id", "invited_by", "user_id",
+			"status", "expires_at", "accepted_at", "updated_at", "created_at"}).
+			AddRow(4, 2, "sita@school.local", "Sita", nil, 3, 5, 11, 2, 200, 150, 150, 100))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetInvitations(orgCtx, 11, "sita@school.local")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, int64(11), list[0].UserID)
+	assert.Equal(t, int64(150), list[0].AcceptedAt)
+}
+
+func TestGetIdentities(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM user_identities i .+ WHERE i.user_id = \? AND p.organization_id = \?\s+UNION ALL .+ FROM ldap_identities i .+ WHERE i.user_id = \? AND i.organization_id = \?`).
+		WithArgs(11, 2, 11, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"issuer", "subject", "created_at"}).
+			AddRow("https://idp.example.com", "user-42", 100).
+			AddRow("ldaps://dc.school.local", "CN=Sita Sharma,OU=Staff,DC=school,DC=local", 110))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetIdentities(orgCtx, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PersonalIdentity{
+		{Issuer: "https://idp.example.com", Subject: "user-42", CreatedAt: 100},
+		{Issuer: "ldaps://dc.school.local", Subject: "CN=Sita Sharma,OU=Staff,DC=school,DC=local", CreatedAt: 110},
+	}, list)
+}
+
+func TestGetSubmissions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM assignment_submissions s .+ WHERE s.user_id = \? AND c.organization_id = \?`).WithArgs(11, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "assignment_id", "user_id", "number", "text", "files", "status", "late", "submitted_at",
+			"score", "scores", "penalty", "final_score", "feedback", "graded_by", "graded_at"}).
+			AddRow(5, 2, 11, 1, "My essay", `[{"name":"a1.pdf","filename":"essay.pdf","file_type":"application/pdf","file_size":10}]`,
+				domain.SubmissionGraded, false, 100, 8, `[{"criterion_id":1,"score":8}]`, 0, 8, "Good work", 5, 120).
+			AddRow(6, 3, 11, 1, nil, `[]`, domain.SubmissionSubmitted, true, 130, nil, nil, 0, nil, nil, nil, nil))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetSubmissions(orgCtx, 11)
+	assert.NoError(t, err)
+	if assert.Len(t, list, 2) {
+		assert.Equal(t, "a1.pdf", list[0].Files[0].Name)
+		assert.Equal(t, 8.0, *list[0].FinalScore)
+		assert.Equal(t, "Good work", list[0].Feedback)
+		assert.Empty(t, list[1].Text)
+		assert.Nil(t, list[1].Score)
+	}
+}
+
+func TestGetPeerReviews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT r.id,r.assignment_id,r.submission_id,IF\(s.user_id = \?,s.user_id,0\),IF\(r.reviewer_id = \?,r.reviewer_id,0\),.+
+		WHERE \(r.reviewer_id = \? OR s.user_id = \?\) AND c.organization_id = \?`).WithArgs(11, 11, 11, 11, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "assignment_id", "submission_id", "author_id", "reviewer_id", "status", "scores", "score",
+			"feedback", "assigned_at", "submitted_at"}).
+			AddRow(8, 2, 40, 0, 11, domain.PeerReviewSubmitted, `[{"criterion_id":1,"score":3}]`, 3, "Well argued", 100, 110).
+			AddRow(9, 2, 5, 11, 0, domain.PeerReviewAssigned, nil, nil, nil, 100, nil))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetPeerReviews(orgCtx, 11)
+	assert.NoError(t, err)
+	if assert.Len(t, list, 2) {
+		assert.Equal(t, int64(11), list[0].ReviewerID)
+		assert.Zero(t, list[0].AuthorID, "the reviewed learner is left out")
+		assert.Equal(t, "Well argued", list[0].Feedback)
+		assert.Zero(t, list[1].ReviewerID, "the reviewer is left out")
+		assert.Nil(t, list[1].Score)
+	}
+}
+
+func TestGetQuizAttempts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM quiz_attempts a .+ WHERE a.user_id = \? AND c.organization_id = \?`).WithArgs(11, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "quiz_id", "user_id", "number", "status", "answers", "score", "max_score", "passed",
+			"started_at", "expires_at", "submitted_at"}).
+			AddRow(3, 1, 11, 1, domain.AttemptSubmitted, `[{"question_id":4,"text":"Kathmandu"}]`, 3, 4, true, 100, nil, 150))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetQuizAttempts(orgCtx, 11)
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, 75.0, list[0].Percentage)
+		assert.Len(t, list[0].Answers, 1)
+		assert.Equal(t, int64(150), list[0].SubmittedAt)
+		assert.Equal(t, 1, list[0].Number)
+	}
+}
+
+func TestEraseUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(eraseQuery).WithArgs(domain.ErasedUserName, domain.UserErased, 100, 11, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		for _, table := range []string{"refresh_tokens", "sessions", "user_tokens", "two_factor_recovery_codes", "two_factor", "user_identities",
+			"ldap_identities", "teams_users"} {
+			mock.ExpectExec(`DELETE FROM ` + table + ` WHERE user_id = \?`).WithArgs(11).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectExec(`UPDATE assignment_submissions SET text=NULL,files='\[\]',feedback=NULL WHERE user_id = \?`).WithArgs(11).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`UPDATE quiz_attempts SET answers=NULL WHERE user_id = \?`).WithArgs(11).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE peer_reviews r JOIN assignment_submissions s ON s.id = r.submission_id SET r.feedback=NULL
+		WHERE r.reviewer_id = \? OR s.user_id = \?`).WithArgs(11, 11).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectQuery(`SELECT id,scores FROM assignment_submissions WHERE user_id = \? AND scores IS NOT NULL`).WithArgs(11).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "scores"}).AddRow(5, `[{"criterion_id":1,"score":4,"comment":"Sita's thesis is clear"}]`))
+		mock.ExpectExec(`UPDATE assignment_submissions SET scores = \? WHERE id = \?`).
+			WithArgs([]byte(`[{"criterion_id":1,"score":4}]`), 5).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT r.id,r.scores FROM peer_reviews r .+ WHERE \(r.reviewer_id = \? OR s.user_id = \?\) AND r.scores IS NOT NULL`).
+			WithArgs(11, 11).WillReturnRows(sqlmock.NewRows([]string{"id", "scores"}).AddRow(8, `[{"criterion_id":1,"level_id":2,"score":3,"comment":"Well argued"}]`))
+		mock.ExpectExec(`UPDATE peer_reviews SET scores = \? WHERE id = \?`).
+			WithArgs([]byte(`[{"criterion_id":1,"level_id":2,"score":3}]`), 8).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM invitations WHERE organization_id = \? AND \(user_id = \? OR email = \?\)`).
+			WithArgs(2, 11, "sita@school.local").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		assert.NoError(t, repo.EraseUser(orgCtx, 11, "sita@school.local", 100))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("not-found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(eraseQuery).WithArgs(domain.ErasedUserName, domain.UserErased, 100, 12, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := mysqlrepo.Init(db)
+		assert.Equal(t, domain.ErrNotFound, repo.EraseUser(orgCtx, 12, "", 100))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("rollback", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(eraseQuery).WithArgs(domain.ErasedUserName, domain.UserErased, 100, 11, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM refresh_tokens WHERE user_id = \?`).WithArgs(11).WillReturnError(errors.New("lock wait timeout"))
+		mock.ExpectRollback()
+
+		repo := mysqlrepo.Init(db)
+		assert.Error(t, repo.EraseUser(orgCtx, 11, "sita@school.local", 100), "the user is not half erased")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// PrivacyUseCase ...
+type PrivacyUseCase struct {
+	privacyRepo    domain.PrivacyRepository
+	userRepo       domain.UserRepository
+	roleRepo       domain.RoleRepository
+	teamRepo       domain.TeamRepository
+	fileStore      domain.AttachmentStorage
+	contextTimeOut time.Duration
+}
+
+// NewPrivacyUseCase will create new an
+func NewPrivacyUseCase(p domain.PrivacyRepository, u domain.UserRepository, r domain.RoleRepository, t domain.TeamRepository,
+	store domain.AttachmentStorage, timeout time.Duration) domain.PrivacyUseCase {
+	return &PrivacyUseCase{
+		privacyRepo:    p,
+		userRepo:       u,
+		roleRepo:       r,
+		teamRepo:       t,
+		fileStore:      store,
+		contextTimeOut: timeout,
+	}
+}
+
+// ExportUser gathers everything stored about a user of the caller's organization
+func (usecase *PrivacyUseCase) ExportUser(c context.Context, userID int64) (*domain.PersonalData, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	user, err := usecase.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Password = ""
+	data := &domain.PersonalData{ExportedAt: time.Now().Unix(), Profile: user}
+	if data.Teams, err = usecase.teamRepo.GetByUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.Enrollments, err = usecase.privacyRepo.GetEnrollments(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.Identities, err = usecase.privacyRepo.GetIdentities(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.Sessions, err = usecase.privacyRepo.GetSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.Invitations, err = usecase.privacyRepo.GetInvitations(ctx, userID, user.Email); err != nil {
+		return nil, err
+	}
+	if data.Submissions, err = usecase.privacyRepo.GetSubmissions(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.PeerReviews, err = usecase.privacyRepo.GetPeerReviews(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.QuizAttempts, err = usecase.privacyRepo.GetQuizAttempts(ctx, userID); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// EraseUser anonymizes a user of the caller's organization, keeping its enrollments for the statistics of the courses.
+// The files of its submissions are deleted first, so an erasure failing half way can be run again.
+// Only a caller holding every permission of the role of the user can erase them.
+func (usecase *PrivacyUseCase) EraseUser(c context.Context, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	user, err := usecase.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	role, err := usecase.roleRepo.GetByID(ctx, user.RoleID)
+	if err != nil && err != domain.ErrNotFound {
+		return err
+	}
+	if role != nil && !domain.HasPermissions(ctx, role.Permissions) {
+		return domain.ErrForbidden
+	}
+	submissions, err := usecase.privacyRepo.GetSubmissions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, s := range submissions {
+		for _, file := range s.Files {
+			if err = usecase.fileStore.DeleteAttachment(ctx, file.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return usecase.privacyRepo.EraseUser(ctx, userID, user.Email, time.Now().Unix())
+}
@@ -0,0 +1,131 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/privacy/usecase"
+)
+
+var adminCtx = domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2), []domain.Permission{domain.PermUserManage})
+
+func TestExportUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockPrivacyRepo := new(mocks.PrivacyRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPrivacyUseCase(mockPrivacyRepo, mockUserRepo, mockRoleRepo, mockTeamRepo, mockAttachmentStorage, time.Second*2)
+		user := &domain.User{ID: 11, Email: "sita@school.local", LastName: "Sharma", RoleID: 4, Password: "hash"}
+		mockUserRepo.On("GetByID", mock.Anything, int64(11)).Return(user, nil).Once()
+		mockTeamRepo.On("GetByUser", mock.Anything, int64(11)).Return([]domain.Team{{ID: 7, Name: "Grade 5"}}, nil).Once()
+		mockPrivacyRepo.On("GetEnrollments", mock.Anything, int64(11)).Return([]domain.Enrollment{{ID: 1, CourseID: 3, UserID: 11}}, nil).Once()
+		mockPrivacyRepo.On("GetIdentities", mock.Anything, int64(11)).Return([]domain.PersonalIdentity{}, nil).Once()
+		mockPrivacyRepo.On("GetSessions", mock.Anything, int64(11)).Return([]domain.PersonalSession{{ExpiresAt: 200, CreatedAt: 100}}, nil).Once()
+		mockPrivacyRepo.On("GetInvitations", mock.Anything, int64(11), "sita@school.local").Return([]domain.Invitation{}, nil).Once()
+		mockPrivacyRepo.On("GetSubmissions", mock.Anything, int64(11)).
+			Return([]domain.Submission{{ID: 5, AssignmentID: 2, UserID: 11, Text: "My essay"}}, nil).Once()
+		mockPrivacyRepo.On("GetPeerReviews", mock.Anything, int64(11)).Return([]domain.PeerReview{{ID: 8, ReviewerID: 11}}, nil).Once()
+		mockPrivacyRepo.On("GetQuizAttempts", mock.Anything, int64(11)).Return([]domain.QuizAttempt{{ID: 3, QuizID: 1, UserID: 11}}, nil).Once()
+
+		data, err := u.ExportUser(adminCtx, 11)
+		assert.NoError(t, err)
+		assert.Equal(t, user, data.Profile)
+		assert.Empty(t, data.Profile.Password, "the password hash is not exported")
+		assert.Len(t, data.Teams, 1)
+		assert.Len(t, data.Enrollments, 1)
+		assert.Len(t, data.Sessions, 1)
+		assert.Equal(t, "My essay", data.Submissions[0].Text)
+		assert.Len(t, data.PeerReviews, 1)
+		assert.Len(t, data.QuizAttempts, 1)
+		assert.NotZero(t, data.ExportedAt)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mockPrivacyRepo := new(mocks.PrivacyRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPrivacyUseCase(mockPrivacyRepo, mockUserRepo, mockRoleRepo, mockTeamRepo, mockAttachmentStorage, time.Second*2)
+		mockUserRepo.On("GetByID", mock.Anything, int64(12)).Return(nil, domain.ErrNotFound).Once()
+
+		_, err := u.ExportUser(adminCtx, 12)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestEraseUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockPrivacyRepo := new(mocks.PrivacyRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPrivacyUseCase(mockPrivacyRepo, mockUserRepo, mockRoleRepo, mockTeamRepo, mockAttachmentStorage, time.Second*2)
+		mockUserRepo.On("GetByID", mock.Anything, int64(11)).Return(&domain.User{ID: 11, Email: "sita@school.local", RoleID: 4}, nil).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, int64(4)).Return(&domain.Role{ID: 4, Code: domain.RoleLearner}, nil).Once()
+		mockPrivacyRepo.On("GetSubmissions", mock.Anything, int64(11)).Return([]domain.Submission{
+			{ID: 5, Files: []domain.SubmissionFile{{Name: "a1.pdf"}, {Name: "a2.png"}}},
+			{ID: 6, Text: "My essay", Files: []domain.SubmissionFile{}},
+		}, nil).Once()
+		mockAttachmentStorage.On("DeleteAttachment", mock.Anything, "a1.pdf").Return(nil).Once()
+		mockAttachmentStorage.On("DeleteAttachment", mock.Anything, "a2.png").Return(nil).Once()
+		mockPrivacyRepo.On("EraseUser", mock.Anything, int64(11), "sita@school.local", mock.AnythingOfType("int64")).Return(nil).Once()
+
+		assert.NoError(t, u.EraseUser(adminCtx, 11))
+		mockPrivacyRepo.AssertExpectations(t)
+		mockAttachmentStorage.AssertExpectations(t)
+	})
+	t.Run("file-not-deleted", func(t *testing.T) {
+		mockPrivacyRepo := new(mocks.PrivacyRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPrivacyUseCase(mockPrivacyRepo, mockUserRepo, mockRoleRepo, mockTeamRepo, mockAttachmentStorage, time.Second*2)
+		mockUserRepo.On("GetByID", mock.Anything, int64(11)).Return(&domain.User{ID: 11, Email: "sita@school.local", RoleID: 4}, nil).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, int64(4)).Return(&domain.Role{ID: 4, Code: domain.RoleLearner}, nil).Once()
+		mockPrivacyRepo.On("GetSubmissions", mock.Anything, int64(11)).
+			Return([]domain.Submission{{ID: 5, Files: []domain.SubmissionFile{{Name: "a1.pdf"}}}}, nil).Once()
+		mockAttachmentStorage.On("DeleteAttachment", mock.Anything, "a1.pdf").Return(errors.New("permission denied")).Once()
+
+		assert.Error(t, u.EraseUser(adminCtx, 11))
+		mockPrivacyRepo.AssertNotCalled(t, "EraseUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("superadmin", func(t *testing.T) {
+		mockPrivacyRepo := new(mocks.PrivacyRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPrivacyUseCase(mockPrivacyRepo, mockUserRepo, mockRoleRepo, mockTeamRepo, mockAttachmentStorage, time.Second*2)
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, RoleID: 1}, nil).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, int64(1)).
+			Return(&domain.Role{ID: 1, Code: domain.RoleSuperAdmin, Permissions: []domain.Permission{domain.PermOrganizationManage}}, nil).Once()
+
+		assert.Equal(t, domain.ErrForbidden, u.EraseUser(adminCtx, 1))
+		mockPrivacyRepo.AssertNotCalled(t, "EraseUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("role-above-caller", func(t *testing.T) {
+		mockPrivacyRepo := new(mocks.PrivacyRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockAttachmentStorage := new(mocks.AttachmentStorage)
+		u := ucase.NewPrivacyUseCase(mockPrivacyRepo, mockUserRepo, mockRoleRepo, mockTeamRepo, mockAttachmentStorage, time.Second*2)
+		mockUserRepo.On("GetByID", mock.Anything, int64(2)).Return(&domain.User{ID: 2, RoleID: 2}, nil).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, int64(2)).
+			Return(&domain.Role{ID: 2, Code: domain.RoleAdmin, Permissions: []domain.Permission{domain.PermUserManage, domain.PermRoleManage}}, nil).Once()
+
+		assert.Equal(t, domain.ErrForbidden, u.EraseUser(adminCtx, 2))
+		mockPrivacyRepo.AssertNotCalled(t, "EraseUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
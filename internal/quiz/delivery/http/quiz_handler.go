@@ -0,0 +1,580 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// QuizHandler ...
+type QuizHandler struct {
+	QuizUseCase domain.QuizUseCase
+}
+
+// NewQuizHandler ...
+func NewQuizHandler(e *echo.Echo, us domain.QuizUseCase) {
+	handler := &QuizHandler{
+		QuizUseCase: us,
+	}
+	// Question bank
+	e.GET("/courses/:id/questions", handler.GetQuestions, rbac.Require(domain.PermCourseUpdate))
+	e.POST("/courses/:id/questions", handler.CreateQuestion, rbac.Require(domain.PermCourseUpdate))
+	e.GET("/questions/:id", handler.GetQuestion, rbac.Require(domain.PermCourseUpdate))
+	e.PUT("/questions/:id", handler.UpdateQuestion, rbac.Require(domain.PermCourseUpdate))
+	e.DELETE("/questions/:id", handler.DeleteQuestion, rbac.Require(domain.PermCourseUpdate))
+
+	// Quizzes
+	e.GET("/lessons/:id/quizzes", handler.GetByLesson, rbac.Require(domain.PermCourseView))
+	e.GET("/quizzes/:id", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.POST("/lessons/:id/quizzes", handler.CreateQuiz, rbac.Require(domain.PermCourseUpdate))
+	e.PUT("/quizzes/:id", handler.UpdateQuiz, rbac.Require(domain.PermCourseUpdate))
+	e.DELETE("/quizzes/:id", handler.DeleteQuiz, rbac.Require(domain.PermCourseUpdate))
+	e.GET("/quizzes/:id/results", handler.GetResults, rbac.Require(domain.PermCourseUpdate))
+
+	// Attempts
+	e.POST("/quizzes/:id/attempts", handler.StartAttempt, rbac.Require(domain.PermCourseView))
+	e.GET("/quizzes/:id/attempts", handler.GetAttempts, rbac.Require(domain.PermCourseView))
+	e.GET("/quiz-attempts/:id", handler.GetAttempt, rbac.Require(domain.PermCourseView))
+	e.POST("/quiz-attempts/:id/submit", handler.SubmitAttempt, rbac.Require(domain.PermCourseView))
+	e.POST("/quiz-attempts/:id/answers/:question_id/grade", handler.GradeAnswer, rbac.Require(domain.PermCourseUpdate))
+}
+
+// startLimit parses the start and limit query params, 0 and 10 by default
+func startLimit(echoContext echo.Context) (start int, limit int, err error) {
+	start, limit = 0, 10
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// GetQuestions godoc
+// @Summary Get the question bank of a course.
+// @Description Get the questions of a course with their keys, to build its quizzes. The tag only returns the questions of a pool.
+// @Tags quizzes
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Param tag query int false "Tag Id"
+// @Success 200 {object} domain.Summaries
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/questions [get]
+func (c *QuizHandler) GetQuestions(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	start, limit, err := startLimit(echoContext)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	var tagID int
+	if tag := echoContext.QueryParam("tag"); tag != "" {
+		if tagID, err = strconv.Atoi(tag); err != nil {
+			return echoContext.JSON(http.StatusBadRequest, ResponseError{Message: domain.ErrBadParamInput.Error()})
+		}
+	}
+	list, err := c.QuizUseCase.GetQuestions(ctx, int64(idParam), int64(tagID), start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetQuestion godoc
+// @Summary Get a question of a question bank.
+// @Description Get a question with its key.
+// @Tags quizzes
+// @Accept */*
+// @Produce json
+// @Param id path int true "Question Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /questions/{id} [get]
+func (c *QuizHandler) GetQuestion(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	question, err := c.QuizUseCase.GetQuestion(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: question, Message: domain.Success})
+}
+
+// CreateQuestion godoc
+// @Summary Add a question to the question bank of a course.
+// @Description Add a single-choice, multiple-choice, true-false, short-answer, numeric, matching or ordering question.
+// @Description The key holds the answer in the field of the question type: choices, truth, texts, number with tolerance,
+// @Description pairs or order. Options and matches are numbered from 1 in the given order.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param question body domain.Question true "question Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "The key does not match the question type"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/questions [post]
+func (c *QuizHandler) CreateQuestion(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var question domain.Question
+	err = echoContext.Bind(&question)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&question); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	question.CourseID = int64(idParam)
+	ctx := echoContext.Request().Context()
+	err = c.QuizUseCase.CreateQuestion(ctx, &question)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusCreated, domain.Response{Data: question, Message: domain.Success})
+}
+
+// UpdateQuestion godoc
+// @Summary Update a question of a question bank.
+// @Description Update a question. The quizzes using it are graded with the new key from then on.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Param id path int true "Question Id"
+// @Param question body domain.Question true "question Data"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError "The key does not match the question type"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /questions/{id} [put]
+func (c *QuizHandler) UpdateQuestion(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var question domain.Question
+	err = echoContext.Bind(&question)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&question); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.QuizUseCase.UpdateQuestion(ctx, &question, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// DeleteQuestion godoc
+// @Summary Delete a question of a question bank.
+// @Description Delete a question and remove it from the quizzes using it.
+// @Tags quizzes
+// @Accept */*
+// @Produce json
+// @Param id path int true "Question Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /questions/{id} [delete]
+func (c *QuizHandler) DeleteQuestion(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.QuizUseCase.DeleteQuestion(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// GetByLesson godoc
+// @Summary Get the quizzes of a lesson.
+// @Description Get the quizzes of a lesson with their settings.
+// @Tags quizzes
+// @Accept */*
+// @Produce json
+// @Param id path int true "Lesson Id"
+// @Success 200 {object} domain.Response
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /lessons/{id}/quizzes [get]
+func (c *QuizHandler) GetByLesson(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	list, err := c.QuizUseCase.GetByLesson(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: list, Message: domain.Success})
+}
+
+// GetByID godoc
+// @Summary Get quiz by ID.
+// @Description Get a quiz. Its questions and their keys are only returned to the ones working on the course.
+// @Tags quizzes
+// @Accept */*
+// @Produce json
+// @Param id path int true "Quiz Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /quizzes/{id} [get]
+func (c *QuizHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	quiz, err := c.QuizUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: quiz, Message: domain.Success})
+}
+
+// CreateQuiz godoc
+// @Summary Add a quiz to a lesson.
+// @Description Add a quiz with questions of the question bank of the course, in order, and its settings:
+// @Description the time limit in seconds, the number of attempts, the passing score in percent and the shuffling.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Param id path int true "Lesson Id"
+// @Param quiz body domain.Quiz true "quiz Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "A question is not in the question bank of the course"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /lessons/{id}/quizzes [post]
+func (c *QuizHandler) CreateQuiz(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var quiz domain.Quiz
+	err = echoContext.Bind(&quiz)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&quiz); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	quiz.LessonID = int64(idParam)
+	ctx := echoContext.Request().Context()
+	err = c.QuizUseCase.CreateQuiz(ctx, &quiz)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusCreated, domain.Response{Data: quiz, Message: domain.Success})
+}
+
+// UpdateQuiz godoc
+// @Summary Update a quiz.
+// @Description Update the settings of a quiz and replace its questions.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Param id path int true "Quiz Id"
+// @Param quiz body domain.Quiz true "quiz Data"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError "A question is not in the question bank of the course"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /quizzes/{id} [put]
+func (c *QuizHandler) UpdateQuiz(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var quiz domain.Quiz
+	err = echoContext.Bind(&quiz)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&quiz); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.QuizUseCase.UpdateQuiz(ctx, &quiz, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// DeleteQuiz godoc
+// @Summary Delete a quiz.
+// @Description Delete a quiz with its attempts.
+// @Tags quizzes
+// @Accept */*
+// @Produce json
+// @Param id path int true "Quiz Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /quizzes/{id} [delete]
+func (c *QuizHandler) DeleteQuiz(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.QuizUseCase.DeleteQuiz(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// GetResults godoc
+// @Summary Get the results of a quiz.
+// @Description Get the attempts of every learner at a quiz with their scores, the latest first.
+// @Tags quizzes
+// @Accept */*
+// @Produce json
+// @Param id path int true "Quiz Id"
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /quizzes/{id}/results [get]
+func (c *QuizHandler) GetResults(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	start, limit, err := startLimit(echoContext)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	list, err := c.QuizUseCase.GetResults(ctx, int64(idParam), start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// StartAttempt godoc
+// @Summary Start an attempt at a quiz.
+// @Description Start an attempt at a quiz of a course the caller is enrolled in, and get its questions without their keys.
+// @Description The attempt in progress is returned instead when there is one.
+// @Tags quizzes
+// @Accept */*
+// @Produce json
+// @Param id path int true "Quiz Id"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "The quiz has no questions"
+// @Failure 403 {object} domain.APIResponseError "Not enrolled in the course"
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "No attempts left, or an attempt started at the same time"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /quizzes/{id}/attempts [post]
+func (c *QuizHandler) StartAttempt(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	attempt, err := c.QuizUseCase.StartAttempt(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusCreated, domain.Response{Data: attempt, Message: domain.Success})
+}
+
+// GetAttempts godoc
+// @Summary Get the caller's attempts at a quiz.
+// @Description Get the caller's attempts at a quiz with their scores, the first one first.
+// @Tags quizzes
+// @Accept */*
+// @Produce json
+// @Param id path int true "Quiz Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /quizzes/{id}/attempts [get]
+func (c *QuizHandler) GetAttempts(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	list, err := c.QuizUseCase.GetAttempts(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: list, Message: domain.Success})
+}
+
+// GetAttempt godoc
+// @Summary Get a quiz attempt.
+// @Description Get an attempt with its questions, answers and score, to the learner who made it and to the ones working on the course.
+// @Tags quizzes
+// @Accept */*
+// @Produce json
+// @Param id path int true "Attempt Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /quiz-attempts/{id} [get]
+func (c *QuizHandler) GetAttempt(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	attempt, err := c.QuizUseCase.GetAttempt(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: attempt, Message: domain.Success})
+}
+
+// SubmitAttempt godoc
+// @Summary Submit a quiz attempt.
+// @Description Submit the answers of the caller's attempt in progress and get it graded.
+// @Description Each answer holds the response in the field of the question type, referring to options by id.
+// @Description The answers to the questions marked with a rubric are left pending, and the attempt is grading until they are marked.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Param id path int true "Attempt Id"
+// @Param submission body domain.AttemptSubmission true "answers"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Already submitted, or the time limit is exceeded"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /quiz-attempts/{id}/submit [post]
+func (c *QuizHandler) SubmitAttempt(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var submission domain.AttemptSubmission
+	err = echoContext.Bind(&submission)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&submission); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	attempt, err := c.QuizUseCase.SubmitAttempt(ctx, int64(idParam), &submission)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: attempt, Message: domain.Success})
+}
+
+// GradeAnswer godoc
+// @Summary Mark an answer with a rubric.
+// @Description Mark the answer to a question of a submitted attempt with a level of each criterion of the rubric
+// @Description of the question. The attempt is graded once none of its answers is left to mark.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Param id path int true "Attempt Id"
+// @Param question_id path int true "Question Id"
+// @Param grade body domain.AnswerGrade true "scores"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "The question has no rubric, or the scores do not match it"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "The attempt is not submitted"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /quiz-attempts/{id}/answers/{question_id}/grade [post]
+func (c *QuizHandler) GradeAnswer(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	questionID, err := strconv.Atoi(echoContext.Param("question_id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var grade domain.AnswerGrade
+	err = echoContext.Bind(&grade)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&grade); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	attempt, err := c.QuizUseCase.GradeAnswer(ctx, int64(idParam), int64(questionID), &grade)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: attempt, Message: domain.Success})
+}
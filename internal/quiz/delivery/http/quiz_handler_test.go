@@ -0,0 +1,528 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	quizHTTP "github.com/meroedu/meroedu/internal/quiz/delivery/http"
+)
+
+const (
+	question = `{"type":"single-choice","text":"2 + 2?","points":1,"options":[{"text":"3"},{"text":"4"}],"key":{"choices":[2]}}`
+	quiz     = `{"title":"Arithmetic","time_limit":600,"max_attempts":2,"passing_score":50,"question_ids":[3],"pools":[{"tag_id":1,"count":2}]}`
+)
+
+func TestGetQuestions(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("GetQuestions", mock.Anything, int64(12), int64(1), 0, 10).Return([]domain.Question{{ID: 3, CourseID: 12}}, nil).Once()
+	mockUCase.On("GetQuestions", mock.Anything, int64(13), int64(0), 0, 10).Return(nil, domain.ErrForbidden).Once()
+
+	tests := []struct {
+		id    string
+		query string
+		code  int
+	}{
+		{"12", "tag=1", http.StatusOK},
+		{"13", "", http.StatusForbidden},
+		{"12", "tag=maths", http.StatusBadRequest},
+		{"12", "start=first", http.StatusInternalServerError},
+		{"go", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/courses/"+tt.id+"/questions?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/courses/:id/questions")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.GetQuestions(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id+"?"+tt.query)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetQuestion(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("GetQuestion", mock.Anything, int64(3)).Return(&domain.Question{ID: 3, Type: domain.QuestionSingleChoice}, nil).Once()
+	mockUCase.On("GetQuestion", mock.Anything, int64(4)).Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"3", http.StatusOK},
+		{"4", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/questions/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/questions/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.GetQuestion(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateQuestion(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("CreateQuestion", mock.Anything, mock.MatchedBy(func(q *domain.Question) bool { return q.CourseID == 12 && q.Type == domain.QuestionSingleChoice })).Return(nil).Once()
+	mockUCase.On("CreateQuestion", mock.Anything, mock.MatchedBy(func(q *domain.Question) bool { return q.Type == "essay" })).Return(domain.ErrBadParamInput).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{question, http.StatusCreated},
+		{strings.Replace(question, "single-choice", "essay", 1), http.StatusBadRequest},
+		{`{"type":"single-choice","text":"2 + 2?","points":0}`, http.StatusBadRequest},
+		{`{"type":"single-choice","text":"2 + 2?","points":1,"options":[{"text":""}]}`, http.StatusBadRequest},
+		{`{"type":"single-choice","points":"one"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/courses/12/questions", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/courses/:id/questions")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.CreateQuestion(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdateQuestion(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("UpdateQuestion", mock.Anything, mock.AnythingOfType("*domain.Question"), int64(3)).Return(nil).Once()
+	mockUCase.On("UpdateQuestion", mock.Anything, mock.AnythingOfType("*domain.Question"), int64(4)).Return(domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		body string
+		code int
+	}{
+		{"3", question, http.StatusNoContent},
+		{"4", question, http.StatusNotFound},
+		{"3", `{"text":"2 + 2?"}`, http.StatusBadRequest},
+		{"sum", question, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/questions/"+tt.id, strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/questions/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.UpdateQuestion(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteQuestion(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("DeleteQuestion", mock.Anything, int64(3)).Return(domain.ErrConflict).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/questions/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/questions/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := quizHTTP.QuizHandler{
+		QuizUseCase: mockUCase,
+	}
+	err = handler.DeleteQuestion(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByLesson(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("GetByLesson", mock.Anything, int64(4)).Return([]domain.Quiz{{ID: 8, LessonID: 4, Title: "Arithmetic"}}, nil).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"4", http.StatusOK},
+		{"intro", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/lessons/"+tt.id+"/quizzes", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/lessons/:id/quizzes")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.GetByLesson(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("GetByID", mock.Anything, int64(8)).Return(&domain.Quiz{ID: 8, Title: "Arithmetic"}, nil).Once()
+	mockUCase.On("GetByID", mock.Anything, int64(9)).Return(nil, domain.ErrCourseNotPublished).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"8", http.StatusOK},
+		{"9", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/quizzes/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/quizzes/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.GetByID(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateQuiz(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("CreateQuiz", mock.Anything, mock.MatchedBy(func(q *domain.Quiz) bool { return q.LessonID == 4 && q.Title == "Arithmetic" })).Return(nil).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{quiz, http.StatusCreated},
+		{`{"title":"Arithmetic","passing_score":150}`, http.StatusBadRequest},
+		{`{"title":"Arithmetic","pools":[{"tag_id":1,"count":0}]}`, http.StatusBadRequest},
+		{`{"description":"Sums"}`, http.StatusBadRequest},
+		{`{"title":"Arithmetic","question_ids":"3"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/lessons/4/quizzes", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/lessons/:id/quizzes")
+		c.SetParamNames("id")
+		c.SetParamValues("4")
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.CreateQuiz(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdateQuiz(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("UpdateQuiz", mock.Anything, mock.AnythingOfType("*domain.Quiz"), int64(8)).Return(nil).Once()
+	mockUCase.On("UpdateQuiz", mock.Anything, mock.AnythingOfType("*domain.Quiz"), int64(9)).Return(domain.ErrForbidden).Once()
+
+	tests := []struct {
+		id   string
+		body string
+		code int
+	}{
+		{"8", quiz, http.StatusNoContent},
+		{"9", quiz, http.StatusForbidden},
+		{"8", `{"title":""}`, http.StatusBadRequest},
+		{"8", `{"title":`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/quizzes/"+tt.id, strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/quizzes/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.UpdateQuiz(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteQuiz(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("DeleteQuiz", mock.Anything, int64(8)).Return(nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/quizzes/8", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/quizzes/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("8")
+	handler := quizHTTP.QuizHandler{
+		QuizUseCase: mockUCase,
+	}
+	err = handler.DeleteQuiz(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetResults(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("GetResults", mock.Anything, int64(8), 10, 10).Return([]domain.QuizAttempt{{ID: 21, QuizID: 8}}, nil).Once()
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"start=10", http.StatusOK},
+		{"limit=all", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/quizzes/8/results?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/quizzes/:id/results")
+		c.SetParamNames("id")
+		c.SetParamValues("8")
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.GetResults(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.query)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStartAttempt(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("StartAttempt", mock.Anything, int64(8)).Return(&domain.QuizAttempt{ID: 21, QuizID: 8, Status: domain.AttemptInProgress}, nil).Once()
+	mockUCase.On("StartAttempt", mock.Anything, int64(9)).Return(nil, domain.ErrNoAttemptsLeft).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"8", http.StatusCreated},
+		{"9", http.StatusConflict},
+		{"arithmetic", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/quizzes/"+tt.id+"/attempts", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/quizzes/:id/attempts")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.StartAttempt(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetAttempts(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("GetAttempts", mock.Anything, int64(8)).Return([]domain.QuizAttempt{{ID: 21, QuizID: 8}}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/quizzes/8/attempts", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/quizzes/:id/attempts")
+	c.SetParamNames("id")
+	c.SetParamValues("8")
+	handler := quizHTTP.QuizHandler{
+		QuizUseCase: mockUCase,
+	}
+	err = handler.GetAttempts(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetAttempt(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("GetAttempt", mock.Anything, int64(21)).Return(nil, domain.ErrNotFound).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/quiz-attempts/21", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/quiz-attempts/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("21")
+	handler := quizHTTP.QuizHandler{
+		QuizUseCase: mockUCase,
+	}
+	err = handler.GetAttempt(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSubmitAttempt(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("SubmitAttempt", mock.Anything, int64(21), &domain.AttemptSubmission{Answers: []domain.QuestionResponse{{QuestionID: 3, Choices: []int{2}}}}).
+		Return(&domain.QuizAttempt{ID: 21, Status: domain.AttemptSubmitted, Passed: true}, nil).Once()
+	mockUCase.On("SubmitAttempt", mock.Anything, int64(22), mock.AnythingOfType("*domain.AttemptSubmission")).Return(nil, domain.ErrTimeLimitExceeded).Once()
+
+	tests := []struct {
+		id   string
+		body string
+		code int
+	}{
+		{"21", `{"answers":[{"question_id":3,"choices":[2]}]}`, http.StatusOK},
+		{"22", `{"answers":[]}`, http.StatusConflict},
+		{"21", `{"answers":[{"choices":[2]}]}`, http.StatusBadRequest},
+		{"21", `{"answers":{"3":[2]}}`, http.StatusUnprocessableEntity},
+		{"last", `{"answers":[]}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/quiz-attempts/"+tt.id+"/submit", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/quiz-attempts/:id/submit")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.SubmitAttempt(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGradeAnswer(t *testing.T) {
+	mockUCase := new(mocks.QuizUseCase)
+	mockUCase.On("GradeAnswer", mock.Anything, int64(21), int64(3), &domain.AnswerGrade{Scores: []domain.CriterionScore{{CriterionID: 1, LevelID: 2}}}).
+		Return(&domain.QuizAttempt{ID: 21, Status: domain.AttemptSubmitted}, nil).Once()
+	mockUCase.On("GradeAnswer", mock.Anything, int64(21), int64(4), mock.AnythingOfType("*domain.AnswerGrade")).Return(nil, domain.ErrBadParamInput).Once()
+
+	tests := []struct {
+		questionID string
+		body       string
+		code       int
+	}{
+		{"3", `{"scores":[{"criterion_id":1,"level_id":2}]}`, http.StatusOK},
+		{"4", `{"scores":[{"criterion_id":1,"level_id":2}]}`, http.StatusBadRequest},
+		{"3", `{"scores":[]}`, http.StatusBadRequest},
+		{"3", `{"scores":[{"criterion_id":"argument"}]}`, http.StatusUnprocessableEntity},
+		{"first", `{"scores":[{"criterion_id":1,"level_id":2}]}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/quiz-attempts/21/answers/"+tt.questionID+"/grade", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/quiz-attempts/:id/answers/:question_id/grade")
+		c.SetParamNames("id", "question_id")
+		c.SetParamValues("21", tt.questionID)
+		handler := quizHTTP.QuizHandler{
+			QuizUseCase: mockUCase,
+		}
+		err = handler.GradeAnswer(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.questionID)
+	}
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,659 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/repository/mysqlerr"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const questionQuery = `SELECT q.id,q.course_id,q.type,q.text,q.points,q.difficulty,q.options,q.matches,q.answer_key,q.explanation,q.rubric_id,
+	q.created_by,q.updated_at,q.created_at FROM questions q JOIN courses c ON c.id = q.course_id`
+
+const quizQuery = `SELECT z.id,z.lesson_id,l.course_id,z.title,z.description,z.time_limit,z.max_attempts,z.passing_score,
+	z.shuffle_questions,z.shuffle_options,(SELECT GROUP_CONCAT(qq.question_id ORDER BY qq.position) FROM quizzes_questions qq
+	WHERE qq.quiz_id = z.id),z.created_by,z.updated_at,z.created_at FROM quizzes z JOIN lessons l ON l.id = z.lesson_id
+	JOIN courses c ON c.id = l.course_id`
+
+const attemptQuery = `SELECT a.id,a.quiz_id,a.user_id,a.number,a.status,a.seed,a.question_ids,a.answers,a.score,a.max_score,a.passed,a.started_at,
+	a.expires_at,a.submitted_at FROM quiz_attempts a JOIN quizzes z ON z.id = a.quiz_id JOIN lessons l ON l.id = z.lesson_id
+	JOIN courses c ON c.id = l.course_id`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the quiz's Repository interface
+func Init(db *sql.DB) domain.QuizRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func nullInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: i, Valid: i != 0}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// joinIDs returns the ids as stored, comma separated
+func joinIDs(ids []int64) sql.NullString {
+	list := make([]string, len(ids))
+	for i, id := range ids {
+		list[i] = strconv.FormatInt(id, 10)
+	}
+	return nullString(strings.Join(list, ","))
+}
+
+// splitIDs parses comma separated ids
+func splitIDs(s string) ([]int64, error) {
+	ids := make([]int64, 0)
+	if s == "" {
+		return ids, nil
+	}
+	for _, v := range strings.Split(s, ",") {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// in returns the placeholders and the arguments of an IN list of ids
+func in(ids []int64) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return `(?` + strings.Repeat(`,?`, len(ids)-1) + `)`, args
+}
+
+func (m *mysqlRepository) fetchQuestions(ctx context.Context, query string, args ...interface{}) (result []domain.Question, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Question, 0)
+	for rows.Next() {
+		t := domain.Question{}
+		var options, matches, key string
+		var explanation sql.NullString
+		var rubricID, createdBy sql.NullInt64
+		err = rows.Scan(
+			&t.ID,
+			&t.CourseID,
+			&t.Type,
+			&t.Text,
+			&t.Points,
+			&t.Difficulty,
+			&options,
+			&matches,
+			&key,
+			&explanation,
+			&rubricID,
+			&createdBy,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.Explanation = explanation.String
+		t.RubricID = rubricID.Int64
+		t.CreatedBy = createdBy.Int64
+		t.Key = &domain.AnswerKey{}
+		if err = json.Unmarshal([]byte(options), &t.Options); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(matches), &t.Matches); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(key), t.Key); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// marshalQuestion returns the options, the matches and the key of a question as stored
+func marshalQuestion(q *domain.Question) (options, matches, key []byte, err error) {
+	if options, err = json.Marshal(q.Options); err != nil {
+		return
+	}
+	if matches, err = json.Marshal(q.Matches); err != nil {
+		return
+	}
+	k := q.Key
+	if k == nil {
+		k = &domain.AnswerKey{}
+	}
+	key, err = json.Marshal(k)
+	return
+}
+
+// GetQuestions returns the question bank of a course, or only its questions with a tag when tagID is not 0
+func (m *mysqlRepository) GetQuestions(ctx context.Context, courseID int64, tagID int64, start int, limit int) ([]domain.Question, error) {
+	query := questionQuery + ` WHERE q.course_id = ? AND c.organization_id = ? AND c.deleted_at IS NULL`
+	args := []interface{}{courseID, domain.OrganizationIDFromContext(ctx)}
+	if tagID != 0 {
+		query += ` AND EXISTS (SELECT 1 FROM questions_tags qt WHERE qt.question_id = q.id AND qt.tag_id = ?)`
+		args = append(args, tagID)
+	}
+	query += ` ORDER BY q.id LIMIT ?,?`
+	return m.fetchQuestions(ctx, query, append(args, start, limit)...)
+}
+
+func (m *mysqlRepository) GetQuestion(ctx context.Context, id int64) (*domain.Question, error) {
+	query := questionQuery + ` WHERE q.id = ? AND c.organization_id = ? AND c.deleted_at IS NULL`
+	list, err := m.fetchQuestions(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	question := &list[0]
+	if question.Tags, err = m.getTags(ctx, id); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (m *mysqlRepository) getTags(ctx context.Context, questionID int64) (result []domain.Tag, err error) {
+	query := `SELECT t.id,t.name,t.updated_at,t.created_at FROM tags t JOIN questions_tags qt ON qt.tag_id = t.id
+		WHERE qt.question_id = ? ORDER BY t.name`
+	rows, err := m.conn.QueryContext(ctx, query, questionID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Tag, 0)
+	for rows.Next() {
+		t := domain.Tag{}
+		if err = rows.Scan(&t.ID, &t.Name, &t.UpdatedAt, &t.CreatedAt); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// GetPoolQuestions returns the questions of the question bank of a course with a tag, in the order they are drawn from
+func (m *mysqlRepository) GetPoolQuestions(ctx context.Context, courseID int64, tagID int64) ([]domain.Question, error) {
+	query := questionQuery + ` JOIN questions_tags qt ON qt.question_id = q.id
+		WHERE q.course_id = ? AND qt.tag_id = ? AND c.organization_id = ? AND c.deleted_at IS NULL ORDER BY q.id`
+	return m.fetchQuestions(ctx, query, courseID, tagID, domain.OrganizationIDFromContext(ctx))
+}
+
+// GetQuestionsByIDs returns the questions of the list which are in the question bank of the course
+func (m *mysqlRepository) GetQuestionsByIDs(ctx context.Context, courseID int64, ids []int64) ([]domain.Question, error) {
+	if len(ids) == 0 {
+		return make([]domain.Question, 0), nil
+	}
+	list, args := in(ids)
+	query := questionQuery + ` WHERE q.course_id = ? AND c.organization_id = ? AND c.deleted_at IS NULL AND q.id IN ` + list
+	return m.fetchQuestions(ctx, query, append([]interface{}{courseID, domain.OrganizationIDFromContext(ctx)}, args...)...)
+}
+
+// CreateQuestion adds the question to the question bank of a course of the caller's organization
+func (m *mysqlRepository) CreateQuestion(ctx context.Context, q *domain.Question) error {
+	options, matches, key, err := marshalQuestion(q)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO questions (course_id,type,text,points,difficulty,options,matches,answer_key,explanation,rubric_id,created_by,
+		updated_at,created_at) SELECT id,?,?,?,?,?,?,?,?,?,?,?,? FROM courses WHERE id = ? AND organization_id = ? AND deleted_at IS NULL`
+	res, err := m.conn.ExecContext(ctx, query, q.Type, q.Text, q.Points, q.Difficulty, options, matches, key, nullString(q.Explanation),
+		nullInt64(q.RubricID), nullInt64(q.CreatedBy), q.UpdatedAt, q.CreatedAt, q.CourseID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	q.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+	}
+	return err
+}
+
+func (m *mysqlRepository) UpdateQuestion(ctx context.Context, q *domain.Question) error {
+	options, matches, key, err := marshalQuestion(q)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE questions q JOIN courses c ON c.id = q.course_id SET q.type=?,q.text=?,q.points=?,q.difficulty=?,q.options=?,
+		q.matches=?,q.answer_key=?,q.explanation=?,q.rubric_id=?,q.updated_at=? WHERE q.id = ? AND c.organization_id = ?`
+	_, err = m.conn.ExecContext(ctx, query, q.Type, q.Text, q.Points, q.Difficulty, options, matches, key, nullString(q.Explanation),
+		nullInt64(q.RubricID), q.UpdatedAt, q.ID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+	}
+	return err
+}
+
+// DeleteQuestion removes the question from the question bank and from the quizzes using it
+func (m *mysqlRepository) DeleteQuestion(ctx context.Context, id int64) error {
+	query := `DELETE q FROM questions q JOIN courses c ON c.id = q.course_id WHERE q.id = ? AND c.organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mysqlRepository) fetchQuizzes(ctx context.Context, query string, args ...interface{}) (result []domain.Quiz, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Quiz, 0)
+	for rows.Next() {
+		t := domain.Quiz{}
+		var description, questionIDs sql.NullString
+		var createdBy sql.NullInt64
+		err = rows.Scan(
+			&t.ID,
+			&t.LessonID,
+			&t.CourseID,
+			&t.Title,
+			&description,
+			&t.TimeLimit,
+			&t.MaxAttempts,
+			&t.PassingScore,
+			&t.ShuffleQuestions,
+			&t.ShuffleOptions,
+			&questionIDs,
+			&createdBy,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.Description = description.String
+		t.CreatedBy = createdBy.Int64
+		if t.QuestionIDs, err = splitIDs(questionIDs.String); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	if err = rows.Close(); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		if result[i].Pools, err = m.getPools(ctx, result[i].ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) getPools(ctx context.Context, quizID int64) (result []domain.QuizPool, err error) {
+	query := `SELECT tag_id,count,easy_weight,medium_weight,hard_weight FROM quizzes_pools WHERE quiz_id = ? ORDER BY position`
+	rows, err := m.conn.QueryContext(ctx, query, quizID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.QuizPool, 0)
+	for rows.Next() {
+		t := domain.QuizPool{}
+		if err = rows.Scan(&t.TagID, &t.Count, &t.EasyWeight, &t.MediumWeight, &t.HardWeight); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+func (m *mysqlRepository) GetByLesson(ctx context.Context, lessonID int64) ([]domain.Quiz, error) {
+	query := quizQuery + ` WHERE z.lesson_id = ? AND c.organization_id = ? AND l.deleted_at IS NULL AND c.deleted_at IS NULL ORDER BY z.id`
+	return m.fetchQuizzes(ctx, query, lessonID, domain.OrganizationIDFromContext(ctx))
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.Quiz, error) {
+	query := quizQuery + ` WHERE z.id = ? AND c.organization_id = ? AND l.deleted_at IS NULL AND c.deleted_at IS NULL`
+	list, err := m.fetchQuizzes(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// GetQuizQuestions returns the questions of a quiz, in order
+func (m *mysqlRepository) GetQuizQuestions(ctx context.Context, quizID int64) ([]domain.Question, error) {
+	query := questionQuery + ` JOIN quizzes_questions qq ON qq.question_id = q.id WHERE qq.quiz_id = ? AND c.organization_id = ?
+		ORDER BY qq.position`
+	return m.fetchQuestions(ctx, query, quizID, domain.OrganizationIDFromContext(ctx))
+}
+
+// setQuestions replaces the questions and the pools of a quiz
+func setQuestions(ctx context.Context, tx *sql.Tx, quiz *domain.Quiz) (err error) {
+	if _, err = tx.ExecContext(ctx, `DELETE FROM quizzes_questions WHERE quiz_id = ?`, quiz.ID); err != nil {
+		log.Error(err)
+		return
+	}
+	query := `INSERT quizzes_questions SET quiz_id=?,question_id=?,position=?`
+	for i, id := range quiz.QuestionIDs {
+		if _, err = tx.ExecContext(ctx, query, quiz.ID, id, i+1); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM quizzes_pools WHERE quiz_id = ?`, quiz.ID); err != nil {
+		log.Error(err)
+		return
+	}
+	query = `INSERT quizzes_pools SET quiz_id=?,tag_id=?,position=?,count=?,easy_weight=?,medium_weight=?,hard_weight=?`
+	for i, p := range quiz.Pools {
+		if _, err = tx.ExecContext(ctx, query, quiz.ID, p.TagID, i+1, p.Count, p.EasyWeight, p.MediumWeight, p.HardWeight); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+	return
+}
+
+// CreateQuiz adds the quiz to a lesson of the caller's organization with its questions
+func (m *mysqlRepository) CreateQuiz(ctx context.Context, quiz *domain.Quiz) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `INSERT INTO quizzes (lesson_id,title,description,time_limit,max_attempts,passing_score,shuffle_questions,shuffle_options,
+		created_by,updated_at,created_at) SELECT l.id,?,?,?,?,?,?,?,?,?,? FROM lessons l JOIN courses c ON c.id = l.course_id
+		WHERE l.id = ? AND c.organization_id = ? AND l.deleted_at IS NULL AND c.deleted_at IS NULL`
+	res, err := tx.ExecContext(ctx, query, quiz.Title, nullString(quiz.Description), quiz.TimeLimit, quiz.MaxAttempts, quiz.PassingScore,
+		quiz.ShuffleQuestions, quiz.ShuffleOptions, nullInt64(quiz.CreatedBy), quiz.UpdatedAt, quiz.CreatedAt, quiz.LessonID,
+		domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	if quiz.ID, err = res.LastInsertId(); err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return
+	}
+	return setQuestions(ctx, tx, quiz)
+}
+
+// UpdateQuiz updates the settings of a quiz and replaces its questions
+func (m *mysqlRepository) UpdateQuiz(ctx context.Context, quiz *domain.Quiz) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `UPDATE quizzes z JOIN lessons l ON l.id = z.lesson_id JOIN courses c ON c.id = l.course_id SET z.title=?,z.description=?,
+		z.time_limit=?,z.max_attempts=?,z.passing_score=?,z.shuffle_questions=?,z.shuffle_options=?,z.updated_at=?
+		WHERE z.id = ? AND c.organization_id = ?`
+	_, err = tx.ExecContext(ctx, query, quiz.Title, nullString(quiz.Description), quiz.TimeLimit, quiz.MaxAttempts, quiz.PassingScore,
+		quiz.ShuffleQuestions, quiz.ShuffleOptions, quiz.UpdatedAt, quiz.ID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	return setQuestions(ctx, tx, quiz)
+}
+
+// DeleteQuiz removes the quiz with its attempts
+func (m *mysqlRepository) DeleteQuiz(ctx context.Context, id int64) error {
+	query := `DELETE z FROM quizzes z JOIN lessons l ON l.id = z.lesson_id JOIN courses c ON c.id = l.course_id
+		WHERE z.id = ? AND c.organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mysqlRepository) fetchAttempts(ctx context.Context, query string, args ...interface{}) (result []domain.QuizAttempt, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.QuizAttempt, 0)
+	for rows.Next() {
+		t := domain.QuizAttempt{}
+		var questionIDs, answers sql.NullString
+		var expiresAt, submittedAt sql.NullInt64
+		err = rows.Scan(
+			&t.ID,
+			&t.QuizID,
+			&t.UserID,
+			&t.Number,
+			&t.Status,
+			&t.Seed,
+			&questionIDs,
+			&answers,
+			&t.Score,
+			&t.MaxScore,
+			&t.Passed,
+			&t.StartedAt,
+			&expiresAt,
+			&submittedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.ExpiresAt = expiresAt.Int64
+		t.SubmittedAt = submittedAt.Int64
+		if t.QuestionIDs, err = splitIDs(questionIDs.String); err != nil {
+			return nil, err
+		}
+		if answers.String != "" {
+			if err = json.Unmarshal([]byte(answers.String), &t.Answers); err != nil {
+				return nil, err
+			}
+		}
+		if t.MaxScore > 0 {
+			t.Percentage = t.Score * 100 / t.MaxScore
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) CreateAttempt(ctx context.Context, a *domain.QuizAttempt) error {
+	query := `INSERT quiz_attempts SET quiz_id=?,user_id=?,number=?,status=?,seed=?,question_ids=?,max_score=?,started_at=?,expires_at=?`
+	res, err := m.conn.ExecContext(ctx, query, a.QuizID, a.UserID, a.Number, a.Status, a.Seed, joinIDs(a.QuestionIDs), a.MaxScore,
+		a.StartedAt, nullInt64(a.ExpiresAt))
+	if mysqlerr.IsDuplicate(err) {
+		return domain.ErrConflict
+	}
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	a.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+	}
+	return err
+}
+
+func (m *mysqlRepository) GetAttempt(ctx context.Context, id int64) (*domain.QuizAttempt, error) {
+	query := attemptQuery + ` WHERE a.id = ? AND c.organization_id = ?`
+	list, err := m.fetchAttempts(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// GetAttempts returns the attempts of a user at a quiz, the first one first
+func (m *mysqlRepository) GetAttempts(ctx context.Context, quizID int64, userID int64) ([]domain.QuizAttempt, error) {
+	query := attemptQuery + ` WHERE a.quiz_id = ? AND a.user_id = ? AND c.organization_id = ? ORDER BY a.id`
+	return m.fetchAttempts(ctx, query, quizID, userID, domain.OrganizationIDFromContext(ctx))
+}
+
+// GetResults returns the attempts of every learner at a quiz, the latest first
+func (m *mysqlRepository) GetResults(ctx context.Context, quizID int64, start int, limit int) ([]domain.QuizAttempt, error) {
+	query := attemptQuery + ` WHERE a.quiz_id = ? AND c.organization_id = ? ORDER BY a.id DESC LIMIT ?,?`
+	return m.fetchAttempts(ctx, query, quizID, domain.OrganizationIDFromContext(ctx), start, limit)
+}
+
+// FinishAttempt records the answers and the score of an attempt in progress. It returns ErrConflict when the
+// attempt is already finished.
+func (m *mysqlRepository) FinishAttempt(ctx context.Context, a *domain.QuizAttempt) error {
+	answers, err := json.Marshal(a.Answers)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE quiz_attempts SET status=?,answers=?,score=?,max_score=?,passed=?,submitted_at=? WHERE id = ? AND status = ?`
+	res, err := m.conn.ExecContext(ctx, query, a.Status, answers, a.Score, a.MaxScore, a.Passed, nullInt64(a.SubmittedAt), a.ID,
+		domain.AttemptInProgress)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+// GradeAttempt records the answers and the score of a submitted attempt marked again. It returns ErrConflict when
+// the attempt is not submitted.
+func (m *mysqlRepository) GradeAttempt(ctx context.Context, a *domain.QuizAttempt) error {
+	answers, err := json.Marshal(a.Answers)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE quiz_attempts SET status=?,answers=?,score=?,passed=? WHERE id = ? AND status IN (?,?)`
+	res, err := m.conn.ExecContext(ctx, query, a.Status, answers, a.Score, a.Passed, a.ID, domain.AttemptGrading, domain.AttemptSubmitted)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
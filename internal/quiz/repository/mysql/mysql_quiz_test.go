@@ -0,0 +1,276 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/quiz/repository/mysql"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+var questionColumns = []string{"id", "course_id", "type", "text", "points", "difficulty", "options", "matches", "answer_key",
+	"explanation", "rubric_id", "created_by", "updated_at", "created_at"}
+
+func TestGetQuestion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM questions q JOIN courses c ON c.id = q.course_id WHERE q.id = \? AND c.organization_id = \?`).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows(questionColumns).AddRow(1, 3, "single-choice", "Capital of Nepal?", 1.5, 1,
+			`[{"id":1,"text":"Pokhara"},{"id":2,"text":"Kathmandu"}]`, `null`, `{"choices":[2]}`, nil, nil, 4, 100, 100))
+	mock.ExpectQuery(`SELECT .+ FROM tags t JOIN questions_tags qt ON qt.tag_id = t.id\s+WHERE qt.question_id = \?`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"}).AddRow(7, "geography", 90, 90))
+
+	repo := mysqlrepo.Init(db)
+	question, err := repo.GetQuestion(orgCtx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Question{ID: 1, CourseID: 3, Type: domain.QuestionSingleChoice, Text: "Capital of Nepal?", Points: 1.5,
+		Difficulty: domain.DifficultyEasy, Tags: []domain.Tag{{ID: 7, Name: "geography", UpdatedAt: 90, CreatedAt: 90}},
+		Options: []domain.QuestionOption{{ID: 1, Text: "Pokhara"}, {ID: 2, Text: "Kathmandu"}}, Key: &domain.AnswerKey{Choices: []int{2}},
+		CreatedBy: 4, UpdatedAt: 100, CreatedAt: 100}, question)
+}
+
+func TestGetQuestions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`WHERE q.course_id = \? AND c.organization_id = \? AND c.deleted_at IS NULL AND EXISTS \(SELECT 1 FROM questions_tags qt WHERE qt.question_id = q.id AND qt.tag_id = \?\) ORDER BY q.id LIMIT \?,\?`).
+		WithArgs(3, 2, 7, 0, 10).
+		WillReturnRows(sqlmock.NewRows(questionColumns))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetQuestions(orgCtx, 3, 7, 0, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPoolQuestions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`JOIN questions_tags qt ON qt.question_id = q.id\s+WHERE q.course_id = \? AND qt.tag_id = \? AND c.organization_id = \? AND c.deleted_at IS NULL ORDER BY q.id`).
+		WithArgs(3, 7, 2).
+		WillReturnRows(sqlmock.NewRows(questionColumns).AddRow(1, 3, "true-false", "Everest is in Nepal.", 1, 3, `null`, `null`,
+			`{"truth":true}`, nil, nil, 4, 100, 100))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetPoolQuestions(orgCtx, 3, 7)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, domain.DifficultyHard, list[0].Difficulty)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetQuestionsByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`WHERE q.course_id = \? AND c.organization_id = \? AND c.deleted_at IS NULL AND q.id IN \(\?,\?\)`).
+		WithArgs(3, 2, 1, 9).
+		WillReturnRows(sqlmock.NewRows(questionColumns))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetQuestionsByIDs(orgCtx, 3, []int64{1, 9})
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateQuestion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	truth := true
+	question := &domain.Question{CourseID: 3, Type: domain.QuestionTrueFalse, Text: "Everest is in Nepal.", Points: 1, Difficulty: 2,
+		Key: &domain.AnswerKey{Truth: &truth}, CreatedBy: 4, UpdatedAt: 100, CreatedAt: 100}
+	mock.ExpectExec(`INSERT INTO questions .+ SELECT id,\?,\?,\?,\?,\?,\?,\?,\?,\?,\?,\?,\? FROM courses WHERE id = \? AND organization_id = \? AND deleted_at IS NULL`).
+		WithArgs("true-false", "Everest is in Nepal.", float64(1), 2, []byte("null"), []byte("null"), []byte(`{"truth":true}`), nil, nil,
+			4, 100, 100, 3, 2).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+
+	repo := mysqlrepo.Init(db)
+	assert.NoError(t, repo.CreateQuestion(orgCtx, question))
+	assert.Equal(t, int64(12), question.ID)
+}
+
+var poolColumns = []string{"tag_id", "count", "easy_weight", "medium_weight", "hard_weight"}
+
+var quizColumns = []string{"id", "lesson_id", "course_id", "title", "description", "time_limit", "max_attempts", "passing_score",
+	"shuffle_questions", "shuffle_options", "question_ids", "created_by", "updated_at", "created_at"}
+
+func TestGetByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`SELECT .+ FROM quizzes z JOIN lessons l ON l.id = z.lesson_id\s+JOIN courses c ON c.id = l.course_id WHERE z.id = \?`).
+			WithArgs(5, 2).
+			WillReturnRows(sqlmock.NewRows(quizColumns).AddRow(5, 8, 3, "Geography", nil, 600, 2, 75, true, false, "7,1", 4, 100, 100))
+		mock.ExpectQuery(`SELECT tag_id,count,easy_weight,medium_weight,hard_weight FROM quizzes_pools WHERE quiz_id = \? ORDER BY position`).
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows(poolColumns).AddRow(9, 3, 1, 2, 1))
+
+		repo := mysqlrepo.Init(db)
+		quiz, err := repo.GetByID(orgCtx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Quiz{ID: 5, LessonID: 8, CourseID: 3, Title: "Geography", TimeLimit: 600, MaxAttempts: 2, PassingScore: 75,
+			ShuffleQuestions: true, QuestionIDs: []int64{7, 1}, Pools: []domain.QuizPool{{TagID: 9, Count: 3, EasyWeight: 1, MediumWeight: 2,
+				HardWeight: 1}}, CreatedBy: 4, UpdatedAt: 100, CreatedAt: 100}, quiz)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`FROM quizzes z`).WithArgs(5, 2).WillReturnRows(sqlmock.NewRows(quizColumns))
+
+		repo := mysqlrepo.Init(db)
+		_, err = repo.GetByID(orgCtx, 5)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestCreateQuiz(t *testing.T) {
+	query := `INSERT INTO quizzes .+ SELECT l.id,.+ FROM lessons l JOIN courses c ON c.id = l.course_id\s+WHERE l.id = \? AND c.organization_id = \?`
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs("Geography", nil, 600, 2, float64(75), true, false, 4, 100, 100, 8, 2).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec(`DELETE FROM quizzes_questions WHERE quiz_id = \?`).WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT quizzes_questions SET quiz_id=\?,question_id=\?,position=\?`).WithArgs(5, 7, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT quizzes_questions SET quiz_id=\?,question_id=\?,position=\?`).WithArgs(5, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM quizzes_pools WHERE quiz_id = \?`).WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT quizzes_pools SET quiz_id=\?,tag_id=\?,position=\?,count=\?,easy_weight=\?,medium_weight=\?,hard_weight=\?`).
+			WithArgs(5, 9, 1, 3, 0, 1, 0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		quiz := &domain.Quiz{LessonID: 8, Title: "Geography", TimeLimit: 600, MaxAttempts: 2, PassingScore: 75, ShuffleQuestions: true,
+			QuestionIDs: []int64{7, 1}, Pools: []domain.QuizPool{{TagID: 9, Count: 3, MediumWeight: 1}}, CreatedBy: 4, UpdatedAt: 100,
+			CreatedAt: 100}
+		assert.NoError(t, repo.CreateQuiz(orgCtx, quiz))
+		assert.Equal(t, int64(5), quiz.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("lesson-not-found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := mysqlrepo.Init(db)
+		assert.Equal(t, domain.ErrNotFound, repo.CreateQuiz(orgCtx, &domain.Quiz{LessonID: 8, Title: "Geography"}))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+var attemptColumns = []string{"id", "quiz_id", "user_id", "number", "status", "seed", "question_ids", "answers", "score", "max_score", "passed", "started_at",
+	"expires_at", "submitted_at"}
+
+func TestGetAttempts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`FROM quiz_attempts a JOIN quizzes z ON z.id = a.quiz_id .+ WHERE a.quiz_id = \? AND a.user_id = \? AND c.organization_id = \?`).
+		WithArgs(5, 6, 2).
+		WillReturnRows(sqlmock.NewRows(attemptColumns).
+			AddRow(1, 5, 6, 1, "submitted", 42, nil, `[{"question_id":1,"choices":[2],"score":1,"correct":true}]`, 1, 2, false, 100, nil, 160).
+			AddRow(2, 5, 6, 2, "in-progress", 43, "4,2,9", nil, 0, 2, false, 200, 800, nil))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetAttempts(orgCtx, 5, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.QuizAttempt{
+		{ID: 1, QuizID: 5, UserID: 6, Number: 1, Status: domain.AttemptSubmitted, Seed: 42, QuestionIDs: []int64{}, Score: 1, MaxScore: 2, Percentage: 50, StartedAt: 100,
+			SubmittedAt: 160, Answers: []domain.QuestionResponse{{QuestionID: 1, Choices: []int{2}, Score: 1, Correct: true}}},
+		{ID: 2, QuizID: 5, UserID: 6, Number: 2, Status: domain.AttemptInProgress, Seed: 43, QuestionIDs: []int64{4, 2, 9}, MaxScore: 2, StartedAt: 200, ExpiresAt: 800},
+	}, list)
+}
+
+func TestCreateAttempt(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectExec(`INSERT quiz_attempts SET quiz_id=\?,user_id=\?,number=\?,status=\?,seed=\?,question_ids=\?,max_score=\?,started_at=\?,expires_at=\?`).
+			WithArgs(5, 6, 2, "in-progress", 42, "4,2,9", float64(3), 100, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		repo := mysqlrepo.Init(db)
+		attempt := &domain.QuizAttempt{QuizID: 5, UserID: 6, Number: 2, Status: domain.AttemptInProgress, Seed: 42,
+			QuestionIDs: []int64{4, 2, 9}, MaxScore: 3, StartedAt: 100}
+		assert.NoError(t, repo.CreateAttempt(orgCtx, attempt))
+		assert.Equal(t, int64(1), attempt.ID)
+	})
+	t.Run("number-taken", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectExec(`INSERT quiz_attempts SET`).
+			WithArgs(5, 6, 2, "in-progress", 42, "4,2,9", float64(3), 100, nil).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '5-6-2' for key 'index_on_quiz_id_user_id_number'"})
+
+		repo := mysqlrepo.Init(db)
+		attempt := &domain.QuizAttempt{QuizID: 5, UserID: 6, Number: 2, Status: domain.AttemptInProgress, Seed: 42,
+			QuestionIDs: []int64{4, 2, 9}, MaxScore: 3, StartedAt: 100}
+		assert.Equal(t, domain.ErrConflict, repo.CreateAttempt(orgCtx, attempt))
+	})
+}
+
+func TestFinishAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`UPDATE quiz_attempts SET status=\?,answers=\?,score=\?,max_score=\?,passed=\?,submitted_at=\? WHERE id = \? AND status = \?`).
+		WithArgs("submitted", []byte(`[]`), float64(2), float64(2), true, 160, 1, "in-progress").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	attempt := &domain.QuizAttempt{ID: 1, Status: domain.AttemptSubmitted, Answers: []domain.QuestionResponse{}, Score: 2, MaxScore: 2,
+		Passed: true, SubmittedAt: 160}
+	assert.Equal(t, domain.ErrConflict, repo.FinishAttempt(orgCtx, attempt), "the attempt is already finished")
+}
+
+func TestGradeAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`UPDATE quiz_attempts SET status=\?,answers=\?,score=\?,passed=\? WHERE id = \? AND status IN \(\?,\?\)`).
+		WithArgs("submitted", []byte(`[]`), float64(2), true, 1, "grading", "submitted").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := mysqlrepo.Init(db)
+	attempt := &domain.QuizAttempt{ID: 1, Status: domain.AttemptSubmitted, Answers: []domain.QuestionResponse{}, Score: 2, MaxScore: 2,
+		Passed: true}
+	assert.NoError(t, repo.GradeAttempt(orgCtx, attempt))
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// numberOptions sets the ids of the options from their positions
+func numberOptions(options []domain.QuestionOption) {
+	for i := range options {
+		options[i].ID = i + 1
+	}
+}
+
+// validIDs reports whether the ids are distinct options of a list of n options
+func validIDs(ids []int, n int) bool {
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if id < 1 || id > n || seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
+
+// validateQuestion checks the options and the key of a question match its type. It numbers the options,
+// keeps only the parts of the key used by the type and makes the question medium by default. Only the
+// short-answer questions are marked with a rubric, and need no accepted answers then.
+func validateQuestion(q *domain.Question) error {
+	if !q.Type.IsValid() || q.Key == nil {
+		return domain.ErrBadParamInput
+	}
+	if q.RubricID != 0 && q.Type != domain.QuestionShortAnswer {
+		return domain.ErrBadParamInput
+	}
+	if q.Difficulty == 0 {
+		q.Difficulty = domain.DifficultyMedium
+	}
+	numberOptions(q.Options)
+	numberOptions(q.Matches)
+	key := q.Key
+	switch q.Type {
+	case domain.QuestionSingleChoice, domain.QuestionMultipleChoice:
+		if len(q.Options) < 2 || len(key.Choices) == 0 || !validIDs(key.Choices, len(q.Options)) {
+			return domain.ErrBadParamInput
+		}
+		if q.Type == domain.QuestionSingleChoice && len(key.Choices) != 1 {
+			return domain.ErrBadParamInput
+		}
+		q.Key, q.Matches = &domain.AnswerKey{Choices: key.Choices}, nil
+	case domain.QuestionTrueFalse:
+		if key.Truth == nil {
+			return domain.ErrBadParamInput
+		}
+		q.Key, q.Options, q.Matches = &domain.AnswerKey{Truth: key.Truth}, nil, nil
+	case domain.QuestionShortAnswer:
+		if len(key.Texts) == 0 && q.RubricID == 0 {
+			return domain.ErrBadParamInput
+		}
+		for _, text := range key.Texts {
+			if normalize(text) == "" {
+				return domain.ErrBadParamInput
+			}
+		}
+		q.Key, q.Options, q.Matches = &domain.AnswerKey{Texts: key.Texts}, nil, nil
+	case domain.QuestionNumeric:
+		if key.Number == nil || key.Tolerance < 0 {
+			return domain.ErrBadParamInput
+		}
+		q.Key, q.Options, q.Matches = &domain.AnswerKey{Number: key.Number, Tolerance: key.Tolerance}, nil, nil
+	case domain.QuestionMatching:
+		if len(q.Options) < 2 || len(q.Matches) < len(q.Options) || len(key.Pairs) != len(q.Options) {
+			return domain.ErrBadParamInput
+		}
+		options := make([]int, len(key.Pairs))
+		for i, pair := range key.Pairs {
+			if pair.MatchID < 1 || pair.MatchID > len(q.Matches) {
+				return domain.ErrBadParamInput
+			}
+			options[i] = pair.OptionID
+		}
+		if !validIDs(options, len(q.Options)) {
+			return domain.ErrBadParamInput
+		}
+		q.Key = &domain.AnswerKey{Pairs: key.Pairs}
+	case domain.QuestionOrdering:
+		if len(q.Options) < 2 {
+			return domain.ErrBadParamInput
+		}
+		order := key.Order
+		if len(order) == 0 {
+			for _, option := range q.Options {
+				order = append(order, option.ID)
+			}
+		}
+		if len(order) != len(q.Options) || !validIDs(order, len(q.Options)) {
+			return domain.ErrBadParamInput
+		}
+		q.Key, q.Matches = &domain.AnswerKey{Order: order}, nil
+	}
+	return nil
+}
+
+// normalize lowers the case of a short answer and collapses its spaces
+func normalize(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+func sameSet(a []int, b []int) bool {
+	set := make(map[int]bool, len(b))
+	for _, id := range b {
+		set[id] = true
+	}
+	answered := make(map[int]bool, len(a))
+	for _, id := range a {
+		if !set[id] {
+			return false
+		}
+		answered[id] = true
+	}
+	return len(answered) == len(set)
+}
+
+// score returns the share of the points of the question earned by the response, between 0 and 1. Matching
+// questions earn a share for every correct pair, the other questions are either right or wrong.
+func score(q *domain.Question, r *domain.QuestionResponse) float64 {
+	key := q.Key
+	if key == nil {
+		return 0
+	}
+	switch q.Type {
+	case domain.QuestionSingleChoice, domain.QuestionMultipleChoice:
+		if sameSet(r.Choices, key.Choices) {
+			return 1
+		}
+	case domain.QuestionTrueFalse:
+		if r.Truth != nil && key.Truth != nil && *r.Truth == *key.Truth {
+			return 1
+		}
+	case domain.QuestionShortAnswer:
+		answer := normalize(r.Text)
+		for _, text := range key.Texts {
+			if answer != "" && answer == normalize(text) {
+				return 1
+			}
+		}
+	case domain.QuestionNumeric:
+		if r.Number != nil && key.Number != nil && math.Abs(*r.Number-*key.Number) <= key.Tolerance+1e-9 {
+			return 1
+		}
+	case domain.QuestionMatching:
+		if len(key.Pairs) == 0 {
+			return 0
+		}
+		matched := make(map[int]int, len(r.Pairs))
+		for _, pair := range r.Pairs {
+			if _, ok := matched[pair.OptionID]; !ok {
+				matched[pair.OptionID] = pair.MatchID
+			}
+		}
+		correct := 0
+		for _, pair := range key.Pairs {
+			if matched[pair.OptionID] == pair.MatchID {
+				correct++
+			}
+		}
+		return float64(correct) / float64(len(key.Pairs))
+	case domain.QuestionOrdering:
+		if len(r.Order) != len(key.Order) {
+			return 0
+		}
+		for i := range key.Order {
+			if r.Order[i] != key.Order[i] {
+				return 0
+			}
+		}
+		return 1
+	}
+	return 0
+}
+
+// grade scores the responses to the questions of a quiz. Every question gets a response, in the order of the quiz,
+// empty for the questions left unanswered. The responses to the questions marked with a rubric are left pending.
+func grade(questions []domain.Question, responses []domain.QuestionResponse) (answers []domain.QuestionResponse, total float64, pending bool) {
+	byQuestion := make(map[int64]domain.QuestionResponse, len(responses))
+	for _, r := range responses {
+		byQuestion[r.QuestionID] = r
+	}
+	answers = make([]domain.QuestionResponse, len(questions))
+	for i := range questions {
+		r, ok := byQuestion[questions[i].ID]
+		if !ok {
+			r = domain.QuestionResponse{QuestionID: questions[i].ID}
+		}
+		r.Scores = nil
+		r.Pending = questions[i].RubricID != 0
+		if r.Pending {
+			r.Score, r.Correct = 0, false
+			answers[i] = r
+			pending = true
+			continue
+		}
+		share := score(&questions[i], &r)
+		r.Score = share * questions[i].Points
+		r.Correct = share == 1
+		answers[i] = r
+		total += r.Score
+	}
+	return answers, total, pending
+}
+
+func shuffleOptions(r *rand.Rand, options []domain.QuestionOption) []domain.QuestionOption {
+	if len(options) == 0 {
+		return options
+	}
+	shuffled := make([]domain.QuestionOption, len(options))
+	copy(shuffled, options)
+	r.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	return shuffled
+}
+
+// present returns the questions of an attempt as shown to the learner, without their keys and explanations.
+// The seed of the attempt shuffles them the same way every time. The items to order and to match are always shuffled.
+func present(quiz *domain.Quiz, questions []domain.Question, seed int64) []domain.Question {
+	r := rand.New(rand.NewSource(seed))
+	shown := make([]domain.Question, len(questions))
+	copy(shown, questions)
+	if quiz.ShuffleQuestions {
+		r.Shuffle(len(shown), func(i, j int) { shown[i], shown[j] = shown[j], shown[i] })
+	}
+	for i := range shown {
+		q := &shown[i]
+		q.Key, q.Explanation = nil, ""
+		switch {
+		case q.Type == domain.QuestionOrdering, q.Type == domain.QuestionMatching:
+			q.Options = shuffleOptions(r, q.Options)
+			q.Matches = shuffleOptions(r, q.Matches)
+		case quiz.ShuffleOptions:
+			q.Options = shuffleOptions(r, q.Options)
+		}
+	}
+	return shown
+}
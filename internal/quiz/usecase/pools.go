@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"math/rand"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// weight returns how likely a question of a pool is to be drawn
+func weight(pool *domain.QuizPool, q *domain.Question) int {
+	if pool.EasyWeight == 0 && pool.MediumWeight == 0 && pool.HardWeight == 0 {
+		return 1
+	}
+	switch q.Difficulty {
+	case domain.DifficultyEasy:
+		return pool.EasyWeight
+	case domain.DifficultyHard:
+		return pool.HardWeight
+	}
+	return pool.MediumWeight
+}
+
+// drawPool draws count questions of the candidates not chosen yet, each as likely as its weight. The questions
+// left with no weight are drawn evenly once the others are all drawn.
+func drawPool(r *rand.Rand, pool *domain.QuizPool, candidates []domain.Question, chosen map[int64]bool) ([]domain.Question, error) {
+	left := make([]domain.Question, 0, len(candidates))
+	for _, q := range candidates {
+		if !chosen[q.ID] {
+			left = append(left, q)
+		}
+	}
+	if len(left) < pool.Count {
+		return nil, domain.ErrBadParamInput
+	}
+	drawn := make([]domain.Question, 0, pool.Count)
+	for len(drawn) < pool.Count {
+		total := 0
+		for i := range left {
+			total += weight(pool, &left[i])
+		}
+		pick := 0
+		if total == 0 {
+			pick = r.Intn(len(left))
+		} else {
+			n := r.Intn(total)
+			for n >= weight(pool, &left[pick]) {
+				n -= weight(pool, &left[pick])
+				pick++
+			}
+		}
+		drawn = append(drawn, left[pick])
+		chosen[left[pick].ID] = true
+		left = append(left[:pick], left[pick+1:]...)
+	}
+	return drawn, nil
+}
+
+// draw returns the questions of an attempt: the questions of the quiz followed by the ones drawn from each of
+// its pools. The seed of the attempt draws the same questions every time.
+func draw(quiz *domain.Quiz, questions []domain.Question, candidates [][]domain.Question, seed int64) ([]domain.Question, error) {
+	r := rand.New(rand.NewSource(seed))
+	chosen := make(map[int64]bool, len(questions))
+	drawn := make([]domain.Question, 0, len(questions))
+	for _, q := range questions {
+		chosen[q.ID] = true
+		drawn = append(drawn, q)
+	}
+	for i := range quiz.Pools {
+		list, err := drawPool(r, &quiz.Pools[i], candidates[i], chosen)
+		if err != nil {
+			return nil, err
+		}
+		drawn = append(drawn, list...)
+	}
+	return drawn, nil
+}
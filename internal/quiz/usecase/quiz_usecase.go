@@ -0,0 +1,579 @@
+package usecase
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/binary"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// submissionGrace is the time given after the time limit of an attempt for the answers to reach the server
+const submissionGrace = 30
+
+// QuizUseCase ...
+type QuizUseCase struct {
+	quizRepo            domain.QuizRepository
+	lessonRepo          domain.LessonRepository
+	enrollmentRepo      domain.EnrollmentRepository
+	collaboratorUseCase domain.CollaboratorUseCase
+	rubricUseCase       domain.RubricUseCase
+	contextTimeOut      time.Duration
+}
+
+// NewQuizUseCase will create new an QuizUseCase
+func NewQuizUseCase(q domain.QuizRepository, l domain.LessonRepository, e domain.EnrollmentRepository, cu domain.CollaboratorUseCase,
+	ru domain.RubricUseCase, timeout time.Duration) domain.QuizUseCase {
+	return &QuizUseCase{
+		quizRepo:            q,
+		lessonRepo:          l,
+		enrollmentRepo:      e,
+		collaboratorUseCase: cu,
+		rubricUseCase:       ru,
+		contextTimeOut:      timeout,
+	}
+}
+
+// newSeed returns a random seed to shuffle an attempt
+func newSeed() (int64, error) {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b[:]) >> 1), nil
+}
+
+// GetQuestions returns the question bank of a course, or its questions with a tag, to the ones working on it
+func (usecase *QuizUseCase) GetQuestions(c context.Context, courseID int64, tagID int64, start int, limit int) ([]domain.Question, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeCourse(ctx, courseID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	return usecase.quizRepo.GetQuestions(ctx, courseID, tagID, start, limit)
+}
+
+// GetQuestion returns a question of the question bank to the ones working on its course
+func (usecase *QuizUseCase) GetQuestion(c context.Context, id int64) (*domain.Question, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	question, err := usecase.quizRepo.GetQuestion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeCourse(ctx, question.CourseID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// checkRubric checks the rubric of a question is a rubric of the organization
+func (usecase *QuizUseCase) checkRubric(ctx context.Context, question *domain.Question) error {
+	if question.RubricID == 0 {
+		return nil
+	}
+	_, err := usecase.rubricUseCase.GetByID(ctx, question.RubricID)
+	if err == domain.ErrNotFound {
+		return domain.ErrBadParamInput
+	}
+	return err
+}
+
+// CreateQuestion adds a question to the question bank of a course
+func (usecase *QuizUseCase) CreateQuestion(c context.Context, question *domain.Question) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeCourse(ctx, question.CourseID, domain.CollaboratorEditor); err != nil {
+		return err
+	}
+	if err := validateQuestion(question); err != nil {
+		return err
+	}
+	if err := usecase.checkRubric(ctx, question); err != nil {
+		return err
+	}
+	question.CreatedBy = domain.UserIDFromContext(ctx)
+	question.UpdatedAt = time.Now().Unix()
+	question.CreatedAt = question.UpdatedAt
+	return usecase.quizRepo.CreateQuestion(ctx, question)
+}
+
+// UpdateQuestion updates a question of the question bank. The quizzes using it are graded with the new key.
+func (usecase *QuizUseCase) UpdateQuestion(c context.Context, question *domain.Question, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existedQuestion, err := usecase.GetQuestion(ctx, id)
+	if err != nil {
+		return err
+	}
+	question.ID = id
+	question.CourseID = existedQuestion.CourseID
+	if err = validateQuestion(question); err != nil {
+		return err
+	}
+	if err = usecase.checkRubric(ctx, question); err != nil {
+		return err
+	}
+	question.CreatedBy = existedQuestion.CreatedBy
+	question.CreatedAt = existedQuestion.CreatedAt
+	question.UpdatedAt = time.Now().Unix()
+	return usecase.quizRepo.UpdateQuestion(ctx, question)
+}
+
+// DeleteQuestion removes a question from the question bank and from the quizzes using it
+func (usecase *QuizUseCase) DeleteQuestion(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.GetQuestion(ctx, id); err != nil {
+		return err
+	}
+	return usecase.quizRepo.DeleteQuestion(ctx, id)
+}
+
+// GetByLesson returns the quizzes of a lesson
+func (usecase *QuizUseCase) GetByLesson(c context.Context, lessonID int64) ([]domain.Quiz, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.quizRepo.GetByLesson(ctx, lessonID)
+}
+
+// GetByID returns a quiz. Its questions are only returned to the ones working on its course.
+func (usecase *QuizUseCase) GetByID(c context.Context, id int64) (*domain.Quiz, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	quiz, err := usecase.quizRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, quiz.LessonID, domain.CollaboratorEditor)
+	if err == domain.ErrForbidden {
+		return quiz, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if quiz.Questions, err = usecase.quizRepo.GetQuizQuestions(ctx, id); err != nil {
+		return nil, err
+	}
+	return quiz, nil
+}
+
+// checkQuestions checks the questions of a quiz are distinct questions of the question bank of its course, and
+// its pools have enough other questions to draw
+func (usecase *QuizUseCase) checkQuestions(ctx context.Context, quiz *domain.Quiz) error {
+	if quiz.QuestionIDs == nil {
+		quiz.QuestionIDs = make([]int64, 0)
+	}
+	seen := make(map[int64]bool, len(quiz.QuestionIDs))
+	for _, id := range quiz.QuestionIDs {
+		if seen[id] {
+			return domain.ErrBadParamInput
+		}
+		seen[id] = true
+	}
+	questions, err := usecase.quizRepo.GetQuestionsByIDs(ctx, quiz.CourseID, quiz.QuestionIDs)
+	if err != nil {
+		return err
+	}
+	if len(questions) != len(quiz.QuestionIDs) {
+		return domain.ErrBadParamInput
+	}
+	if quiz.Pools == nil {
+		quiz.Pools = make([]domain.QuizPool, 0)
+	}
+	tags := make(map[int64]bool, len(quiz.Pools))
+	for _, pool := range quiz.Pools {
+		if tags[pool.TagID] {
+			return domain.ErrBadParamInput
+		}
+		tags[pool.TagID] = true
+		candidates, err := usecase.quizRepo.GetPoolQuestions(ctx, quiz.CourseID, pool.TagID)
+		if err != nil {
+			return err
+		}
+		left := 0
+		for _, q := range candidates {
+			if !seen[q.ID] {
+				left++
+			}
+		}
+		if left < pool.Count {
+			return domain.ErrBadParamInput
+		}
+	}
+	return nil
+}
+
+// drawQuestions returns the questions of a new attempt, drawn from the pools of the quiz with its seed
+func (usecase *QuizUseCase) drawQuestions(ctx context.Context, quiz *domain.Quiz, seed int64) ([]domain.Question, error) {
+	questions, err := usecase.quizRepo.GetQuizQuestions(ctx, quiz.ID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([][]domain.Question, len(quiz.Pools))
+	for i, pool := range quiz.Pools {
+		if candidates[i], err = usecase.quizRepo.GetPoolQuestions(ctx, quiz.CourseID, pool.TagID); err != nil {
+			return nil, err
+		}
+	}
+	return draw(quiz, questions, candidates, seed)
+}
+
+// attemptQuestions returns the questions drawn for an attempt, in the order they were drawn. The attempts
+// made before the quizzes had pools have the questions of the quiz.
+func (usecase *QuizUseCase) attemptQuestions(ctx context.Context, quiz *domain.Quiz, attempt *domain.QuizAttempt) ([]domain.Question, error) {
+	if len(attempt.QuestionIDs) == 0 {
+		return usecase.quizRepo.GetQuizQuestions(ctx, quiz.ID)
+	}
+	list, err := usecase.quizRepo.GetQuestionsByIDs(ctx, quiz.CourseID, attempt.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]domain.Question, len(list))
+	for _, q := range list {
+		byID[q.ID] = q
+	}
+	questions := make([]domain.Question, 0, len(list))
+	for _, id := range attempt.QuestionIDs {
+		if q, ok := byID[id]; ok {
+			questions = append(questions, q)
+		}
+	}
+	return questions, nil
+}
+
+// CreateQuiz adds a quiz to a lesson, with questions of the question bank of its course
+func (usecase *QuizUseCase) CreateQuiz(c context.Context, quiz *domain.Quiz) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeLesson(ctx, quiz.LessonID, domain.CollaboratorEditor); err != nil {
+		return err
+	}
+	lesson, err := usecase.lessonRepo.GetByID(ctx, quiz.LessonID)
+	if err != nil {
+		return err
+	}
+	quiz.CourseID = lesson.CourseID
+	if err = usecase.checkQuestions(ctx, quiz); err != nil {
+		return err
+	}
+	quiz.CreatedBy = domain.UserIDFromContext(ctx)
+	quiz.UpdatedAt = time.Now().Unix()
+	quiz.CreatedAt = quiz.UpdatedAt
+	return usecase.quizRepo.CreateQuiz(ctx, quiz)
+}
+
+// UpdateQuiz updates the settings and the questions of a quiz
+func (usecase *QuizUseCase) UpdateQuiz(c context.Context, quiz *domain.Quiz, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existedQuiz, err := usecase.quizRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, existedQuiz.LessonID, domain.CollaboratorEditor); err != nil {
+		return err
+	}
+	quiz.ID = id
+	quiz.LessonID = existedQuiz.LessonID
+	quiz.CourseID = existedQuiz.CourseID
+	if err = usecase.checkQuestions(ctx, quiz); err != nil {
+		return err
+	}
+	quiz.CreatedBy = existedQuiz.CreatedBy
+	quiz.CreatedAt = existedQuiz.CreatedAt
+	quiz.UpdatedAt = time.Now().Unix()
+	return usecase.quizRepo.UpdateQuiz(ctx, quiz)
+}
+
+// DeleteQuiz removes a quiz with its attempts
+func (usecase *QuizUseCase) DeleteQuiz(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	quiz, err := usecase.quizRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, quiz.LessonID, domain.CollaboratorEditor); err != nil {
+		return err
+	}
+	return usecase.quizRepo.DeleteQuiz(ctx, id)
+}
+
+// expire closes an attempt not submitted within its time limit, with no score
+func (usecase *QuizUseCase) expire(ctx context.Context, attempt *domain.QuizAttempt) error {
+	attempt.Status = domain.AttemptExpired
+	attempt.Answers = make([]domain.QuestionResponse, 0)
+	attempt.Score, attempt.Percentage, attempt.Passed = 0, 0, false
+	return usecase.quizRepo.FinishAttempt(ctx, attempt)
+}
+
+func timedOut(attempt *domain.QuizAttempt, now int64) bool {
+	return attempt.ExpiresAt != 0 && now > attempt.ExpiresAt+submissionGrace
+}
+
+// StartAttempt starts an attempt at a quiz of a course the caller is enrolled in. An attempt in progress is
+// resumed instead, and closed when its time limit is exceeded.
+func (usecase *QuizUseCase) StartAttempt(c context.Context, quizID int64) (*domain.QuizAttempt, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, domain.ErrForbidden
+	}
+	quiz, err := usecase.quizRepo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = usecase.enrollmentRepo.GetEnrollment(ctx, quiz.CourseID, userID)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrForbidden
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(quiz.QuestionIDs) == 0 && len(quiz.Pools) == 0 {
+		return nil, domain.ErrBadParamInput
+	}
+	attempts, err := usecase.quizRepo.GetAttempts(ctx, quizID, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	for i := range attempts {
+		attempt := &attempts[i]
+		if attempt.Status != domain.AttemptInProgress {
+			continue
+		}
+		if !timedOut(attempt, now) {
+			questions, err := usecase.attemptQuestions(ctx, quiz, attempt)
+			if err != nil {
+				return nil, err
+			}
+			attempt.Questions = present(quiz, questions, attempt.Seed)
+			return attempt, nil
+		}
+		if err = usecase.expire(ctx, attempt); err != nil {
+			return nil, err
+		}
+	}
+	if quiz.MaxAttempts > 0 && len(attempts) >= quiz.MaxAttempts {
+		return nil, domain.ErrNoAttemptsLeft
+	}
+	// the number of the attempt is unique to the learner and the quiz, so that of two attempts started at once only
+	// one is kept when a single attempt is left
+	attempt := &domain.QuizAttempt{
+		QuizID:    quizID,
+		UserID:    userID,
+		Number:    len(attempts) + 1,
+		Status:    domain.AttemptInProgress,
+		StartedAt: now,
+	}
+	if attempt.Seed, err = newSeed(); err != nil {
+		return nil, err
+	}
+	questions, err := usecase.drawQuestions(ctx, quiz, attempt.Seed)
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 {
+		return nil, domain.ErrBadParamInput
+	}
+	attempt.QuestionIDs = make([]int64, len(questions))
+	for i, q := range questions {
+		attempt.QuestionIDs[i] = q.ID
+		attempt.MaxScore += q.Points
+	}
+	if quiz.TimeLimit > 0 {
+		attempt.ExpiresAt = now + int64(quiz.TimeLimit)
+	}
+	if err = usecase.quizRepo.CreateAttempt(ctx, attempt); err != nil {
+		return nil, err
+	}
+	attempt.Questions = present(quiz, questions, attempt.Seed)
+	return attempt, nil
+}
+
+// SubmitAttempt grades the answers of the caller's attempt in progress. An attempt submitted after its time
+// limit is closed without a score. An attempt with answers to mark with a rubric is graded once they are marked.
+func (usecase *QuizUseCase) SubmitAttempt(c context.Context, attemptID int64, submission *domain.AttemptSubmission) (*domain.QuizAttempt, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	attempt, err := usecase.quizRepo.GetAttempt(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt.UserID != domain.UserIDFromContext(ctx) {
+		return nil, domain.ErrForbidden
+	}
+	if attempt.Status != domain.AttemptInProgress {
+		return nil, domain.ErrConflict
+	}
+	now := time.Now().Unix()
+	if timedOut(attempt, now) {
+		if err = usecase.expire(ctx, attempt); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrTimeLimitExceeded
+	}
+	quiz, err := usecase.quizRepo.GetByID(ctx, attempt.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := usecase.attemptQuestions(ctx, quiz, attempt)
+	if err != nil {
+		return nil, err
+	}
+	var pending bool
+	attempt.Answers, attempt.Score, pending = grade(questions, submission.Answers)
+	attempt.MaxScore = 0
+	for _, q := range questions {
+		attempt.MaxScore += q.Points
+	}
+	if attempt.MaxScore > 0 {
+		attempt.Percentage = attempt.Score * 100 / attempt.MaxScore
+	}
+	attempt.Passed = attempt.Percentage >= quiz.PassingScore
+	attempt.Status = domain.AttemptSubmitted
+	if pending {
+		attempt.Passed = false
+		attempt.Status = domain.AttemptGrading
+	}
+	attempt.SubmittedAt = now
+	if err = usecase.quizRepo.FinishAttempt(ctx, attempt); err != nil {
+		return nil, err
+	}
+	attempt.Questions = present(quiz, questions, attempt.Seed)
+	return attempt, nil
+}
+
+// GetAttempt returns an attempt with its questions to the learner who made it and to the ones working on the course
+func (usecase *QuizUseCase) GetAttempt(c context.Context, id int64) (*domain.QuizAttempt, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	attempt, err := usecase.quizRepo.GetAttempt(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	quiz, err := usecase.quizRepo.GetByID(ctx, attempt.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt.UserID != domain.UserIDFromContext(ctx) {
+		if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, quiz.LessonID, domain.CollaboratorEditor); err != nil {
+			return nil, err
+		}
+	}
+	questions, err := usecase.attemptQuestions(ctx, quiz, attempt)
+	if err != nil {
+		return nil, err
+	}
+	attempt.Questions = present(quiz, questions, attempt.Seed)
+	return attempt, nil
+}
+
+// GetAttempts returns the caller's attempts at a quiz
+func (usecase *QuizUseCase) GetAttempts(c context.Context, quizID int64) ([]domain.QuizAttempt, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.quizRepo.GetByID(ctx, quizID); err != nil {
+		return nil, err
+	}
+	return usecase.quizRepo.GetAttempts(ctx, quizID, domain.UserIDFromContext(ctx))
+}
+
+// GetResults returns the attempts of every learner at a quiz to the ones working on the course
+func (usecase *QuizUseCase) GetResults(c context.Context, quizID int64, start int, limit int) ([]domain.QuizAttempt, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	quiz, err := usecase.quizRepo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, quiz.LessonID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	return usecase.quizRepo.GetResults(ctx, quizID, start, limit)
+}
+
+// GradeAnswer marks the answer to a question of an attempt with the rubric of the question. The answer earns the
+// share of the points of the question the scores earn of the rubric. The attempt is graded once none of its
+// answers is left to mark, and an answer can be marked again.
+func (usecase *QuizUseCase) GradeAnswer(c context.Context, attemptID int64, questionID int64, grade *domain.AnswerGrade) (*domain.QuizAttempt, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	attempt, err := usecase.quizRepo.GetAttempt(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	quiz, err := usecase.quizRepo.GetByID(ctx, attempt.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.collaboratorUseCase.AuthorizeLesson(ctx, quiz.LessonID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	if attempt.Status != domain.AttemptGrading && attempt.Status != domain.AttemptSubmitted {
+		return nil, domain.ErrConflict
+	}
+	questions, err := usecase.attemptQuestions(ctx, quiz, attempt)
+	if err != nil {
+		return nil, err
+	}
+	var question *domain.Question
+	for i := range questions {
+		if questions[i].ID == questionID {
+			question = &questions[i]
+		}
+	}
+	var answer *domain.QuestionResponse
+	for i := range attempt.Answers {
+		if attempt.Answers[i].QuestionID == questionID {
+			answer = &attempt.Answers[i]
+		}
+	}
+	if question == nil || answer == nil {
+		return nil, domain.ErrNotFound
+	}
+	if question.RubricID == 0 {
+		return nil, domain.ErrBadParamInput
+	}
+	total, maxPoints, err := usecase.rubricUseCase.Apply(ctx, question.RubricID, grade.Scores)
+	if err != nil {
+		return nil, err
+	}
+	answer.Score = 0
+	if maxPoints > 0 {
+		answer.Score = question.Points * total / maxPoints
+	}
+	answer.Correct = total == maxPoints
+	answer.Pending = false
+	answer.Scores = grade.Scores
+
+	attempt.Score = 0
+	pending := false
+	for _, a := range attempt.Answers {
+		attempt.Score += a.Score
+		pending = pending || a.Pending
+	}
+	attempt.Percentage = 0
+	if attempt.MaxScore > 0 {
+		attempt.Percentage = attempt.Score * 100 / attempt.MaxScore
+	}
+	attempt.Status = domain.AttemptSubmitted
+	attempt.Passed = attempt.Percentage >= quiz.PassingScore
+	if pending {
+		attempt.Status = domain.AttemptGrading
+		attempt.Passed = false
+	}
+	if err = usecase.quizRepo.GradeAttempt(ctx, attempt); err != nil {
+		return nil, err
+	}
+	work := &domain.RubricWork{RubricID: question.RubricID, CourseID: quiz.CourseID, AttemptID: attempt.ID, QuestionID: questionID}
+	if err = usecase.rubricUseCase.Record(ctx, work, grade.Scores); err != nil {
+		return nil, err
+	}
+	attempt.Questions = present(quiz, questions, attempt.Seed)
+	return attempt, nil
+}
@@ -0,0 +1,736 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/quiz/usecase"
+)
+
+var learnerCtx = domain.WithUserID(domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2),
+	[]domain.Permission{domain.PermCourseView}), 6)
+
+var instructorCtx = domain.WithUserID(domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2),
+	[]domain.Permission{domain.PermCourseUpdate}), 4)
+
+func truth(b bool) *bool {
+	return &b
+}
+
+func number(f float64) *float64 {
+	return &f
+}
+
+func options(texts ...string) []domain.QuestionOption {
+	list := make([]domain.QuestionOption, len(texts))
+	for i, text := range texts {
+		list[i] = domain.QuestionOption{ID: i + 1, Text: text}
+	}
+	return list
+}
+
+// questions has a question of every type, worth 1 point each but the matching question, worth 2
+func questions() []domain.Question {
+	return []domain.Question{
+		{ID: 1, Type: domain.QuestionSingleChoice, Text: "Capital of Nepal?", Points: 1, Options: options("Pokhara", "Kathmandu"),
+			Key: &domain.AnswerKey{Choices: []int{2}}},
+		{ID: 2, Type: domain.QuestionMultipleChoice, Text: "Prime numbers?", Points: 1, Options: options("2", "4", "5"),
+			Key: &domain.AnswerKey{Choices: []int{1, 3}}},
+		{ID: 3, Type: domain.QuestionTrueFalse, Text: "Everest is in Nepal.", Points: 1, Key: &domain.AnswerKey{Truth: truth(true)}},
+		{ID: 4, Type: domain.QuestionShortAnswer, Text: "Largest lake of Nepal?", Points: 1,
+			Key: &domain.AnswerKey{Texts: []string{"Rara Lake", "Rara"}}},
+		{ID: 5, Type: domain.QuestionNumeric, Text: "Pi?", Points: 1, Key: &domain.AnswerKey{Number: number(3.14), Tolerance: 0.01}},
+		{ID: 6, Type: domain.QuestionMatching, Text: "Match the rivers.", Points: 2, Options: options("Koshi", "Karnali"),
+			Matches: options("West", "East"), Key: &domain.AnswerKey{Pairs: []domain.MatchPair{{OptionID: 1, MatchID: 2}, {OptionID: 2, MatchID: 1}}}},
+		{ID: 7, Type: domain.QuestionOrdering, Text: "Order by height.", Points: 1, Options: options("Everest", "Lhotse", "Makalu"),
+			Key: &domain.AnswerKey{Order: []int{1, 2, 3}}},
+	}
+}
+
+func TestCreateQuestion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeCourse", mock.Anything, int64(3), domain.CollaboratorEditor).Return(nil).Once()
+		mockQuizRepo.On("CreateQuestion", mock.Anything, mock.AnythingOfType("*domain.Question")).Return(nil).Once()
+
+		question := &domain.Question{CourseID: 3, Type: domain.QuestionOrdering, Text: "Order by height.", Points: 1,
+			Options: []domain.QuestionOption{{Text: "Everest"}, {Text: "Lhotse"}}, Matches: options("ignored"),
+			Key: &domain.AnswerKey{Choices: []int{1}}}
+		assert.NoError(t, u.CreateQuestion(instructorCtx, question))
+		assert.Equal(t, options("Everest", "Lhotse"), question.Options, "the options are numbered by position")
+		assert.Equal(t, &domain.AnswerKey{Order: []int{1, 2}}, question.Key, "the order defaults to the order of the options")
+		assert.Nil(t, question.Matches)
+		assert.Equal(t, int64(4), question.CreatedBy)
+		mockQuizRepo.AssertExpectations(t)
+	})
+	invalid := []struct {
+		name     string
+		question domain.Question
+	}{
+		{"unknown-type", domain.Question{Type: "essay", Key: &domain.AnswerKey{}}},
+		{"no-key", domain.Question{Type: domain.QuestionTrueFalse}},
+		{"two-single-choices", domain.Question{Type: domain.QuestionSingleChoice, Options: options("a", "b"),
+			Key: &domain.AnswerKey{Choices: []int{1, 2}}}},
+		{"unknown-choice", domain.Question{Type: domain.QuestionMultipleChoice, Options: options("a", "b"),
+			Key: &domain.AnswerKey{Choices: []int{3}}}},
+		{"no-truth", domain.Question{Type: domain.QuestionTrueFalse, Key: &domain.AnswerKey{}}},
+		{"blank-answer", domain.Question{Type: domain.QuestionShortAnswer, Key: &domain.AnswerKey{Texts: []string{" "}}}},
+		{"negative-tolerance", domain.Question{Type: domain.QuestionNumeric, Key: &domain.AnswerKey{Number: number(1), Tolerance: -1}}},
+		{"option-matched-twice", domain.Question{Type: domain.QuestionMatching, Options: options("a", "b"), Matches: options("x", "y"),
+			Key: &domain.AnswerKey{Pairs: []domain.MatchPair{{OptionID: 1, MatchID: 1}, {OptionID: 1, MatchID: 2}}}}},
+		{"incomplete-order", domain.Question{Type: domain.QuestionOrdering, Options: options("a", "b", "c"),
+			Key: &domain.AnswerKey{Order: []int{1, 2}}}},
+		{"rubric-of-a-choice", domain.Question{Type: domain.QuestionSingleChoice, Options: options("a", "b"), RubricID: 7,
+			Key: &domain.AnswerKey{Choices: []int{1}}}},
+		{"no-answer-nor-rubric", domain.Question{Type: domain.QuestionShortAnswer, Key: &domain.AnswerKey{}}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuizRepo := new(mocks.QuizRepository)
+			mockLessonRepo := new(mocks.LessonRepository)
+			mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+			mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+			mockRubricUseCase := new(mocks.RubricUseCase)
+			u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+				mockRubricUseCase, time.Second*2)
+			mockCollaboratorUseCase.On("AuthorizeCourse", mock.Anything, int64(3), domain.CollaboratorEditor).Return(nil).Once()
+			question := tt.question
+			question.CourseID = 3
+			assert.Equal(t, domain.ErrBadParamInput, u.CreateQuestion(instructorCtx, &question))
+			mockQuizRepo.AssertNotCalled(t, "CreateQuestion", mock.Anything, mock.Anything)
+		})
+	}
+	t.Run("rubric", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeCourse", mock.Anything, int64(3), domain.CollaboratorEditor).Return(nil).Once()
+		mockRubricUseCase.On("GetByID", mock.Anything, int64(7)).Return(&domain.Rubric{ID: 7}, nil).Once()
+		mockQuizRepo.On("CreateQuestion", mock.Anything, mock.AnythingOfType("*domain.Question")).Return(nil).Once()
+
+		question := &domain.Question{CourseID: 3, Type: domain.QuestionShortAnswer, Text: "Why do rivers meander?", Points: 4,
+			RubricID: 7, Key: &domain.AnswerKey{}}
+		assert.NoError(t, u.CreateQuestion(instructorCtx, question), "the answers marked with a rubric need no key")
+	})
+	t.Run("unknown-rubric", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeCourse", mock.Anything, int64(3), domain.CollaboratorEditor).Return(nil).Once()
+		mockRubricUseCase.On("GetByID", mock.Anything, int64(7)).Return(nil, domain.ErrNotFound).Once()
+
+		question := &domain.Question{CourseID: 3, Type: domain.QuestionShortAnswer, RubricID: 7, Key: &domain.AnswerKey{}}
+		assert.Equal(t, domain.ErrBadParamInput, u.CreateQuestion(instructorCtx, question))
+		mockQuizRepo.AssertNotCalled(t, "CreateQuestion", mock.Anything, mock.Anything)
+	})
+	t.Run("not-working-on-the-course", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeCourse", mock.Anything, int64(3), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+		err := u.CreateQuestion(instructorCtx, &domain.Question{CourseID: 3, Type: domain.QuestionTrueFalse, Key: &domain.AnswerKey{Truth: truth(true)}})
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+}
+
+func TestCreateQuiz(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockLessonRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Lesson{ID: 8, CourseID: 3}, nil).Once()
+		mockQuizRepo.On("GetQuestionsByIDs", mock.Anything, int64(3), []int64{2, 1}).Return(questions()[:2], nil).Once()
+		mockQuizRepo.On("CreateQuiz", mock.Anything, mock.AnythingOfType("*domain.Quiz")).Return(nil).Once()
+
+		quiz := &domain.Quiz{LessonID: 8, Title: "Geography", QuestionIDs: []int64{2, 1}}
+		assert.NoError(t, u.CreateQuiz(instructorCtx, quiz))
+		assert.Equal(t, int64(3), quiz.CourseID)
+		mockQuizRepo.AssertExpectations(t)
+	})
+	t.Run("question-of-another-course", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockLessonRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Lesson{ID: 8, CourseID: 3}, nil).Once()
+		mockQuizRepo.On("GetQuestionsByIDs", mock.Anything, int64(3), []int64{1, 9}).Return(questions()[:1], nil).Once()
+
+		err := u.CreateQuiz(instructorCtx, &domain.Quiz{LessonID: 8, Title: "Geography", QuestionIDs: []int64{1, 9}})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockQuizRepo.AssertNotCalled(t, "CreateQuiz", mock.Anything, mock.Anything)
+	})
+	t.Run("duplicate-question", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockLessonRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Lesson{ID: 8, CourseID: 3}, nil).Once()
+
+		err := u.CreateQuiz(instructorCtx, &domain.Quiz{LessonID: 8, Title: "Geography", QuestionIDs: []int64{1, 1}})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+	t.Run("pools", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockLessonRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Lesson{ID: 8, CourseID: 3}, nil).Once()
+		mockQuizRepo.On("GetQuestionsByIDs", mock.Anything, int64(3), []int64{}).Return([]domain.Question{}, nil).Once()
+		mockQuizRepo.On("GetPoolQuestions", mock.Anything, int64(3), int64(9)).Return(questions()[:3], nil).Once()
+		mockQuizRepo.On("CreateQuiz", mock.Anything, mock.AnythingOfType("*domain.Quiz")).Return(nil).Once()
+
+		quiz := &domain.Quiz{LessonID: 8, Title: "Geography", Pools: []domain.QuizPool{{TagID: 9, Count: 3}}}
+		assert.NoError(t, u.CreateQuiz(instructorCtx, quiz))
+		assert.Equal(t, []int64{}, quiz.QuestionIDs)
+		mockQuizRepo.AssertExpectations(t)
+	})
+	t.Run("pool-too-small", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockLessonRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Lesson{ID: 8, CourseID: 3}, nil).Once()
+		mockQuizRepo.On("GetQuestionsByIDs", mock.Anything, int64(3), []int64{1}).Return(questions()[:1], nil).Once()
+		mockQuizRepo.On("GetPoolQuestions", mock.Anything, int64(3), int64(9)).Return(questions()[:3], nil).Once()
+
+		quiz := &domain.Quiz{LessonID: 8, Title: "Geography", QuestionIDs: []int64{1}, Pools: []domain.QuizPool{{TagID: 9, Count: 3}}}
+		assert.Equal(t, domain.ErrBadParamInput, u.CreateQuiz(instructorCtx, quiz), "the questions of the quiz are not drawn again")
+		mockQuizRepo.AssertNotCalled(t, "CreateQuiz", mock.Anything, mock.Anything)
+	})
+	t.Run("duplicate-pool", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockLessonRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Lesson{ID: 8, CourseID: 3}, nil).Once()
+		mockQuizRepo.On("GetQuestionsByIDs", mock.Anything, int64(3), []int64{}).Return([]domain.Question{}, nil).Once()
+		mockQuizRepo.On("GetPoolQuestions", mock.Anything, int64(3), int64(9)).Return(questions(), nil).Once()
+
+		quiz := &domain.Quiz{LessonID: 8, Title: "Geography", Pools: []domain.QuizPool{{TagID: 9, Count: 1}, {TagID: 9, Count: 2}}}
+		assert.Equal(t, domain.ErrBadParamInput, u.CreateQuiz(instructorCtx, quiz))
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	t.Run("instructor", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Quiz{ID: 5, LessonID: 8}, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(questions(), nil).Once()
+
+		quiz, err := u.GetByID(instructorCtx, 5)
+		assert.NoError(t, err)
+		assert.Len(t, quiz.Questions, 7)
+	})
+	t.Run("learner", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Quiz{ID: 5, LessonID: 8}, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+
+		quiz, err := u.GetByID(learnerCtx, 5)
+		assert.NoError(t, err)
+		assert.Empty(t, quiz.Questions, "learners only see the questions of their attempts")
+		mockQuizRepo.AssertNotCalled(t, "GetQuizQuestions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestStartAttempt(t *testing.T) {
+	quiz := &domain.Quiz{ID: 5, LessonID: 8, CourseID: 3, TimeLimit: 600, MaxAttempts: 2, ShuffleQuestions: true, ShuffleOptions: true,
+		QuestionIDs: []int64{1, 2, 3, 4, 5, 6, 7}}
+	t.Run("new-attempt", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(&domain.Enrollment{ID: 1}, nil).Once()
+		mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(questions(), nil).Once()
+		mockQuizRepo.On("GetAttempts", mock.Anything, int64(5), int64(6)).
+			Return([]domain.QuizAttempt{{ID: 1, Status: domain.AttemptSubmitted}}, nil).Once()
+		mockQuizRepo.On("CreateAttempt", mock.Anything, mock.AnythingOfType("*domain.QuizAttempt")).Return(nil).Once()
+
+		attempt, err := u.StartAttempt(learnerCtx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AttemptInProgress, attempt.Status)
+		assert.Equal(t, 2, attempt.Number)
+		assert.Equal(t, float64(8), attempt.MaxScore)
+		assert.Equal(t, attempt.StartedAt+600, attempt.ExpiresAt)
+		assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7}, attempt.QuestionIDs)
+		assert.Len(t, attempt.Questions, 7)
+		for _, q := range attempt.Questions {
+			assert.Nil(t, q.Key, "the keys are not shown to learners")
+		}
+	})
+	t.Run("resume", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		now := time.Now().Unix()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(&domain.Enrollment{ID: 1}, nil).Once()
+		mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(questions(), nil).Twice()
+		inProgress := domain.QuizAttempt{ID: 2, Status: domain.AttemptInProgress, Seed: 42, StartedAt: now, ExpiresAt: now + 600}
+		mockQuizRepo.On("GetAttempts", mock.Anything, int64(5), int64(6)).Return([]domain.QuizAttempt{inProgress}, nil).Once()
+
+		attempt, err := u.StartAttempt(learnerCtx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), attempt.ID)
+		mockQuizRepo.AssertNotCalled(t, "CreateAttempt", mock.Anything, mock.Anything)
+
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).Return(&inProgress, nil).Once()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		inProgress.QuizID, inProgress.UserID = 5, 6
+		again, err := u.GetAttempt(learnerCtx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, attempt.Questions, again.Questions, "the seed shuffles the attempt the same way every time")
+	})
+	t.Run("no-attempts-left", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		now := time.Now().Unix()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(&domain.Enrollment{ID: 1}, nil).Once()
+		mockQuizRepo.On("GetAttempts", mock.Anything, int64(5), int64(6)).Return([]domain.QuizAttempt{
+			{ID: 1, Status: domain.AttemptSubmitted},
+			{ID: 2, Status: domain.AttemptInProgress, StartedAt: now - 3600, ExpiresAt: now - 3000},
+		}, nil).Once()
+		mockQuizRepo.On("FinishAttempt", mock.Anything, mock.MatchedBy(func(a *domain.QuizAttempt) bool {
+			return a.ID == 2 && a.Status == domain.AttemptExpired
+		})).Return(nil).Once()
+
+		_, err := u.StartAttempt(learnerCtx, 5)
+		assert.Equal(t, domain.ErrNoAttemptsLeft, err)
+		mockQuizRepo.AssertExpectations(t)
+	})
+	t.Run("started-meanwhile", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(&domain.Enrollment{ID: 1}, nil).Once()
+		mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(questions(), nil).Once()
+		mockQuizRepo.On("GetAttempts", mock.Anything, int64(5), int64(6)).
+			Return([]domain.QuizAttempt{{ID: 1, Number: 1, Status: domain.AttemptSubmitted}}, nil).Once()
+		mockQuizRepo.On("CreateAttempt", mock.Anything, mock.MatchedBy(func(a *domain.QuizAttempt) bool {
+			return a.Number == 2
+		})).Return(domain.ErrConflict).Once()
+
+		_, err := u.StartAttempt(learnerCtx, 5)
+		assert.Equal(t, domain.ErrConflict, err, "the last attempt left was started by another request")
+		mockQuizRepo.AssertExpectations(t)
+	})
+	t.Run("not-enrolled", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(nil, domain.ErrNotFound).Once()
+
+		_, err := u.StartAttempt(learnerCtx, 5)
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+}
+
+func TestStartAttemptFromPools(t *testing.T) {
+	// question 1 is always asked, 2 questions are drawn from the first 5 and the hard question of 6 and 7
+	quiz := &domain.Quiz{ID: 5, LessonID: 8, CourseID: 3, ShuffleQuestions: true, QuestionIDs: []int64{1}, Pools: []domain.QuizPool{
+		{TagID: 9, Count: 2},
+		{TagID: 10, Count: 1, EasyWeight: 0, HardWeight: 1},
+	}}
+	bank := questions()
+	bank[5].Difficulty, bank[6].Difficulty = domain.DifficultyEasy, domain.DifficultyHard
+	t.Run("drawn", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			mockQuizRepo := new(mocks.QuizRepository)
+			mockLessonRepo := new(mocks.LessonRepository)
+			mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+			mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+			mockRubricUseCase := new(mocks.RubricUseCase)
+			u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase,
+				mockRubricUseCase, time.Second*2)
+			mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+			mockEnrollmentRepo.On("GetEnrollment", mock.Anything, int64(3), int64(6)).Return(&domain.Enrollment{ID: 1}, nil).Once()
+			mockQuizRepo.On("GetAttempts", mock.Anything, int64(5), int64(6)).Return([]domain.QuizAttempt{}, nil).Once()
+			mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(bank[:1], nil).Once()
+			mockQuizRepo.On("GetPoolQuestions", mock.Anything, int64(3), int64(9)).Return(bank[:5], nil).Once()
+			mockQuizRepo.On("GetPoolQuestions", mock.Anything, int64(3), int64(10)).Return(bank[5:], nil).Once()
+			mockQuizRepo.On("CreateAttempt", mock.Anything, mock.AnythingOfType("*domain.QuizAttempt")).Return(nil).Once()
+
+			attempt, err := u.StartAttempt(learnerCtx, 5)
+			assert.NoError(t, err)
+			ids := attempt.QuestionIDs
+			assert.Len(t, ids, 4)
+			assert.Equal(t, int64(1), ids[0], "the questions of the quiz come first")
+			assert.NotEqual(t, ids[1], ids[2])
+			assert.True(t, ids[1] > 1 && ids[1] <= 5 && ids[2] > 1 && ids[2] <= 5, "a question is drawn once")
+			assert.Equal(t, int64(7), ids[3], "the easy questions have no weight")
+			assert.Equal(t, float64(4), attempt.MaxScore)
+		}
+	})
+	t.Run("reproduced", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		attempt := &domain.QuizAttempt{ID: 2, QuizID: 5, UserID: 6, Status: domain.AttemptInProgress, Seed: 42, QuestionIDs: []int64{1, 4, 2, 7}}
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).Return(attempt, nil).Twice()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Twice()
+		mockQuizRepo.On("GetQuestionsByIDs", mock.Anything, int64(3), []int64{1, 4, 2, 7}).
+			Return([]domain.Question{bank[0], bank[1], bank[3], bank[6]}, nil).Twice()
+
+		first, err := u.GetAttempt(learnerCtx, 2)
+		assert.NoError(t, err)
+		again, err := u.GetAttempt(learnerCtx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, first.Questions, again.Questions)
+		shown := make([]int64, len(first.Questions))
+		for i, q := range first.Questions {
+			shown[i] = q.ID
+		}
+		assert.ElementsMatch(t, []int64{1, 4, 2, 7}, shown)
+		mockQuizRepo.AssertNotCalled(t, "GetPoolQuestions", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSubmitAttempt(t *testing.T) {
+	quiz := &domain.Quiz{ID: 5, LessonID: 8, CourseID: 3, PassingScore: 75}
+	t.Run("graded", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		attempt := &domain.QuizAttempt{ID: 2, QuizID: 5, UserID: 6, Status: domain.AttemptInProgress, Seed: 42, StartedAt: time.Now().Unix()}
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).Return(attempt, nil).Once()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(questions(), nil).Once()
+		mockQuizRepo.On("FinishAttempt", mock.Anything, attempt).Return(nil).Once()
+
+		graded, err := u.SubmitAttempt(learnerCtx, 2, &domain.AttemptSubmission{Answers: []domain.QuestionResponse{
+			{QuestionID: 7, Order: []int{1, 3, 2}},
+			{QuestionID: 1, Choices: []int{2}},
+			{QuestionID: 2, Choices: []int{3, 1}},
+			{QuestionID: 3, Truth: truth(true)},
+			{QuestionID: 4, Text: "  rara   LAKE "},
+			{QuestionID: 5, Number: number(3.1416)},
+			{QuestionID: 6, Pairs: []domain.MatchPair{{OptionID: 1, MatchID: 2}, {OptionID: 2, MatchID: 2}}},
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AttemptSubmitted, graded.Status)
+		scores := make([]float64, len(graded.Answers))
+		for i, a := range graded.Answers {
+			scores[i] = a.Score
+		}
+		assert.Equal(t, []float64{1, 1, 1, 1, 1, 1, 0}, scores, "the answers are in the order of the quiz, half of the pairs match")
+		assert.False(t, graded.Answers[5].Correct)
+		assert.Equal(t, float64(6), graded.Score)
+		assert.Equal(t, float64(75), graded.Percentage)
+		assert.True(t, graded.Passed)
+		assert.NotZero(t, graded.SubmittedAt)
+	})
+	t.Run("unanswered", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		attempt := &domain.QuizAttempt{ID: 2, QuizID: 5, UserID: 6, Status: domain.AttemptInProgress, StartedAt: time.Now().Unix()}
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).Return(attempt, nil).Once()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(questions(), nil).Once()
+		mockQuizRepo.On("FinishAttempt", mock.Anything, attempt).Return(nil).Once()
+
+		graded, err := u.SubmitAttempt(learnerCtx, 2, &domain.AttemptSubmission{})
+		assert.NoError(t, err)
+		assert.Len(t, graded.Answers, 7)
+		assert.Zero(t, graded.Score)
+		assert.False(t, graded.Passed)
+	})
+	t.Run("time-limit-exceeded", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		now := time.Now().Unix()
+		attempt := &domain.QuizAttempt{ID: 2, QuizID: 5, UserID: 6, Status: domain.AttemptInProgress, StartedAt: now - 700, ExpiresAt: now - 100}
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).Return(attempt, nil).Once()
+		mockQuizRepo.On("FinishAttempt", mock.Anything, attempt).Return(nil).Once()
+
+		_, err := u.SubmitAttempt(learnerCtx, 2, &domain.AttemptSubmission{})
+		assert.Equal(t, domain.ErrTimeLimitExceeded, err)
+		assert.Equal(t, domain.AttemptExpired, attempt.Status)
+	})
+	t.Run("within-grace", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		now := time.Now().Unix()
+		attempt := &domain.QuizAttempt{ID: 2, QuizID: 5, UserID: 6, Status: domain.AttemptInProgress, StartedAt: now - 605, ExpiresAt: now - 5}
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).Return(attempt, nil).Once()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(questions(), nil).Once()
+		mockQuizRepo.On("FinishAttempt", mock.Anything, attempt).Return(nil).Once()
+
+		graded, err := u.SubmitAttempt(learnerCtx, 2, &domain.AttemptSubmission{})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AttemptSubmitted, graded.Status)
+	})
+	t.Run("attempt-of-another-learner", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).
+			Return(&domain.QuizAttempt{ID: 2, QuizID: 5, UserID: 7, Status: domain.AttemptInProgress}, nil).Once()
+
+		_, err := u.SubmitAttempt(learnerCtx, 2, &domain.AttemptSubmission{})
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+	t.Run("already-submitted", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).
+			Return(&domain.QuizAttempt{ID: 2, QuizID: 5, UserID: 6, Status: domain.AttemptSubmitted}, nil).Once()
+
+		_, err := u.SubmitAttempt(learnerCtx, 2, &domain.AttemptSubmission{})
+		assert.Equal(t, domain.ErrConflict, err)
+	})
+	t.Run("pending-rubric", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		list := questions()
+		list[3].RubricID = 7
+		attempt := &domain.QuizAttempt{ID: 2, QuizID: 5, UserID: 6, Status: domain.AttemptInProgress, StartedAt: time.Now().Unix()}
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).Return(attempt, nil).Once()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(list, nil).Once()
+		mockQuizRepo.On("FinishAttempt", mock.Anything, attempt).Return(nil).Once()
+
+		graded, err := u.SubmitAttempt(learnerCtx, 2, &domain.AttemptSubmission{Answers: []domain.QuestionResponse{
+			{QuestionID: 3, Truth: truth(true), Pending: true},
+			{QuestionID: 4, Text: "Rara"},
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AttemptGrading, graded.Status, "the answer to the question with a rubric is left to mark")
+		assert.True(t, graded.Answers[3].Pending)
+		assert.Zero(t, graded.Answers[3].Score)
+		assert.False(t, graded.Answers[2].Pending, "the learner can not leave an answer pending")
+		assert.Equal(t, float64(1), graded.Score)
+		assert.False(t, graded.Passed)
+	})
+}
+
+func TestGradeAnswer(t *testing.T) {
+	quiz := &domain.Quiz{ID: 5, LessonID: 8, CourseID: 3, PassingScore: 50}
+	list := questions()
+	list[3].RubricID, list[3].Points = 7, 2
+	scores := []domain.CriterionScore{{CriterionID: 1, LevelID: 2}, {CriterionID: 2, LevelID: 1}}
+	newAttempt := func() *domain.QuizAttempt {
+		return &domain.QuizAttempt{ID: 2, QuizID: 5, UserID: 6, Status: domain.AttemptGrading, MaxScore: 4, Score: 1,
+			Answers: []domain.QuestionResponse{
+				{QuestionID: 1, Score: 1, Correct: true},
+				{QuestionID: 4, Text: "Rivers erode their outer banks.", Pending: true},
+				{QuestionID: 5},
+			}}
+	}
+	t.Run("graded", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		attempt := newAttempt()
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).Return(attempt, nil).Once()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(list, nil).Once()
+		mockRubricUseCase.On("Apply", mock.Anything, int64(7), scores).Return(float64(6), float64(8), nil).Once()
+		mockQuizRepo.On("GradeAttempt", mock.Anything, attempt).Return(nil).Once()
+		mockRubricUseCase.On("Record", mock.Anything, &domain.RubricWork{RubricID: 7, CourseID: 3, AttemptID: 2, QuestionID: 4}, scores).
+			Return(nil).Once()
+
+		graded, err := u.GradeAnswer(instructorCtx, 2, 4, &domain.AnswerGrade{Scores: scores})
+		assert.NoError(t, err)
+		assert.Equal(t, float64(1.5), graded.Answers[1].Score, "the answer earns the share of the rubric of the points")
+		assert.False(t, graded.Answers[1].Pending)
+		assert.Equal(t, scores, graded.Answers[1].Scores)
+		assert.Equal(t, domain.AttemptSubmitted, graded.Status, "no answer is left to mark")
+		assert.Equal(t, float64(2.5), graded.Score)
+		assert.True(t, graded.Passed)
+		mockRubricUseCase.AssertExpectations(t)
+	})
+	t.Run("question-without-rubric", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).Return(newAttempt(), nil).Once()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+		mockQuizRepo.On("GetQuizQuestions", mock.Anything, int64(5)).Return(list, nil).Once()
+
+		_, err := u.GradeAnswer(instructorCtx, 2, 1, &domain.AnswerGrade{Scores: scores})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockQuizRepo.AssertNotCalled(t, "GradeAttempt", mock.Anything, mock.Anything)
+	})
+	t.Run("in-progress", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).
+			Return(&domain.QuizAttempt{ID: 2, QuizID: 5, UserID: 6, Status: domain.AttemptInProgress}, nil).Once()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(nil).Once()
+
+		_, err := u.GradeAnswer(instructorCtx, 2, 4, &domain.AnswerGrade{Scores: scores})
+		assert.Equal(t, domain.ErrConflict, err)
+	})
+	t.Run("not-working-on-the-course", func(t *testing.T) {
+		mockQuizRepo := new(mocks.QuizRepository)
+		mockLessonRepo := new(mocks.LessonRepository)
+		mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+		mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+		mockRubricUseCase := new(mocks.RubricUseCase)
+		u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+			time.Second*2)
+		mockQuizRepo.On("GetAttempt", mock.Anything, int64(2)).Return(newAttempt(), nil).Once()
+		mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(quiz, nil).Once()
+		mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+
+		_, err := u.GradeAnswer(learnerCtx, 2, 4, &domain.AnswerGrade{Scores: scores})
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+}
+
+func TestGetResults(t *testing.T) {
+	mockQuizRepo := new(mocks.QuizRepository)
+	mockLessonRepo := new(mocks.LessonRepository)
+	mockEnrollmentRepo := new(mocks.EnrollmentRepository)
+	mockCollaboratorUseCase := new(mocks.CollaboratorUseCase)
+	mockRubricUseCase := new(mocks.RubricUseCase)
+	u := ucase.NewQuizUseCase(mockQuizRepo, mockLessonRepo, mockEnrollmentRepo, mockCollaboratorUseCase, mockRubricUseCase,
+		time.Second*2)
+	mockQuizRepo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Quiz{ID: 5, LessonID: 8}, nil).Once()
+	mockCollaboratorUseCase.On("AuthorizeLesson", mock.Anything, int64(8), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+
+	_, err := u.GetResults(learnerCtx, 5, 0, 10)
+	assert.Equal(t, domain.ErrForbidden, err)
+	mockQuizRepo.AssertNotCalled(t, "GetResults", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,30 @@
+package rbac
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// Require returns a route guard that lets the request through only when the authenticated user
+// was granted every one of the given permissions.
+func Require(permissions ...domain.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			for _, p := range permissions {
+				if !domain.HasPermission(ctx, p) {
+					return c.JSON(http.StatusForbidden, ResponseError{Message: domain.ErrForbidden.Error()})
+				}
+			}
+			return next(c)
+		}
+	}
+}
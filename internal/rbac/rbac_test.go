@@ -0,0 +1,118 @@
+package rbac_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	_accountHttpDelivery "github.com/meroedu/meroedu/internal/account/delivery/http"
+	_apiKeyHttpDelivery "github.com/meroedu/meroedu/internal/apikey/delivery/http"
+	_assignmentHttpDelivery "github.com/meroedu/meroedu/internal/assignment/delivery/http"
+	_attachmentHttpDelivery "github.com/meroedu/meroedu/internal/attachment/delivery/http"
+	_authHttpDelivery "github.com/meroedu/meroedu/internal/auth/delivery/http"
+	_categoryHttpDelivery "github.com/meroedu/meroedu/internal/category/delivery/http"
+	_collaboratorHttpDelivery "github.com/meroedu/meroedu/internal/collaborator/delivery/http"
+	_contentHttpDelivery "github.com/meroedu/meroedu/internal/content/delivery/http"
+	_courseHttpDelivery "github.com/meroedu/meroedu/internal/course/delivery/http"
+	"github.com/meroedu/meroedu/internal/domain"
+	_enrollmentHttpDelivery "github.com/meroedu/meroedu/internal/enrollment/delivery/http"
+	_healthHttpDelivery "github.com/meroedu/meroedu/internal/health/delivery/http"
+	_invitationHttpDelivery "github.com/meroedu/meroedu/internal/invitation/delivery/http"
+	_ldapHttpDelivery "github.com/meroedu/meroedu/internal/ldap/delivery/http"
+	_lessonHttpDelivery "github.com/meroedu/meroedu/internal/lesson/delivery/http"
+	_oidcHttpDelivery "github.com/meroedu/meroedu/internal/oidc/delivery/http"
+	_organizationHttpDelivery "github.com/meroedu/meroedu/internal/organization/delivery/http"
+	_peerReviewHttpDelivery "github.com/meroedu/meroedu/internal/peerreview/delivery/http"
+	_privacyHttpDelivery "github.com/meroedu/meroedu/internal/privacy/delivery/http"
+	_quizHttpDelivery "github.com/meroedu/meroedu/internal/quiz/delivery/http"
+	"github.com/meroedu/meroedu/internal/rbac"
+	_roleHttpDelivery "github.com/meroedu/meroedu/internal/role/delivery/http"
+	_rubricHttpDelivery "github.com/meroedu/meroedu/internal/rubric/delivery/http"
+	_sessionHttpDelivery "github.com/meroedu/meroedu/internal/session/delivery/http"
+	_tagHttpDelivery "github.com/meroedu/meroedu/internal/tag/delivery/http"
+	_teamHttpDelivery "github.com/meroedu/meroedu/internal/team/delivery/http"
+	_twoFactorHttpDelivery "github.com/meroedu/meroedu/internal/twofactor/delivery/http"
+	_userHttpDelivery "github.com/meroedu/meroedu/internal/user/delivery/http"
+	_userImportHttpDelivery "github.com/meroedu/meroedu/internal/userimport/delivery/http"
+)
+
+func TestRequire(t *testing.T) {
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, rbac.Require(domain.PermCourseView, domain.PermCourseUpdate))
+
+	tests := []struct {
+		permissions []domain.Permission
+		code        int
+	}{
+		{nil, http.StatusForbidden},
+		{[]domain.Permission{domain.PermCourseView}, http.StatusForbidden},
+		{[]domain.Permission{domain.PermCourseView, domain.PermCourseUpdate}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		req = req.WithContext(domain.WithPermissions(req.Context(), tt.permissions))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, tt.code, rec.Code)
+	}
+}
+
+// TestEveryRouteIsGuarded makes sure no route registered by the handlers is reachable without a permission,
+// except the health check and the auth endpoints.
+func TestEveryRouteIsGuarded(t *testing.T) {
+	e := echo.New()
+	_healthHttpDelivery.NewHealthHandler(e)
+	_authHttpDelivery.NewAuthHandler(e, nil)
+	_accountHttpDelivery.NewAccountHandler(e, nil)
+	_apiKeyHttpDelivery.NewAPIKeyHandler(e, nil)
+	_userImportHttpDelivery.NewUserImportHandler(e, nil)
+	_twoFactorHttpDelivery.NewTwoFactorHandler(e, nil)
+	_oidcHttpDelivery.NewOIDCHandler(e, nil)
+	_ldapHttpDelivery.NewLDAPHandler(e, nil)
+	_invitationHttpDelivery.NewInvitationHandler(e, nil)
+	_organizationHttpDelivery.NewOrganizationHandler(e, nil)
+	_userHttpDelivery.NewUserHandler(e, nil)
+	_roleHttpDelivery.NewRoleHandler(e, nil)
+	_contentHttpDelivery.NewContentHandler(e, nil)
+	_tagHttpDelivery.NewTagHandler(e, nil)
+	_categoryHttpDelivery.NewCategoryHandler(e, nil)
+	_attachmentHttpDelivery.NewAttachmentHandler(e, nil)
+	_lessonHttpDelivery.NewLessonHandler(e, nil)
+	_courseHttpDelivery.NewCourseHandler(e, nil)
+	_enrollmentHttpDelivery.NewEnrollmentHandler(e, nil)
+	_teamHttpDelivery.NewTeamHandler(e, nil)
+	_privacyHttpDelivery.NewPrivacyHandler(e, nil)
+	_sessionHttpDelivery.NewSessionHandler(e, nil)
+	_collaboratorHttpDelivery.NewCollaboratorHandler(e, nil)
+	_quizHttpDelivery.NewQuizHandler(e, nil)
+	_rubricHttpDelivery.NewRubricHandler(e, nil)
+	_assignmentHttpDelivery.NewAssignmentHandler(e, nil)
+	_peerReviewHttpDelivery.NewPeerReviewHandler(e, nil)
+
+	open := map[string]bool{"/": true}
+	for _, r := range e.Routes() {
+		path := r.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		if open[path] || strings.HasPrefix(path, "/auth/") {
+			continue
+		}
+		parts := strings.Split(path, "/")
+		for i, part := range parts {
+			if strings.HasPrefix(part, ":") {
+				parts[i] = "1"
+			}
+		}
+		req := httptest.NewRequest(r.Method, strings.Join(parts, "/"), nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusForbidden, rec.Code, r.Method+" "+r.Path)
+	}
+}
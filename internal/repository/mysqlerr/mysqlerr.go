@@ -0,0 +1,17 @@
+// Package mysqlerr recognizes the errors returned by the MySQL driver, for the MySQL repositories
+package mysqlerr
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// erDupEntry is the MySQL error number of a row breaking a unique index
+const erDupEntry = 1062
+
+// IsDuplicate reports whether err comes from a row breaking a unique index
+func IsDuplicate(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == erDupEntry
+}
@@ -0,0 +1,21 @@
+package mysqlerr_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/meroedu/meroedu/internal/repository/mysqlerr"
+)
+
+func TestIsDuplicate(t *testing.T) {
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '5-6-2' for key 'index_on_assignment_id_user_id_number'"}
+	assert.True(t, mysqlerr.IsDuplicate(duplicate))
+	assert.True(t, mysqlerr.IsDuplicate(fmt.Errorf("insert: %w", duplicate)))
+	assert.False(t, mysqlerr.IsDuplicate(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"}))
+	assert.False(t, mysqlerr.IsDuplicate(errors.New("invalid connection")))
+	assert.False(t, mysqlerr.IsDuplicate(nil))
+}
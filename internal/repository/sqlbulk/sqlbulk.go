@@ -0,0 +1,81 @@
+// Package sqlbulk runs the items of a bulk action in one transaction, for the MySQL repositories
+package sqlbulk
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// Run runs fn once for every distinct id inside a single transaction and reports the outcome
+// of each item. Items failing with domain.ErrNotFound or domain.ErrConflict are reported and skipped, any other
+// error rolls the whole batch back.
+func Run(ctx context.Context, db *sql.DB, ids []int64, fn func(tx *sql.Tx, id int64) error) (results []domain.BulkResult, err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			results = nil
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	results = make([]domain.BulkResult, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		errItem := fn(tx, id)
+		if errItem == domain.ErrNotFound || errItem == domain.ErrConflict {
+			results = append(results, domain.BulkResult{ID: id, Error: errItem.Error()})
+			continue
+		}
+		if errItem != nil {
+			return nil, errItem
+		}
+		results = append(results, domain.BulkResult{ID: id, Success: true})
+	}
+	return results, nil
+}
+
+// ExecItem executes the statement of a bulk item, returning domain.ErrNotFound when no row was affected
+func ExecItem(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) error {
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Unused returns domain.ErrConflict when the query, selecting the rows still using a bulk item, finds any.
+// It keeps a delete from cascading to the rows using the item.
+func Unused(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) error {
+	var used bool
+	if err := tx.QueryRowContext(ctx, "SELECT EXISTS("+query+")", args...).Scan(&used); err != nil {
+		log.Error(err)
+		return err
+	}
+	if used {
+		return domain.ErrConflict
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package sqlbulk_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/repository/sqlbulk"
+)
+
+func TestRun(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `UPDATE courses SET status=\? WHERE id = \?`
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs("Archived", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs("Archived", 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	results, err := sqlbulk.Run(context.TODO(), db, []int64{1, 2, 1}, func(tx *sql.Tx, id int64) error {
+		return sqlbulk.ExecItem(context.TODO(), tx, "UPDATE courses SET status=? WHERE id = ?", "Archived", id)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.BulkResult{
+		{ID: 1, Success: true},
+		{ID: 2, Error: domain.ErrNotFound.Error()},
+	}, results)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunRollback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `UPDATE courses SET status=\? WHERE id = \?`
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs("Archived", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs("Archived", 2).WillReturnError(errors.New("deadlock"))
+	mock.ExpectRollback()
+
+	results, err := sqlbulk.Run(context.TODO(), db, []int64{1, 2, 3}, func(tx *sql.Tx, id int64) error {
+		return sqlbulk.ExecItem(context.TODO(), tx, "UPDATE courses SET status=? WHERE id = ?", "Archived", id)
+	})
+	assert.Error(t, err)
+	assert.Nil(t, results)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
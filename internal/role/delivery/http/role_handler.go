@@ -0,0 +1,229 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// RoleHandler ...
+type RoleHandler struct {
+	RoleUseCase domain.RoleUseCase
+}
+
+// NewRoleHandler ...
+func NewRoleHandler(e *echo.Echo, us domain.RoleUseCase) {
+	handler := &RoleHandler{
+		RoleUseCase: us,
+	}
+	manage := rbac.Require(domain.PermRoleManage)
+	e.GET("/permissions", handler.GetPermissions, manage)
+	e.GET("/roles", handler.GetAll, manage)
+	e.GET("/roles/:id", handler.GetByID, manage)
+	e.POST("/roles", handler.CreateRole, manage)
+	e.PUT("/roles/:id", handler.UpdateRole, manage)
+	e.DELETE("/roles/:id", handler.DeleteRole, manage)
+}
+
+// GetPermissions godoc
+// @Summary Get all permissions.
+// @Description Get every permission that can be granted to a role.
+// @Tags roles
+// @Accept */*
+// @Produce json
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Router /permissions [get]
+func (c *RoleHandler) GetPermissions(echoContext echo.Context) error {
+	res := domain.Response{
+		Data:    domain.Permissions,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetAll godoc
+// @Summary Get All Roles.
+// @Description Get the default roles and the custom roles of the organization with their permissions.
+// @Tags roles
+// @Accept */*
+// @Produce json
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /roles [get]
+func (c *RoleHandler) GetAll(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	start, limit := 0, 10
+	var err error
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		}
+	}
+
+	list, err := c.RoleUseCase.GetAll(ctx, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary Get role by ID.
+// @Description Get Specific role with its permissions.
+// @Tags roles
+// @Accept */*
+// @Produce json
+// @Param id path int true "role Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /roles/{id} [get]
+func (c *RoleHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	role, err := c.RoleUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    role,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// CreateRole godoc
+// @Summary Create New role
+// @Description Create a custom role with the given permissions
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param role body domain.Role true "role Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Unknown permission"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Code already exists"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /roles [post]
+func (c *RoleHandler) CreateRole(echoContext echo.Context) error {
+	var role domain.Role
+	err := echoContext.Bind(&role)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&role); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.RoleUseCase.CreateRole(ctx, &role)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    role,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusCreated, res)
+}
+
+// UpdateRole godoc
+// @Summary Update existing role
+// @Description Update a custom role and replace its permissions. Default roles are shared by every organization and can not be changed.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param id path int true "role Id"
+// @Param role body domain.Role true "role Data"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Code already exists or default role"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /roles/{id} [put]
+func (c *RoleHandler) UpdateRole(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var role domain.Role
+	err = echoContext.Bind(&role)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&role); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.RoleUseCase.UpdateRole(ctx, &role, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    role,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// DeleteRole godoc
+// @Summary Delete existing role
+// @Description Delete a custom role. Default roles and roles assigned to users can not be deleted.
+// @Tags roles
+// @Accept */*
+// @Produce json
+// @Param id path int true "role Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Default role or role in use"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /roles/{id} [delete]
+func (c *RoleHandler) DeleteRole(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.RoleUseCase.DeleteRole(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
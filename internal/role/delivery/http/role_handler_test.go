@@ -0,0 +1,205 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	roleHTTP "github.com/meroedu/meroedu/internal/role/delivery/http"
+)
+
+func TestGetPermissions(t *testing.T) {
+	mockUCase := new(mocks.RoleUseCase)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/permissions", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := roleHTTP.RoleHandler{
+		RoleUseCase: mockUCase,
+	}
+	err = handler.GetPermissions(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res struct {
+		Data []domain.Permission `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, domain.Permissions, res.Data)
+}
+
+func TestGetAll(t *testing.T) {
+	mockUCase := new(mocks.RoleUseCase)
+	mockUCase.On("GetAll", mock.Anything, 0, 20).Return([]domain.Role{{ID: 1, Code: "learner", Name: "Learner"}}, nil).Once()
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"limit=20", http.StatusOK},
+		{"limit=all", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/roles?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := roleHTTP.RoleHandler{
+			RoleUseCase: mockUCase,
+		}
+		err = handler.GetAll(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.query)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	mockUCase := new(mocks.RoleUseCase)
+	mockUCase.On("GetByID", mock.Anything, int64(1)).Return(&domain.Role{ID: 1, Code: "learner", Name: "Learner"}, nil).Once()
+	mockUCase.On("GetByID", mock.Anything, int64(9)).Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"1", http.StatusOK},
+		{"9", http.StatusNotFound},
+		{"learner", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/roles/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/roles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := roleHTTP.RoleHandler{
+			RoleUseCase: mockUCase,
+		}
+		err = handler.GetByID(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateRole(t *testing.T) {
+	mockUCase := new(mocks.RoleUseCase)
+	mockUCase.On("CreateRole", mock.Anything, mock.MatchedBy(func(r *domain.Role) bool { return r.Code == "reviewer" })).Return(nil).Once()
+	mockUCase.On("CreateRole", mock.Anything, mock.MatchedBy(func(r *domain.Role) bool { return r.Code == "learner" })).Return(domain.ErrConflict).Once()
+	mockUCase.On("CreateRole", mock.Anything, mock.MatchedBy(func(r *domain.Role) bool { return r.Code == "root" })).Return(domain.ErrForbidden).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"code":"reviewer","name":"Reviewer","permissions":["course:view"]}`, http.StatusCreated},
+		{`{"code":"learner","name":"Learner","permissions":["course:view"]}`, http.StatusConflict},
+		{`{"code":"root","name":"Root","permissions":["role:manage"]}`, http.StatusForbidden},
+		{`{"name":"Reviewer"}`, http.StatusBadRequest},
+		{`{"code":"reviewer","permissions":"course:view"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/roles", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := roleHTTP.RoleHandler{
+			RoleUseCase: mockUCase,
+		}
+		err = handler.CreateRole(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdateRole(t *testing.T) {
+	mockUCase := new(mocks.RoleUseCase)
+	mockUCase.On("UpdateRole", mock.Anything, mock.AnythingOfType("*domain.Role"), int64(5)).Return(nil).Once()
+	mockUCase.On("UpdateRole", mock.Anything, mock.AnythingOfType("*domain.Role"), int64(1)).Return(domain.ErrForbidden).Once()
+
+	tests := []struct {
+		id   string
+		body string
+		code int
+	}{
+		{"5", `{"code":"reviewer","name":"Reviewer","permissions":["course:view"]}`, http.StatusOK},
+		{"1", `{"code":"learner","name":"Learner","permissions":["course:view"]}`, http.StatusForbidden},
+		{"5", `{"code":"reviewer"}`, http.StatusBadRequest},
+		{"5", `{"code":`, http.StatusUnprocessableEntity},
+		{"reviewer", `{"code":"reviewer","name":"Reviewer"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/roles/"+tt.id, strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/roles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := roleHTTP.RoleHandler{
+			RoleUseCase: mockUCase,
+		}
+		err = handler.UpdateRole(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteRole(t *testing.T) {
+	mockUCase := new(mocks.RoleUseCase)
+	mockUCase.On("DeleteRole", mock.Anything, int64(5)).Return(nil).Once()
+	mockUCase.On("DeleteRole", mock.Anything, int64(6)).Return(domain.ErrConflict).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"5", http.StatusNoContent},
+		{"6", http.StatusConflict},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/roles/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/roles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := roleHTTP.RoleHandler{
+			RoleUseCase: mockUCase,
+		}
+		err = handler.DeleteRole(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
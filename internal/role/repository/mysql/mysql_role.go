@@ -0,0 +1,236 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const roleQuery = `SELECT r.id,r.code,r.name,r.description,r.organization_id,r.createdBy,r.updated_at,r.created_at,
+	COALESCE(GROUP_CONCAT(rp.permission ORDER BY rp.permission),'')
+	FROM roles r LEFT JOIN roles_permissions rp ON rp.role_id = r.id`
+
+// visible matches the default roles and the custom roles of the caller's organization
+const visible = `(r.organization_id IS NULL OR r.organization_id = ?)`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the role's Repository interface
+func Init(db *sql.DB) domain.RoleRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Role, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Role, 0)
+	for rows.Next() {
+		t := domain.Role{}
+		description, organizationID := sql.NullString{}, sql.NullInt64{}
+		permissions := ""
+		err = rows.Scan(
+			&t.ID,
+			&t.Code,
+			&t.Name,
+			&description,
+			&organizationID,
+			&t.CreatedBy,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&permissions,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.Description = description.String
+		t.OrganizationID = organizationID.Int64
+		t.Permissions = make([]domain.Permission, 0)
+		if permissions != "" {
+			for _, p := range strings.Split(permissions, ",") {
+				t.Permissions = append(t.Permissions, domain.Permission(p))
+			}
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) getOne(ctx context.Context, query string, args ...interface{}) (*domain.Role, error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+func (m *mysqlRepository) GetAll(ctx context.Context, start int, limit int) ([]domain.Role, error) {
+	query := roleQuery + ` WHERE ` + visible + ` GROUP BY r.id ORDER BY r.id LIMIT ?,?`
+	return m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.Role, error) {
+	query := roleQuery + ` WHERE r.id = ? AND ` + visible + ` GROUP BY r.id`
+	return m.getOne(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+}
+
+func (m *mysqlRepository) GetByCode(ctx context.Context, code string) (*domain.Role, error) {
+	query := roleQuery + ` WHERE r.code = ? AND ` + visible + ` GROUP BY r.id ORDER BY r.organization_id`
+	return m.getOne(ctx, query, code, domain.OrganizationIDFromContext(ctx))
+}
+
+func insertPermissions(ctx context.Context, tx *sql.Tx, roleID int64, permissions []domain.Permission) error {
+	for _, p := range permissions {
+		_, err := tx.ExecContext(ctx, `INSERT roles_permissions SET role_id=?,permission=?`, roleID, string(p))
+		if err != nil {
+			log.Error("Error while executing statement ", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mysqlRepository) CreateRole(ctx context.Context, r *domain.Role) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	r.OrganizationID = domain.OrganizationIDFromContext(ctx)
+	query := `INSERT roles SET code=?,name=?,description=?,organization_id=?,createdBy=?,updated_at=?,created_at=?`
+	res, err := tx.ExecContext(ctx, query, r.Code, r.Name, r.Description, r.OrganizationID, r.CreatedBy, r.UpdatedAt, r.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	r.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return
+	}
+	return insertPermissions(ctx, tx, r.ID, r.Permissions)
+}
+
+// UpdateRole updates the custom role and replaces its permissions
+func (m *mysqlRepository) UpdateRole(ctx context.Context, r *domain.Role) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `UPDATE roles SET code=?,name=?,description=?,updated_at=? WHERE id = ? AND organization_id = ?`
+	res, err := tx.ExecContext(ctx, query, r.Code, r.Name, r.Description, r.UpdatedAt, r.ID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		return fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM roles_permissions WHERE role_id = ?`, r.ID); err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	return insertPermissions(ctx, tx, r.ID, r.Permissions)
+}
+
+func (m *mysqlRepository) DeleteRole(ctx context.Context, id int64) error {
+	query := `DELETE FROM roles WHERE id = ? AND organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mysqlRepository) GetUserCount(ctx context.Context, roleID int64) (count int64, err error) {
+	query := `SELECT COUNT(*) FROM users WHERE role_id = ? AND organization_id = ?`
+	err = m.conn.QueryRowContext(ctx, query, roleID, domain.OrganizationIDFromContext(ctx)).Scan(&count)
+	if err != nil {
+		log.Error(err)
+	}
+	return
+}
+
+// GetUserPermissions returns the permissions granted by the role of an active user
+func (m *mysqlRepository) GetUserPermissions(ctx context.Context, userID int64) ([]domain.Permission, error) {
+	query := `SELECT rp.permission FROM users u JOIN roles_permissions rp ON rp.role_id = u.role_id
+		WHERE u.id = ? AND u.organization_id = ? AND u.status = ?`
+	rows, err := m.conn.QueryContext(ctx, query, userID, domain.OrganizationIDFromContext(ctx), domain.UserActive)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result := make([]domain.Permission, 0)
+	for rows.Next() {
+		var p string
+		if err = rows.Scan(&p); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result = append(result, domain.Permission(p))
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	repository "github.com/meroedu/meroedu/internal/role/repository/mysql"
+)
+
+var roleColumns = []string{"id", "code", "name", "description", "organization_id", "createdBy", "updated_at", "created_at", "permissions"}
+
+func TestGetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows(roleColumns).
+		AddRow(1, "admin", "Administrator", nil, nil, 0, 10, 10, "course:create,course:view").
+		AddRow(2, "empty", "Empty", "no permissions", 2, 1, 10, 10, "")
+	mock.ExpectQuery("SELECT (.+) FROM roles r LEFT JOIN roles_permissions rp (.+) WHERE \\(r.organization_id IS NULL OR r.organization_id = \\?\\) GROUP BY r.id").
+		WithArgs(int64(2), 0, 10).WillReturnRows(rows)
+
+	r := repository.Init(db)
+	list, err := r.GetAll(domain.WithOrganizationID(context.TODO(), 2), 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, int64(0), list[0].OrganizationID)
+	assert.Equal(t, int64(2), list[1].OrganizationID)
+	assert.Equal(t, []domain.Permission{domain.PermCourseCreate, domain.PermCourseView}, list[0].Permissions)
+	assert.Empty(t, list[1].Permissions)
+	assert.Equal(t, "no permissions", list[1].Description)
+}
+
+func TestUpdateRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	role := &domain.Role{ID: 3, Code: "reviewer", Name: "Reviewer", UpdatedAt: 20,
+		Permissions: []domain.Permission{domain.PermReportView}}
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE roles SET (.+) WHERE id = \\? AND organization_id = \\?").
+		WithArgs(role.Code, role.Name, role.Description, role.UpdatedAt, role.ID, int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM roles_permissions WHERE role_id = \\?").WithArgs(role.ID).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT roles_permissions SET").WithArgs(role.ID, "report:view").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	r := repository.Init(db)
+	err = r.UpdateRole(domain.WithOrganizationID(context.TODO(), 2), role)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUserPermissions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	rows := sqlmock.NewRows([]string{"permission"}).AddRow("course:view").AddRow("report:view")
+	mock.ExpectQuery("SELECT rp.permission FROM users u JOIN roles_permissions rp").
+		WithArgs(int64(4), int64(2), domain.UserActive).WillReturnRows(rows)
+
+	r := repository.Init(db)
+	permissions, err := r.GetUserPermissions(domain.WithOrganizationID(context.TODO(), 2), 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Permission{domain.PermCourseView, domain.PermReportView}, permissions)
+}
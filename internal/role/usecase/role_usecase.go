@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// RoleUseCase ...
+type RoleUseCase struct {
+	roleRepo       domain.RoleRepository
+	contextTimeOut time.Duration
+}
+
+// NewRoleUseCase will create new an
+func NewRoleUseCase(r domain.RoleRepository, timeout time.Duration) domain.RoleUseCase {
+	return &RoleUseCase{
+		roleRepo:       r,
+		contextTimeOut: timeout,
+	}
+}
+
+// normalizePermissions rejects unknown permissions and drops duplicates.
+// A caller can only grant permissions they hold themselves.
+func normalizePermissions(ctx context.Context, permissions []domain.Permission) ([]domain.Permission, error) {
+	seen := make(map[domain.Permission]bool, len(permissions))
+	result := make([]domain.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		if !p.IsValid() {
+			return nil, domain.ErrBadParamInput
+		}
+		if !domain.HasPermission(ctx, p) {
+			return nil, domain.ErrForbidden
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// GetAll ...
+func (usecase *RoleUseCase) GetAll(c context.Context, start int, limit int) ([]domain.Role, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.roleRepo.GetAll(ctx, start, limit)
+}
+
+// GetByID ...
+func (usecase *RoleUseCase) GetByID(c context.Context, id int64) (*domain.Role, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.roleRepo.GetByID(ctx, id)
+}
+
+// CreateRole ...
+func (usecase *RoleUseCase) CreateRole(c context.Context, role *domain.Role) (err error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if role.Permissions, err = normalizePermissions(ctx, role.Permissions); err != nil {
+		return err
+	}
+	existing, err := usecase.roleRepo.GetByCode(ctx, role.Code)
+	if err != nil && err != domain.ErrNotFound {
+		return err
+	}
+	if existing != nil {
+		return domain.ErrConflict
+	}
+	role.CreatedBy = domain.UserIDFromContext(ctx)
+	role.UpdatedAt = time.Now().Unix()
+	role.CreatedAt = time.Now().Unix()
+	return usecase.roleRepo.CreateRole(ctx, role)
+}
+
+// UpdateRole updates a custom role of the organization and replaces its permissions.
+// The default roles are shared by every organization and can not be changed.
+func (usecase *RoleUseCase) UpdateRole(c context.Context, role *domain.Role, id int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if role.Permissions, err = normalizePermissions(ctx, role.Permissions); err != nil {
+		return err
+	}
+	existing, err := usecase.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing.OrganizationID == 0 {
+		return domain.ErrConflict
+	}
+	if role.Code != existing.Code {
+		other, err := usecase.roleRepo.GetByCode(ctx, role.Code)
+		if err != nil && err != domain.ErrNotFound {
+			return err
+		}
+		if other != nil {
+			return domain.ErrConflict
+		}
+	}
+	role.ID = id
+	role.CreatedBy = existing.CreatedBy
+	role.CreatedAt = existing.CreatedAt
+	role.UpdatedAt = time.Now().Unix()
+	return usecase.roleRepo.UpdateRole(ctx, role)
+}
+
+// DeleteRole deletes a custom role of the organization that is not assigned to any user
+func (usecase *RoleUseCase) DeleteRole(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existing, err := usecase.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing.OrganizationID == 0 {
+		return domain.ErrConflict
+	}
+	count, err := usecase.roleRepo.GetUserCount(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrConflict
+	}
+	return usecase.roleRepo.DeleteRole(ctx, id)
+}
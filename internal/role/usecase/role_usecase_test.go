@@ -0,0 +1,133 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/role/usecase"
+)
+
+func TestCreateRole(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		role := domain.Role{Code: "reviewer", Name: "Reviewer",
+			Permissions: []domain.Permission{domain.PermCourseView, domain.PermReportView, domain.PermCourseView}}
+		mockRoleRepo.On("GetByCode", mock.Anything, "reviewer").Return(nil, domain.ErrNotFound).Once()
+		mockRoleRepo.On("CreateRole", mock.Anything, mock.AnythingOfType("*domain.Role")).Return(nil).Once()
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		ctx := domain.WithPermissions(domain.WithUserID(context.TODO(), 7),
+			[]domain.Permission{domain.PermRoleManage, domain.PermCourseView, domain.PermReportView})
+		err := u.CreateRole(ctx, &role)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Permission{domain.PermCourseView, domain.PermReportView}, role.Permissions)
+		assert.Equal(t, int64(7), role.CreatedBy)
+		mockRoleRepo.AssertExpectations(t)
+	})
+	t.Run("unknown-permission", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		role := domain.Role{Code: "reviewer", Name: "Reviewer", Permissions: []domain.Permission{"course:fly"}}
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		err := u.CreateRole(context.TODO(), &role)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockRoleRepo.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+	t.Run("organization-manage-needs-holder", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		role := domain.Role{Code: "owner", Name: "Owner", Permissions: []domain.Permission{domain.PermOrganizationManage}}
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		err := u.CreateRole(domain.WithPermissions(context.TODO(), []domain.Permission{domain.PermRoleManage}), &role)
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockRoleRepo.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+	t.Run("permission-needs-holder", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		role := domain.Role{Code: "reviewer", Name: "Reviewer",
+			Permissions: []domain.Permission{domain.PermCourseView, domain.PermReportView}}
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		err := u.CreateRole(domain.WithPermissions(context.TODO(), []domain.Permission{domain.PermRoleManage, domain.PermCourseView}), &role)
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockRoleRepo.AssertNotCalled(t, "GetByCode", mock.Anything, mock.Anything)
+		mockRoleRepo.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+	t.Run("conflict", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		role := domain.Role{Code: "admin", Name: "Admin"}
+		mockRoleRepo.On("GetByCode", mock.Anything, "admin").Return(&domain.Role{ID: 1, Code: "admin"}, nil).Once()
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		err := u.CreateRole(context.TODO(), &role)
+		assert.Equal(t, domain.ErrConflict, err)
+	})
+}
+
+func TestUpdateRole(t *testing.T) {
+	t.Run("default-is-shared", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockRoleRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Role{ID: 1, Code: domain.RoleLearner}, nil).Once()
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		err := u.UpdateRole(context.TODO(), &domain.Role{Code: domain.RoleLearner, Name: "Student"}, 1)
+		assert.Equal(t, domain.ErrConflict, err)
+		mockRoleRepo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything)
+	})
+	t.Run("success", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockRoleRepo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Role{ID: 5, Code: "reviewer", OrganizationID: 2, CreatedAt: 10}, nil).Once()
+		mockRoleRepo.On("UpdateRole", mock.Anything, mock.AnythingOfType("*domain.Role")).Return(nil).Once()
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		role := domain.Role{Code: "reviewer", Name: "Course reviewer", Permissions: []domain.Permission{domain.PermCourseView}}
+		err := u.UpdateRole(domain.WithPermissions(context.TODO(), []domain.Permission{domain.PermCourseView}), &role, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), role.CreatedAt)
+		mockRoleRepo.AssertExpectations(t)
+	})
+	t.Run("permission-needs-holder", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		role := domain.Role{Code: "reviewer", Name: "Course reviewer", Permissions: []domain.Permission{domain.PermUserManage}}
+		err := u.UpdateRole(domain.WithPermissions(context.TODO(), []domain.Permission{domain.PermRoleManage}), &role, 5)
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockRoleRepo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteRole(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockRoleRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Role{ID: 1, Code: domain.RoleInstructor}, nil).Once()
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		assert.Equal(t, domain.ErrConflict, u.DeleteRole(context.TODO(), 1))
+	})
+	t.Run("in-use", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockRoleRepo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Role{ID: 5, Code: "reviewer", OrganizationID: 2}, nil).Once()
+		mockRoleRepo.On("GetUserCount", mock.Anything, int64(5)).Return(int64(2), nil).Once()
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		assert.Equal(t, domain.ErrConflict, u.DeleteRole(context.TODO(), 5))
+		mockRoleRepo.AssertNotCalled(t, "DeleteRole", mock.Anything, mock.Anything)
+	})
+	t.Run("success", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockRoleRepo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Role{ID: 5, Code: "reviewer", OrganizationID: 2}, nil).Once()
+		mockRoleRepo.On("GetUserCount", mock.Anything, int64(5)).Return(int64(0), nil).Once()
+		mockRoleRepo.On("DeleteRole", mock.Anything, int64(5)).Return(nil).Once()
+
+		u := ucase.NewRoleUseCase(mockRoleRepo, time.Second*2)
+		assert.NoError(t, u.DeleteRole(context.TODO(), 5))
+		mockRoleRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,233 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// RubricHandler ...
+type RubricHandler struct {
+	RubricUseCase domain.RubricUseCase
+}
+
+// NewRubricHandler ...
+func NewRubricHandler(e *echo.Echo, us domain.RubricUseCase) {
+	handler := &RubricHandler{
+		RubricUseCase: us,
+	}
+	e.GET("/rubrics", handler.GetAll, rbac.Require(domain.PermCourseUpdate))
+	e.GET("/rubrics/:id", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.POST("/rubrics", handler.CreateRubric, rbac.Require(domain.PermCourseUpdate))
+	e.PUT("/rubrics/:id", handler.UpdateRubric, rbac.Require(domain.PermCourseUpdate))
+	e.DELETE("/rubrics/:id", handler.DeleteRubric, rbac.Require(domain.PermCourseUpdate))
+	e.GET("/courses/:id/rubrics/:rubric_id/statistics", handler.GetStatistics, rbac.Require(domain.PermCourseUpdate))
+}
+
+// startLimit parses the start and limit query params, 0 and 10 by default
+func startLimit(echoContext echo.Context) (start int, limit int, err error) {
+	start, limit = 0, 10
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// GetAll godoc
+// @Summary Get the rubrics.
+// @Description Get the rubrics of the organization.
+// @Tags rubrics
+// @Accept */*
+// @Produce json
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /rubrics [get]
+func (c *RubricHandler) GetAll(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	start, limit, err := startLimit(echoContext)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	list, err := c.RubricUseCase.GetAll(ctx, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary Get a rubric.
+// @Description Get a rubric with its criteria and their levels.
+// @Tags rubrics
+// @Accept */*
+// @Produce json
+// @Param id path int true "Rubric Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /rubrics/{id} [get]
+func (c *RubricHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	rubric, err := c.RubricUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: rubric, Message: domain.Success})
+}
+
+// CreateRubric godoc
+// @Summary Add a rubric.
+// @Description Add a rubric with criteria and their levels of performance. The points of the rubric are the total
+// @Description of the best levels of its criteria.
+// @Tags rubrics
+// @Accept json
+// @Produce json
+// @Param rubric body domain.Rubric true "rubric Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "No criteria, or no points"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /rubrics [post]
+func (c *RubricHandler) CreateRubric(echoContext echo.Context) error {
+	var rubric domain.Rubric
+	err := echoContext.Bind(&rubric)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&rubric); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.RubricUseCase.CreateRubric(ctx, &rubric)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusCreated, domain.Response{Data: rubric, Message: domain.Success})
+}
+
+// UpdateRubric godoc
+// @Summary Update a rubric.
+// @Description Update a rubric. Only the title and the description of a rubric used by an assignment or a question, or with
+// @Description scores given with it, can change.
+// @Tags rubrics
+// @Accept json
+// @Produce json
+// @Param id path int true "Rubric Id"
+// @Param rubric body domain.Rubric true "rubric Data"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError "No criteria, or no points"
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "The criteria or levels of a used rubric changed"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /rubrics/{id} [put]
+func (c *RubricHandler) UpdateRubric(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var rubric domain.Rubric
+	err = echoContext.Bind(&rubric)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&rubric); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.RubricUseCase.UpdateRubric(ctx, &rubric, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// DeleteRubric godoc
+// @Summary Delete a rubric.
+// @Description Delete a rubric. A rubric used by an assignment or a question, or with scores given with it, can not be deleted.
+// @Tags rubrics
+// @Accept */*
+// @Produce json
+// @Param id path int true "Rubric Id"
+// @Success 204
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "The rubric is used"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /rubrics/{id} [delete]
+func (c *RubricHandler) DeleteRubric(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.RubricUseCase.DeleteRubric(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// GetStatistics godoc
+// @Summary Get the statistics of a rubric in a course.
+// @Description Get the count, mean, standard deviation, minimum and maximum of the totals of the works of a course
+// @Description marked with a rubric and of the scores of each criterion, with the number of times each level was given.
+// @Tags rubrics
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param rubric_id path int true "Rubric Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/rubrics/{rubric_id}/statistics [get]
+func (c *RubricHandler) GetStatistics(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	rubricID, err := strconv.Atoi(echoContext.Param("rubric_id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	stats, err := c.RubricUseCase.GetStatistics(ctx, int64(rubricID), int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: stats, Message: domain.Success})
+}
@@ -0,0 +1,173 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	rubricHTTP "github.com/meroedu/meroedu/internal/rubric/delivery/http"
+)
+
+const essay = `{"title":"Essay","criteria":[{"title":"Argument","levels":[{"title":"Weak","points":1},{"title":"Strong","points":4}]}]}`
+
+func TestGetAll(t *testing.T) {
+	mockUCase := new(mocks.RubricUseCase)
+	mockUCase.On("GetAll", mock.Anything, 0, 10).Return([]domain.Rubric{{ID: 7, Title: "Essay"}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/rubrics?start=0&limit=10", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rubricHTTP.RubricHandler{
+		RubricUseCase: mockUCase,
+	}
+	err = handler.GetAll(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	mockUCase := new(mocks.RubricUseCase)
+	mockUCase.On("GetByID", mock.Anything, int64(7)).Return(&domain.Rubric{ID: 7, Title: "Essay"}, nil).Once()
+	mockUCase.On("GetByID", mock.Anything, int64(8)).Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"7", http.StatusOK},
+		{"8", http.StatusNotFound},
+		{"essay", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/rubrics/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/rubrics/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := rubricHTTP.RubricHandler{
+			RubricUseCase: mockUCase,
+		}
+		err = handler.GetByID(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateRubric(t *testing.T) {
+	mockUCase := new(mocks.RubricUseCase)
+	mockUCase.On("CreateRubric", mock.Anything, mock.AnythingOfType("*domain.Rubric")).Return(nil).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{essay, http.StatusCreated},
+		{`{"title":`, http.StatusUnprocessableEntity},
+		{`{"title":"Essay","criteria":[]}`, http.StatusBadRequest},
+		{`{"title":"Essay","criteria":[{"title":"Argument","levels":[{"points":1}]}]}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/rubrics", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := rubricHTTP.RubricHandler{
+			RubricUseCase: mockUCase,
+		}
+		err = handler.CreateRubric(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdateRubricInUse(t *testing.T) {
+	mockUCase := new(mocks.RubricUseCase)
+	mockUCase.On("UpdateRubric", mock.Anything, mock.AnythingOfType("*domain.Rubric"), int64(7)).Return(domain.ErrConflict).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/rubrics/7", strings.NewReader(essay))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/rubrics/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	handler := rubricHTTP.RubricHandler{
+		RubricUseCase: mockUCase,
+	}
+	err = handler.UpdateRubric(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteRubric(t *testing.T) {
+	mockUCase := new(mocks.RubricUseCase)
+	mockUCase.On("DeleteRubric", mock.Anything, int64(7)).Return(nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/rubrics/7", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/rubrics/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	handler := rubricHTTP.RubricHandler{
+		RubricUseCase: mockUCase,
+	}
+	err = handler.DeleteRubric(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetStatisticsForbidden(t *testing.T) {
+	mockUCase := new(mocks.RubricUseCase)
+	mockUCase.On("GetStatistics", mock.Anything, int64(7), int64(12)).Return(nil, domain.ErrForbidden).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/courses/12/rubrics/7/statistics", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/rubrics/:rubric_id/statistics")
+	c.SetParamNames("id", "rubric_id")
+	c.SetParamValues("12", "7")
+	handler := rubricHTTP.RubricHandler{
+		RubricUseCase: mockUCase,
+	}
+	err = handler.GetStatistics(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
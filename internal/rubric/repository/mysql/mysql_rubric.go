@@ -0,0 +1,284 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const rubricQuery = `SELECT id,title,description,criteria,max_points,created_by,updated_at,created_at FROM rubrics`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the rubric's Repository interface
+func Init(db *sql.DB) domain.RubricRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func nullInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: i, Valid: i != 0}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Rubric, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Rubric, 0)
+	for rows.Next() {
+		t := domain.Rubric{}
+		var description sql.NullString
+		var createdBy sql.NullInt64
+		var criteria string
+		err = rows.Scan(
+			&t.ID,
+			&t.Title,
+			&description,
+			&criteria,
+			&t.MaxPoints,
+			&createdBy,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.Description = description.String
+		t.CreatedBy = createdBy.Int64
+		if err = json.Unmarshal([]byte(criteria), &t.Criteria); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) GetAll(ctx context.Context, start int, limit int) ([]domain.Rubric, error) {
+	query := rubricQuery + ` WHERE organization_id = ? ORDER BY title LIMIT ?,?`
+	return m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.Rubric, error) {
+	query := rubricQuery + ` WHERE id = ? AND organization_id = ?`
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// CreateRubric adds the rubric to the caller's organization
+func (m *mysqlRepository) CreateRubric(ctx context.Context, r *domain.Rubric) error {
+	criteria, err := json.Marshal(r.Criteria)
+	if err != nil {
+		return err
+	}
+	query := `INSERT rubrics SET organization_id=?,title=?,description=?,criteria=?,max_points=?,created_by=?,updated_at=?,created_at=?`
+	res, err := m.conn.ExecContext(ctx, query, domain.OrganizationIDFromContext(ctx), r.Title, nullString(r.Description), criteria,
+		r.MaxPoints, nullInt64(r.CreatedBy), r.UpdatedAt, r.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	r.ID, err = res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+	}
+	return err
+}
+
+func (m *mysqlRepository) UpdateRubric(ctx context.Context, r *domain.Rubric) error {
+	criteria, err := json.Marshal(r.Criteria)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE rubrics SET title=?,description=?,criteria=?,max_points=?,updated_at=? WHERE id = ? AND organization_id = ?`
+	_, err = m.conn.ExecContext(ctx, query, r.Title, nullString(r.Description), criteria, r.MaxPoints, r.UpdatedAt, r.ID,
+		domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+	}
+	return err
+}
+
+// DeleteRubric removes the rubric with the scores given with it
+func (m *mysqlRepository) DeleteRubric(ctx context.Context, id int64) error {
+	query := `DELETE FROM rubrics WHERE id = ? AND organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// IsUsed reports whether an assignment or a question is marked with the rubric, or a work was scored with it
+func (m *mysqlRepository) IsUsed(ctx context.Context, id int64) (used bool, err error) {
+	query := `SELECT EXISTS(SELECT 1 FROM assignments WHERE rubric_id = ?) OR EXISTS(SELECT 1 FROM questions WHERE rubric_id = ?)
+		OR EXISTS(SELECT 1 FROM rubric_scores WHERE rubric_id = ?)`
+	if err = m.conn.QueryRowContext(ctx, query, id, id, id).Scan(&used); err != nil {
+		log.Error(err)
+	}
+	return
+}
+
+// Record replaces the scores given to a work with the scores of the criteria of its rubric
+func (m *mysqlRepository) Record(ctx context.Context, work *domain.RubricWork, scores []domain.CriterionScore) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	submissionID, attemptID, questionID := nullInt64(work.SubmissionID), nullInt64(work.AttemptID), nullInt64(work.QuestionID)
+	query := `DELETE FROM rubric_scores WHERE submission_id <=> ? AND attempt_id <=> ? AND question_id <=> ?`
+	if _, err = tx.ExecContext(ctx, query, submissionID, attemptID, questionID); err != nil {
+		log.Error(err)
+		return
+	}
+	query = `INSERT rubric_scores SET rubric_id=?,course_id=?,submission_id=?,attempt_id=?,question_id=?,criterion_id=?,level_id=?,
+		points=?,comment=?,graded_by=?,graded_at=?`
+	for _, s := range scores {
+		_, err = tx.ExecContext(ctx, query, work.RubricID, work.CourseID, submissionID, attemptID, questionID, s.CriterionID, s.LevelID,
+			s.Score, nullString(s.Comment), nullInt64(work.GradedBy), work.GradedAt)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+	}
+	return
+}
+
+// GetStatistics returns the statistics of the scores given with a rubric in a course, in total and for each
+// criterion and level scored
+func (m *mysqlRepository) GetStatistics(ctx context.Context, rubricID int64, courseID int64) (*domain.RubricStatistics, error) {
+	orgID := domain.OrganizationIDFromContext(ctx)
+	stats := &domain.RubricStatistics{RubricID: rubricID, CourseID: courseID, Criteria: make([]domain.CriterionStatistics, 0)}
+	query := `SELECT COUNT(*),COALESCE(AVG(t.total),0),COALESCE(STDDEV_POP(t.total),0),COALESCE(MIN(t.total),0),COALESCE(MAX(t.total),0)
+		FROM (SELECT SUM(s.points) total FROM rubric_scores s JOIN rubrics r ON r.id = s.rubric_id
+		WHERE s.rubric_id = ? AND s.course_id = ? AND r.organization_id = ? GROUP BY s.submission_id,s.attempt_id,s.question_id) t`
+	err := m.conn.QueryRowContext(ctx, query, rubricID, courseID, orgID).Scan(&stats.Count, &stats.Mean, &stats.StdDev, &stats.Min,
+		&stats.Max)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if stats.Criteria, err = m.criterionStatistics(ctx, rubricID, courseID, orgID); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (m *mysqlRepository) criterionStatistics(ctx context.Context, rubricID int64, courseID int64,
+	orgID int64) (result []domain.CriterionStatistics, err error) {
+	query := `SELECT s.criterion_id,COUNT(*),AVG(s.points),STDDEV_POP(s.points),MIN(s.points),MAX(s.points) FROM rubric_scores s
+		JOIN rubrics r ON r.id = s.rubric_id WHERE s.rubric_id = ? AND s.course_id = ? AND r.organization_id = ?
+		GROUP BY s.criterion_id ORDER BY s.criterion_id`
+	rows, err := m.conn.QueryContext(ctx, query, rubricID, courseID, orgID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.CriterionStatistics, 0)
+	byID := make(map[int]int)
+	for rows.Next() {
+		t := domain.CriterionStatistics{Levels: make([]domain.LevelCount, 0)}
+		if err = rows.Scan(&t.CriterionID, &t.Count, &t.Mean, &t.StdDev, &t.Min, &t.Max); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		byID[t.CriterionID] = len(result)
+		result = append(result, t)
+	}
+	levels, err := m.levelCounts(ctx, rubricID, courseID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for criterionID, counts := range levels {
+		if i, ok := byID[criterionID]; ok {
+			result[i].Levels = counts
+		}
+	}
+	return result, nil
+}
+
+// levelCounts returns the number of times each level of the criteria was given
+func (m *mysqlRepository) levelCounts(ctx context.Context, rubricID int64, courseID int64,
+	orgID int64) (result map[int][]domain.LevelCount, err error) {
+	query := `SELECT s.criterion_id,s.level_id,COUNT(*) FROM rubric_scores s JOIN rubrics r ON r.id = s.rubric_id
+		WHERE s.rubric_id = ? AND s.course_id = ? AND r.organization_id = ? GROUP BY s.criterion_id,s.level_id
+		ORDER BY s.criterion_id,s.level_id`
+	rows, err := m.conn.QueryContext(ctx, query, rubricID, courseID, orgID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make(map[int][]domain.LevelCount)
+	for rows.Next() {
+		var criterionID int
+		t := domain.LevelCount{}
+		if err = rows.Scan(&criterionID, &t.LevelID, &t.Count); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result[criterionID] = append(result[criterionID], t)
+	}
+	return result, nil
+}
@@ -0,0 +1,149 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/rubric/repository/mysql"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+var rubricColumns = []string{"id", "title", "description", "criteria", "max_points", "created_by", "updated_at", "created_at"}
+
+func TestGetByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`SELECT .+ FROM rubrics WHERE id = \? AND organization_id = \?`).
+			WithArgs(7, 2).
+			WillReturnRows(sqlmock.NewRows(rubricColumns).AddRow(7, "Essay", nil,
+				`[{"id":1,"title":"Argument","levels":[{"id":1,"title":"Weak","points":1},{"id":2,"title":"Strong","points":4}]}]`,
+				4, 4, 100, 100))
+
+		repo := mysqlrepo.Init(db)
+		rubric, err := repo.GetByID(orgCtx, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Rubric{ID: 7, Title: "Essay", Criteria: []domain.RubricCriterion{{ID: 1, Title: "Argument",
+			Levels: []domain.RubricLevel{{ID: 1, Title: "Weak", Points: 1}, {ID: 2, Title: "Strong", Points: 4}}}}, MaxPoints: 4,
+			CreatedBy: 4, UpdatedAt: 100, CreatedAt: 100}, rubric)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery(`FROM rubrics`).WithArgs(7, 2).WillReturnRows(sqlmock.NewRows(rubricColumns))
+
+		repo := mysqlrepo.Init(db)
+		_, err = repo.GetByID(orgCtx, 7)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestCreateRubric(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`INSERT rubrics SET organization_id=\?,title=\?`).
+		WithArgs(2, "Essay", nil, []byte(`[{"id":1,"title":"Argument","levels":[{"id":1,"title":"Strong","points":4}]}]`), float64(4), 4,
+			100, 100).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	repo := mysqlrepo.Init(db)
+	rubric := &domain.Rubric{Title: "Essay", Criteria: []domain.RubricCriterion{{ID: 1, Title: "Argument",
+		Levels: []domain.RubricLevel{{ID: 1, Title: "Strong", Points: 4}}}}, MaxPoints: 4, CreatedBy: 4, UpdatedAt: 100, CreatedAt: 100}
+	assert.NoError(t, repo.CreateRubric(orgCtx, rubric))
+	assert.Equal(t, int64(7), rubric.ID)
+}
+
+func TestDeleteRubric(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`DELETE FROM rubrics WHERE id = \? AND organization_id = \?`).WithArgs(7, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	assert.Equal(t, domain.ErrNotFound, repo.DeleteRubric(orgCtx, 7))
+}
+
+func TestIsUsed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM assignments WHERE rubric_id = \?\) OR EXISTS\(SELECT 1 FROM questions WHERE rubric_id = \?\)
+		OR EXISTS\(SELECT 1 FROM rubric_scores WHERE rubric_id = \?\)`).
+		WithArgs(7, 7, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(true))
+
+	repo := mysqlrepo.Init(db)
+	used, err := repo.IsUsed(orgCtx, 7)
+	assert.NoError(t, err)
+	assert.True(t, used, "a rubric detached from its assignment stays in use while works are scored with it")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecord(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM rubric_scores WHERE submission_id <=> \? AND attempt_id <=> \? AND question_id <=> \?`).
+		WithArgs(nil, 2, 4).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`INSERT rubric_scores SET rubric_id=\?`).
+		WithArgs(7, 3, nil, 2, 4, 1, 2, float64(4), "Clear.", 4, 100).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT rubric_scores SET rubric_id=\?`).
+		WithArgs(7, 3, nil, 2, 4, 2, 1, float64(0), nil, 4, 100).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	repo := mysqlrepo.Init(db)
+	work := &domain.RubricWork{RubricID: 7, CourseID: 3, AttemptID: 2, QuestionID: 4, GradedBy: 4, GradedAt: 100}
+	err = repo.Record(orgCtx, work, []domain.CriterionScore{{CriterionID: 1, LevelID: 2, Score: 4, Comment: "Clear."},
+		{CriterionID: 2, LevelID: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetStatistics(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT COUNT\(\*\),.+ FROM \(SELECT SUM\(s.points\) total FROM rubric_scores s .+ GROUP BY s.submission_id,s.attempt_id,s.question_id\) t`).
+		WithArgs(7, 3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "mean", "std_dev", "min", "max"}).AddRow(2, 6, 1, 5, 7))
+	mock.ExpectQuery(`SELECT s.criterion_id,COUNT\(\*\),AVG\(s.points\).+ GROUP BY s.criterion_id ORDER BY s.criterion_id`).
+		WithArgs(7, 3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"criterion_id", "count", "mean", "std_dev", "min", "max"}).
+			AddRow(1, 2, 3.5, 0.5, 3, 4).
+			AddRow(2, 2, 2.5, 0.5, 2, 3))
+	mock.ExpectQuery(`SELECT s.criterion_id,s.level_id,COUNT\(\*\) .+ GROUP BY s.criterion_id,s.level_id`).
+		WithArgs(7, 3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"criterion_id", "level_id", "count"}).
+			AddRow(1, 2, 1).
+			AddRow(1, 3, 1).
+			AddRow(2, 2, 2))
+
+	repo := mysqlrepo.Init(db)
+	stats, err := repo.GetStatistics(orgCtx, 7, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.RubricStatistics{RubricID: 7, CourseID: 3, Count: 2, Mean: 6, StdDev: 1, Min: 5, Max: 7,
+		Criteria: []domain.CriterionStatistics{
+			{CriterionID: 1, Count: 2, Mean: 3.5, StdDev: 0.5, Min: 3, Max: 4, Levels: []domain.LevelCount{{LevelID: 2, Count: 1},
+				{LevelID: 3, Count: 1}}},
+			{CriterionID: 2, Count: 2, Mean: 2.5, StdDev: 0.5, Min: 2, Max: 3, Levels: []domain.LevelCount{{LevelID: 2, Count: 2}}},
+		}}, stats)
+}
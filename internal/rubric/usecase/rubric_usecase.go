@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// RubricUseCase ...
+type RubricUseCase struct {
+	rubricRepo          domain.RubricRepository
+	collaboratorUseCase domain.CollaboratorUseCase
+	contextTimeOut      time.Duration
+}
+
+// NewRubricUseCase will create new an RubricUseCase
+func NewRubricUseCase(r domain.RubricRepository, cu domain.CollaboratorUseCase, timeout time.Duration) domain.RubricUseCase {
+	return &RubricUseCase{
+		rubricRepo:          r,
+		collaboratorUseCase: cu,
+		contextTimeOut:      timeout,
+	}
+}
+
+// best returns the points of the best level of a criterion
+func best(criterion *domain.RubricCriterion) float64 {
+	points := 0.0
+	for _, level := range criterion.Levels {
+		if level.Points > points {
+			points = level.Points
+		}
+	}
+	return points
+}
+
+// validateRubric numbers the criteria of a rubric and their levels, and sets its points from them
+func validateRubric(r *domain.Rubric) error {
+	r.MaxPoints = 0
+	for i := range r.Criteria {
+		criterion := &r.Criteria[i]
+		criterion.ID = i + 1
+		for j := range criterion.Levels {
+			criterion.Levels[j].ID = j + 1
+		}
+		r.MaxPoints += best(criterion)
+	}
+	if len(r.Criteria) == 0 || r.MaxPoints <= 0 {
+		return domain.ErrBadParamInput
+	}
+	return nil
+}
+
+// sameCriteria tells whether two numbered rubrics have the same criteria and levels
+func sameCriteria(a, b []domain.RubricCriterion) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Title != b[i].Title || a[i].Description != b[i].Description || len(a[i].Levels) != len(b[i].Levels) {
+			return false
+		}
+		for j := range a[i].Levels {
+			if a[i].Levels[j] != b[i].Levels[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// GetAll returns the rubrics of the organization
+func (usecase *RubricUseCase) GetAll(c context.Context, start int, limit int) ([]domain.Rubric, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.rubricRepo.GetAll(ctx, start, limit)
+}
+
+// GetByID returns a rubric
+func (usecase *RubricUseCase) GetByID(c context.Context, id int64) (*domain.Rubric, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.rubricRepo.GetByID(ctx, id)
+}
+
+// CreateRubric adds a rubric to the organization
+func (usecase *RubricUseCase) CreateRubric(c context.Context, rubric *domain.Rubric) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := validateRubric(rubric); err != nil {
+		return err
+	}
+	rubric.CreatedBy = domain.UserIDFromContext(ctx)
+	rubric.UpdatedAt = time.Now().Unix()
+	rubric.CreatedAt = rubric.UpdatedAt
+	return usecase.rubricRepo.CreateRubric(ctx, rubric)
+}
+
+// UpdateRubric updates a rubric. Only the title and the description of a rubric used by an assignment or a question,
+// or with scores given with it, can change, so the scores given with its criteria and levels keep their meaning.
+func (usecase *RubricUseCase) UpdateRubric(c context.Context, rubric *domain.Rubric, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existedRubric, err := usecase.rubricRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = validateRubric(rubric); err != nil {
+		return err
+	}
+	if !sameCriteria(rubric.Criteria, existedRubric.Criteria) {
+		used, err := usecase.rubricRepo.IsUsed(ctx, id)
+		if err != nil {
+			return err
+		}
+		if used {
+			return domain.ErrConflict
+		}
+	}
+	rubric.ID = id
+	rubric.CreatedBy = existedRubric.CreatedBy
+	rubric.CreatedAt = existedRubric.CreatedAt
+	rubric.UpdatedAt = time.Now().Unix()
+	return usecase.rubricRepo.UpdateRubric(ctx, rubric)
+}
+
+// DeleteRubric removes a rubric. A rubric used by an assignment or a question, or with scores given with it, is kept.
+func (usecase *RubricUseCase) DeleteRubric(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.rubricRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	used, err := usecase.rubricRepo.IsUsed(ctx, id)
+	if err != nil {
+		return err
+	}
+	if used {
+		return domain.ErrConflict
+	}
+	return usecase.rubricRepo.DeleteRubric(ctx, id)
+}
+
+// Apply checks every criterion of a rubric is scored once with one of its levels, and sets the scores to the
+// points of the levels
+func (usecase *RubricUseCase) Apply(c context.Context, rubricID int64, scores []domain.CriterionScore) (float64, float64, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	rubric, err := usecase.rubricRepo.GetByID(ctx, rubricID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(scores) != len(rubric.Criteria) {
+		return 0, 0, domain.ErrBadParamInput
+	}
+	scored := make(map[int]bool, len(scores))
+	total := 0.0
+	for i := range scores {
+		s := &scores[i]
+		if s.CriterionID < 1 || s.CriterionID > len(rubric.Criteria) || scored[s.CriterionID] {
+			return 0, 0, domain.ErrBadParamInput
+		}
+		levels := rubric.Criteria[s.CriterionID-1].Levels
+		if s.LevelID < 1 || s.LevelID > len(levels) {
+			return 0, 0, domain.ErrBadParamInput
+		}
+		scored[s.CriterionID] = true
+		s.Score = levels[s.LevelID-1].Points
+		total += s.Score
+	}
+	return total, rubric.MaxPoints, nil
+}
+
+// Record keeps the scores given to a work by the caller, replacing the ones given before
+func (usecase *RubricUseCase) Record(c context.Context, work *domain.RubricWork, scores []domain.CriterionScore) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	work.GradedBy = domain.UserIDFromContext(ctx)
+	work.GradedAt = time.Now().Unix()
+	return usecase.rubricRepo.Record(ctx, work, scores)
+}
+
+// GetStatistics returns the statistics of the works of a course marked with a rubric to the ones working on the
+// course. Every criterion and level of the rubric is listed, the ones never given with no scores.
+func (usecase *RubricUseCase) GetStatistics(c context.Context, rubricID int64, courseID int64) (*domain.RubricStatistics, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.collaboratorUseCase.AuthorizeCourse(ctx, courseID, domain.CollaboratorEditor); err != nil {
+		return nil, err
+	}
+	rubric, err := usecase.rubricRepo.GetByID(ctx, rubricID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := usecase.rubricRepo.GetStatistics(ctx, rubricID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	scored := make(map[int]domain.CriterionStatistics, len(stats.Criteria))
+	for _, s := range stats.Criteria {
+		scored[s.CriterionID] = s
+	}
+	stats.MaxPoints = rubric.MaxPoints
+	stats.Criteria = make([]domain.CriterionStatistics, len(rubric.Criteria))
+	for i := range rubric.Criteria {
+		criterion := &rubric.Criteria[i]
+		s := scored[criterion.ID]
+		s.CriterionID = criterion.ID
+		s.Title = criterion.Title
+		s.MaxPoints = best(criterion)
+		given := make(map[int]int64, len(s.Levels))
+		for _, level := range s.Levels {
+			given[level.LevelID] = level.Count
+		}
+		s.Levels = make([]domain.LevelCount, len(criterion.Levels))
+		for j, level := range criterion.Levels {
+			s.Levels[j] = domain.LevelCount{LevelID: level.ID, Title: level.Title, Count: given[level.ID]}
+		}
+		stats.Criteria[i] = s
+	}
+	return stats, nil
+}
@@ -0,0 +1,212 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/rubric/usecase"
+)
+
+var instructorCtx = domain.WithUserID(domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2),
+	[]domain.Permission{domain.PermCourseUpdate}), 4)
+
+// essay has two criteria, the best levels worth 4 and 3 points
+func essay() *domain.Rubric {
+	return &domain.Rubric{ID: 7, Title: "Essay", MaxPoints: 7, Criteria: []domain.RubricCriterion{
+		{ID: 1, Title: "Argument", Levels: []domain.RubricLevel{{ID: 1, Title: "Weak", Points: 1}, {ID: 2, Title: "Fair", Points: 2},
+			{ID: 3, Title: "Strong", Points: 4}}},
+		{ID: 2, Title: "Sources", Levels: []domain.RubricLevel{{ID: 1, Title: "None", Points: 0}, {ID: 2, Title: "Cited", Points: 3}}},
+	}}
+}
+
+func TestCreateRubric(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		repo.On("CreateRubric", mock.Anything, mock.AnythingOfType("*domain.Rubric")).Return(nil).Once()
+		u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+		rubric := &domain.Rubric{Title: "Essay", MaxPoints: 100, Criteria: []domain.RubricCriterion{
+			{Title: "Argument", Levels: []domain.RubricLevel{{Title: "Weak", Points: 1}, {Title: "Fair", Points: 2}, {Title: "Strong", Points: 4}}},
+			{Title: "Sources", Levels: []domain.RubricLevel{{Title: "None"}, {Title: "Cited", Points: 3}}},
+		}}
+		assert.NoError(t, u.CreateRubric(instructorCtx, rubric))
+		assert.Equal(t, essay().Criteria, rubric.Criteria, "the criteria and their levels are numbered by position")
+		assert.Equal(t, float64(7), rubric.MaxPoints, "the points are the total of the best levels")
+		assert.Equal(t, int64(4), rubric.CreatedBy)
+	})
+	t.Run("no-points", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+		err := u.CreateRubric(instructorCtx, &domain.Rubric{Title: "Essay", Criteria: []domain.RubricCriterion{
+			{Title: "Argument", Levels: []domain.RubricLevel{{Title: "Weak"}}},
+		}})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		repo.AssertNotCalled(t, "CreateRubric", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateRubric(t *testing.T) {
+	t.Run("used-rubric-title", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		repo.On("GetByID", mock.Anything, int64(7)).Return(essay(), nil).Once()
+		repo.On("UpdateRubric", mock.Anything, mock.MatchedBy(func(r *domain.Rubric) bool {
+			return r.ID == 7 && r.Title == "Argumentative essay"
+		})).Return(nil).Once()
+		u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+		rubric := essay()
+		rubric.Title = "Argumentative essay"
+		rubric.Description = "For the essays of term 2"
+		assert.NoError(t, u.UpdateRubric(instructorCtx, rubric, 7))
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "IsUsed", mock.Anything, mock.Anything)
+	})
+	t.Run("used-rubric-levels", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		repo.On("GetByID", mock.Anything, int64(7)).Return(essay(), nil).Once()
+		repo.On("IsUsed", mock.Anything, int64(7)).Return(true, nil).Once()
+		u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+		rubric := essay()
+		rubric.Criteria[0].Levels[2].Points = 5
+		assert.Equal(t, domain.ErrConflict, u.UpdateRubric(instructorCtx, rubric, 7))
+		repo.AssertNotCalled(t, "UpdateRubric", mock.Anything, mock.Anything)
+	})
+	t.Run("used-rubric-criteria", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		repo.On("GetByID", mock.Anything, int64(7)).Return(essay(), nil).Once()
+		repo.On("IsUsed", mock.Anything, int64(7)).Return(true, nil).Once()
+		u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+		rubric := essay()
+		rubric.Criteria = rubric.Criteria[:1]
+		assert.Equal(t, domain.ErrConflict, u.UpdateRubric(instructorCtx, rubric, 7))
+		repo.AssertNotCalled(t, "UpdateRubric", mock.Anything, mock.Anything)
+	})
+	t.Run("unused-rubric-criteria", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		repo.On("GetByID", mock.Anything, int64(7)).Return(essay(), nil).Once()
+		repo.On("IsUsed", mock.Anything, int64(7)).Return(false, nil).Once()
+		repo.On("UpdateRubric", mock.Anything, mock.AnythingOfType("*domain.Rubric")).Return(nil).Once()
+		u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+		rubric := essay()
+		rubric.Criteria = rubric.Criteria[:1]
+		assert.NoError(t, u.UpdateRubric(instructorCtx, rubric, 7))
+		assert.Equal(t, float64(4), rubric.MaxPoints)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestDeleteRubric(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		repo.On("GetByID", mock.Anything, int64(7)).Return(essay(), nil).Once()
+		repo.On("IsUsed", mock.Anything, int64(7)).Return(false, nil).Once()
+		repo.On("DeleteRubric", mock.Anything, int64(7)).Return(nil).Once()
+		u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+		assert.NoError(t, u.DeleteRubric(instructorCtx, 7))
+		repo.AssertExpectations(t)
+	})
+	t.Run("used", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		repo.On("GetByID", mock.Anything, int64(7)).Return(essay(), nil).Once()
+		repo.On("IsUsed", mock.Anything, int64(7)).Return(true, nil).Once()
+		u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+		assert.Equal(t, domain.ErrConflict, u.DeleteRubric(instructorCtx, 7))
+		repo.AssertNotCalled(t, "DeleteRubric", mock.Anything, mock.Anything)
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		repo.On("GetByID", mock.Anything, int64(7)).Return(essay(), nil).Once()
+		u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+		scores := []domain.CriterionScore{{CriterionID: 2, LevelID: 2, Score: 10}, {CriterionID: 1, LevelID: 2, Comment: "Unclear."}}
+		total, maxPoints, err := u.Apply(instructorCtx, 7, scores)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(5), total)
+		assert.Equal(t, float64(7), maxPoints)
+		assert.Equal(t, []domain.CriterionScore{{CriterionID: 2, LevelID: 2, Score: 3}, {CriterionID: 1, LevelID: 2, Score: 2,
+			Comment: "Unclear."}}, scores, "the scores are the points of the levels")
+	})
+
+	tests := []struct {
+		name   string
+		scores []domain.CriterionScore
+	}{
+		{name: "missing-criterion", scores: []domain.CriterionScore{{CriterionID: 1, LevelID: 1}}},
+		{name: "duplicate-criterion", scores: []domain.CriterionScore{{CriterionID: 1, LevelID: 1}, {CriterionID: 1, LevelID: 2}}},
+		{name: "unknown-criterion", scores: []domain.CriterionScore{{CriterionID: 1, LevelID: 1}, {CriterionID: 3, LevelID: 1}}},
+		{name: "unknown-level", scores: []domain.CriterionScore{{CriterionID: 1, LevelID: 1}, {CriterionID: 2, LevelID: 3}}},
+		{name: "no-level", scores: []domain.CriterionScore{{CriterionID: 1, LevelID: 1}, {CriterionID: 2, Score: 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.RubricRepository)
+			repo.On("GetByID", mock.Anything, int64(7)).Return(essay(), nil).Once()
+			u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+			_, _, err := u.Apply(instructorCtx, 7, tt.scores)
+			assert.Equal(t, domain.ErrBadParamInput, err)
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	repo := new(mocks.RubricRepository)
+	scores := []domain.CriterionScore{{CriterionID: 1, LevelID: 3, Score: 4}, {CriterionID: 2, LevelID: 2, Score: 3}}
+	repo.On("Record", mock.Anything, mock.AnythingOfType("*domain.RubricWork"), scores).Return(nil).Once()
+	u := ucase.NewRubricUseCase(repo, new(mocks.CollaboratorUseCase), time.Second*2)
+
+	work := &domain.RubricWork{RubricID: 7, CourseID: 3, SubmissionID: 2}
+	assert.NoError(t, u.Record(instructorCtx, work, scores))
+	assert.Equal(t, int64(4), work.GradedBy)
+	assert.NotZero(t, work.GradedAt)
+}
+
+func TestGetStatistics(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		collaborator := new(mocks.CollaboratorUseCase)
+		collaborator.On("AuthorizeCourse", mock.Anything, int64(3), domain.CollaboratorEditor).Return(nil).Once()
+		repo.On("GetByID", mock.Anything, int64(7)).Return(essay(), nil).Once()
+		repo.On("GetStatistics", mock.Anything, int64(7), int64(3)).Return(&domain.RubricStatistics{RubricID: 7, CourseID: 3, Count: 2,
+			Mean: 6, StdDev: 1, Min: 5, Max: 7, Criteria: []domain.CriterionStatistics{
+				{CriterionID: 1, Count: 2, Mean: 3, StdDev: 1, Min: 2, Max: 4, Levels: []domain.LevelCount{{LevelID: 2, Count: 1},
+					{LevelID: 3, Count: 1}}},
+			}}, nil).Once()
+		u := ucase.NewRubricUseCase(repo, collaborator, time.Second*2)
+
+		stats, err := u.GetStatistics(instructorCtx, 7, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(7), stats.MaxPoints)
+		assert.Equal(t, []domain.CriterionStatistics{
+			{CriterionID: 1, Title: "Argument", MaxPoints: 4, Count: 2, Mean: 3, StdDev: 1, Min: 2, Max: 4, Levels: []domain.LevelCount{
+				{LevelID: 1, Title: "Weak"}, {LevelID: 2, Title: "Fair", Count: 1}, {LevelID: 3, Title: "Strong", Count: 1}}},
+			{CriterionID: 2, Title: "Sources", MaxPoints: 3, Levels: []domain.LevelCount{{LevelID: 1, Title: "None"},
+				{LevelID: 2, Title: "Cited"}}},
+		}, stats.Criteria, "every criterion and level of the rubric is listed")
+	})
+	t.Run("not-working-on-the-course", func(t *testing.T) {
+		repo := new(mocks.RubricRepository)
+		collaborator := new(mocks.CollaboratorUseCase)
+		collaborator.On("AuthorizeCourse", mock.Anything, int64(3), domain.CollaboratorEditor).Return(domain.ErrForbidden).Once()
+		u := ucase.NewRubricUseCase(repo, collaborator, time.Second*2)
+
+		_, err := u.GetStatistics(instructorCtx, 7, 3)
+		assert.Equal(t, domain.ErrForbidden, err)
+		repo.AssertNotCalled(t, "GetStatistics", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
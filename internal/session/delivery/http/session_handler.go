@@ -0,0 +1,152 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// SessionHandler ...
+type SessionHandler struct {
+	SessionUseCase domain.SessionUseCase
+}
+
+// NewSessionHandler ...
+func NewSessionHandler(e *echo.Echo, us domain.SessionUseCase) {
+	handler := &SessionHandler{
+		SessionUseCase: us,
+	}
+	e.GET("/auth/sessions", handler.GetOwn)
+	e.DELETE("/auth/sessions/:id", handler.RevokeOwn)
+	e.GET("/users/:id/sessions", handler.GetByUser, rbac.Require(domain.PermUserManage))
+	e.DELETE("/users/:id/sessions/:session_id", handler.RevokeSession, rbac.Require(domain.PermUserManage))
+	e.GET("/dashboard/active-learners", handler.GetPresence, rbac.Require(domain.PermReportView))
+}
+
+// GetOwn godoc
+// @Summary Get my sessions.
+// @Description Get the active sessions of the authenticated user, last seen first, with the device and the IP address they were opened from.
+// @Tags auth
+// @Accept */*
+// @Produce json
+// @Success 200 {object} domain.Response
+// @Failure 401 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/sessions [get]
+func (c *SessionHandler) GetOwn(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	list, err := c.SessionUseCase.GetByUser(ctx, domain.UserIDFromContext(ctx))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: list, Message: domain.Success})
+}
+
+// RevokeOwn godoc
+// @Summary Revoke one of my sessions.
+// @Description Sign the authenticated user out of one of its sessions, such as a lost device.
+// @Tags auth
+// @Accept */*
+// @Produce json
+// @Param id path int true "Session Id"
+// @Success 204
+// @Failure 401 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/sessions/{id} [delete]
+func (c *SessionHandler) RevokeOwn(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.SessionUseCase.RevokeSession(ctx, domain.UserIDFromContext(ctx), int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// GetByUser godoc
+// @Summary Get the sessions of a user.
+// @Description Get the active sessions of a user, last seen first.
+// @Tags users
+// @Accept */*
+// @Produce json
+// @Param id path int true "User Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users/{id}/sessions [get]
+func (c *SessionHandler) GetByUser(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	list, err := c.SessionUseCase.GetByUser(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: list, Message: domain.Success})
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session of a user.
+// @Description Sign a user out of one of its sessions. Its tokens are rejected right away.
+// @Tags users
+// @Accept */*
+// @Produce json
+// @Param id path int true "User Id"
+// @Param session_id path int true "Session Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users/{id}/sessions/{session_id} [delete]
+func (c *SessionHandler) RevokeSession(echoContext echo.Context) error {
+	userID, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	sessionID, err := strconv.Atoi(echoContext.Param("session_id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.SessionUseCase.RevokeSession(ctx, int64(userID), int64(sessionID))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// GetPresence godoc
+// @Summary Get the learners active now.
+// @Description Count the learners of the organization seen within the last few minutes.
+// @Tags dashboard
+// @Accept */*
+// @Produce json
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /dashboard/active-learners [get]
+func (c *SessionHandler) GetPresence(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	presence, err := c.SessionUseCase.GetPresence(ctx)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: presence, Message: domain.Success})
+}
@@ -0,0 +1,126 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	sessionHTTP "github.com/meroedu/meroedu/internal/session/delivery/http"
+)
+
+func TestGetOwn(t *testing.T) {
+	mockUCase := new(mocks.SessionUseCase)
+	mockUCase.On("GetByUser", mock.Anything, int64(3)).Return([]domain.Session{{ID: 5, UserID: 3}}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/auth/sessions", strings.NewReader(""))
+	assert.NoError(t, err)
+	req = req.WithContext(domain.WithUserID(req.Context(), 3))
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := sessionHTTP.SessionHandler{
+		SessionUseCase: mockUCase,
+	}
+	err = handler.GetOwn(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRevokeOwn(t *testing.T) {
+	mockUCase := new(mocks.SessionUseCase)
+	mockUCase.On("RevokeSession", mock.Anything, int64(3), int64(5)).Return(nil).Once()
+	mockUCase.On("RevokeSession", mock.Anything, int64(3), int64(6)).Return(domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"5", http.StatusNoContent},
+		{"6", http.StatusNotFound},
+		{"current", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/auth/sessions/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+		req = req.WithContext(domain.WithUserID(req.Context(), 3))
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/auth/sessions/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := sessionHTTP.SessionHandler{
+			SessionUseCase: mockUCase,
+		}
+		err = handler.RevokeOwn(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRevokeSession(t *testing.T) {
+	mockUCase := new(mocks.SessionUseCase)
+	mockUCase.On("RevokeSession", mock.Anything, int64(3), int64(5)).Return(nil).Once()
+
+	tests := []struct {
+		userID    string
+		sessionID string
+		code      int
+	}{
+		{"3", "5", http.StatusNoContent},
+		{"me", "5", http.StatusNotFound},
+		{"3", "current", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/users/"+tt.userID+"/sessions/"+tt.sessionID, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/sessions/:session_id")
+		c.SetParamNames("id", "session_id")
+		c.SetParamValues(tt.userID, tt.sessionID)
+		handler := sessionHTTP.SessionHandler{
+			SessionUseCase: mockUCase,
+		}
+		err = handler.RevokeSession(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.userID+"/"+tt.sessionID)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetPresence(t *testing.T) {
+	mockUCase := new(mocks.SessionUseCase)
+	mockUCase.On("GetPresence", mock.Anything).Return(&domain.Presence{ActiveLearners: 4}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/dashboard/active-learners", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := sessionHTTP.SessionHandler{
+		SessionUseCase: mockUCase,
+	}
+	err = handler.GetPresence(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"active_learners":4`)
+	mockUCase.AssertExpectations(t)
+}
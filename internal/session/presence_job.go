@@ -0,0 +1,44 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// PresenceJob writes the presence of the users and sessions seen by this instance periodically
+type PresenceJob struct {
+	sessionUseCase domain.SessionUseCase
+	interval       time.Duration
+}
+
+// NewPresenceJob will create a job flushing the presence every interval
+func NewPresenceJob(us domain.SessionUseCase, interval time.Duration) *PresenceJob {
+	return &PresenceJob{
+		sessionUseCase: us,
+		interval:       interval,
+	}
+}
+
+// Start runs the job every interval until ctx is done, then flushes a last time
+func (j *PresenceJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			j.flush(context.Background())
+			return
+		case <-ticker.C:
+			j.flush(ctx)
+		}
+	}
+}
+
+func (j *PresenceJob) flush(ctx context.Context) {
+	if err := j.sessionUseCase.Flush(ctx); err != nil {
+		log.Errorf("Error while writing the presence of the users: %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const sessionColumns = `id,user_id,organization_id,device,ip,last_seen_at,expires_at,revoked_at,created_at`
+
+// batchSize is the number of rows updated by a single statement
+const batchSize = 500
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the session's Repository interface
+func Init(db *sql.DB) domain.SessionRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Session, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Session, 0)
+	for rows.Next() {
+		s := domain.Session{}
+		var device, ip sql.NullString
+		var revokedAt sql.NullInt64
+		err = rows.Scan(
+			&s.ID,
+			&s.UserID,
+			&s.OrganizationID,
+			&device,
+			&ip,
+			&s.LastSeenAt,
+			&s.ExpiresAt,
+			&revokedAt,
+			&s.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		s.Device = device.String
+		s.IP = ip.String
+		s.RevokedAt = revokedAt.Int64
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) CreateSession(ctx context.Context, s *domain.Session) error {
+	query := `INSERT sessions SET user_id=?,organization_id=?,device=?,ip=?,last_seen_at=?,expires_at=?,created_at=?`
+	res, err := m.conn.ExecContext(ctx, query, s.UserID, s.OrganizationID, sql.NullString{String: s.Device, Valid: s.Device != ""},
+		sql.NullString{String: s.IP, Valid: s.IP != ""}, s.LastSeenAt, s.ExpiresAt, s.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	if s.ID, err = res.LastInsertId(); err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return err
+	}
+	return nil
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE id = ? AND organization_id = ?`
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// GetByUser returns the sessions of the user that are neither revoked nor expired at now, last seen first
+func (m *mysqlRepository) GetByUser(ctx context.Context, userID int64, now int64) ([]domain.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE user_id = ? AND organization_id = ? AND revoked_at IS NULL
+		AND expires_at > ? ORDER BY last_seen_at DESC, id DESC`
+	return m.fetch(ctx, query, userID, domain.OrganizationIDFromContext(ctx), now)
+}
+
+func (m *mysqlRepository) ExtendSession(ctx context.Context, id int64, expiresAt int64) error {
+	query := `UPDATE sessions SET expires_at=? WHERE id = ? AND organization_id = ?`
+	_, err := m.conn.ExecContext(ctx, query, expiresAt, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+	}
+	return err
+}
+
+// RevokeSession revokes the session with its refresh tokens. It returns ErrNotFound when the session was already revoked.
+func (m *mysqlRepository) RevokeSession(ctx context.Context, id int64, revokedAt int64) (err error) {
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `UPDATE sessions SET revoked_at=? WHERE id = ? AND organization_id = ? AND revoked_at IS NULL`
+	res, err := tx.ExecContext(ctx, query, revokedAt, id, organizationID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	query = `UPDATE refresh_tokens SET revoked_at=? WHERE session_id = ? AND organization_id = ? AND revoked_at IS NULL`
+	if _, err = tx.ExecContext(ctx, query, revokedAt, id, organizationID); err != nil {
+		log.Error(err)
+	}
+	return
+}
+
+// update sets the column of the rows of table to the times in seen, by batches. A time never goes back.
+func (m *mysqlRepository) update(ctx context.Context, table string, column string, seen map[int64]int64) error {
+	ids := make([]int64, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+		args := make([]interface{}, 0, 3*len(batch))
+		for _, id := range batch {
+			args = append(args, id, seen[id])
+		}
+		for _, id := range batch {
+			args = append(args, id)
+		}
+		query := `UPDATE ` + table + ` SET ` + column + ` = GREATEST(COALESCE(` + column + `,0), CASE id` +
+			strings.Repeat(` WHEN ? THEN ?`, len(batch)) + ` END) WHERE id IN (?` + strings.Repeat(`,?`, len(batch)-1) + `)`
+		if _, err := m.conn.ExecContext(ctx, query, args...); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateLastSeen sets when the sessions were last seen, by session ID
+func (m *mysqlRepository) UpdateLastSeen(ctx context.Context, seen map[int64]int64) error {
+	return m.update(ctx, "sessions", "last_seen_at", seen)
+}
+
+// UpdateLastOnline sets when the users were last online, by user ID
+func (m *mysqlRepository) UpdateLastOnline(ctx context.Context, seen map[int64]int64) error {
+	return m.update(ctx, "users", "lastOnline", seen)
+}
+
+// CountOnline counts the active users of the caller's organization with the role that were online since
+func (m *mysqlRepository) CountOnline(ctx context.Context, roleCode string, since int64) (count int64, err error) {
+	query := `SELECT COUNT(*) FROM users u JOIN roles r ON r.id = u.role_id WHERE u.organization_id = ? AND u.status = ?
+		AND u.lastOnline >= ? AND r.code = ?`
+	err = m.conn.QueryRowContext(ctx, query, domain.OrganizationIDFromContext(ctx), domain.UserActive, since, roleCode).Scan(&count)
+	if err != nil {
+		log.Error(err)
+	}
+	return
+}
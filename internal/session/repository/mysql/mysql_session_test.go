@@ -0,0 +1,142 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/session/repository/mysql"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+var sessionRows = []string{"id", "user_id", "organization_id", "device", "ip", "last_seen_at", "expires_at", "revoked_at", "created_at"}
+
+func TestCreateSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`INSERT sessions SET user_id=\?,organization_id=\?,device=\?,ip=\?,last_seen_at=\?,expires_at=\?,created_at=\?`).
+		WithArgs(4, 2, "Firefox", nil, 100, 200, 100).WillReturnResult(sqlmock.NewResult(7, 1))
+
+	repo := mysqlrepo.Init(db)
+	s := &domain.Session{UserID: 4, OrganizationID: 2, Device: "Firefox", LastSeenAt: 100, ExpiresAt: 200, CreatedAt: 100}
+	assert.NoError(t, repo.CreateSession(orgCtx, s))
+	assert.Equal(t, int64(7), s.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM sessions WHERE user_id = \? AND organization_id = \? AND revoked_at IS NULL\s+AND expires_at > \? ORDER BY last_seen_at DESC`).
+		WithArgs(4, 2, 150).
+		WillReturnRows(sqlmock.NewRows(sessionRows).
+			AddRow(7, 4, 2, "Firefox", "10.0.0.1", 140, 200, nil, 100).
+			AddRow(5, 4, 2, nil, nil, 120, 300, nil, 90))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetByUser(orgCtx, 4, 150)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Session{
+		{ID: 7, UserID: 4, OrganizationID: 2, Device: "Firefox", IP: "10.0.0.1", LastSeenAt: 140, ExpiresAt: 200, CreatedAt: 100},
+		{ID: 5, UserID: 4, OrganizationID: 2, LastSeenAt: 120, ExpiresAt: 300, CreatedAt: 90},
+	}, list)
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM sessions WHERE id = \? AND organization_id = \?`).WithArgs(9, 2).
+		WillReturnRows(sqlmock.NewRows(sessionRows))
+
+	repo := mysqlrepo.Init(db)
+	_, err = repo.GetByID(orgCtx, 9)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestRevokeSession(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE sessions SET revoked_at=\? WHERE id = \? AND organization_id = \? AND revoked_at IS NULL`).
+			WithArgs(150, 7, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE refresh_tokens SET revoked_at=\? WHERE session_id = \? AND organization_id = \? AND revoked_at IS NULL`).
+			WithArgs(150, 7, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		assert.NoError(t, repo.RevokeSession(orgCtx, 7, 150))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("already-revoked", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE sessions SET revoked_at=\?`).WithArgs(150, 7, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := mysqlrepo.Init(db)
+		assert.Equal(t, domain.ErrNotFound, repo.RevokeSession(orgCtx, 7, 150))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateLastOnline(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`UPDATE users SET lastOnline = GREATEST\(COALESCE\(lastOnline,0\), CASE id WHEN \? THEN \? WHEN \? THEN \? END\) WHERE id IN \(\?,\?\)`).
+		WithArgs(3, 110, 4, 100, 3, 4).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	repo := mysqlrepo.Init(db)
+	assert.NoError(t, repo.UpdateLastOnline(context.TODO(), map[int64]int64{4: 100, 3: 110}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateLastSeenBatches(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	seen := map[int64]int64{}
+	for id := int64(1); id <= 501; id++ {
+		seen[id] = 100
+	}
+	mock.ExpectExec(`UPDATE sessions SET last_seen_at = GREATEST`).WillReturnResult(sqlmock.NewResult(0, 500))
+	mock.ExpectExec(`UPDATE sessions SET last_seen_at = GREATEST\(COALESCE\(last_seen_at,0\), CASE id WHEN \? THEN \? END\) WHERE id IN \(\?\)`).
+		WithArgs(501, 100, 501).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := mysqlrepo.Init(db)
+	assert.NoError(t, repo.UpdateLastSeen(context.TODO(), seen))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountOnline(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users u JOIN roles r ON r.id = u.role_id WHERE u.organization_id = \? AND u.status = \?\s+AND u.lastOnline >= \? AND r.code = \?`).
+		WithArgs(2, domain.UserActive, 100, domain.RoleLearner).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+	repo := mysqlrepo.Init(db)
+	count, err := repo.CountOnline(orgCtx, domain.RoleLearner, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), count)
+}
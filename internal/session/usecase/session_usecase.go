@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+)
+
+// SessionUseCase ...
+type SessionUseCase struct {
+	sessionRepo    domain.SessionRepository
+	onlineWindow   time.Duration
+	contextTimeOut time.Duration
+
+	// users and sessions hold the last time each was seen since the last flush, so that any number of
+	// requests makes a single write per user and per session
+	mutex    sync.Mutex
+	users    map[int64]int64
+	sessions map[int64]int64
+}
+
+// NewSessionUseCase will create new an SessionUseCase. A user seen within onlineWindow counts as active.
+func NewSessionUseCase(s domain.SessionRepository, onlineWindow time.Duration, timeout time.Duration) domain.SessionUseCase {
+	return &SessionUseCase{
+		sessionRepo:    s,
+		onlineWindow:   onlineWindow,
+		contextTimeOut: timeout,
+		users:          map[int64]int64{},
+		sessions:       map[int64]int64{},
+	}
+}
+
+// GetByUser returns the active sessions of a user of the caller's organization, marking the caller's own
+func (usecase *SessionUseCase) GetByUser(c context.Context, userID int64) ([]domain.Session, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if userID == 0 {
+		return nil, domain.ErrUnauthorized
+	}
+	list, err := usecase.sessionRepo.GetByUser(ctx, userID, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	current := domain.SessionIDFromContext(ctx)
+	for i := range list {
+		list[i].Current = list[i].ID == current
+	}
+	return list, nil
+}
+
+// RevokeSession signs the user out of one of its sessions. Its refresh tokens and access tokens are rejected from then on.
+func (usecase *SessionUseCase) RevokeSession(c context.Context, userID int64, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if userID == 0 {
+		return domain.ErrUnauthorized
+	}
+	session, err := usecase.sessionRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID || session.RevokedAt != 0 {
+		return domain.ErrNotFound
+	}
+	return usecase.sessionRepo.RevokeSession(ctx, id, time.Now().Unix())
+}
+
+// Touch records that the user was seen now in the session. Nothing is written until the next flush.
+func (usecase *SessionUseCase) Touch(ctx context.Context, userID int64, sessionID int64) {
+	if userID == 0 {
+		return
+	}
+	now := time.Now().Unix()
+	usecase.mutex.Lock()
+	defer usecase.mutex.Unlock()
+	usecase.users[userID] = now
+	if sessionID != 0 {
+		usecase.sessions[sessionID] = now
+	}
+}
+
+// Flush writes when the users and the sessions touched since the last flush were last seen
+func (usecase *SessionUseCase) Flush(c context.Context) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	usecase.mutex.Lock()
+	users, sessions := usecase.users, usecase.sessions
+	usecase.users, usecase.sessions = map[int64]int64{}, map[int64]int64{}
+	usecase.mutex.Unlock()
+
+	if len(users) > 0 {
+		if err := usecase.sessionRepo.UpdateLastOnline(ctx, users); err != nil {
+			return err
+		}
+	}
+	if len(sessions) > 0 {
+		return usecase.sessionRepo.UpdateLastSeen(ctx, sessions)
+	}
+	return nil
+}
+
+// GetPresence counts the learners of the caller's organization seen within the online window.
+// Presence is flushed periodically, so a learner shows up at the latest one flush after its request.
+func (usecase *SessionUseCase) GetPresence(c context.Context) (*domain.Presence, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	since := time.Now().Add(-usecase.onlineWindow).Unix()
+	count, err := usecase.sessionRepo.CountOnline(ctx, domain.RoleLearner, since)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Presence{ActiveLearners: count, Since: since}, nil
+}
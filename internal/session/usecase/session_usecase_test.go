@@ -0,0 +1,92 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/session/usecase"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+func TestGetByUser(t *testing.T) {
+	mockSessionRepo := new(mocks.SessionRepository)
+	mockSessionRepo.On("GetByUser", mock.Anything, int64(4), mock.AnythingOfType("int64")).
+		Return([]domain.Session{{ID: 7, UserID: 4}, {ID: 5, UserID: 4}}, nil).Once()
+
+	u := ucase.NewSessionUseCase(mockSessionRepo, 5*time.Minute, time.Second*2)
+	list, err := u.GetByUser(domain.WithSessionID(orgCtx, 5), 4)
+	assert.NoError(t, err)
+	assert.False(t, list[0].Current)
+	assert.True(t, list[1].Current)
+
+	_, err = u.GetByUser(orgCtx, 0)
+	assert.Equal(t, domain.ErrUnauthorized, err)
+}
+
+func TestRevokeSession(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Session{ID: 7, UserID: 4}, nil).Once()
+		mockSessionRepo.On("RevokeSession", mock.Anything, int64(7), mock.AnythingOfType("int64")).Return(nil).Once()
+
+		u := ucase.NewSessionUseCase(mockSessionRepo, 5*time.Minute, time.Second*2)
+		assert.NoError(t, u.RevokeSession(orgCtx, 4, 7))
+		mockSessionRepo.AssertExpectations(t)
+	})
+	t.Run("other-user", func(t *testing.T) {
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Session{ID: 7, UserID: 5}, nil).Once()
+
+		u := ucase.NewSessionUseCase(mockSessionRepo, 5*time.Minute, time.Second*2)
+		assert.Equal(t, domain.ErrNotFound, u.RevokeSession(orgCtx, 4, 7))
+		mockSessionRepo.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("already-revoked", func(t *testing.T) {
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Session{ID: 7, UserID: 4, RevokedAt: 100}, nil).Once()
+
+		u := ucase.NewSessionUseCase(mockSessionRepo, 5*time.Minute, time.Second*2)
+		assert.Equal(t, domain.ErrNotFound, u.RevokeSession(orgCtx, 4, 7))
+	})
+}
+
+func TestFlush(t *testing.T) {
+	mockSessionRepo := new(mocks.SessionRepository)
+	mockSessionRepo.On("UpdateLastOnline", mock.Anything, mock.MatchedBy(func(seen map[int64]int64) bool {
+		return len(seen) == 2 && seen[4] > 0 && seen[5] > 0
+	})).Return(nil).Once()
+	mockSessionRepo.On("UpdateLastSeen", mock.Anything, mock.MatchedBy(func(seen map[int64]int64) bool {
+		return len(seen) == 1 && seen[7] > 0
+	})).Return(nil).Once()
+
+	u := ucase.NewSessionUseCase(mockSessionRepo, 5*time.Minute, time.Second*2)
+	for i := 0; i < 10; i++ {
+		u.Touch(orgCtx, 4, 7)
+	}
+	u.Touch(orgCtx, 5, 0)
+	u.Touch(orgCtx, 0, 0)
+	assert.NoError(t, u.Flush(context.TODO()))
+	// nothing was touched since, so nothing is written
+	assert.NoError(t, u.Flush(context.TODO()))
+	mockSessionRepo.AssertExpectations(t)
+}
+
+func TestGetPresence(t *testing.T) {
+	mockSessionRepo := new(mocks.SessionRepository)
+	before := time.Now().Add(-5 * time.Minute).Unix()
+	mockSessionRepo.On("CountOnline", mock.Anything, domain.RoleLearner, mock.MatchedBy(func(since int64) bool {
+		return since >= before && since <= time.Now().Add(-5*time.Minute).Unix()
+	})).Return(int64(12), nil).Once()
+
+	u := ucase.NewSessionUseCase(mockSessionRepo, 5*time.Minute, time.Second*2)
+	presence, err := u.GetPresence(orgCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), presence.ActiveLearners)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
 	"github.com/meroedu/meroedu/internal/util"
 )
 
@@ -27,24 +28,27 @@ func NewTagHandler(e *echo.Echo, us domain.TagUseCase) {
 		TagUseCase: us,
 	}
 	// Get Operation
-	e.GET("/tags", handler.GetAll)
-	e.GET("/tags/:id", handler.GetByID)
-	e.GET("/tags/course/:id", handler.GetCourseTags)
-	e.GET("/tags/lesson/:id", handler.GetLessonTags)
+	e.GET("/tags", handler.GetAll, rbac.Require(domain.PermCourseView))
+	e.GET("/tags/:id", handler.GetByID, rbac.Require(domain.PermCourseView))
+	e.GET("/tags/course/:id", handler.GetCourseTags, rbac.Require(domain.PermCourseView))
+	e.GET("/tags/lesson/:id", handler.GetLessonTags, rbac.Require(domain.PermCourseView))
+	e.GET("/tags/question/:id", handler.GetQuestionTags, rbac.Require(domain.PermCourseUpdate))
 
 	// Create/Add Operation
-	e.POST("/tags", handler.CreateTag)
-	e.POST("/tags/course/:course_id/:tag_id", handler.CreateCourseTag)
-	e.POST("/tags/lesson/:lesson_id/:tag_id", handler.CreateLessonTag)
+	e.POST("/tags", handler.CreateTag, rbac.Require(domain.PermCategoryManage))
+	e.POST("/tags/course/:course_id/:tag_id", handler.CreateCourseTag, rbac.Require(domain.PermCourseUpdate))
+	e.POST("/tags/lesson/:lesson_id/:tag_id", handler.CreateLessonTag, rbac.Require(domain.PermCourseUpdate))
+	e.POST("/tags/question/:question_id/:tag_id", handler.CreateQuestionTag, rbac.Require(domain.PermCourseUpdate))
 
 	// Update Operation
-	e.PUT("/tags/:id", handler.UpdateTag)
-	e.PUT("/tags/actions", handler.GetByID)
+	e.PUT("/tags/:id", handler.UpdateTag, rbac.Require(domain.PermCategoryManage))
+	e.PUT("/tags/actions", handler.BulkAction, rbac.Require(domain.PermCategoryManage))
 
 	// Remove/Delete Operation
-	e.DELETE("/tags/:id", handler.DeleteTag)
-	e.DELETE("/tags/course/:course_id/:tag_id", handler.DeleteCourseTag)
-	e.DELETE("/tags/lesson/:lesson_id/:tag_id", handler.DeleteLessonTag)
+	e.DELETE("/tags/:id", handler.DeleteTag, rbac.Require(domain.PermCategoryManage))
+	e.DELETE("/tags/course/:course_id/:tag_id", handler.DeleteCourseTag, rbac.Require(domain.PermCourseUpdate))
+	e.DELETE("/tags/lesson/:lesson_id/:tag_id", handler.DeleteLessonTag, rbac.Require(domain.PermCourseUpdate))
+	e.DELETE("/tags/question/:question_id/:tag_id", handler.DeleteQuestionTag, rbac.Require(domain.PermCourseUpdate))
 
 }
 
@@ -415,3 +419,123 @@ func (c *TagHandler) GetLessonTags(echoContext echo.Context) error {
 	}
 	return echoContext.JSON(http.StatusOK, res)
 }
+
+// CreateQuestionTag godoc
+// @Summary Create Question Tag
+// @Description Tag a question of a question bank. The quizzes draw questions from the pools of their tags.
+// @Tags tags
+// @Accept */*
+// @Produce json
+// @Param question_id path int true  "question id"
+// @Param tag_id path int true  "tag id"
+// @Success 201
+// @Failure 404 {object} domain.APIResponseError "Can not find ID, or the question already has the tag"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /tags/question/{question_id}/{tag_id} [post]
+func (c *TagHandler) CreateQuestionTag(echoContext echo.Context) error {
+	questionID, err := strconv.Atoi(echoContext.Param("question_id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	tagID, err := strconv.Atoi(echoContext.Param("tag_id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	err = c.TagUseCase.CreateQuestionTag(ctx, int64(tagID), int64(questionID))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return echoContext.NoContent(http.StatusCreated)
+}
+
+// DeleteQuestionTag godoc
+// @Summary Delete question tags by questionID and tagID
+// @Description Remove a tag from a question of a question bank.
+// @Tags tags
+// @Accept */*
+// @Produce json
+// @Param question_id path int true  "question id"
+// @Param tag_id path int true  "tag id"
+// @Success 200
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /tags/question/{question_id}/{tag_id} [delete]
+func (c *TagHandler) DeleteQuestionTag(echoContext echo.Context) error {
+	questionID, err := strconv.Atoi(echoContext.Param("question_id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	tagID, err := strconv.Atoi(echoContext.Param("tag_id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	err = c.TagUseCase.DeleteQuestionTag(ctx, int64(tagID), int64(questionID))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return echoContext.NoContent(http.StatusOK)
+}
+
+// GetQuestionTags godoc
+// @Summary Get tags by QuestionID.
+// @Description Get the tags of a question of a question bank, by name.
+// @Tags tags
+// @Accept */*
+// @Produce json
+// @Param id path int true "Question Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /tags/question/{id} [get]
+func (c *TagHandler) GetQuestionTags(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	tags, err := c.TagUseCase.GetQuestionTags(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: tags, Message: domain.Success})
+}
+
+// BulkAction godoc
+// @Summary Run an action on many tags.
+// @Description Run delete on a list of tag IDs in a single transaction. The outcome is reported for each ID.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param action body domain.BulkAction true "Action and IDs"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Unsupported action or missing parameter"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /tags/actions [put]
+func (c *TagHandler) BulkAction(echoContext echo.Context) error {
+	var action domain.BulkAction
+	err := echoContext.Bind(&action)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&action); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	results, err := c.TagUseCase.BulkAction(ctx, &action)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    results,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
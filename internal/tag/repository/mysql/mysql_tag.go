@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/repository/sqlbulk"
 	"github.com/meroedu/meroedu/pkg/log"
 )
 
@@ -55,18 +56,18 @@ func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...inter
 }
 
 func (m *mysqlRepository) GetAll(ctx context.Context, searchQuery string, start int, limit int) (res []domain.Tag, err error) {
-	query := `SELECT id,name,updated_at,created_at FROM tags WHERE name like ? ORDER BY created_at DESC LIMIT ?,?`
+	query := `SELECT id,name,updated_at,created_at FROM tags WHERE organization_id = ? AND name like ? ORDER BY created_at DESC LIMIT ?,?`
 	searchQuery = "%" + searchQuery + "%"
-	res, err = m.fetch(ctx, query, searchQuery, start, limit)
+	res, err = m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), searchQuery, start, limit)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (res *domain.Tag, err error) {
-	query := `SELECT id,name,updated_at,created_at FROM tags WHERE ID = ?`
+	query := `SELECT id,name,updated_at,created_at FROM tags WHERE ID = ? AND organization_id = ?`
 
-	list, err := m.fetch(ctx, query, id)
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -80,8 +81,8 @@ func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (res *domain.Ta
 	return &tag, nil
 }
 func (m *mysqlRepository) GetByName(ctx context.Context, name string) (res *domain.Tag, err error) {
-	query := `SELECT id,name,updated_at,created_at FROM tags WHERE name = ?`
-	list, err := m.fetch(ctx, query, name)
+	query := `SELECT id,name,updated_at,created_at FROM tags WHERE name = ? AND organization_id = ?`
+	list, err := m.fetch(ctx, query, name, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -96,13 +97,13 @@ func (m *mysqlRepository) GetByName(ctx context.Context, name string) (res *doma
 }
 
 func (m *mysqlRepository) CreateTag(ctx context.Context, a *domain.Tag) (err error) {
-	query := `INSERT tags SET name=?,updated_at=?,created_at=?`
+	query := `INSERT tags SET name=?,organization_id=?,updated_at=?,created_at=?`
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		log.Error("Error while preparing statement ", err)
 		return
 	}
-	res, err := stmt.ExecContext(ctx, a.Name, a.UpdatedAt, a.CreatedAt)
+	res, err := stmt.ExecContext(ctx, a.Name, domain.OrganizationIDFromContext(ctx), a.UpdatedAt, a.CreatedAt)
 	if err != nil {
 		log.Error("Error while executing statement ", err)
 		return
@@ -117,14 +118,14 @@ func (m *mysqlRepository) CreateTag(ctx context.Context, a *domain.Tag) (err err
 }
 
 func (m *mysqlRepository) DeleteTag(ctx context.Context, id int64) (err error) {
-	query := "DELETE FROM tags WHERE id = ?"
+	query := "DELETE FROM tags WHERE id = ? AND organization_id = ?"
 
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	res, err := stmt.ExecContext(ctx, id, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return
 	}
@@ -142,14 +143,14 @@ func (m *mysqlRepository) DeleteTag(ctx context.Context, id int64) (err error) {
 	return
 }
 func (m *mysqlRepository) UpdateTag(ctx context.Context, ar *domain.Tag) (err error) {
-	query := `UPDATE tags set name=?,updated_at=? WHERE ID = ?`
+	query := `UPDATE tags set name=?,updated_at=? WHERE ID = ? AND organization_id = ?`
 
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, ar.Name, ar.UpdatedAt, ar.ID)
+	res, err := stmt.ExecContext(ctx, ar.Name, ar.UpdatedAt, ar.ID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return
 	}
@@ -165,34 +166,40 @@ func (m *mysqlRepository) UpdateTag(ctx context.Context, ar *domain.Tag) (err er
 	return
 }
 
+// CreateCourseTag links the tag to the course. Both must belong to the caller's organization.
 func (m *mysqlRepository) CreateCourseTag(ctx context.Context, tagID int64, courseID int64) error {
-	query := `INSERT courses_tags SET course_id=?,tag_id=?,created_at=?`
+	query := `INSERT INTO courses_tags (course_id,tag_id,created_at) SELECT c.id,t.id,? FROM courses c JOIN tags t ON t.id = ?
+		WHERE c.id = ? AND c.organization_id = ? AND t.organization_id = ?`
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		log.Error("Error while preparing statement ", err)
 		return err
 	}
-	res, err := stmt.ExecContext(ctx, courseID, tagID, time.Now().Unix())
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	res, err := stmt.ExecContext(ctx, time.Now().Unix(), tagID, courseID, organizationID, organizationID)
 	if err != nil {
 		log.Error("Error while executing statement ", err)
 		return err
 	}
-	_, err = res.LastInsertId()
+	affect, err := res.RowsAffected()
 	if err != nil {
-		log.Error("Got Error from LastInsertId method: ", err)
 		return err
 	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
 	return nil
 }
 func (m *mysqlRepository) DeleteCourseTag(ctx context.Context, tagID int64, courseID int64) error {
-	query := "DELETE FROM courses_tags WHERE course_id = ? and tag_id= ?"
+	query := `DELETE ct FROM courses_tags ct JOIN tags t ON t.id = ct.tag_id
+		WHERE ct.course_id = ? and ct.tag_id= ? AND t.organization_id = ?`
 
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.ExecContext(ctx, courseID, tagID)
+	res, err := stmt.ExecContext(ctx, courseID, tagID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -210,42 +217,50 @@ func (m *mysqlRepository) DeleteCourseTag(ctx context.Context, tagID int64, cour
 	return nil
 }
 func (m *mysqlRepository) GetCourseTags(ctx context.Context, courseID int64) ([]domain.Tag, error) {
-	query := `select t.id,t.name,t.updated_at,t.created_at from tags t, courses_tags ct where ct.course_id=?`
-	tags, err := m.fetch(ctx, query, courseID)
+	query := `select t.id,t.name,t.updated_at,t.created_at from tags t, courses_tags ct
+		where ct.course_id=? AND t.id=ct.tag_id AND t.organization_id=?`
+	tags, err := m.fetch(ctx, query, courseID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	return tags, nil
 }
 
+// CreateLessonTag links the tag to the lesson. Both must belong to the caller's organization.
 func (m *mysqlRepository) CreateLessonTag(ctx context.Context, tagID int64, lessonID int64) error {
-	query := `INSERT lessons_tags SET lesson_id=?,tag_id=?,created_at=?`
+	query := `INSERT INTO lessons_tags (lesson_id,tag_id,created_at) SELECT l.id,t.id,? FROM lessons l
+		JOIN courses c ON c.id = l.course_id JOIN tags t ON t.id = ?
+		WHERE l.id = ? AND c.organization_id = ? AND t.organization_id = ?`
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		log.Error("Error while preparing statement ", err)
 		return err
 	}
-	res, err := stmt.ExecContext(ctx, lessonID, tagID, time.Now().Unix())
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	res, err := stmt.ExecContext(ctx, time.Now().Unix(), tagID, lessonID, organizationID, organizationID)
 	if err != nil {
 		log.Error("Error while executing statement ", err)
 		return err
 	}
-	_, err = res.LastInsertId()
+	affect, err := res.RowsAffected()
 	if err != nil {
-		log.Error("Got Error from LastInsertId method: ", err)
 		return err
 	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
 	return nil
 }
 func (m *mysqlRepository) DeleteLessonTag(ctx context.Context, tagID int64, lessonID int64) error {
-	query := "DELETE FROM lessons_tags WHERE lesson_id = ? and tag_id= ?"
+	query := `DELETE lt FROM lessons_tags lt JOIN tags t ON t.id = lt.tag_id
+		WHERE lt.lesson_id = ? and lt.tag_id= ? AND t.organization_id = ?`
 
 	stmt, err := m.conn.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.ExecContext(ctx, lessonID, tagID)
+	res, err := stmt.ExecContext(ctx, lessonID, tagID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -264,10 +279,70 @@ func (m *mysqlRepository) DeleteLessonTag(ctx context.Context, tagID int64, less
 }
 
 func (m *mysqlRepository) GetLessonTags(ctx context.Context, lessonID int64) ([]domain.Tag, error) {
-	query := `select t.id,t.name,t.updated_at,t.created_at from tags t, lessons_tags lt where lt.lesson_id=?`
-	tags, err := m.fetch(ctx, query, lessonID)
+	query := `select t.id,t.name,t.updated_at,t.created_at from tags t, lessons_tags lt
+		where lt.lesson_id=? AND t.id=lt.tag_id AND t.organization_id=?`
+	tags, err := m.fetch(ctx, query, lessonID, domain.OrganizationIDFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	return tags, nil
 }
+
+// CreateQuestionTag links the tag to a question of the question bank. Both must belong to the caller's organization.
+// It returns ErrNotFound when either is missing or the question already has the tag.
+func (m *mysqlRepository) CreateQuestionTag(ctx context.Context, tagID int64, questionID int64) error {
+	query := `INSERT INTO questions_tags (question_id,tag_id,created_at) SELECT q.id,t.id,? FROM questions q
+		JOIN courses c ON c.id = q.course_id JOIN tags t ON t.id = ?
+		WHERE q.id = ? AND c.organization_id = ? AND t.organization_id = ?
+		AND NOT EXISTS (SELECT 1 FROM questions_tags WHERE question_id = q.id AND tag_id = t.id)`
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	res, err := m.conn.ExecContext(ctx, query, time.Now().Unix(), tagID, questionID, organizationID, organizationID)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mysqlRepository) DeleteQuestionTag(ctx context.Context, tagID int64, questionID int64) error {
+	query := `DELETE qt FROM questions_tags qt JOIN tags t ON t.id = qt.tag_id
+		WHERE qt.question_id = ? AND qt.tag_id = ? AND t.organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, questionID, tagID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mysqlRepository) GetQuestionTags(ctx context.Context, questionID int64) ([]domain.Tag, error) {
+	query := `SELECT t.id,t.name,t.updated_at,t.created_at FROM tags t JOIN questions_tags qt ON qt.tag_id = t.id
+		WHERE qt.question_id = ? AND t.organization_id = ? ORDER BY t.name`
+	return m.fetch(ctx, query, questionID, domain.OrganizationIDFromContext(ctx))
+}
+
+// BulkDelete removes many tags in a single transaction, untagging their courses, lessons and questions. A tag a
+// quiz draws a question pool from is refused since deleting it would drop the pool from the quiz.
+func (m *mysqlRepository) BulkDelete(ctx context.Context, ids []int64) ([]domain.BulkResult, error) {
+	organizationID := domain.OrganizationIDFromContext(ctx)
+	return sqlbulk.Run(ctx, m.conn, ids, func(tx *sql.Tx, id int64) error {
+		query := `SELECT 1 FROM quizzes_pools p JOIN tags t ON t.id = p.tag_id WHERE t.id = ? AND t.organization_id = ?`
+		if err := sqlbulk.Unused(ctx, tx, query, id, organizationID); err != nil {
+			return err
+		}
+		return sqlbulk.ExecItem(ctx, tx, "DELETE FROM tags WHERE id = ? AND organization_id = ?", id, organizationID)
+	})
+}
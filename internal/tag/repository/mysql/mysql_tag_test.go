@@ -11,6 +11,8 @@ import (
 	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
+var orgCtx = domain.WithOrganizationID(context.TODO(), 1)
+
 func TestGetAll(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -24,11 +26,11 @@ func TestGetAll(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"}).
 		AddRow(mockTags[0].ID, mockTags[0].Name, mockTags[0].UpdatedAt, mockTags[0].CreatedAt)
 
-	query := `SELECT id,name,updated_at,created_at FROM tags WHERE name like \? ORDER BY created_at DESC LIMIT \?,\?`
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	query := `SELECT id,name,updated_at,created_at FROM tags WHERE organization_id = \? AND name like \? ORDER BY created_at DESC LIMIT \?,\?`
+	mock.ExpectQuery(query).WithArgs(int64(1), "%%", 0, 10).WillReturnRows(rows)
 	c := mysqlrepo.Init(db)
 	start, limit := 0, 10
-	list, err := c.GetAll(context.TODO(), "", start, limit)
+	list, err := c.GetAll(orgCtx, "", start, limit)
 	assert.NoError(t, err)
 	assert.Len(t, list, 1)
 
@@ -42,10 +44,10 @@ func TestGetByID(t *testing.T) {
 	row := sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"}).
 		AddRow("1", "testing-2", time.Now().Unix(), time.Now().Unix())
 
-	query := `SELECT id,name,updated_at,created_at FROM tags WHERE ID = \?`
-	mock.ExpectQuery(query).WillReturnRows(row)
+	query := `SELECT id,name,updated_at,created_at FROM tags WHERE ID = \? AND organization_id = \?`
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(1)).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	tag, err := c.GetByID(context.TODO(), 1)
+	tag, err := c.GetByID(orgCtx, 1)
 	assert.NoError(t, err)
 	assert.NotNil(t, tag)
 }
@@ -57,10 +59,10 @@ func TestGetByName(t *testing.T) {
 	row := sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"}).
 		AddRow("1", "testing-2", time.Now().Unix(), time.Now().Unix())
 
-	query := `SELECT id,name,updated_at,created_at FROM tags WHERE name = \?`
-	mock.ExpectQuery(query).WillReturnRows(row)
+	query := `SELECT id,name,updated_at,created_at FROM tags WHERE name = \? AND organization_id = \?`
+	mock.ExpectQuery(query).WithArgs("testing-2", int64(1)).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	category, err := c.GetByName(context.TODO(), "testing-2")
+	category, err := c.GetByName(orgCtx, "testing-2")
 	assert.NoError(t, err)
 	assert.NotNil(t, category)
 }
@@ -74,12 +76,12 @@ func TestCreateTag(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `INSERT tags SET name=\?,updated_at=\?,created_at=\?`
+	query := `INSERT tags SET name=\?,organization_id=\?,updated_at=\?,created_at=\?`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(c.Name, c.UpdatedAt, c.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(c.Name, int64(1), c.UpdatedAt, c.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.CreateTag(context.TODO(), c)
+	err = repo.CreateTag(orgCtx, c)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(12), c.ID)
 }
@@ -90,12 +92,12 @@ func TestDeleteTag(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `DELETE FROM tags WHERE id = \?`
+	query := `DELETE FROM tags WHERE id = \? AND organization_id = \?`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(tag_id).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(tag_id, int64(1)).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.DeleteTag(context.TODO(), int64(tag_id))
+	err = repo.DeleteTag(orgCtx, int64(tag_id))
 	assert.NoError(t, err)
 }
 
@@ -110,12 +112,12 @@ func TestUpdateTag(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `UPDATE tags set name=\?,updated_at=\? WHERE ID = \?`
+	query := `UPDATE tags set name=\?,updated_at=\? WHERE ID = \? AND organization_id = \?`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(c.Name, c.UpdatedAt, c.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(c.Name, c.UpdatedAt, c.ID, int64(1)).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.UpdateTag(context.TODO(), c)
+	err = repo.UpdateTag(orgCtx, c)
 	assert.NoError(t, err)
 }
 
@@ -126,12 +128,13 @@ func TestDeleteCourseTag(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `DELETE FROM courses_tags WHERE course_id = \? and tag_id= \?`
+	query := `DELETE ct FROM courses_tags ct JOIN tags t ON t.id = ct.tag_id
+		WHERE ct.course_id = \? and ct.tag_id= \? AND t.organization_id = \?`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(courseID, tagID).WillReturnResult(sqlmock.NewResult(1, 1))
+	prep.ExpectExec().WithArgs(courseID, tagID, int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.DeleteCourseTag(context.TODO(), int64(courseID), int64(tagID))
+	err = repo.DeleteCourseTag(orgCtx, int64(courseID), int64(tagID))
 	assert.NoError(t, err)
 }
 func TestCreateCourseTag(t *testing.T) {
@@ -141,13 +144,17 @@ func TestCreateCourseTag(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `INSERT courses_tags SET course_id=\?,tag_id=\?,created_at=\?`
+	query := `INSERT INTO courses_tags \(course_id,tag_id,created_at\) SELECT (.+) FROM courses c JOIN tags t`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(courseID, tagID, time.Now().Unix()).WillReturnResult(sqlmock.NewResult(1, 1))
+	prep.ExpectExec().WithArgs(sqlmock.AnyArg(), tagID, courseID, int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(sqlmock.AnyArg(), tagID, courseID, int64(2), int64(2)).WillReturnResult(sqlmock.NewResult(0, 0))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.CreateCourseTag(context.TODO(), courseID, tagID)
+	err = repo.CreateCourseTag(orgCtx, tagID, courseID)
 	assert.NoError(t, err)
+	err = repo.CreateCourseTag(domain.WithOrganizationID(orgCtx, 2), tagID, courseID)
+	assert.Equal(t, domain.ErrNotFound, err)
 }
 func TestGetCourseTags(t *testing.T) {
 	db, mock, err := sqlmock.New()
@@ -157,10 +164,11 @@ func TestGetCourseTags(t *testing.T) {
 	row := sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"}).
 		AddRow("1", "testing-2", time.Now().Unix(), time.Now().Unix())
 
-	query := `select t.id,t.name,t.updated_at,t.created_at from tags t, courses_tags ct where ct.course_id=\?`
-	mock.ExpectQuery(query).WillReturnRows(row)
+	query := `select t.id,t.name,t.updated_at,t.created_at from tags t, courses_tags ct
+		where ct.course_id=\? AND t.id=ct.tag_id AND t.organization_id=\?`
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(1)).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	tag, err := c.GetCourseTags(context.TODO(), 1)
+	tag, err := c.GetCourseTags(orgCtx, 1)
 	assert.NoError(t, err)
 	assert.NotNil(t, tag)
 }
@@ -171,12 +179,12 @@ func TestCreateLessonTag(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `INSERT lessons_tags SET lesson_id=\?,tag_id=\?,created_at=\?`
+	query := `INSERT INTO lessons_tags \(lesson_id,tag_id,created_at\) SELECT (.+) FROM lessons l`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(lessonID, tagID, time.Now().Unix()).WillReturnResult(sqlmock.NewResult(1, 1))
+	prep.ExpectExec().WithArgs(sqlmock.AnyArg(), tagID, lessonID, int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.CreateLessonTag(context.TODO(), lessonID, tagID)
+	err = repo.CreateLessonTag(orgCtx, tagID, lessonID)
 	assert.NoError(t, err)
 }
 func TestDeleteLessonTag(t *testing.T) {
@@ -186,12 +194,13 @@ func TestDeleteLessonTag(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error %s was not expected when opening stub database connection", err)
 	}
-	query := `DELETE FROM lessons_tags WHERE lesson_id = \? and tag_id= \?`
+	query := `DELETE lt FROM lessons_tags lt JOIN tags t ON t.id = lt.tag_id
+		WHERE lt.lesson_id = \? and lt.tag_id= \? AND t.organization_id = \?`
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(lessonID, tagID).WillReturnResult(sqlmock.NewResult(1, 1))
+	prep.ExpectExec().WithArgs(lessonID, tagID, int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := mysqlrepo.Init(db)
-	err = repo.DeleteLessonTag(context.TODO(), int64(lessonID), int64(tagID))
+	err = repo.DeleteLessonTag(orgCtx, int64(lessonID), int64(tagID))
 	assert.NoError(t, err)
 }
 
@@ -203,10 +212,86 @@ func TestGetLessonTags(t *testing.T) {
 	row := sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"}).
 		AddRow("1", "testing-2", time.Now().Unix(), time.Now().Unix())
 
-	query := `select t.id,t.name,t.updated_at,t.created_at from tags t, lessons_tags lt where lt.lesson_id=\?`
-	mock.ExpectQuery(query).WillReturnRows(row)
+	query := `select t.id,t.name,t.updated_at,t.created_at from tags t, lessons_tags lt
+		where lt.lesson_id=\? AND t.id=lt.tag_id AND t.organization_id=\?`
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(1)).WillReturnRows(row)
 	c := mysqlrepo.Init(db)
-	tag, err := c.GetLessonTags(context.TODO(), 1)
+	tag, err := c.GetLessonTags(orgCtx, 1)
 	assert.NoError(t, err)
 	assert.NotNil(t, tag)
 }
+
+func TestCreateQuestionTag(t *testing.T) {
+	query := `INSERT INTO questions_tags \(question_id,tag_id,created_at\) SELECT q.id,t.id,\? FROM questions q`
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error %s was not expected when opening stub database connection", err)
+		}
+		mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), int64(7), int64(3), int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := mysqlrepo.Init(db)
+		assert.NoError(t, repo.CreateQuestionTag(orgCtx, 7, 3))
+	})
+	t.Run("already-tagged", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error %s was not expected when opening stub database connection", err)
+		}
+		mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := mysqlrepo.Init(db)
+		assert.Equal(t, domain.ErrNotFound, repo.CreateQuestionTag(orgCtx, 7, 3))
+	})
+}
+
+func TestDeleteQuestionTag(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	query := `DELETE qt FROM questions_tags qt JOIN tags t ON t.id = qt.tag_id
+		WHERE qt.question_id = \? AND qt.tag_id = \? AND t.organization_id = \?`
+	mock.ExpectExec(query).WithArgs(int64(3), int64(7), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := mysqlrepo.Init(db)
+	assert.NoError(t, repo.DeleteQuestionTag(orgCtx, 7, 3))
+}
+
+func TestGetQuestionTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	row := sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"}).
+		AddRow("7", "geography", time.Now().Unix(), time.Now().Unix())
+
+	query := `SELECT t.id,t.name,t.updated_at,t.created_at FROM tags t JOIN questions_tags qt ON qt.tag_id = t.id
+		WHERE qt.question_id = \? AND t.organization_id = \? ORDER BY t.name`
+	mock.ExpectQuery(query).WithArgs(int64(3), int64(1)).WillReturnRows(row)
+	c := mysqlrepo.Init(db)
+	tags, err := c.GetQuestionTags(orgCtx, 3)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 1)
+}
+
+func TestBulkDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error %s was not expected when opening stub database connection", err)
+	}
+	used := `SELECT EXISTS\(SELECT 1 FROM quizzes_pools p JOIN tags t ON t.id = p.tag_id WHERE t.id = \? AND t.organization_id = \?\)`
+	query := `DELETE FROM tags WHERE id = \? AND organization_id = \?`
+	mock.ExpectBegin()
+	mock.ExpectQuery(used).WithArgs(1, int64(1)).WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(false))
+	mock.ExpectExec(query).WithArgs(1, int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(used).WithArgs(2, int64(1)).WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(true))
+	mock.ExpectCommit()
+
+	repo := mysqlrepo.Init(db)
+	results, err := repo.BulkDelete(orgCtx, []int64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.BulkResult{{ID: 1, Success: true}, {ID: 2, Error: domain.ErrConflict.Error()}}, results,
+		"a tag a quiz draws a pool from is kept")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
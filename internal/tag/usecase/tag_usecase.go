@@ -163,3 +163,34 @@ func (usecase *TagUseCase) GetLessonTags(ctx context.Context, lessonID int64) ([
 
 	return tags, nil
 }
+
+// CreateQuestionTag tags a question of a question bank, to draw it from the pool of the tag
+func (usecase *TagUseCase) CreateQuestionTag(c context.Context, tagID int64, questionID int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.tagRepo.CreateQuestionTag(ctx, tagID, questionID)
+}
+
+// DeleteQuestionTag ...
+func (usecase *TagUseCase) DeleteQuestionTag(c context.Context, tagID int64, questionID int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.tagRepo.DeleteQuestionTag(ctx, tagID, questionID)
+}
+
+// GetQuestionTags ...
+func (usecase *TagUseCase) GetQuestionTags(c context.Context, questionID int64) ([]domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.tagRepo.GetQuestionTags(ctx, questionID)
+}
+
+// BulkAction will run the same action on many tags at once. Only delete is supported.
+func (usecase *TagUseCase) BulkAction(c context.Context, action *domain.BulkAction) ([]domain.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if action.Action != domain.BulkDelete {
+		return nil, domain.ErrBadParamInput
+	}
+	return usecase.tagRepo.BulkDelete(ctx, action.IDs)
+}
@@ -386,3 +386,41 @@ func TestGetLessonTags(t *testing.T) {
 		mockTagRepo.AssertExpectations(t)
 	})
 }
+
+func TestCreateQuestionTag(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+	t.Run("success", func(t *testing.T) {
+		mockTagRepo.On("CreateQuestionTag", mock.Anything, int64(1), int64(2)).Return(nil).Once()
+
+		u := ucase.NewTagUseCase(mockTagRepo, time.Second*2)
+
+		err := u.CreateQuestionTag(context.TODO(), 1, 2)
+
+		assert.NoError(t, err)
+		mockTagRepo.AssertExpectations(t)
+	})
+	t.Run("error", func(t *testing.T) {
+		mockTagRepo.On("CreateQuestionTag", mock.Anything, int64(1), int64(2)).Return(domain.ErrNotFound).Once()
+
+		u := ucase.NewTagUseCase(mockTagRepo, time.Second*2)
+
+		err := u.CreateQuestionTag(context.TODO(), 1, 2)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockTagRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetQuestionTags(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+	mockListTag := []domain.Tag{{ID: 1, Name: "geography"}}
+	mockTagRepo.On("GetQuestionTags", mock.Anything, int64(2)).Return(mockListTag, nil).Once()
+
+	u := ucase.NewTagUseCase(mockTagRepo, time.Second*2)
+
+	tags, err := u.GetQuestionTags(context.TODO(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mockListTag, tags)
+	mockTagRepo.AssertExpectations(t)
+}
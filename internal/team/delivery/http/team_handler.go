@@ -0,0 +1,469 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// TeamHandler ...
+type TeamHandler struct {
+	TeamUseCase domain.TeamUseCase
+}
+
+// NewTeamHandler ...
+func NewTeamHandler(e *echo.Echo, us domain.TeamUseCase) {
+	handler := &TeamHandler{
+		TeamUseCase: us,
+	}
+	// Get Operation
+	e.GET("/teams", handler.GetAll, rbac.Require(domain.PermUserManage))
+	e.GET("/teams/:id", handler.GetByID, rbac.Require(domain.PermUserManage))
+	e.GET("/teams/:id/members", handler.GetMembers, rbac.Require(domain.PermUserManage))
+	e.GET("/users/:id/teams", handler.GetByUser, rbac.Require(domain.PermUserManage))
+	e.GET("/courses/:id/teams", handler.GetByCourse, rbac.Require(domain.PermReportView))
+
+	// Create/Add Operation
+	e.POST("/teams", handler.CreateTeam, rbac.Require(domain.PermUserManage))
+	e.POST("/teams/:id/members/:user_id", handler.AddMember, rbac.Require(domain.PermUserManage))
+	e.POST("/courses/:id/teams", handler.EnrollTeam, rbac.Require(domain.PermEnrollmentManage))
+
+	// Update Operation
+	e.PUT("/teams/:id", handler.UpdateTeam, rbac.Require(domain.PermUserManage))
+
+	// Remove/Delete Operation
+	e.DELETE("/teams/:id", handler.DeleteTeam, rbac.Require(domain.PermUserManage))
+	e.DELETE("/teams/:id/members/:user_id", handler.RemoveMember, rbac.Require(domain.PermUserManage))
+	e.DELETE("/courses/:id/teams/:team_id", handler.UnenrollTeam, rbac.Require(domain.PermEnrollmentManage))
+}
+
+// startLimit parses the start and limit query params, 0 and 10 by default
+func startLimit(echoContext echo.Context) (start int, limit int, err error) {
+	start, limit = 0, 10
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// GetAll godoc
+// @Summary Get All teams.
+// @Description Get the teams of the organization whose name contains q, by name.
+// @Tags teams
+// @Accept */*
+// @Produce json
+// @Param q query string false "search query"
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /teams [get]
+func (c *TeamHandler) GetAll(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	searchQuery := echoContext.QueryParam("q")
+	start, limit, err := startLimit(echoContext)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	list, err := c.TeamUseCase.GetAll(ctx, searchQuery, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary Get team by ID.
+// @Description Get team by ID.
+// @Tags teams
+// @Accept */*
+// @Produce json
+// @Param id path int true "Team Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /teams/{id} [get]
+func (c *TeamHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	team, err := c.TeamUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    team,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetByUser godoc
+// @Summary Get the teams of a user.
+// @Description Get the teams the user is a member of, by name.
+// @Tags teams
+// @Accept */*
+// @Produce json
+// @Param id path int true "User Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users/{id}/teams [get]
+func (c *TeamHandler) GetByUser(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	list, err := c.TeamUseCase.GetByUser(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    list,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// CreateTeam godoc
+// @Summary Create a team.
+// @Description Create a team in the organization. Its role is learner unless role_id is set, and its name is unique.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param team body domain.Team true "team Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Name already taken"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /teams [post]
+func (c *TeamHandler) CreateTeam(echoContext echo.Context) error {
+	var team domain.Team
+	err := echoContext.Bind(&team)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&team); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.TeamUseCase.CreateTeam(ctx, &team)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    team,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusCreated, res)
+}
+
+// UpdateTeam godoc
+// @Summary Update existing team
+// @Description Update the name, description, image and role of a team.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team Id"
+// @Param team body domain.Team true "team Data"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Name already taken"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /teams/{id} [put]
+func (c *TeamHandler) UpdateTeam(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var team domain.Team
+	err = echoContext.Bind(&team)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&team); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.TeamUseCase.UpdateTeam(ctx, &team, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    team,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// DeleteTeam godoc
+// @Summary Delete a team.
+// @Description Delete a team with its memberships and course enrollments. The enrollments of its members stay.
+// @Tags teams
+// @Accept */*
+// @Produce json
+// @Param id path int true "Team Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /teams/{id} [delete]
+func (c *TeamHandler) DeleteTeam(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.TeamUseCase.DeleteTeam(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// GetMembers godoc
+// @Summary Get the members of a team.
+// @Description Get the members of a team, by name.
+// @Tags teams
+// @Accept */*
+// @Produce json
+// @Param id path int true "Team Id"
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /teams/{id}/members [get]
+func (c *TeamHandler) GetMembers(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	start, limit, err := startLimit(echoContext)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	list, err := c.TeamUseCase.GetMembers(ctx, int64(idParam), start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// memberParams parses the team and user IDs of a membership route
+func memberParams(echoContext echo.Context) (teamID int64, userID int64, err error) {
+	id, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return
+	}
+	uid, err := strconv.Atoi(echoContext.Param("user_id"))
+	return int64(id), int64(uid), err
+}
+
+// AddMember godoc
+// @Summary Add a member to a team.
+// @Description Add a user of the organization to a team, and enroll them in the courses of the team.
+// @Description The members of a team synced from a directory group can not be changed.
+// @Tags teams
+// @Accept */*
+// @Produce json
+// @Param id path int true "Team Id"
+// @Param user_id path int true "User Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Already a member"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /teams/{id}/members/{user_id} [post]
+func (c *TeamHandler) AddMember(echoContext echo.Context) error {
+	teamID, userID, err := memberParams(echoContext)
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.TeamUseCase.AddMember(ctx, teamID, userID)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// RemoveMember godoc
+// @Summary Remove a member from a team.
+// @Description Remove a user from a team. The enrollments of the user stay.
+// @Tags teams
+// @Accept */*
+// @Produce json
+// @Param id path int true "Team Id"
+// @Param user_id path int true "User Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Team synced from a directory group"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /teams/{id}/members/{user_id} [delete]
+func (c *TeamHandler) RemoveMember(echoContext echo.Context) error {
+	teamID, userID, err := memberParams(echoContext)
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.TeamUseCase.RemoveMember(ctx, teamID, userID)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// GetByCourse godoc
+// @Summary Get the teams enrolled in a course.
+// @Description Get the teams enrolled in a course, by name.
+// @Tags teams
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/teams [get]
+func (c *TeamHandler) GetByCourse(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	list, err := c.TeamUseCase.GetByCourse(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    list,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// EnrollTeam godoc
+// @Summary Enroll a team in a course.
+// @Description Enroll a team in a published course, and its members with it. The members who join later are enrolled as they are added.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param enrollment body domain.TeamEnrollment true "team_id"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError "Course not published"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Team already enrolled"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/teams [post]
+func (c *TeamHandler) EnrollTeam(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var enrollment domain.TeamEnrollment
+	err = echoContext.Bind(&enrollment)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	enrollment.CourseID = int64(idParam)
+	var ok bool
+	if ok, err = util.IsRequestValid(&enrollment); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.TeamUseCase.EnrollTeam(ctx, &enrollment)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    enrollment,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusCreated, res)
+}
+
+// UnenrollTeam godoc
+// @Summary Unenroll a team from a course.
+// @Description Remove the enrollment of a team in a course. The enrollments of its members stay.
+// @Tags teams
+// @Accept */*
+// @Produce json
+// @Param id path int true "Course Id"
+// @Param team_id path int true "Team Id"
+// @Success 204
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /courses/{id}/teams/{team_id} [delete]
+func (c *TeamHandler) UnenrollTeam(echoContext echo.Context) error {
+	courseID, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	teamID, err := strconv.Atoi(echoContext.Param("team_id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.TeamUseCase.UnenrollTeam(ctx, int64(courseID), int64(teamID))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
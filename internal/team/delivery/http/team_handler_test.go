@@ -0,0 +1,372 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	teamHTTP "github.com/meroedu/meroedu/internal/team/delivery/http"
+)
+
+func TestGetAll(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("GetAll", mock.Anything, "sales", 0, 10).Return([]domain.Team{{ID: 2, Name: "Sales"}}, nil).Once()
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"q=sales", http.StatusOK},
+		{"limit=ten", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/teams?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := teamHTTP.TeamHandler{
+			TeamUseCase: mockUCase,
+		}
+		err = handler.GetAll(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.query)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("GetByID", mock.Anything, int64(2)).Return(&domain.Team{ID: 2, Name: "Sales"}, nil).Once()
+	mockUCase.On("GetByID", mock.Anything, int64(3)).Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"2", http.StatusOK},
+		{"3", http.StatusNotFound},
+		{"sales", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/teams/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/teams/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := teamHTTP.TeamHandler{
+			TeamUseCase: mockUCase,
+		}
+		err = handler.GetByID(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByUser(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("GetByUser", mock.Anything, int64(3)).Return([]domain.Team{{ID: 2, Name: "Sales"}}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/users/3/teams", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/users/:id/teams")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := teamHTTP.TeamHandler{
+		TeamUseCase: mockUCase,
+	}
+	err = handler.GetByUser(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateTeam(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("CreateTeam", mock.Anything, mock.MatchedBy(func(team *domain.Team) bool { return team.Name == "Sales" })).Return(nil).Once()
+	mockUCase.On("CreateTeam", mock.Anything, mock.MatchedBy(func(team *domain.Team) bool { return team.Name == "Support" })).Return(domain.ErrConflict).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"name":"Sales","role_id":2}`, http.StatusCreated},
+		{`{"name":"Support"}`, http.StatusConflict},
+		{`{"description":"Sales team"}`, http.StatusBadRequest},
+		{`{"name":"Sales","role_id":"learner"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/teams", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := teamHTTP.TeamHandler{
+			TeamUseCase: mockUCase,
+		}
+		err = handler.CreateTeam(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdateTeam(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("UpdateTeam", mock.Anything, mock.AnythingOfType("*domain.Team"), int64(2)).Return(nil).Once()
+	mockUCase.On("UpdateTeam", mock.Anything, mock.AnythingOfType("*domain.Team"), int64(5)).Return(domain.ErrForbidden).Once()
+
+	tests := []struct {
+		id   string
+		body string
+		code int
+	}{
+		{"2", `{"name":"Sales"}`, http.StatusOK},
+		{"5", `{"name":"Directory group"}`, http.StatusForbidden},
+		{"2", `{"name":""}`, http.StatusBadRequest},
+		{"sales", `{"name":"Sales"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/teams/"+tt.id, strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/teams/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := teamHTTP.TeamHandler{
+			TeamUseCase: mockUCase,
+		}
+		err = handler.UpdateTeam(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteTeam(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("DeleteTeam", mock.Anything, int64(2)).Return(nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/teams/2", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/teams/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	handler := teamHTTP.TeamHandler{
+		TeamUseCase: mockUCase,
+	}
+	err = handler.DeleteTeam(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetMembers(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("GetMembers", mock.Anything, int64(2), 20, 10).Return([]domain.User{{ID: 3}}, nil).Once()
+
+	tests := []struct {
+		id    string
+		query string
+		code  int
+	}{
+		{"2", "start=20", http.StatusOK},
+		{"2", "start=first", http.StatusInternalServerError},
+		{"sales", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/teams/"+tt.id+"/members?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/teams/:id/members")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := teamHTTP.TeamHandler{
+			TeamUseCase: mockUCase,
+		}
+		err = handler.GetMembers(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id+"?"+tt.query)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestAddMember(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("AddMember", mock.Anything, int64(2), int64(3)).Return(nil).Once()
+	mockUCase.On("AddMember", mock.Anything, int64(2), int64(4)).Return(domain.ErrConflict).Once()
+
+	tests := []struct {
+		userID string
+		code   int
+	}{
+		{"3", http.StatusNoContent},
+		{"4", http.StatusConflict},
+		{"ram", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/teams/2/members/"+tt.userID, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/teams/:id/members/:user_id")
+		c.SetParamNames("id", "user_id")
+		c.SetParamValues("2", tt.userID)
+		handler := teamHTTP.TeamHandler{
+			TeamUseCase: mockUCase,
+		}
+		err = handler.AddMember(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.userID)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRemoveMember(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("RemoveMember", mock.Anything, int64(2), int64(3)).Return(domain.ErrNotFound).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/teams/2/members/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/teams/:id/members/:user_id")
+	c.SetParamNames("id", "user_id")
+	c.SetParamValues("2", "3")
+	handler := teamHTTP.TeamHandler{
+		TeamUseCase: mockUCase,
+	}
+	err = handler.RemoveMember(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByCourse(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("GetByCourse", mock.Anything, int64(12)).Return([]domain.Team{{ID: 2, Name: "Sales"}}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/courses/12/teams", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/courses/:id/teams")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := teamHTTP.TeamHandler{
+		TeamUseCase: mockUCase,
+	}
+	err = handler.GetByCourse(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestEnrollTeam(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("EnrollTeam", mock.Anything, &domain.TeamEnrollment{CourseID: 12, TeamID: 2}).Return(nil).Once()
+	mockUCase.On("EnrollTeam", mock.Anything, &domain.TeamEnrollment{CourseID: 12, TeamID: 4}).Return(domain.ErrCourseNotPublished).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"team_id":2}`, http.StatusCreated},
+		{`{"team_id":4}`, http.StatusBadRequest},
+		{`{}`, http.StatusBadRequest},
+		{`{"team_id":"sales"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/courses/12/teams", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/courses/:id/teams")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := teamHTTP.TeamHandler{
+			TeamUseCase: mockUCase,
+		}
+		err = handler.EnrollTeam(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUnenrollTeam(t *testing.T) {
+	mockUCase := new(mocks.TeamUseCase)
+	mockUCase.On("UnenrollTeam", mock.Anything, int64(12), int64(2)).Return(nil).Once()
+
+	tests := []struct {
+		courseID string
+		teamID   string
+		code     int
+	}{
+		{"12", "2", http.StatusNoContent},
+		{"go", "2", http.StatusNotFound},
+		{"12", "sales", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/courses/"+tt.courseID+"/teams/"+tt.teamID, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/courses/:id/teams/:team_id")
+		c.SetParamNames("id", "team_id")
+		c.SetParamValues(tt.courseID, tt.teamID)
+		handler := teamHTTP.TeamHandler{
+			TeamUseCase: mockUCase,
+		}
+		err = handler.UnenrollTeam(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.courseID+"/"+tt.teamID)
+	}
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,312 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const teamQuery = `SELECT t.id,t.name,t.description,t.image_url,t.role_id,t.organization_id,t.ldap_group_dn,
+	(SELECT COUNT(*) FROM teams_users tu WHERE tu.team_id = t.id),t.updated_at,t.created_at FROM teams t`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the team's Repository interface
+func Init(db *sql.DB) domain.TeamRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Team, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Team, 0)
+	for rows.Next() {
+		t := domain.Team{}
+		var description, imageURL, groupDN sql.NullString
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&description,
+			&imageURL,
+			&t.RoleID,
+			&t.OrganizationID,
+			&groupDN,
+			&t.Members,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.Description = description.String
+		t.ImageURL = imageURL.String
+		t.LDAPGroupDN = groupDN.String
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) getOne(ctx context.Context, query string, args ...interface{}) (*domain.Team, error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// fetchIDs returns the first column of the rows of the query
+func (m *mysqlRepository) fetchIDs(ctx context.Context, query string, args ...interface{}) ([]int64, error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+// GetAll returns the teams of the caller's organization whose name contains searchQuery, by name
+func (m *mysqlRepository) GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]domain.Team, error) {
+	query := teamQuery + ` WHERE t.organization_id = ? AND t.name LIKE ? ORDER BY t.name, t.id LIMIT ?,?`
+	return m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), "%"+searchQuery+"%", start, limit)
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.Team, error) {
+	query := teamQuery + ` WHERE t.id = ? AND t.organization_id = ?`
+	return m.getOne(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+}
+
+func (m *mysqlRepository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
+	query := teamQuery + ` WHERE t.name = ? AND t.organization_id = ? ORDER BY t.id LIMIT 1`
+	return m.getOne(ctx, query, name, domain.OrganizationIDFromContext(ctx))
+}
+
+// GetByUser returns the teams the user is a member of, by name
+func (m *mysqlRepository) GetByUser(ctx context.Context, userID int64) ([]domain.Team, error) {
+	query := teamQuery + ` JOIN teams_users m ON m.team_id = t.id WHERE m.user_id = ? AND t.organization_id = ? ORDER BY t.name, t.id`
+	return m.fetch(ctx, query, userID, domain.OrganizationIDFromContext(ctx))
+}
+
+// CreateTeam creates the team in the caller's organization
+func (m *mysqlRepository) CreateTeam(ctx context.Context, t *domain.Team) error {
+	t.OrganizationID = domain.OrganizationIDFromContext(ctx)
+	query := `INSERT teams SET name=?,description=?,image_url=?,role_id=?,organization_id=?,updated_at=?,created_at=?`
+	res, err := m.conn.ExecContext(ctx, query, t.Name, nullString(t.Description), nullString(t.ImageURL), t.RoleID, t.OrganizationID,
+		t.UpdatedAt, t.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	if t.ID, err = res.LastInsertId(); err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return err
+	}
+	return nil
+}
+
+func (m *mysqlRepository) UpdateTeam(ctx context.Context, t *domain.Team) error {
+	query := `UPDATE teams SET name=?,description=?,image_url=?,role_id=?,updated_at=? WHERE id = ? AND organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, t.Name, nullString(t.Description), nullString(t.ImageURL), t.RoleID, t.UpdatedAt,
+		t.ID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteTeam removes the team with its memberships and course enrollments. The enrollments of its members stay.
+func (m *mysqlRepository) DeleteTeam(ctx context.Context, id int64) error {
+	res, err := m.conn.ExecContext(ctx, `DELETE FROM teams WHERE id = ? AND organization_id = ?`, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetMembers returns the members of a team of the caller's organization, by name
+func (m *mysqlRepository) GetMembers(ctx context.Context, teamID int64, start int, limit int) ([]domain.User, error) {
+	query := `SELECT u.id,u.firstName,u.lastName,u.email,u.username,u.role_id,u.status,tu.created_at FROM teams_users tu
+		JOIN teams t ON t.id = tu.team_id JOIN users u ON u.id = tu.user_id
+		WHERE tu.team_id = ? AND t.organization_id = ? ORDER BY u.lastName, u.firstName, u.id LIMIT ?,?`
+	rows, err := m.conn.QueryContext(ctx, query, teamID, domain.OrganizationIDFromContext(ctx), start, limit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result := make([]domain.User, 0)
+	for rows.Next() {
+		u := domain.User{}
+		var firstName, email, username sql.NullString
+		// the joined date of a member is when it joined the team
+		if err = rows.Scan(&u.ID, &firstName, &u.LastName, &email, &username, &u.RoleID, &u.Status, &u.JoinedDate); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		u.FirstName = firstName.String
+		u.Email = email.String
+		u.Username = username.String
+		result = append(result, u)
+	}
+	return result, nil
+}
+
+// GetMemberIDs returns the IDs of every member of a team of the caller's organization
+func (m *mysqlRepository) GetMemberIDs(ctx context.Context, teamID int64) ([]int64, error) {
+	query := `SELECT tu.user_id FROM teams_users tu JOIN teams t ON t.id = tu.team_id WHERE tu.team_id = ? AND t.organization_id = ?
+		ORDER BY tu.user_id`
+	return m.fetchIDs(ctx, query, teamID, domain.OrganizationIDFromContext(ctx))
+}
+
+// AddMember adds a user of the caller's organization to one of its teams. A member already gives ErrConflict.
+func (m *mysqlRepository) AddMember(ctx context.Context, teamID int64, userID int64, createdAt int64) error {
+	query := `INSERT INTO teams_users (team_id,user_id,created_at) SELECT t.id,u.id,? FROM teams t JOIN users u ON u.organization_id = t.organization_id
+		WHERE t.id = ? AND u.id = ? AND t.organization_id = ?
+		AND NOT EXISTS (SELECT 1 FROM teams_users tu WHERE tu.team_id = t.id AND tu.user_id = u.id)`
+	res, err := m.conn.ExecContext(ctx, query, createdAt, teamID, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+func (m *mysqlRepository) RemoveMember(ctx context.Context, teamID int64, userID int64) error {
+	query := `DELETE tu FROM teams_users tu JOIN teams t ON t.id = tu.team_id WHERE tu.team_id = ? AND tu.user_id = ? AND t.organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, teamID, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetByCourse returns the teams enrolled in a course of the caller's organization, by name
+func (m *mysqlRepository) GetByCourse(ctx context.Context, courseID int64) ([]domain.Team, error) {
+	query := teamQuery + ` JOIN courses_teams_enrollments e ON e.team_id = t.id WHERE e.course_id = ? AND t.organization_id = ?
+		ORDER BY t.name, t.id`
+	return m.fetch(ctx, query, courseID, domain.OrganizationIDFromContext(ctx))
+}
+
+// GetCourseIDs returns the IDs of the courses a team of the caller's organization is enrolled in
+func (m *mysqlRepository) GetCourseIDs(ctx context.Context, teamID int64) ([]int64, error) {
+	query := `SELECT e.course_id FROM courses_teams_enrollments e JOIN teams t ON t.id = e.team_id WHERE e.team_id = ? AND t.organization_id = ?
+		ORDER BY e.course_id`
+	return m.fetchIDs(ctx, query, teamID, domain.OrganizationIDFromContext(ctx))
+}
+
+// EnrollTeam enrolls a team of the caller's organization in one of its courses. A team enrolled already gives ErrConflict.
+func (m *mysqlRepository) EnrollTeam(ctx context.Context, e *domain.TeamEnrollment) error {
+	query := `INSERT INTO courses_teams_enrollments (course_id,team_id,status,created_at) SELECT c.id,t.id,?,? FROM courses c
+		JOIN teams t ON t.organization_id = c.organization_id WHERE c.id = ? AND t.id = ? AND c.organization_id = ?
+		AND NOT EXISTS (SELECT 1 FROM courses_teams_enrollments x WHERE x.course_id = c.id AND x.team_id = t.id)`
+	res, err := m.conn.ExecContext(ctx, query, e.Status, e.CreatedAt, e.CourseID, e.TeamID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+// UnenrollTeam removes the enrollment of a team in a course. The enrollments of its members stay.
+func (m *mysqlRepository) UnenrollTeam(ctx context.Context, courseID int64, teamID int64) error {
+	query := `DELETE e FROM courses_teams_enrollments e JOIN teams t ON t.id = e.team_id WHERE e.course_id = ? AND e.team_id = ?
+		AND t.organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, courseID, teamID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
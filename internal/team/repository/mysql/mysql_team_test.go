@@ -0,0 +1,128 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/team/repository/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+var columns = []string{"id", "name", "description", "image_url", "role_id", "organization_id", "ldap_group_dn", "members",
+	"updated_at", "created_at"}
+
+func TestGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `SELECT .+ FROM teams t WHERE t.id = \? AND t.organization_id = \?`
+	mock.ExpectQuery(query).WithArgs(7, 2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(7, "Grade 5", nil, "https://cdn/grade5.png", 4, 2, nil, 12, 100, 90))
+	mock.ExpectQuery(query).WithArgs(8, 2).WillReturnRows(sqlmock.NewRows(columns))
+
+	repo := mysqlrepo.Init(db)
+	team, err := repo.GetByID(orgCtx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Team{ID: 7, Name: "Grade 5", ImageURL: "https://cdn/grade5.png", RoleID: 4, OrganizationID: 2, Members: 12,
+		UpdatedAt: 100, CreatedAt: 90}, team)
+
+	_, err = repo.GetByID(orgCtx, 8)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestGetByUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT .+ FROM teams t JOIN teams_users m ON m.team_id = t.id WHERE m.user_id = \? AND t.organization_id = \?`).
+		WithArgs(11, 2).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(7, "Grade 5", "", nil, 4, 2, nil, 12, 100, 90).
+		AddRow(8, "Staff", "", nil, 4, 2, "cn=staff,dc=school", 3, 100, 90))
+
+	repo := mysqlrepo.Init(db)
+	list, err := repo.GetByUser(orgCtx, 11)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "cn=staff,dc=school", list[1].LDAPGroupDN)
+}
+
+func TestCreateTeam(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`INSERT teams SET name=\?,description=\?,image_url=\?,role_id=\?,organization_id=\?,updated_at=\?,created_at=\?`).
+		WithArgs("Grade 5", nil, "https://cdn/grade5.png", 4, 2, 100, 100).WillReturnResult(sqlmock.NewResult(7, 1))
+
+	repo := mysqlrepo.Init(db)
+	team := &domain.Team{Name: "Grade 5", ImageURL: "https://cdn/grade5.png", RoleID: 4, UpdatedAt: 100, CreatedAt: 100}
+	assert.NoError(t, repo.CreateTeam(orgCtx, team))
+	assert.Equal(t, int64(7), team.ID)
+	assert.Equal(t, int64(2), team.OrganizationID)
+}
+
+func TestAddMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `INSERT INTO teams_users \(team_id,user_id,created_at\) SELECT .+ AND NOT EXISTS`
+	mock.ExpectExec(query).WithArgs(100, 7, 11, 2).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(query).WithArgs(100, 7, 11, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	assert.NoError(t, repo.AddMember(orgCtx, 7, 11, 100))
+	assert.Equal(t, domain.ErrConflict, repo.AddMember(orgCtx, 7, 11, 100), "a member is only added once")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `DELETE tu FROM teams_users tu JOIN teams t ON t.id = tu.team_id WHERE tu.team_id = \? AND tu.user_id = \? AND t.organization_id = \?`
+	mock.ExpectExec(query).WithArgs(7, 11, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(7, 12, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	assert.NoError(t, repo.RemoveMember(orgCtx, 7, 11))
+	assert.Equal(t, domain.ErrNotFound, repo.RemoveMember(orgCtx, 7, 12))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnrollTeam(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `INSERT INTO courses_teams_enrollments \(course_id,team_id,status,created_at\) SELECT .+ AND NOT EXISTS`
+	mock.ExpectExec(query).WithArgs(domain.EnrollmentActive, 100, 3, 7, 2).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(query).WithArgs(domain.EnrollmentActive, 100, 3, 7, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	enrollment := &domain.TeamEnrollment{CourseID: 3, TeamID: 7, Status: domain.EnrollmentActive, CreatedAt: 100}
+	assert.NoError(t, repo.EnrollTeam(orgCtx, enrollment))
+	assert.Equal(t, domain.ErrConflict, repo.EnrollTeam(orgCtx, enrollment))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMemberIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT tu.user_id FROM teams_users tu JOIN teams t ON t.id = tu.team_id WHERE tu.team_id = \? AND t.organization_id = \?`).
+		WithArgs(7, 2).WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(11).AddRow(12))
+
+	repo := mysqlrepo.Init(db)
+	ids, err := repo.GetMemberIDs(orgCtx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{11, 12}, ids)
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// TeamUseCase ...
+type TeamUseCase struct {
+	teamRepo          domain.TeamRepository
+	userRepo          domain.UserRepository
+	roleRepo          domain.RoleRepository
+	courseRepo        domain.CourseRepository
+	enrollmentUseCase domain.EnrollmentUseCase
+	contextTimeOut    time.Duration
+}
+
+// NewTeamUseCase will create new an
+func NewTeamUseCase(t domain.TeamRepository, u domain.UserRepository, r domain.RoleRepository, c domain.CourseRepository,
+	e domain.EnrollmentUseCase, timeout time.Duration) domain.TeamUseCase {
+	return &TeamUseCase{
+		teamRepo:          t,
+		userRepo:          u,
+		roleRepo:          r,
+		courseRepo:        c,
+		enrollmentUseCase: e,
+		contextTimeOut:    timeout,
+	}
+}
+
+// GetAll ...
+func (usecase *TeamUseCase) GetAll(c context.Context, searchQuery string, start int, limit int) ([]domain.Team, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.teamRepo.GetAll(ctx, searchQuery, start, limit)
+}
+
+// GetByID ...
+func (usecase *TeamUseCase) GetByID(c context.Context, id int64) (*domain.Team, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.teamRepo.GetByID(ctx, id)
+}
+
+// GetByUser returns the teams of a user of the caller's organization
+func (usecase *TeamUseCase) GetByUser(c context.Context, userID int64) ([]domain.Team, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return usecase.teamRepo.GetByUser(ctx, userID)
+}
+
+// setRole defaults the role of the team to learner. It returns ErrBadParamInput when the role is not visible
+// to the organization, and ErrForbidden when it grants a permission the caller does not hold.
+func (usecase *TeamUseCase) setRole(ctx context.Context, team *domain.Team) error {
+	var role *domain.Role
+	var err error
+	if team.RoleID == 0 {
+		role, err = usecase.roleRepo.GetByCode(ctx, domain.RoleLearner)
+	} else {
+		role, err = usecase.roleRepo.GetByID(ctx, team.RoleID)
+	}
+	if err == domain.ErrNotFound {
+		return domain.ErrBadParamInput
+	}
+	if err != nil {
+		return err
+	}
+	if !domain.HasPermissions(ctx, role.Permissions) {
+		return domain.ErrForbidden
+	}
+	team.RoleID = role.ID
+	return nil
+}
+
+// CreateTeam will create the team in the caller's organization. Team names are unique in an organization.
+func (usecase *TeamUseCase) CreateTeam(c context.Context, team *domain.Team) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existingTeam, err := usecase.teamRepo.GetByName(ctx, team.Name)
+	if existingTeam != nil {
+		return domain.ErrConflict
+	}
+	if err != nil && err != domain.ErrNotFound {
+		return err
+	}
+	if err = usecase.setRole(ctx, team); err != nil {
+		return err
+	}
+	team.LDAPGroupDN = ""
+	team.Members = 0
+	team.UpdatedAt = time.Now().Unix()
+	team.CreatedAt = team.UpdatedAt
+	return usecase.teamRepo.CreateTeam(ctx, team)
+}
+
+// UpdateTeam ..
+func (usecase *TeamUseCase) UpdateTeam(c context.Context, team *domain.Team, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existingTeam, err := usecase.teamRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if team.Name != existingTeam.Name {
+		namesake, err := usecase.teamRepo.GetByName(ctx, team.Name)
+		if namesake != nil && namesake.ID != id {
+			return domain.ErrConflict
+		}
+		if err != nil && err != domain.ErrNotFound {
+			return err
+		}
+	}
+	if err = usecase.setRole(ctx, team); err != nil {
+		return err
+	}
+	team.ID = id
+	team.OrganizationID = existingTeam.OrganizationID
+	team.LDAPGroupDN = existingTeam.LDAPGroupDN
+	team.Members = existingTeam.Members
+	team.CreatedAt = existingTeam.CreatedAt
+	team.UpdatedAt = time.Now().Unix()
+	return usecase.teamRepo.UpdateTeam(ctx, team)
+}
+
+// DeleteTeam ...
+func (usecase *TeamUseCase) DeleteTeam(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.teamRepo.DeleteTeam(ctx, id)
+}
+
+// GetMembers ...
+func (usecase *TeamUseCase) GetMembers(c context.Context, teamID int64, start int, limit int) ([]domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.teamRepo.GetByID(ctx, teamID); err != nil {
+		return nil, err
+	}
+	return usecase.teamRepo.GetMembers(ctx, teamID, start, limit)
+}
+
+// manualTeam returns the team, or ErrConflict when its members follow a directory group
+func (usecase *TeamUseCase) manualTeam(ctx context.Context, teamID int64) (*domain.Team, error) {
+	team, err := usecase.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team.LDAPGroupDN != "" {
+		return nil, domain.ErrConflict
+	}
+	return team, nil
+}
+
+// AddMember adds a user of the organization to the team and enrolls them in the courses of the team
+func (usecase *TeamUseCase) AddMember(c context.Context, teamID int64, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.manualTeam(ctx, teamID); err != nil {
+		return err
+	}
+	if _, err := usecase.userRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+	if err := usecase.teamRepo.AddMember(ctx, teamID, userID, time.Now().Unix()); err != nil {
+		return err
+	}
+	courseIDs, err := usecase.teamRepo.GetCourseIDs(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	for _, courseID := range courseIDs {
+		usecase.enroll(ctx, courseID, userID)
+	}
+	return nil
+}
+
+// enroll enrolls a member in a course of the team. A failure is only logged, so that it does not undo the membership.
+func (usecase *TeamUseCase) enroll(ctx context.Context, courseID int64, userID int64) {
+	err := usecase.enrollmentUseCase.EnrollUser(ctx, &domain.Enrollment{CourseID: courseID, UserID: userID})
+	if err != nil && err != domain.ErrConflict {
+		log.Errorf("Enrollment of team member %d in course %d failed: %v", userID, courseID, err)
+	}
+}
+
+// RemoveMember removes the user from the team. The enrollments of the user stay.
+func (usecase *TeamUseCase) RemoveMember(c context.Context, teamID int64, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.manualTeam(ctx, teamID); err != nil {
+		return err
+	}
+	return usecase.teamRepo.RemoveMember(ctx, teamID, userID)
+}
+
+// GetByCourse returns the teams enrolled in the course
+func (usecase *TeamUseCase) GetByCourse(c context.Context, courseID int64) ([]domain.Team, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.courseRepo.GetByID(ctx, courseID); err != nil {
+		return nil, err
+	}
+	return usecase.teamRepo.GetByCourse(ctx, courseID)
+}
+
+// EnrollTeam enrolls the team in a published course, and its current members with it.
+// The members who join the team later are enrolled when they are added.
+func (usecase *TeamUseCase) EnrollTeam(c context.Context, enrollment *domain.TeamEnrollment) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if _, err := usecase.teamRepo.GetByID(ctx, enrollment.TeamID); err != nil {
+		return err
+	}
+	if _, err := usecase.courseRepo.GetByID(ctx, enrollment.CourseID); err != nil {
+		return err
+	}
+	_, err := usecase.courseRepo.GetLatestVersion(ctx, enrollment.CourseID)
+	if err == domain.ErrNotFound {
+		return domain.ErrCourseNotPublished
+	}
+	if err != nil {
+		return err
+	}
+	enrollment.Status = domain.EnrollmentActive
+	enrollment.CreatedAt = time.Now().Unix()
+	if err = usecase.teamRepo.EnrollTeam(ctx, enrollment); err != nil {
+		return err
+	}
+	memberIDs, err := usecase.teamRepo.GetMemberIDs(ctx, enrollment.TeamID)
+	if err != nil {
+		return err
+	}
+	for _, userID := range memberIDs {
+		usecase.enroll(ctx, enrollment.CourseID, userID)
+	}
+	return nil
+}
+
+// UnenrollTeam removes the enrollment of the team in the course. The enrollments of its members stay.
+func (usecase *TeamUseCase) UnenrollTeam(c context.Context, courseID int64, teamID int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.teamRepo.UnenrollTeam(ctx, courseID, teamID)
+}
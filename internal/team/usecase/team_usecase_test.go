@@ -0,0 +1,196 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/team/usecase"
+)
+
+var adminCtx = domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2), []domain.Permission{domain.PermUserManage})
+
+func TestCreateTeam(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+		mockTeamRepo.On("GetByName", mock.Anything, "Grade 5").Return(nil, domain.ErrNotFound).Once()
+		mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(&domain.Role{ID: 4, Code: domain.RoleLearner}, nil).Once()
+		mockTeamRepo.On("CreateTeam", mock.Anything, mock.AnythingOfType("*domain.Team")).Return(nil).Once()
+
+		team := &domain.Team{Name: "Grade 5", LDAPGroupDN: "cn=grade5"}
+		assert.NoError(t, u.CreateTeam(adminCtx, team))
+		assert.Equal(t, int64(4), team.RoleID, "the role is learner by default")
+		assert.Empty(t, team.LDAPGroupDN, "only the directory sync links teams to groups")
+		assert.NotZero(t, team.CreatedAt)
+		mockTeamRepo.AssertExpectations(t)
+	})
+	t.Run("name-taken", func(t *testing.T) {
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+		mockTeamRepo.On("GetByName", mock.Anything, "Grade 5").Return(&domain.Team{ID: 7}, nil).Once()
+
+		assert.Equal(t, domain.ErrConflict, u.CreateTeam(adminCtx, &domain.Team{Name: "Grade 5"}))
+		mockTeamRepo.AssertNotCalled(t, "CreateTeam", mock.Anything, mock.Anything)
+	})
+	t.Run("superadmin-role", func(t *testing.T) {
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+		mockTeamRepo.On("GetByName", mock.Anything, "Admins").Return(nil, domain.ErrNotFound).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, int64(1)).
+			Return(&domain.Role{ID: 1, Code: domain.RoleSuperAdmin, Permissions: []domain.Permission{domain.PermOrganizationManage}}, nil).Once()
+
+		assert.Equal(t, domain.ErrForbidden, u.CreateTeam(adminCtx, &domain.Team{Name: "Admins", RoleID: 1}))
+	})
+	t.Run("role-above-caller", func(t *testing.T) {
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+		mockTeamRepo.On("GetByName", mock.Anything, "Managers").Return(nil, domain.ErrNotFound).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, int64(5)).
+			Return(&domain.Role{ID: 5, Code: "manager", Permissions: []domain.Permission{domain.PermUserManage, domain.PermRoleManage}}, nil).Once()
+
+		assert.Equal(t, domain.ErrForbidden, u.CreateTeam(adminCtx, &domain.Team{Name: "Managers", RoleID: 5}))
+		mockTeamRepo.AssertNotCalled(t, "CreateTeam", mock.Anything, mock.Anything)
+	})
+	t.Run("unknown-role", func(t *testing.T) {
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+		mockTeamRepo.On("GetByName", mock.Anything, "Staff").Return(nil, domain.ErrNotFound).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, int64(9)).Return(nil, domain.ErrNotFound).Once()
+
+		assert.Equal(t, domain.ErrBadParamInput, u.CreateTeam(adminCtx, &domain.Team{Name: "Staff", RoleID: 9}))
+	})
+}
+
+func TestUpdateTeam(t *testing.T) {
+	mockTeamRepo := new(mocks.TeamRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	mockCourseRepo := new(mocks.CourseRepository)
+	mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+	u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+	existing := &domain.Team{ID: 7, Name: "Grade 5", RoleID: 4, OrganizationID: 2, LDAPGroupDN: "cn=grade5", Members: 12, CreatedAt: 90}
+	mockTeamRepo.On("GetByID", mock.Anything, int64(7)).Return(existing, nil)
+	mockTeamRepo.On("GetByName", mock.Anything, "Grade 6").Return(&domain.Team{ID: 8}, nil).Once()
+	mockTeamRepo.On("GetByName", mock.Anything, "Grade five").Return(nil, domain.ErrNotFound).Once()
+	mockRoleRepo.On("GetByID", mock.Anything, int64(4)).Return(&domain.Role{ID: 4, Code: domain.RoleLearner}, nil).Once()
+	mockTeamRepo.On("UpdateTeam", mock.Anything, mock.AnythingOfType("*domain.Team")).Return(nil).Once()
+
+	assert.Equal(t, domain.ErrConflict, u.UpdateTeam(adminCtx, &domain.Team{Name: "Grade 6", RoleID: 4}, 7))
+
+	team := &domain.Team{Name: "Grade five", ImageURL: "https://cdn/grade5.png", RoleID: 4}
+	assert.NoError(t, u.UpdateTeam(adminCtx, team, 7))
+	assert.Equal(t, int64(7), team.ID)
+	assert.Equal(t, "cn=grade5", team.LDAPGroupDN)
+	assert.Equal(t, int64(90), team.CreatedAt)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestAddMember(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+		mockTeamRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Team{ID: 7}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, int64(11)).Return(&domain.User{ID: 11}, nil).Once()
+		mockTeamRepo.On("AddMember", mock.Anything, int64(7), int64(11), mock.AnythingOfType("int64")).Return(nil).Once()
+		mockTeamRepo.On("GetCourseIDs", mock.Anything, int64(7)).Return([]int64{3, 5}, nil).Once()
+		mockEnrollmentUseCase.On("EnrollUser", mock.Anything, &domain.Enrollment{CourseID: 3, UserID: 11}).Return(domain.ErrConflict).Once()
+		mockEnrollmentUseCase.On("EnrollUser", mock.Anything, &domain.Enrollment{CourseID: 5, UserID: 11}).Return(nil).Once()
+
+		assert.NoError(t, u.AddMember(adminCtx, 7, 11))
+		mockTeamRepo.AssertExpectations(t)
+		mockEnrollmentUseCase.AssertExpectations(t)
+	})
+	t.Run("unknown-user", func(t *testing.T) {
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+		mockTeamRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Team{ID: 7}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, int64(12)).Return(nil, domain.ErrNotFound).Once()
+
+		assert.Equal(t, domain.ErrNotFound, u.AddMember(adminCtx, 7, 12))
+		mockTeamRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("directory-team", func(t *testing.T) {
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+		mockTeamRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Team{ID: 8, LDAPGroupDN: "cn=staff"}, nil).Twice()
+
+		assert.Equal(t, domain.ErrConflict, u.AddMember(adminCtx, 8, 11))
+		assert.Equal(t, domain.ErrConflict, u.RemoveMember(adminCtx, 8, 11))
+	})
+}
+
+func TestEnrollTeam(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+		mockTeamRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Team{ID: 7}, nil).Once()
+		mockCourseRepo.On("GetByID", mock.Anything, int64(3)).Return(&domain.Course{ID: 3}, nil).Once()
+		mockCourseRepo.On("GetLatestVersion", mock.Anything, int64(3)).Return(&domain.CourseVersion{ID: 30}, nil).Once()
+		mockTeamRepo.On("EnrollTeam", mock.Anything, mock.AnythingOfType("*domain.TeamEnrollment")).Return(nil).Once()
+		mockTeamRepo.On("GetMemberIDs", mock.Anything, int64(7)).Return([]int64{11, 12}, nil).Once()
+		mockEnrollmentUseCase.On("EnrollUser", mock.Anything, &domain.Enrollment{CourseID: 3, UserID: 11}).Return(nil).Once()
+		mockEnrollmentUseCase.On("EnrollUser", mock.Anything, &domain.Enrollment{CourseID: 3, UserID: 12}).Return(domain.ErrConflict).Once()
+
+		enrollment := &domain.TeamEnrollment{CourseID: 3, TeamID: 7}
+		assert.NoError(t, u.EnrollTeam(adminCtx, enrollment))
+		assert.Equal(t, domain.EnrollmentActive, enrollment.Status)
+		assert.NotZero(t, enrollment.CreatedAt)
+		mockEnrollmentUseCase.AssertExpectations(t)
+	})
+	t.Run("not-published", func(t *testing.T) {
+		mockTeamRepo := new(mocks.TeamRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewTeamUseCase(mockTeamRepo, mockUserRepo, mockRoleRepo, mockCourseRepo, mockEnrollmentUseCase, time.Second*2)
+		mockTeamRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Team{ID: 7}, nil).Once()
+		mockCourseRepo.On("GetByID", mock.Anything, int64(9)).Return(&domain.Course{ID: 9}, nil).Once()
+		mockCourseRepo.On("GetLatestVersion", mock.Anything, int64(9)).Return(nil, domain.ErrNotFound).Once()
+
+		assert.Equal(t, domain.ErrCourseNotPublished, u.EnrollTeam(adminCtx, &domain.TeamEnrollment{CourseID: 9, TeamID: 7}))
+		mockTeamRepo.AssertNotCalled(t, "EnrollTeam", mock.Anything, mock.Anything)
+	})
+}
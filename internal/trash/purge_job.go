@@ -0,0 +1,61 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// PurgeJob permanently removes the items that stayed in the trash longer than the retention
+type PurgeJob struct {
+	purgers   []domain.TrashPurger
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+}
+
+// NewPurgeJob will create a job purging the trash of the given usecases every interval.
+// Purgers are run in order, so children (contents) should come before their parents.
+func NewPurgeJob(retention time.Duration, interval time.Duration, purgers ...domain.TrashPurger) *PurgeJob {
+	return &PurgeJob{
+		purgers:   purgers,
+		retention: retention,
+		interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// Run purges the trash once and returns the number of removed items
+func (j *PurgeJob) Run(ctx context.Context) (int64, error) {
+	deletedBefore := j.now().Add(-j.retention).Unix()
+	var total int64
+	for _, purger := range j.purgers {
+		count, err := purger.PurgeTrash(ctx, deletedBefore)
+		if err != nil {
+			return total, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// Start runs the job every interval until ctx is done
+func (j *PurgeJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		count, err := j.Run(ctx)
+		if err != nil {
+			log.Errorf("Error while purging the trash: %v", err)
+		} else if count > 0 {
+			log.Infof("Purged %d items from the trash", count)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRun(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	deletedBefore := now.Add(-30 * 24 * time.Hour).Unix()
+	mockContentUCase := new(mocks.ContentUseCase)
+	mockLessonUCase := new(mocks.LessonUseCase)
+	mockCourseUCase := new(mocks.CourseUseCase)
+
+	t.Run("success", func(t *testing.T) {
+		mockContentUCase.On("PurgeTrash", mock.Anything, deletedBefore).Return(int64(3), nil).Once()
+		mockLessonUCase.On("PurgeTrash", mock.Anything, deletedBefore).Return(int64(1), nil).Once()
+		mockCourseUCase.On("PurgeTrash", mock.Anything, deletedBefore).Return(int64(0), nil).Once()
+		job := NewPurgeJob(30*24*time.Hour, time.Hour, mockContentUCase, mockLessonUCase, mockCourseUCase)
+		job.now = func() time.Time { return now }
+
+		count, err := job.Run(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+		mockContentUCase.AssertExpectations(t)
+		mockLessonUCase.AssertExpectations(t)
+		mockCourseUCase.AssertExpectations(t)
+	})
+	t.Run("error-stops-purge", func(t *testing.T) {
+		mockLessonUCase := new(mocks.LessonUseCase)
+		mockContentUCase.On("PurgeTrash", mock.Anything, deletedBefore).Return(int64(0), errors.New("Unexpected Error")).Once()
+		job := NewPurgeJob(30*24*time.Hour, time.Hour, mockContentUCase, mockLessonUCase, mockCourseUCase)
+		job.now = func() time.Time { return now }
+
+		_, err := job.Run(context.TODO())
+
+		assert.Error(t, err)
+		mockContentUCase.AssertExpectations(t)
+		mockLessonUCase.AssertNotCalled(t, "PurgeTrash", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,349 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// TwoFactorHandler ...
+type TwoFactorHandler struct {
+	TwoFactorUseCase domain.TwoFactorUseCase
+}
+
+// NewTwoFactorHandler ...
+func NewTwoFactorHandler(e *echo.Echo, us domain.TwoFactorUseCase) {
+	handler := &TwoFactorHandler{
+		TwoFactorUseCase: us,
+	}
+	e.GET("/auth/2fa", handler.GetStatus)
+	e.POST("/auth/2fa/enroll", handler.Enroll)
+	e.POST("/auth/2fa/enable", handler.Enable)
+	e.POST("/auth/2fa/disable", handler.Disable)
+	e.POST("/auth/2fa/recovery-codes", handler.RegenerateRecoveryCodes)
+	e.POST("/auth/2fa/login", handler.Login)
+	e.POST("/auth/2fa/login/enroll", handler.EnrollChallenge)
+	e.POST("/auth/2fa/login/enable", handler.EnableChallenge)
+	e.DELETE("/users/:id/2fa", handler.Reset, rbac.Require(domain.PermUserManage))
+	e.GET("/2fa/policy", handler.GetPolicy, rbac.Require(domain.PermUserManage))
+	e.PUT("/2fa/policy", handler.SetPolicy, rbac.Require(domain.PermUserManage))
+}
+
+// GetStatus godoc
+// @Summary Get the two-factor status.
+// @Description Tell whether two-factor authentication is enabled for the authenticated user, whether the organization requires it and how many recovery codes are left.
+// @Tags auth
+// @Accept */*
+// @Produce json
+// @Success 200 {object} domain.Response
+// @Failure 401 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/2fa [get]
+func (c *TwoFactorHandler) GetStatus(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return echoContext.JSON(http.StatusUnauthorized, ResponseError{Message: domain.ErrUnauthorized.Error()})
+	}
+	status, err := c.TwoFactorUseCase.GetStatus(ctx, userID)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: status, Message: domain.Success})
+}
+
+// Enroll godoc
+// @Summary Enroll an authenticator app.
+// @Description Create the secret of a new authenticator for the authenticated user. Show the otpauth URI as a QR code to scan with the app, then enable it with a first code.
+// @Tags auth
+// @Accept */*
+// @Produce json
+// @Success 200 {object} domain.Response
+// @Failure 401 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Two-factor authentication is enabled already"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/2fa/enroll [post]
+func (c *TwoFactorHandler) Enroll(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return echoContext.JSON(http.StatusUnauthorized, ResponseError{Message: domain.ErrUnauthorized.Error()})
+	}
+	enrollment, err := c.TwoFactorUseCase.Enroll(ctx, userID)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: enrollment, Message: domain.Success})
+}
+
+// Enable godoc
+// @Summary Enable two-factor authentication.
+// @Description Enable the enrolled authenticator with a first code of the app. Returns the recovery codes, which are shown only once.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param code body domain.TwoFactorCode true "Code of the authenticator app"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Wrong code"
+// @Failure 404 {object} domain.APIResponseError "No authenticator enrolled"
+// @Failure 409 {object} domain.APIResponseError "Two-factor authentication is enabled already"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/2fa/enable [post]
+func (c *TwoFactorHandler) Enable(echoContext echo.Context) error {
+	var code domain.TwoFactorCode
+	err := echoContext.Bind(&code)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&code); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return echoContext.JSON(http.StatusUnauthorized, ResponseError{Message: domain.ErrUnauthorized.Error()})
+	}
+	codes, err := c.TwoFactorUseCase.Enable(ctx, userID, code.Code)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: codes, Message: domain.Success})
+}
+
+// Disable godoc
+// @Summary Disable two-factor authentication.
+// @Description Remove the authenticator of the authenticated user, confirmed by a code of the app or a recovery code. Not allowed when the organization requires two-factor authentication.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param code body domain.TwoFactorCode true "Code of the authenticator app or recovery code"
+// @Success 204
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Wrong code"
+// @Failure 403 {object} domain.APIResponseError "Required by the organization"
+// @Failure 404 {object} domain.APIResponseError "Two-factor authentication is not enabled"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/2fa/disable [post]
+func (c *TwoFactorHandler) Disable(echoContext echo.Context) error {
+	var code domain.TwoFactorCode
+	err := echoContext.Bind(&code)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&code); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return echoContext.JSON(http.StatusUnauthorized, ResponseError{Message: domain.ErrUnauthorized.Error()})
+	}
+	if err = c.TwoFactorUseCase.Disable(ctx, userID, code.Code); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate the recovery codes.
+// @Description Replace the recovery codes of the authenticated user, confirmed by a code of the app or a recovery code. The previous codes stop working.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param code body domain.TwoFactorCode true "Code of the authenticator app or recovery code"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Wrong code"
+// @Failure 404 {object} domain.APIResponseError "Two-factor authentication is not enabled"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/2fa/recovery-codes [post]
+func (c *TwoFactorHandler) RegenerateRecoveryCodes(echoContext echo.Context) error {
+	var code domain.TwoFactorCode
+	err := echoContext.Bind(&code)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&code); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	userID := domain.UserIDFromContext(ctx)
+	if userID == 0 {
+		return echoContext.JSON(http.StatusUnauthorized, ResponseError{Message: domain.ErrUnauthorized.Error()})
+	}
+	codes, err := c.TwoFactorUseCase.RegenerateRecoveryCodes(ctx, userID, code.Code)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: codes, Message: domain.Success})
+}
+
+// Login godoc
+// @Summary Pass the second login step.
+// @Description Answer a "totp" login challenge with a code of the authenticator app or a recovery code. Returns the token pair. Wrong codes count as failed logins.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param login body domain.TwoFactorLogin true "Challenge token and code"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Invalid or expired challenge, or wrong code"
+// @Failure 423 {object} domain.APIResponseError "Locked after too many failed logins"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/2fa/login [post]
+func (c *TwoFactorHandler) Login(echoContext echo.Context) error {
+	var login domain.TwoFactorLogin
+	err := echoContext.Bind(&login)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&login); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	tokens, err := c.TwoFactorUseCase.Login(ctx, &login)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: tokens, Message: domain.Success})
+}
+
+// EnrollChallenge godoc
+// @Summary Enroll an authenticator app during login.
+// @Description Answer a "totp_enrollment" login challenge, given when the organization requires two-factor authentication, by enrolling an authenticator.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param challenge body domain.TwoFactorChallenge true "Challenge token"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Invalid or expired challenge"
+// @Failure 409 {object} domain.APIResponseError "Two-factor authentication is enabled already"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/2fa/login/enroll [post]
+func (c *TwoFactorHandler) EnrollChallenge(echoContext echo.Context) error {
+	var challenge domain.TwoFactorChallenge
+	err := echoContext.Bind(&challenge)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&challenge); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	enrollment, err := c.TwoFactorUseCase.EnrollChallenge(ctx, challenge.ChallengeToken)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: enrollment, Message: domain.Success})
+}
+
+// EnableChallenge godoc
+// @Summary Enable two-factor authentication during login.
+// @Description Enable the authenticator enrolled during the login with a first code, and complete the login. Returns the recovery codes and the token pair.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param login body domain.TwoFactorLogin true "Challenge token and code"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 401 {object} domain.APIResponseError "Invalid or expired challenge, or wrong code"
+// @Failure 423 {object} domain.APIResponseError "Locked after too many failed logins"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /auth/2fa/login/enable [post]
+func (c *TwoFactorHandler) EnableChallenge(echoContext echo.Context) error {
+	var login domain.TwoFactorLogin
+	err := echoContext.Bind(&login)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&login); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	codes, err := c.TwoFactorUseCase.EnableChallenge(ctx, &login)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: codes, Message: domain.Success})
+}
+
+// Reset godoc
+// @Summary Reset the two-factor authentication of a user.
+// @Description Remove the authenticator and recovery codes of a user who lost them, and sign the user out everywhere.
+// @Tags users
+// @Accept */*
+// @Produce json
+// @Param id path int true "user Id"
+// @Success 204
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users/{id}/2fa [delete]
+func (c *TwoFactorHandler) Reset(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.TwoFactorUseCase.Reset(ctx, int64(idParam)); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
+
+// GetPolicy godoc
+// @Summary Get the two-factor policy.
+// @Description Tell whether the organization requires two-factor authentication of every user.
+// @Tags users
+// @Accept */*
+// @Produce json
+// @Success 200 {object} domain.Response
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /2fa/policy [get]
+func (c *TwoFactorHandler) GetPolicy(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	policy, err := c.TwoFactorUseCase.GetPolicy(ctx)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.JSON(http.StatusOK, domain.Response{Data: policy, Message: domain.Success})
+}
+
+// SetPolicy godoc
+// @Summary Set the two-factor policy.
+// @Description Require two-factor authentication of every user of the organization, or make it optional. Users without an authenticator enroll one at their next login.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param policy body domain.TwoFactorPolicy true "Policy"
+// @Success 204
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /2fa/policy [put]
+func (c *TwoFactorHandler) SetPolicy(echoContext echo.Context) error {
+	var policy domain.TwoFactorPolicy
+	err := echoContext.Bind(&policy)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	if err = c.TwoFactorUseCase.SetPolicy(ctx, &policy); err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,359 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	twofactorHTTP "github.com/meroedu/meroedu/internal/twofactor/delivery/http"
+)
+
+func TestGetStatus(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("GetStatus", mock.Anything, int64(3)).Return(&domain.TwoFactorStatus{Enabled: true, RecoveryCodesLeft: 10}, nil).Once()
+
+	tests := []struct {
+		userID int64
+		code   int
+	}{
+		{3, http.StatusOK},
+		{0, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/auth/2fa", strings.NewReader(""))
+		assert.NoError(t, err)
+		req = req.WithContext(domain.WithUserID(req.Context(), tt.userID))
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := twofactorHTTP.TwoFactorHandler{
+			TwoFactorUseCase: mockUCase,
+		}
+		err = handler.GetStatus(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.userID)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestEnroll(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("Enroll", mock.Anything, int64(3)).Return(&domain.TwoFactorEnrollment{Secret: "JBSWY3DPEHPK3PXP"}, nil).Once()
+	mockUCase.On("Enroll", mock.Anything, int64(4)).Return(nil, domain.ErrConflict).Once()
+
+	tests := []struct {
+		userID int64
+		code   int
+	}{
+		{3, http.StatusOK},
+		{4, http.StatusConflict},
+		{0, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/2fa/enroll", strings.NewReader(""))
+		assert.NoError(t, err)
+		req = req.WithContext(domain.WithUserID(req.Context(), tt.userID))
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := twofactorHTTP.TwoFactorHandler{
+			TwoFactorUseCase: mockUCase,
+		}
+		err = handler.Enroll(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.userID)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestEnable(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("Enable", mock.Anything, int64(3), "123456").Return(&domain.RecoveryCodes{Codes: []string{"a1b2c3d4"}}, nil).Once()
+	mockUCase.On("Enable", mock.Anything, int64(3), "000000").Return(nil, domain.ErrInvalidCredentials).Once()
+
+	tests := []struct {
+		userID int64
+		body   string
+		code   int
+	}{
+		{3, `{"code":"123456"}`, http.StatusOK},
+		{3, `{"code":"000000"}`, http.StatusUnauthorized},
+		{3, `{}`, http.StatusBadRequest},
+		{3, `{"code":123456}`, http.StatusUnprocessableEntity},
+		{0, `{"code":"123456"}`, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/2fa/enable", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req = req.WithContext(domain.WithUserID(req.Context(), tt.userID))
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := twofactorHTTP.TwoFactorHandler{
+			TwoFactorUseCase: mockUCase,
+		}
+		err = handler.Enable(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDisable(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("Disable", mock.Anything, int64(3), "123456").Return(nil).Once()
+	mockUCase.On("Disable", mock.Anything, int64(4), "123456").Return(domain.ErrForbidden).Once()
+
+	tests := []struct {
+		userID int64
+		body   string
+		code   int
+	}{
+		{3, `{"code":"123456"}`, http.StatusNoContent},
+		{4, `{"code":"123456"}`, http.StatusForbidden},
+		{3, `{"code":""}`, http.StatusBadRequest},
+		{0, `{"code":"123456"}`, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/2fa/disable", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req = req.WithContext(domain.WithUserID(req.Context(), tt.userID))
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := twofactorHTTP.TwoFactorHandler{
+			TwoFactorUseCase: mockUCase,
+		}
+		err = handler.Disable(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRegenerateRecoveryCodes(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("RegenerateRecoveryCodes", mock.Anything, int64(3), "123456").Return(&domain.RecoveryCodes{Codes: []string{"a1b2c3d4"}}, nil).Once()
+	mockUCase.On("RegenerateRecoveryCodes", mock.Anything, int64(4), "123456").Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		userID int64
+		body   string
+		code   int
+	}{
+		{3, `{"code":"123456"}`, http.StatusOK},
+		{4, `{"code":"123456"}`, http.StatusNotFound},
+		{3, `{"code":`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/2fa/recovery-codes", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req = req.WithContext(domain.WithUserID(req.Context(), tt.userID))
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := twofactorHTTP.TwoFactorHandler{
+			TwoFactorUseCase: mockUCase,
+		}
+		err = handler.RegenerateRecoveryCodes(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestLogin(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("Login", mock.Anything, &domain.TwoFactorLogin{ChallengeToken: "challenge", Code: "123456"}).
+		Return(&domain.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil).Once()
+	mockUCase.On("Login", mock.Anything, &domain.TwoFactorLogin{ChallengeToken: "challenge", Code: "000000"}).
+		Return(nil, domain.ErrAccountLocked).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"challenge_token":"challenge","code":"123456"}`, http.StatusOK},
+		{`{"challenge_token":"challenge","code":"000000"}`, http.StatusLocked},
+		{`{"code":"123456"}`, http.StatusBadRequest},
+		{`{"challenge_token":1}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/2fa/login", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := twofactorHTTP.TwoFactorHandler{
+			TwoFactorUseCase: mockUCase,
+		}
+		err = handler.Login(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestEnrollChallenge(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("EnrollChallenge", mock.Anything, "challenge").Return(&domain.TwoFactorEnrollment{Secret: "JBSWY3DPEHPK3PXP"}, nil).Once()
+	mockUCase.On("EnrollChallenge", mock.Anything, "expired").Return(nil, domain.ErrUnauthorized).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"challenge_token":"challenge"}`, http.StatusOK},
+		{`{"challenge_token":"expired"}`, http.StatusUnauthorized},
+		{`{}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/2fa/login/enroll", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := twofactorHTTP.TwoFactorHandler{
+			TwoFactorUseCase: mockUCase,
+		}
+		err = handler.EnrollChallenge(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestEnableChallenge(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("EnableChallenge", mock.Anything, &domain.TwoFactorLogin{ChallengeToken: "challenge", Code: "123456"}).
+		Return(&domain.RecoveryCodes{Codes: []string{"a1b2c3d4"}, Tokens: &domain.TokenPair{AccessToken: "access"}}, nil).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"challenge_token":"challenge","code":"123456"}`, http.StatusOK},
+		{`{"challenge_token":"challenge"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/auth/2fa/login/enable", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := twofactorHTTP.TwoFactorHandler{
+			TwoFactorUseCase: mockUCase,
+		}
+		err = handler.EnableChallenge(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestReset(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("Reset", mock.Anything, int64(3)).Return(nil).Once()
+	mockUCase.On("Reset", mock.Anything, int64(4)).Return(domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"3", http.StatusNoContent},
+		{"4", http.StatusNotFound},
+		{"me", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/users/"+tt.id+"/2fa", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/2fa")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := twofactorHTTP.TwoFactorHandler{
+			TwoFactorUseCase: mockUCase,
+		}
+		err = handler.Reset(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetPolicy(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("GetPolicy", mock.Anything).Return(&domain.TwoFactorPolicy{Required: true}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/2fa/policy", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := twofactorHTTP.TwoFactorHandler{
+		TwoFactorUseCase: mockUCase,
+	}
+	err = handler.GetPolicy(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"required":true`)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSetPolicy(t *testing.T) {
+	mockUCase := new(mocks.TwoFactorUseCase)
+	mockUCase.On("SetPolicy", mock.Anything, &domain.TwoFactorPolicy{Required: true}).Return(nil).Once()
+	mockUCase.On("SetPolicy", mock.Anything, &domain.TwoFactorPolicy{Required: false}).Return(domain.ErrForbidden).Once()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"required":true}`, http.StatusNoContent},
+		{`{"required":false}`, http.StatusForbidden},
+		{`{"required":"yes"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/2fa/policy", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := twofactorHTTP.TwoFactorHandler{
+			TwoFactorUseCase: mockUCase,
+		}
+		err = handler.SetPolicy(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,202 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the two-factor Repository interface
+func Init(db *sql.DB) domain.TwoFactorRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func (m *mysqlRepository) Get(ctx context.Context, userID int64) (*domain.TwoFactor, error) {
+	query := `SELECT user_id,organization_id,secret,enabled_at,last_step,updated_at,created_at FROM two_factor
+		WHERE user_id = ? AND organization_id = ?`
+	t := domain.TwoFactor{}
+	enabledAt := sql.NullInt64{}
+	err := m.conn.QueryRowContext(ctx, query, userID, domain.OrganizationIDFromContext(ctx)).Scan(&t.UserID, &t.OrganizationID, &t.Secret,
+		&enabledAt, &t.LastStep, &t.UpdatedAt, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	t.EnabledAt = enabledAt.Int64
+	return &t, nil
+}
+
+// Save stores a pending authenticator, replacing the secret of a previous pending one. The secret of an
+// enabled authenticator is kept.
+func (m *mysqlRepository) Save(ctx context.Context, t *domain.TwoFactor) error {
+	t.OrganizationID = domain.OrganizationIDFromContext(ctx)
+	query := `INSERT two_factor SET user_id=?,organization_id=?,secret=?,last_step=0,updated_at=?,created_at=?
+		ON DUPLICATE KEY UPDATE secret=IF(enabled_at IS NULL, VALUES(secret), secret),updated_at=VALUES(updated_at)`
+	if _, err := m.conn.ExecContext(ctx, query, t.UserID, t.OrganizationID, t.Secret, t.UpdatedAt, t.CreatedAt); err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	return nil
+}
+
+// Enable enables the pending authenticator of the user, confirmed by a code of the step, and stores its
+// recovery codes. An authenticator enabled meanwhile gives ErrNotFound.
+func (m *mysqlRepository) Enable(ctx context.Context, userID int64, step int64, enabledAt int64, codeHashes []string) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `UPDATE two_factor SET enabled_at=?,last_step=?,updated_at=? WHERE user_id = ? AND organization_id = ? AND enabled_at IS NULL`
+	res, err := tx.ExecContext(ctx, query, enabledAt, step, enabledAt, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		err = domain.ErrNotFound
+		return
+	}
+	err = m.replaceRecoveryCodes(ctx, tx, userID, codeHashes, enabledAt)
+	return
+}
+
+// UseStep records the step of a code as used. A code of that step or after was used meanwhile when it
+// gives ErrNotFound, so each code works once.
+func (m *mysqlRepository) UseStep(ctx context.Context, userID int64, step int64) error {
+	query := `UPDATE two_factor SET last_step=? WHERE user_id = ? AND organization_id = ? AND enabled_at IS NOT NULL AND last_step < ?`
+	return m.update(ctx, query, step, userID, domain.OrganizationIDFromContext(ctx), step)
+}
+
+// UseRecoveryCode marks an unused recovery code of the user used. An unknown or used code gives ErrNotFound.
+func (m *mysqlRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string, usedAt int64) error {
+	query := `UPDATE two_factor_recovery_codes c JOIN two_factor t ON t.user_id = c.user_id SET c.used_at=?
+		WHERE c.user_id = ? AND t.organization_id = ? AND t.enabled_at IS NOT NULL AND c.code_hash = ? AND c.used_at IS NULL`
+	return m.update(ctx, query, usedAt, userID, domain.OrganizationIDFromContext(ctx), codeHash)
+}
+
+func (m *mysqlRepository) update(ctx context.Context, query string, args ...interface{}) error {
+	res, err := m.conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes replaces every recovery code of the user with new ones
+func (m *mysqlRepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string, createdAt int64) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+	err = m.replaceRecoveryCodes(ctx, tx, userID, codeHashes, createdAt)
+	return
+}
+
+func (m *mysqlRepository) replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID int64, codeHashes []string, createdAt int64) error {
+	query := `DELETE c FROM two_factor_recovery_codes c JOIN two_factor t ON t.user_id = c.user_id
+		WHERE c.user_id = ? AND t.organization_id = ?`
+	if _, err := tx.ExecContext(ctx, query, userID, domain.OrganizationIDFromContext(ctx)); err != nil {
+		log.Error(err)
+		return err
+	}
+	query = `INSERT two_factor_recovery_codes SET user_id=?,code_hash=?,created_at=?`
+	for _, hash := range codeHashes {
+		if _, err := tx.ExecContext(ctx, query, userID, hash, createdAt); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// CountRecoveryCodes returns how many recovery codes of the user are unused
+func (m *mysqlRepository) CountRecoveryCodes(ctx context.Context, userID int64) (int, error) {
+	query := `SELECT COUNT(*) FROM two_factor_recovery_codes c JOIN two_factor t ON t.user_id = c.user_id
+		WHERE c.user_id = ? AND t.organization_id = ? AND c.used_at IS NULL`
+	count := 0
+	err := m.conn.QueryRowContext(ctx, query, userID, domain.OrganizationIDFromContext(ctx)).Scan(&count)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// Delete removes the authenticator of the user and its recovery codes
+func (m *mysqlRepository) Delete(ctx context.Context, userID int64) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if err = m.replaceRecoveryCodes(ctx, tx, userID, nil, 0); err != nil {
+		return
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM two_factor WHERE user_id = ? AND organization_id = ?`, userID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		err = domain.ErrNotFound
+	}
+	return
+}
@@ -0,0 +1,85 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/twofactor/repository/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+func TestGet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	columns := []string{"user_id", "organization_id", "secret", "enabled_at", "last_step", "updated_at", "created_at"}
+	query := `SELECT .+ FROM two_factor WHERE user_id = \? AND organization_id = \?`
+	mock.ExpectQuery(query).WithArgs(5, 2).WillReturnRows(sqlmock.NewRows(columns).AddRow(5, 2, "ABC", nil, 0, 100, 100))
+	mock.ExpectQuery(query).WithArgs(6, 2).WillReturnRows(sqlmock.NewRows(columns))
+
+	repo := mysqlrepo.Init(db)
+	twoFactor, err := repo.Get(orgCtx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "ABC", twoFactor.Secret)
+	assert.Zero(t, twoFactor.EnabledAt)
+
+	_, err = repo.Get(orgCtx, 6)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestEnable(t *testing.T) {
+	enable := `UPDATE two_factor SET enabled_at=\?,last_step=\?,updated_at=\? WHERE user_id = \? AND organization_id = \? AND enabled_at IS NULL`
+	purge := `DELETE c FROM two_factor_recovery_codes c JOIN two_factor t ON t.user_id = c.user_id`
+	insert := `INSERT two_factor_recovery_codes SET user_id=\?,code_hash=\?,created_at=\?`
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(enable).WithArgs(100, 3, 100, 5, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(purge).WithArgs(5, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(insert).WithArgs(5, "h1", 100).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(insert).WithArgs(5, "h2", 100).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		err = repo.Enable(orgCtx, 5, 3, 100, []string{"h1", "h2"})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("enabled-meanwhile", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(enable).WithArgs(100, 3, 100, 5, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := mysqlrepo.Init(db)
+		err = repo.Enable(orgCtx, 5, 3, 100, []string{"h1"})
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUseStep(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `UPDATE two_factor SET last_step=\? WHERE user_id = \? AND organization_id = \? AND enabled_at IS NOT NULL AND last_step < \?`
+	mock.ExpectExec(query).WithArgs(7, 5, 2, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(7, 5, 2, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := mysqlrepo.Init(db)
+	assert.NoError(t, repo.UseStep(orgCtx, 5, 7))
+	assert.Equal(t, domain.ErrNotFound, repo.UseStep(orgCtx, 5, 7), "a code works once")
+}
@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/totp"
+)
+
+// recoveryCodeCount is the number of recovery codes given when two-factor authentication is enabled
+const recoveryCodeCount = 10
+
+// TwoFactorUseCase ...
+type TwoFactorUseCase struct {
+	twoFactorRepo  domain.TwoFactorRepository
+	userRepo       domain.UserRepository
+	orgRepo        domain.OrganizationRepository
+	authUseCase    domain.AuthUseCase
+	issuer         string
+	contextTimeOut time.Duration
+}
+
+// NewTwoFactorUseCase will create new a TwoFactorUseCase. Authenticator apps show the accounts under issuer.
+func NewTwoFactorUseCase(t domain.TwoFactorRepository, u domain.UserRepository, o domain.OrganizationRepository, a domain.AuthUseCase,
+	issuer string, timeout time.Duration) domain.TwoFactorUseCase {
+	return &TwoFactorUseCase{
+		twoFactorRepo:  t,
+		userRepo:       u,
+		orgRepo:        o,
+		authUseCase:    a,
+		issuer:         issuer,
+		contextTimeOut: timeout,
+	}
+}
+
+// normalizeCode removes the separators and spaces users type in recovery codes
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// newRecoveryCodes returns new recovery codes, formatted as xxxxx-xxxxx, and their hashes
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	for i := range codes {
+		raw := make([]byte, 6)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(encoding.EncodeToString(raw))
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashCode(code)
+	}
+	return codes, hashes, nil
+}
+
+// isTOTPCode reports whether the code looks like a code of an authenticator app rather than a recovery code
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// verify checks a code of the authenticator app or a recovery code, and uses it up. A wrong or already used
+// code gives ErrInvalidCredentials.
+func (usecase *TwoFactorUseCase) verify(ctx context.Context, twoFactor *domain.TwoFactor, code string) error {
+	code = strings.TrimSpace(code)
+	now := time.Now()
+	var err error
+	if isTOTPCode(code) {
+		step, ok := totp.Validate(twoFactor.Secret, code, now)
+		if !ok || step <= twoFactor.LastStep {
+			return domain.ErrInvalidCredentials
+		}
+		err = usecase.twoFactorRepo.UseStep(ctx, twoFactor.UserID, step)
+	} else {
+		err = usecase.twoFactorRepo.UseRecoveryCode(ctx, twoFactor.UserID, hashCode(code), now.Unix())
+	}
+	if err == domain.ErrNotFound {
+		return domain.ErrInvalidCredentials
+	}
+	return err
+}
+
+// enabled returns the enabled authenticator of the user, or ErrNotFound
+func (usecase *TwoFactorUseCase) enabled(ctx context.Context, userID int64) (*domain.TwoFactor, error) {
+	twoFactor, err := usecase.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor.EnabledAt == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return twoFactor, nil
+}
+
+func (usecase *TwoFactorUseCase) required(ctx context.Context) (bool, error) {
+	org, err := usecase.orgRepo.GetByID(ctx, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	return org.RequireTwoFactor, nil
+}
+
+// GetStatus ...
+func (usecase *TwoFactorUseCase) GetStatus(c context.Context, userID int64) (*domain.TwoFactorStatus, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	status := &domain.TwoFactorStatus{}
+	var err error
+	if status.Required, err = usecase.required(ctx); err != nil {
+		return nil, err
+	}
+	twoFactor, err := usecase.enabled(ctx, userID)
+	if err == domain.ErrNotFound {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	status.Enabled = true
+	status.EnabledAt = twoFactor.EnabledAt
+	if status.RecoveryCodesLeft, err = usecase.twoFactorRepo.CountRecoveryCodes(ctx, userID); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Enroll creates a new pending authenticator for the user, replacing a pending one. It is enabled by
+// confirming a first code. An enabled authenticator gives ErrConflict.
+func (usecase *TwoFactorUseCase) Enroll(c context.Context, userID int64) (*domain.TwoFactorEnrollment, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	user, err := usecase.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.enroll(ctx, user)
+}
+
+func (usecase *TwoFactorUseCase) enroll(ctx context.Context, user *domain.User) (*domain.TwoFactorEnrollment, error) {
+	if _, err := usecase.enabled(ctx, user.ID); err != domain.ErrNotFound {
+		if err == nil {
+			return nil, domain.ErrConflict
+		}
+		return nil, err
+	}
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	err = usecase.twoFactorRepo.Save(ctx, &domain.TwoFactor{UserID: user.ID, Secret: secret, UpdatedAt: now, CreatedAt: now})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TwoFactorEnrollment{Secret: secret, URI: totp.URI(usecase.issuer, user.Email, secret)}, nil
+}
+
+// Enable enables the pending authenticator of the user with a first code, and returns the recovery codes
+func (usecase *TwoFactorUseCase) Enable(c context.Context, userID int64, code string) (*domain.RecoveryCodes, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.enable(ctx, userID, code)
+}
+
+func (usecase *TwoFactorUseCase) enable(ctx context.Context, userID int64, code string) (*domain.RecoveryCodes, error) {
+	twoFactor, err := usecase.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor.EnabledAt != 0 {
+		return nil, domain.ErrConflict
+	}
+	now := time.Now()
+	step, ok := totp.Validate(twoFactor.Secret, strings.TrimSpace(code), now)
+	if !ok {
+		return nil, domain.ErrInvalidCredentials
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	err = usecase.twoFactorRepo.Enable(ctx, userID, step, now.Unix(), hashes)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.RecoveryCodes{Codes: codes}, nil
+}
+
+// Disable removes the authenticator of the user after checking a code. It gives ErrForbidden when the
+// organization requires two-factor authentication.
+func (usecase *TwoFactorUseCase) Disable(c context.Context, userID int64, code string) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	required, err := usecase.required(ctx)
+	if err != nil {
+		return err
+	}
+	if required {
+		return domain.ErrForbidden
+	}
+	twoFactor, err := usecase.enabled(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err = usecase.verify(ctx, twoFactor, code); err != nil {
+		return err
+	}
+	return usecase.twoFactorRepo.Delete(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user after checking a code
+func (usecase *TwoFactorUseCase) RegenerateRecoveryCodes(c context.Context, userID int64, code string) (*domain.RecoveryCodes, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	twoFactor, err := usecase.enabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.verify(ctx, twoFactor, code); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, hashes, time.Now().Unix()); err != nil {
+		return nil, err
+	}
+	return &domain.RecoveryCodes{Codes: codes}, nil
+}
+
+// Reset removes the authenticator of a user who lost it along with the recovery codes, and signs the user
+// out everywhere. When the organization requires two-factor authentication the user enrolls again at the next login.
+func (usecase *TwoFactorUseCase) Reset(c context.Context, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if err := usecase.twoFactorRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+	return usecase.authUseCase.RevokeAll(ctx, userID)
+}
+
+// challenge returns the user of a login challenge, with the context of its organization
+func (usecase *TwoFactorUseCase) challenge(ctx context.Context, challengeToken string) (context.Context, *domain.User, error) {
+	user, err := usecase.authUseCase.ParseChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return domain.WithOrganizationID(ctx, user.OrganizationID), user, nil
+}
+
+// fail counts a wrong code as a failed login, so codes can not be guessed
+func (usecase *TwoFactorUseCase) fail(ctx context.Context, user *domain.User, err error) error {
+	if err == domain.ErrInvalidCredentials {
+		if errRecord := usecase.authUseCase.RecordLoginFailure(ctx, user); errRecord != nil {
+			return errRecord
+		}
+	}
+	return err
+}
+
+// signIn issues the token pair of a user who passed the second factor
+func (usecase *TwoFactorUseCase) signIn(ctx context.Context, user *domain.User) (*domain.TokenPair, error) {
+	if user.FailedLogins > 0 || user.LockedUntil != 0 {
+		if err := usecase.userRepo.ResetLoginFailures(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+	return usecase.authUseCase.IssueTokens(ctx, user)
+}
+
+// Login is the second step of a login: it checks a code of the authenticator app or a recovery code for the
+// challenge and issues a token pair. Wrong codes count as failed logins and lock the account at the limit.
+func (usecase *TwoFactorUseCase) Login(c context.Context, login *domain.TwoFactorLogin) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	ctx, user, err := usecase.challenge(ctx, login.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	twoFactor, err := usecase.enabled(ctx, user.ID)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.verify(ctx, twoFactor, login.Code); err != nil {
+		return nil, usecase.fail(ctx, user, err)
+	}
+	return usecase.signIn(ctx, user)
+}
+
+// EnrollChallenge enrolls an authenticator for a user whose organization requires one, during the login
+func (usecase *TwoFactorUseCase) EnrollChallenge(c context.Context, challengeToken string) (*domain.TwoFactorEnrollment, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	ctx, user, err := usecase.challenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.enroll(ctx, user)
+}
+
+// EnableChallenge enables the authenticator enrolled during the login with a first code, and completes the login
+func (usecase *TwoFactorUseCase) EnableChallenge(c context.Context, login *domain.TwoFactorLogin) (*domain.RecoveryCodes, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	ctx, user, err := usecase.challenge(ctx, login.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := usecase.enable(ctx, user.ID, login.Code)
+	if err != nil {
+		return nil, usecase.fail(ctx, user, err)
+	}
+	if codes.Tokens, err = usecase.signIn(ctx, user); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// GetPolicy ...
+func (usecase *TwoFactorUseCase) GetPolicy(c context.Context) (*domain.TwoFactorPolicy, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	required, err := usecase.required(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TwoFactorPolicy{Required: required}, nil
+}
+
+// SetPolicy sets whether the caller's organization requires two-factor authentication. Users without an
+// authenticator enroll one at their next login.
+func (usecase *TwoFactorUseCase) SetPolicy(c context.Context, policy *domain.TwoFactorPolicy) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.orgRepo.SetTwoFactorRequired(ctx, domain.OrganizationIDFromContext(ctx), policy.Required, time.Now().Unix())
+}
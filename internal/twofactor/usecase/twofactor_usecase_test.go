@@ -0,0 +1,174 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/twofactor/usecase"
+	"github.com/meroedu/meroedu/pkg/totp"
+)
+
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+func code(t *testing.T) (string, int64) {
+	step := totp.Step(time.Now())
+	c, err := totp.Code(secret, step)
+	assert.NoError(t, err)
+	return c, step
+}
+
+func TestEnrollAndEnable(t *testing.T) {
+	mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	mockOrganizationRepo := new(mocks.OrganizationRepository)
+	mockAuthUseCase := new(mocks.AuthUseCase)
+	u := ucase.NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, mockOrganizationRepo, mockAuthUseCase, "Meroedu",
+		time.Second*2)
+	user := &domain.User{ID: 5, OrganizationID: 2, Email: "sita@school.local"}
+	mockUserRepo.On("GetByID", mock.Anything, int64(5)).Return(user, nil).Once()
+	mockTwoFactorRepo.On("Get", mock.Anything, int64(5)).Return(nil, domain.ErrNotFound).Once()
+	var saved *domain.TwoFactor
+	mockTwoFactorRepo.On("Save", mock.Anything, mock.AnythingOfType("*domain.TwoFactor")).Return(nil).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*domain.TwoFactor) }).Once()
+
+	enrollment, err := u.Enroll(orgCtx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, saved.Secret, enrollment.Secret)
+	assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/Meroedu:sita@school.local?"), enrollment.URI)
+
+	saved.Secret = secret
+	mockTwoFactorRepo.On("Get", mock.Anything, int64(5)).Return(saved, nil)
+	_, err = u.Enable(orgCtx, 5, "000000")
+	assert.Equal(t, domain.ErrInvalidCredentials, err)
+
+	c, step := code(t)
+	var hashes []string
+	mockTwoFactorRepo.On("Enable", mock.Anything, int64(5), step, mock.AnythingOfType("int64"), mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) { hashes = args.Get(4).([]string) }).Once()
+	codes, err := u.Enable(orgCtx, 5, c)
+	assert.NoError(t, err)
+	assert.Len(t, codes.Codes, 10)
+	if assert.Len(t, hashes, 10) {
+		sum := sha256.Sum256([]byte(strings.Replace(codes.Codes[0], "-", "", 1)))
+		assert.Equal(t, hex.EncodeToString(sum[:]), hashes[0], "recovery codes are stored hashed")
+	}
+}
+
+func TestLogin(t *testing.T) {
+	user := &domain.User{ID: 5, OrganizationID: 2, Status: domain.UserActive, FailedLogins: 2}
+	enabled := &domain.TwoFactor{UserID: 5, OrganizationID: 2, Secret: secret, EnabledAt: 100}
+
+	t.Run("success", func(t *testing.T) {
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, mockOrganizationRepo, mockAuthUseCase, "Meroedu",
+			time.Second*2)
+		c, step := code(t)
+		tokens := &domain.TokenPair{AccessToken: "access"}
+		mockAuthUseCase.On("ParseChallenge", mock.Anything, "challenge").Return(user, nil).Once()
+		mockTwoFactorRepo.On("Get", mock.Anything, int64(5)).Return(enabled, nil).Once()
+		mockTwoFactorRepo.On("UseStep", mock.Anything, int64(5), step).Return(nil).Once()
+		mockUserRepo.On("ResetLoginFailures", mock.Anything, int64(5)).Return(nil).Once()
+		mockAuthUseCase.On("IssueTokens", mock.Anything, user).Return(tokens, nil).Once()
+
+		result, err := u.Login(context.TODO(), &domain.TwoFactorLogin{ChallengeToken: "challenge", Code: c})
+		assert.NoError(t, err)
+		assert.Equal(t, tokens, result)
+		mockUserRepo.AssertExpectations(t)
+	})
+	t.Run("replayed-code", func(t *testing.T) {
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, mockOrganizationRepo, mockAuthUseCase, "Meroedu",
+			time.Second*2)
+		c, step := code(t)
+		used := *enabled
+		used.LastStep = step
+		mockAuthUseCase.On("ParseChallenge", mock.Anything, "challenge").Return(user, nil).Once()
+		mockTwoFactorRepo.On("Get", mock.Anything, int64(5)).Return(&used, nil).Once()
+		mockAuthUseCase.On("RecordLoginFailure", mock.Anything, user).Return(nil).Once()
+
+		_, err := u.Login(context.TODO(), &domain.TwoFactorLogin{ChallengeToken: "challenge", Code: c})
+		assert.Equal(t, domain.ErrInvalidCredentials, err)
+		mockAuthUseCase.AssertExpectations(t)
+		mockAuthUseCase.AssertNotCalled(t, "IssueTokens", mock.Anything, mock.Anything)
+	})
+	t.Run("recovery-code", func(t *testing.T) {
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, mockOrganizationRepo, mockAuthUseCase, "Meroedu",
+			time.Second*2)
+		sum := sha256.Sum256([]byte("abcde23456"))
+		mockAuthUseCase.On("ParseChallenge", mock.Anything, "challenge").Return(user, nil).Once()
+		mockTwoFactorRepo.On("Get", mock.Anything, int64(5)).Return(enabled, nil).Once()
+		mockTwoFactorRepo.On("UseRecoveryCode", mock.Anything, int64(5), hex.EncodeToString(sum[:]), mock.AnythingOfType("int64")).Return(nil).Once()
+		mockUserRepo.On("ResetLoginFailures", mock.Anything, int64(5)).Return(nil).Once()
+		mockAuthUseCase.On("IssueTokens", mock.Anything, user).Return(&domain.TokenPair{}, nil).Once()
+
+		_, err := u.Login(context.TODO(), &domain.TwoFactorLogin{ChallengeToken: "challenge", Code: "ABCDE-23456"})
+		assert.NoError(t, err)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+	t.Run("locked", func(t *testing.T) {
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, mockOrganizationRepo, mockAuthUseCase, "Meroedu",
+			time.Second*2)
+		mockAuthUseCase.On("ParseChallenge", mock.Anything, "challenge").Return(nil, domain.ErrAccountLocked).Once()
+
+		_, err := u.Login(context.TODO(), &domain.TwoFactorLogin{ChallengeToken: "challenge", Code: "123456"})
+		assert.Equal(t, domain.ErrAccountLocked, err)
+	})
+}
+
+func TestDisable(t *testing.T) {
+	t.Run("required-by-organization", func(t *testing.T) {
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, mockOrganizationRepo, mockAuthUseCase, "Meroedu",
+			time.Second*2)
+		mockOrganizationRepo.On("GetByID", mock.Anything, int64(2)).Return(&domain.Organization{ID: 2, RequireTwoFactor: true}, nil).Once()
+
+		err := u.Disable(orgCtx, 5, "123456")
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockTwoFactorRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+	t.Run("success", func(t *testing.T) {
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockOrganizationRepo := new(mocks.OrganizationRepository)
+		mockAuthUseCase := new(mocks.AuthUseCase)
+		u := ucase.NewTwoFactorUseCase(mockTwoFactorRepo, mockUserRepo, mockOrganizationRepo, mockAuthUseCase, "Meroedu",
+			time.Second*2)
+		c, step := code(t)
+		mockOrganizationRepo.On("GetByID", mock.Anything, int64(2)).Return(&domain.Organization{ID: 2}, nil).Once()
+		mockTwoFactorRepo.On("Get", mock.Anything, int64(5)).Return(&domain.TwoFactor{UserID: 5, Secret: secret, EnabledAt: 100}, nil).Once()
+		mockTwoFactorRepo.On("UseStep", mock.Anything, int64(5), step).Return(nil).Once()
+		mockTwoFactorRepo.On("Delete", mock.Anything, int64(5)).Return(nil).Once()
+
+		err := u.Disable(orgCtx, 5, c)
+		assert.NoError(t, err)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+}
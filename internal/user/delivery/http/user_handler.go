@@ -0,0 +1,206 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// UserHandler ...
+type UserHandler struct {
+	UserUseCase domain.UserUseCase
+}
+
+// NewUserHandler ...
+func NewUserHandler(e *echo.Echo, us domain.UserUseCase) {
+	handler := &UserHandler{
+		UserUseCase: us,
+	}
+	e.GET("/users", handler.GetAll, rbac.Require(domain.PermUserManage))
+	e.GET("/users/:id", handler.GetByID, rbac.Require(domain.PermUserManage))
+	e.POST("/users", handler.CreateUser, rbac.Require(domain.PermUserManage))
+	e.PUT("/users/:id", handler.UpdateUser, rbac.Require(domain.PermUserManage))
+	e.POST("/users/:id/deactivate", handler.DeactivateUser, rbac.Require(domain.PermUserManage))
+}
+
+// GetAll godoc
+// @Summary Get All Users.
+// @Description Get all users, optionally filtered by name, email or username.
+// @Tags users
+// @Accept */*
+// @Produce json
+// @Param q query string false "search"
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users [get]
+func (c *UserHandler) GetAll(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	start, limit := 0, 10
+	searchQuery := echoContext.QueryParam("q")
+	var err error
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		}
+	}
+
+	list, err := c.UserUseCase.GetAll(ctx, searchQuery, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary Get user by ID.
+// @Description Get Specific user details.
+// @Tags users
+// @Accept */*
+// @Produce json
+// @Param id path int true "user Id"
+// @Success 200 {object} domain.Response
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users/{id} [get]
+func (c *UserHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	user, err := c.UserUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    user,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// CreateUser godoc
+// @Summary Create New user
+// @Description Create New user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body domain.User true "user Data"
+// @Success 201 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Email or username already exists"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users [post]
+func (c *UserHandler) CreateUser(echoContext echo.Context) error {
+	var user domain.User
+	err := echoContext.Bind(&user)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&user); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.UserUseCase.CreateUser(ctx, &user)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    user,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusCreated, res)
+}
+
+// UpdateUser godoc
+// @Summary Update existing user
+// @Description Update the profile of an existing user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "user Id"
+// @Param user body domain.User true "user Data"
+// @Success 200 {object} domain.Response
+// @Failure 400 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 409 {object} domain.APIResponseError "Email or username already exists"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users/{id} [put]
+func (c *UserHandler) UpdateUser(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	var user domain.User
+	err = echoContext.Bind(&user)
+	if err != nil {
+		return echoContext.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	var ok bool
+	if ok, err = util.IsRequestValid(&user); !ok {
+		return echoContext.JSON(http.StatusBadRequest, err.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.UserUseCase.UpdateUser(ctx, &user, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    user,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// DeactivateUser godoc
+// @Summary Deactivate user
+// @Description Deactivate the user by given id. The user is kept but marked inactive.
+// @Tags users
+// @Accept */*
+// @Produce json
+// @Param id path int true "user Id"
+// @Success 204
+// @Failure 404 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /users/{id}/deactivate [post]
+func (c *UserHandler) DeactivateUser(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+	err = c.UserUseCase.DeactivateUser(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return echoContext.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,103 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	userHTTP "github.com/meroedu/meroedu/internal/user/delivery/http"
+)
+
+func TestGetAll(t *testing.T) {
+	mockUCase := new(mocks.UserUseCase)
+	mockUCase.On("GetAll", mock.Anything, "kat", 0, 10).Return([]domain.User{{ID: 1, LastName: "Katwal"}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/users?q=kat&start=0&limit=10", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := userHTTP.UserHandler{
+		UserUseCase: mockUCase,
+	}
+	err = handler.GetAll(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateUser(t *testing.T) {
+	mockUCase := new(mocks.UserUseCase)
+	mockUCase.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+
+	e := echo.New()
+	body := `{"last_name":"Katwal","email":"dinesh@example.com","organization_id":1,"role_id":1}`
+	req, err := http.NewRequest(echo.POST, "/users", strings.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := userHTTP.UserHandler{
+		UserUseCase: mockUCase,
+	}
+	err = handler.CreateUser(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCreateUserInvalidEmail(t *testing.T) {
+	mockUCase := new(mocks.UserUseCase)
+
+	e := echo.New()
+	body := `{"last_name":"Katwal","email":"not-an-email","organization_id":1,"role_id":1}`
+	req, err := http.NewRequest(echo.POST, "/users", strings.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := userHTTP.UserHandler{
+		UserUseCase: mockUCase,
+	}
+	err = handler.CreateUser(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestDeactivateUser(t *testing.T) {
+	mockUCase := new(mocks.UserUseCase)
+	mockUCase.On("DeactivateUser", mock.Anything, int64(3)).Return(domain.ErrNotFound).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/users/3/deactivate", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/users/:id/deactivate")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := userHTTP.UserHandler{
+		UserUseCase: mockUCase,
+	}
+	err = handler.DeactivateUser(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,267 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const userColumns = `id,firstName,lastName,email,username,phone,organization_id,role_id,country_id,address1,address2,profileUrl,status,joinedDate,lastOnline,
+	email_verified_at,failed_logins,locked_until,updated_at,created_at`
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the user's Repository interface
+func Init(db *sql.DB) domain.UserRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: i, Valid: i != 0}
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.User, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.User, 0)
+	for rows.Next() {
+		t := domain.User{}
+		var firstName, email, username, phone, address1, address2, profileURL sql.NullString
+		var countryID, lastOnline, emailVerifiedAt, lockedUntil sql.NullInt64
+		err = rows.Scan(
+			&t.ID,
+			&firstName,
+			&t.LastName,
+			&email,
+			&username,
+			&phone,
+			&t.OrganizationID,
+			&t.RoleID,
+			&countryID,
+			&address1,
+			&address2,
+			&profileURL,
+			&t.Status,
+			&t.JoinedDate,
+			&lastOnline,
+			&emailVerifiedAt,
+			&t.FailedLogins,
+			&lockedUntil,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		t.FirstName = firstName.String
+		t.Email = email.String
+		t.Username = username.String
+		t.Phone = phone.String
+		t.CountryID = countryID.Int64
+		t.Address1 = address1.String
+		t.Address2 = address2.String
+		t.ProfileURL = profileURL.String
+		t.LastOnline = lastOnline.Int64
+		t.EmailVerifiedAt = emailVerifiedAt.Int64
+		t.LockedUntil = lockedUntil.Int64
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlRepository) getOne(ctx context.Context, query string, args ...interface{}) (*domain.User, error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+func (m *mysqlRepository) GetAll(ctx context.Context, searchQuery string, start int, limit int) ([]domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE organization_id = ?
+		AND CONCAT_WS(' ',firstName,lastName,email,username) LIKE ? ORDER BY created_at DESC LIMIT ?,?`
+	return m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), "%"+searchQuery+"%", start, limit)
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ? AND organization_id = ?`
+	return m.getOne(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+}
+
+// GetByEmail looks the user up across every organization: emails are unique installation wide
+// and are used to sign in before the organization is known.
+func (m *mysqlRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = ?`
+	return m.getOne(ctx, query, email)
+}
+
+// GetByUsername looks the user up across every organization, like GetByEmail.
+func (m *mysqlRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE username = ?`
+	return m.getOne(ctx, query, username)
+}
+
+func (m *mysqlRepository) CreateUser(ctx context.Context, u *domain.User) (err error) {
+	query := `INSERT users SET firstName=?,lastName=?,email=?,username=?,password=?,phone=?,organization_id=?,role_id=?,country_id=?,
+		address1=?,address2=?,profileUrl=?,status=?,joinedDate=?,updated_at=?,created_at=?`
+	stmt, err := m.conn.PrepareContext(ctx, query)
+	if err != nil {
+		log.Error("Error while preparing statement ", err)
+		return
+	}
+	res, err := stmt.ExecContext(ctx, nullString(u.FirstName), u.LastName, nullString(u.Email), nullString(u.Username),
+		nullString(u.Password), nullString(u.Phone), u.OrganizationID, u.RoleID, nullInt64(u.CountryID), nullString(u.Address1),
+		nullString(u.Address2), nullString(u.ProfileURL), u.Status, u.JoinedDate, u.UpdatedAt, u.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return
+	}
+	u.ID = lastID
+	return
+}
+
+// UpdateUser updates the profile of the user. Users can not be moved to another organization.
+func (m *mysqlRepository) UpdateUser(ctx context.Context, u *domain.User) (err error) {
+	query := `UPDATE users SET firstName=?,lastName=?,email=?,email_verified_at=?,username=?,phone=?,role_id=?,country_id=?,
+		address1=?,address2=?,profileUrl=?,updated_at=? WHERE id = ? AND organization_id = ?`
+	stmt, err := m.conn.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+	res, err := stmt.ExecContext(ctx, nullString(u.FirstName), u.LastName, nullString(u.Email), nullInt64(u.EmailVerifiedAt),
+		nullString(u.Username), nullString(u.Phone), u.RoleID, nullInt64(u.CountryID), nullString(u.Address1),
+		nullString(u.Address2), nullString(u.ProfileURL), u.UpdatedAt, u.ID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+	return
+}
+
+func (m *mysqlRepository) UpdateStatus(ctx context.Context, id int64, status int, updatedAt int64) (err error) {
+	query := `UPDATE users SET status=?,updated_at=? WHERE id = ? AND organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, status, updatedAt, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return
+}
+
+// GetPassword returns the stored password hash of the user, empty when no password was set.
+func (m *mysqlRepository) GetPassword(ctx context.Context, id int64) (string, error) {
+	hash := sql.NullString{}
+	query := `SELECT password FROM users WHERE id = ? AND organization_id = ?`
+	err := m.conn.QueryRowContext(ctx, query, id, domain.OrganizationIDFromContext(ctx)).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return "", err
+	}
+	return hash.String, nil
+}
+
+func (m *mysqlRepository) UpdatePassword(ctx context.Context, id int64, hash string, updatedAt int64) (err error) {
+	query := `UPDATE users SET password=?,updated_at=? WHERE id = ? AND organization_id = ?`
+	res, err := m.conn.ExecContext(ctx, query, hash, updatedAt, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return
+}
+
+// MarkEmailVerified verifies the email of the user. It returns ErrNotFound when the user changed the email since.
+func (m *mysqlRepository) MarkEmailVerified(ctx context.Context, id int64, email string, verifiedAt int64) (err error) {
+	query := `UPDATE users SET email_verified_at=? WHERE id = ? AND organization_id = ? AND email = ?`
+	res, err := m.conn.ExecContext(ctx, query, verifiedAt, id, domain.OrganizationIDFromContext(ctx), email)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return
+}
+
+// RecordLoginFailure counts a wrong password. The user is locked until lockedUntil when the count reaches
+// maxFailures, and the count starts over.
+func (m *mysqlRepository) RecordLoginFailure(ctx context.Context, id int64, maxFailures int, lockedUntil int64) error {
+	query := `UPDATE users SET locked_until=IF(failed_logins+1 >= ?, ?, locked_until),
+		failed_logins=IF(failed_logins+1 >= ?, 0, failed_logins+1) WHERE id = ? AND organization_id = ?`
+	_, err := m.conn.ExecContext(ctx, query, maxFailures, lockedUntil, maxFailures, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+	}
+	return err
+}
+
+// ResetLoginFailures clears the wrong password count and the lock of the user
+func (m *mysqlRepository) ResetLoginFailures(ctx context.Context, id int64) error {
+	query := `UPDATE users SET failed_logins=0,locked_until=NULL WHERE id = ? AND organization_id = ?`
+	_, err := m.conn.ExecContext(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	repository "github.com/meroedu/meroedu/internal/user/repository/mysql"
+)
+
+var userColumns = []string{"id", "firstName", "lastName", "email", "username", "phone", "organization_id", "role_id",
+	"country_id", "address1", "address2", "profileUrl", "status", "joinedDate", "lastOnline", "email_verified_at", "failed_logins", "locked_until", "updated_at", "created_at"}
+
+func TestGetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now().Unix()
+	rows := sqlmock.NewRows(userColumns).
+		AddRow(1, "Dinesh", "Katwal", "dinesh@example.com", "dinesh", nil, 1, 2, nil, nil, nil, nil, 1, now, nil, nil, 0, nil, now, now).
+		AddRow(2, nil, "Sharma", "sharma@example.com", nil, "98000", 1, 2, 3, nil, nil, nil, 0, now, now, now, 2, now+900, now, now)
+
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE organization_id = \\?\\s+AND CONCAT_WS").WithArgs(int64(1), "%din%", 0, 10).WillReturnRows(rows)
+
+	r := repository.Init(db)
+	list, err := r.GetAll(domain.WithOrganizationID(context.TODO(), 1), "din", 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "dinesh", list[0].Username)
+	assert.Equal(t, "", list[1].FirstName)
+	assert.Equal(t, int64(3), list[1].CountryID)
+	assert.Equal(t, domain.UserInactive, list[1].Status)
+	assert.Equal(t, int64(0), list[0].EmailVerifiedAt)
+	assert.Equal(t, now, list[1].EmailVerifiedAt)
+	assert.Equal(t, 2, list[1].FailedLogins)
+	assert.Equal(t, now+900, list[1].LockedUntil)
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now().Unix()
+	rows := sqlmock.NewRows(userColumns).
+		AddRow(1, "Dinesh", "Katwal", "dinesh@example.com", "dinesh", nil, 1, 2, nil, nil, nil, nil, 1, now, nil, nil, 0, nil, now, now)
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\? AND organization_id = \\?").WithArgs(int64(1), int64(1)).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\? AND organization_id = \\?").WithArgs(int64(1), int64(2)).WillReturnRows(sqlmock.NewRows(userColumns))
+
+	r := repository.Init(db)
+	user, err := r.GetByID(domain.WithOrganizationID(context.TODO(), 1), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Katwal", user.LastName)
+
+	_, err = r.GetByID(domain.WithOrganizationID(context.TODO(), 2), 1)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestCreateUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now().Unix()
+	u := &domain.User{
+		LastName: "Katwal", Email: "dinesh@example.com", OrganizationID: 1, RoleID: 2,
+		Status: domain.UserActive, JoinedDate: now, UpdatedAt: now, CreatedAt: now,
+	}
+	prep := mock.ExpectPrepare("INSERT users SET")
+	prep.ExpectExec().WithArgs(nil, u.LastName, u.Email, nil, nil, nil, u.OrganizationID, u.RoleID, nil, nil, nil, nil,
+		u.Status, u.JoinedDate, u.UpdatedAt, u.CreatedAt).WillReturnResult(sqlmock.NewResult(7, 1))
+
+	r := repository.Init(db)
+	err = r.CreateUser(context.TODO(), u)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), u.ID)
+}
+
+func TestUpdateStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now().Unix()
+	mock.ExpectExec("UPDATE users SET status=\\?,updated_at=\\? WHERE id = \\? AND organization_id = \\?").
+		WithArgs(domain.UserInactive, now, int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE users SET status=\\?,updated_at=\\? WHERE id = \\? AND organization_id = \\?").
+		WithArgs(domain.UserInactive, now, int64(2), int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	r := repository.Init(db)
+	ctx := domain.WithOrganizationID(context.TODO(), 1)
+	assert.NoError(t, r.UpdateStatus(ctx, 1, domain.UserInactive, now))
+	assert.Equal(t, domain.ErrNotFound, r.UpdateStatus(ctx, 2, domain.UserInactive, now))
+}
+
+func TestRecordLoginFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	lockedUntil := time.Now().Add(15 * time.Minute).Unix()
+	mock.ExpectExec(`UPDATE users SET locked_until=IF\(failed_logins\+1 >= \?, \?, locked_until\),\s+failed_logins=IF\(failed_logins\+1 >= \?, 0, failed_logins\+1\) WHERE id = \? AND organization_id = \?`).
+		WithArgs(5, lockedUntil, 5, 1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := repository.Init(db)
+	err = r.RecordLoginFailure(domain.WithOrganizationID(context.TODO(), 2), 1, 5, lockedUntil)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkEmailVerified(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now().Unix()
+	mock.ExpectExec(`UPDATE users SET email_verified_at=\? WHERE id = \? AND organization_id = \? AND email = \?`).
+		WithArgs(now, 1, 2, "old@example.com").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	r := repository.Init(db)
+	err = r.MarkEmailVerified(domain.WithOrganizationID(context.TODO(), 2), 1, "old@example.com", now)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+	"github.com/meroedu/meroedu/pkg/password"
+)
+
+// UserUseCase ...
+type UserUseCase struct {
+	userRepo       domain.UserRepository
+	roleRepo       domain.RoleRepository
+	accountUseCase domain.AccountUseCase
+	contextTimeOut time.Duration
+}
+
+// NewUserUseCase will create new an UserUseCase. New emails are verified through the AccountUseCase.
+func NewUserUseCase(u domain.UserRepository, r domain.RoleRepository, a domain.AccountUseCase, timeout time.Duration) domain.UserUseCase {
+	return &UserUseCase{
+		userRepo:       u,
+		roleRepo:       r,
+		accountUseCase: a,
+		contextTimeOut: timeout,
+	}
+}
+
+// sendVerification emails a verification link for the new email of the user. The user is saved already,
+// so a failure is only logged; the user can ask for another link.
+func (usecase *UserUseCase) sendVerification(ctx context.Context, user *domain.User) {
+	if err := usecase.accountUseCase.SendVerification(ctx, user); err != nil {
+		log.Errorf("Verification of the email of user %d failed: %v", user.ID, err)
+	}
+}
+
+// GetAll ...
+func (usecase *UserUseCase) GetAll(c context.Context, searchQuery string, start int, limit int) (res []domain.User, err error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+
+	res, err = usecase.userRepo.GetAll(ctx, searchQuery, start, limit)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetByID ...
+func (usecase *UserUseCase) GetByID(c context.Context, id int64) (res *domain.User, err error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+
+	res, err = usecase.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// checkUnique returns ErrConflict when the email or username is taken by a user other than id.
+func (usecase *UserUseCase) checkUnique(ctx context.Context, user *domain.User, id int64) error {
+	existing, err := usecase.userRepo.GetByEmail(ctx, user.Email)
+	if err != nil && err != domain.ErrNotFound {
+		return err
+	}
+	if existing != nil && existing.ID != id {
+		return domain.ErrConflict
+	}
+	if user.Username == "" {
+		return nil
+	}
+	existing, err = usecase.userRepo.GetByUsername(ctx, user.Username)
+	if err != nil && err != domain.ErrNotFound {
+		return err
+	}
+	if existing != nil && existing.ID != id {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+// checkRole returns ErrBadParamInput when the role is not visible to the organization, and ErrForbidden
+// when the role grants a permission the caller does not hold.
+func (usecase *UserUseCase) checkRole(ctx context.Context, roleID int64) error {
+	role, err := usecase.roleRepo.GetByID(ctx, roleID)
+	if err == domain.ErrNotFound {
+		return domain.ErrBadParamInput
+	}
+	if err != nil {
+		return err
+	}
+	if !domain.HasPermissions(ctx, role.Permissions) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// CreateUser will create the user in the caller's organization. Only a caller allowed to manage
+// organizations can create the user in another one. The optional initial password is stored hashed,
+// and a verification link is emailed to the user.
+func (usecase *UserUseCase) CreateUser(c context.Context, user *domain.User) (err error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	if user.OrganizationID == 0 || !domain.HasPermission(ctx, domain.PermOrganizationManage) {
+		user.OrganizationID = domain.OrganizationIDFromContext(ctx)
+	}
+	if err = usecase.checkRole(domain.WithOrganizationID(ctx, user.OrganizationID), user.RoleID); err != nil {
+		return err
+	}
+	if err = usecase.checkUnique(ctx, user, 0); err != nil {
+		return err
+	}
+	if user.Password != "" {
+		if user.Password, err = password.Hash(user.Password); err != nil {
+			return err
+		}
+		defer func() { user.Password = "" }()
+	}
+	now := time.Now().Unix()
+	user.Status = domain.UserActive
+	user.EmailVerifiedAt = 0
+	user.LockedUntil = 0
+	user.JoinedDate = now
+	user.UpdatedAt = now
+	user.CreatedAt = now
+	if err = usecase.userRepo.CreateUser(ctx, user); err != nil {
+		return err
+	}
+	usecase.sendVerification(ctx, user)
+	return nil
+}
+
+// UpdateUser will update the profile fields of the user. Status and password are left as is. A changed
+// email is no longer verified and a verification link is emailed to it.
+func (usecase *UserUseCase) UpdateUser(c context.Context, user *domain.User, id int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	existing, err := usecase.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user.RoleID != existing.RoleID {
+		if err = usecase.checkRole(ctx, user.RoleID); err != nil {
+			return err
+		}
+	}
+	if err = usecase.checkUnique(ctx, user, id); err != nil {
+		return err
+	}
+	user.ID = id
+	user.OrganizationID = existing.OrganizationID
+	user.Password = ""
+	user.Status = existing.Status
+	user.JoinedDate = existing.JoinedDate
+	user.LastOnline = existing.LastOnline
+	user.FailedLogins = existing.FailedLogins
+	user.LockedUntil = existing.LockedUntil
+	user.CreatedAt = existing.CreatedAt
+	user.UpdatedAt = time.Now().Unix()
+	emailChanged := !strings.EqualFold(user.Email, existing.Email)
+	user.EmailVerifiedAt = existing.EmailVerifiedAt
+	if emailChanged {
+		user.EmailVerifiedAt = 0
+	}
+	if err = usecase.userRepo.UpdateUser(ctx, user); err != nil {
+		return err
+	}
+	if emailChanged {
+		usecase.sendVerification(ctx, user)
+	}
+	return nil
+}
+
+// DeactivateUser ...
+func (usecase *UserUseCase) DeactivateUser(c context.Context, id int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.userRepo.UpdateStatus(ctx, id, domain.UserInactive, time.Now().Unix())
+}
@@ -0,0 +1,176 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/user/usecase"
+	"github.com/meroedu/meroedu/pkg/password"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAccount := new(mocks.AccountUseCase)
+		user := domain.User{LastName: "Katwal", Email: "dinesh@example.com", Username: "dinesh", Password: "s3cret-pass", OrganizationID: 9, RoleID: 1}
+		mockRoleRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Role{ID: 1, Code: domain.RoleAdmin}, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("GetByUsername", mock.Anything, user.Username).Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return password.Compare(u.Password, "s3cret-pass")
+		})).Return(nil).Once()
+		mockAccount.On("SendVerification", mock.Anything, &user).Return(nil).Once()
+
+		u := ucase.NewUserUseCase(mockUserRepo, mockRoleRepo, mockAccount, time.Second*2)
+		err := u.CreateUser(domain.WithOrganizationID(context.TODO(), 2), &user)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), user.OrganizationID)
+		assert.Equal(t, domain.UserActive, user.Status)
+		assert.NotZero(t, user.JoinedDate)
+		assert.Empty(t, user.Password)
+		mockUserRepo.AssertExpectations(t)
+		mockAccount.AssertExpectations(t)
+	})
+	t.Run("email-conflict", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAccount := new(mocks.AccountUseCase)
+		user := domain.User{LastName: "Katwal", Email: "dinesh@example.com", OrganizationID: 1, RoleID: 1}
+		mockRoleRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Role{ID: 1, Code: domain.RoleAdmin}, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(&domain.User{ID: 3}, nil).Once()
+
+		u := ucase.NewUserUseCase(mockUserRepo, mockRoleRepo, mockAccount, time.Second*2)
+		err := u.CreateUser(context.TODO(), &user)
+		assert.Equal(t, domain.ErrConflict, err)
+		mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+	t.Run("superadmin-role", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAccount := new(mocks.AccountUseCase)
+		user := domain.User{LastName: "Katwal", Email: "dinesh@example.com", RoleID: 4}
+		mockRoleRepo.On("GetByID", mock.Anything, int64(4)).
+			Return(&domain.Role{ID: 4, Code: domain.RoleSuperAdmin, Permissions: []domain.Permission{domain.PermOrganizationManage}}, nil).Once()
+
+		u := ucase.NewUserUseCase(mockUserRepo, mockRoleRepo, mockAccount, time.Second*2)
+		ctx := domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2), []domain.Permission{domain.PermUserManage})
+		err := u.CreateUser(ctx, &user)
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+	t.Run("other-organization", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAccount := new(mocks.AccountUseCase)
+		user := domain.User{LastName: "Katwal", Email: "dinesh@example.com", OrganizationID: 9, RoleID: 1}
+		mockRoleRepo.On("GetByID", mock.MatchedBy(func(ctx context.Context) bool {
+			return domain.OrganizationIDFromContext(ctx) == 9
+		}), int64(1)).Return(&domain.Role{ID: 1, Code: domain.RoleAdmin}, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+		mockAccount.On("SendVerification", mock.Anything, &user).Return(domain.ErrTooManyRequests).Once()
+
+		u := ucase.NewUserUseCase(mockUserRepo, mockRoleRepo, mockAccount, time.Second*2)
+		ctx := domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 2), []domain.Permission{domain.PermOrganizationManage})
+		assert.NoError(t, u.CreateUser(ctx, &user))
+		assert.Equal(t, int64(9), user.OrganizationID)
+		mockRoleRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	existing := &domain.User{ID: 1, LastName: "Katwal", Email: "dinesh@example.com", OrganizationID: 1, RoleID: 1, Status: domain.UserInactive, JoinedDate: 10, CreatedAt: 10}
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAccount := new(mocks.AccountUseCase)
+		user := domain.User{LastName: "Katwal", Email: "dinesh@example.com", OrganizationID: 1, RoleID: 1}
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(existing, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(existing, nil).Once()
+		mockUserRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+
+		u := ucase.NewUserUseCase(mockUserRepo, mockRoleRepo, mockAccount, time.Second*2)
+		err := u.UpdateUser(context.TODO(), &user, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.UserInactive, user.Status)
+		assert.Equal(t, int64(10), user.CreatedAt)
+		mockUserRepo.AssertExpectations(t)
+		mockAccount.AssertNotCalled(t, "SendVerification", mock.Anything, mock.Anything)
+	})
+	t.Run("email-changed", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAccount := new(mocks.AccountUseCase)
+		verified := *existing
+		verified.EmailVerifiedAt = 10
+		user := domain.User{LastName: "Katwal", Email: "new@example.com", OrganizationID: 1, RoleID: 1}
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&verified, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(nil, domain.ErrNotFound).Once()
+		mockUserRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.EmailVerifiedAt == 0
+		})).Return(nil).Once()
+		mockAccount.On("SendVerification", mock.Anything, &user).Return(nil).Once()
+
+		u := ucase.NewUserUseCase(mockUserRepo, mockRoleRepo, mockAccount, time.Second*2)
+		err := u.UpdateUser(context.TODO(), &user, 1)
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockAccount.AssertExpectations(t)
+	})
+	t.Run("username-conflict", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAccount := new(mocks.AccountUseCase)
+		user := domain.User{LastName: "Katwal", Email: "dinesh@example.com", Username: "taken", OrganizationID: 1, RoleID: 1}
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(existing, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(existing, nil).Once()
+		mockUserRepo.On("GetByUsername", mock.Anything, user.Username).Return(&domain.User{ID: 2}, nil).Once()
+
+		u := ucase.NewUserUseCase(mockUserRepo, mockRoleRepo, mockAccount, time.Second*2)
+		err := u.UpdateUser(context.TODO(), &user, 1)
+		assert.Equal(t, domain.ErrConflict, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+	t.Run("role-above-caller", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAccount := new(mocks.AccountUseCase)
+		user := domain.User{LastName: "Katwal", Email: "dinesh@example.com", OrganizationID: 1, RoleID: 5}
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(existing, nil).Once()
+		mockRoleRepo.On("GetByID", mock.Anything, int64(5)).
+			Return(&domain.Role{ID: 5, Code: "user-admin", Permissions: []domain.Permission{domain.PermUserManage, domain.PermRoleManage}}, nil).Once()
+
+		u := ucase.NewUserUseCase(mockUserRepo, mockRoleRepo, mockAccount, time.Second*2)
+		ctx := domain.WithPermissions(domain.WithOrganizationID(context.TODO(), 1), []domain.Permission{domain.PermUserManage})
+		err := u.UpdateUser(ctx, &user, 1)
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockUserRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockAccount := new(mocks.AccountUseCase)
+		mockUserRepo.On("GetByID", mock.Anything, int64(5)).Return(nil, domain.ErrNotFound).Once()
+
+		u := ucase.NewUserUseCase(mockUserRepo, mockRoleRepo, mockAccount, time.Second*2)
+		err := u.UpdateUser(context.TODO(), &domain.User{}, 5)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestDeactivateUser(t *testing.T) {
+	mockUserRepo := new(mocks.UserRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	mockAccount := new(mocks.AccountUseCase)
+	mockUserRepo.On("UpdateStatus", mock.Anything, int64(1), domain.UserInactive, mock.AnythingOfType("int64")).Return(nil).Once()
+
+	u := ucase.NewUserUseCase(mockUserRepo, mockRoleRepo, mockAccount, time.Second*2)
+	assert.NoError(t, u.DeactivateUser(context.TODO(), 1))
+	mockUserRepo.AssertExpectations(t)
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/rbac"
+	"github.com/meroedu/meroedu/internal/util"
+)
+
+// maxFileSize is the size of the largest import file accepted
+const maxFileSize = 10 << 20
+
+// ResponseError represents the response error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// UserImportHandler ...
+type UserImportHandler struct {
+	UserImportUseCase domain.UserImportUseCase
+}
+
+// NewUserImportHandler ...
+func NewUserImportHandler(e *echo.Echo, us domain.UserImportUseCase) {
+	handler := &UserImportHandler{
+		UserImportUseCase: us,
+	}
+	e.GET("/user-imports", handler.GetAll, rbac.Require(domain.PermUserManage))
+	e.GET("/user-imports/:id", handler.GetByID, rbac.Require(domain.PermUserManage))
+	e.POST("/user-imports", handler.ImportUsers, rbac.Require(domain.PermUserManage))
+}
+
+// GetAll godoc
+// @Summary Get All user imports.
+// @Description Get the user imports of the organization, latest first.
+// @Tags user-imports
+// @Accept */*
+// @Produce json
+// @Param start query int true "start"
+// @Param limit query int true "limit"
+// @Success 200 {object} domain.Summaries
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /user-imports [get]
+func (c *UserImportHandler) GetAll(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	start, limit := 0, 10
+	var err error
+	for k, v := range echoContext.QueryParams() {
+		switch k {
+		case "start":
+			val := strings.TrimSpace(v[0])
+			if start, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		case "limit":
+			val := strings.TrimSpace(v[0])
+			if limit, err = strconv.Atoi(val); err != nil {
+				return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+			}
+		}
+	}
+
+	list, err := c.UserImportUseCase.GetAll(ctx, start, limit)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Summaries{
+		Response: domain.Response{
+			Message: domain.Success,
+			Data:    list,
+		},
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary Get user import by ID.
+// @Description Get the progress of a user import: the rows processed so far, the users created and the errors by row.
+// @Tags user-imports
+// @Accept */*
+// @Produce json
+// @Param id path int true "User import Id"
+// @Success 200 {object} domain.Response
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 404 {object} domain.APIResponseError "Can not find ID"
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /user-imports/{id} [get]
+func (c *UserImportHandler) GetByID(echoContext echo.Context) error {
+	idParam, err := strconv.Atoi(echoContext.Param("id"))
+	if err != nil {
+		return echoContext.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+	ctx := echoContext.Request().Context()
+
+	userImport, err := c.UserImportUseCase.GetByID(ctx, int64(idParam))
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	res := domain.Response{
+		Data:    userImport,
+		Message: domain.Success,
+	}
+	return echoContext.JSON(http.StatusOK, res)
+}
+
+// ImportUsers godoc
+// @Summary Import users from a file.
+// @Description Import users from a CSV or XLSX file whose header row names the columns: email and last_name, and optionally first_name,
+// @Description username, phone, country (ISO code or name), role (code, learner by default), teams (names) and courses (IDs of published
+// @Description courses), the last two separated by ";". Every row is validated first. A dry run only reports the errors by row; otherwise
+// @Description the import is queued when every row is valid, and its progress is polled at /user-imports/{id}.
+// @Tags user-imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file"
+// @Param dry_run formData bool false "Only validate the file"
+// @Success 200 {object} domain.Response "Dry run report"
+// @Success 202 {object} domain.Response "Report with the queued import"
+// @Failure 400 {object} domain.Response "Report of the invalid rows, or an unreadable file"
+// @Failure 403 {object} domain.APIResponseError
+// @Failure 500 {object} domain.APIResponseError "Internal Server Error"
+// @Router /user-imports [post]
+func (c *UserImportHandler) ImportUsers(echoContext echo.Context) error {
+	ctx := echoContext.Request().Context()
+	fileHeader, err := echoContext.FormFile("file")
+	if err != nil {
+		return echoContext.JSON(http.StatusBadRequest, ResponseError{Message: err.Error()})
+	}
+	if fileHeader.Size > maxFileSize {
+		return echoContext.JSON(http.StatusBadRequest, ResponseError{Message: domain.ErrBadParamInput.Error()})
+	}
+	dryRun := false
+	if value := echoContext.FormValue("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			return echoContext.JSON(http.StatusBadRequest, ResponseError{Message: err.Error()})
+		}
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	defer file.Close()
+
+	report, err := c.UserImportUseCase.ImportUsers(ctx, fileHeader.Filename, file, dryRun)
+	if err != nil {
+		return echoContext.JSON(util.GetStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	switch {
+	case dryRun:
+		return echoContext.JSON(http.StatusOK, domain.Response{Data: report, Message: domain.Success})
+	case report.Import == nil:
+		return echoContext.JSON(http.StatusBadRequest, domain.Response{Data: report, Message: domain.Error})
+	default:
+		return echoContext.JSON(http.StatusAccepted, domain.Response{Data: report, Message: domain.Success})
+	}
+}
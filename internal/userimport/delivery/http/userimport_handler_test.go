@@ -0,0 +1,203 @@
+package http_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	userimportHTTP "github.com/meroedu/meroedu/internal/userimport/delivery/http"
+)
+
+const users = "email,last_name\nram@meroedu.com,Thapa\n"
+
+// upload returns a multipart request importing content, with dryRun as the dry_run field when it is not empty
+func upload(t *testing.T, content string, dryRun string) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	if dryRun != "" {
+		require.NoError(t, writer.WriteField("dry_run", dryRun))
+	}
+	part, err := writer.CreateFormFile("file", "users.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest(echo.POST, "/user-imports", body)
+	require.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	return req
+}
+
+func TestGetAll(t *testing.T) {
+	mockUCase := new(mocks.UserImportUseCase)
+	mockUCase.On("GetAll", mock.Anything, 10, 5).Return([]domain.UserImport{{ID: 2}}, nil).Once()
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"start=10&limit=5", http.StatusOK},
+		{"start=ten", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/user-imports?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := userimportHTTP.UserImportHandler{
+			UserImportUseCase: mockUCase,
+		}
+		err = handler.GetAll(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.query)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByID(t *testing.T) {
+	mockUCase := new(mocks.UserImportUseCase)
+	mockUCase.On("GetByID", mock.Anything, int64(2)).Return(&domain.UserImport{ID: 2}, nil).Once()
+	mockUCase.On("GetByID", mock.Anything, int64(3)).Return(nil, domain.ErrNotFound).Once()
+
+	tests := []struct {
+		id   string
+		code int
+	}{
+		{"2", http.StatusOK},
+		{"3", http.StatusNotFound},
+		{"latest", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/user-imports/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/user-imports/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		handler := userimportHTTP.UserImportHandler{
+			UserImportUseCase: mockUCase,
+		}
+		err = handler.GetByID(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestImportUsers(t *testing.T) {
+	t.Run("queued", func(t *testing.T) {
+		mockUCase := new(mocks.UserImportUseCase)
+		mockUCase.On("ImportUsers", mock.Anything, "users.csv", mock.Anything, false).
+			Return(&domain.ImportReport{Total: 1, Valid: 1, Import: &domain.UserImport{ID: 2}}, nil).Once()
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(upload(t, users, ""), rec)
+		handler := userimportHTTP.UserImportHandler{
+			UserImportUseCase: mockUCase,
+		}
+		err := handler.ImportUsers(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("dry-run", func(t *testing.T) {
+		mockUCase := new(mocks.UserImportUseCase)
+		mockUCase.On("ImportUsers", mock.Anything, "users.csv", mock.Anything, true).
+			Return(&domain.ImportReport{Total: 1, Valid: 1}, nil).Once()
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(upload(t, users, "true"), rec)
+		handler := userimportHTTP.UserImportHandler{
+			UserImportUseCase: mockUCase,
+		}
+		err := handler.ImportUsers(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("invalid-rows", func(t *testing.T) {
+		mockUCase := new(mocks.UserImportUseCase)
+		mockUCase.On("ImportUsers", mock.Anything, "users.csv", mock.Anything, false).
+			Return(&domain.ImportReport{Total: 1, Errors: []domain.ImportError{{Line: 2, Error: "email is required"}}}, nil).Once()
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(upload(t, users, ""), rec)
+		handler := userimportHTTP.UserImportHandler{
+			UserImportUseCase: mockUCase,
+		}
+		err := handler.ImportUsers(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("unreadable-file", func(t *testing.T) {
+		mockUCase := new(mocks.UserImportUseCase)
+		mockUCase.On("ImportUsers", mock.Anything, "users.csv", mock.Anything, false).Return(nil, domain.ErrBadParamInput).Once()
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(upload(t, "", ""), rec)
+		handler := userimportHTTP.UserImportHandler{
+			UserImportUseCase: mockUCase,
+		}
+		err := handler.ImportUsers(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("bad-dry-run", func(t *testing.T) {
+		mockUCase := new(mocks.UserImportUseCase)
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(upload(t, users, "maybe"), rec)
+		handler := userimportHTTP.UserImportHandler{
+			UserImportUseCase: mockUCase,
+		}
+		err := handler.ImportUsers(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("no-file", func(t *testing.T) {
+		mockUCase := new(mocks.UserImportUseCase)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/user-imports", strings.NewReader(""))
+		assert.NoError(t, err)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := userimportHTTP.UserImportHandler{
+			UserImportUseCase: mockUCase,
+		}
+		err = handler.ImportUsers(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,39 @@
+package userimport
+
+import (
+	"context"
+	"time"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+// ImportJob runs the queued user imports of every organization
+type ImportJob struct {
+	userImportUseCase domain.UserImportUseCase
+	interval          time.Duration
+}
+
+// NewImportJob will create a job looking for queued imports every interval
+func NewImportJob(us domain.UserImportUseCase, interval time.Duration) *ImportJob {
+	return &ImportJob{
+		userImportUseCase: us,
+		interval:          interval,
+	}
+}
+
+// Start runs the job every interval until ctx is done
+func (j *ImportJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		if err := j.userImportUseCase.RunPending(ctx); err != nil && ctx.Err() == nil {
+			log.Errorf("Error while running the user imports: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+)
+
+const importColumns = `id,organization_id,file_name,status,total,processed,created,failed,errors,permissions,created_by,started_at,
+	finished_at,updated_at,created_at`
+
+// existingBatch is the number of values looked up by query
+const existingBatch = 500
+
+type mysqlRepository struct {
+	conn *sql.DB
+}
+
+// Init will create an object that represent the user import's Repository interface
+func Init(db *sql.DB) domain.UserImportRepository {
+	return &mysqlRepository{
+		conn: db,
+	}
+}
+
+func nullInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: i, Valid: i != 0}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scan reads the importColumns of a row, followed by the given destinations
+func scan(row scanner, extra ...interface{}) (*domain.UserImport, error) {
+	t := &domain.UserImport{}
+	var errs, permissions string
+	var createdBy, startedAt, finishedAt sql.NullInt64
+	dest := []interface{}{
+		&t.ID,
+		&t.OrganizationID,
+		&t.FileName,
+		&t.Status,
+		&t.Total,
+		&t.Processed,
+		&t.Created,
+		&t.Failed,
+		&errs,
+		&permissions,
+		&createdBy,
+		&startedAt,
+		&finishedAt,
+		&t.UpdatedAt,
+		&t.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	t.CreatedBy = createdBy.Int64
+	t.StartedAt = startedAt.Int64
+	t.FinishedAt = finishedAt.Int64
+	if err := json.Unmarshal([]byte(errs), &t.Errors); err != nil {
+		return nil, err
+	}
+	if t.Errors == nil {
+		t.Errors = make([]domain.ImportError, 0)
+	}
+	t.Permissions = make([]domain.Permission, 0)
+	if permissions != "" {
+		for _, p := range strings.Split(permissions, ",") {
+			t.Permissions = append(t.Permissions, domain.Permission(p))
+		}
+	}
+	return t, nil
+}
+
+func (m *mysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.UserImport, err error) {
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.UserImport, 0)
+	for rows.Next() {
+		t, err := scan(rows)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result = append(result, *t)
+	}
+
+	return result, nil
+}
+
+// GetAll returns the imports of the caller's organization, latest first
+func (m *mysqlRepository) GetAll(ctx context.Context, start int, limit int) ([]domain.UserImport, error) {
+	query := `SELECT ` + importColumns + ` FROM user_imports WHERE organization_id = ? ORDER BY created_at DESC, id DESC LIMIT ?,?`
+	return m.fetch(ctx, query, domain.OrganizationIDFromContext(ctx), start, limit)
+}
+
+func (m *mysqlRepository) GetByID(ctx context.Context, id int64) (*domain.UserImport, error) {
+	query := `SELECT ` + importColumns + ` FROM user_imports WHERE id = ? AND organization_id = ?`
+	list, err := m.fetch(ctx, query, id, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// CreateImport queues the import in the caller's organization with the rows to create
+func (m *mysqlRepository) CreateImport(ctx context.Context, i *domain.UserImport) error {
+	i.OrganizationID = domain.OrganizationIDFromContext(ctx)
+	errs, err := json.Marshal(i.Errors)
+	if err != nil {
+		return err
+	}
+	rows, err := json.Marshal(i.Rows)
+	if err != nil {
+		return err
+	}
+	permissions := make([]string, 0, len(i.Permissions))
+	for _, p := range i.Permissions {
+		permissions = append(permissions, string(p))
+	}
+	query := `INSERT user_imports SET organization_id=?,file_name=?,status=?,total=?,processed=?,created=?,failed=?,errors=?,
+		pending_rows=?,permissions=?,created_by=?,updated_at=?,created_at=?`
+	res, err := m.conn.ExecContext(ctx, query, i.OrganizationID, i.FileName, i.Status, i.Total, i.Processed, i.Created, i.Failed,
+		string(errs), string(rows), strings.Join(permissions, ","), nullInt64(i.CreatedBy), i.UpdatedAt, i.CreatedAt)
+	if err != nil {
+		log.Error("Error while executing statement ", err)
+		return err
+	}
+	if i.ID, err = res.LastInsertId(); err != nil {
+		log.Error("Got Error from LastInsertId method: ", err)
+		return err
+	}
+	return nil
+}
+
+// ClaimPending marks the oldest pending import of any organization running and returns it with its rows.
+// A running import not updated since staleBefore was interrupted, and is claimed again to be resumed.
+// No import to run gives ErrNotFound.
+func (m *mysqlRepository) ClaimPending(ctx context.Context, staleBefore int64, startedAt int64) (res *domain.UserImport, err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	var rows sql.NullString
+	query := `SELECT ` + importColumns + `,pending_rows FROM user_imports WHERE status = ? OR (status = ? AND updated_at < ?)
+		ORDER BY id LIMIT 1 FOR UPDATE`
+	res, err = scan(tx.QueryRowContext(ctx, query, domain.ImportPending, domain.ImportRunning, staleBefore), &rows)
+	if err == sql.ErrNoRows {
+		err = domain.ErrNotFound
+		return
+	}
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if rows.Valid {
+		if err = json.Unmarshal([]byte(rows.String), &res.Rows); err != nil {
+			return
+		}
+	}
+	if res.StartedAt == 0 {
+		res.StartedAt = startedAt
+	}
+	res.Status = domain.ImportRunning
+	res.UpdatedAt = startedAt
+	query = `UPDATE user_imports SET status=?,started_at=?,updated_at=? WHERE id = ?`
+	if _, err = tx.ExecContext(ctx, query, res.Status, res.StartedAt, res.UpdatedAt, res.ID); err != nil {
+		log.Error(err)
+	}
+	return
+}
+
+// UpdateProgress saves the progress of an import of the caller's organization. The rows are dropped once it is completed.
+func (m *mysqlRepository) UpdateProgress(ctx context.Context, i *domain.UserImport) error {
+	errs, err := json.Marshal(i.Errors)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE user_imports SET status=?,processed=?,created=?,failed=?,errors=?,finished_at=?,updated_at=?`
+	if i.Status == domain.ImportCompleted {
+		query += `,pending_rows=NULL`
+	}
+	query += ` WHERE id = ? AND organization_id = ?`
+	_, err = m.conn.ExecContext(ctx, query, i.Status, i.Processed, i.Created, i.Failed, string(errs), nullInt64(i.FinishedAt), i.UpdatedAt,
+		i.ID, domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// existing returns the given values, lower cased, which are taken in the column of the users of any organization
+func (m *mysqlRepository) existing(ctx context.Context, column string, values []string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	for start := 0; start < len(values); start += existingBatch {
+		end := start + existingBatch
+		if end > len(values) {
+			end = len(values)
+		}
+		batch := values[start:end]
+		args := make([]interface{}, len(batch))
+		for i, v := range batch {
+			args[i] = v
+		}
+		query := `SELECT ` + column + ` FROM users WHERE ` + column + ` IN (?` + strings.Repeat(`,?`, len(batch)-1) + `)`
+		rows, err := m.conn.QueryContext(ctx, query, args...)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		for rows.Next() {
+			var v string
+			if err = rows.Scan(&v); err != nil {
+				log.Error(err)
+				rows.Close()
+				return nil, err
+			}
+			result[strings.ToLower(v)] = true
+		}
+		if err = rows.Close(); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// GetExistingEmails returns the given emails, lower cased, which are taken. Emails are unique across organizations.
+func (m *mysqlRepository) GetExistingEmails(ctx context.Context, emails []string) (map[string]bool, error) {
+	return m.existing(ctx, "email", emails)
+}
+
+// GetExistingUsernames returns the given usernames, lower cased, which are taken. Usernames are unique across organizations.
+func (m *mysqlRepository) GetExistingUsernames(ctx context.Context, usernames []string) (map[string]bool, error) {
+	return m.existing(ctx, "username", usernames)
+}
+
+// GetCountryIDs returns the IDs of the countries by lower case ISO code, ISO3 code and name
+func (m *mysqlRepository) GetCountryIDs(ctx context.Context) (map[string]int64, error) {
+	rows, err := m.conn.QueryContext(ctx, `SELECT id,iso,COALESCE(iso3,''),name,nicename FROM countries ORDER BY id`)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result := make(map[string]int64)
+	for rows.Next() {
+		var id int64
+		var iso, iso3, name, niceName string
+		if err = rows.Scan(&id, &iso, &iso3, &name, &niceName); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		for _, key := range []string{iso, iso3, name, niceName} {
+			key = strings.ToLower(key)
+			if _, ok := result[key]; key != "" && !ok {
+				result[key] = id
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetTeamIDs returns the IDs of the teams of the caller's organization by lower case name. Of teams
+// sharing a name, the oldest is given.
+func (m *mysqlRepository) GetTeamIDs(ctx context.Context) (map[string]int64, error) {
+	rows, err := m.conn.QueryContext(ctx, `SELECT id,name FROM teams WHERE organization_id = ? ORDER BY id`,
+		domain.OrganizationIDFromContext(ctx))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			log.Error(errRow)
+		}
+	}()
+
+	result := make(map[string]int64)
+	for rows.Next() {
+		var id int64
+		var name string
+		if err = rows.Scan(&id, &name); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if _, ok := result[strings.ToLower(name)]; !ok {
+			result[strings.ToLower(name)] = id
+		}
+	}
+	return result, nil
+}
+
+// AddTeamMembers adds the user to the teams of the caller's organization it is not a member of yet
+func (m *mysqlRepository) AddTeamMembers(ctx context.Context, userID int64, teamIDs []int64, createdAt int64) (err error) {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Error while starting transaction ", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `INSERT INTO teams_users (team_id,user_id,created_at) SELECT t.id,?,? FROM teams t WHERE t.id = ? AND t.organization_id = ?
+		AND NOT EXISTS (SELECT 1 FROM teams_users tu WHERE tu.team_id = t.id AND tu.user_id = ?)`
+	for _, id := range teamIDs {
+		if _, err = tx.ExecContext(ctx, query, userID, createdAt, id, domain.OrganizationIDFromContext(ctx), userID); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,118 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	mysqlrepo "github.com/meroedu/meroedu/internal/userimport/repository/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var orgCtx = domain.WithOrganizationID(context.TODO(), 2)
+
+var columns = []string{"id", "organization_id", "file_name", "status", "total", "processed", "created", "failed", "errors", "permissions",
+	"created_by", "started_at", "finished_at", "updated_at", "created_at"}
+
+func TestGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	query := `SELECT .+ FROM user_imports WHERE id = \? AND organization_id = \?`
+	rows := sqlmock.NewRows(columns).
+		AddRow(4, 2, "users.csv", domain.ImportRunning, 3, 2, 1, 1, `[{"line":2,"email":"a@b.c","error":"email or username is already taken"}]`,
+			"user:manage", 5, 100, nil, 110, 90)
+	mock.ExpectQuery(query).WithArgs(4, 2).WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs(5, 2).WillReturnRows(sqlmock.NewRows(columns))
+
+	repo := mysqlrepo.Init(db)
+	userImport, err := repo.GetByID(orgCtx, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, userImport.Processed)
+	assert.Equal(t, []domain.ImportError{{Line: 2, Email: "a@b.c", Error: "email or username is already taken"}}, userImport.Errors)
+	assert.Equal(t, []domain.Permission{domain.PermUserManage}, userImport.Permissions)
+	assert.Zero(t, userImport.FinishedAt)
+
+	_, err = repo.GetByID(orgCtx, 5)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestClaimPending(t *testing.T) {
+	query := `SELECT .+,pending_rows FROM user_imports WHERE status = \? OR \(status = \? AND updated_at < \?\)\s+ORDER BY id LIMIT 1 FOR UPDATE`
+	update := `UPDATE user_imports SET status=\?,started_at=\?,updated_at=\? WHERE id = \?`
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).WithArgs(domain.ImportPending, domain.ImportRunning, 50).
+			WillReturnRows(sqlmock.NewRows(append(columns, "pending_rows")).
+				AddRow(4, 2, "users.csv", domain.ImportPending, 1, 0, 0, 0, `[]`, "user:manage,enrollment:manage", 5, nil, nil, 90, 90,
+					`[{"line":2,"email":"a@b.c","last_name":"B","role_id":4,"team_ids":[7]}]`))
+		mock.ExpectExec(update).WithArgs(domain.ImportRunning, 100, 100, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := mysqlrepo.Init(db)
+		userImport, err := repo.ClaimPending(context.TODO(), 50, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ImportRunning, userImport.Status)
+		assert.Equal(t, int64(100), userImport.StartedAt)
+		assert.Equal(t, []domain.ImportRow{{Line: 2, Email: "a@b.c", LastName: "B", RoleID: 4, TeamIDs: []int64{7}}}, userImport.Rows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("none", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).WithArgs(domain.ImportPending, domain.ImportRunning, 50).
+			WillReturnRows(sqlmock.NewRows(append(columns, "pending_rows")))
+		mock.ExpectRollback()
+
+		repo := mysqlrepo.Init(db)
+		_, err = repo.ClaimPending(context.TODO(), 50, 100)
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateProgress(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec(`UPDATE user_imports SET status=\?,processed=\?,created=\?,failed=\?,errors=\?,finished_at=\?,updated_at=\? WHERE id = \?`).
+		WithArgs(domain.ImportRunning, 25, 25, 0, `[]`, nil, 100, 4, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE user_imports SET .+,pending_rows=NULL WHERE id = \? AND organization_id = \?`).
+		WithArgs(domain.ImportCompleted, 30, 30, 0, `[]`, 120, 120, 4, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := mysqlrepo.Init(db)
+	userImport := &domain.UserImport{ID: 4, Status: domain.ImportRunning, Processed: 25, Created: 25, Errors: []domain.ImportError{}, UpdatedAt: 100}
+	assert.NoError(t, repo.UpdateProgress(orgCtx, userImport))
+	userImport.Status, userImport.Processed, userImport.Created, userImport.FinishedAt, userImport.UpdatedAt = domain.ImportCompleted, 30, 30, 120, 120
+	assert.NoError(t, repo.UpdateProgress(orgCtx, userImport), "the rows are dropped once completed")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetExistingEmails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery(`SELECT email FROM users WHERE email IN \(\?,\?\)`).WithArgs("Sita@School.local", "ram@school.local").
+		WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("sita@school.local"))
+
+	repo := mysqlrepo.Init(db)
+	taken, err := repo.GetExistingEmails(orgCtx, []string{"Sita@School.local", "ram@school.local"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"sita@school.local": true}, taken)
+
+	taken, err = repo.GetExistingEmails(orgCtx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, taken)
+}
@@ -0,0 +1,487 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/mail"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/pkg/log"
+	"github.com/meroedu/meroedu/pkg/xlsx"
+)
+
+const (
+	// maxRows is the number of users a file can import
+	maxRows = 5000
+	// progressEvery is the number of rows imported between two saves of the progress
+	progressEvery = 25
+	// staleAfter is how long a running import can go without progress before it is taken as interrupted and resumed
+	staleAfter = 10 * time.Minute
+	// listSeparator separates the teams and the courses of a cell
+	listSeparator = ";"
+)
+
+// Columns of an import file. The header row names them, in any order; only email and last_name are required.
+const (
+	columnEmail     = "email"
+	columnFirstName = "first_name"
+	columnLastName  = "last_name"
+	columnUsername  = "username"
+	columnPhone     = "phone"
+	columnCountry   = "country"
+	columnRole      = "role"
+	columnTeams     = "teams"
+	columnCourses   = "courses"
+)
+
+var columns = []string{columnEmail, columnFirstName, columnLastName, columnUsername, columnPhone, columnCountry, columnRole,
+	columnTeams, columnCourses}
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// UserImportUseCase ...
+type UserImportUseCase struct {
+	importRepo        domain.UserImportRepository
+	roleRepo          domain.RoleRepository
+	courseRepo        domain.CourseRepository
+	userUseCase       domain.UserUseCase
+	enrollmentUseCase domain.EnrollmentUseCase
+	contextTimeOut    time.Duration
+}
+
+// NewUserImportUseCase will create new an UserImportUseCase. The users are created through the UserUseCase,
+// so they get the same checks and verification emails as users created one by one.
+func NewUserImportUseCase(i domain.UserImportRepository, r domain.RoleRepository, c domain.CourseRepository, u domain.UserUseCase,
+	e domain.EnrollmentUseCase, timeout time.Duration) domain.UserImportUseCase {
+	return &UserImportUseCase{
+		importRepo:        i,
+		roleRepo:          r,
+		courseRepo:        c,
+		userUseCase:       u,
+		enrollmentUseCase: e,
+		contextTimeOut:    timeout,
+	}
+}
+
+// GetAll ...
+func (usecase *UserImportUseCase) GetAll(c context.Context, start int, limit int) ([]domain.UserImport, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.importRepo.GetAll(ctx, start, limit)
+}
+
+// GetByID ...
+func (usecase *UserImportUseCase) GetByID(c context.Context, id int64) (*domain.UserImport, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	return usecase.importRepo.GetByID(ctx, id)
+}
+
+// ImportUsers validates every row of a CSV or XLSX file and, unless it is a dry run or a row is invalid, queues
+// the import of its users in the caller's organization. The report lists the errors by row, the header being row 1.
+// An unreadable file, an unknown column or too many rows give ErrBadParamInput.
+func (usecase *UserImportUseCase) ImportUsers(c context.Context, fileName string, file io.Reader, dryRun bool) (*domain.ImportReport, error) {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	records, err := readRecords(fileName, file)
+	if err != nil {
+		return nil, err
+	}
+	report, rows, err := usecase.validate(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+	if len(rows) == 0 {
+		return nil, domain.ErrBadParamInput
+	}
+
+	now := time.Now().Unix()
+	fileName = path.Base(fileName)
+	if len(fileName) > 255 {
+		fileName = fileName[len(fileName)-255:]
+	}
+	report.Import = &domain.UserImport{
+		FileName:    fileName,
+		Status:      domain.ImportPending,
+		Total:       len(rows),
+		Errors:      make([]domain.ImportError, 0),
+		Rows:        rows,
+		Permissions: domain.PermissionsFromContext(ctx),
+		CreatedBy:   domain.UserIDFromContext(ctx),
+		UpdatedAt:   now,
+		CreatedAt:   now,
+	}
+	if err = usecase.importRepo.CreateImport(ctx, report.Import); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// readRecords returns the rows of the file, which is read as CSV or XLSX after its extension
+func readRecords(fileName string, file io.Reader) ([][]string, error) {
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv":
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, domain.ErrBadParamInput
+		}
+		return records, nil
+	case ".xlsx":
+		// the header and maxRows users, so a sheet far past the limit is not read in full
+		records, err := xlsx.Read(bytes.NewReader(data), int64(len(data)), maxRows+1)
+		if err != nil {
+			return nil, domain.ErrBadParamInput
+		}
+		return records, nil
+	default:
+		return nil, domain.ErrBadParamInput
+	}
+}
+
+// validate turns the records into the rows to import, checking them against the users schema, the users
+// already there and the countries, roles, teams and courses of the organization
+func (usecase *UserImportUseCase) validate(ctx context.Context, records [][]string) (*domain.ImportReport, []domain.ImportRow, error) {
+	if len(records) == 0 {
+		return nil, nil, domain.ErrBadParamInput
+	}
+	header := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, c := range columns {
+			known = known || c == name
+		}
+		if _, dup := header[name]; !known || dup {
+			return nil, nil, domain.ErrBadParamInput
+		}
+		header[name] = i
+	}
+	if _, ok := header[columnEmail]; !ok {
+		return nil, nil, domain.ErrBadParamInput
+	}
+	if _, ok := header[columnLastName]; !ok {
+		return nil, nil, domain.ErrBadParamInput
+	}
+
+	type record struct {
+		line   int
+		values []string
+	}
+	data := make([]record, 0, len(records)-1)
+	for i, values := range records[1:] {
+		if strings.TrimSpace(strings.Join(values, "")) != "" {
+			data = append(data, record{line: i + 2, values: values})
+		}
+	}
+	if len(data) > maxRows {
+		return nil, nil, domain.ErrBadParamInput
+	}
+
+	report := &domain.ImportReport{Total: len(data), Errors: make([]domain.ImportError, 0)}
+	cells := make([]map[string]string, len(data))
+	emails := make([]string, 0, len(data))
+	usernames := make([]string, 0)
+	for i, r := range data {
+		cells[i] = make(map[string]string)
+		for name, index := range header {
+			if index < len(r.values) {
+				cells[i][name] = strings.TrimSpace(r.values[index])
+			}
+		}
+		emails = append(emails, cells[i][columnEmail])
+		if cells[i][columnUsername] != "" {
+			usernames = append(usernames, cells[i][columnUsername])
+		}
+	}
+	takenEmails, err := usecase.importRepo.GetExistingEmails(ctx, emails)
+	if err != nil {
+		return nil, nil, err
+	}
+	takenUsernames, err := usecase.importRepo.GetExistingUsernames(ctx, usernames)
+	if err != nil {
+		return nil, nil, err
+	}
+	countries, err := usecase.importRepo.GetCountryIDs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	teams, err := usecase.importRepo.GetTeamIDs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	roles := make(map[string]int64)
+	roleErrors := make(map[string]string)
+	courseErrors := make(map[int64]string)
+	seenEmails := make(map[string]int)
+	seenUsernames := make(map[string]int)
+
+	rows := make([]domain.ImportRow, 0, len(data))
+	for i, r := range data {
+		cell := cells[i]
+		row := domain.ImportRow{
+			Line:      r.line,
+			Email:     cell[columnEmail],
+			FirstName: cell[columnFirstName],
+			LastName:  cell[columnLastName],
+			Username:  cell[columnUsername],
+			Phone:     cell[columnPhone],
+		}
+		errs := make([]string, 0)
+		fail := func(format string, args ...interface{}) {
+			errs = append(errs, fmt.Sprintf(format, args...))
+		}
+
+		email := strings.ToLower(row.Email)
+		if row.Email == "" {
+			fail("email is required")
+		} else if addr, err := mail.ParseAddress(row.Email); err != nil || addr.Address != row.Email || len(row.Email) > 255 {
+			fail("email %q is not valid", row.Email)
+		} else if line, ok := seenEmails[email]; ok {
+			fail("email %s is already in row %d", row.Email, line)
+		} else if takenEmails[email] {
+			fail("email %s is already taken", row.Email)
+		}
+		seenEmails[email] = r.line
+		if row.LastName == "" {
+			fail("last_name is required")
+		}
+		if utf8.RuneCountInString(row.LastName) > 100 || utf8.RuneCountInString(row.FirstName) > 100 {
+			fail("names are limited to 100 characters")
+		}
+		if row.Username != "" {
+			username := strings.ToLower(row.Username)
+			if len(row.Username) > 255 {
+				fail("username is limited to 255 characters")
+			} else if line, ok := seenUsernames[username]; ok {
+				fail("username %s is already in row %d", row.Username, line)
+			} else if takenUsernames[username] {
+				fail("username %s is already taken", row.Username)
+			}
+			seenUsernames[username] = r.line
+		}
+		if len(row.Phone) > 50 {
+			fail("phone is limited to 50 characters")
+		}
+		if country := cell[columnCountry]; country != "" {
+			if row.CountryID = countries[strings.ToLower(country)]; row.CountryID == 0 {
+				fail("country %s is unknown", country)
+			}
+		}
+
+		code := cell[columnRole]
+		if code == "" {
+			code = domain.RoleLearner
+		}
+		if _, ok := roles[code]; !ok {
+			if roles[code], roleErrors[code], err = usecase.checkRole(ctx, code); err != nil {
+				return nil, nil, err
+			}
+		}
+		row.RoleID = roles[code]
+		if roleErrors[code] != "" {
+			errs = append(errs, roleErrors[code])
+		}
+
+		for _, name := range split(cell[columnTeams]) {
+			id, ok := teams[strings.ToLower(name)]
+			if !ok {
+				fail("team %s is unknown", name)
+				continue
+			}
+			row.TeamIDs = appendUnique(row.TeamIDs, id)
+		}
+
+		courses := split(cell[columnCourses])
+		if len(courses) > 0 && !domain.HasPermission(ctx, domain.PermEnrollmentManage) {
+			fail("you are not allowed to manage enrollments")
+			courses = nil
+		}
+		for _, value := range courses {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || id <= 0 {
+				fail("course %s is not a course ID", value)
+				continue
+			}
+			if _, ok := courseErrors[id]; !ok {
+				if courseErrors[id], err = usecase.checkCourse(ctx, id); err != nil {
+					return nil, nil, err
+				}
+			}
+			if courseErrors[id] != "" {
+				errs = append(errs, courseErrors[id])
+				continue
+			}
+			row.CourseIDs = appendUnique(row.CourseIDs, id)
+		}
+
+		for _, e := range errs {
+			report.Errors = append(report.Errors, domain.ImportError{Line: row.Line, Email: row.Email, Error: e})
+		}
+		if len(errs) == 0 {
+			rows = append(rows, row)
+		}
+	}
+	report.Valid = len(rows)
+	return report, rows, nil
+}
+
+// checkRole returns the ID of the role with the code, or why users of the file can not be given it. Like for a
+// user created alone, the importer must hold every permission the role grants.
+func (usecase *UserImportUseCase) checkRole(ctx context.Context, code string) (int64, string, error) {
+	role, err := usecase.roleRepo.GetByCode(ctx, code)
+	if err == domain.ErrNotFound {
+		return 0, fmt.Sprintf("role %s is unknown", code), nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	if !domain.HasPermissions(ctx, role.Permissions) {
+		return 0, fmt.Sprintf("you are not allowed to grant role %s", code), nil
+	}
+	return role.ID, "", nil
+}
+
+// checkCourse returns why users can not be enrolled in the course, if they can not
+func (usecase *UserImportUseCase) checkCourse(ctx context.Context, id int64) (string, error) {
+	_, err := usecase.courseRepo.GetLatestVersion(ctx, id)
+	if err == domain.ErrNotFound {
+		return fmt.Sprintf("course %d is not published", id), nil
+	}
+	return "", err
+}
+
+func split(cell string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(cell, listSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func appendUnique(ids []int64, id int64) []int64 {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
+
+// RunPending runs the queued imports of every organization one after the other, and resumes those which were
+// interrupted. It returns when no import is left, or when ctx is done.
+func (usecase *UserImportUseCase) RunPending(c context.Context) error {
+	for {
+		now := time.Now()
+		ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+		userImport, err := usecase.importRepo.ClaimPending(ctx, now.Add(-staleAfter).Unix(), now.Unix())
+		cancel()
+		if err == domain.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = usecase.run(c, userImport); err != nil {
+			return err
+		}
+	}
+}
+
+// run imports the rows of the import not processed yet, on behalf of the caller who started it
+func (usecase *UserImportUseCase) run(c context.Context, userImport *domain.UserImport) error {
+	ctx := domain.WithOrganizationID(c, userImport.OrganizationID)
+	ctx = domain.WithUserID(ctx, userImport.CreatedBy)
+	ctx = domain.WithPermissions(ctx, userImport.Permissions)
+	for userImport.Processed < len(userImport.Rows) {
+		if err := ctx.Err(); err != nil {
+			// the import stays running and is resumed once stale
+			return err
+		}
+		usecase.importRow(ctx, userImport, &userImport.Rows[userImport.Processed])
+		userImport.Processed++
+		if userImport.Processed%progressEvery == 0 {
+			if err := usecase.save(ctx, userImport); err != nil {
+				return err
+			}
+		}
+	}
+	userImport.Status = domain.ImportCompleted
+	userImport.FinishedAt = time.Now().Unix()
+	if err := usecase.save(ctx, userImport); err != nil {
+		return err
+	}
+	log.Infof("Imported %d of %d users of import %d", userImport.Created, userImport.Total, userImport.ID)
+	return nil
+}
+
+// importRow creates the user of the row, then adds it to its teams and enrolls it in its courses. A failure to
+// create the user fails the row; later failures are reported for the row, but the user stays.
+func (usecase *UserImportUseCase) importRow(ctx context.Context, userImport *domain.UserImport, row *domain.ImportRow) {
+	report := func(format string, args ...interface{}) {
+		userImport.Errors = append(userImport.Errors, domain.ImportError{Line: row.Line, Email: row.Email, Error: fmt.Sprintf(format, args...)})
+	}
+	user := &domain.User{
+		FirstName: row.FirstName,
+		LastName:  row.LastName,
+		Email:     row.Email,
+		Username:  row.Username,
+		Phone:     row.Phone,
+		CountryID: row.CountryID,
+		RoleID:    row.RoleID,
+	}
+	if err := usecase.userUseCase.CreateUser(ctx, user); err != nil {
+		userImport.Failed++
+		if err == domain.ErrConflict {
+			report("email or username is already taken")
+		} else {
+			report("user could not be created: %v", err)
+		}
+		return
+	}
+	userImport.Created++
+
+	if len(row.TeamIDs) > 0 {
+		teamCtx, cancel := context.WithTimeout(ctx, usecase.contextTimeOut)
+		err := usecase.importRepo.AddTeamMembers(teamCtx, user.ID, row.TeamIDs, time.Now().Unix())
+		cancel()
+		if err != nil {
+			report("user could not be added to its teams: %v", err)
+		}
+	}
+	for _, courseID := range row.CourseIDs {
+		if err := usecase.enrollmentUseCase.EnrollUser(ctx, &domain.Enrollment{CourseID: courseID, UserID: user.ID}); err != nil {
+			report("user could not be enrolled in course %d: %v", courseID, err)
+		}
+	}
+}
+
+func (usecase *UserImportUseCase) save(c context.Context, userImport *domain.UserImport) error {
+	ctx, cancel := context.WithTimeout(c, usecase.contextTimeOut)
+	defer cancel()
+	userImport.UpdatedAt = time.Now().Unix()
+	return usecase.importRepo.UpdateProgress(ctx, userImport)
+}
@@ -0,0 +1,195 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/meroedu/meroedu/internal/domain"
+	"github.com/meroedu/meroedu/internal/domain/mocks"
+	ucase "github.com/meroedu/meroedu/internal/userimport/usecase"
+)
+
+var adminCtx = domain.WithPermissions(domain.WithUserID(domain.WithOrganizationID(context.TODO(), 2), 5),
+	[]domain.Permission{domain.PermUserManage, domain.PermEnrollmentManage})
+
+// lookups stubs the countries, teams, roles and courses of the organization
+func lookups(mockUserImportRepo *mocks.UserImportRepository, mockRoleRepo *mocks.RoleRepository, mockCourseRepo *mocks.CourseRepository,
+	takenEmails map[string]bool) {
+	mockUserImportRepo.On("GetExistingEmails", mock.Anything, mock.Anything).Return(takenEmails, nil).Once()
+	mockUserImportRepo.On("GetExistingUsernames", mock.Anything, mock.Anything).Return(map[string]bool{}, nil).Once()
+	mockUserImportRepo.On("GetCountryIDs", mock.Anything).Return(map[string]int64{"np": 149, "nepal": 149}, nil).Once()
+	mockUserImportRepo.On("GetTeamIDs", mock.Anything).Return(map[string]int64{"grade 5": 7, "teachers": 8}, nil).Once()
+	mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleLearner).Return(&domain.Role{ID: 4, Code: domain.RoleLearner}, nil)
+	mockRoleRepo.On("GetByCode", mock.Anything, domain.RoleSuperAdmin).
+		Return(&domain.Role{ID: 1, Code: domain.RoleSuperAdmin, Permissions: []domain.Permission{domain.PermOrganizationManage}}, nil)
+	mockRoleRepo.On("GetByCode", mock.Anything, "manager").
+		Return(&domain.Role{ID: 6, Code: "manager", Permissions: []domain.Permission{domain.PermUserManage, domain.PermRoleManage}}, nil)
+	mockRoleRepo.On("GetByCode", mock.Anything, "janitor").Return(nil, domain.ErrNotFound)
+	mockCourseRepo.On("GetLatestVersion", mock.Anything, int64(3)).Return(&domain.CourseVersion{ID: 30}, nil)
+	mockCourseRepo.On("GetLatestVersion", mock.Anything, int64(9)).Return(nil, domain.ErrNotFound)
+}
+
+func TestImportUsers(t *testing.T) {
+	t.Run("dry-run", func(t *testing.T) {
+		mockUserImportRepo := new(mocks.UserImportRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockUserUseCase := new(mocks.UserUseCase)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewUserImportUseCase(mockUserImportRepo, mockRoleRepo, mockCourseRepo, mockUserUseCase, mockEnrollmentUseCase,
+			time.Second*2)
+		lookups(mockUserImportRepo, mockRoleRepo, mockCourseRepo, map[string]bool{"ram@school.local": true})
+		file := "\xef\xbb\xbfEmail,First Name,last_name,country,role,teams,courses\n" +
+			"sita@school.local,Sita,Sharma,NP,,Grade 5; teachers,3\n" +
+			"\n" + // blank lines are skipped by the CSV reader and not numbered
+			"SITA@school.local,Sita,Rai,,,,\n" +
+			"ram@school.local,Ram,Thapa,Narnia,janitor,,\n" +
+			"hari@school.local,Hari,,,superadmin,Grade 6,9;x\n"
+
+		report, err := u.ImportUsers(adminCtx, "users.csv", strings.NewReader(file), true)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, report.Total)
+		assert.Equal(t, 1, report.Valid)
+		assert.Nil(t, report.Import)
+		assert.Equal(t, []domain.ImportError{
+			{Line: 3, Email: "SITA@school.local", Error: "email SITA@school.local is already in row 2"},
+			{Line: 4, Email: "ram@school.local", Error: "email ram@school.local is already taken"},
+			{Line: 4, Email: "ram@school.local", Error: "country Narnia is unknown"},
+			{Line: 4, Email: "ram@school.local", Error: "role janitor is unknown"},
+			{Line: 5, Email: "hari@school.local", Error: "last_name is required"},
+			{Line: 5, Email: "hari@school.local", Error: "you are not allowed to grant role superadmin"},
+			{Line: 5, Email: "hari@school.local", Error: "team Grade 6 is unknown"},
+			{Line: 5, Email: "hari@school.local", Error: "course 9 is not published"},
+			{Line: 5, Email: "hari@school.local", Error: "course x is not a course ID"},
+		}, report.Errors)
+		mockUserImportRepo.AssertNotCalled(t, "CreateImport", mock.Anything, mock.Anything)
+	})
+	t.Run("role-above-importer", func(t *testing.T) {
+		mockUserImportRepo := new(mocks.UserImportRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockUserUseCase := new(mocks.UserUseCase)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewUserImportUseCase(mockUserImportRepo, mockRoleRepo, mockCourseRepo, mockUserUseCase, mockEnrollmentUseCase,
+			time.Second*2)
+		lookups(mockUserImportRepo, mockRoleRepo, mockCourseRepo, map[string]bool{})
+		file := "email,last_name,role\nsita@school.local,Sharma,manager\n"
+
+		report, err := u.ImportUsers(adminCtx, "users.csv", strings.NewReader(file), false)
+		assert.NoError(t, err)
+		assert.Zero(t, report.Valid)
+		assert.Equal(t, []domain.ImportError{
+			{Line: 2, Email: "sita@school.local", Error: "you are not allowed to grant role manager"},
+		}, report.Errors)
+		mockUserImportRepo.AssertNotCalled(t, "CreateImport", mock.Anything, mock.Anything)
+	})
+	t.Run("queued", func(t *testing.T) {
+		mockUserImportRepo := new(mocks.UserImportRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockUserUseCase := new(mocks.UserUseCase)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewUserImportUseCase(mockUserImportRepo, mockRoleRepo, mockCourseRepo, mockUserUseCase, mockEnrollmentUseCase,
+			time.Second*2)
+		lookups(mockUserImportRepo, mockRoleRepo, mockCourseRepo, map[string]bool{})
+		var queued *domain.UserImport
+		mockUserImportRepo.On("CreateImport", mock.Anything, mock.AnythingOfType("*domain.UserImport")).Return(nil).
+			Run(func(args mock.Arguments) { queued = args.Get(1).(*domain.UserImport) }).Once()
+		file := "email,last_name,teams,courses\nsita@school.local,Sharma,Grade 5,3\nram@school.local,Thapa,,\n"
+
+		report, err := u.ImportUsers(adminCtx, "/tmp/users.CSV", strings.NewReader(file), false)
+		assert.NoError(t, err)
+		assert.Empty(t, report.Errors)
+		assert.Equal(t, queued, report.Import)
+		assert.Equal(t, "users.CSV", queued.FileName)
+		assert.Equal(t, domain.ImportPending, queued.Status)
+		assert.Equal(t, int64(5), queued.CreatedBy)
+		assert.Equal(t, []domain.Permission{domain.PermUserManage, domain.PermEnrollmentManage}, queued.Permissions)
+		assert.Equal(t, []domain.ImportRow{
+			{Line: 2, Email: "sita@school.local", LastName: "Sharma", RoleID: 4, TeamIDs: []int64{7}, CourseIDs: []int64{3}},
+			{Line: 3, Email: "ram@school.local", LastName: "Thapa", RoleID: 4},
+		}, queued.Rows)
+	})
+	t.Run("enrollments-not-allowed", func(t *testing.T) {
+		mockUserImportRepo := new(mocks.UserImportRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockUserUseCase := new(mocks.UserUseCase)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewUserImportUseCase(mockUserImportRepo, mockRoleRepo, mockCourseRepo, mockUserUseCase, mockEnrollmentUseCase,
+			time.Second*2)
+		lookups(mockUserImportRepo, mockRoleRepo, mockCourseRepo, map[string]bool{})
+		ctx := domain.WithPermissions(adminCtx, []domain.Permission{domain.PermUserManage})
+
+		report, err := u.ImportUsers(ctx, "users.csv", strings.NewReader("email,last_name,courses\nsita@school.local,Sharma,3\n"), false)
+		assert.NoError(t, err)
+		assert.Nil(t, report.Import)
+		assert.Equal(t, "you are not allowed to manage enrollments", report.Errors[0].Error)
+	})
+	t.Run("invalid-file", func(t *testing.T) {
+		mockUserImportRepo := new(mocks.UserImportRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockCourseRepo := new(mocks.CourseRepository)
+		mockUserUseCase := new(mocks.UserUseCase)
+		mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+		u := ucase.NewUserImportUseCase(mockUserImportRepo, mockRoleRepo, mockCourseRepo, mockUserUseCase, mockEnrollmentUseCase,
+			time.Second*2)
+		for name, file := range map[string]string{
+			"users.csv":  "email,last_name,password\nsita@school.local,Sharma,secret\n",
+			"users2.csv": "email,first_name\nsita@school.local,Sita\n",
+			"users.xlsx": "email,last_name\n",
+			"users.txt":  "email,last_name\n",
+		} {
+			_, err := u.ImportUsers(adminCtx, name, strings.NewReader(file), true)
+			assert.Equal(t, domain.ErrBadParamInput, err, name)
+		}
+	})
+}
+
+func TestRunPending(t *testing.T) {
+	mockUserImportRepo := new(mocks.UserImportRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	mockCourseRepo := new(mocks.CourseRepository)
+	mockUserUseCase := new(mocks.UserUseCase)
+	mockEnrollmentUseCase := new(mocks.EnrollmentUseCase)
+	u := ucase.NewUserImportUseCase(mockUserImportRepo, mockRoleRepo, mockCourseRepo, mockUserUseCase, mockEnrollmentUseCase,
+		time.Second*2)
+	userImport := &domain.UserImport{ID: 4, OrganizationID: 2, Status: domain.ImportRunning, Total: 2, Errors: []domain.ImportError{},
+		CreatedBy: 5, Permissions: []domain.Permission{domain.PermUserManage}, Rows: []domain.ImportRow{
+			{Line: 2, Email: "sita@school.local", LastName: "Sharma", RoleID: 4, TeamIDs: []int64{7}, CourseIDs: []int64{3}},
+			{Line: 3, Email: "ram@school.local", LastName: "Thapa", RoleID: 4},
+		}}
+	mockUserImportRepo.On("ClaimPending", mock.Anything, mock.AnythingOfType("int64"), mock.AnythingOfType("int64")).Return(userImport, nil).Once()
+	mockUserImportRepo.On("ClaimPending", mock.Anything, mock.AnythingOfType("int64"), mock.AnythingOfType("int64")).Return(nil, domain.ErrNotFound).Once()
+	mockUserUseCase.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool { return u.Email == "sita@school.local" })).
+		Return(nil).Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		assert.Equal(t, int64(2), domain.OrganizationIDFromContext(ctx))
+		assert.True(t, domain.HasPermission(ctx, domain.PermUserManage), "users are created on behalf of the caller who started the import")
+		args.Get(1).(*domain.User).ID = 11
+	}).Once()
+	mockUserUseCase.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool { return u.Email == "ram@school.local" })).
+		Return(domain.ErrConflict).Once()
+	mockUserImportRepo.On("AddTeamMembers", mock.Anything, int64(11), []int64{7}, mock.AnythingOfType("int64")).Return(nil).Once()
+	mockEnrollmentUseCase.On("EnrollUser", mock.Anything, &domain.Enrollment{CourseID: 3, UserID: 11}).Return(domain.ErrCourseNotPublished).Once()
+	mockUserImportRepo.On("UpdateProgress", mock.Anything, userImport).Return(nil).Once()
+
+	err := u.RunPending(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImportCompleted, userImport.Status)
+	assert.Equal(t, 2, userImport.Processed)
+	assert.Equal(t, 1, userImport.Created)
+	assert.Equal(t, 1, userImport.Failed)
+	assert.NotZero(t, userImport.FinishedAt)
+	assert.Equal(t, []domain.ImportError{
+		{Line: 2, Email: "sita@school.local", Error: "user could not be enrolled in course 3: " + domain.ErrCourseNotPublished.Error()},
+		{Line: 3, Email: "ram@school.local", Error: "email or username is already taken"},
+	}, userImport.Errors)
+	mockUserImportRepo.AssertExpectations(t)
+	mockEnrollmentUseCase.AssertExpectations(t)
+}
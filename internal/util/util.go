@@ -29,10 +29,18 @@ func GetStatusCode(err error) int {
 		return http.StatusInternalServerError
 	case domain.ErrNotFound:
 		return http.StatusNotFound
-	case domain.ErrConflict:
+	case domain.ErrConflict, domain.ErrNoAttemptsLeft, domain.ErrTimeLimitExceeded, domain.ErrSubmissionClosed:
 		return http.StatusConflict
-	case domain.ErrBadParamInput:
+	case domain.ErrBadParamInput, domain.ErrCourseNotPublished:
 		return http.StatusBadRequest
+	case domain.ErrUnauthorized, domain.ErrInvalidCredentials:
+		return http.StatusUnauthorized
+	case domain.ErrForbidden:
+		return http.StatusForbidden
+	case domain.ErrAccountLocked:
+		return http.StatusLocked
+	case domain.ErrTooManyRequests:
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
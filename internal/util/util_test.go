@@ -27,9 +27,36 @@ func TestGetStatusCode(t *testing.T) {
 	response = util.GetStatusCode(domain.ErrConflict)
 	assert.Equal(t, response, http.StatusConflict)
 
+	response = util.GetStatusCode(domain.ErrNoAttemptsLeft)
+	assert.Equal(t, response, http.StatusConflict)
+
+	response = util.GetStatusCode(domain.ErrTimeLimitExceeded)
+	assert.Equal(t, response, http.StatusConflict)
+
+	response = util.GetStatusCode(domain.ErrSubmissionClosed)
+	assert.Equal(t, response, http.StatusConflict)
+
 	response = util.GetStatusCode(domain.ErrBadParamInput)
 	assert.Equal(t, response, http.StatusBadRequest)
 
+	response = util.GetStatusCode(domain.ErrCourseNotPublished)
+	assert.Equal(t, response, http.StatusBadRequest)
+
+	response = util.GetStatusCode(domain.ErrUnauthorized)
+	assert.Equal(t, response, http.StatusUnauthorized)
+
+	response = util.GetStatusCode(domain.ErrInvalidCredentials)
+	assert.Equal(t, response, http.StatusUnauthorized)
+
+	response = util.GetStatusCode(domain.ErrForbidden)
+	assert.Equal(t, response, http.StatusForbidden)
+
+	response = util.GetStatusCode(domain.ErrAccountLocked)
+	assert.Equal(t, response, http.StatusLocked)
+
+	response = util.GetStatusCode(domain.ErrTooManyRequests)
+	assert.Equal(t, response, http.StatusTooManyRequests)
+
 	response = util.GetStatusCode(errors.New("unknown"))
 	assert.Equal(t, response, http.StatusInternalServerError)
 
@@ -14,13 +14,29 @@ import (
 	"gopkg.in/alecthomas/kingpin.v2"
 
 	_ "github.com/meroedu/meroedu/docs"
+	_accountHttpDelivery "github.com/meroedu/meroedu/internal/account/delivery/http"
+	_accountRepo "github.com/meroedu/meroedu/internal/account/repository/mysql"
+	_accountUcase "github.com/meroedu/meroedu/internal/account/usecase"
+	_apiKeyHttpDelivery "github.com/meroedu/meroedu/internal/apikey/delivery/http"
+	_apiKeyRepo "github.com/meroedu/meroedu/internal/apikey/repository/mysql"
+	_apiKeyUcase "github.com/meroedu/meroedu/internal/apikey/usecase"
+	_assignmentHttpDelivery "github.com/meroedu/meroedu/internal/assignment/delivery/http"
+	_assignmentRepo "github.com/meroedu/meroedu/internal/assignment/repository/mysql"
+	_assignmentUcase "github.com/meroedu/meroedu/internal/assignment/usecase"
 	_attachmentHttpDelivery "github.com/meroedu/meroedu/internal/attachment/delivery/http"
 	_attachmentRepo "github.com/meroedu/meroedu/internal/attachment/repository/mysql"
 	_attachmentStore "github.com/meroedu/meroedu/internal/attachment/storage/filesystem"
 	_attachmentUcase "github.com/meroedu/meroedu/internal/attachment/usecase"
+	_authHttpDelivery "github.com/meroedu/meroedu/internal/auth/delivery/http"
+	_authHttpDeliveryMiddleware "github.com/meroedu/meroedu/internal/auth/delivery/http/middleware"
+	_authRepo "github.com/meroedu/meroedu/internal/auth/repository/mysql"
+	_authUcase "github.com/meroedu/meroedu/internal/auth/usecase"
 	_categoryHttpDelivery "github.com/meroedu/meroedu/internal/category/delivery/http"
 	_categoryRepo "github.com/meroedu/meroedu/internal/category/repository/mysql"
 	_categoryUcase "github.com/meroedu/meroedu/internal/category/usecase"
+	_collaboratorHttpDelivery "github.com/meroedu/meroedu/internal/collaborator/delivery/http"
+	_collaboratorRepo "github.com/meroedu/meroedu/internal/collaborator/repository/mysql"
+	_collaboratorUcase "github.com/meroedu/meroedu/internal/collaborator/usecase"
 	_contentHttpDelivery "github.com/meroedu/meroedu/internal/content/delivery/http"
 	_contentRepo "github.com/meroedu/meroedu/internal/content/repository/mysql"
 	_contentStore "github.com/meroedu/meroedu/internal/content/storage/filesystem"
@@ -29,16 +45,71 @@ import (
 	_courseHttpDeliveryMiddleware "github.com/meroedu/meroedu/internal/course/delivery/http/middleware"
 	_courseRepo "github.com/meroedu/meroedu/internal/course/repository/mysql"
 	_courseUcase "github.com/meroedu/meroedu/internal/course/usecase"
+	_enrollmentHttpDelivery "github.com/meroedu/meroedu/internal/enrollment/delivery/http"
+	_enrollmentRepo "github.com/meroedu/meroedu/internal/enrollment/repository/mysql"
+	_enrollmentUcase "github.com/meroedu/meroedu/internal/enrollment/usecase"
 	_healthHttpDelivery "github.com/meroedu/meroedu/internal/health/delivery/http"
+	_invitationHttpDelivery "github.com/meroedu/meroedu/internal/invitation/delivery/http"
+	_invitationRepo "github.com/meroedu/meroedu/internal/invitation/repository/mysql"
+	_invitationUcase "github.com/meroedu/meroedu/internal/invitation/usecase"
+	"github.com/meroedu/meroedu/internal/ldap"
+	_ldapClient "github.com/meroedu/meroedu/internal/ldap/client/ldap"
+	_ldapHttpDelivery "github.com/meroedu/meroedu/internal/ldap/delivery/http"
+	_ldapRepo "github.com/meroedu/meroedu/internal/ldap/repository/mysql"
+	_ldapUcase "github.com/meroedu/meroedu/internal/ldap/usecase"
 	_lessonHttpDelivery "github.com/meroedu/meroedu/internal/lesson/delivery/http"
 	_lessonRepo "github.com/meroedu/meroedu/internal/lesson/repository/mysql"
 	_lessonUcase "github.com/meroedu/meroedu/internal/lesson/usecase"
+	_logMailer "github.com/meroedu/meroedu/internal/mail/logger"
+	_smtpMailer "github.com/meroedu/meroedu/internal/mail/smtp"
+	_oidcClient "github.com/meroedu/meroedu/internal/oidc/client/http"
+	_oidcHttpDelivery "github.com/meroedu/meroedu/internal/oidc/delivery/http"
+	_oidcRepo "github.com/meroedu/meroedu/internal/oidc/repository/mysql"
+	_oidcUcase "github.com/meroedu/meroedu/internal/oidc/usecase"
+	_organizationHttpDelivery "github.com/meroedu/meroedu/internal/organization/delivery/http"
+	_organizationRepo "github.com/meroedu/meroedu/internal/organization/repository/mysql"
+	_organizationUcase "github.com/meroedu/meroedu/internal/organization/usecase"
+	"github.com/meroedu/meroedu/internal/peerreview"
+	_peerReviewHttpDelivery "github.com/meroedu/meroedu/internal/peerreview/delivery/http"
+	_peerReviewRepo "github.com/meroedu/meroedu/internal/peerreview/repository/mysql"
+	_peerReviewUcase "github.com/meroedu/meroedu/internal/peerreview/usecase"
+	_privacyHttpDelivery "github.com/meroedu/meroedu/internal/privacy/delivery/http"
+	_privacyRepo "github.com/meroedu/meroedu/internal/privacy/repository/mysql"
+	_privacyUcase "github.com/meroedu/meroedu/internal/privacy/usecase"
+	_quizHttpDelivery "github.com/meroedu/meroedu/internal/quiz/delivery/http"
+	_quizRepo "github.com/meroedu/meroedu/internal/quiz/repository/mysql"
+	_quizUcase "github.com/meroedu/meroedu/internal/quiz/usecase"
+	_roleHttpDelivery "github.com/meroedu/meroedu/internal/role/delivery/http"
+	_roleRepo "github.com/meroedu/meroedu/internal/role/repository/mysql"
+	_roleUcase "github.com/meroedu/meroedu/internal/role/usecase"
+	_rubricHttpDelivery "github.com/meroedu/meroedu/internal/rubric/delivery/http"
+	_rubricRepo "github.com/meroedu/meroedu/internal/rubric/repository/mysql"
+	_rubricUcase "github.com/meroedu/meroedu/internal/rubric/usecase"
+	"github.com/meroedu/meroedu/internal/session"
+	_sessionHttpDelivery "github.com/meroedu/meroedu/internal/session/delivery/http"
+	_sessionRepo "github.com/meroedu/meroedu/internal/session/repository/mysql"
+	_sessionUcase "github.com/meroedu/meroedu/internal/session/usecase"
 	_tagHttpDelivery "github.com/meroedu/meroedu/internal/tag/delivery/http"
 	_tagRepo "github.com/meroedu/meroedu/internal/tag/repository/mysql"
 	_tagUcase "github.com/meroedu/meroedu/internal/tag/usecase"
+	_teamHttpDelivery "github.com/meroedu/meroedu/internal/team/delivery/http"
+	_teamRepo "github.com/meroedu/meroedu/internal/team/repository/mysql"
+	_teamUcase "github.com/meroedu/meroedu/internal/team/usecase"
+	"github.com/meroedu/meroedu/internal/trash"
+	_twoFactorHttpDelivery "github.com/meroedu/meroedu/internal/twofactor/delivery/http"
+	_twoFactorRepo "github.com/meroedu/meroedu/internal/twofactor/repository/mysql"
+	_twoFactorUcase "github.com/meroedu/meroedu/internal/twofactor/usecase"
+	_userHttpDelivery "github.com/meroedu/meroedu/internal/user/delivery/http"
+	_userRepo "github.com/meroedu/meroedu/internal/user/repository/mysql"
+	_userUcase "github.com/meroedu/meroedu/internal/user/usecase"
+	"github.com/meroedu/meroedu/internal/userimport"
+	_userImportHttpDelivery "github.com/meroedu/meroedu/internal/userimport/delivery/http"
+	_userImportRepo "github.com/meroedu/meroedu/internal/userimport/repository/mysql"
+	_userImportUcase "github.com/meroedu/meroedu/internal/userimport/usecase"
 	datastore "github.com/meroedu/meroedu/pkg/database"
 
 	"github.com/meroedu/meroedu/internal/config"
+	"github.com/meroedu/meroedu/internal/domain"
 	"github.com/meroedu/meroedu/pkg/log"
 )
 
@@ -89,13 +160,90 @@ func main() {
 	// healthcheck
 	_healthHttpDelivery.NewHealthHandler(e)
 
+	// Organizations
+	organizationRepository := _organizationRepo.Init(db)
+	_organizationHttpDelivery.NewOrganizationHandler(e, _organizationUcase.NewOrganizationUseCase(organizationRepository, timeoutContext))
+
+	// Roles
+	roleRepository := _roleRepo.Init(db)
+	_roleHttpDelivery.NewRoleHandler(e, _roleUcase.NewRoleUseCase(roleRepository, timeoutContext))
+
+	// Users
+	userRepository := _userRepo.Init(db)
+
+	// Auth
+	authSecret := viper.GetString("auth.secret")
+	if authSecret == "" {
+		log.Fatal("auth.secret is not configured")
+	}
+	accessTokenTTL := time.Duration(viper.GetInt("auth.access_token_ttl")) * time.Minute
+	refreshTokenTTL := time.Duration(viper.GetInt("auth.refresh_token_ttl")) * time.Hour
+	lockoutDuration := time.Duration(viper.GetInt("auth.lockout_duration")) * time.Minute
+	twoFactorRepository := _twoFactorRepo.Init(db)
+	sessionRepository := _sessionRepo.Init(db)
+	authUseCase := _authUcase.NewAuthUseCase(_authRepo.Init(db), userRepository, roleRepository, twoFactorRepository, organizationRepository,
+		sessionRepository, authSecret, accessTokenTTL, refreshTokenTTL, viper.GetInt("auth.max_failed_logins"), lockoutDuration, timeoutContext)
+	_authHttpDelivery.NewAuthHandler(e, authUseCase)
+	apiKeyUseCase := _apiKeyUcase.NewAPIKeyUseCase(_apiKeyRepo.Init(db), timeoutContext)
+	_apiKeyHttpDelivery.NewAPIKeyHandler(e, apiKeyUseCase)
+	onlineWindow := time.Duration(viper.GetInt("session.online_window")) * time.Minute
+	if onlineWindow <= 0 {
+		onlineWindow = 5 * time.Minute
+	}
+	sessionUseCase := _sessionUcase.NewSessionUseCase(sessionRepository, onlineWindow, timeoutContext)
+	_sessionHttpDelivery.NewSessionHandler(e, sessionUseCase)
+	e.Use(_authHttpDeliveryMiddleware.Authenticate(authUseCase, apiKeyUseCase, sessionUseCase, "/", "/swagger/*", "/auth/login", "/auth/refresh", "/auth/logout", "/auth/oidc/*", "/auth/ldap/login",
+		"/auth/invitations/accept", "/auth/password/forgot", "/auth/password/reset", "/auth/email/verify", "/auth/2fa/login", "/auth/2fa/login/*"))
+
+	// Two-factor authentication
+	twoFactorIssuer := viper.GetString("auth.two_factor_issuer")
+	if twoFactorIssuer == "" {
+		twoFactorIssuer = "Meroedu"
+	}
+	_twoFactorHttpDelivery.NewTwoFactorHandler(e, _twoFactorUcase.NewTwoFactorUseCase(twoFactorRepository, userRepository, organizationRepository,
+		authUseCase, twoFactorIssuer, timeoutContext))
+
+	// Mail
+	var mailer domain.Mailer
+	switch driver := viper.GetString("mail.driver"); driver {
+	case "smtp":
+		mailer = _smtpMailer.Init(viper.GetString("mail.smtp.host"), viper.GetInt("mail.smtp.port"), viper.GetString("mail.smtp.username"),
+			viper.GetString("mail.smtp.password"), viper.GetString("mail.from"))
+	case "", "log":
+		mailer = _logMailer.Init()
+	default:
+		log.Fatalf("Unknown mail driver %q", driver)
+	}
+
+	// Password reset and email verification
+	resetTTL := time.Duration(viper.GetInt("account.reset_token_ttl")) * time.Minute
+	verificationTTL := time.Duration(viper.GetInt("account.verification_token_ttl")) * time.Hour
+	accountUseCase := _accountUcase.NewAccountUseCase(_accountRepo.Init(db), userRepository, authUseCase, mailer, viper.GetString("account.reset_url"),
+		viper.GetString("account.verify_url"), resetTTL, verificationTTL, viper.GetInt("account.tokens_per_hour"), timeoutContext)
+	_accountHttpDelivery.NewAccountHandler(e, accountUseCase)
+	userUseCase := _userUcase.NewUserUseCase(userRepository, roleRepository, accountUseCase, timeoutContext)
+	_userHttpDelivery.NewUserHandler(e, userUseCase)
+
+	// Single sign-on
+	oidcClient := _oidcClient.Init(time.Duration(viper.GetInt("oidc.timeout")) * time.Second)
+	oidcUseCase := _oidcUcase.NewOIDCUseCase(_oidcRepo.Init(db), oidcClient, userRepository, roleRepository, authUseCase, timeoutContext)
+	_oidcHttpDelivery.NewOIDCHandler(e, oidcUseCase)
+	ldapClient := _ldapClient.Init(time.Duration(viper.GetInt("ldap.timeout")) * time.Second)
+	ldapSyncTimeout := time.Duration(viper.GetInt("ldap.sync_timeout")) * time.Minute
+	ldapUseCase := _ldapUcase.NewLDAPUseCase(_ldapRepo.Init(db), ldapClient, userRepository, roleRepository, authUseCase, timeoutContext, ldapSyncTimeout)
+	_ldapHttpDelivery.NewLDAPHandler(e, ldapUseCase)
+
+	// Course collaborators, who edit the courses with their authors
+	collaboratorUseCase := _collaboratorUcase.NewCollaboratorUseCase(_collaboratorRepo.Init(db), userRepository, roleRepository, timeoutContext)
+	_collaboratorHttpDelivery.NewCollaboratorHandler(e, collaboratorUseCase)
+
 	// contents
 	contentRepository := _contentRepo.Init(db)
 	contentStorage, err := _contentStore.Init()
 	if err != nil {
 		log.Fatalf("Error initializing content storage: %v", err)
 	}
-	contentUseCase := _contentUcase.NewContentUseCase(contentRepository, contentStorage, timeoutContext)
+	contentUseCase := _contentUcase.NewContentUseCase(contentRepository, contentStorage, collaboratorUseCase, timeoutContext)
 	_contentHttpDelivery.NewContentHandler(e, contentUseCase)
 
 	// tags
@@ -117,12 +265,97 @@ func main() {
 
 	// Lessons
 	lessonRepository := _lessonRepo.Init(db)
-	lessonUseCase := _lessonUcase.NewLessonUseCase(lessonRepository, contentUseCase, timeoutContext)
+	lessonUseCase := _lessonUcase.NewLessonUseCase(lessonRepository, contentUseCase, collaboratorUseCase, timeoutContext)
 	_lessonHttpDelivery.NewLessonHandler(e, lessonUseCase)
 
 	// Courses
 	courseRepository := _courseRepo.Init(db)
-	_courseHttpDelivery.NewCourseHandler(e, _courseUcase.NewCourseUseCase(courseRepository, lessonUseCase, attachmentUseCase, timeoutContext))
+	courseUseCase := _courseUcase.NewCourseUseCase(courseRepository, lessonUseCase, attachmentUseCase, collaboratorUseCase, timeoutContext)
+	_courseHttpDelivery.NewCourseHandler(e, courseUseCase)
+
+	// Enrollments
+	enrollmentRepository := _enrollmentRepo.Init(db)
+	enrollmentUseCase := _enrollmentUcase.NewEnrollmentUseCase(enrollmentRepository, courseRepository, timeoutContext)
+	_enrollmentHttpDelivery.NewEnrollmentHandler(e, enrollmentUseCase)
+
+	// Rubrics, marking the assignments and the short-answer questions
+	rubricUseCase := _rubricUcase.NewRubricUseCase(_rubricRepo.Init(db), collaboratorUseCase, timeoutContext)
+	_rubricHttpDelivery.NewRubricHandler(e, rubricUseCase)
+
+	// Quizzes
+	_quizHttpDelivery.NewQuizHandler(e, _quizUcase.NewQuizUseCase(_quizRepo.Init(db), lessonRepository, enrollmentRepository,
+		collaboratorUseCase, rubricUseCase, timeoutContext))
+
+	// Assignments, with the files handed in kept apart from the attachments
+	if config.C.Filesystem.SubmissionsPath == "" || config.C.Filesystem.SubmissionsPath == config.C.Filesystem.RelativePath {
+		log.Fatalf("filesystem.submissionsPath must name a directory apart from filesystem.relativePath")
+	}
+	submissionStorage, err := _attachmentStore.InitIn(config.C.Filesystem.SubmissionsPath)
+	if err != nil {
+		log.Fatalf("Error initializing submission storage: %v", err)
+	}
+	assignmentRepository := _assignmentRepo.Init(db)
+	_assignmentHttpDelivery.NewAssignmentHandler(e, _assignmentUcase.NewAssignmentUseCase(assignmentRepository, lessonRepository,
+		enrollmentRepository, collaboratorUseCase, rubricUseCase, submissionStorage, timeoutContext))
+
+	// Peer reviews of the assignments, against their rubric
+	peerReviewUseCase := _peerReviewUcase.NewPeerReviewUseCase(_peerReviewRepo.Init(db), assignmentRepository, collaboratorUseCase,
+		rubricUseCase, submissionStorage, timeoutContext)
+	_peerReviewHttpDelivery.NewPeerReviewHandler(e, peerReviewUseCase)
+
+	// Teams
+	teamRepository := _teamRepo.Init(db)
+	_teamHttpDelivery.NewTeamHandler(e, _teamUcase.NewTeamUseCase(teamRepository, userRepository, roleRepository, courseRepository,
+		enrollmentUseCase, timeoutContext))
+
+	// Personal data
+	_privacyHttpDelivery.NewPrivacyHandler(e, _privacyUcase.NewPrivacyUseCase(_privacyRepo.Init(db), userRepository, roleRepository,
+		teamRepository, submissionStorage, timeoutContext))
+
+	// Invitations
+	invitationTTL := time.Duration(viper.GetInt("invitation.ttl")) * time.Hour
+	invitationUseCase := _invitationUcase.NewInvitationUseCase(_invitationRepo.Init(db), userRepository, roleRepository, courseRepository,
+		enrollmentUseCase, authUseCase, mailer, viper.GetString("invitation.accept_url"), invitationTTL, timeoutContext)
+	_invitationHttpDelivery.NewInvitationHandler(e, invitationUseCase)
+
+	// User imports
+	userImportUseCase := _userImportUcase.NewUserImportUseCase(_userImportRepo.Init(db), roleRepository, courseRepository, userUseCase,
+		enrollmentUseCase, timeoutContext)
+	_userImportHttpDelivery.NewUserImportHandler(e, userImportUseCase)
+
+	// Trash
+	jobContext, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	retention := time.Duration(viper.GetInt("trash.retention_days")) * 24 * time.Hour
+	purgeInterval := time.Duration(viper.GetInt("trash.purge_interval")) * time.Hour
+	if retention > 0 && purgeInterval > 0 {
+		go trash.NewPurgeJob(retention, purgeInterval, contentUseCase, lessonUseCase, courseUseCase).Start(jobContext)
+	}
+
+	// LDAP directory sync
+	ldapSyncInterval := time.Duration(viper.GetInt("ldap.sync_interval")) * time.Minute
+	if ldapSyncInterval > 0 {
+		go ldap.NewSyncJob(ldapUseCase, ldapSyncInterval).Start(jobContext)
+	}
+
+	// User imports
+	importInterval := time.Duration(viper.GetInt("import.interval")) * time.Second
+	if importInterval > 0 {
+		go userimport.NewImportJob(userImportUseCase, importInterval).Start(jobContext)
+	}
+
+	// Peer reviewer allocation
+	allocationInterval := time.Duration(viper.GetInt("peer_review.allocation_interval")) * time.Second
+	if allocationInterval > 0 {
+		go peerreview.NewAllocationJob(peerReviewUseCase, allocationInterval).Start(jobContext)
+	}
+
+	// Presence, always written since every request is recorded until the next flush
+	presenceInterval := time.Duration(viper.GetInt("session.flush_interval")) * time.Second
+	if presenceInterval <= 0 {
+		presenceInterval = time.Minute
+	}
+	go session.NewPresenceJob(sessionUseCase, presenceInterval).Start(jobContext)
 
 	// Start HTTP Server
 	go func() {
@@ -133,7 +366,7 @@ func main() {
 
 	//Wait for interrupt signal to gracefully shutdown the server with a timeout of 10 seconds
 
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 	<-quit
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -0,0 +1,4 @@
+DELETE FROM `roles_permissions` WHERE `permission` = 'user:invite';
+DROP TABLE IF EXISTS invitations_courses;
+DROP TABLE IF EXISTS invitations_teams;
+DROP TABLE IF EXISTS invitations;
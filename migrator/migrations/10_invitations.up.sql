@@ -0,0 +1,42 @@
+CREATE TABLE `invitations` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `organization_id` bigint(20) NOT NULL,
+  `email` VARCHAR(255) NOT NULL,
+  `first_name` VARCHAR(100) DEFAULT NULL,
+  `last_name` VARCHAR(100) DEFAULT NULL,
+  `role_id` bigint(20) NOT NULL,
+  `invited_by` bigint(20) NOT NULL,
+  `user_id` bigint(20) DEFAULT NULL,
+  `status` int(1) NOT NULL,
+  `token_hash` CHAR(64) UNIQUE NOT NULL,
+  `expires_at` bigint(20) NOT NULL,
+  `accepted_at` bigint(20) DEFAULT NULL,
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `invitations` ADD FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+ALTER TABLE `invitations` ADD FOREIGN KEY (`role_id`) REFERENCES `roles` (`id`) ON DELETE CASCADE;
+ALTER TABLE `invitations` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE SET NULL;
+CREATE INDEX `index_on_organization_id_email` ON `invitations` (`organization_id`, `email`);
+
+CREATE TABLE `invitations_teams` (
+  `invitation_id` bigint(20) NOT NULL,
+  `team_id` bigint(20) NOT NULL,
+  PRIMARY KEY (`invitation_id`, `team_id`)
+);
+
+ALTER TABLE `invitations_teams` ADD FOREIGN KEY (`invitation_id`) REFERENCES `invitations` (`id`) ON DELETE CASCADE;
+ALTER TABLE `invitations_teams` ADD FOREIGN KEY (`team_id`) REFERENCES `teams` (`id`) ON DELETE CASCADE;
+
+CREATE TABLE `invitations_courses` (
+  `invitation_id` bigint(20) NOT NULL,
+  `course_id` bigint(20) NOT NULL,
+  PRIMARY KEY (`invitation_id`, `course_id`)
+);
+
+ALTER TABLE `invitations_courses` ADD FOREIGN KEY (`invitation_id`) REFERENCES `invitations` (`id`) ON DELETE CASCADE;
+ALTER TABLE `invitations_courses` ADD FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON DELETE CASCADE;
+
+INSERT INTO `roles_permissions` (`role_id`, `permission`)
+  SELECT r.id, 'user:invite' FROM `roles` r WHERE r.code IN ('admin', 'instructor', 'superadmin') AND r.organization_id IS NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS user_tokens;
+ALTER TABLE `users` DROP COLUMN `locked_until`;
+ALTER TABLE `users` DROP COLUMN `failed_logins`;
+ALTER TABLE `users` DROP COLUMN `email_verified_at`;
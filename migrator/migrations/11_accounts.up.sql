@@ -0,0 +1,20 @@
+ALTER TABLE `users` ADD `email_verified_at` bigint(20) DEFAULT NULL;
+
+ALTER TABLE `users` ADD `failed_logins` int(11) NOT NULL DEFAULT 0;
+
+ALTER TABLE `users` ADD `locked_until` bigint(20) DEFAULT NULL;
+
+CREATE TABLE `user_tokens` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) NOT NULL,
+  `organization_id` bigint(20) NOT NULL,
+  `purpose` VARCHAR(50) NOT NULL,
+  `email` VARCHAR(255) NOT NULL,
+  `token_hash` CHAR(64) UNIQUE NOT NULL,
+  `expires_at` bigint(20) NOT NULL,
+  `used_at` bigint(20) DEFAULT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `user_tokens` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+CREATE INDEX `index_on_user_id_purpose` ON `user_tokens` (`user_id`, `purpose`, `created_at`);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS two_factor_recovery_codes;
+DROP TABLE IF EXISTS two_factor;
+ALTER TABLE `organizations` DROP COLUMN `require_two_factor`;
@@ -0,0 +1,24 @@
+ALTER TABLE `organizations` ADD `require_two_factor` tinyint(1) NOT NULL DEFAULT 0;
+
+CREATE TABLE `two_factor` (
+  `user_id` bigint(20) PRIMARY KEY NOT NULL,
+  `organization_id` bigint(20) NOT NULL,
+  `secret` VARCHAR(64) NOT NULL,
+  `enabled_at` bigint(20) DEFAULT NULL,
+  `last_step` bigint(20) NOT NULL DEFAULT 0,
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `two_factor` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+
+CREATE TABLE `two_factor_recovery_codes` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) NOT NULL,
+  `code_hash` CHAR(64) NOT NULL,
+  `used_at` bigint(20) DEFAULT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `two_factor_recovery_codes` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+CREATE INDEX `index_on_user_id_code_hash` ON `two_factor_recovery_codes` (`user_id`, `code_hash`);
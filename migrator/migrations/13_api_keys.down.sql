@@ -0,0 +1,3 @@
+DELETE FROM `roles_permissions` WHERE `permission` = 'apikey:manage';
+DROP TABLE IF EXISTS api_keys_scopes;
+DROP TABLE IF EXISTS api_keys;
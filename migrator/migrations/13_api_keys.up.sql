@@ -0,0 +1,28 @@
+CREATE TABLE `api_keys` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `organization_id` bigint(20) NOT NULL,
+  `name` VARCHAR(100) NOT NULL,
+  `prefix` VARCHAR(16) NOT NULL,
+  `key_hash` CHAR(64) UNIQUE NOT NULL,
+  `created_by` bigint(20) DEFAULT NULL,
+  `expires_at` bigint(20) DEFAULT NULL,
+  `last_used_at` bigint(20) DEFAULT NULL,
+  `revoked_at` bigint(20) DEFAULT NULL,
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `api_keys` ADD FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+ALTER TABLE `api_keys` ADD FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON DELETE SET NULL;
+CREATE INDEX `index_on_organization_id` ON `api_keys` (`organization_id`);
+
+CREATE TABLE `api_keys_scopes` (
+  `api_key_id` bigint(20) NOT NULL,
+  `permission` VARCHAR(50) NOT NULL,
+  PRIMARY KEY (`api_key_id`, `permission`)
+);
+
+ALTER TABLE `api_keys_scopes` ADD FOREIGN KEY (`api_key_id`) REFERENCES `api_keys` (`id`) ON DELETE CASCADE;
+
+INSERT INTO `roles_permissions` (`role_id`, `permission`)
+  SELECT r.id, 'apikey:manage' FROM `roles` r WHERE r.code IN ('admin', 'superadmin') AND r.organization_id IS NULL;
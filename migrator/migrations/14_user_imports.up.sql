@@ -0,0 +1,23 @@
+CREATE TABLE `user_imports` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `organization_id` bigint(20) NOT NULL,
+  `file_name` VARCHAR(255) NOT NULL,
+  `status` int(1) NOT NULL,
+  `total` int NOT NULL,
+  `processed` int NOT NULL DEFAULT 0,
+  `created` int NOT NULL DEFAULT 0,
+  `failed` int NOT NULL DEFAULT 0,
+  `errors` LONGTEXT NOT NULL,
+  `pending_rows` LONGTEXT DEFAULT NULL,
+  `permissions` TEXT NOT NULL,
+  `created_by` bigint(20) DEFAULT NULL,
+  `started_at` bigint(20) DEFAULT NULL,
+  `finished_at` bigint(20) DEFAULT NULL,
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `user_imports` ADD FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+ALTER TABLE `user_imports` ADD FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON DELETE SET NULL;
+CREATE INDEX `index_on_organization_id` ON `user_imports` (`organization_id`);
+CREATE INDEX `index_on_status` ON `user_imports` (`status`);
@@ -0,0 +1,5 @@
+ALTER TABLE `courses_teams_enrollments` DROP INDEX `unique_course_team`;
+ALTER TABLE `courses_teams_enrollments` DROP COLUMN `created_at`;
+
+DROP INDEX `index_on_organization_id_name` ON `teams`;
+ALTER TABLE `teams` CHANGE COLUMN `image_url` `mage_url` VARCHAR(255) DEFAULT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE `teams` CHANGE COLUMN `mage_url` `image_url` VARCHAR(255) DEFAULT NULL;
+CREATE INDEX `index_on_organization_id_name` ON `teams` (`organization_id`, `name`);
+
+ALTER TABLE `courses_teams_enrollments` ADD COLUMN `created_at` bigint(20) NOT NULL DEFAULT 0;
+ALTER TABLE `courses_teams_enrollments` ADD CONSTRAINT `unique_course_team` UNIQUE (`course_id`, `team_id`);
@@ -0,0 +1,6 @@
+DROP INDEX `index_on_organization_id_last_online` ON `users`;
+
+ALTER TABLE `refresh_tokens` DROP FOREIGN KEY `fk_refresh_tokens_session`;
+ALTER TABLE `refresh_tokens` DROP COLUMN `session_id`;
+
+DROP TABLE IF EXISTS `sessions`;
@@ -0,0 +1,19 @@
+CREATE TABLE `sessions` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) NOT NULL,
+  `organization_id` bigint(20) NOT NULL,
+  `device` VARCHAR(255) DEFAULT NULL,
+  `ip` VARCHAR(45) DEFAULT NULL,
+  `last_seen_at` bigint(20) NOT NULL,
+  `expires_at` bigint(20) NOT NULL,
+  `revoked_at` bigint(20) DEFAULT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `sessions` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+CREATE INDEX `index_on_user_id_revoked_at` ON `sessions` (`user_id`, `revoked_at`);
+
+ALTER TABLE `refresh_tokens` ADD `session_id` bigint(20) DEFAULT NULL;
+ALTER TABLE `refresh_tokens` ADD CONSTRAINT `fk_refresh_tokens_session` FOREIGN KEY (`session_id`) REFERENCES `sessions` (`id`) ON DELETE CASCADE;
+
+CREATE INDEX `index_on_organization_id_last_online` ON `users` (`organization_id`, `lastOnline`);
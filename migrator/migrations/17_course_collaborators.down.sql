@@ -0,0 +1,3 @@
+DELETE FROM `roles_permissions` WHERE `permission` = 'course:manage';
+
+DROP TABLE IF EXISTS `courses_collaborators`;
@@ -0,0 +1,17 @@
+CREATE TABLE `courses_collaborators` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `course_id` bigint(20) NOT NULL,
+  `user_id` bigint(20) NOT NULL,
+  `permission` VARCHAR(20) NOT NULL,
+  `added_by` bigint(20),
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL,
+  UNIQUE (`course_id`, `user_id`)
+);
+
+ALTER TABLE `courses_collaborators` ADD FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `courses_collaborators` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+
+INSERT INTO `roles_permissions` (`role_id`, `permission`)
+  SELECT r.id, 'course:manage' FROM `roles` r WHERE r.code IN ('admin', 'superadmin') AND r.organization_id IS NULL;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS `quiz_attempts`;
+
+DROP TABLE IF EXISTS `quizzes_questions`;
+
+DROP TABLE IF EXISTS `quizzes`;
+
+DROP TABLE IF EXISTS `questions`;
@@ -0,0 +1,66 @@
+CREATE TABLE `questions` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `course_id` bigint(20) NOT NULL,
+  `type` VARCHAR(20) NOT NULL,
+  `text` TEXT NOT NULL,
+  `points` DOUBLE NOT NULL,
+  `options` TEXT NOT NULL,
+  `matches` TEXT NOT NULL,
+  `answer_key` TEXT NOT NULL,
+  `explanation` TEXT DEFAULT NULL,
+  `created_by` bigint(20) DEFAULT NULL,
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `questions` ADD FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON DELETE CASCADE;
+
+CREATE TABLE `quizzes` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `lesson_id` bigint(20) NOT NULL,
+  `title` VARCHAR(255) NOT NULL,
+  `description` TEXT DEFAULT NULL,
+  `time_limit` int NOT NULL DEFAULT 0,
+  `max_attempts` int NOT NULL DEFAULT 0,
+  `passing_score` DOUBLE NOT NULL DEFAULT 0,
+  `shuffle_questions` BOOLEAN NOT NULL DEFAULT FALSE,
+  `shuffle_options` BOOLEAN NOT NULL DEFAULT FALSE,
+  `created_by` bigint(20) DEFAULT NULL,
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `quizzes` ADD FOREIGN KEY (`lesson_id`) REFERENCES `lessons` (`id`) ON DELETE CASCADE;
+
+CREATE TABLE `quizzes_questions` (
+  `quiz_id` bigint(20) NOT NULL,
+  `question_id` bigint(20) NOT NULL,
+  `position` int NOT NULL,
+  PRIMARY KEY (`quiz_id`, `question_id`)
+);
+
+ALTER TABLE `quizzes_questions` ADD FOREIGN KEY (`quiz_id`) REFERENCES `quizzes` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `quizzes_questions` ADD FOREIGN KEY (`question_id`) REFERENCES `questions` (`id`) ON DELETE CASCADE;
+
+CREATE TABLE `quiz_attempts` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `quiz_id` bigint(20) NOT NULL,
+  `user_id` bigint(20) NOT NULL,
+  `number` int NOT NULL,
+  `status` VARCHAR(20) NOT NULL,
+  `seed` bigint(20) NOT NULL,
+  `answers` LONGTEXT DEFAULT NULL,
+  `score` DOUBLE NOT NULL DEFAULT 0,
+  `max_score` DOUBLE NOT NULL DEFAULT 0,
+  `passed` BOOLEAN NOT NULL DEFAULT FALSE,
+  `started_at` bigint(20) NOT NULL,
+  `expires_at` bigint(20) DEFAULT NULL,
+  `submitted_at` bigint(20) DEFAULT NULL
+);
+
+ALTER TABLE `quiz_attempts` ADD FOREIGN KEY (`quiz_id`) REFERENCES `quizzes` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `quiz_attempts` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX `index_on_quiz_id_user_id_number` ON `quiz_attempts` (`quiz_id`, `user_id`, `number`);
@@ -0,0 +1,7 @@
+ALTER TABLE `quiz_attempts` DROP COLUMN `question_ids`;
+
+DROP TABLE IF EXISTS `quizzes_pools`;
+
+DROP TABLE IF EXISTS `questions_tags`;
+
+ALTER TABLE `questions` DROP COLUMN `difficulty`;
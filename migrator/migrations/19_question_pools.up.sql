@@ -0,0 +1,29 @@
+ALTER TABLE `questions` ADD COLUMN `difficulty` int NOT NULL DEFAULT 2;
+
+CREATE TABLE `questions_tags` (
+  `question_id` bigint(20) NOT NULL,
+  `tag_id` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL,
+  PRIMARY KEY (`question_id`, `tag_id`)
+);
+
+ALTER TABLE `questions_tags` ADD FOREIGN KEY (`question_id`) REFERENCES `questions` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `questions_tags` ADD FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`) ON DELETE CASCADE;
+
+CREATE TABLE `quizzes_pools` (
+  `quiz_id` bigint(20) NOT NULL,
+  `tag_id` bigint(20) NOT NULL,
+  `position` int NOT NULL,
+  `count` int NOT NULL,
+  `easy_weight` int NOT NULL DEFAULT 0,
+  `medium_weight` int NOT NULL DEFAULT 0,
+  `hard_weight` int NOT NULL DEFAULT 0,
+  PRIMARY KEY (`quiz_id`, `tag_id`)
+);
+
+ALTER TABLE `quizzes_pools` ADD FOREIGN KEY (`quiz_id`) REFERENCES `quizzes` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `quizzes_pools` ADD FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `quiz_attempts` ADD COLUMN `question_ids` TEXT DEFAULT NULL;
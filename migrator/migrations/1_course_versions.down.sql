@@ -0,0 +1,4 @@
+ALTER TABLE `courses_users_enrollments` DROP FOREIGN KEY `fk_enrollments_course_version`;
+ALTER TABLE `courses_users_enrollments` DROP COLUMN `course_version_id`;
+ALTER TABLE `courses_users_enrollments` DROP COLUMN `created_at`;
+DROP TABLE IF EXISTS course_versions;
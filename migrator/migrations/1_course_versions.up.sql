@@ -0,0 +1,18 @@
+CREATE TABLE `course_versions` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `course_id` bigint(20) NOT NULL,
+  `version` int NOT NULL,
+  `change_note` VARCHAR(255),
+  `snapshot` longtext COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `courses_users_enrollments` ADD COLUMN `course_version_id` bigint(20);
+
+ALTER TABLE `courses_users_enrollments` ADD COLUMN `created_at` bigint(20) NOT NULL DEFAULT 0;
+
+ALTER TABLE `course_versions` ADD FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `courses_users_enrollments` ADD CONSTRAINT `fk_enrollments_course_version` FOREIGN KEY (`course_version_id`) REFERENCES `course_versions` (`id`) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX `index_on_course_id_version` ON `course_versions` (`course_id`, `version`);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `assignment_submissions`;
+
+DROP TABLE IF EXISTS `assignments`;
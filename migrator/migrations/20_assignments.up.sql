@@ -0,0 +1,43 @@
+CREATE TABLE `assignments` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `lesson_id` bigint(20) NOT NULL,
+  `title` VARCHAR(255) NOT NULL,
+  `instructions` TEXT DEFAULT NULL,
+  `due_at` bigint(20) DEFAULT NULL,
+  `late_policy` VARCHAR(20) NOT NULL,
+  `late_penalty` DOUBLE NOT NULL DEFAULT 0,
+  `max_points` DOUBLE NOT NULL,
+  `criteria` TEXT NOT NULL,
+  `allow_resubmission` BOOLEAN NOT NULL DEFAULT FALSE,
+  `max_submissions` int NOT NULL DEFAULT 0,
+  `created_by` bigint(20) DEFAULT NULL,
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `assignments` ADD FOREIGN KEY (`lesson_id`) REFERENCES `lessons` (`id`) ON DELETE CASCADE;
+
+CREATE TABLE `assignment_submissions` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `assignment_id` bigint(20) NOT NULL,
+  `user_id` bigint(20) NOT NULL,
+  `number` int NOT NULL,
+  `text` LONGTEXT DEFAULT NULL,
+  `files` TEXT NOT NULL,
+  `status` VARCHAR(20) NOT NULL,
+  `late` BOOLEAN NOT NULL DEFAULT FALSE,
+  `submitted_at` bigint(20) NOT NULL,
+  `score` DOUBLE DEFAULT NULL,
+  `scores` TEXT DEFAULT NULL,
+  `penalty` DOUBLE NOT NULL DEFAULT 0,
+  `final_score` DOUBLE DEFAULT NULL,
+  `feedback` TEXT DEFAULT NULL,
+  `graded_by` bigint(20) DEFAULT NULL,
+  `graded_at` bigint(20) DEFAULT NULL
+);
+
+ALTER TABLE `assignment_submissions` ADD FOREIGN KEY (`assignment_id`) REFERENCES `assignments` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `assignment_submissions` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX `index_on_assignment_id_user_id_number` ON `assignment_submissions` (`assignment_id`, `user_id`, `number`);
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS `rubric_scores`;
+
+ALTER TABLE `questions` DROP FOREIGN KEY `fk_questions_rubric`;
+
+ALTER TABLE `questions` DROP COLUMN `rubric_id`;
+
+ALTER TABLE `assignments` DROP FOREIGN KEY `fk_assignments_rubric`;
+
+ALTER TABLE `assignments` DROP COLUMN `rubric_id`;
+
+DROP TABLE IF EXISTS `rubrics`;
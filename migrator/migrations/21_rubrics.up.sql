@@ -0,0 +1,46 @@
+CREATE TABLE `rubrics` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `organization_id` bigint(20) NOT NULL,
+  `title` VARCHAR(255) NOT NULL,
+  `description` TEXT DEFAULT NULL,
+  `criteria` TEXT NOT NULL,
+  `max_points` DOUBLE NOT NULL,
+  `created_by` bigint(20) DEFAULT NULL,
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `rubrics` ADD FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `assignments` ADD COLUMN `rubric_id` bigint(20) DEFAULT NULL;
+
+ALTER TABLE `assignments` ADD CONSTRAINT `fk_assignments_rubric` FOREIGN KEY (`rubric_id`) REFERENCES `rubrics` (`id`);
+
+ALTER TABLE `questions` ADD COLUMN `rubric_id` bigint(20) DEFAULT NULL;
+
+ALTER TABLE `questions` ADD CONSTRAINT `fk_questions_rubric` FOREIGN KEY (`rubric_id`) REFERENCES `rubrics` (`id`);
+
+CREATE TABLE `rubric_scores` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `rubric_id` bigint(20) NOT NULL,
+  `course_id` bigint(20) NOT NULL,
+  `submission_id` bigint(20) DEFAULT NULL,
+  `attempt_id` bigint(20) DEFAULT NULL,
+  `question_id` bigint(20) DEFAULT NULL,
+  `criterion_id` int NOT NULL,
+  `level_id` int NOT NULL,
+  `points` DOUBLE NOT NULL,
+  `comment` TEXT DEFAULT NULL,
+  `graded_by` bigint(20) DEFAULT NULL,
+  `graded_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `rubric_scores` ADD FOREIGN KEY (`rubric_id`) REFERENCES `rubrics` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `rubric_scores` ADD FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `rubric_scores` ADD FOREIGN KEY (`submission_id`) REFERENCES `assignment_submissions` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `rubric_scores` ADD FOREIGN KEY (`attempt_id`) REFERENCES `quiz_attempts` (`id`) ON DELETE CASCADE;
+
+CREATE INDEX `index_on_rubric_id_course_id` ON `rubric_scores` (`rubric_id`, `course_id`);
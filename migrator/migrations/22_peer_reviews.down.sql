@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS `peer_reviews`;
+
+ALTER TABLE `assignments` DROP COLUMN `peer_allocated_at`;
+
+ALTER TABLE `assignments` DROP COLUMN `peer_review_due_at`;
+
+ALTER TABLE `assignments` DROP COLUMN `peer_anonymous`;
+
+ALTER TABLE `assignments` DROP COLUMN `peer_reviews`;
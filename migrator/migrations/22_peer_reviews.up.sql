@@ -0,0 +1,30 @@
+ALTER TABLE `assignments` ADD COLUMN `peer_reviews` int NOT NULL DEFAULT 0;
+
+ALTER TABLE `assignments` ADD COLUMN `peer_anonymous` BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE `assignments` ADD COLUMN `peer_review_due_at` bigint(20) DEFAULT NULL;
+
+ALTER TABLE `assignments` ADD COLUMN `peer_allocated_at` bigint(20) DEFAULT NULL;
+
+CREATE TABLE `peer_reviews` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `assignment_id` bigint(20) NOT NULL,
+  `submission_id` bigint(20) NOT NULL,
+  `reviewer_id` bigint(20) NOT NULL,
+  `status` VARCHAR(20) NOT NULL,
+  `scores` TEXT DEFAULT NULL,
+  `score` DOUBLE DEFAULT NULL,
+  `feedback` TEXT DEFAULT NULL,
+  `assigned_at` bigint(20) NOT NULL,
+  `submitted_at` bigint(20) DEFAULT NULL
+);
+
+ALTER TABLE `peer_reviews` ADD FOREIGN KEY (`assignment_id`) REFERENCES `assignments` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `peer_reviews` ADD FOREIGN KEY (`submission_id`) REFERENCES `assignment_submissions` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `peer_reviews` ADD FOREIGN KEY (`reviewer_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `peer_reviews` ADD CONSTRAINT `unique_submission_reviewer` UNIQUE (`submission_id`, `reviewer_id`);
+
+CREATE INDEX `index_on_assignment_id_reviewer_id` ON `peer_reviews` (`assignment_id`, `reviewer_id`);
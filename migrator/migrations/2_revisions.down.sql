@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS content_revisions;
+DROP TABLE IF EXISTS lesson_revisions;
+ALTER TABLE `contents` DROP COLUMN `name`;
+ALTER TABLE `contents` DROP COLUMN `content_type`;
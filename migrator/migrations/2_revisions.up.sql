@@ -0,0 +1,31 @@
+ALTER TABLE `contents` ADD COLUMN `content_type` varchar(30);
+
+ALTER TABLE `contents` ADD COLUMN `name` varchar(256);
+
+CREATE TABLE `lesson_revisions` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `lesson_id` bigint(20) NOT NULL,
+  `revision` int NOT NULL,
+  `author_id` bigint(20),
+  `snapshot` longtext COLLATE utf8mb4_unicode_ci NOT NULL,
+  `diff` longtext COLLATE utf8mb4_unicode_ci,
+  `created_at` bigint(20) NOT NULL
+);
+
+CREATE TABLE `content_revisions` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `content_id` bigint(20) NOT NULL,
+  `revision` int NOT NULL,
+  `author_id` bigint(20),
+  `snapshot` longtext COLLATE utf8mb4_unicode_ci NOT NULL,
+  `diff` longtext COLLATE utf8mb4_unicode_ci,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `lesson_revisions` ADD FOREIGN KEY (`lesson_id`) REFERENCES `lessons` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `content_revisions` ADD FOREIGN KEY (`content_id`) REFERENCES `contents` (`id`) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX `index_on_lesson_id_revision` ON `lesson_revisions` (`lesson_id`, `revision`);
+
+CREATE UNIQUE INDEX `index_on_content_id_revision` ON `content_revisions` (`content_id`, `revision`);
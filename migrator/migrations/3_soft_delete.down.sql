@@ -0,0 +1,6 @@
+DROP INDEX `index_on_deleted_at` ON `contents`;
+DROP INDEX `index_on_deleted_at` ON `lessons`;
+DROP INDEX `index_on_deleted_at` ON `courses`;
+ALTER TABLE `contents` DROP COLUMN `deleted_at`;
+ALTER TABLE `lessons` DROP COLUMN `deleted_at`;
+ALTER TABLE `courses` DROP COLUMN `deleted_at`;
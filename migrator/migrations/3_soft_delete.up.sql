@@ -0,0 +1,11 @@
+ALTER TABLE `courses` ADD COLUMN `deleted_at` bigint(20) DEFAULT NULL;
+
+ALTER TABLE `lessons` ADD COLUMN `deleted_at` bigint(20) DEFAULT NULL;
+
+ALTER TABLE `contents` ADD COLUMN `deleted_at` bigint(20) DEFAULT NULL;
+
+CREATE INDEX `index_on_deleted_at` ON `courses` (`deleted_at`);
+
+CREATE INDEX `index_on_deleted_at` ON `lessons` (`deleted_at`);
+
+CREATE INDEX `index_on_deleted_at` ON `contents` (`deleted_at`);
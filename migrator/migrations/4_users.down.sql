@@ -0,0 +1,8 @@
+DROP INDEX `index_on_username` ON `users`;
+DROP INDEX `index_on_email` ON `users`;
+ALTER TABLE `users` MODIFY `status` int(1);
+ALTER TABLE `users` MODIFY `inviteBy` bigint(20) NOT NULL;
+ALTER TABLE `users` MODIFY `lastOnline` bigint(20) NOT NULL;
+ALTER TABLE `users` MODIFY `country_id` bigint(20) NOT NULL;
+ALTER TABLE `users` MODIFY `phone` VARCHAR(50) NOT NULL;
+ALTER TABLE `users` MODIFY `password` VARCHAR(50) NOT NULL;
@@ -0,0 +1,15 @@
+ALTER TABLE `users` MODIFY `password` VARCHAR(255) DEFAULT NULL;
+
+ALTER TABLE `users` MODIFY `phone` VARCHAR(50) DEFAULT NULL;
+
+ALTER TABLE `users` MODIFY `country_id` bigint(20) DEFAULT NULL;
+
+ALTER TABLE `users` MODIFY `lastOnline` bigint(20) DEFAULT NULL;
+
+ALTER TABLE `users` MODIFY `inviteBy` bigint(20) DEFAULT NULL;
+
+ALTER TABLE `users` MODIFY `status` int(1) NOT NULL DEFAULT 1;
+
+CREATE UNIQUE INDEX `index_on_email` ON `users` (`email`);
+
+CREATE UNIQUE INDEX `index_on_username` ON `users` (`username`);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS roles_permissions;
+ALTER TABLE `roles` DROP COLUMN `updated_at`;
@@ -0,0 +1,33 @@
+ALTER TABLE `roles` ADD COLUMN `updated_at` bigint(20) NOT NULL DEFAULT 0;
+
+CREATE TABLE `roles_permissions` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `role_id` bigint(20) NOT NULL,
+  `permission` VARCHAR(50) NOT NULL,
+  UNIQUE (`role_id`, `permission`)
+);
+
+ALTER TABLE `roles_permissions` ADD FOREIGN KEY (`role_id`) REFERENCES `roles` (`id`) ON DELETE CASCADE;
+
+INSERT IGNORE INTO `roles` (`code`, `name`, `description`, `created_at`, `createdBy`, `updated_at`) VALUES
+  ('admin', 'Administrator', 'Full access to the organization', UNIX_TIMESTAMP(), 0, UNIX_TIMESTAMP()),
+  ('instructor', 'Instructor', 'Creates and publishes courses', UNIX_TIMESTAMP(), 0, UNIX_TIMESTAMP()),
+  ('learner', 'Learner', 'Takes courses', UNIX_TIMESTAMP(), 0, UNIX_TIMESTAMP());
+
+INSERT INTO `roles_permissions` (`role_id`, `permission`)
+  SELECT r.id, p.permission FROM `roles` r JOIN (
+    SELECT 'course:view' AS permission UNION ALL SELECT 'course:create' UNION ALL SELECT 'course:update'
+    UNION ALL SELECT 'course:delete' UNION ALL SELECT 'course:publish' UNION ALL SELECT 'content:upload'
+    UNION ALL SELECT 'category:manage' UNION ALL SELECT 'enrollment:manage' UNION ALL SELECT 'user:manage'
+    UNION ALL SELECT 'role:manage' UNION ALL SELECT 'report:view'
+  ) p WHERE r.code = 'admin';
+
+INSERT INTO `roles_permissions` (`role_id`, `permission`)
+  SELECT r.id, p.permission FROM `roles` r JOIN (
+    SELECT 'course:view' AS permission UNION ALL SELECT 'course:create' UNION ALL SELECT 'course:update'
+    UNION ALL SELECT 'course:delete' UNION ALL SELECT 'course:publish' UNION ALL SELECT 'content:upload'
+    UNION ALL SELECT 'category:manage' UNION ALL SELECT 'enrollment:manage' UNION ALL SELECT 'report:view'
+  ) p WHERE r.code = 'instructor';
+
+INSERT INTO `roles_permissions` (`role_id`, `permission`)
+  SELECT r.id, 'course:view' FROM `roles` r WHERE r.code = 'learner';
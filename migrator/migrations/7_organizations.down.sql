@@ -0,0 +1,24 @@
+DELETE FROM `roles` WHERE `code` = 'superadmin' AND `organization_id` IS NULL;
+DELETE FROM `roles` WHERE `organization_id` IS NOT NULL;
+ALTER TABLE `roles` DROP FOREIGN KEY `fk_roles_organization`;
+ALTER TABLE `roles` DROP INDEX `index_on_organization_id_code`;
+ALTER TABLE `roles` DROP COLUMN `organization_id`;
+ALTER TABLE `roles` ADD UNIQUE `code` (`code`);
+
+ALTER TABLE `refresh_tokens` DROP COLUMN `organization_id`;
+
+ALTER TABLE `attachments` DROP FOREIGN KEY `fk_attachments_organization`;
+ALTER TABLE `attachments` DROP INDEX `index_on_organization_id`;
+ALTER TABLE `attachments` DROP COLUMN `organization_id`;
+
+ALTER TABLE `tags` DROP FOREIGN KEY `fk_tags_organization`;
+ALTER TABLE `tags` DROP INDEX `index_on_organization_id`;
+ALTER TABLE `tags` DROP COLUMN `organization_id`;
+
+ALTER TABLE `categories` DROP FOREIGN KEY `fk_categories_organization`;
+ALTER TABLE `categories` DROP INDEX `index_on_organization_id`;
+ALTER TABLE `categories` DROP COLUMN `organization_id`;
+
+ALTER TABLE `courses` DROP FOREIGN KEY `fk_courses_organization`;
+ALTER TABLE `courses` DROP INDEX `index_on_organization_id`;
+ALTER TABLE `courses` MODIFY `organization_id` bigint(20) NULL;
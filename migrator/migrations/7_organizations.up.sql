@@ -0,0 +1,52 @@
+ALTER TABLE `organizations` MODIFY `country_id` bigint(20) NULL;
+ALTER TABLE `organizations` MODIFY `name` VARCHAR(200) NOT NULL;
+ALTER TABLE `organizations` MODIFY `status` int(1) NOT NULL DEFAULT 1;
+
+INSERT INTO `organizations` (`name`, `description`, `status`, `updated_at`, `created_at`)
+  SELECT 'Default', 'Organization owning the data created before multi tenancy', 1, UNIX_TIMESTAMP(), UNIX_TIMESTAMP()
+  FROM DUAL WHERE NOT EXISTS (SELECT 1 FROM `organizations`);
+
+UPDATE `courses` SET `organization_id` = (SELECT MIN(`id`) FROM `organizations`) WHERE `organization_id` IS NULL;
+ALTER TABLE `courses` MODIFY `organization_id` bigint(20) NOT NULL;
+ALTER TABLE `courses` ADD CONSTRAINT `fk_courses_organization` FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+CREATE INDEX `index_on_organization_id` ON `courses` (`organization_id`);
+
+ALTER TABLE `categories` ADD COLUMN `organization_id` bigint(20) NULL;
+UPDATE `categories` SET `organization_id` = (SELECT MIN(`id`) FROM `organizations`);
+ALTER TABLE `categories` MODIFY `organization_id` bigint(20) NOT NULL;
+ALTER TABLE `categories` ADD CONSTRAINT `fk_categories_organization` FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+CREATE INDEX `index_on_organization_id` ON `categories` (`organization_id`);
+
+ALTER TABLE `tags` ADD COLUMN `organization_id` bigint(20) NULL;
+UPDATE `tags` SET `organization_id` = (SELECT MIN(`id`) FROM `organizations`);
+ALTER TABLE `tags` MODIFY `organization_id` bigint(20) NOT NULL;
+ALTER TABLE `tags` ADD CONSTRAINT `fk_tags_organization` FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+CREATE INDEX `index_on_organization_id` ON `tags` (`organization_id`);
+
+ALTER TABLE `attachments` ADD COLUMN `organization_id` bigint(20) NULL;
+UPDATE `attachments` SET `organization_id` = (SELECT MIN(`id`) FROM `organizations`);
+ALTER TABLE `attachments` MODIFY `organization_id` bigint(20) NOT NULL;
+ALTER TABLE `attachments` ADD CONSTRAINT `fk_attachments_organization` FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+CREATE INDEX `index_on_organization_id` ON `attachments` (`organization_id`);
+
+ALTER TABLE `refresh_tokens` ADD COLUMN `organization_id` bigint(20) NULL;
+UPDATE `refresh_tokens` t JOIN `users` u ON u.id = t.user_id SET t.organization_id = u.organization_id;
+ALTER TABLE `refresh_tokens` MODIFY `organization_id` bigint(20) NOT NULL;
+
+/* roles without organization are the default roles shared by every organization */
+ALTER TABLE `roles` ADD COLUMN `organization_id` bigint(20) NULL;
+ALTER TABLE `roles` DROP INDEX `code`;
+ALTER TABLE `roles` ADD UNIQUE `index_on_organization_id_code` (`organization_id`, `code`);
+ALTER TABLE `roles` ADD CONSTRAINT `fk_roles_organization` FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+
+INSERT INTO `roles` (`code`, `name`, `description`, `created_at`, `createdBy`, `updated_at`)
+  SELECT 'superadmin', 'Super Administrator', 'Manages the organizations', UNIX_TIMESTAMP(), 0, UNIX_TIMESTAMP()
+  FROM DUAL WHERE NOT EXISTS (SELECT 1 FROM `roles` WHERE `code` = 'superadmin' AND `organization_id` IS NULL);
+
+INSERT INTO `roles_permissions` (`role_id`, `permission`)
+  SELECT r.id, p.permission FROM `roles` r JOIN (
+    SELECT 'course:view' AS permission UNION ALL SELECT 'course:create' UNION ALL SELECT 'course:update'
+    UNION ALL SELECT 'course:delete' UNION ALL SELECT 'course:publish' UNION ALL SELECT 'content:upload'
+    UNION ALL SELECT 'category:manage' UNION ALL SELECT 'enrollment:manage' UNION ALL SELECT 'user:manage'
+    UNION ALL SELECT 'role:manage' UNION ALL SELECT 'report:view' UNION ALL SELECT 'organization:manage'
+  ) p WHERE r.code = 'superadmin' AND r.organization_id IS NULL;
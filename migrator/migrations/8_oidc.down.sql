@@ -0,0 +1,4 @@
+DELETE FROM `roles_permissions` WHERE `permission` = 'sso:manage';
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS oidc_login_states;
+DROP TABLE IF EXISTS oidc_providers;
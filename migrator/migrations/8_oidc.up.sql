@@ -0,0 +1,45 @@
+CREATE TABLE `oidc_providers` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `organization_id` bigint(20) UNIQUE NOT NULL,
+  `issuer` VARCHAR(255) NOT NULL,
+  `client_id` VARCHAR(255) NOT NULL,
+  `client_secret` VARCHAR(255) DEFAULT NULL,
+  `redirect_url` VARCHAR(255) NOT NULL,
+  `scopes` VARCHAR(255) DEFAULT NULL,
+  `role_claim` VARCHAR(100) DEFAULT NULL,
+  `role_mapping` TEXT DEFAULT NULL,
+  `default_role` VARCHAR(50) NOT NULL,
+  `enabled` tinyint(1) NOT NULL DEFAULT 1,
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `oidc_providers` ADD FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+
+CREATE TABLE `oidc_login_states` (
+  `state_hash` CHAR(64) PRIMARY KEY NOT NULL,
+  `provider_id` bigint(20) NOT NULL,
+  `organization_id` bigint(20) NOT NULL,
+  `nonce` VARCHAR(64) NOT NULL,
+  `code_verifier` VARCHAR(128) NOT NULL,
+  `expires_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `oidc_login_states` ADD FOREIGN KEY (`provider_id`) REFERENCES `oidc_providers` (`id`) ON DELETE CASCADE;
+
+CREATE TABLE `user_identities` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) NOT NULL,
+  `provider_id` bigint(20) NOT NULL,
+  `subject` VARCHAR(255) NOT NULL,
+  `created_at` bigint(20) NOT NULL,
+  UNIQUE (`provider_id`, `subject`)
+);
+
+ALTER TABLE `user_identities` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `user_identities` ADD FOREIGN KEY (`provider_id`) REFERENCES `oidc_providers` (`id`) ON DELETE CASCADE;
+
+INSERT INTO `roles_permissions` (`role_id`, `permission`)
+  SELECT r.id, 'sso:manage' FROM `roles` r WHERE r.code IN ('admin', 'superadmin') AND r.organization_id IS NULL;
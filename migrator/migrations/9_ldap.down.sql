@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS ldap_identities;
+ALTER TABLE `teams` DROP INDEX `unique_ldap_group`;
+ALTER TABLE `teams` DROP COLUMN `ldap_group_dn`;
+DROP TABLE IF EXISTS ldap_directories;
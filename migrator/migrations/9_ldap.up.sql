@@ -0,0 +1,38 @@
+CREATE TABLE `ldap_directories` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `organization_id` bigint(20) UNIQUE NOT NULL,
+  `url` VARCHAR(255) NOT NULL,
+  `bind_dn` VARCHAR(255) NOT NULL,
+  `bind_password` VARCHAR(255) DEFAULT NULL,
+  `base_dn` VARCHAR(255) NOT NULL,
+  `user_filter` VARCHAR(255) NOT NULL,
+  `username_attribute` VARCHAR(100) NOT NULL,
+  `email_attribute` VARCHAR(100) NOT NULL,
+  `first_name_attribute` VARCHAR(100) NOT NULL,
+  `last_name_attribute` VARCHAR(100) NOT NULL,
+  `group_attribute` VARCHAR(100) NOT NULL,
+  `group_base_dn` VARCHAR(255) DEFAULT NULL,
+  `default_role` VARCHAR(50) NOT NULL,
+  `enabled` tinyint(1) NOT NULL DEFAULT 1,
+  `last_sync_at` bigint(20) DEFAULT NULL,
+  `updated_at` bigint(20) NOT NULL,
+  `created_at` bigint(20) NOT NULL
+);
+
+ALTER TABLE `ldap_directories` ADD FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `teams` ADD COLUMN `ldap_group_dn` VARCHAR(255) NULL;
+ALTER TABLE `teams` ADD CONSTRAINT `unique_ldap_group` UNIQUE (`organization_id`, `ldap_group_dn`);
+
+CREATE TABLE `ldap_identities` (
+  `id` bigint(20) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) UNIQUE NOT NULL,
+  `organization_id` bigint(20) NOT NULL,
+  `dn` VARCHAR(255) NOT NULL,
+  `created_at` bigint(20) NOT NULL,
+  UNIQUE (`organization_id`, `dn`)
+);
+
+ALTER TABLE `ldap_identities` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `ldap_identities` ADD FOREIGN KEY (`organization_id`) REFERENCES `organizations` (`id`) ON DELETE CASCADE;
@@ -0,0 +1,200 @@
+// Package ber encodes and decodes the subset of the ASN.1 Basic Encoding Rules used by LDAP:
+// single octet tags and definite lengths.
+package ber
+
+import (
+	"errors"
+	"io"
+)
+
+// Classes and the constructed bit of an identifier octet
+const (
+	ClassApplication byte = 0x40
+	ClassContext     byte = 0x80
+	Constructed      byte = 0x20
+)
+
+// Universal tags
+const (
+	TagBoolean     byte = 0x01
+	TagInteger     byte = 0x02
+	TagOctetString byte = 0x04
+	TagEnumerated  byte = 0x0a
+	TagSequence    byte = 0x30
+	TagSet         byte = 0x31
+)
+
+// maxLength bounds the size of an element read from the network
+const maxLength = 16 << 20
+
+// ErrInvalid is returned for malformed or unsupported encodings
+var ErrInvalid = errors.New("ber: invalid encoding")
+
+// Element is a decoded element. The value of a constructed element holds its encoded children.
+type Element struct {
+	Tag   byte
+	Value []byte
+}
+
+// Encode returns the element with the given tag and value
+func Encode(tag byte, value []byte) []byte {
+	n := len(value)
+	header := []byte{tag}
+	switch {
+	case n < 0x80:
+		header = append(header, byte(n))
+	default:
+		var length []byte
+		for ; n > 0; n >>= 8 {
+			length = append([]byte{byte(n)}, length...)
+		}
+		header = append(header, 0x80|byte(len(length)))
+		header = append(header, length...)
+	}
+	return append(header, value...)
+}
+
+// Wrap returns the constructed element with the given tag holding the encoded elements
+func Wrap(tag byte, elements ...[]byte) []byte {
+	var value []byte
+	for _, e := range elements {
+		value = append(value, e...)
+	}
+	return Encode(tag|Constructed, value)
+}
+
+// Sequence returns a SEQUENCE of the encoded elements
+func Sequence(elements ...[]byte) []byte {
+	return Wrap(TagSequence, elements...)
+}
+
+// OctetString returns an OCTET STRING
+func OctetString(s string) []byte {
+	return Encode(TagOctetString, []byte(s))
+}
+
+// Integer returns an INTEGER
+func Integer(i int64) []byte {
+	return Encode(TagInteger, intBytes(i))
+}
+
+// Enumerated returns an ENUMERATED
+func Enumerated(i int64) []byte {
+	return Encode(TagEnumerated, intBytes(i))
+}
+
+// Boolean returns a BOOLEAN
+func Boolean(b bool) []byte {
+	if b {
+		return Encode(TagBoolean, []byte{0xff})
+	}
+	return Encode(TagBoolean, []byte{0x00})
+}
+
+// intBytes returns the shortest two's complement encoding of i
+func intBytes(i int64) []byte {
+	n := 1
+	for v := i; v > 127 || v < -128; v >>= 8 {
+		n++
+	}
+	b := make([]byte, n)
+	for j := n - 1; j >= 0; j-- {
+		b[j] = byte(i)
+		i >>= 8
+	}
+	return b
+}
+
+// Read reads one element from r
+func Read(r io.Reader) (Element, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return Element{}, err
+	}
+	if header[0]&0x1f == 0x1f {
+		return Element{}, ErrInvalid
+	}
+	n := int(header[1])
+	if n&0x80 != 0 {
+		size := n & 0x7f
+		if size == 0 || size > 4 {
+			return Element{}, ErrInvalid
+		}
+		length := make([]byte, size)
+		if _, err := io.ReadFull(r, length); err != nil {
+			return Element{}, err
+		}
+		n = 0
+		for _, b := range length {
+			n = n<<8 | int(b)
+		}
+	}
+	if n > maxLength {
+		return Element{}, ErrInvalid
+	}
+	value := make([]byte, n)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return Element{}, err
+	}
+	return Element{Tag: header[0], Value: value}, nil
+}
+
+// Parse decodes the first element of b and returns the remaining bytes
+func Parse(b []byte) (Element, []byte, error) {
+	r := &sliceReader{b: b}
+	e, err := Read(r)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = ErrInvalid
+	}
+	return e, r.b, err
+}
+
+type sliceReader struct {
+	b []byte
+}
+
+func (r *sliceReader) Read(p []byte) (int, error) {
+	if len(r.b) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.b)
+	r.b = r.b[n:]
+	return n, nil
+}
+
+// Children decodes the elements held by a constructed element
+func (e Element) Children() ([]Element, error) {
+	var children []Element
+	rest := e.Value
+	for len(rest) > 0 {
+		var child Element
+		var err error
+		if child, rest, err = Parse(rest); err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	return children, nil
+}
+
+// Int decodes an INTEGER or ENUMERATED value
+func (e Element) Int() (int64, error) {
+	if len(e.Value) == 0 || len(e.Value) > 8 {
+		return 0, ErrInvalid
+	}
+	v := int64(int8(e.Value[0]))
+	for _, b := range e.Value[1:] {
+		v = v<<8 | int64(b)
+	}
+	return v, nil
+}
+
+// Bool decodes a BOOLEAN value
+func (e Element) Bool() bool {
+	return len(e.Value) == 1 && e.Value[0] != 0
+}
+
+// String returns the value of an OCTET STRING
+func (e Element) String() string {
+	return string(e.Value)
+}
@@ -0,0 +1,48 @@
+package ber
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInteger(t *testing.T) {
+	for _, i := range []int64{0, 1, 127, 128, -1, -128, -129, 256, 1 << 40} {
+		e, rest, err := Parse(Integer(i))
+		assert.NoError(t, err)
+		assert.Empty(t, rest)
+		v, err := e.Int()
+		assert.NoError(t, err)
+		assert.Equal(t, i, v)
+	}
+	assert.Equal(t, []byte{TagInteger, 2, 0x00, 0x80}, Integer(128))
+}
+
+func TestSequence(t *testing.T) {
+	long := strings.Repeat("x", 300)
+	encoded := Sequence(OctetString("cn=admin"), OctetString(long), Boolean(true))
+	assert.Equal(t, []byte{TagSequence, 0x82, 0x01, 0x3d}, encoded[:4])
+
+	e, err := Read(bytes.NewReader(encoded))
+	assert.NoError(t, err)
+	children, err := e.Children()
+	assert.NoError(t, err)
+	assert.Len(t, children, 3)
+	assert.Equal(t, "cn=admin", children[0].String())
+	assert.Equal(t, long, children[1].String())
+	assert.True(t, children[2].Bool())
+}
+
+func TestInvalid(t *testing.T) {
+	for _, b := range [][]byte{
+		{TagOctetString, 5, 'a'},
+		{TagOctetString, 0x85, 1, 1, 1, 1, 1},
+		{0x1f, 0x01, 0x00},
+		{TagSequence, 0x84, 0x7f, 0xff, 0xff, 0xff},
+	} {
+		_, _, err := Parse(b)
+		assert.Error(t, err)
+	}
+}
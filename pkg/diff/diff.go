@@ -0,0 +1,141 @@
+package diff
+
+import (
+	"strings"
+)
+
+// Lines returns a line based diff between two texts. Removed lines are prefixed
+// with "-", added lines with "+" and unchanged lines with a single space.
+// Within a change the removed lines come before the added ones.
+//
+// It uses the linear space variant of Myers' algorithm, so long texts are
+// compared without holding a table of every pair of lines.
+func Lines(before, after string) string {
+	if before == after {
+		return ""
+	}
+	a := split(before)
+	b := split(after)
+
+	var sb strings.Builder
+	var removed, added []string
+	flush := func() {
+		for _, line := range removed {
+			sb.WriteString("-" + line + "\n")
+		}
+		for _, line := range added {
+			sb.WriteString("+" + line + "\n")
+		}
+		removed, added = removed[:0], added[:0]
+	}
+	compare(a, b, func(kind byte, line string) {
+		switch kind {
+		case '-':
+			removed = append(removed, line)
+		case '+':
+			added = append(added, line)
+		default:
+			flush()
+			sb.WriteString(" " + line + "\n")
+		}
+	})
+	flush()
+	return sb.String()
+}
+
+// compare emits the lines of a shortest edit script from a to b, in order
+func compare(a, b []string, emit func(kind byte, line string)) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		emit(' ', a[prefix])
+		prefix++
+	}
+	a, b = a[prefix:], b[prefix:]
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-suffix-1] == b[len(b)-suffix-1] {
+		suffix++
+	}
+	common := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			emit('+', line)
+		}
+	case len(b) == 0:
+		for _, line := range a {
+			emit('-', line)
+		}
+	default:
+		// both ends differ, so at least two edits are needed and each half of the
+		// split holds fewer of them
+		x, y, u, v := middleSnake(a, b)
+		compare(a[:x], b[:y], emit)
+		for _, line := range a[x:u] {
+			emit(' ', line)
+		}
+		compare(a[u:], b[v:], emit)
+	}
+	for _, line := range common {
+		emit(' ', line)
+	}
+}
+
+// middleSnake returns the run of equal lines from (x, y) to (u, v) in the middle
+// of a shortest edit script from a to b, searching from both ends at once
+func middleSnake(a, b []string) (x, y, u, v int) {
+	n, m := len(a), len(b)
+	max := (n + m + 1) / 2
+	offset := max + 1
+	// forward[k] and backward[k] hold the furthest x reached on diagonal k = x - y,
+	// the backward search running over the reversed texts
+	forward := make([]int, 2*max+3)
+	backward := make([]int, 2*max+3)
+	delta := n - m
+	odd := delta%2 != 0
+	for d := 0; d <= max; d++ {
+		for k := -d; k <= d; k += 2 {
+			x := furthest(forward, offset, k, d)
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			if odd && k >= delta-(d-1) && k <= delta+(d-1) && x+backward[offset+delta-k] >= n {
+				return startX, startY, x, y
+			}
+		}
+		for k := -d; k <= d; k += 2 {
+			x := furthest(backward, offset, k, d)
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+			if !odd && k >= delta-d && k <= delta+d && x+forward[offset+delta-k] >= n {
+				return n - x, m - y, n - startX, m - startY
+			}
+		}
+	}
+	return 0, 0, 0, 0
+}
+
+// furthest returns the x a d-path on diagonal k starts its run of equal lines from
+func furthest(reached []int, offset, k, d int) int {
+	if k == -d || k != d && reached[offset+k-1] < reached[offset+k+1] {
+		return reached[offset+k+1]
+	}
+	return reached[offset+k-1] + 1
+}
+
+func split(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
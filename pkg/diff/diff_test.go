@@ -0,0 +1,93 @@
+package diff_test
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/meroedu/meroedu/pkg/diff"
+)
+
+func TestLines(t *testing.T) {
+	assert.Equal(t, "", diff.Lines("same\ntext", "same\ntext"))
+	assert.Equal(t, " first\n-second\n+changed\n third\n", diff.Lines("first\nsecond\nthird", "first\nchanged\nthird"))
+	assert.Equal(t, "-gone\n", diff.Lines("gone", ""))
+	assert.Equal(t, "+new\n", diff.Lines("", "new"))
+	assert.Equal(t, "-a\n-b\n+x\n c\n+d\n", diff.Lines("a\nb\nc", "x\nc\nd"))
+}
+
+// lcs is the length of the longest common subsequence of two short texts
+func lcs(a, b []string) int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				table[i][j] = table[i+1][j+1] + 1
+			case table[i+1][j] >= table[i][j+1]:
+				table[i][j] = table[i+1][j]
+			default:
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+	return table[0][0]
+}
+
+func randomLines(r *rand.Rand) []string {
+	lines := make([]string, r.Intn(12))
+	for i := range lines {
+		lines[i] = strconv.Itoa(r.Intn(4))
+	}
+	return lines
+}
+
+func TestLinesIsShortest(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		a, b := randomLines(r), randomLines(r)
+		before, after := strings.Join(a, "\n"), strings.Join(b, "\n")
+		result := diff.Lines(before, after)
+		if before == after {
+			assert.Empty(t, result)
+			continue
+		}
+		var gotBefore, gotAfter []string
+		unchanged := 0
+		for _, line := range strings.Split(strings.TrimSuffix(result, "\n"), "\n") {
+			switch line[0] {
+			case ' ':
+				unchanged++
+				gotBefore = append(gotBefore, line[1:])
+				gotAfter = append(gotAfter, line[1:])
+			case '-':
+				gotBefore = append(gotBefore, line[1:])
+			case '+':
+				gotAfter = append(gotAfter, line[1:])
+			}
+		}
+		assert.Equal(t, before, strings.Join(gotBefore, "\n"))
+		assert.Equal(t, after, strings.Join(gotAfter, "\n"))
+		assert.Equal(t, lcs(a, b), unchanged, "%q to %q", before, after)
+	}
+}
+
+func TestLinesLongText(t *testing.T) {
+	lines := make([]string, 20000)
+	for i := range lines {
+		lines[i] = "line " + strconv.Itoa(i)
+	}
+	before := strings.Join(lines, "\n")
+	lines[10000] = "changed"
+	lines = append(lines[:500], lines[501:]...)
+	result := diff.Lines(before, strings.Join(lines, "\n"))
+	assert.Equal(t, 20001, strings.Count(result, "\n"))
+	assert.Contains(t, result, "-line 500\n")
+	assert.Contains(t, result, "-line 10000\n+changed\n")
+}
@@ -0,0 +1,17 @@
+package password
+
+import "golang.org/x/crypto/bcrypt"
+
+// Hash returns the bcrypt hash of plain
+func Hash(plain string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(plain), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Compare reports whether plain matches the stored hash
+func Compare(hash string, plain string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain)) == nil
+}
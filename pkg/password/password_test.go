@@ -0,0 +1,18 @@
+package password_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/meroedu/meroedu/pkg/password"
+)
+
+func TestHashAndCompare(t *testing.T) {
+	hash, err := password.Hash("s3cret-pass")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "s3cret-pass", hash)
+	assert.True(t, password.Compare(hash, "s3cret-pass"))
+	assert.False(t, password.Compare(hash, "wrong-pass"))
+	assert.False(t, password.Compare("", "s3cret-pass"))
+}
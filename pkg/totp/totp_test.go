@@ -0,0 +1,55 @@
+package totp_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/meroedu/meroedu/pkg/totp"
+)
+
+// secret of the RFC 6238 test vectors, "12345678901234567890" in base32
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	for unix, expected := range map[int64]string{59: "287082", 1111111109: "081804", 1234567890: "005924", 2000000000: "279037"} {
+		code, err := totp.Code(secret, totp.Step(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "at %d", unix)
+	}
+	_, err := totp.Code("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step, ok := totp.Validate(secret, "081804", now)
+	assert.True(t, ok)
+	assert.Equal(t, totp.Step(now), step)
+
+	step, ok = totp.Validate(secret, "081804", now.Add(totp.Period*time.Second))
+	assert.True(t, ok, "a code of the previous period is accepted")
+	assert.Equal(t, totp.Step(now), step)
+
+	_, ok = totp.Validate(secret, "081804", now.Add(2*totp.Period*time.Second))
+	assert.False(t, ok)
+	_, ok = totp.Validate(secret, "000000", now)
+	assert.False(t, ok)
+	_, ok = totp.Validate(secret, "81804", now)
+	assert.False(t, ok)
+}
+
+func TestNewSecretAndURI(t *testing.T) {
+	s, err := totp.NewSecret()
+	assert.NoError(t, err)
+	assert.Len(t, s, 32)
+	_, err = totp.Code(s, 1)
+	assert.NoError(t, err)
+
+	uri := totp.URI("Meroedu", "sita@school.local", s)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Meroedu:sita@school.local?"), uri)
+	assert.Contains(t, uri, "secret="+s)
+	assert.Contains(t, uri, "issuer=Meroedu")
+}
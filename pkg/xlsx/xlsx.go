@@ -0,0 +1,238 @@
+// Package xlsx reads the cell values of the first worksheet of an Office Open XML workbook (.xlsx).
+// Formatting, formulas and dates are not interpreted: a cell gives the text or the number it stores.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxPartSize bounds the size of an uncompressed part, against zip bombs
+const maxPartSize = 64 << 20
+
+// MaxRows is the number of rows of a worksheet
+const MaxRows = 1 << 20
+
+var (
+	// ErrInvalid is returned for files which are not workbooks or have no worksheet
+	ErrInvalid = errors.New("xlsx: invalid workbook")
+	// ErrTooManyRows is returned for worksheets with rows past the limit given to Read
+	ErrTooManyRows = errors.New("xlsx: too many rows")
+)
+
+type relationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type workbook struct {
+	Sheets []struct {
+		RelationshipID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type richText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t richText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+type sharedStrings struct {
+	Items []richText `xml:"si"`
+}
+
+type row struct {
+	Index int `xml:"r,attr"`
+	Cells []struct {
+		Ref    string   `xml:"r,attr"`
+		Type   string   `xml:"t,attr"`
+		Value  string   `xml:"v"`
+		Inline richText `xml:"is"`
+	} `xml:"c"`
+}
+
+// Read returns the rows of the first worksheet, each row as its cell values from column A up to the last
+// non-empty cell. Missing rows and cells are returned empty, so row i of the result is row i+1 of the sheet.
+// A row past maxRows, or past MaxRows when it is larger, gives ErrTooManyRows.
+func Read(r io.ReaderAt, size int64, maxRows int) ([][]string, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	parts := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		parts[f.Name] = f
+	}
+
+	var book workbook
+	if err = decode(parts, "xl/workbook.xml", &book); err != nil {
+		return nil, err
+	}
+	var rels relationships
+	if err = decode(parts, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	if len(book.Sheets) == 0 {
+		return nil, ErrInvalid
+	}
+	sheetPath := ""
+	for _, rel := range rels.Relationships {
+		if rel.ID == book.Sheets[0].RelationshipID {
+			sheetPath = rel.Target
+		}
+	}
+	if sheetPath == "" {
+		return nil, ErrInvalid
+	}
+	if strings.HasPrefix(sheetPath, "/") {
+		sheetPath = strings.TrimPrefix(sheetPath, "/")
+	} else {
+		sheetPath = path.Join("xl", sheetPath)
+	}
+
+	var strs sharedStrings
+	if _, ok := parts["xl/sharedStrings.xml"]; ok {
+		if err = decode(parts, "xl/sharedStrings.xml", &strs); err != nil {
+			return nil, err
+		}
+	}
+	sheet, ok := parts[sheetPath]
+	if !ok {
+		return nil, ErrInvalid
+	}
+	rc, err := sheet.Open()
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	defer rc.Close()
+	if maxRows > MaxRows {
+		maxRows = MaxRows
+	}
+
+	// rows are decoded one at a time, so a sheet past the limit is rejected before it is held in memory
+	result := make([][]string, 0)
+	dec := xml.NewDecoder(io.LimitReader(rc, maxPartSize))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalid
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		var row row
+		if err = dec.DecodeElement(&row, &start); err != nil {
+			return nil, ErrInvalid
+		}
+		index := row.Index
+		if index == 0 {
+			index = len(result) + 1
+		}
+		if index < len(result)+1 {
+			return nil, ErrInvalid
+		}
+		if index > maxRows {
+			return nil, ErrTooManyRows
+		}
+		for len(result) < index-1 {
+			result = append(result, []string{})
+		}
+		values, err := rowValues(&row, &strs)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, values)
+	}
+	return result, nil
+}
+
+// rowValues returns the cell values of the row from column A up to the last non-empty cell
+func rowValues(row *row, strs *sharedStrings) ([]string, error) {
+	values := make([]string, 0, len(row.Cells))
+	for j, c := range row.Cells {
+		col := j
+		if c.Ref != "" {
+			var err error
+			if col, err = column(c.Ref); err != nil {
+				return nil, err
+			}
+		}
+		if col < len(values) {
+			return nil, ErrInvalid
+		}
+		for len(values) < col {
+			values = append(values, "")
+		}
+		value := c.Value
+		switch c.Type {
+		case "s":
+			n, err := strconv.Atoi(c.Value)
+			if err != nil || n < 0 || n >= len(strs.Items) {
+				return nil, ErrInvalid
+			}
+			value = strs.Items[n].String()
+		case "inlineStr":
+			value = c.Inline.String()
+		}
+		values = append(values, value)
+	}
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	return values, nil
+}
+
+func decode(parts map[string]*zip.File, name string, v interface{}) error {
+	f, ok := parts[name]
+	if !ok {
+		return ErrInvalid
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return ErrInvalid
+	}
+	defer rc.Close()
+	if err = xml.NewDecoder(io.LimitReader(rc, maxPartSize)).Decode(v); err != nil {
+		return ErrInvalid
+	}
+	return nil
+}
+
+// column returns the zero based column of a cell reference such as "AB12"
+func column(ref string) (int, error) {
+	col := 0
+	i := 0
+	for ; i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z'; i++ {
+		col = col*26 + int(ref[i]-'A'+1)
+		if col > 16384 {
+			return 0, ErrInvalid
+		}
+	}
+	if i == 0 {
+		return 0, ErrInvalid
+	}
+	return col - 1, nil
+}
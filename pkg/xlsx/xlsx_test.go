@@ -0,0 +1,97 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/meroedu/meroedu/pkg/xlsx"
+)
+
+func workbook(t *testing.T, parts map[string]string) *bytes.Reader {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	for name, content := range parts {
+		f, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestRead(t *testing.T) {
+	r := workbook(t, map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+	<sheets><sheet name="Users" sheetId="1" r:id="rId2"/><sheet name="Other" sheetId="2" r:id="rId1"/></sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+	<Relationship Id="rId1" Target="worksheets/sheet1.xml"/>
+	<Relationship Id="rId2" Target="worksheets/sheet2.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+	<si><t>email</t></si><si><t>last_name</t></si><si><r><t>Sha</t></r><r><t>rma</t></r></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet/>`,
+		"xl/worksheets/sheet2.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+	<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="inlineStr"><is><t>phone</t></is></c></row>
+	<row r="3"><c r="A3" t="str"><v>sita@school.local</v></c><c r="B3" t="s"><v>2</v></c><c r="D3"><v>9779812345678</v></c><c r="E3"/></row>
+</sheetData></worksheet>`,
+	})
+
+	rows, err := xlsx.Read(r, r.Size(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"email", "last_name", "", "phone"},
+		{},
+		{"sita@school.local", "Sharma", "", "9779812345678"},
+	}, rows)
+}
+
+func TestReadInvalid(t *testing.T) {
+	r := bytes.NewReader([]byte("email,last_name\n"))
+	_, err := xlsx.Read(r, r.Size(), 10)
+	assert.Equal(t, xlsx.ErrInvalid, err)
+
+	r = workbook(t, map[string]string{"xl/workbook.xml": `<workbook/>`})
+	_, err = xlsx.Read(r, r.Size(), 10)
+	assert.Equal(t, xlsx.ErrInvalid, err)
+}
+
+func TestReadTooManyRows(t *testing.T) {
+	sheet := func(rows string) *bytes.Reader {
+		return workbook(t, map[string]string{
+			"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+	<sheets><sheet name="Users" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`,
+			"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+			"xl/worksheets/sheet1.xml":   `<worksheet><sheetData>` + rows + `</sheetData></worksheet>`,
+		})
+	}
+
+	r := sheet(`<row r="1"><c><v>1</v></c></row><row><c><v>2</v></c></row><row><c><v>3</v></c></row>`)
+	rows, err := xlsx.Read(r, r.Size(), 3)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+
+	r = sheet(`<row r="1"><c><v>1</v></c></row><row><c><v>2</v></c></row><row><c><v>3</v></c></row><row><c><v>4</v></c></row>`)
+	_, err = xlsx.Read(r, r.Size(), 3)
+	assert.Equal(t, xlsx.ErrTooManyRows, err)
+
+	// a far row index is rejected before the rows up to it are filled in
+	r = sheet(`<row r="1"><c><v>1</v></c></row><row r="2000000000"><c><v>2</v></c></row>`)
+	_, err = xlsx.Read(r, r.Size(), 5000)
+	assert.Equal(t, xlsx.ErrTooManyRows, err)
+
+	r = sheet(`<row r="1048577"><c><v>1</v></c></row>`)
+	_, err = xlsx.Read(r, r.Size(), 1<<30)
+	assert.Equal(t, xlsx.ErrTooManyRows, err)
+}